@@ -72,3 +72,152 @@ func (assetType *AssetTypeApi) GetAssetTypes(c *fiber.Ctx) error {
 
 	return err
 }
+
+// CreateAssetType registers an asset type accepted by the market of the
+// country. Only the admins are able to change the asset types.
+func (assetType *AssetTypeApi) CreateAssetType(c *fiber.Ctx) error {
+	var assetTypeBody presenter.AssetTypeBody
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	if err := c.BodyParser(&assetTypeBody); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	httpStatusCode, assetTypeCreated, err := assetType.LogicApi.ApiCreateAssetType(
+		assetTypeBody.Type, assetTypeBody.Name, assetTypeBody.Country,
+		userId.String())
+	if httpStatusCode == 403 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiAuthorization.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	} else if httpStatusCode == 400 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	} else if httpStatusCode == 500 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"assetType": presenter.ConvertAssetTypeToApiReturn(assetTypeCreated.Id,
+			assetTypeCreated.Type, assetTypeCreated.Name,
+			assetTypeCreated.Country),
+		"message": "Asset type creation was successful",
+	})
+
+	return err
+}
+
+// UpdateAssetType renames an asset type. The type and country are kept, since
+// the assets of this type were validated against them.
+func (assetType *AssetTypeApi) UpdateAssetType(c *fiber.Ctx) error {
+	var assetTypeBody presenter.AssetTypeBody
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	if err := c.BodyParser(&assetTypeBody); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	httpStatusCode, updatedAssetType, err := assetType.LogicApi.ApiUpdateAssetType(
+		c.Params("id"), assetTypeBody.Name, userId.String())
+	if httpStatusCode == 403 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiAuthorization.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	} else if httpStatusCode == 400 || httpStatusCode == 404 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	} else if httpStatusCode == 500 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"assetType": presenter.ConvertAssetTypeToApiReturn(updatedAssetType.Id,
+			updatedAssetType.Type, updatedAssetType.Name,
+			updatedAssetType.Country),
+		"message": "Asset type was updated successfully",
+	})
+
+	return err
+}
+
+// DeleteAssetType deletes an asset type without assets.
+func (assetType *AssetTypeApi) DeleteAssetType(c *fiber.Ctx) error {
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	httpStatusCode, deletedAssetType, err := assetType.LogicApi.ApiDeleteAssetType(
+		c.Params("id"), userId.String())
+	if httpStatusCode == 403 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiAuthorization.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	} else if httpStatusCode == 400 || httpStatusCode == 404 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	} else if httpStatusCode == 500 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"assetType": presenter.ConvertAssetTypeToApiReturn(deletedAssetType.Id,
+			deletedAssetType.Type, deletedAssetType.Name,
+			deletedAssetType.Country),
+		"message": "Asset type was deleted successfully",
+	})
+
+	return err
+}
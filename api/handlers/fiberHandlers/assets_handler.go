@@ -7,6 +7,7 @@ import (
 	externalapi "stockfyApi/externalApi"
 	"stockfyApi/usecases"
 	"stockfyApi/usecases/logicApi"
+	"strconv"
 
 	"github.com/gofiber/fiber/v2"
 	_ "github.com/lib/pq"
@@ -51,11 +52,69 @@ func (asset *AssetApi) GetSymbolLookup(c *fiber.Ctx) error {
 
 }
 
+// SearchSymbols suggests the symbols matching the code or the company name
+// typed by the user, using only the local symbol index.
+func (asset *AssetApi) SearchSymbols(c *fiber.Ctx) error {
+	var err error
+
+	limit := 0
+	if c.Query("limit") != "" {
+		limit, err = strconv.Atoi(c.Query("limit"))
+		if err != nil {
+			return c.Status(400).JSON(&fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiRequest.Error(),
+				"error":   entity.ErrInvalidSymbolSearchLimit.Error(),
+				"code":    400,
+			})
+		}
+	}
+
+	suggestions, err := asset.ApplicationLogic.SymbolSearchApp.SearchSymbols(
+		c.Query("q"), c.Query("country"), limit)
+	if err == entity.ErrInvalidSymbolSearchQuery ||
+		err == entity.ErrInvalidSymbolSearchLimit ||
+		err == entity.ErrInvalidCountryCode {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    400,
+		})
+	}
+
+	if err != nil {
+		return c.Status(500).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"symbols": presenter.ConvertSymbolSuggestionToApiReturn(suggestions),
+		"message": "Symbol search returned successfully",
+	})
+
+	return err
+}
+
 func (asset *AssetApi) GetSymbolPrice(c *fiber.Ctx) error {
 	var err error
 
-	symbolPrice, err := asset.ApplicationLogic.AssetApp.AssetVerificationPrice(
-		c.Query("symbol"), c.Query("country"), asset.ExternalInterfaces)
+	var symbolPrice *entity.SymbolPrice
+
+	// Cryptocurrencies can be quoted in a currency different from the default
+	if c.Query("country") == entity.CryptoCountry && c.Query("currency") != "" {
+		symbolPrice, err = asset.ApplicationLogic.AssetApp.
+			AssetVerificationCryptoPrice(c.Query("symbol"), c.Query("currency"),
+				asset.ExternalInterfaces)
+	} else {
+		symbolPrice, err = asset.ApplicationLogic.AssetApp.AssetVerificationPrice(
+			c.Query("symbol"), c.Query("country"), asset.ExternalInterfaces)
+	}
 
 	if err == entity.ErrInvalidAssetSymbol {
 		return c.Status(404).JSON(&fiber.Map{
@@ -83,11 +142,46 @@ func (asset *AssetApi) GetSymbolPrice(c *fiber.Ctx) error {
 	return err
 }
 
+func (asset *AssetApi) GetSymbolPrices(c *fiber.Ctx) error {
+	var assetsBody []presenter.AssetPriceBody
+	var err error
+
+	if err := c.BodyParser(&assetsBody); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	symbolPrices, err := asset.ApplicationLogic.AssetApp.AssetVerificationPrices(
+		presenter.ConvertAssetPriceBodyToSymbolPriceQuery(assetsBody),
+		asset.ExternalInterfaces)
+	if err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    400,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success":      true,
+		"symbolPrices": presenter.ConvertArraySymbolPriceToApiReturn(symbolPrices),
+		"message":      "Symbol Prices returned successfully",
+	})
+
+	return err
+}
+
 func (asset *AssetApi) GetAsset(c *fiber.Ctx) error {
 
 	withOrders := false
 	withOrderResume := false
 	withPrice := false
+	withProfile := false
 
 	userInfo := c.Context().Value("user")
 	userId := reflect.ValueOf(userInfo).FieldByName("userID")
@@ -131,6 +225,19 @@ func (asset *AssetApi) GetAsset(c *fiber.Ctx) error {
 		})
 	}
 
+	if c.Query("withProfile") == "true" {
+		withProfile = true
+	} else if c.Query("withProfile") == "" || c.Query("withProfile") == "false" {
+		withProfile = false
+	} else {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiQueryWithProfile.Error(),
+			"code":    400,
+		})
+	}
+
 	statusCode, searchedAsset, err := asset.LogicApi.ApiGetAssetByUser(
 		c.Params("symbol"), userId.String(), withOrders, withOrderResume, withPrice)
 	// fmt.Println(statusCode, searchedAsset, err)
@@ -167,6 +274,25 @@ func (asset *AssetApi) GetAsset(c *fiber.Ctx) error {
 		searchedAsset.AssetType.Type, searchedAsset.AssetType.Country,
 		searchedAsset.AssetType.Name, searchedAsset.OrdersList, searchedAsset.OrderInfo,
 		searchedAsset.Price)
+	assetApiReturn.FixedIncome = presenter.ConvertFixedIncomeToApiReturn(
+		searchedAsset.FixedIncome)
+
+	// The profile is only embedded for the asset types with a company profile
+	if withProfile {
+		statusCode, profile, err := asset.LogicApi.ApiGetCompanyProfile(
+			searchedAsset.Symbol)
+		if statusCode == 500 {
+			return c.Status(statusCode).JSON(&fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiInternalError.Error(),
+				"error":   err.Error(),
+				"code":    statusCode,
+			})
+		}
+
+		assetApiReturn.Profile = presenter.ConvertCompanyProfileToApiReturn(
+			profile)
+	}
 
 	err = c.JSON(&fiber.Map{
 		"success": true,
@@ -177,6 +303,46 @@ func (asset *AssetApi) GetAsset(c *fiber.Ctx) error {
 	return err
 }
 
+func (asset *AssetApi) GetAssetProfile(c *fiber.Ctx) error {
+
+	statusCode, profile, err := asset.LogicApi.ApiGetCompanyProfile(
+		c.Params("symbol"))
+
+	if statusCode == 500 {
+		return c.Status(statusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    statusCode,
+		})
+	}
+
+	if statusCode == 400 {
+		return c.Status(statusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    statusCode,
+		})
+	}
+
+	if statusCode == 404 {
+		return c.Status(statusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiAssetSymbolUser.Error(),
+			"code":    statusCode,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"profile": presenter.ConvertCompanyProfileToApiReturn(profile),
+		"message": "Company profile returned successfully",
+	})
+
+	return err
+}
+
 func (asset *AssetApi) CreateAsset(c *fiber.Ctx) error {
 
 	var assetInsert presenter.AssetBody
@@ -257,6 +423,78 @@ func (asset *AssetApi) CreateAsset(c *fiber.Ctx) error {
 
 }
 
+// UpdateAsset fixes the fullname, preference or sector of an asset for every
+// user. Only the admins are able to update the assets.
+func (asset *AssetApi) UpdateAsset(c *fiber.Ctx) error {
+	var assetUpdate presenter.AssetUpdateBody
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	if err := c.BodyParser(&assetUpdate); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	httpStatusCode, updatedAsset, err := asset.LogicApi.ApiUpdateAsset(
+		c.Params("symbol"), assetUpdate.Fullname, assetUpdate.Preference,
+		assetUpdate.SectorId, userId.String())
+
+	if httpStatusCode == 403 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiAuthorization.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	}
+
+	if httpStatusCode == 400 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	}
+
+	if httpStatusCode == 404 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiAssetSymbolUser.Error(),
+			"code":    404,
+		})
+	}
+
+	if httpStatusCode == 500 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	}
+
+	updatedAssetApiReturn := presenter.ConvertAssetToApiReturn(
+		updatedAsset.Id, *updatedAsset.Preference, updatedAsset.Fullname,
+		updatedAsset.Symbol, updatedAsset.Sector.Name, updatedAsset.Sector.Id,
+		updatedAsset.AssetType.Id, updatedAsset.AssetType.Type,
+		updatedAsset.AssetType.Country, updatedAsset.AssetType.Name,
+		nil, nil, nil)
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"asset":   updatedAssetApiReturn,
+		"message": "Asset was updated successfully",
+	})
+
+	return err
+}
+
 func (asset *AssetApi) DeleteAsset(c *fiber.Ctx) error {
 
 	myUser := false
@@ -76,6 +76,17 @@ func TestApiAssetGet(t *testing.T) {
 				Error:   entity.ErrInvalidApiQueryWithPrice.Error(),
 			},
 		},
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			path:    "TEST3?withProfile=error",
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Asset:   nil,
+				Error:   entity.ErrInvalidApiQueryWithProfile.Error(),
+			},
+		},
 		{
 			idToken: "ValidIdTokenWithoutPrivilegedUser",
 			path:    "UNKNOWN_SYMBOL?withOrders=true&withOrderResume=true",
@@ -287,6 +298,40 @@ func TestApiAssetGet(t *testing.T) {
 				Error: "",
 			},
 		},
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			path:    "TEST3?withProfile=true",
+			expectedResp: body{
+				Code:    200,
+				Success: true,
+				Message: "Asset information returned successfully",
+				Asset: &presenter.AssetApiReturn{
+					Id:         "TestID",
+					Symbol:     "TEST3",
+					Preference: "TestPref",
+					Fullname:   "Test Name",
+					AssetType: &presenter.AssetType{
+						Id:      "TestAssetTypeID",
+						Type:    "ETF",
+						Name:    "Test ETF",
+						Country: "BR",
+					},
+					Sector: &presenter.Sector{
+						Id:   "TestSectorID",
+						Name: "Test Sector",
+					},
+					Profile: &presenter.CompanyProfileApiReturn{
+						Logo:      "https://test.com/logo.png",
+						Website:   "https://test.com",
+						Exchange:  "NASDAQ",
+						MarketCap: 2462175e6,
+						PeRatio:   28.9,
+						UpdatedAt: &dateFormatted,
+					},
+				},
+				Error: "",
+			},
+		},
 	}
 
 	// Mock UseCases function (Sector Application Logic)
@@ -333,6 +378,114 @@ func TestApiAssetGet(t *testing.T) {
 	}
 }
 
+func TestApiAssetGetProfile(t *testing.T) {
+	type body struct {
+		Success bool                               `json:"success"`
+		Message string                             `json:"message"`
+		Error   string                             `json:"error"`
+		Code    int                                `json:"code"`
+		Profile *presenter.CompanyProfileApiReturn `json:"profile"`
+	}
+
+	type test struct {
+		idToken      string
+		symbol       string
+		expectedResp body
+	}
+
+	updatedAt := entity.StringToTime("2021-10-01")
+	tests := []test{
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			symbol:  "UNKNOWN_SYMBOL",
+			expectedResp: body{
+				Code:    404,
+				Success: false,
+				Message: entity.ErrMessageApiAssetSymbolUser.Error(),
+			},
+		},
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			symbol:  "BTC",
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidCompanyProfileAssetType.Error(),
+			},
+		},
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			symbol:  "ERROR_ASSET_REPOSITORY",
+			expectedResp: body{
+				Code:    500,
+				Success: false,
+				Message: entity.ErrMessageApiInternalError.Error(),
+				Error:   errors.New("Unknown error in the asset repository").Error(),
+			},
+		},
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			symbol:  "AAPL",
+			expectedResp: body{
+				Code:    200,
+				Success: true,
+				Message: "Company profile returned successfully",
+				Profile: &presenter.CompanyProfileApiReturn{
+					Logo:      "https://test.com/logo.png",
+					Website:   "https://test.com",
+					Exchange:  "NASDAQ",
+					MarketCap: 2462175e6,
+					PeRatio:   28.9,
+					UpdatedAt: &updatedAt,
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Company Profile Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	asset := AssetApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Get("/asset/:symbol/profile", asset.GetAssetProfile)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "GET", "/api/asset/"+testCase.symbol+
+			"/profile", "application/json", testCase.idToken, nil)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
+
 func TestApiAssetPost(t *testing.T) {
 
 	type body struct {
@@ -763,6 +916,133 @@ func TestApiAssetDelete(t *testing.T) {
 	}
 }
 
+func TestApiAssetUpdate(t *testing.T) {
+
+	type body struct {
+		Success bool                      `json:"success"`
+		Message string                    `json:"message"`
+		Error   string                    `json:"error"`
+		Code    int                       `json:"code"`
+		Asset   *presenter.AssetApiReturn `json:"asset"`
+	}
+
+	type test struct {
+		idToken      string
+		symbol       string
+		bodyRequest  presenter.AssetUpdateBody
+		expectedResp body
+	}
+
+	assetApiReturn := presenter.AssetApiReturn{
+		Id:         "TestID",
+		Symbol:     "TEST3",
+		Preference: "TestPref",
+		Fullname:   "Test Company S.A",
+		AssetType: &presenter.AssetType{
+			Id:      "TestAssetTypeID",
+			Type:    "STOCK",
+			Country: "US",
+			Name:    "Test ASTY Name",
+		},
+		Sector: &presenter.Sector{
+			Id:   "TestFinancialsID",
+			Name: "Test Sector",
+		},
+	}
+
+	tests := []test{
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			symbol:      "TEST3",
+			bodyRequest: presenter.AssetUpdateBody{Fullname: "Test Company S.A"},
+			expectedResp: body{
+				Code:    403,
+				Success: false,
+				Message: entity.ErrMessageApiAuthorization.Error(),
+				Error:   entity.ErrInvalidUserAdminPrivilege.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			symbol:      "UNKNOWN_SYMBOL",
+			bodyRequest: presenter.AssetUpdateBody{Fullname: "Test Company S.A"},
+			expectedResp: body{
+				Code:    404,
+				Success: false,
+				Message: entity.ErrMessageApiAssetSymbolUser.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			symbol:      "TEST3",
+			bodyRequest: presenter.AssetUpdateBody{SectorId: "UNKNOWN_ID"},
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidSector.Error(),
+			},
+		},
+		{
+			idToken: "ValidIdTokenPrivilegeUser",
+			symbol:  "TEST3",
+			bodyRequest: presenter.AssetUpdateBody{
+				Fullname: "Test Company S.A",
+				SectorId: "TestFinancialsID",
+			},
+			expectedResp: body{
+				Code:    200,
+				Success: true,
+				Message: "Asset was updated successfully",
+				Asset:   &assetApiReturn,
+			},
+		},
+	}
+
+	// Mock UseCases function (Sector Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	// Declare Sector Application Logic
+	asset := AssetApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Put("/asset/:symbol", asset.UpdateAsset)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "PUT", "/api/asset/"+testCase.symbol,
+			"application/json", testCase.idToken, testCase.bodyRequest)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
+
 func TestApiAssetLookup(t *testing.T) {
 	type body struct {
 		Success      bool                 `json:"success"`
@@ -907,6 +1187,135 @@ func TestApiAssetLookup(t *testing.T) {
 	}
 }
 
+func TestApiAssetSearch(t *testing.T) {
+	type body struct {
+		Success bool                                  `json:"success"`
+		Message string                                `json:"message"`
+		Error   string                                `json:"error"`
+		Code    int                                   `json:"code"`
+		Symbols []presenter.SymbolSuggestionApiReturn `json:"symbols"`
+	}
+
+	type test struct {
+		idToken      string
+		query        string
+		expectedResp body
+	}
+
+	assetId := "TestAssetID"
+
+	tests := []test{
+		{
+			idToken: "ValidIdTokenWithoutEmailVerification",
+			query:   "q=itau",
+			expectedResp: body{
+				Code:    401,
+				Success: false,
+				Message: entity.ErrMessageApiAuthentication.Error(),
+			},
+		},
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			query:   "q=",
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidSymbolSearchQuery.Error(),
+			},
+		},
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			query:   "q=itau&limit=all",
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidSymbolSearchLimit.Error(),
+			},
+		},
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			query:   "q=itau&country=ERROR",
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidCountryCode.Error(),
+			},
+		},
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			query:   "q=ERROR_REPOSITORY",
+			expectedResp: body{
+				Code:    500,
+				Success: false,
+				Message: entity.ErrMessageApiInternalError.Error(),
+				Error:   "Unknown symbol repository error",
+			},
+		},
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			query:   "q=itau&country=BR&limit=1",
+			expectedResp: body{
+				Code:    200,
+				Success: true,
+				Message: "Symbol search returned successfully",
+				Symbols: []presenter.SymbolSuggestionApiReturn{
+					{
+						Symbol:   "ITUB4",
+						Fullname: "Itau Unibanco Holding S.A",
+						Type:     "STOCK",
+						Country:  "BR",
+						AssetId:  &assetId,
+					},
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Symbol Search Application Logic)
+	usecases := usecases.NewMockApplications()
+
+	// Declare Asset Application Logic
+	asset := AssetApi{
+		ApplicationLogic: *usecases,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Get("/asset-search", asset.SearchSymbols)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "GET", "/api/asset-search?"+
+			testCase.query, "application/json", testCase.idToken, nil)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
+
 func TestApiAssetGetPrice(t *testing.T) {
 	type body struct {
 		Success     bool                `json:"success"`
@@ -920,6 +1329,7 @@ func TestApiAssetGetPrice(t *testing.T) {
 		idToken      string
 		symbol       string
 		country      string
+		currency     string
 		expectedResp body
 	}
 
@@ -992,6 +1402,36 @@ func TestApiAssetGetPrice(t *testing.T) {
 				Error: "",
 			},
 		},
+		{
+			idToken:  "ValidIdTokenWithoutPrivilegedUser",
+			symbol:   "BTC",
+			country:  "CRYPTO",
+			currency: "BRL",
+			expectedResp: body{
+				Code:    200,
+				Success: true,
+				Message: "Symbol Price returned successfully",
+				SymbolPrice: &entity.SymbolPrice{
+					Symbol:       "BTC",
+					CurrentPrice: 61870.44,
+					Currency:     "BRL",
+				},
+				Error: "",
+			},
+		},
+		{
+			idToken:  "ValidIdTokenWithoutPrivilegedUser",
+			symbol:   "BTC",
+			country:  "CRYPTO",
+			currency: "EUR",
+			expectedResp: body{
+				Code:        400,
+				Success:     false,
+				Message:     entity.ErrMessageApiRequest.Error(),
+				SymbolPrice: nil,
+				Error:       entity.ErrInvalidCryptoCurrency.Error(),
+			},
+		},
 	}
 
 	// Mock UseCases function (Sector Application Logic)
@@ -1026,7 +1466,8 @@ func TestApiAssetGetPrice(t *testing.T) {
 	for _, testCase := range tests {
 		jsonResponse := body{}
 		resp, _ := MockHttpRequest(app, "GET", "/api/asset-price?symbol="+
-			testCase.symbol+"&country="+testCase.country, "application/json",
+			testCase.symbol+"&country="+testCase.country+"&currency="+
+			testCase.currency, "application/json",
 			testCase.idToken, nil)
 
 		body, _ := ioutil.ReadAll(resp.Body)
@@ -1038,3 +1479,150 @@ func TestApiAssetGetPrice(t *testing.T) {
 		assert.Equal(t, testCase.expectedResp, jsonResponse)
 	}
 }
+
+func TestApiAssetGetPrices(t *testing.T) {
+	type body struct {
+		Success      bool                             `json:"success"`
+		Message      string                           `json:"message"`
+		Error        string                           `json:"error"`
+		Code         int                              `json:"code"`
+		SymbolPrices []presenter.SymbolPriceApiReturn `json:"symbolPrices"`
+	}
+
+	type test struct {
+		idToken      string
+		contentType  string
+		bodyReq      []presenter.AssetPriceBody
+		expectedResp body
+	}
+
+	tests := []test{
+		{
+			idToken:     "ValidIdTokenWithoutEmailVerification",
+			contentType: "application/json",
+			bodyReq: []presenter.AssetPriceBody{
+				{Symbol: "TEST3", Country: "BR"},
+			},
+			expectedResp: body{
+				Code:         401,
+				Success:      false,
+				Message:      entity.ErrMessageApiAuthentication.Error(),
+				SymbolPrices: nil,
+				Error:        "",
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/pdf",
+			bodyReq: []presenter.AssetPriceBody{
+				{Symbol: "TEST3", Country: "BR"},
+			},
+			expectedResp: body{
+				Code:         400,
+				Success:      false,
+				Message:      entity.ErrMessageApiRequest.Error(),
+				SymbolPrices: nil,
+				Error:        entity.ErrInvalidApiBody.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyReq:     []presenter.AssetPriceBody{},
+			expectedResp: body{
+				Code:         400,
+				Success:      false,
+				Message:      entity.ErrMessageApiRequest.Error(),
+				SymbolPrices: nil,
+				Error:        entity.ErrInvalidAssetPricesBlank.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyReq: []presenter.AssetPriceBody{
+				{Symbol: "TEST3", Country: "BR"},
+				{Symbol: "UNKNOWN_SYMBOL", Country: "US"},
+				{Symbol: "TEST3", Country: "BR"},
+				{Symbol: "TEST4", Country: "ERROR"},
+			},
+			expectedResp: body{
+				Code:    200,
+				Success: true,
+				Message: "Symbol Prices returned successfully",
+				SymbolPrices: []presenter.SymbolPriceApiReturn{
+					{
+						Symbol:  "TEST3",
+						Country: "BR",
+						Success: true,
+						SymbolPrice: &entity.SymbolPrice{
+							Symbol:         "TEST3",
+							CurrentPrice:   29.29,
+							LowPrice:       28.00,
+							HighPrice:      29.89,
+							OpenPrice:      29.29,
+							PrevClosePrice: 29.29,
+							MarketCap:      1018388,
+						},
+					},
+					{
+						Symbol:  "UNKNOWN_SYMBOL",
+						Country: "US",
+						Success: false,
+						Error:   entity.ErrInvalidAssetSymbol.Error(),
+					},
+					{
+						Symbol:  "TEST4",
+						Country: "ERROR",
+						Success: false,
+						Error:   entity.ErrInvalidCountryCode.Error(),
+					},
+				},
+				Error: "",
+			},
+		},
+	}
+
+	// Mock UseCases function (Sector Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	// Declare Sector Application Logic
+	asset := AssetApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Post("/asset-prices", asset.GetSymbolPrices)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "POST", "/api/asset-prices",
+			testCase.contentType, testCase.idToken, testCase.bodyReq)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
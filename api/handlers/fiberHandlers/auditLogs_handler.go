@@ -0,0 +1,77 @@
+package fiberHandlers
+
+import (
+	"reflect"
+	"stockfyApi/api/presenter"
+	"stockfyApi/entity"
+	"stockfyApi/usecases"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AuditLogApi struct {
+	ApplicationLogic usecases.Applications
+}
+
+// GetAuditLogs returns the most recent changes made by the admins. The
+// entityType and entityId queries filter the changes of an entity.
+func (auditLog *AuditLogApi) GetAuditLogs(c *fiber.Ctx) error {
+	var err error
+	limit := 0
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	searchedUser, _ := auditLog.ApplicationLogic.UserApp.SearchUser(
+		userId.String())
+	if searchedUser.Type != "admin" {
+		return c.Status(403).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiAuthorization.Error(),
+			"error":   entity.ErrInvalidUserAdminPrivilege.Error(),
+			"code":    403,
+		})
+	}
+
+	if c.Query("limit") != "" {
+		limit, err = strconv.Atoi(c.Query("limit"))
+		if err != nil {
+			return c.Status(400).JSON(&fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiRequest.Error(),
+				"error":   entity.ErrInvalidAuditLogLimit.Error(),
+				"code":    400,
+			})
+		}
+	}
+
+	auditLogs, err := auditLog.ApplicationLogic.AuditLogApp.SearchAuditLogs(
+		c.Query("entityType"), c.Query("entityId"), limit)
+	if err == entity.ErrInvalidAuditLogEntityType ||
+		err == entity.ErrInvalidAuditLogLimit {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    400,
+		})
+	}
+
+	if err != nil {
+		return c.Status(500).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success":   true,
+		"auditLogs": presenter.ConvertAuditLogToApiReturn(auditLogs),
+		"message":   "Audit logs returned successfully",
+	})
+
+	return err
+}
@@ -0,0 +1,94 @@
+package fiberHandlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"stockfyApi/api/presenter"
+	"stockfyApi/entity"
+	"stockfyApi/usecases"
+	"stockfyApi/usecases/logicApi"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type BackupApi struct {
+	ApplicationLogic usecases.Applications
+	LogicApi         logicApi.UseCases
+}
+
+// CreateBackup returns a versioned JSON file with the whole portfolio of the
+// user, which can be restored in another account with RestoreBackup.
+func (backup *BackupApi) CreateBackup(c *fiber.Ctx) error {
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	httpStatusCode, portfolioBackup, err := backup.LogicApi.ApiCreateBackup(
+		userId.String())
+	if httpStatusCode == 500 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	}
+
+	c.Attachment("portfolio-backup.json")
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := json.NewEncoder(w).Encode(portfolioBackup); err != nil {
+			fmt.Println("fiberHandlers.CreateBackup: ", err)
+		}
+		w.Flush()
+	})
+
+	return nil
+}
+
+// RestoreBackup replays a backup created by CreateBackup in the portfolio of
+// the user. The assets are matched by symbol and country, and restoring the
+// same backup twice does not duplicate anything.
+func (backup *BackupApi) RestoreBackup(c *fiber.Ctx) error {
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	var portfolioBackup entity.PortfolioBackup
+	if err := c.BodyParser(&portfolioBackup); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	httpStatusCode, restore, err := backup.LogicApi.ApiRestoreBackup(
+		portfolioBackup, userId.String())
+	if httpStatusCode == 400 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	} else if httpStatusCode == 500 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"restore": presenter.ConvertBackupRestoreToApiReturn(*restore),
+		"message": "Backup was restored successfully",
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package fiberHandlers
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"stockfyApi/api/middleware"
+	"stockfyApi/api/presenter"
+	"stockfyApi/entity"
+	"stockfyApi/usecases"
+	"stockfyApi/usecases/logicApi"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiCreateBackup(t *testing.T) {
+	// Mock UseCases function (Backup Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	// Declare Backup Application Logic
+	backup := BackupApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Get("/backup", backup.CreateBackup)
+
+	resp, _ := MockHttpRequest(app, "GET", "/api/backup", "application/json",
+		"ValidIdTokenWithoutPrivilegedUser", nil)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, `attachment; filename="portfolio-backup.json"`,
+		resp.Header.Get("Content-Disposition"))
+
+	backupResponse := entity.PortfolioBackup{}
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	assert.Nil(t, json.Unmarshal(respBody, &backupResponse))
+	assert.Equal(t, entity.BackupVersion, backupResponse.Version)
+	assert.Equal(t, []entity.BackupAccount{
+		{Nickname: "Test BR 1", Brokerage: "Test BR 1", Country: "BR"},
+	}, backupResponse.Accounts)
+	assert.Equal(t, []entity.BackupAsset{
+		{Symbol: "TEST3", Country: "BR", Fullname: "Test Name",
+			Preference: "TestPref", AssetType: "STOCK", Sector: "Test Sector"},
+	}, backupResponse.Assets)
+	assert.Equal(t, []entity.BackupOrder{
+		{Symbol: "TEST3", Country: "BR", Account: "Test Brokerage",
+			OrderType: "buy", Quantity: 20, Price: 29.29, Currency: "BRL",
+			Date: "2021-10-01"},
+	}, backupResponse.Orders)
+	assert.Equal(t, []entity.BackupEarning{
+		{Symbol: "TEST3", Country: "BR", EarningType: "Dividendos", Amount: 5,
+			Currency: "BRL", Date: "2021-10-01"},
+	}, backupResponse.Earnings)
+}
+
+func TestApiRestoreBackup(t *testing.T) {
+	type body struct {
+		Success bool                     `json:"success"`
+		Message string                   `json:"message"`
+		Error   string                   `json:"error"`
+		Code    int                      `json:"code"`
+		Restore *presenter.BackupRestore `json:"restore"`
+	}
+
+	type test struct {
+		idToken      string
+		contentType  string
+		bodyReq      interface{}
+		expectedResp body
+	}
+
+	validBackup := entity.PortfolioBackup{
+		Version: entity.BackupVersion,
+		Accounts: []entity.BackupAccount{
+			{Nickname: "Clear", Brokerage: "Clear", Country: "BR"},
+		},
+		Assets: []entity.BackupAsset{
+			{Symbol: "ITUB4", Country: "BR", Fullname: "Itau Unibanco",
+				AssetType: "STOCK", Sector: "Finance"},
+		},
+		Orders: []entity.BackupOrder{
+			{Symbol: "ITUB4", Country: "BR", Account: "Clear", OrderType: "buy",
+				Quantity: 20, Price: 29.29, Currency: "BRL", Date: "2021-10-01"},
+		},
+	}
+
+	invalidBackup := validBackup
+	invalidBackup.Version = entity.BackupVersion + 1
+
+	tests := []test{
+		{
+			idToken:     "ValidIdTokenWithoutEmailVerification",
+			contentType: "application/json",
+			bodyReq:     validBackup,
+			expectedResp: body{
+				Code:    401,
+				Success: false,
+				Message: entity.ErrMessageApiAuthentication.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyReq:     invalidBackup,
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidBackupVersion.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyReq:     validBackup,
+			expectedResp: body{
+				Code:    200,
+				Success: true,
+				Message: "Backup was restored successfully",
+				Restore: &presenter.BackupRestore{
+					Accounts: 1,
+					Assets:   1,
+					Orders:   1,
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Backup Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	// Declare Backup Application Logic
+	backup := BackupApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Post("/backup/restore", backup.RestoreBackup)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "POST", "/api/backup/restore",
+			testCase.contentType, testCase.idToken, testCase.bodyReq)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
@@ -1,9 +1,11 @@
 package fiberHandlers
 
 import (
+	"reflect"
 	"stockfyApi/api/presenter"
 	"stockfyApi/entity"
 	"stockfyApi/usecases"
+	"stockfyApi/usecases/logicApi"
 
 	"github.com/gofiber/fiber/v2"
 	_ "github.com/lib/pq"
@@ -11,12 +13,16 @@ import (
 
 type BrokerageApi struct {
 	ApplicationLogic usecases.Applications
+	LogicApi         logicApi.UseCases
 }
 
 func (brokerage *BrokerageApi) GetBrokerageFirms(c *fiber.Ctx) error {
 
 	var searchType string
 
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
 	if c.Query("country") == "" {
 		searchType = "ALL"
 	} else {
@@ -24,7 +30,7 @@ func (brokerage *BrokerageApi) GetBrokerageFirms(c *fiber.Ctx) error {
 	}
 
 	brokerageFirms, err := brokerage.ApplicationLogic.BrokerageApp.
-		SearchBrokerage(searchType, "", c.Query("country"))
+		SearchBrokerage(searchType, "", c.Query("country"), userId.String())
 	if err == entity.ErrInvalidCountryCode ||
 		err == entity.ErrInvalidBrokerageNameSearch {
 		return c.Status(400).JSON(&fiber.Map{
@@ -59,8 +65,11 @@ func (brokerage *BrokerageApi) GetBrokerageFirms(c *fiber.Ctx) error {
 
 func (brokerage *BrokerageApi) GetBrokerageFirm(c *fiber.Ctx) error {
 
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
 	brokerageInfo, err := brokerage.ApplicationLogic.BrokerageApp.SearchBrokerage(
-		"SINGLE", c.Params("name"), "")
+		"SINGLE", c.Params("name"), "", userId.String())
 	if err == entity.ErrInvalidBrokerageNameSearch {
 		return c.Status(404).JSON(&fiber.Map{
 			"success": false,
@@ -90,3 +99,342 @@ func (brokerage *BrokerageApi) GetBrokerageFirm(c *fiber.Ctx) error {
 	return err
 
 }
+
+// CreateBrokerage creates a brokerage firm. Custom brokerages are visible only
+// to the user who created them, while the others are created by the admins
+// for everyone.
+func (brokerage *BrokerageApi) CreateBrokerage(c *fiber.Ctx) error {
+	var brokerageBody presenter.BrokerageBody
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	if err := c.BodyParser(&brokerageBody); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	httpStatusCode, brokerageCreated, err := brokerage.LogicApi.
+		ApiCreateBrokerage(brokerageBody.Name, brokerageBody.Fullname,
+			brokerageBody.Country, brokerageBody.Custom, userId.String())
+	if httpStatusCode == 403 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiAuthorization.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	} else if httpStatusCode == 400 || httpStatusCode == 404 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	} else if httpStatusCode == 500 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success":   true,
+		"brokerage": presenter.ConvertFullBrokerageToApiReturn(*brokerageCreated),
+		"message":   "Brokerage firm creation was successful",
+	})
+
+	return err
+}
+
+func (brokerage *BrokerageApi) UpdateBrokerage(c *fiber.Ctx) error {
+	var brokerageBody presenter.BrokerageBody
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	if err := c.BodyParser(&brokerageBody); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	httpStatusCode, brokerageUpdated, err := brokerage.LogicApi.
+		ApiUpdateBrokerage(c.Params("id"), brokerageBody.Name,
+			brokerageBody.Fullname, brokerageBody.Country, userId.String())
+	if httpStatusCode == 403 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiAuthorization.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	} else if httpStatusCode == 400 || httpStatusCode == 404 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	} else if httpStatusCode == 500 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success":   true,
+		"brokerage": presenter.ConvertFullBrokerageToApiReturn(*brokerageUpdated),
+		"message":   "Brokerage firm was updated successfully",
+	})
+
+	return err
+}
+
+func (brokerage *BrokerageApi) DeleteBrokerage(c *fiber.Ctx) error {
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	httpStatusCode, brokerageDeleted, err := brokerage.LogicApi.
+		ApiDeleteBrokerage(c.Params("id"), userId.String())
+	if httpStatusCode == 403 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiAuthorization.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	} else if httpStatusCode == 400 || httpStatusCode == 404 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	} else if httpStatusCode == 500 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success":   true,
+		"brokerage": presenter.ConvertFullBrokerageToApiReturn(*brokerageDeleted),
+		"message":   "Brokerage firm was deleted successfully",
+	})
+
+	return err
+}
+
+func (brokerage *BrokerageApi) GetBrokerageAccounts(c *fiber.Ctx) error {
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	accounts, err := brokerage.ApplicationLogic.BrokerageApp.SearchAccounts(
+		userId.String())
+	if err != nil {
+		return c.Status(500).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success":  true,
+		"accounts": presenter.ConvertArrayBrokerageAccountToApiReturn(accounts),
+		"message":  "Brokerage accounts returned successfully",
+	})
+
+	return err
+}
+
+func (brokerage *BrokerageApi) CreateBrokerageAccount(c *fiber.Ctx) error {
+	var accountBody presenter.BrokerageAccountBody
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	if err := c.BodyParser(&accountBody); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	accountCreated, err := brokerage.ApplicationLogic.BrokerageApp.CreateAccount(
+		accountBody.Nickname, accountBody.BrokerageId, userId.String())
+	if err == entity.ErrInvalidBrokerageAccountNickname ||
+		err == entity.ErrInvalidBrokerageAccountExist ||
+		err == entity.ErrInvalidBrokerageId {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    400,
+		})
+	}
+
+	if err != nil {
+		return c.Status(500).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"account": presenter.ConvertBrokerageAccountToApiReturn(accountCreated),
+		"message": "Brokerage account creation was successful",
+	})
+
+	return err
+}
+
+func (brokerage *BrokerageApi) UpdateBrokerageAccount(c *fiber.Ctx) error {
+	var accountBody presenter.BrokerageAccountBody
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	if err := c.BodyParser(&accountBody); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	accountUpdated, err := brokerage.ApplicationLogic.BrokerageApp.UpdateAccount(
+		c.Params("id"), userId.String(), accountBody.Nickname)
+	if err == entity.ErrInvalidBrokerageAccount {
+		return c.Status(404).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    404,
+		})
+	}
+
+	if err == entity.ErrInvalidBrokerageAccountNickname ||
+		err == entity.ErrInvalidBrokerageAccountExist {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    400,
+		})
+	}
+
+	if err != nil {
+		return c.Status(500).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"account": presenter.ConvertBrokerageAccountToApiReturn(accountUpdated),
+		"message": "Brokerage account was updated successfully",
+	})
+
+	return err
+}
+
+// DeleteBrokerageAccount removes an account of the user without orders.
+func (brokerage *BrokerageApi) DeleteBrokerageAccount(c *fiber.Ctx) error {
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	accountDeleted, err := brokerage.ApplicationLogic.BrokerageApp.DeleteAccount(
+		c.Params("id"), userId.String())
+	if err == entity.ErrInvalidBrokerageAccount {
+		return c.Status(404).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    404,
+		})
+	}
+
+	if err == entity.ErrInvalidBrokerageAccountInUse {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    400,
+		})
+	}
+
+	if err != nil {
+		return c.Status(500).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"account": presenter.ConvertBrokerageAccountToApiReturn(accountDeleted),
+		"message": "Brokerage account was deleted successfully",
+	})
+
+	return err
+}
+
+// GetBrokerageAccountPositions returns the assets of the user grouped by the
+// brokerage account where they are held.
+func (brokerage *BrokerageApi) GetBrokerageAccountPositions(
+	c *fiber.Ctx) error {
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	positions, err := brokerage.ApplicationLogic.BrokerageApp.AccountPositions(
+		userId.String())
+	if err != nil {
+		return c.Status(500).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success":   true,
+		"positions": presenter.ConvertAccountPositionToApiReturn(positions),
+		"message":   "Brokerage account positions returned successfully",
+	})
+
+	return err
+}
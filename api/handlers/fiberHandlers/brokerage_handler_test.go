@@ -8,6 +8,7 @@ import (
 	"stockfyApi/api/presenter"
 	"stockfyApi/entity"
 	"stockfyApi/usecases"
+	"stockfyApi/usecases/logicApi"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
@@ -275,3 +276,211 @@ func TestApiGetBrokerageFirm(t *testing.T) {
 		assert.Equal(t, testCase.expectedResp, jsonResponse)
 	}
 }
+
+func TestApiCreateBrokerage(t *testing.T) {
+	type body struct {
+		Success   bool                 `json:"success"`
+		Message   string               `json:"message"`
+		Error     string               `json:"error"`
+		Code      int                  `json:"code"`
+		Brokerage *presenter.Brokerage `json:"brokerage"`
+	}
+
+	type test struct {
+		idToken      string
+		contentType  string
+		bodyRequest  presenter.BrokerageBody
+		expectedResp body
+	}
+
+	tests := []test{
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyRequest: presenter.BrokerageBody{
+				Name:    "Test BR 3",
+				Country: "BR",
+			},
+			expectedResp: body{
+				Code:    403,
+				Success: false,
+				Message: entity.ErrMessageApiAuthorization.Error(),
+				Error:   entity.ErrInvalidUserAdminPrivilege.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			contentType: "application/json",
+			bodyRequest: presenter.BrokerageBody{
+				Name:    "Test BR 1",
+				Country: "BR",
+			},
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidBrokerageExist.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyRequest: presenter.BrokerageBody{
+				Name:    "Test BR 3",
+				Country: "br",
+				Custom:  true,
+			},
+			expectedResp: body{
+				Code:    200,
+				Success: true,
+				Message: "Brokerage firm creation was successful",
+				Brokerage: &presenter.Brokerage{
+					Id:       "TestBrokerageID",
+					Name:     "Test BR 3",
+					Fullname: "Test BR 3",
+					Country:  "BR",
+					Custom:   true,
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Brokerage Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	brokerage := BrokerageApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Post("/brokerage", brokerage.CreateBrokerage)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "POST", "/api/brokerage",
+			testCase.contentType, testCase.idToken, testCase.bodyRequest)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
+
+func TestApiDeleteBrokerageAccount(t *testing.T) {
+	type body struct {
+		Success bool                        `json:"success"`
+		Message string                      `json:"message"`
+		Error   string                      `json:"error"`
+		Code    int                         `json:"code"`
+		Account *presenter.BrokerageAccount `json:"account"`
+	}
+
+	type test struct {
+		idToken      string
+		contentType  string
+		accountId    string
+		expectedResp body
+	}
+
+	tests := []test{
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			accountId:   "UNKNOWN_ACCOUNT",
+			expectedResp: body{
+				Code:    404,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidBrokerageAccount.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			accountId:   "WITH_ORDERS",
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidBrokerageAccountInUse.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			accountId:   "TestAccountID",
+			expectedResp: body{
+				Code:    200,
+				Success: true,
+				Message: "Brokerage account was deleted successfully",
+				Account: &presenter.BrokerageAccount{
+					Id:       "TestAccountID",
+					Nickname: "Test BR 1",
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Brokerage Application Logic)
+	usecases := usecases.NewMockApplications()
+
+	brokerage := BrokerageApi{
+		ApplicationLogic: *usecases,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Delete("/brokerage-accounts/:id", brokerage.DeleteBrokerageAccount)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "DELETE",
+			"/api/brokerage-accounts/"+testCase.accountId, testCase.contentType,
+			testCase.idToken, nil)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
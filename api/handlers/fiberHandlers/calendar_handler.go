@@ -0,0 +1,72 @@
+package fiberHandlers
+
+import (
+	"stockfyApi/api/presenter"
+	"stockfyApi/calendar"
+	"stockfyApi/entity"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CalendarApi struct {
+}
+
+func (calendarApi *CalendarApi) GetExchangeCalendars(c *fiber.Ctx) error {
+	var exchangesApiReturn []presenter.ExchangeCalendarApiReturn
+
+	now := time.Now()
+
+	for _, exchange := range calendar.ListExchanges() {
+		exchangesApiReturn = append(exchangesApiReturn,
+			presenter.ConvertExchangeCalendarToApiReturn(exchange,
+				exchange.LocalDate(now), now))
+	}
+
+	err := c.JSON(&fiber.Map{
+		"success":   true,
+		"exchanges": exchangesApiReturn,
+		"message":   "Exchange calendars returned successfully",
+	})
+
+	return err
+}
+
+// GetExchangeCalendar returns the sessions of the exchange around the date of
+// the query, or around today in the exchange time zone.
+func (calendarApi *CalendarApi) GetExchangeCalendar(c *fiber.Ctx) error {
+	var err error
+
+	exchange, err := calendar.SearchExchange(c.Params("exchange"))
+	if err != nil {
+		return c.Status(404).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    404,
+		})
+	}
+
+	now := time.Now()
+	date := exchange.LocalDate(now)
+	if c.Query("date") != "" {
+		date, err = calendar.ParseDate(c.Query("date"))
+		if err != nil {
+			return c.Status(400).JSON(&fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiRequest.Error(),
+				"error":   err.Error(),
+				"code":    400,
+			})
+		}
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"exchange": presenter.ConvertExchangeCalendarToApiReturn(*exchange,
+			date, now),
+		"message": "Exchange calendar returned successfully",
+	})
+
+	return err
+}
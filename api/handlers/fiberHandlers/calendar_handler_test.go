@@ -0,0 +1,118 @@
+package fiberHandlers
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"stockfyApi/api/middleware"
+	"stockfyApi/api/presenter"
+	"stockfyApi/entity"
+	"stockfyApi/usecases"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiGetExchangeCalendar(t *testing.T) {
+	type body struct {
+		Success  bool                                 `json:"success"`
+		Message  string                               `json:"message"`
+		Error    string                               `json:"error"`
+		Code     int                                  `json:"code"`
+		Exchange *presenter.ExchangeCalendarApiReturn `json:"exchange"`
+	}
+
+	type test struct {
+		idToken      string
+		path         string
+		expectedResp body
+	}
+
+	tests := []test{
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			path:    "/api/calendar/LSE",
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidExchange.Error(),
+				Code:    404,
+			},
+		},
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			path:    "/api/calendar/B3?date=02/10/2021",
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidCalendarDate.Error(),
+				Code:    400,
+			},
+		},
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			path:    "/api/calendar/B3?date=2021-10-02",
+			expectedResp: body{
+				Success: true,
+				Message: "Exchange calendar returned successfully",
+				Code:    200,
+				Exchange: &presenter.ExchangeCalendarApiReturn{
+					Code:            "B3",
+					Name:            "B3 - Brasil, Bolsa, Balcão",
+					Countries:       []string{"BR", "BDR"},
+					Timezone:        "America/Sao_Paulo",
+					Open:            "10:00",
+					Close:           "17:00",
+					Date:            entity.StringToTime("2021-10-02"),
+					IsTradingDay:    false,
+					PreviousSession: entity.StringToTime("2021-10-01"),
+					NextSession:     entity.StringToTime("2021-10-04"),
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (only used for the user authentication)
+	usecases := usecases.NewMockApplications()
+
+	// Declare Calendar Application Logic
+	calendarApi := CalendarApi{}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Get("/calendar/:exchange", calendarApi.GetExchangeCalendar)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "GET", testCase.path,
+			"application/json", testCase.idToken, nil)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		// The exchange status depends on the time of the test execution
+		if jsonResponse.Exchange != nil {
+			jsonResponse.Exchange.IsOpen = false
+		}
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
@@ -0,0 +1,161 @@
+package fiberHandlers
+
+import (
+	"reflect"
+	"stockfyApi/api/presenter"
+	"stockfyApi/entity"
+	"stockfyApi/usecases"
+	"stockfyApi/usecases/logicApi"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CashApi struct {
+	ApplicationLogic usecases.Applications
+	LogicApi         logicApi.UseCases
+}
+
+// CreateCashMovement registers a deposit, withdrawal, transfer, fee or
+// interest in a brokerage account of the user.
+func (cash *CashApi) CreateCashMovement(c *fiber.Ctx) error {
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	var movementInsert presenter.CashMovementBody
+	if err := c.BodyParser(&movementInsert); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	httpStatusCode, movementCreated, err := cash.LogicApi.ApiCreateCashMovement(
+		movementInsert.Type, movementInsert.Amount, movementInsert.Currency,
+		movementInsert.Date, movementInsert.Description,
+		movementInsert.AccountId, movementInsert.ToAccountId, userId.String())
+	if httpStatusCode == 400 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	} else if httpStatusCode == 500 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success":  true,
+		"movement": presenter.ConvertCashMovementToApiReturn(*movementCreated),
+		"message":  "Cash movement registered successfully",
+	})
+
+	return err
+}
+
+// DeleteCashMovement deletes a cash movement registered by the user. The
+// entries posted by the orders and the earnings change only with them.
+func (cash *CashApi) DeleteCashMovement(c *fiber.Ctx) error {
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	movementDeleted, err := cash.ApplicationLogic.CashApp.DeleteMovement(
+		c.Params("id"), userId.String())
+	if err == entity.ErrInvalidCashMovement {
+		return c.Status(404).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    404,
+		})
+	} else if err != nil {
+		return c.Status(500).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success":  true,
+		"movement": presenter.ConvertCashMovementToApiReturn(*movementDeleted),
+		"message":  "Cash movement was deleted successfully",
+	})
+
+	return err
+}
+
+// GetCashLedger returns the cash ledger of the user until the date query, or
+// until today, with the balance of the account after each entry. The
+// accountId query limits the ledger to one brokerage account.
+func (cash *CashApi) GetCashLedger(c *fiber.Ctx) error {
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	httpStatusCode, ledger, err := cash.LogicApi.ApiCashLedger(
+		c.Query("accountId"), c.Query("date"), userId.String())
+	if httpStatusCode == 400 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	} else if httpStatusCode == 500 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"ledger":  presenter.ConvertCashLedgerToApiReturn(ledger),
+		"message": "Cash ledger returned successfully",
+	})
+
+	return err
+}
+
+// GetCashBalances returns the cash of the user in each brokerage account at
+// the date query, or today.
+func (cash *CashApi) GetCashBalances(c *fiber.Ctx) error {
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	httpStatusCode, balances, err := cash.LogicApi.ApiCashBalances(
+		c.Query("date"), userId.String())
+	if httpStatusCode == 400 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	} else if httpStatusCode == 500 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success":  true,
+		"balances": presenter.ConvertCashBalancesToApiReturn(balances),
+		"message":  "Cash balances returned successfully",
+	})
+
+	return err
+}
@@ -0,0 +1,426 @@
+package fiberHandlers
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"stockfyApi/api/middleware"
+	"stockfyApi/api/presenter"
+	"stockfyApi/entity"
+	"stockfyApi/usecases"
+	"stockfyApi/usecases/logicApi"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiCreateCashMovement(t *testing.T) {
+	type body struct {
+		Success  bool                    `json:"success"`
+		Message  string                  `json:"message"`
+		Error    string                  `json:"error"`
+		Code     int                     `json:"code"`
+		Movement *presenter.CashMovement `json:"movement"`
+	}
+
+	type test struct {
+		idToken      string
+		contentType  string
+		bodyReq      interface{}
+		expectedResp body
+	}
+
+	tests := []test{
+		{
+			idToken:     "ValidIdTokenWithoutEmailVerification",
+			contentType: "application/json",
+			bodyReq: presenter.CashMovementBody{
+				Type: "deposit", Amount: 1000, Date: "2021-10-01",
+				AccountId: "TestAccountID"},
+			expectedResp: body{
+				Code:    401,
+				Success: false,
+				Message: entity.ErrMessageApiAuthentication.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyReq: presenter.CashMovementBody{
+				Type: "deposit", Amount: 1000, Date: "2021-10-01",
+				AccountId: "UNKNOWN_ACCOUNT"},
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidBrokerageAccount.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyReq: presenter.CashMovementBody{
+				Type: "deposit", Amount: 1000, Currency: "USD",
+				Date: "2021-10-01", AccountId: "TestAccountID"},
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidCashMovementCurrency.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyReq: presenter.CashMovementBody{
+				Type: "transfer", Amount: 1000, Date: "2021-10-01",
+				AccountId: "TestAccountID", ToAccountId: "US_ACCOUNT"},
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidCashMovementCurrency.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyReq: presenter.CashMovementBody{
+				Type: "loan", Amount: 1000, Date: "2021-10-01",
+				AccountId: "TestAccountID"},
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidCashMovementType.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyReq: presenter.CashMovementBody{
+				Type: "withdrawal", Amount: 200, Date: "2021-10-01",
+				Description: "Rent", AccountId: "TestAccountID"},
+			expectedResp: body{
+				Code:    200,
+				Success: true,
+				Message: "Cash movement registered successfully",
+				Movement: &presenter.CashMovement{
+					Id:          "TestMovementID",
+					Type:        entity.CashWithdrawal,
+					Amount:      -200,
+					Currency:    "BRL",
+					Date:        "2021-10-01",
+					Description: "Rent",
+					Account: &presenter.BrokerageAccount{
+						Id:       "TestAccountID",
+						Nickname: "Test BR 1",
+						Brokerage: &presenter.Brokerage{
+							Id:      "TestBrokerageID3",
+							Name:    "Test BR 1",
+							Country: "BR",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Cash Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	// Declare Cash Application Logic
+	cash := CashApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Get("/cash-movements", cash.GetCashLedger)
+	api.Get("/cash-movements/balances", cash.GetCashBalances)
+	api.Post("/cash-movements", cash.CreateCashMovement)
+	api.Delete("/cash-movements/:id", cash.DeleteCashMovement)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "POST", "/api/cash-movements",
+			testCase.contentType, testCase.idToken, testCase.bodyReq)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
+
+func TestApiDeleteCashMovement(t *testing.T) {
+	type body struct {
+		Success  bool                    `json:"success"`
+		Message  string                  `json:"message"`
+		Error    string                  `json:"error"`
+		Code     int                     `json:"code"`
+		Movement *presenter.CashMovement `json:"movement"`
+	}
+
+	type test struct {
+		path         string
+		expectedResp body
+	}
+
+	tests := []test{
+		{
+			path: "/api/cash-movements/UNKNOWN_ID",
+			expectedResp: body{
+				Code:    404,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidCashMovement.Error(),
+			},
+		},
+		{
+			path: "/api/cash-movements/TestMovementID",
+			expectedResp: body{
+				Code:    200,
+				Success: true,
+				Message: "Cash movement was deleted successfully",
+				Movement: &presenter.CashMovement{
+					Id:       "TestMovementID",
+					Type:     entity.CashDeposit,
+					Amount:   1000,
+					Currency: "BRL",
+					Date:     "2021-10-01",
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Cash Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	// Declare Cash Application Logic
+	cash := CashApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Get("/cash-movements", cash.GetCashLedger)
+	api.Get("/cash-movements/balances", cash.GetCashBalances)
+	api.Post("/cash-movements", cash.CreateCashMovement)
+	api.Delete("/cash-movements/:id", cash.DeleteCashMovement)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "DELETE", testCase.path,
+			"application/json", "ValidIdTokenWithoutPrivilegedUser", nil)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
+
+func TestApiGetCashLedger(t *testing.T) {
+	type body struct {
+		Success bool                  `json:"success"`
+		Message string                `json:"message"`
+		Error   string                `json:"error"`
+		Code    int                   `json:"code"`
+		Ledger  []presenter.CashEntry `json:"ledger"`
+	}
+
+	type test struct {
+		path         string
+		expectedResp body
+	}
+
+	tests := []test{
+		{
+			path: "/api/cash-movements?date=31/10/2021",
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidCashMovementDate.Error(),
+			},
+		},
+		{
+			path: "/api/cash-movements?accountId=UNKNOWN_ACCOUNT",
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidBrokerageAccount.Error(),
+			},
+		},
+		{
+			path: "/api/cash-movements?accountId=TestAccountID1&date=2021-10-31",
+			expectedResp: body{
+				Code:    200,
+				Success: true,
+				Message: "Cash ledger returned successfully",
+				Ledger: []presenter.CashEntry{
+					{Source: entity.CashSourceMovement,
+						SourceId: "TestMovementID", Type: entity.CashDeposit,
+						Amount: 1000, Currency: "BRL", Date: "2021-10-01",
+						Description: "Monthly deposit",
+						AccountId:   "TestAccountID1", Nickname: "Test BR 1",
+						Balance: 1000},
+					{Source: entity.CashSourceOrder, SourceId: "TestOrderID",
+						Type: "buy", Amount: -585.8, Currency: "BRL",
+						Date: "2021-10-05", Description: "TEST3",
+						AccountId: "TestAccountID1", Nickname: "Test BR 1",
+						Balance: 414.2},
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Cash Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	// Declare Cash Application Logic
+	cash := CashApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Get("/cash-movements", cash.GetCashLedger)
+	api.Get("/cash-movements/balances", cash.GetCashBalances)
+	api.Post("/cash-movements", cash.CreateCashMovement)
+	api.Delete("/cash-movements/:id", cash.DeleteCashMovement)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "GET", testCase.path,
+			"application/json", "ValidIdTokenWithoutPrivilegedUser", nil)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
+
+func TestApiGetCashBalances(t *testing.T) {
+	type body struct {
+		Success  bool                    `json:"success"`
+		Message  string                  `json:"message"`
+		Error    string                  `json:"error"`
+		Code     int                     `json:"code"`
+		Balances []presenter.CashBalance `json:"balances"`
+	}
+
+	// Mock UseCases function (Cash Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	// Declare Cash Application Logic
+	cash := CashApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Get("/cash-movements", cash.GetCashLedger)
+	api.Get("/cash-movements/balances", cash.GetCashBalances)
+	api.Post("/cash-movements", cash.CreateCashMovement)
+	api.Delete("/cash-movements/:id", cash.DeleteCashMovement)
+
+	jsonResponse := body{}
+	resp, _ := MockHttpRequest(app, "GET",
+		"/api/cash-movements/balances?date=2021-12-31", "application/json",
+		"ValidIdTokenWithoutPrivilegedUser", nil)
+
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	json.Unmarshal(respBody, &jsonResponse)
+	jsonResponse.Code = resp.StatusCode
+
+	assert.Equal(t, body{
+		Code:    200,
+		Success: true,
+		Message: "Cash balances returned successfully",
+		Balances: []presenter.CashBalance{
+			{AccountId: "TestAccountID1", Nickname: "Test BR 1",
+				Currency: "BRL", Balance: 419.2},
+		},
+	}, jsonResponse)
+}
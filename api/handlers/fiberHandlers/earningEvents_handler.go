@@ -0,0 +1,77 @@
+package fiberHandlers
+
+import (
+	"reflect"
+	"stockfyApi/api/presenter"
+	"stockfyApi/entity"
+	"stockfyApi/usecases"
+	"stockfyApi/usecases/logicApi"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type EarningEventApi struct {
+	ApplicationLogic usecases.Applications
+	LogicApi         logicApi.UseCases
+}
+
+func (earningEvent *EarningEventApi) ImportEarningEvents(c *fiber.Ctx) error {
+	var err error
+	var eventBody presenter.EarningEventBody
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	searchedUser, _ := earningEvent.ApplicationLogic.UserApp.SearchUser(
+		userId.String())
+	if searchedUser.Type != "admin" {
+		return c.Status(403).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiAuthorization.Error(),
+			"error":   entity.ErrInvalidUserAdminPrivilege.Error(),
+			"code":    403,
+		})
+	}
+
+	if err := c.BodyParser(&eventBody); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	statusCode, events, err := earningEvent.LogicApi.ApiImportEarningEvents(
+		eventBody.Symbol)
+
+	if statusCode == 400 {
+		return c.Status(statusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    statusCode,
+		})
+	} else if statusCode == 404 {
+		return c.Status(statusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": err.Error(),
+			"code":    statusCode,
+		})
+	} else if statusCode == 500 {
+		return c.Status(statusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    statusCode,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success":       true,
+		"earningEvents": presenter.ConvertEarningEventToApiReturn(events),
+		"message":       "Earning events imported successfully",
+	})
+
+	return err
+}
@@ -0,0 +1,273 @@
+package fiberHandlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"stockfyApi/api/middleware"
+	"stockfyApi/api/presenter"
+	"stockfyApi/entity"
+	"stockfyApi/usecases"
+	"stockfyApi/usecases/logicApi"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiImportEarningEvents(t *testing.T) {
+
+	type body struct {
+		Success       bool                              `json:"success"`
+		Message       string                            `json:"message"`
+		Error         string                            `json:"error"`
+		Code          int                               `json:"code"`
+		EarningEvents []presenter.EarningEventApiReturn `json:"earningEvents"`
+	}
+
+	type test struct {
+		idToken      string
+		contentType  string
+		bodyRequest  presenter.EarningEventBody
+		expectedResp body
+	}
+
+	exDate := entity.StringToTime("2021-10-05")
+	paymentDate := entity.StringToTime("2021-10-20")
+
+	tests := []test{
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyRequest: presenter.EarningEventBody{Symbol: "ITUB4"},
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiAuthorization.Error(),
+				Error:   entity.ErrInvalidUserAdminPrivilege.Error(),
+				Code:    403,
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			contentType: "application/pdf",
+			bodyRequest: presenter.EarningEventBody{Symbol: "ITUB4"},
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidApiBody.Error(),
+				Code:    400,
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			contentType: "application/json",
+			bodyRequest: presenter.EarningEventBody{Symbol: "BTC"},
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidEarningEventProvider.Error(),
+				Code:    400,
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			contentType: "application/json",
+			bodyRequest: presenter.EarningEventBody{Symbol: "UNKNOWN_SYMBOL"},
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrInvalidAssetSymbol.Error(),
+				Code:    404,
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			contentType: "application/json",
+			bodyRequest: presenter.EarningEventBody{
+				Symbol: "ERROR_ASSET_REPOSITORY",
+			},
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiInternalError.Error(),
+				Error: errors.New(
+					"Unknown error in the asset repository").Error(),
+				Code: 500,
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			contentType: "application/json",
+			bodyRequest: presenter.EarningEventBody{Symbol: "ITUB4"},
+			expectedResp: body{
+				Success: true,
+				Message: "Earning events imported successfully",
+				Code:    200,
+				EarningEvents: []presenter.EarningEventApiReturn{
+					{
+						Id:          "EarningEventID",
+						Type:        "JCP",
+						Amount:      0.5,
+						Currency:    "BRL",
+						ExDate:      &exDate,
+						PaymentDate: &paymentDate,
+						Asset: &presenter.AssetApiReturn{
+							Id:     "TestID",
+							Symbol: "ITUB4",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Earning Event Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	// Declare Earning Event Application Logic
+	earningEvent := EarningEventApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Post("/earning-events/import", earningEvent.ImportEarningEvents)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "POST", "/api/earning-events/import",
+			testCase.contentType, testCase.idToken, testCase.bodyRequest)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
+
+func TestApiGetProposedEarnings(t *testing.T) {
+	type body struct {
+		Success bool                          `json:"success"`
+		Message string                        `json:"message"`
+		Error   string                        `json:"error"`
+		Code    int                           `json:"code"`
+		Earning []presenter.EarningsApiReturn `json:"earning"`
+	}
+
+	type test struct {
+		idToken      string
+		contentType  string
+		pathQuery    string
+		expectedResp body
+	}
+
+	paymentDate := entity.StringToTime("2021-10-20")
+
+	tests := []test{
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			pathQuery:   "",
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidApiQuerySymbolBlank.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			pathQuery:   "?symbol=INVALID_SYMBOL",
+			expectedResp: body{
+				Code:    404,
+				Success: false,
+				Message: entity.ErrMessageApiAssetSymbolUser.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			pathQuery:   "?symbol=ITUB4",
+			expectedResp: body{
+				Code:    200,
+				Success: true,
+				Message: "Proposed earnings returned successfully",
+				Earning: []presenter.EarningsApiReturn{
+					{
+						Type:     "JCP",
+						Earning:  50,
+						Currency: "BRL",
+						Date:     &paymentDate,
+						Asset: &presenter.AssetApiReturn{
+							Id:     "TestID",
+							Symbol: "ITUB4",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Earnings Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	// Declare Earnings Application Logic
+	earnings := EarningsApi{
+		ApplicationLogic: *usecases,
+		ApiLogic:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Get("/earnings/proposed", earnings.GetProposedEarnings)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "GET",
+			"/api/earnings/proposed"+testCase.pathQuery, testCase.contentType,
+			testCase.idToken, nil)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
@@ -210,3 +210,131 @@ func (earnings *EarningsApi) UpdateEarningFromUser(c *fiber.Ctx) error {
 
 	return err
 }
+
+func (earnings *EarningsApi) GetProposedEarnings(c *fiber.Ctx) error {
+	var err error
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	httpStatusCode, earningsProposed, err := earnings.ApiLogic.
+		ApiProposedEarnings(c.Query("symbol"), userId.String())
+
+	if httpStatusCode == 400 {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    400,
+		})
+	}
+
+	if httpStatusCode == 404 {
+		return c.Status(404).JSON(&fiber.Map{
+			"success": false,
+			"message": err.Error(),
+			"code":    404,
+		})
+	}
+
+	if httpStatusCode == 500 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	}
+
+	earningsApiReturn := presenter.ConvertArrayEarningToApiReturn(earningsProposed)
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"earning": earningsApiReturn,
+		"message": "Proposed earnings returned successfully",
+	})
+
+	return err
+}
+
+func (earnings *EarningsApi) CreateEarningsFromEvents(c *fiber.Ctx) error {
+	var err error
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	var eventBody presenter.EarningEventBody
+	if err := c.BodyParser(&eventBody); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	httpStatusCode, earningsCreated, err := earnings.ApiLogic.
+		ApiCreateEarningsFromEvents(eventBody.Symbol, userId.String())
+
+	if httpStatusCode == 400 {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    400,
+		})
+	}
+
+	if httpStatusCode == 404 {
+		return c.Status(404).JSON(&fiber.Map{
+			"success": false,
+			"message": err.Error(),
+			"code":    404,
+		})
+	}
+
+	if httpStatusCode == 500 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	}
+
+	earningsApiReturn := presenter.ConvertArrayEarningToApiReturn(earningsCreated)
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"earning": earningsApiReturn,
+		"message": "Earnings registered successfully",
+	})
+
+	return err
+}
+
+func (earnings *EarningsApi) GetIncomeProjection(c *fiber.Ctx) error {
+	var err error
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	projection, err := earnings.ApplicationLogic.IncomeProjectionApp.
+		ProjectIncome(userId.String(), time.Now())
+	if err != nil {
+		return c.Status(500).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success":    true,
+		"projection": presenter.ConvertIncomeProjectionToApiReturn(projection),
+		"message":    "Income projection returned successfully",
+	})
+
+	return err
+}
@@ -751,3 +751,58 @@ func TestApiDeleteEarning(t *testing.T) {
 		// }
 	}
 }
+
+func TestApiGetIncomeProjection(t *testing.T) {
+	type body struct {
+		Success    bool                                 `json:"success"`
+		Message    string                               `json:"message"`
+		Error      string                               `json:"error"`
+		Code       int                                  `json:"code"`
+		Projection *presenter.IncomeProjectionApiReturn `json:"projection"`
+	}
+
+	// Mock UseCases function (Earnings Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	// Declare Earnings Application Logic
+	earnings := EarningsApi{
+		ApplicationLogic: *usecases,
+		ApiLogic:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Get("/earnings/projection", earnings.GetIncomeProjection)
+
+	jsonResponse := body{}
+	resp, _ := MockHttpRequest(app, "GET", "/api/earnings/projection",
+		"application/json", "ValidIdTokenWithoutPrivilegedUser", nil)
+
+	respBody, _ := ioutil.ReadAll(resp.Body)
+
+	json.Unmarshal(respBody, &jsonResponse)
+	jsonResponse.Code = resp.StatusCode
+
+	assert.Equal(t, 200, jsonResponse.Code)
+	assert.Equal(t, true, jsonResponse.Success)
+	assert.Equal(t, "Income projection returned successfully",
+		jsonResponse.Message)
+	assert.Equal(t, entity.IncomeProjectionMonths,
+		len(jsonResponse.Projection.Months))
+}
@@ -0,0 +1,82 @@
+package fiberHandlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"stockfyApi/api/presenter"
+	"stockfyApi/entity"
+	portfolioexport "stockfyApi/portfolioExport"
+	"stockfyApi/usecases"
+	"stockfyApi/usecases/logicApi"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ExportApi struct {
+	ApplicationLogic usecases.Applications
+	LogicApi         logicApi.UseCases
+}
+
+// ExportPortfolio returns a file with all the assets, orders, earnings and
+// positions of the user, in the JSON, CSV or XLSX format. The CSV format is a
+// ZIP file with a CSV file for each entity. The file is streamed while it is
+// written, so it is never kept whole in memory.
+func (export *ExportApi) ExportPortfolio(c *fiber.Ctx) error {
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	format := strings.ToLower(c.Query("format"))
+	if format == "" {
+		format = entity.ExportJson
+	}
+
+	httpStatusCode, portfolio, err := export.LogicApi.ApiExportPortfolio(
+		format, userId.String())
+	if httpStatusCode != 200 {
+		message := entity.ErrMessageApiRequest.Error()
+		if httpStatusCode == 500 {
+			message = entity.ErrMessageApiInternalError.Error()
+		}
+
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": message,
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	}
+
+	var writeExport func(w *bufio.Writer) error
+	switch format {
+	case entity.ExportCsv:
+		c.Attachment("portfolio.zip")
+		writeExport = func(w *bufio.Writer) error {
+			return portfolioexport.WriteCsv(w, portfolio)
+		}
+	case entity.ExportXlsx:
+		c.Attachment("portfolio.xlsx")
+		c.Set(fiber.HeaderContentType,
+			"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		writeExport = func(w *bufio.Writer) error {
+			return portfolioexport.WriteXlsx(w, portfolio)
+		}
+	default:
+		c.Attachment("portfolio.json")
+		writeExport = func(w *bufio.Writer) error {
+			return json.NewEncoder(w).Encode(
+				presenter.ConvertPortfolioExportToApiReturn(*portfolio))
+		}
+	}
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := writeExport(w); err != nil {
+			fmt.Println("fiberHandlers.ExportPortfolio: ", err)
+		}
+		w.Flush()
+	})
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package fiberHandlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"stockfyApi/api/middleware"
+	"stockfyApi/api/presenter"
+	"stockfyApi/entity"
+	"stockfyApi/spreadsheet"
+	"stockfyApi/usecases"
+	"stockfyApi/usecases/logicApi"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiExportPortfolio(t *testing.T) {
+	type body struct {
+		Success bool   `json:"success"`
+		Message string `json:"message"`
+		Error   string `json:"error"`
+		Code    int    `json:"code"`
+	}
+
+	type test struct {
+		idToken      string
+		contentType  string
+		path         string
+		expectedResp body
+	}
+
+	tests := []test{
+		{
+			idToken:     "ValidIdTokenWithoutEmailVerification",
+			contentType: "application/json",
+			expectedResp: body{
+				Code:    401,
+				Success: false,
+				Message: entity.ErrMessageApiAuthentication.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			path:        "format=pdf",
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidExportFormat.Error(),
+			},
+		},
+	}
+
+	dateFormatted := entity.StringToTime("2021-10-01")
+	expectedExport := presenter.PortfolioExport{
+		Assets: []presenter.AssetApiReturn{
+			{
+				Id:         "TestID",
+				Preference: "TestPref",
+				Fullname:   "Test Name",
+				Symbol:     "TEST3",
+				Sector: &presenter.Sector{
+					Id:   "TestSectorID",
+					Name: "Test Sector",
+				},
+				AssetType: &presenter.AssetType{
+					Id:      "TestAssetTypeID",
+					Type:    "STOCK",
+					Name:    "Test Stocks",
+					Country: "BR",
+				},
+			},
+		},
+		Orders: []presenter.OrderApiReturn{
+			{
+				Id:        "TestOrderID",
+				Quantity:  20,
+				Price:     29.29,
+				Currency:  "BRL",
+				OrderType: "buy",
+				Date:      dateFormatted,
+				Brokerage: &presenter.Brokerage{
+					Id:      "TestBrokerageID",
+					Name:    "Test Brokerage",
+					Country: "BR",
+				},
+				Account: &presenter.BrokerageAccount{
+					Id:       "TestAccountID",
+					Nickname: "Test Brokerage",
+				},
+				Asset: &presenter.AssetApiReturn{
+					Id:     "TestID",
+					Symbol: "TEST3",
+				},
+			},
+		},
+		Earnings: []presenter.EarningsApiReturn{
+			{
+				Id:       "TestEarningID1",
+				Type:     "Dividendos",
+				Earning:  5,
+				Currency: "BRL",
+				Date:     &dateFormatted,
+				Asset: &presenter.AssetApiReturn{
+					Id:     "TestID",
+					Symbol: "TEST3",
+				},
+			},
+		},
+		Positions: []presenter.AccountPositions{
+			{
+				Account: presenter.BrokerageAccount{
+					Id:       "TestAccountID",
+					Nickname: "Test BR 1",
+					Brokerage: &presenter.Brokerage{
+						Id:   "TestBrokerageID3",
+						Name: "Test BR 1",
+					},
+				},
+				Positions: []presenter.AccountPosition{
+					{
+						Symbol:   "TEST3",
+						Currency: "BRL",
+						Quantity: 20,
+						Invested: 450.5,
+					},
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Export Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	// Declare Export Application Logic
+	export := ExportApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Get("/export", export.ExportPortfolio)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "GET", "/api/export?"+testCase.path,
+			testCase.contentType, testCase.idToken, nil)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+
+	// JSON export, the default format
+	resp, _ := MockHttpRequest(app, "GET", "/api/export", "application/json",
+		"ValidIdTokenWithoutPrivilegedUser", nil)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, `attachment; filename="portfolio.json"`,
+		resp.Header.Get("Content-Disposition"))
+
+	exportResponse := presenter.PortfolioExport{}
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	assert.Nil(t, json.Unmarshal(respBody, &exportResponse))
+	exportResponse.Orders[0].Date = exportResponse.Orders[0].Date.In(time.UTC)
+	earningDate := exportResponse.Earnings[0].Date.In(time.UTC)
+	exportResponse.Earnings[0].Date = &earningDate
+	assert.Equal(t, expectedExport, exportResponse)
+
+	// CSV export, a ZIP file with a CSV file for each entity
+	resp, _ = MockHttpRequest(app, "GET", "/api/export?format=CSV",
+		"application/json", "ValidIdTokenWithoutPrivilegedUser", nil)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "application/zip", resp.Header.Get("Content-Type"))
+
+	respBody, _ = ioutil.ReadAll(resp.Body)
+	archive, err := zip.NewReader(bytes.NewReader(respBody), int64(len(respBody)))
+	assert.Nil(t, err)
+
+	var files []string
+	for _, file := range archive.File {
+		files = append(files, file.Name)
+	}
+	assert.Equal(t, []string{"assets.csv", "orders.csv", "earnings.csv",
+		"positions.csv"}, files)
+
+	// XLSX export, with a sheet for each entity
+	resp, _ = MockHttpRequest(app, "GET", "/api/export?format=xlsx",
+		"application/json", "ValidIdTokenWithoutPrivilegedUser", nil)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, `attachment; filename="portfolio.xlsx"`,
+		resp.Header.Get("Content-Disposition"))
+
+	respBody, _ = ioutil.ReadAll(resp.Body)
+	sheets, err := spreadsheet.ReadXlsx(respBody)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(sheets))
+	assert.Equal(t, []string{"2021-10-01", "TEST3", "buy", "20", "29.29", "BRL",
+		"0", "0", "", "Test Brokerage", "Test Brokerage"}, sheets[1].Rows[1])
+}
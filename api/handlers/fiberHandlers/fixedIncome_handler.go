@@ -0,0 +1,150 @@
+package fiberHandlers
+
+import (
+	"reflect"
+	"stockfyApi/api/presenter"
+	"stockfyApi/entity"
+	"stockfyApi/usecases"
+	"stockfyApi/usecases/logicApi"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type FixedIncomeApi struct {
+	ApplicationLogic usecases.Applications
+	LogicApi         logicApi.UseCases
+}
+
+func (fixedIncome *FixedIncomeApi) CreateFixedIncome(c *fiber.Ctx) error {
+
+	var fixedIncomeInsert presenter.FixedIncomeBody
+	var err error
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	// Verify if this is a Admin user. If not, this user is not authorized to
+	// create a fixed income asset.
+	searchedUser, _ := fixedIncome.ApplicationLogic.UserApp.SearchUser(
+		userId.String())
+	if searchedUser.Type != "admin" {
+		return c.Status(403).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiAuthorization.Error(),
+			"error":   entity.ErrInvalidUserAdminPrivilege.Error(),
+			"code":    403,
+		})
+	}
+
+	if err := c.BodyParser(&fixedIncomeInsert); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	// Verify if this Asset is already in our database
+	condAssetExist := "symbol='" + fixedIncomeInsert.Symbol + "'"
+	assetExist := fixedIncome.ApplicationLogic.DbVerificationApp.RowValidation(
+		"assets", condAssetExist)
+	if assetExist {
+		return c.Status(403).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiAuthorization.Error(),
+			"error":   entity.ErrInvalidAssetSymbolExist.Error(),
+			"code":    403,
+		})
+	}
+
+	statusCode, fixedIncomeCreated, err := fixedIncome.LogicApi.
+		ApiCreateFixedIncome(fixedIncomeInsert.Symbol, fixedIncomeInsert.Fullname,
+			fixedIncomeInsert.Category, fixedIncomeInsert.Issuer,
+			fixedIncomeInsert.Indexer, fixedIncomeInsert.Rate,
+			fixedIncomeInsert.Maturity)
+
+	if statusCode == 400 {
+		return c.Status(statusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    statusCode,
+		})
+	} else if statusCode == 500 {
+		return c.Status(statusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    statusCode,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success":     true,
+		"fixedIncome": presenter.ConvertFixedIncomeToApiReturn(fixedIncomeCreated),
+		"message":     "Fixed income creation was sucessful",
+	})
+
+	return err
+}
+
+func (fixedIncome *FixedIncomeApi) CreateIndexSeries(c *fiber.Ctx) error {
+
+	var seriesInsert []presenter.IndexSeriesBody
+	var err error
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	// Verify if this is a Admin user. If not, this user is not authorized to
+	// register the index series.
+	searchedUser, _ := fixedIncome.ApplicationLogic.UserApp.SearchUser(
+		userId.String())
+	if searchedUser.Type != "admin" {
+		return c.Status(403).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiAuthorization.Error(),
+			"error":   entity.ErrInvalidUserAdminPrivilege.Error(),
+			"code":    403,
+		})
+	}
+
+	if err := c.BodyParser(&seriesInsert); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	seriesCreated, err := fixedIncome.ApplicationLogic.FixedIncomeApp.
+		CreateIndexSeries(presenter.ConvertIndexSeriesBodyToEntity(seriesInsert))
+	if err == entity.ErrInvalidFixedIncomeIndexer ||
+		err == entity.ErrInvalidFixedIncomeBlank {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    400,
+		})
+	}
+
+	if err != nil {
+		return c.Status(500).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success":     true,
+		"indexSeries": presenter.ConvertArrayIndexSeriesToApiReturn(seriesCreated),
+		"message":     "Index series registered successfully",
+	})
+
+	return err
+}
@@ -0,0 +1,297 @@
+package fiberHandlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"stockfyApi/api/middleware"
+	"stockfyApi/api/presenter"
+	"stockfyApi/entity"
+	"stockfyApi/usecases"
+	"stockfyApi/usecases/logicApi"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiCreateFixedIncome(t *testing.T) {
+
+	type body struct {
+		Success     bool                            `json:"success"`
+		Message     string                          `json:"message"`
+		Error       string                          `json:"error"`
+		Code        int                             `json:"code"`
+		FixedIncome *presenter.FixedIncomeApiReturn `json:"fixedIncome"`
+	}
+
+	type test struct {
+		idToken      string
+		contentType  string
+		bodyRequest  presenter.FixedIncomeBody
+		expectedResp body
+	}
+
+	maturity := entity.StringToTime("2026-01-05")
+
+	validBody := presenter.FixedIncomeBody{
+		Symbol:   "CDB_INTER_2026",
+		Fullname: "CDB Banco Inter 2026",
+		Category: "CDB",
+		Issuer:   "Banco Inter",
+		Indexer:  "CDI",
+		Rate:     110,
+		Maturity: "2026-01-05",
+	}
+
+	invalidIndexerBody := validBody
+	invalidIndexerBody.Indexer = "SELIC"
+
+	existentSymbolBody := validBody
+	existentSymbolBody.Symbol = "SYMBOL_EXIST"
+
+	errorRepositoryBody := validBody
+	errorRepositoryBody.Symbol = "ERROR_ASSET_REPO"
+
+	tests := []test{
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyRequest: validBody,
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiAuthorization.Error(),
+				Error:   entity.ErrInvalidUserAdminPrivilege.Error(),
+				Code:    403,
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			contentType: "application/pdf",
+			bodyRequest: validBody,
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidApiBody.Error(),
+				Code:    400,
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			contentType: "application/json",
+			bodyRequest: existentSymbolBody,
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiAuthorization.Error(),
+				Error:   entity.ErrInvalidAssetSymbolExist.Error(),
+				Code:    403,
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			contentType: "application/json",
+			bodyRequest: invalidIndexerBody,
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidFixedIncomeIndexer.Error(),
+				Code:    400,
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			contentType: "application/json",
+			bodyRequest: errorRepositoryBody,
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiInternalError.Error(),
+				Error:   errors.New("Unknown asset repository error").Error(),
+				Code:    500,
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			contentType: "application/json",
+			bodyRequest: validBody,
+			expectedResp: body{
+				Success: true,
+				Message: "Fixed income creation was sucessful",
+				Code:    200,
+				FixedIncome: &presenter.FixedIncomeApiReturn{
+					Id:       "FixedIncomeID",
+					Category: "CDB",
+					Issuer:   "Banco Inter",
+					Indexer:  "CDI",
+					Rate:     110,
+					Maturity: &maturity,
+					Asset: &presenter.AssetApiReturn{
+						Id:       "TestID",
+						Symbol:   "CDB_INTER_2026",
+						Fullname: "CDB Banco Inter 2026",
+					},
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Fixed Income Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	// Declare Fixed Income Application Logic
+	fixedIncome := FixedIncomeApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Post("/fixed-income", fixedIncome.CreateFixedIncome)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "POST", "/api/fixed-income",
+			testCase.contentType, testCase.idToken, testCase.bodyRequest)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
+
+func TestApiCreateIndexSeries(t *testing.T) {
+
+	type body struct {
+		Success     bool                             `json:"success"`
+		Message     string                           `json:"message"`
+		Error       string                           `json:"error"`
+		Code        int                              `json:"code"`
+		IndexSeries []presenter.IndexSeriesApiReturn `json:"indexSeries"`
+	}
+
+	type test struct {
+		idToken      string
+		contentType  string
+		bodyRequest  []presenter.IndexSeriesBody
+		expectedResp body
+	}
+
+	tests := []test{
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyRequest: []presenter.IndexSeriesBody{
+				{Indexer: "CDI", Date: "2021-10-04", Value: 6.15},
+			},
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiAuthorization.Error(),
+				Error:   entity.ErrInvalidUserAdminPrivilege.Error(),
+				Code:    403,
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			contentType: "application/json",
+			bodyRequest: []presenter.IndexSeriesBody{
+				{Indexer: "SELIC", Date: "2021-10-04", Value: 6.15},
+			},
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidFixedIncomeIndexer.Error(),
+				Code:    400,
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			contentType: "application/json",
+			bodyRequest: []presenter.IndexSeriesBody{
+				{Indexer: "CDI", Date: "2021-10-04", Value: 6.15},
+				{Indexer: "IPCA", Date: "2021-10-01", Value: 1.25},
+			},
+			expectedResp: body{
+				Success: true,
+				Message: "Index series registered successfully",
+				Code:    200,
+				IndexSeries: []presenter.IndexSeriesApiReturn{
+					{
+						Indexer: "CDI",
+						Date: time.Date(2021, time.October, 4, 0, 0, 0, 0,
+							time.UTC),
+						Value: 6.15,
+					},
+					{
+						Indexer: "IPCA",
+						Date: time.Date(2021, time.October, 1, 0, 0, 0, 0,
+							time.UTC),
+						Value: 1.25,
+					},
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Fixed Income Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	// Declare Fixed Income Application Logic
+	fixedIncome := FixedIncomeApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Post("/index-series", fixedIncome.CreateIndexSeries)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "POST", "/api/index-series",
+			testCase.contentType, testCase.idToken, testCase.bodyRequest)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
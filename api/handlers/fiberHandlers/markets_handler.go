@@ -0,0 +1,25 @@
+package fiberHandlers
+
+import (
+	"stockfyApi/api/presenter"
+	"stockfyApi/usecases"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type MarketApi struct {
+	ApplicationLogic usecases.Applications
+}
+
+func (market *MarketApi) GetMarkets(c *fiber.Ctx) error {
+
+	markets := market.ApplicationLogic.MarketApp.SearchMarkets()
+
+	err := c.JSON(&fiber.Map{
+		"success": true,
+		"markets": presenter.ConvertArrayMarketToApiReturn(markets),
+		"message": "Markets returned successfully",
+	})
+
+	return err
+}
@@ -0,0 +1,117 @@
+package fiberHandlers
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"stockfyApi/api/middleware"
+	"stockfyApi/api/presenter"
+	"stockfyApi/entity"
+	"stockfyApi/usecases"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiGetMarkets(t *testing.T) {
+	type body struct {
+		Success bool               `json:"success"`
+		Message string             `json:"message"`
+		Error   string             `json:"error"`
+		Code    int                `json:"code"`
+		Markets []presenter.Market `json:"markets"`
+	}
+
+	type test struct {
+		idToken      string
+		contentType  string
+		expectedResp body
+	}
+
+	tests := []test{
+		{
+			idToken:     "ValidIdTokenWithoutEmailVerification",
+			contentType: "application/json",
+			expectedResp: body{
+				Code:    401,
+				Success: false,
+				Message: entity.ErrMessageApiAuthentication.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			expectedResp: body{
+				Success: true,
+				Message: "Markets returned successfully",
+				Code:    200,
+				Markets: []presenter.Market{
+					{
+						Country:          "BR",
+						Name:             "Brasil",
+						Currencies:       []string{"BRL"},
+						SymbolSuffix:     ".SA",
+						QuantityDecimals: 0,
+						AssetTypes: []string{"STOCK", "ETF", "FII",
+							"FIXED_INCOME", "OPTION"},
+					},
+					{
+						Country:          "US",
+						Name:             "Estados Unidos",
+						Currencies:       []string{"USD"},
+						QuantityDecimals: -1,
+						AssetTypes:       []string{"STOCK", "ETF", "REIT"},
+					},
+					{
+						Country:          "CRYPTO",
+						Name:             "Criptomoedas",
+						Currencies:       []string{"BRL", "USD"},
+						QuantityDecimals: 8,
+						AssetTypes:       []string{"CRYPTO"},
+					},
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Market Application Logic)
+	usecases := usecases.NewMockApplications()
+
+	// Declare Market Application Logic
+	market := MarketApi{
+		ApplicationLogic: *usecases,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Get("/markets", market.GetMarkets)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "GET", "/api/markets",
+			testCase.contentType, testCase.idToken, nil)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
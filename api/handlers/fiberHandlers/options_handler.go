@@ -0,0 +1,153 @@
+package fiberHandlers
+
+import (
+	"reflect"
+	"stockfyApi/api/presenter"
+	"stockfyApi/entity"
+	"stockfyApi/usecases"
+	"stockfyApi/usecases/logicApi"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type OptionApi struct {
+	ApplicationLogic usecases.Applications
+	LogicApi         logicApi.UseCases
+}
+
+func (option *OptionApi) CreateOption(c *fiber.Ctx) error {
+
+	var optionInsert presenter.OptionBody
+	var err error
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	// Verify if this is a Admin user. If not, this user is not authorized to
+	// create an option, since the options are shared by every user.
+	searchedUser, _ := option.ApplicationLogic.UserApp.SearchUser(
+		userId.String())
+	if searchedUser.Type != "admin" {
+		return c.Status(403).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiAuthorization.Error(),
+			"error":   entity.ErrInvalidUserAdminPrivilege.Error(),
+			"code":    403,
+		})
+	}
+
+	if err := c.BodyParser(&optionInsert); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	// Verify if this Asset is already in our database
+	condAssetExist := "symbol='" + optionInsert.Symbol + "'"
+	assetExist := option.ApplicationLogic.DbVerificationApp.RowValidation(
+		"assets", condAssetExist)
+	if assetExist {
+		return c.Status(403).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiAuthorization.Error(),
+			"error":   entity.ErrInvalidAssetSymbolExist.Error(),
+			"code":    403,
+		})
+	}
+
+	statusCode, optionCreated, err := option.LogicApi.ApiCreateOption(
+		optionInsert.Symbol, optionInsert.Underlying, optionInsert.OptionType,
+		optionInsert.Strike, optionInsert.Expiry)
+
+	if statusCode == 400 {
+		return c.Status(statusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    statusCode,
+		})
+	} else if statusCode == 500 {
+		return c.Status(statusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    statusCode,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"option":  presenter.ConvertOptionToApiReturn(optionCreated),
+		"message": "Option creation was sucessful",
+	})
+
+	return err
+}
+
+func (option *OptionApi) ExpireOptions(c *fiber.Ctx) error {
+
+	var expiryBody presenter.OptionExpiryBody
+	var err error
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	// Verify if this is a Admin user. If not, this user is not authorized to
+	// settle the expired options.
+	searchedUser, _ := option.ApplicationLogic.UserApp.SearchUser(
+		userId.String())
+	if searchedUser.Type != "admin" {
+		return c.Status(403).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiAuthorization.Error(),
+			"error":   entity.ErrInvalidUserAdminPrivilege.Error(),
+			"code":    403,
+		})
+	}
+
+	if err := c.BodyParser(&expiryBody); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	// Without a date the options expired until today are settled
+	date := time.Now()
+	if expiryBody.Date != "" {
+		date = entity.StringToTime(expiryBody.Date)
+	}
+
+	statusCode, ordersCreated, _, err := option.LogicApi.ApiExpireOptions(
+		date)
+
+	if statusCode == 400 {
+		return c.Status(statusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    statusCode,
+		})
+	} else if statusCode == 500 {
+		return c.Status(statusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    statusCode,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"orders":  presenter.ConvertOrderToApiReturn(ordersCreated),
+		"message": "Expired options settled successfully",
+	})
+
+	return err
+}
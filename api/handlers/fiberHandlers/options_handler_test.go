@@ -0,0 +1,301 @@
+package fiberHandlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"stockfyApi/api/middleware"
+	"stockfyApi/api/presenter"
+	"stockfyApi/entity"
+	"stockfyApi/usecases"
+	"stockfyApi/usecases/logicApi"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiCreateOption(t *testing.T) {
+
+	type body struct {
+		Success bool                       `json:"success"`
+		Message string                     `json:"message"`
+		Error   string                     `json:"error"`
+		Code    int                        `json:"code"`
+		Option  *presenter.OptionApiReturn `json:"option"`
+	}
+
+	type test struct {
+		idToken      string
+		contentType  string
+		bodyRequest  presenter.OptionBody
+		expectedResp body
+	}
+
+	expiry := entity.StringToTime("2021-12-17")
+
+	validBody := presenter.OptionBody{
+		Symbol:     "PETRL300",
+		Underlying: "PETR4",
+		OptionType: "CALL",
+		Strike:     30,
+		Expiry:     "2021-12-17",
+	}
+
+	invalidTypeBody := validBody
+	invalidTypeBody.OptionType = "STRADDLE"
+
+	unknownUnderlyingBody := validBody
+	unknownUnderlyingBody.Underlying = "UNKNOWN_SYMBOL"
+
+	existentSymbolBody := validBody
+	existentSymbolBody.Symbol = "SYMBOL_EXIST"
+
+	errorRepositoryBody := validBody
+	errorRepositoryBody.Symbol = "ERROR_ASSET_REPO"
+
+	tests := []test{
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyRequest: validBody,
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiAuthorization.Error(),
+				Error:   entity.ErrInvalidUserAdminPrivilege.Error(),
+				Code:    403,
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			contentType: "application/pdf",
+			bodyRequest: validBody,
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidApiBody.Error(),
+				Code:    400,
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			contentType: "application/json",
+			bodyRequest: existentSymbolBody,
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiAuthorization.Error(),
+				Error:   entity.ErrInvalidAssetSymbolExist.Error(),
+				Code:    403,
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			contentType: "application/json",
+			bodyRequest: invalidTypeBody,
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidOptionType.Error(),
+				Code:    400,
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			contentType: "application/json",
+			bodyRequest: unknownUnderlyingBody,
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidOptionUnderlying.Error(),
+				Code:    400,
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			contentType: "application/json",
+			bodyRequest: errorRepositoryBody,
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiInternalError.Error(),
+				Error:   errors.New("Unknown asset repository error").Error(),
+				Code:    500,
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			contentType: "application/json",
+			bodyRequest: validBody,
+			expectedResp: body{
+				Success: true,
+				Message: "Option creation was sucessful",
+				Code:    200,
+				Option: &presenter.OptionApiReturn{
+					Id:         "OptionID",
+					OptionType: "CALL",
+					Strike:     30,
+					Expiry:     &expiry,
+					Asset: &presenter.AssetApiReturn{
+						Id:       "TestID",
+						Symbol:   "PETRL300",
+						Fullname: "PETR4 CALL 30 2021-12-17",
+					},
+					Underlying: &presenter.AssetApiReturn{
+						Id:     "TestUnderlyingID",
+						Symbol: "PETR4",
+					},
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Option Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	// Declare Option Application Logic
+	option := OptionApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Post("/option", option.CreateOption)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "POST", "/api/option",
+			testCase.contentType, testCase.idToken, testCase.bodyRequest)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
+
+func TestApiExpireOptions(t *testing.T) {
+
+	type body struct {
+		Success bool                       `json:"success"`
+		Message string                     `json:"message"`
+		Error   string                     `json:"error"`
+		Code    int                        `json:"code"`
+		Orders  []presenter.OrderApiReturn `json:"orders"`
+	}
+
+	type test struct {
+		idToken      string
+		contentType  string
+		bodyRequest  presenter.OptionExpiryBody
+		expectedResp body
+	}
+
+	date := entity.StringToTime("2021-12-17")
+
+	tests := []test{
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyRequest: presenter.OptionExpiryBody{Date: "2021-12-17"},
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiAuthorization.Error(),
+				Error:   entity.ErrInvalidUserAdminPrivilege.Error(),
+				Code:    403,
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			contentType: "application/json",
+			bodyRequest: presenter.OptionExpiryBody{Date: "17/12/2021"},
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidOptionExpiry.Error(),
+				Code:    400,
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			contentType: "application/json",
+			bodyRequest: presenter.OptionExpiryBody{Date: "2021-12-17"},
+			expectedResp: body{
+				Success: true,
+				Message: "Expired options settled successfully",
+				Code:    200,
+				Orders: []presenter.OrderApiReturn{
+					{
+						Id:        "TestOrderID",
+						Quantity:  -100,
+						Currency:  "BRL",
+						OrderType: "sell",
+						Date:      date,
+						Brokerage: &presenter.Brokerage{Id: "TestBrokerageID"},
+					},
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Option Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	// Declare Option Application Logic
+	option := OptionApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Post("/options/expire", option.ExpireOptions)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "POST", "/api/options/expire",
+			testCase.contentType, testCase.idToken, testCase.bodyRequest)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	externalapi "stockfyApi/externalApi"
 	"stockfyApi/usecases"
 	"stockfyApi/usecases/logicApi"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	_ "github.com/lib/pq"
@@ -36,7 +37,8 @@ func (order *OrderApi) CreateUserOrder(c *fiber.Ctx) error {
 	httpStatusCode, orderCreated, err := order.LogicApi.ApiCreateOrder(
 		orderInserted.Symbol, orderInserted.Country, orderInserted.OrderType,
 		orderInserted.Quantity, orderInserted.Price, orderInserted.Currency,
-		orderInserted.Brokerage, orderInserted.Date, userId.String())
+		orderInserted.Brokerage, orderInserted.AccountId, orderInserted.Date,
+		userId.String())
 
 	if httpStatusCode == 400 {
 		return c.Status(httpStatusCode).JSON(&fiber.Map{
@@ -76,6 +78,275 @@ func (order *OrderApi) CreateUserOrder(c *fiber.Ctx) error {
 
 }
 
+// ImportOrders creates the orders of a CSV file sent in the body. Nothing is
+// created when a row has problems, and the dryRun query only validates the
+// rows.
+func (order *OrderApi) ImportOrders(c *fiber.Ctx) error {
+
+	var importBody presenter.OrderImportBody
+	if err := c.BodyParser(&importBody); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	dryRun := c.Query("dryRun") == "true"
+
+	httpStatusCode, rows, ordersCreated, err := order.LogicApi.ApiImportOrders(
+		strings.NewReader(importBody.File), importBody.Mapping,
+		importBody.Delimiter, dryRun, userId.String())
+
+	if httpStatusCode == 400 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"rows":    presenter.ConvertOrderImportRowToApiReturn(rows),
+			"code":    400,
+		})
+	}
+
+	if httpStatusCode == 404 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    404,
+		})
+	}
+
+	if httpStatusCode == 500 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	}
+
+	message := "Orders imported successfully"
+	if dryRun {
+		message = "Orders validated successfully"
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"rows":    presenter.ConvertOrderImportRowToApiReturn(rows),
+		"orders":  presenter.ConvertOrderToApiReturn(ordersCreated),
+		"message": message,
+	})
+
+	return err
+}
+
+// ImportTradeNotes reads the orders of the brazilian trade notes sent in the
+// body as the text extracted of their PDF. The orders are only created when
+// the user confirms them, after reviewing the preview of the notes.
+func (order *OrderApi) ImportTradeNotes(c *fiber.Ctx) error {
+
+	var noteBody presenter.TradeNoteBody
+	if err := c.BodyParser(&noteBody); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	httpStatusCode, notes, rows, ordersCreated, err := order.LogicApi.
+		ApiImportTradeNotes(noteBody.Text, noteBody.Brokerage, noteBody.Symbols,
+			noteBody.Confirm, userId.String())
+
+	if httpStatusCode == 400 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"notes":   presenter.ConvertTradeNoteToApiReturn(notes),
+			"rows":    presenter.ConvertOrderImportRowToApiReturn(rows),
+			"code":    400,
+		})
+	}
+
+	if httpStatusCode == 404 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    404,
+		})
+	}
+
+	if httpStatusCode == 500 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	}
+
+	message := "Trade notes read successfully"
+	if noteBody.Confirm {
+		message = "Trade notes imported successfully"
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"notes":   presenter.ConvertTradeNoteToApiReturn(notes),
+		"rows":    presenter.ConvertOrderImportRowToApiReturn(rows),
+		"orders":  presenter.ConvertOrderToApiReturn(ordersCreated),
+		"message": message,
+	})
+
+	return err
+}
+
+// ImportB3Report imports a report of the investor area of the B3 sent in the
+// body: the trades, the movements or the custody position. Nothing is created
+// when a row has problems, and the dryRun query only validates the rows. The
+// custody position returns its reconciliation with the orders until the date
+// informed in the body.
+func (order *OrderApi) ImportB3Report(c *fiber.Ctx) error {
+
+	var reportBody presenter.B3ReportBody
+	if err := c.BodyParser(&reportBody); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	dryRun := c.Query("dryRun") == "true"
+
+	httpStatusCode, report, err := order.LogicApi.ApiImportB3Report(
+		reportBody.File, reportBody.Brokerages, reportBody.Date, dryRun,
+		userId.String())
+
+	if httpStatusCode == 400 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"report":  presenter.ConvertB3ReportToApiReturn(report),
+			"code":    400,
+		})
+	}
+
+	if httpStatusCode == 404 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    404,
+		})
+	}
+
+	if httpStatusCode == 500 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	}
+
+	message := "B3 report imported successfully"
+	if report.Type == entity.B3PositionReport {
+		message = "B3 custody reconciled successfully"
+	} else if dryRun {
+		message = "B3 report validated successfully"
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"report":  presenter.ConvertB3ReportToApiReturn(report),
+		"message": message,
+	})
+
+	return err
+}
+
+func (order *OrderApi) ImportBrokerStatement(c *fiber.Ctx) error {
+
+	var statementBody presenter.BrokerStatementBody
+	if err := c.BodyParser(&statementBody); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	dryRun := c.Query("dryRun") == "true"
+
+	httpStatusCode, statement, ordersCreated, earningsCreated, err :=
+		order.LogicApi.ApiImportBrokerStatement(statementBody.File,
+			statementBody.Brokerage, dryRun, userId.String())
+
+	if httpStatusCode == 400 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success":   false,
+			"message":   entity.ErrMessageApiRequest.Error(),
+			"error":     err.Error(),
+			"statement": presenter.ConvertBrokerStatementToApiReturn(statement),
+			"code":      400,
+		})
+	}
+
+	if httpStatusCode == 404 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    404,
+		})
+	}
+
+	if httpStatusCode == 500 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	}
+
+	message := "Broker statement imported successfully"
+	if dryRun {
+		message = "Broker statement validated successfully"
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success":   true,
+		"statement": presenter.ConvertBrokerStatementToApiReturn(statement),
+		"orders":    presenter.ConvertOrderToApiReturn(ordersCreated),
+		"earnings":  presenter.ConvertArrayEarningToApiReturn(earningsCreated),
+		"message":   message,
+	})
+
+	return err
+}
+
 func (order *OrderApi) GetOrdersFromAssetUser(c *fiber.Ctx) error {
 	var err error
 
@@ -176,7 +447,7 @@ func (order *OrderApi) UpdateOrderFromUser(c *fiber.Ctx) error {
 	httpStatusCode, updatedOrder, err := order.LogicApi.ApiUpdateOrdersFromUser(
 		c.Params("id"), userId.String(), orderUpdate.OrderType,
 		orderUpdate.Price, orderUpdate.Quantity, orderUpdate.Date,
-		orderUpdate.Brokerage)
+		orderUpdate.Brokerage, orderUpdate.AccountId)
 
 	if httpStatusCode == 400 {
 		return c.Status(httpStatusCode).JSON(&fiber.Map{
@@ -658,6 +658,69 @@ func TestApiCreateOrder(t *testing.T) {
 				},
 			},
 		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyReq: presenter.OrderBody{
+				Symbol:    "BTC",
+				Fullname:  "Bitcoin",
+				Brokerage: "Test Brokerage",
+				Quantity:  0.00012345,
+				Price:     341890.27,
+				OrderType: "buy",
+				Currency:  "BRL",
+				Date:      "2021-10-01",
+				Country:   "CRYPTO",
+				AssetType: "CRYPTO",
+			},
+			expectedResp: body{
+				Success: true,
+				Message: "Order registered successfully",
+				Error:   "",
+				Code:    200,
+				Orders: &presenter.OrderApiReturn{
+					Id:        "TestOrderID",
+					Quantity:  0.00012345,
+					Price:     341890.27,
+					Currency:  "BRL",
+					OrderType: "buy",
+					Date:      dateFormatted,
+					Brokerage: &presenter.Brokerage{
+						Id:      "TestBrokerageID",
+						Name:    "Test Brokerage",
+						Country: "CRYPTO",
+					},
+					Asset: &presenter.AssetApiReturn{
+						Id:       "TestID",
+						Symbol:   "BTC",
+						Fullname: "Test Name",
+					},
+				},
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyReq: presenter.OrderBody{
+				Symbol:    "BTC",
+				Fullname:  "Bitcoin",
+				Brokerage: "Test Brokerage",
+				Quantity:  0.123456789,
+				Price:     61870.44,
+				OrderType: "buy",
+				Currency:  "USD",
+				Date:      "2021-10-01",
+				Country:   "CRYPTO",
+				AssetType: "CRYPTO",
+			},
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidOrderQuantityCrypto.Error(),
+				Code:    400,
+				Orders:  nil,
+			},
+		},
 	}
 
 	// Mock UseCases function (Sector Application Logic)
@@ -1111,3 +1174,517 @@ func TestApiUpdateOrder(t *testing.T) {
 		assert.Equal(t, testCase.expectedResp, jsonResponse)
 	}
 }
+
+func TestApiImportOrders(t *testing.T) {
+
+	type body struct {
+		Success bool                       `json:"success"`
+		Message string                     `json:"message"`
+		Error   string                     `json:"error"`
+		Code    int                        `json:"code"`
+		Rows    []presenter.OrderImportRow `json:"rows"`
+	}
+
+	type test struct {
+		idToken      string
+		dryRun       string
+		bodyRequest  presenter.OrderImportBody
+		expectedResp body
+	}
+
+	header := "symbol,country,type,quantity,price,currency,brokerage,date\n"
+	tests := []test{
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			bodyRequest: presenter.OrderImportBody{
+				File: "symbol,country\nITUB4,BR\n",
+			},
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidOrderImportColumn.Error(),
+				Code:    400,
+			},
+		},
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			bodyRequest: presenter.OrderImportBody{
+				File: header +
+					"ITUB4,BR,buy,10,25.50,BRL,Clear,2021-10-05\n" +
+					"UNKNOWN_SYMBOL,BR,buy,10,25.50,BRL,UNKNOWN_BROKERAGE,2021-10-05\n",
+			},
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidOrderImportRows.Error(),
+				Code:    400,
+				Rows: []presenter.OrderImportRow{
+					{
+						Line:      2,
+						Symbol:    "ITUB4",
+						Country:   "BR",
+						OrderType: "buy",
+						Quantity:  10,
+						Price:     25.50,
+						Currency:  "BRL",
+						Brokerage: "Clear",
+						Date:      "2021-10-05",
+					},
+					{
+						Line:      3,
+						Symbol:    "UNKNOWN_SYMBOL",
+						Country:   "BR",
+						OrderType: "buy",
+						Quantity:  10,
+						Price:     25.50,
+						Currency:  "BRL",
+						Brokerage: "UNKNOWN_BROKERAGE",
+						Date:      "2021-10-05",
+						Errors: []string{entity.ErrInvalidAssetSymbol.Error(),
+							entity.ErrInvalidBrokerageNameSearch.Error()},
+					},
+				},
+			},
+		},
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			dryRun:  "true",
+			bodyRequest: presenter.OrderImportBody{
+				File: header + "NEW_SYMBOL,BR,buy,10,25.50,BRL,Clear,2021-10-05\n",
+			},
+			expectedResp: body{
+				Success: true,
+				Message: "Orders validated successfully",
+				Code:    200,
+				Rows: []presenter.OrderImportRow{
+					{
+						Line:      2,
+						Symbol:    "NEW_SYMBOL",
+						Country:   "BR",
+						OrderType: "buy",
+						Quantity:  10,
+						Price:     25.50,
+						Currency:  "BRL",
+						Brokerage: "Clear",
+						Date:      "2021-10-05",
+						NewAsset:  true,
+					},
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Order Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	orders := OrderApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Post("/orders/import", orders.ImportOrders)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "POST",
+			"/api/orders/import?dryRun="+testCase.dryRun, "application/json",
+			testCase.idToken, testCase.bodyRequest)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
+
+func TestApiImportTradeNotes(t *testing.T) {
+
+	type body struct {
+		Success bool                       `json:"success"`
+		Message string                     `json:"message"`
+		Error   string                     `json:"error"`
+		Code    int                        `json:"code"`
+		Rows    []presenter.OrderImportRow `json:"rows"`
+	}
+
+	type test struct {
+		idToken      string
+		bodyRequest  presenter.TradeNoteBody
+		expectedResp body
+	}
+
+	noteText, err := ioutil.ReadFile("../../../tradeNote/testdata/clear_note.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedRows := []presenter.OrderImportRow{
+		{
+			Line:           1,
+			Symbol:         "ITUB4",
+			Country:        "BR",
+			OrderType:      "buy",
+			Quantity:       100,
+			Price:          25.50,
+			Currency:       "BRL",
+			Brokerage:      "Clear",
+			Date:           "2021-10-05",
+			Fees:           0.82,
+			SettlementDate: "2021-10-07",
+		},
+		{
+			Line:           2,
+			Symbol:         "PETR4",
+			Country:        "BR",
+			OrderType:      "sell",
+			Quantity:       -5,
+			Price:          28.10,
+			Currency:       "BRL",
+			Brokerage:      "Clear",
+			Date:           "2021-10-05",
+			Fees:           0.05,
+			SettlementDate: "2021-10-07",
+		},
+	}
+
+	tests := []test{
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			bodyRequest: presenter.TradeNoteBody{Text: " "},
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidTradeNoteBlank.Error(),
+				Code:    400,
+			},
+		},
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			bodyRequest: presenter.TradeNoteBody{
+				Text: string(noteText),
+				Symbols: map[string]string{
+					"ITAUUNIBANCO PN N1": "ITUB4",
+					"PETROBRAS PN N2":    "PETR4",
+				},
+			},
+			expectedResp: body{
+				Success: true,
+				Message: "Trade notes read successfully",
+				Code:    200,
+				Rows:    expectedRows,
+			},
+		},
+	}
+
+	// Mock UseCases function (Order Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	orders := OrderApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Post("/orders/trade-notes", orders.ImportTradeNotes)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "POST", "/api/orders/trade-notes",
+			"application/json", testCase.idToken, testCase.bodyRequest)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
+
+func TestApiImportB3Report(t *testing.T) {
+
+	type body struct {
+		Success bool                `json:"success"`
+		Message string              `json:"message"`
+		Error   string              `json:"error"`
+		Code    int                 `json:"code"`
+		Report  *presenter.B3Report `json:"report"`
+	}
+
+	type test struct {
+		idToken      string
+		bodyRequest  presenter.B3ReportBody
+		expectedResp body
+	}
+
+	tests := []test{
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			bodyRequest: presenter.B3ReportBody{
+				File: []byte("symbol,quantity\nITUB4,10\n"),
+			},
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidB3Report.Error(),
+				Code:    400,
+			},
+		},
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			bodyRequest: presenter.B3ReportBody{
+				File: []byte("Produto;Instituição;Código de Negociação;" +
+					"Quantidade\nITUB4 - ITAU S.A.;CORRETORA TESTE;ITUB4;20\n"),
+			},
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidCustodyDate.Error(),
+				Code:    400,
+				Report: &presenter.B3Report{
+					Type: entity.B3PositionReport,
+					Positions: []presenter.B3Position{
+						{Line: 2, Symbol: "ITUB4",
+							Brokerage: "CORRETORA TESTE", Quantity: 20},
+					},
+				},
+			},
+		},
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			bodyRequest: presenter.B3ReportBody{
+				File: []byte("Produto;Instituição;Código de Negociação;" +
+					"Quantidade\nITUB4 - ITAU S.A.;CORRETORA TESTE;ITUB4;20\n" +
+					"BBDC4 - BRADESCO S.A.;CORRETORA TESTE;BBDC4;20\n"),
+				Brokerages: map[string]string{"CORRETORA TESTE": "Test BR 1"},
+				Date:       "2021-12-30",
+			},
+			expectedResp: body{
+				Success: true,
+				Message: "B3 custody reconciled successfully",
+				Code:    200,
+				Report: &presenter.B3Report{
+					Type: entity.B3PositionReport,
+					Date: "2021-12-30",
+					Positions: []presenter.B3Position{
+						{Line: 2, Symbol: "ITUB4", Brokerage: "Test BR 1",
+							Quantity: 20},
+						{Line: 3, Symbol: "BBDC4", Brokerage: "Test BR 1",
+							Quantity: 20},
+					},
+					Reconciliation: []presenter.CustodyReconciliation{
+						{
+							Symbol:          "BBDC4",
+							Brokerage:       "Test BR 1",
+							CustodyQuantity: 20,
+							OrdersQuantity:  10,
+							Difference:      10,
+							Status:          entity.CustodyQuantityMismatch,
+							Fix: &presenter.CustodyFix{
+								Action: entity.CustodyFixSplit,
+								Ratio:  2,
+								Date:   "2021-12-30",
+							},
+						},
+						{
+							Symbol:          "ITUB4",
+							Brokerage:       "Test BR 1",
+							CustodyQuantity: 20,
+							OrdersQuantity:  20,
+							Status:          entity.CustodyMatch,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Order Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	orders := OrderApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Post("/orders/b3-report", orders.ImportB3Report)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "POST", "/api/orders/b3-report",
+			"application/json", testCase.idToken, testCase.bodyRequest)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
+
+func TestApiImportBrokerStatement(t *testing.T) {
+
+	type body struct {
+		Success   bool                          `json:"success"`
+		Message   string                        `json:"message"`
+		Error     string                        `json:"error"`
+		Code      int                           `json:"code"`
+		Statement *presenter.BrokerStatement    `json:"statement"`
+		Orders    []presenter.OrderApiReturn    `json:"orders"`
+		Earnings  []presenter.EarningsApiReturn `json:"earnings"`
+	}
+
+	type test struct {
+		idToken      string
+		dryRun       bool
+		bodyRequest  presenter.BrokerStatementBody
+		expectedResp body
+	}
+
+	statementFile := []byte("Date,Activity Type,Symbol,Side,Quantity,Price," +
+		"Net Amount\n2021-10-05,FILL,TEST3,BUY,0.5,20.10,-10.05\n")
+
+	tests := []test{
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			bodyRequest: presenter.BrokerStatementBody{
+				File: []byte("symbol,quantity\nTEST3,10\n"),
+			},
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidBrokerStatement.Error(),
+				Code:    400,
+			},
+		},
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			dryRun:  true,
+			bodyRequest: presenter.BrokerStatementBody{
+				File:      statementFile,
+				Brokerage: "Test US 1",
+			},
+			expectedResp: body{
+				Success: true,
+				Message: "Broker statement validated successfully",
+				Code:    200,
+				Statement: &presenter.BrokerStatement{
+					Format:    entity.PassfolioStatement,
+					Brokerage: "Test US 1",
+					Orders: []presenter.OrderImportRow{
+						{Line: 2, Symbol: "TEST3", Country: "US",
+							OrderType: "buy", Quantity: 0.5, Price: 20.10,
+							Currency: "USD", Brokerage: "Test US 1",
+							Date: "2021-10-05"},
+					},
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Order Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	orders := OrderApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Post("/orders/broker-statement", orders.ImportBrokerStatement)
+
+	for _, testCase := range tests {
+		path := "/api/orders/broker-statement"
+		if testCase.dryRun {
+			path += "?dryRun=true"
+		}
+
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "POST", path, "application/json",
+			testCase.idToken, testCase.bodyRequest)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
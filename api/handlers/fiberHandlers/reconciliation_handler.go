@@ -0,0 +1,68 @@
+package fiberHandlers
+
+import (
+	"reflect"
+	"stockfyApi/api/presenter"
+	"stockfyApi/entity"
+	"stockfyApi/usecases"
+	"stockfyApi/usecases/logicApi"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReconciliationApi struct {
+	ApplicationLogic usecases.Applications
+	LogicApi         logicApi.UseCases
+}
+
+// ReconcileCustody compares the custody reported by a brokerage at a date,
+// informed by the user or read from a position report of the B3, with the
+// positions computed from the orders until that date. Each discrepancy comes
+// with the order or split suggested to fix it.
+func (reconciliation *ReconciliationApi) ReconcileCustody(
+	c *fiber.Ctx) error {
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	var body presenter.ReconciliationBody
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	httpStatusCode, discrepancies, err := reconciliation.LogicApi.
+		ApiReconcileCustody(body.Brokerage, body.Date,
+			presenter.ConvertCustodyPositionsToEntity(body.Positions),
+			body.File, userId.String())
+	if httpStatusCode == 400 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	} else if httpStatusCode == 500 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"discrepancies": presenter.ConvertCustodyReconciliationToApiReturn(
+			discrepancies),
+		"message": "Custody was reconciled successfully",
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
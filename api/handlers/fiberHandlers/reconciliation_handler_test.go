@@ -0,0 +1,172 @@
+package fiberHandlers
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"stockfyApi/api/middleware"
+	"stockfyApi/api/presenter"
+	"stockfyApi/entity"
+	"stockfyApi/usecases"
+	"stockfyApi/usecases/logicApi"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiReconcileCustody(t *testing.T) {
+	type body struct {
+		Success       bool                              `json:"success"`
+		Message       string                            `json:"message"`
+		Error         string                            `json:"error"`
+		Code          int                               `json:"code"`
+		Discrepancies []presenter.CustodyReconciliation `json:"discrepancies"`
+	}
+
+	type test struct {
+		idToken      string
+		contentType  string
+		bodyReq      interface{}
+		expectedResp body
+	}
+
+	positions := []presenter.CustodyPosition{
+		{Symbol: "ITUB4", Quantity: 20},
+		{Symbol: "BBDC4", Quantity: 20},
+		{Symbol: "PETR4", Quantity: 5},
+	}
+
+	tests := []test{
+		{
+			idToken:     "ValidIdTokenWithoutEmailVerification",
+			contentType: "application/json",
+			bodyReq: presenter.ReconciliationBody{
+				Brokerage: "Clear",
+				Date:      "2021-12-30",
+				Positions: positions,
+			},
+			expectedResp: body{
+				Code:    401,
+				Success: false,
+				Message: entity.ErrMessageApiAuthentication.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyReq: presenter.ReconciliationBody{
+				Brokerage: "Clear",
+				Date:      "30/12/2021",
+				Positions: positions,
+			},
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidCustodyDate.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyReq: presenter.ReconciliationBody{
+				Brokerage: "UNKNOWN_BROKERAGE",
+				Date:      "2021-12-30",
+				Positions: positions,
+			},
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidBrokerageNameSearch.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyReq: presenter.ReconciliationBody{
+				Brokerage: "Clear",
+				Date:      "2021-12-30",
+				Positions: []presenter.CustodyPosition{
+					{Symbol: "ITUB4", Quantity: -20},
+				},
+			},
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidCustodyPosition.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyReq: presenter.ReconciliationBody{
+				Brokerage: "Clear",
+				Date:      "2021-12-30",
+				Positions: positions,
+			},
+			expectedResp: body{
+				Code:    200,
+				Success: true,
+				Message: "Custody was reconciled successfully",
+				Discrepancies: []presenter.CustodyReconciliation{
+					{Symbol: "BBDC4", Brokerage: "Clear", CustodyQuantity: 20,
+						OrdersQuantity: 10, Difference: 10,
+						Status: entity.CustodyQuantityMismatch,
+						Fix: &presenter.CustodyFix{
+							Action: entity.CustodyFixSplit, Ratio: 2,
+							Date: "2021-12-30"}},
+					{Symbol: "PETR4", Brokerage: "Clear", CustodyQuantity: 5,
+						Difference: 5, Status: entity.CustodyMissingOrders,
+						Fix: &presenter.CustodyFix{
+							Action: entity.CustodyFixBuyOrder, Quantity: 5,
+							Date: "2021-12-30"}},
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Reconciliation Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	// Declare Reconciliation Application Logic
+	reconciliation := ReconciliationApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Post("/reconciliation", reconciliation.ReconcileCustody)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "POST", "/api/reconciliation",
+			testCase.contentType, testCase.idToken, testCase.bodyReq)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
@@ -0,0 +1,151 @@
+package fiberHandlers
+
+import (
+	"reflect"
+	"stockfyApi/api/presenter"
+	"stockfyApi/entity"
+	"stockfyApi/usecases"
+	"stockfyApi/usecases/logicApi"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RemittanceApi struct {
+	ApplicationLogic usecases.Applications
+	LogicApi         logicApi.UseCases
+}
+
+// CreateRemittance registers money sent from BRL, or another currency, and
+// received in a brokerage account of the user in another currency.
+func (remittance *RemittanceApi) CreateRemittance(c *fiber.Ctx) error {
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	var remittanceInsert presenter.RemittanceBody
+	if err := c.BodyParser(&remittanceInsert); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	httpStatusCode, remittanceCreated, err :=
+		remittance.LogicApi.ApiCreateRemittance(remittanceInsert.SentAmount,
+			remittanceInsert.SentCurrency, remittanceInsert.ReceivedAmount,
+			remittanceInsert.Iof, remittanceInsert.Fees,
+			remittanceInsert.CommercialRate, remittanceInsert.Date,
+			remittanceInsert.FromAccountId, remittanceInsert.ToAccountId,
+			userId.String())
+	if httpStatusCode == 400 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	} else if httpStatusCode == 500 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"remittance": presenter.ConvertRemittanceToApiReturn(
+			*remittanceCreated),
+		"message": "Remittance registered successfully",
+	})
+
+	return err
+}
+
+// GetRemittances returns the remittances of the user with the effective rate,
+// the spread and the IOF paid in each one.
+func (remittance *RemittanceApi) GetRemittances(c *fiber.Ctx) error {
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	remittances, err := remittance.ApplicationLogic.RemittanceApp.
+		SearchRemittances(userId.String())
+	if err != nil {
+		return c.Status(500).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success":     true,
+		"remittances": presenter.ConvertRemittancesToApiReturn(remittances),
+		"message":     "Remittances returned successfully",
+	})
+
+	return err
+}
+
+// DeleteRemittance deletes a remittance registered by the user.
+func (remittance *RemittanceApi) DeleteRemittance(c *fiber.Ctx) error {
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	remittanceDeleted, err := remittance.ApplicationLogic.RemittanceApp.
+		DeleteRemittance(c.Params("id"), userId.String())
+	if err == entity.ErrInvalidRemittance {
+		return c.Status(404).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    404,
+		})
+	} else if err != nil {
+		return c.Status(500).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"remittance": presenter.ConvertRemittanceToApiReturn(
+			*remittanceDeleted),
+		"message": "Remittance was deleted successfully",
+	})
+
+	return err
+}
+
+// GetBrlCostBasis returns the cost in BRL of the positions of the user bought
+// in foreign currencies, using the effective rate of the remittances as the
+// acquisition rate.
+func (remittance *RemittanceApi) GetBrlCostBasis(c *fiber.Ctx) error {
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	httpStatusCode, costBasis, err := remittance.LogicApi.ApiBrlCostBasis(
+		userId.String())
+	if httpStatusCode == 500 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success":   true,
+		"costBasis": presenter.ConvertBrlCostBasisToApiReturn(costBasis),
+		"message":   "BRL cost basis returned successfully",
+	})
+
+	return err
+}
@@ -0,0 +1,426 @@
+package fiberHandlers
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"stockfyApi/api/middleware"
+	"stockfyApi/api/presenter"
+	"stockfyApi/entity"
+	"stockfyApi/usecases"
+	"stockfyApi/usecases/logicApi"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiCreateRemittance(t *testing.T) {
+	type body struct {
+		Success    bool                  `json:"success"`
+		Message    string                `json:"message"`
+		Error      string                `json:"error"`
+		Code       int                   `json:"code"`
+		Remittance *presenter.Remittance `json:"remittance"`
+	}
+
+	type test struct {
+		idToken      string
+		contentType  string
+		bodyReq      interface{}
+		expectedResp body
+	}
+
+	tests := []test{
+		{
+			idToken:     "ValidIdTokenWithoutEmailVerification",
+			contentType: "application/json",
+			bodyReq: presenter.RemittanceBody{
+				SentAmount: 5066, ReceivedAmount: 1000, Date: "2021-10-01",
+				ToAccountId: "US_ACCOUNT"},
+			expectedResp: body{
+				Code:    401,
+				Success: false,
+				Message: entity.ErrMessageApiAuthentication.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyReq: presenter.RemittanceBody{
+				SentAmount: 5066, ReceivedAmount: 1000, Date: "01/10/2021",
+				ToAccountId: "US_ACCOUNT"},
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidRemittanceDate.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyReq: presenter.RemittanceBody{
+				SentAmount: 5066, ReceivedAmount: 1000, Date: "2021-10-01",
+				ToAccountId: "UNKNOWN_ACCOUNT"},
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidBrokerageAccount.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyReq: presenter.RemittanceBody{
+				SentAmount: 5066, SentCurrency: "USD", ReceivedAmount: 1000,
+				Date: "2021-10-01", FromAccountId: "TestAccountID",
+				ToAccountId: "US_ACCOUNT"},
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidRemittanceCurrency.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyReq: presenter.RemittanceBody{
+				SentAmount: 5066, ReceivedAmount: 1000, Iof: 5066,
+				Date: "2021-10-01", ToAccountId: "US_ACCOUNT"},
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidRemittanceTaxes.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyReq: presenter.RemittanceBody{
+				SentAmount: 5066, ReceivedAmount: 1000, Iof: 55, Fees: 11,
+				CommercialRate: 4.9, Date: "2021-10-01",
+				FromAccountId: "TestAccountID", ToAccountId: "US_ACCOUNT"},
+			expectedResp: body{
+				Code:    200,
+				Success: true,
+				Message: "Remittance registered successfully",
+				Remittance: &presenter.Remittance{
+					Id:               "TestRemittanceID",
+					SentAmount:       5066,
+					SentCurrency:     "BRL",
+					ReceivedAmount:   1000,
+					ReceivedCurrency: "USD",
+					Iof:              55,
+					Fees:             11,
+					CommercialRate:   4.9,
+					EffectiveRate:    5.066,
+					ExchangeRate:     5,
+					Spread:           2.04,
+					IofRate:          1.1,
+					Date:             "2021-10-01",
+					FromAccount: &presenter.BrokerageAccount{
+						Id:       "TestAccountID",
+						Nickname: "Test BR 1",
+						Brokerage: &presenter.Brokerage{
+							Id:      "TestBrokerageID3",
+							Name:    "Test BR 1",
+							Country: "BR",
+						},
+					},
+					ToAccount: &presenter.BrokerageAccount{
+						Id:       "US_ACCOUNT",
+						Nickname: "Test US 1",
+						Brokerage: &presenter.Brokerage{
+							Id:      "TestBrokerageID1",
+							Name:    "Test US 1",
+							Country: "US",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Remittance Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	// Declare Remittance Application Logic
+	remittance := RemittanceApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Get("/remittances", remittance.GetRemittances)
+	api.Get("/remittances/cost-basis", remittance.GetBrlCostBasis)
+	api.Post("/remittances", remittance.CreateRemittance)
+	api.Delete("/remittances/:id", remittance.DeleteRemittance)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "POST", "/api/remittances",
+			testCase.contentType, testCase.idToken, testCase.bodyReq)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
+
+func TestApiGetRemittances(t *testing.T) {
+	type body struct {
+		Success     bool                   `json:"success"`
+		Message     string                 `json:"message"`
+		Error       string                 `json:"error"`
+		Code        int                    `json:"code"`
+		Remittances []presenter.Remittance `json:"remittances"`
+	}
+
+	expectedResp := body{
+		Code:    200,
+		Success: true,
+		Message: "Remittances returned successfully",
+		Remittances: []presenter.Remittance{
+			{
+				Id:               "TestRemittanceID",
+				SentAmount:       5066,
+				SentCurrency:     "BRL",
+				ReceivedAmount:   1000,
+				ReceivedCurrency: "USD",
+				Iof:              55,
+				Fees:             11,
+				CommercialRate:   4.9,
+				EffectiveRate:    5.066,
+				ExchangeRate:     5,
+				Spread:           2.04,
+				IofRate:          1.1,
+				Date:             "2021-10-01",
+				ToAccount: &presenter.BrokerageAccount{
+					Id:       "TestAccountID2",
+					Nickname: "Test US 1",
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Remittance Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	// Declare Remittance Application Logic
+	remittance := RemittanceApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Get("/remittances", remittance.GetRemittances)
+	api.Get("/remittances/cost-basis", remittance.GetBrlCostBasis)
+	api.Post("/remittances", remittance.CreateRemittance)
+	api.Delete("/remittances/:id", remittance.DeleteRemittance)
+
+	jsonResponse := body{}
+	resp, _ := MockHttpRequest(app, "GET", "/api/remittances",
+		"application/json", "ValidIdTokenWithoutPrivilegedUser", nil)
+
+	respBody, _ := ioutil.ReadAll(resp.Body)
+
+	json.Unmarshal(respBody, &jsonResponse)
+	jsonResponse.Code = resp.StatusCode
+
+	assert.NotNil(t, resp)
+	assert.Equal(t, expectedResp, jsonResponse)
+}
+
+func TestApiDeleteRemittance(t *testing.T) {
+	type body struct {
+		Success    bool                  `json:"success"`
+		Message    string                `json:"message"`
+		Error      string                `json:"error"`
+		Code       int                   `json:"code"`
+		Remittance *presenter.Remittance `json:"remittance"`
+	}
+
+	type test struct {
+		path         string
+		expectedResp body
+	}
+
+	tests := []test{
+		{
+			path: "/api/remittances/UNKNOWN_ID",
+			expectedResp: body{
+				Code:    404,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidRemittance.Error(),
+			},
+		},
+		{
+			path: "/api/remittances/TestRemittanceID",
+			expectedResp: body{
+				Code:    200,
+				Success: true,
+				Message: "Remittance was deleted successfully",
+				Remittance: &presenter.Remittance{
+					Id:               "TestRemittanceID",
+					SentAmount:       5066,
+					SentCurrency:     "BRL",
+					ReceivedAmount:   1000,
+					ReceivedCurrency: "USD",
+					EffectiveRate:    5.066,
+					ExchangeRate:     5.066,
+					Date:             "2021-10-01",
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Remittance Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	// Declare Remittance Application Logic
+	remittance := RemittanceApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Get("/remittances", remittance.GetRemittances)
+	api.Get("/remittances/cost-basis", remittance.GetBrlCostBasis)
+	api.Post("/remittances", remittance.CreateRemittance)
+	api.Delete("/remittances/:id", remittance.DeleteRemittance)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "DELETE", testCase.path,
+			"application/json", "ValidIdTokenWithoutPrivilegedUser", nil)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
+
+func TestApiGetBrlCostBasis(t *testing.T) {
+	type body struct {
+		Success   bool                     `json:"success"`
+		Message   string                   `json:"message"`
+		Error     string                   `json:"error"`
+		Code      int                      `json:"code"`
+		CostBasis []presenter.BrlCostBasis `json:"costBasis"`
+	}
+
+	// The orders of the mock are in BRL and have no cost basis to convert
+	expectedResp := body{
+		Code:      200,
+		Success:   true,
+		Message:   "BRL cost basis returned successfully",
+		CostBasis: []presenter.BrlCostBasis{},
+	}
+
+	// Mock UseCases function (Remittance Application Logic)
+	usecases := usecases.NewMockApplications()
+	logicApi := logicApi.NewMockApplication(*usecases)
+
+	// Declare Remittance Application Logic
+	remittance := RemittanceApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApi,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Get("/remittances", remittance.GetRemittances)
+	api.Get("/remittances/cost-basis", remittance.GetBrlCostBasis)
+	api.Post("/remittances", remittance.CreateRemittance)
+	api.Delete("/remittances/:id", remittance.DeleteRemittance)
+
+	jsonResponse := body{}
+	resp, _ := MockHttpRequest(app, "GET", "/api/remittances/cost-basis",
+		"application/json", "ValidIdTokenWithoutPrivilegedUser", nil)
+
+	respBody, _ := ioutil.ReadAll(resp.Body)
+
+	json.Unmarshal(respBody, &jsonResponse)
+	jsonResponse.Code = resp.StatusCode
+
+	assert.NotNil(t, resp)
+	assert.Equal(t, expectedResp, jsonResponse)
+}
@@ -5,6 +5,7 @@ import (
 	"stockfyApi/api/presenter"
 	"stockfyApi/entity"
 	"stockfyApi/usecases"
+	"stockfyApi/usecases/logicApi"
 
 	"github.com/gofiber/fiber/v2"
 	_ "github.com/lib/pq"
@@ -12,6 +13,7 @@ import (
 
 type SectorApi struct {
 	ApplicationLogic usecases.Applications
+	LogicApi         logicApi.UseCases
 }
 
 func (sector *SectorApi) GetSector(c *fiber.Ctx) error {
@@ -75,7 +77,18 @@ func (sector *SectorApi) CreateSector(c *fiber.Ctx) error {
 	}
 
 	sectorCreated, err := sector.ApplicationLogic.SectorApp.CreateSector(
-		sectorBody.Sector)
+		sectorBody.Sector, sectorBody.Level, sectorBody.Code,
+		sectorBody.ParentId)
+	if err == entity.ErrInvalidSectorBlank || err == entity.ErrInvalidSectorLevel ||
+		err == entity.ErrInvalidSectorParent {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    400,
+		})
+	}
+
 	if err != nil {
 		return c.Status(500).JSON(&fiber.Map{
 			"success": false,
@@ -85,8 +98,7 @@ func (sector *SectorApi) CreateSector(c *fiber.Ctx) error {
 		})
 	}
 
-	sectorApiReturn := presenter.ConvertSectorToApiReturn(sectorCreated[0].Id,
-		sectorCreated[0].Name)
+	sectorApiReturn := presenter.ConvertSectorNodeToApiReturn(&sectorCreated[0])
 
 	err = c.JSON(&fiber.Map{
 		"success": true,
@@ -96,3 +108,267 @@ func (sector *SectorApi) CreateSector(c *fiber.Ctx) error {
 
 	return err
 }
+
+// GetSectors returns the sector taxonomy as a tree, from the sectors to the
+// industries.
+func (sector *SectorApi) GetSectors(c *fiber.Ctx) error {
+
+	sectors, err := sector.ApplicationLogic.SectorApp.SearchSectors()
+	if err != nil {
+		return c.Status(500).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"sectors": presenter.ConvertSectorTreeToApiReturn(sectors),
+		"message": "Sector taxonomy returned successfully",
+	})
+
+	return err
+}
+
+// GetSectorAllocation returns the amount invested by the user in each node
+// of the taxonomy level informed in the level query, SECTOR by default.
+func (sector *SectorApi) GetSectorAllocation(c *fiber.Ctx) error {
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	allocations, err := sector.ApplicationLogic.SectorApp.SectorAllocation(
+		userId.String(), c.Query("level"))
+	if err == entity.ErrInvalidSectorLevel {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    400,
+		})
+	}
+
+	if err != nil {
+		return c.Status(500).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success":     true,
+		"allocations": presenter.ConvertSectorAllocationToApiReturn(allocations),
+		"message":     "Sector allocation returned successfully",
+	})
+
+	return err
+}
+
+// GetSectorMappings returns the provider labels and their sectors. With the
+// unmapped query, only the labels waiting for a sector are returned.
+func (sector *SectorApi) GetSectorMappings(c *fiber.Ctx) error {
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	searchedUser, _ := sector.ApplicationLogic.UserApp.SearchUser(userId.String())
+	if searchedUser.Type != "admin" {
+		return c.Status(403).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiAuthorization.Error(),
+			"error":   entity.ErrInvalidUserAdminPrivilege.Error(),
+			"code":    403,
+		})
+	}
+
+	mappings, err := sector.ApplicationLogic.SectorApp.SearchSectorMappings(
+		c.Query("provider"), c.Query("unmapped") == "true")
+	if err == entity.ErrInvalidSectorProvider {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    400,
+		})
+	}
+
+	if err != nil {
+		return c.Status(500).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success":  true,
+		"mappings": presenter.ConvertSectorMappingToApiReturn(mappings),
+		"message":  "Sector mappings returned successfully",
+	})
+
+	return err
+}
+
+// UpdateSectorMapping maps a provider label to a node of the taxonomy. The
+// assets classified by this label are moved to the new node.
+func (sector *SectorApi) UpdateSectorMapping(c *fiber.Ctx) error {
+	var mappingBody presenter.SectorMappingBody
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	searchedUser, _ := sector.ApplicationLogic.UserApp.SearchUser(userId.String())
+	if searchedUser.Type != "admin" {
+		return c.Status(403).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiAuthorization.Error(),
+			"error":   entity.ErrInvalidUserAdminPrivilege.Error(),
+			"code":    403,
+		})
+	}
+
+	if err := c.BodyParser(&mappingBody); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	mapping, err := sector.ApplicationLogic.SectorApp.UpdateSectorMapping(
+		c.Params("id"), mappingBody.SectorId)
+	if err == entity.ErrInvalidSector || err == entity.ErrInvalidSectorMapping {
+		return c.Status(404).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    404,
+		})
+	}
+
+	if err != nil {
+		return c.Status(500).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"mapping": presenter.ConvertSectorMappingToApiReturn(
+			[]entity.SectorMapping{*mapping})[0],
+		"message": "Sector mapping updated successfully",
+	})
+
+	return err
+}
+
+// UpdateSector renames a node of the taxonomy. Only the admins are able to
+// change the taxonomy.
+func (sector *SectorApi) UpdateSector(c *fiber.Ctx) error {
+	var sectorBody presenter.SectorUpdateBody
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	if err := c.BodyParser(&sectorBody); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	httpStatusCode, renamedSector, err := sector.LogicApi.ApiRenameSector(
+		c.Params("id"), sectorBody.Name, userId.String())
+	if httpStatusCode == 403 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiAuthorization.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	} else if httpStatusCode == 400 || httpStatusCode == 404 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	} else if httpStatusCode == 500 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"sector":  presenter.ConvertSectorNodeToApiReturn(renamedSector),
+		"message": "Sector was updated successfully",
+	})
+
+	return err
+}
+
+// MergeSector moves the assets, label mappings and children of the sector to
+// the target sector of the same level and deletes the sector.
+func (sector *SectorApi) MergeSector(c *fiber.Ctx) error {
+	var mergeBody presenter.SectorMergeBody
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	if err := c.BodyParser(&mergeBody); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	httpStatusCode, targetSector, err := sector.LogicApi.ApiMergeSectors(
+		c.Params("id"), mergeBody.TargetId, userId.String())
+	if httpStatusCode == 403 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiAuthorization.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	} else if httpStatusCode == 400 || httpStatusCode == 404 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	} else if httpStatusCode == 500 {
+		return c.Status(httpStatusCode).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    httpStatusCode,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success": true,
+		"sector":  presenter.ConvertSectorNodeToApiReturn(targetSector),
+		"message": "Sectors were merged successfully",
+	})
+
+	return err
+}
@@ -134,7 +134,9 @@ func TestApiSectorCreateSector(t *testing.T) {
 	}
 
 	type bodyRequest struct {
-		Sector string `json:"sector"`
+		Sector   string `json:"sector"`
+		Level    string `json:"level"`
+		ParentId string `json:"parentId"`
 	}
 
 	type test struct {
@@ -190,6 +192,22 @@ func TestApiSectorCreateSector(t *testing.T) {
 				},
 			},
 		},
+		{
+			contentType: "application/json",
+			idToken:     "ValidIdTokenPrivilegeUser",
+			bodyRequest: bodyRequest{
+				Sector:   "Test Industry",
+				Level:    entity.SectorLevelIndustry,
+				ParentId: "TestFinancialsID",
+			},
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidSectorParent.Error(),
+				Code:    400,
+				Sector:  nil,
+			},
+		},
 		{
 			contentType: "application/json",
 			idToken:     "ValidIdTokenPrivilegeUser",
@@ -248,3 +266,218 @@ func TestApiSectorCreateSector(t *testing.T) {
 	}
 
 }
+
+func TestApiSectorGetAllocation(t *testing.T) {
+	type body struct {
+		Success     bool                                  `json:"success"`
+		Message     string                                `json:"message"`
+		Error       string                                `json:"error"`
+		Code        int                                   `json:"code"`
+		Allocations []presenter.SectorAllocationApiReturn `json:"allocations"`
+	}
+
+	type test struct {
+		idToken      string
+		level        string
+		expectedResp body
+	}
+
+	financialsId := "TestFinancialsID"
+
+	tests := []test{
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			level:   "SUB_INDUSTRY",
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidSectorLevel.Error(),
+				Code:    400,
+			},
+		},
+		{
+			idToken: "ValidIdTokenWithoutPrivilegedUser",
+			level:   "INDUSTRY_GROUP",
+			expectedResp: body{
+				Success: true,
+				Message: "Sector allocation returned successfully",
+				Code:    200,
+				Allocations: []presenter.SectorAllocationApiReturn{
+					{
+						Sector: &presenter.SectorNodeApiReturn{
+							Id:       "TestBanksGroupID",
+							Name:     "Banks",
+							Level:    entity.SectorLevelIndustryGroup,
+							Code:     "4010",
+							ParentId: &financialsId,
+						},
+						Currency:   "BRL",
+						Invested:   300,
+						Percentage: 100,
+						Symbols:    []string{"ITUB4"},
+					},
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Sector Application Logic)
+	usecases := usecases.NewMockApplications()
+
+	// Declare Sector Application Logic
+	sector := SectorApi{
+		ApplicationLogic: *usecases,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Get("/sectors/allocation", sector.GetSectorAllocation)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "GET", "/api/sectors/allocation?level="+
+			testCase.level, "application/json", testCase.idToken, nil)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
+
+func TestApiSectorUpdateMapping(t *testing.T) {
+	type body struct {
+		Success bool                              `json:"success"`
+		Message string                            `json:"message"`
+		Error   string                            `json:"error"`
+		Code    int                               `json:"code"`
+		Mapping *presenter.SectorMappingApiReturn `json:"mapping"`
+	}
+
+	type test struct {
+		idToken      string
+		mappingId    string
+		bodyRequest  presenter.SectorMappingBody
+		expectedResp body
+	}
+
+	banksGroupId := "TestBanksGroupID"
+
+	tests := []test{
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			mappingId:   "TestUnmappedID",
+			bodyRequest: presenter.SectorMappingBody{SectorId: "TestBanksID"},
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiAuthorization.Error(),
+				Error:   entity.ErrInvalidUserAdminPrivilege.Error(),
+				Code:    403,
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			mappingId:   "TestUnmappedID",
+			bodyRequest: presenter.SectorMappingBody{SectorId: "UNKNOWN_ID"},
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidSector.Error(),
+				Code:    404,
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			mappingId:   "UNKNOWN_ID",
+			bodyRequest: presenter.SectorMappingBody{SectorId: "TestBanksID"},
+			expectedResp: body{
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidSectorMapping.Error(),
+				Code:    404,
+			},
+		},
+		{
+			idToken:     "ValidIdTokenPrivilegeUser",
+			mappingId:   "TestUnmappedID",
+			bodyRequest: presenter.SectorMappingBody{SectorId: "TestBanksID"},
+			expectedResp: body{
+				Success: true,
+				Message: "Sector mapping updated successfully",
+				Code:    200,
+				Mapping: &presenter.SectorMappingApiReturn{
+					Id:       "TestUnmappedID",
+					Provider: entity.SectorProviderFinnhub,
+					Label:    "Fintech",
+					Sector: &presenter.SectorNodeApiReturn{
+						Id:       "TestBanksID",
+						Name:     "Banks",
+						Level:    entity.SectorLevelIndustry,
+						Code:     "401010",
+						ParentId: &banksGroupId,
+					},
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (Sector Application Logic)
+	usecases := usecases.NewMockApplications()
+
+	// Declare Sector Application Logic
+	sector := SectorApi{
+		ApplicationLogic: *usecases,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Put("/sector-mappings/:id", sector.UpdateSectorMapping)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "PUT", "/api/sector-mappings/"+
+			testCase.mappingId, "application/json", testCase.idToken,
+			testCase.bodyRequest)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+}
@@ -48,6 +48,26 @@ func (f *UsersApi) SignUp(c *fiber.Ctx) error {
 		})
 	}
 
+	// An email already registered, with another sign in method, must be
+	// linked to the user instead of creating a second one
+	registeredUser, err := f.ApplicationLogic.UserApp.SearchUserByEmail(
+		signUpUser.Email)
+	if err != nil {
+		return c.Status(500).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	} else if registeredUser != nil {
+		return c.Status(409).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiLinkAccount.Error(),
+			"error":   entity.ErrUserLinkRequired.Error(),
+			"code":    409,
+		})
+	}
+
 	// Create the user on Firebase
 	user, err := f.ApplicationLogic.UserApp.UserCreate(signUpUser.Email,
 		signUpUser.Password, signUpUser.DisplayName)
@@ -316,9 +336,46 @@ func (f *UsersApi) OAuth2Redirect(c *fiber.Ctx) error {
 		})
 	}
 
+	userLoginApiReturn := presenter.ConvertUserLoginToUserLoginApiReturn(
+		userInfo.Email, userInfo.Fullname, userInfo.IdToken,
+		userInfo.RefreshToken, userInfo.Expiration)
+
+	// The Firebase asks for a confirmation when the email is registered with
+	// another sign in method
+	if userInfo.NeedConfirmation {
+		return c.Status(409).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiLinkAccount.Error(),
+			"error":   entity.ErrUserLinkRequired.Error(),
+			"code":    409,
+		})
+	}
+
 	// Verify if the user already exists in our database. If not, we need to
 	// create.
 	if userInfo.IsNewUser == true {
+		// A new Firebase user with the email of an user already registered
+		// must be linked to it. Its ID token is returned for the link.
+		registeredUser, err := f.ApplicationLogic.UserApp.SearchUserByEmail(
+			userInfo.Email)
+		if err != nil {
+			return c.Status(500).JSON(&fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiInternalError.Error(),
+				"error":   err.Error(),
+				"code":    500,
+			})
+		} else if registeredUser != nil &&
+			registeredUser.Uid != userInfo.UserUid {
+			return c.Status(409).JSON(&fiber.Map{
+				"success":  false,
+				"userInfo": userLoginApiReturn,
+				"message":  entity.ErrMessageApiLinkAccount.Error(),
+				"error":    entity.ErrUserLinkRequired.Error(),
+				"code":     409,
+			})
+		}
+
 		// Create User in our database
 		_, err = f.ApplicationLogic.UserApp.CreateUser(userInfo.UserUid,
 			userInfo.Email, userInfo.Fullname, "normal")
@@ -332,10 +389,6 @@ func (f *UsersApi) OAuth2Redirect(c *fiber.Ctx) error {
 		}
 	}
 
-	userLoginApiReturn := presenter.ConvertUserLoginToUserLoginApiReturn(
-		userInfo.Email, userInfo.Fullname, userInfo.IdToken,
-		userInfo.RefreshToken, userInfo.Expiration)
-
 	err = c.JSON(&fiber.Map{
 		"success":  true,
 		"userInfo": userLoginApiReturn,
@@ -488,3 +541,83 @@ func (f *UsersApi) UpdateUserInfo(c *fiber.Ctx) error {
 
 	return err
 }
+
+// LinkAccount links the Firebase user of the ID token in the body, signed in
+// with another authentication provider with the same email, to the
+// authenticated user. The portfolio of the linked user is merged into the one
+// of the authenticated user.
+func (f *UsersApi) LinkAccount(c *fiber.Ctx) error {
+	var linkAccount presenter.LinkAccountBody
+
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+	userEmail := reflect.ValueOf(userInfo).FieldByName("email")
+
+	if err := c.BodyParser(&linkAccount); err != nil {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   entity.ErrInvalidApiBody.Error(),
+			"code":    400,
+		})
+	}
+
+	identities, err := f.ApplicationLogic.UserApp.LinkUser(userId.String(),
+		userEmail.String(), linkAccount.IdToken)
+	if err == entity.ErrInvalidUserToken ||
+		err == entity.ErrInvalidUserLinkSameUser ||
+		err == entity.ErrInvalidUserLinkEmail ||
+		err == entity.ErrInvalidUserLinked {
+		return c.Status(400).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiRequest.Error(),
+			"error":   err.Error(),
+			"code":    400,
+		})
+	} else if err != nil {
+		return c.Status(500).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success":    true,
+		"identities": presenter.ConvertUserIdentitiesToApiReturn(identities),
+		"message":    "Account was linked successfully",
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (f *UsersApi) GetLinkedAccounts(c *fiber.Ctx) error {
+	userInfo := c.Context().Value("user")
+	userId := reflect.ValueOf(userInfo).FieldByName("userID")
+
+	identities, err := f.ApplicationLogic.UserApp.SearchUserIdentities(
+		userId.String())
+	if err != nil {
+		return c.Status(500).JSON(&fiber.Map{
+			"success": false,
+			"message": entity.ErrMessageApiInternalError.Error(),
+			"error":   err.Error(),
+			"code":    500,
+		})
+	}
+
+	err = c.JSON(&fiber.Map{
+		"success":    true,
+		"identities": presenter.ConvertUserIdentitiesToApiReturn(identities),
+		"message":    "Linked accounts returned successfully",
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
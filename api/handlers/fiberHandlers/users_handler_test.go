@@ -170,6 +170,21 @@ func TestApiUsersSignUp(t *testing.T) {
 				UserInfo: nil,
 			},
 		},
+		{
+			contentType: "application/json",
+			bodyReq: presenter.SignUpBody{
+				Email:       "registered@email.com",
+				Password:    "PasswdTest",
+				DisplayName: "Test Username",
+			},
+			expectedResp: body{
+				Success:  false,
+				Message:  entity.ErrMessageApiLinkAccount.Error(),
+				Error:    entity.ErrUserLinkRequired.Error(),
+				Code:     409,
+				UserInfo: nil,
+			},
+		},
 		{
 			contentType: "application/json",
 			bodyReq: presenter.SignUpBody{
@@ -600,6 +615,37 @@ func TestApiUsersGoogleOAuth2Redirect(t *testing.T) {
 				Error:   entity.ErrInvalidUserEmailBlank.Error(),
 			},
 		},
+		{
+			contentType:   "application/json",
+			urlParams:     "google",
+			urlQuery:      "code=NEW_USER_REGISTERED_EMAIL&state=VALID_USERNAME",
+			stateUsername: "VALID_USERNAME",
+			expectedResp: body{
+				Code:    409,
+				Success: false,
+				Message: entity.ErrMessageApiLinkAccount.Error(),
+				Error:   entity.ErrUserLinkRequired.Error(),
+				UserInfo: &presenter.UserLoginApiReturn{
+					Email:        "registered@email.com",
+					DisplayName:  "Test Name",
+					IdToken:      "NewProviderIdToken",
+					RefreshToken: "ValidRefreshToken",
+					Expiration:   "3600",
+				},
+			},
+		},
+		{
+			contentType:   "application/json",
+			urlParams:     "google",
+			urlQuery:      "code=NEED_CONFIRMATION&state=VALID_USERNAME",
+			stateUsername: "VALID_USERNAME",
+			expectedResp: body{
+				Code:    409,
+				Success: false,
+				Message: entity.ErrMessageApiLinkAccount.Error(),
+				Error:   entity.ErrUserLinkRequired.Error(),
+			},
+		},
 		{
 			contentType:   "application/json",
 			urlParams:     "google",
@@ -1250,3 +1296,148 @@ func TestApiUpdateUserInfo(t *testing.T) {
 		assert.Equal(t, testCase.expectedResp, jsonResponse)
 	}
 }
+
+func TestApiLinkAccount(t *testing.T) {
+	type body struct {
+		Success    bool                              `json:"success"`
+		Message    string                            `json:"message"`
+		Error      string                            `json:"error"`
+		Code       int                               `json:"code"`
+		Identities []presenter.UserIdentityApiReturn `json:"identities"`
+	}
+
+	type test struct {
+		idToken      string
+		contentType  string
+		bodyReq      presenter.LinkAccountBody
+		expectedResp body
+	}
+
+	tests := []test{
+		{
+			idToken:     "ValidIdTokenWithoutEmailVerification",
+			contentType: "application/json",
+			bodyReq:     presenter.LinkAccountBody{IdToken: "NewProviderIdToken"},
+			expectedResp: body{
+				Code:    401,
+				Success: false,
+				Message: entity.ErrMessageApiAuthentication.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyReq:     presenter.LinkAccountBody{IdToken: "INVALID_ID_TOKEN"},
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidUserToken.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyReq: presenter.LinkAccountBody{
+				IdToken: "ValidIdTokenWithoutPrivilegedUser"},
+			expectedResp: body{
+				Code:    400,
+				Success: false,
+				Message: entity.ErrMessageApiRequest.Error(),
+				Error:   entity.ErrInvalidUserLinkSameUser.Error(),
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyReq:     presenter.LinkAccountBody{IdToken: "ERROR_USER_REPOSITORY"},
+			expectedResp: body{
+				Code:    500,
+				Success: false,
+				Message: entity.ErrMessageApiInternalError.Error(),
+				Error:   "Unknown error in the user repository",
+			},
+		},
+		{
+			idToken:     "ValidIdTokenWithoutPrivilegedUser",
+			contentType: "application/json",
+			bodyReq:     presenter.LinkAccountBody{IdToken: "NewProviderIdToken"},
+			expectedResp: body{
+				Code:    200,
+				Success: true,
+				Message: "Account was linked successfully",
+				Identities: []presenter.UserIdentityApiReturn{
+					{
+						Uid:        "LinkedUserUID",
+						ProviderId: "google.com",
+						CreatedAt:  entity.StringToTime("2021-10-01"),
+					},
+				},
+			},
+		},
+	}
+
+	// Mock UseCases function (User Application Logic)
+	usecases := usecases.NewMockApplications()
+
+	// Declare User Application Logic
+	users := UsersApi{
+		ApplicationLogic: *usecases,
+	}
+
+	// Mock HTTP request
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Use(middleware.NewFiberMiddleware(middleware.FiberMiddleware{
+		UserAuthentication: usecases.UserApp,
+		ErrorHandler: func(c *fiber.Ctx, e error) error {
+			var err error
+			c.Status(401).JSON(fiber.Map{
+				"success": false,
+				"message": entity.ErrMessageApiAuthentication.Error(),
+				"code":    401,
+			})
+
+			return err
+		},
+		ContextKey: "user",
+	}))
+	api.Post("/link-account", users.LinkAccount)
+	api.Get("/linked-accounts", users.GetLinkedAccounts)
+
+	for _, testCase := range tests {
+		jsonResponse := body{}
+		resp, _ := MockHttpRequest(app, "POST", "/api/link-account",
+			testCase.contentType, testCase.idToken, testCase.bodyReq)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		json.Unmarshal(body, &jsonResponse)
+		jsonResponse.Code = resp.StatusCode
+
+		assert.NotNil(t, resp)
+		assert.Equal(t, testCase.expectedResp, jsonResponse)
+	}
+
+	jsonResponse := body{}
+	resp, _ := MockHttpRequest(app, "GET", "/api/linked-accounts",
+		"application/json", "ValidIdTokenWithoutPrivilegedUser", nil)
+
+	respBody, _ := ioutil.ReadAll(resp.Body)
+
+	json.Unmarshal(respBody, &jsonResponse)
+	jsonResponse.Code = resp.StatusCode
+
+	assert.Equal(t, body{
+		Code:    200,
+		Success: true,
+		Message: "Linked accounts returned successfully",
+		Identities: []presenter.UserIdentityApiReturn{
+			{
+				Uid:        "LinkedUserUID",
+				ProviderId: "google.com",
+				CreatedAt:  entity.StringToTime("2021-10-01"),
+			},
+		},
+	}, jsonResponse)
+}
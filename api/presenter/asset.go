@@ -12,21 +12,44 @@ type AssetBody struct {
 	Country  string `json:"country"`
 }
 
+// AssetUpdateBody has the fields of the asset fixed by an admin. The omitted
+// fields keep their values.
+type AssetUpdateBody struct {
+	Fullname   string  `json:"fullname"`
+	Preference *string `json:"preference"`
+	SectorId   string  `json:"sectorId"`
+}
+
+type AssetPriceBody struct {
+	Symbol  string `json:"symbol"`
+	Country string `json:"country"`
+}
+
+type SymbolPriceApiReturn struct {
+	Symbol      string              `json:"symbol"`
+	Country     string              `json:"country"`
+	Success     bool                `json:"success"`
+	SymbolPrice *entity.SymbolPrice `json:"symbolPrice,omitempty"`
+	Error       string              `json:"error,omitempty"`
+}
+
 type AssetPrice struct {
 	ActualPrice float64 `json:"actualPrice"`
 	OpenPrice   float64 `json:"openPrice"`
 }
 
 type AssetApiReturn struct {
-	Id         string           `json:"id,omitempty"`
-	Preference string           `json:"preference,omitempty"`
-	Fullname   string           `json:"fullname,omitempty"`
-	Symbol     string           `json:"symbol,omitempty"`
-	Sector     *Sector          `json:"sector,omitempty"`
-	AssetType  *AssetType       `json:"assetType,omitempty"`
-	OrderInfos *OrderInfos      `json:"orderResume,omitempty"`
-	Orders     []OrderApiReturn `json:"orders,omitempty"`
-	Price      *AssetPrice      `json:"price,omitempty"`
+	Id          string                   `json:"id,omitempty"`
+	Preference  string                   `json:"preference,omitempty"`
+	Fullname    string                   `json:"fullname,omitempty"`
+	Symbol      string                   `json:"symbol,omitempty"`
+	Sector      *Sector                  `json:"sector,omitempty"`
+	AssetType   *AssetType               `json:"assetType,omitempty"`
+	OrderInfos  *OrderInfos              `json:"orderResume,omitempty"`
+	Orders      []OrderApiReturn         `json:"orders,omitempty"`
+	Price       *AssetPrice              `json:"price,omitempty"`
+	FixedIncome *FixedIncomeApiReturn    `json:"fixedIncome,omitempty"`
+	Profile     *CompanyProfileApiReturn `json:"profile,omitempty"`
 }
 
 func ConvertAssetToApiReturn(assetId string, preference string, fullname string,
@@ -79,6 +102,8 @@ func ConvertArrayAssetApiReturn(assets []entity.Asset) []AssetApiReturn {
 			*asset.Preference, asset.Fullname, asset.Symbol,
 			asset.Sector.Name, asset.Sector.Id, "", "", "", "", nil,
 			asset.OrderInfo, asset.Price)
+		convertedAsset.FixedIncome = ConvertFixedIncomeToApiReturn(
+			asset.FixedIncome)
 
 		convertedAssets = append(convertedAssets, convertedAsset)
 	}
@@ -86,3 +111,39 @@ func ConvertArrayAssetApiReturn(assets []entity.Asset) []AssetApiReturn {
 	return convertedAssets
 
 }
+
+func ConvertAssetPriceBodyToSymbolPriceQuery(
+	assetsBody []AssetPriceBody) []entity.SymbolPriceQuery {
+	var symbolPriceQueries []entity.SymbolPriceQuery
+
+	for _, assetBody := range assetsBody {
+		symbolPriceQueries = append(symbolPriceQueries, entity.SymbolPriceQuery{
+			Symbol:  assetBody.Symbol,
+			Country: assetBody.Country,
+		})
+	}
+
+	return symbolPriceQueries
+}
+
+func ConvertArraySymbolPriceToApiReturn(
+	symbolPrices []entity.SymbolPriceResult) []SymbolPriceApiReturn {
+	var convertedPrices []SymbolPriceApiReturn
+
+	for _, symbolPrice := range symbolPrices {
+		convertedPrice := SymbolPriceApiReturn{
+			Symbol:      symbolPrice.Symbol,
+			Country:     symbolPrice.Country,
+			Success:     symbolPrice.Err == nil,
+			SymbolPrice: symbolPrice.Price,
+		}
+
+		if symbolPrice.Err != nil {
+			convertedPrice.Error = symbolPrice.Err.Error()
+		}
+
+		convertedPrices = append(convertedPrices, convertedPrice)
+	}
+
+	return convertedPrices
+}
@@ -1,5 +1,11 @@
 package presenter
 
+type AssetTypeBody struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Country string `json:"country"`
+}
+
 type AssetType struct {
 	Id      string           `json:"id,omitempty"`
 	Type    string           `json:"type,omitempty"`
@@ -0,0 +1,38 @@
+package presenter
+
+import (
+	"encoding/json"
+	"stockfyApi/entity"
+	"time"
+)
+
+type AuditLogApiReturn struct {
+	Id         string          `json:"id"`
+	UserUid    string          `json:"userUid"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entityType"`
+	EntityId   string          `json:"entityId"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
+func ConvertAuditLogToApiReturn(
+	auditLogs []entity.AuditLog) []AuditLogApiReturn {
+
+	auditLogsReturn := []AuditLogApiReturn{}
+	for _, auditLog := range auditLogs {
+		auditLogsReturn = append(auditLogsReturn, AuditLogApiReturn{
+			Id:         auditLog.Id,
+			UserUid:    auditLog.UserUid,
+			Action:     auditLog.Action,
+			EntityType: auditLog.EntityType,
+			EntityId:   auditLog.EntityId,
+			Before:     auditLog.Before,
+			After:      auditLog.After,
+			CreatedAt:  auditLog.CreatedAt,
+		})
+	}
+
+	return auditLogsReturn
+}
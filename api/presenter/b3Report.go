@@ -0,0 +1,111 @@
+package presenter
+
+import "stockfyApi/entity"
+
+// B3ReportBody has the report file encoded in base64, since the XLSX files
+// are binary. The date of the custody position is informed in the body, since
+// the report does not have it.
+type B3ReportBody struct {
+	File       []byte            `json:"file"`
+	Brokerages map[string]string `json:"brokerages"`
+	Date       string            `json:"date"`
+}
+
+type B3Report struct {
+	Type           string                  `json:"type"`
+	Date           string                  `json:"date,omitempty"`
+	Trades         []OrderImportRow        `json:"trades,omitempty"`
+	Movements      []B3Movement            `json:"movements,omitempty"`
+	Positions      []B3Position            `json:"positions,omitempty"`
+	Orders         []OrderApiReturn        `json:"orders,omitempty"`
+	Earnings       []EarningsApiReturn     `json:"earnings,omitempty"`
+	Reconciliation []CustodyReconciliation `json:"reconciliation,omitempty"`
+}
+
+type B3Movement struct {
+	Line        int      `json:"line"`
+	Direction   string   `json:"direction"`
+	Date        string   `json:"date,omitempty"`
+	Movement    string   `json:"movement"`
+	Symbol      string   `json:"symbol,omitempty"`
+	Brokerage   string   `json:"brokerage,omitempty"`
+	Quantity    float64  `json:"quantity,omitempty"`
+	Price       float64  `json:"price,omitempty"`
+	Amount      float64  `json:"amount,omitempty"`
+	EarningType string   `json:"earningType,omitempty"`
+	OrderType   string   `json:"orderType,omitempty"`
+	Ignored     bool     `json:"ignored,omitempty"`
+	NewAsset    bool     `json:"newAsset,omitempty"`
+	Duplicate   bool     `json:"duplicate,omitempty"`
+	Errors      []string `json:"errors,omitempty"`
+}
+
+type B3Position struct {
+	Sheet     string   `json:"sheet,omitempty"`
+	Line      int      `json:"line"`
+	Symbol    string   `json:"symbol"`
+	Brokerage string   `json:"brokerage,omitempty"`
+	Quantity  float64  `json:"quantity"`
+	Errors    []string `json:"errors,omitempty"`
+}
+
+func ConvertB3ReportToApiReturn(report *entity.B3Report) *B3Report {
+	if report == nil {
+		return nil
+	}
+
+	var movements []B3Movement
+	for _, movement := range report.Movements {
+		movements = append(movements, B3Movement{
+			Line:        movement.Line,
+			Direction:   movement.Direction,
+			Date:        movement.Date,
+			Movement:    movement.Movement,
+			Symbol:      movement.Symbol,
+			Brokerage:   movement.Brokerage,
+			Quantity:    movement.Quantity,
+			Price:       movement.Price,
+			Amount:      movement.Amount,
+			EarningType: movement.EarningType,
+			OrderType:   movement.OrderType,
+			Ignored:     movement.Ignored,
+			NewAsset:    movement.NewAsset,
+			Duplicate:   movement.Duplicate,
+			Errors:      movement.Errors,
+		})
+	}
+
+	var positions []B3Position
+	for _, position := range report.Positions {
+		positions = append(positions, B3Position{
+			Sheet:     position.Sheet,
+			Line:      position.Line,
+			Symbol:    position.Symbol,
+			Brokerage: position.Brokerage,
+			Quantity:  position.Quantity,
+			Errors:    position.Errors,
+		})
+	}
+
+	var reconciliation []CustodyReconciliation
+	if report.Reconciliation != nil {
+		reconciliation = ConvertCustodyReconciliationToApiReturn(
+			report.Reconciliation)
+	}
+
+	var date string
+	if !report.Date.IsZero() {
+		date = report.Date.Format("2006-01-02")
+	}
+
+	return &B3Report{
+		Type:           report.Type,
+		Date:           date,
+		Trades:         ConvertOrderImportRowToApiReturn(report.Trades),
+		Movements:      movements,
+		Positions:      positions,
+		Orders:         ConvertOrderToApiReturn(report.Orders),
+		Earnings:       ConvertArrayEarningToApiReturn(report.Earnings),
+		Reconciliation: reconciliation,
+	}
+}
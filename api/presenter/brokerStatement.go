@@ -0,0 +1,63 @@
+package presenter
+
+import "stockfyApi/entity"
+
+// BrokerStatementBody has the statement file encoded in base64, like the B3
+// reports. The brokerage replaces the brokerage found in the statement.
+type BrokerStatementBody struct {
+	File      []byte `json:"file"`
+	Brokerage string `json:"brokerage"`
+}
+
+type BrokerStatement struct {
+	Format    string             `json:"format"`
+	Brokerage string             `json:"brokerage,omitempty"`
+	Orders    []OrderImportRow   `json:"orders,omitempty"`
+	Earnings  []EarningImportRow `json:"earnings,omitempty"`
+}
+
+type EarningImportRow struct {
+	Line        int      `json:"line"`
+	Symbol      string   `json:"symbol,omitempty"`
+	Country     string   `json:"country,omitempty"`
+	EarningType string   `json:"earningType,omitempty"`
+	Amount      float64  `json:"amount,omitempty"`
+	WithheldTax float64  `json:"withheldTax,omitempty"`
+	Currency    string   `json:"currency,omitempty"`
+	Date        string   `json:"date,omitempty"`
+	NewAsset    bool     `json:"newAsset,omitempty"`
+	Duplicate   bool     `json:"duplicate,omitempty"`
+	Errors      []string `json:"errors,omitempty"`
+}
+
+func ConvertBrokerStatementToApiReturn(
+	statement *entity.BrokerStatement) *BrokerStatement {
+
+	if statement == nil {
+		return nil
+	}
+
+	var earnings []EarningImportRow
+	for _, row := range statement.Earnings {
+		earnings = append(earnings, EarningImportRow{
+			Line:        row.Line,
+			Symbol:      row.Symbol,
+			Country:     row.Country,
+			EarningType: row.EarningType,
+			Amount:      row.Amount,
+			WithheldTax: row.WithheldTax,
+			Currency:    row.Currency,
+			Date:        row.Date,
+			NewAsset:    row.NewAsset,
+			Duplicate:   row.Duplicate,
+			Errors:      row.Errors,
+		})
+	}
+
+	return &BrokerStatement{
+		Format:    statement.Format,
+		Brokerage: statement.Brokerage,
+		Orders:    ConvertOrderImportRowToApiReturn(statement.Orders),
+		Earnings:  earnings,
+	}
+}
@@ -2,10 +2,42 @@ package presenter
 
 import "stockfyApi/entity"
 
+type BrokerageBody struct {
+	Name     string `json:"name"`
+	Fullname string `json:"fullname"`
+	Country  string `json:"country"`
+	Custom   bool   `json:"custom"`
+}
+
+type BrokerageAccountBody struct {
+	Nickname    string `json:"nickname"`
+	BrokerageId string `json:"brokerageId"`
+}
+
 type Brokerage struct {
-	Id      string `json:"id,omitempty"`
-	Name    string `json:"name,omitempty"`
-	Country string `json:"country,omitempty"`
+	Id       string `json:"id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Fullname string `json:"fullname,omitempty"`
+	Country  string `json:"country,omitempty"`
+	Custom   bool   `json:"custom,omitempty"`
+}
+
+type BrokerageAccount struct {
+	Id        string     `json:"id,omitempty"`
+	Nickname  string     `json:"nickname,omitempty"`
+	Brokerage *Brokerage `json:"brokerage,omitempty"`
+}
+
+type AccountPosition struct {
+	Symbol   string  `json:"symbol"`
+	Currency string  `json:"currency"`
+	Quantity float64 `json:"quantity"`
+	Invested float64 `json:"invested"`
+}
+
+type AccountPositions struct {
+	Account   BrokerageAccount  `json:"account"`
+	Positions []AccountPosition `json:"positions"`
 }
 
 func ConvertBrokerageToApiReturn(id string, name string, country string) *Brokerage {
@@ -22,9 +54,93 @@ func ConvertArrayBrokerageToApiReturn(brokerageFirms []entity.Brokerage) []Broke
 	for _, brokerage := range brokerageFirms {
 		brokerageConverted := ConvertBrokerageToApiReturn(brokerage.Id,
 			brokerage.Name, brokerage.Country)
+		brokerageConverted.Custom = brokerage.IsCustom()
 		brokerageFirmsConverted = append(brokerageFirmsConverted,
 			*brokerageConverted)
 	}
 
 	return brokerageFirmsConverted
 }
+
+// ConvertFullBrokerageToApiReturn converts the brokerage returned by its
+// creation and update, which have all its fields.
+func ConvertFullBrokerageToApiReturn(brokerage entity.Brokerage) *Brokerage {
+	return &Brokerage{
+		Id:       brokerage.Id,
+		Name:     brokerage.Name,
+		Fullname: brokerage.Fullname,
+		Country:  brokerage.Country,
+		Custom:   brokerage.IsCustom(),
+	}
+}
+
+func ConvertBrokerageAccountToApiReturn(
+	account *entity.BrokerageAccount) *BrokerageAccount {
+
+	if account == nil {
+		return nil
+	}
+
+	accountConverted := &BrokerageAccount{
+		Id:       account.Id,
+		Nickname: account.Nickname,
+	}
+
+	if account.Brokerage != nil {
+		accountConverted.Brokerage = ConvertBrokerageToApiReturn(
+			account.Brokerage.Id, account.Brokerage.Name,
+			account.Brokerage.Country)
+	}
+
+	return accountConverted
+}
+
+func ConvertArrayBrokerageAccountToApiReturn(
+	accounts []entity.BrokerageAccount) []BrokerageAccount {
+
+	accountsConverted := []BrokerageAccount{}
+
+	for i := range accounts {
+		accountsConverted = append(accountsConverted,
+			*ConvertBrokerageAccountToApiReturn(&accounts[i]))
+	}
+
+	return accountsConverted
+}
+
+// ConvertAccountPositionToApiReturn groups the positions by brokerage account,
+// keeping the order returned by the database.
+func ConvertAccountPositionToApiReturn(
+	positions []entity.AccountPosition) []AccountPositions {
+
+	accountsPositions := []AccountPositions{}
+	accountIndex := map[string]int{}
+
+	for _, position := range positions {
+		index, ok := accountIndex[position.AccountId]
+		if !ok {
+			index = len(accountsPositions)
+			accountIndex[position.AccountId] = index
+			accountsPositions = append(accountsPositions, AccountPositions{
+				Account: BrokerageAccount{
+					Id:       position.AccountId,
+					Nickname: position.Nickname,
+					Brokerage: &Brokerage{
+						Id:   position.BrokerageId,
+						Name: position.BrokerageName,
+					},
+				},
+			})
+		}
+
+		accountsPositions[index].Positions = append(
+			accountsPositions[index].Positions, AccountPosition{
+				Symbol:   position.Symbol,
+				Currency: position.Currency,
+				Quantity: position.Quantity,
+				Invested: position.Invested,
+			})
+	}
+
+	return accountsPositions
+}
@@ -0,0 +1,53 @@
+package presenter
+
+import (
+	"stockfyApi/calendar"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ExchangeCalendarApiReturn struct {
+	Code            string    `json:"code"`
+	Name            string    `json:"name"`
+	Countries       []string  `json:"countries"`
+	Timezone        string    `json:"timezone"`
+	Open            string    `json:"open"`
+	Close           string    `json:"close"`
+	IsOpen          bool      `json:"isOpen"`
+	Date            time.Time `json:"date"`
+	IsTradingDay    bool      `json:"isTradingDay"`
+	PreviousSession time.Time `json:"previousSession"`
+	NextSession     time.Time `json:"nextSession"`
+	Holidays        []string  `json:"holidays"`
+}
+
+// ConvertExchangeCalendarToApiReturn describes the sessions of the exchange
+// around the date, with the holidays of the same year.
+func ConvertExchangeCalendarToApiReturn(exchange calendar.Exchange,
+	date time.Time, now time.Time) ExchangeCalendarApiReturn {
+
+	var holidays []string
+
+	year := strconv.Itoa(date.Year())
+	for _, holiday := range exchange.Holidays {
+		if strings.HasPrefix(holiday, year) {
+			holidays = append(holidays, holiday)
+		}
+	}
+
+	return ExchangeCalendarApiReturn{
+		Code:            exchange.Code,
+		Name:            exchange.Name,
+		Countries:       exchange.Countries,
+		Timezone:        exchange.Timezone,
+		Open:            exchange.Open,
+		Close:           exchange.Close,
+		IsOpen:          exchange.IsOpen(now),
+		Date:            date,
+		IsTradingDay:    exchange.IsTradingDay(date),
+		PreviousSession: exchange.PreviousSession(date),
+		NextSession:     exchange.NextSession(date),
+		Holidays:        holidays,
+	}
+}
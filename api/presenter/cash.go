@@ -0,0 +1,95 @@
+package presenter
+
+import "stockfyApi/entity"
+
+type CashMovementBody struct {
+	Type        string  `json:"type"`
+	Amount      float64 `json:"amount"`
+	Currency    string  `json:"currency"`
+	Date        string  `json:"date"`
+	Description string  `json:"description"`
+	AccountId   string  `json:"accountId"`
+	ToAccountId string  `json:"toAccountId"`
+}
+
+type CashMovement struct {
+	Id          string            `json:"id"`
+	Type        string            `json:"type,omitempty"`
+	Amount      float64           `json:"amount,omitempty"`
+	Currency    string            `json:"currency,omitempty"`
+	Date        string            `json:"date,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Account     *BrokerageAccount `json:"account,omitempty"`
+	ToAccount   *BrokerageAccount `json:"toAccount,omitempty"`
+}
+
+type CashEntry struct {
+	Source      string  `json:"source"`
+	SourceId    string  `json:"sourceId"`
+	Type        string  `json:"type"`
+	Amount      float64 `json:"amount"`
+	Currency    string  `json:"currency"`
+	Date        string  `json:"date"`
+	Description string  `json:"description,omitempty"`
+	AccountId   string  `json:"accountId"`
+	Nickname    string  `json:"nickname"`
+	Balance     float64 `json:"balance"`
+}
+
+type CashBalance struct {
+	AccountId string  `json:"accountId"`
+	Nickname  string  `json:"nickname"`
+	Currency  string  `json:"currency"`
+	Balance   float64 `json:"balance"`
+}
+
+func ConvertCashMovementToApiReturn(
+	movement entity.CashMovement) CashMovement {
+
+	return CashMovement{
+		Id:          movement.Id,
+		Type:        movement.Type,
+		Amount:      movement.Amount,
+		Currency:    movement.Currency,
+		Date:        movement.Date.Format("2006-01-02"),
+		Description: movement.Description,
+		Account:     ConvertBrokerageAccountToApiReturn(movement.Account),
+		ToAccount:   ConvertBrokerageAccountToApiReturn(movement.ToAccount),
+	}
+}
+
+func ConvertCashLedgerToApiReturn(entries []entity.CashEntry) []CashEntry {
+	ledger := []CashEntry{}
+	for _, entry := range entries {
+		ledger = append(ledger, CashEntry{
+			Source:      entry.Source,
+			SourceId:    entry.SourceId,
+			Type:        entry.Type,
+			Amount:      entry.Amount,
+			Currency:    entry.Currency,
+			Date:        entry.Date.Format("2006-01-02"),
+			Description: entry.Description,
+			AccountId:   entry.AccountId,
+			Nickname:    entry.Nickname,
+			Balance:     entry.Balance,
+		})
+	}
+
+	return ledger
+}
+
+func ConvertCashBalancesToApiReturn(
+	balances []entity.CashBalance) []CashBalance {
+
+	cashBalances := []CashBalance{}
+	for _, balance := range balances {
+		cashBalances = append(cashBalances, CashBalance{
+			AccountId: balance.AccountId,
+			Nickname:  balance.Nickname,
+			Currency:  balance.Currency,
+			Balance:   balance.Balance,
+		})
+	}
+
+	return cashBalances
+}
@@ -0,0 +1,42 @@
+package presenter
+
+import (
+	"stockfyApi/entity"
+	"time"
+)
+
+type CompanyProfileApiReturn struct {
+	Logo          string     `json:"logo,omitempty"`
+	Website       string     `json:"website,omitempty"`
+	Exchange      string     `json:"exchange,omitempty"`
+	MarketCap     float64    `json:"marketCap,omitempty"`
+	PeRatio       float64    `json:"peRatio,omitempty"`
+	DividendYield float64    `json:"dividendYield,omitempty"`
+	IpoDate       *time.Time `json:"ipoDate,omitempty"`
+	UpdatedAt     *time.Time `json:"updatedAt,omitempty"`
+}
+
+func ConvertCompanyProfileToApiReturn(
+	profile *entity.CompanyProfile) *CompanyProfileApiReturn {
+
+	var updatedAt *time.Time
+
+	if profile == nil {
+		return nil
+	}
+
+	if !profile.UpdatedAt.IsZero() {
+		updatedAt = &profile.UpdatedAt
+	}
+
+	return &CompanyProfileApiReturn{
+		Logo:          profile.Logo,
+		Website:       profile.Website,
+		Exchange:      profile.Exchange,
+		MarketCap:     profile.MarketCap,
+		PeRatio:       profile.PeRatio,
+		DividendYield: profile.DividendYield,
+		IpoDate:       profile.IpoDate,
+		UpdatedAt:     updatedAt,
+	}
+}
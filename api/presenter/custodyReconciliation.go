@@ -0,0 +1,77 @@
+package presenter
+
+import "stockfyApi/entity"
+
+// ReconciliationBody has the custody positions informed by the user or a
+// position report of the B3 encoded in base64, or both.
+type ReconciliationBody struct {
+	Brokerage string            `json:"brokerage"`
+	Date      string            `json:"date"`
+	Positions []CustodyPosition `json:"positions"`
+	File      []byte            `json:"file"`
+}
+
+type CustodyPosition struct {
+	Symbol   string  `json:"symbol"`
+	Quantity float64 `json:"quantity"`
+}
+
+type CustodyReconciliation struct {
+	Symbol          string      `json:"symbol"`
+	Brokerage       string      `json:"brokerage"`
+	CustodyQuantity float64     `json:"custodyQuantity"`
+	OrdersQuantity  float64     `json:"ordersQuantity"`
+	Difference      float64     `json:"difference"`
+	Status          string      `json:"status"`
+	Fix             *CustodyFix `json:"fix,omitempty"`
+}
+
+type CustodyFix struct {
+	Action   string  `json:"action"`
+	Quantity float64 `json:"quantity,omitempty"`
+	Ratio    float64 `json:"ratio,omitempty"`
+	Date     string  `json:"date"`
+}
+
+func ConvertCustodyPositionsToEntity(
+	positions []CustodyPosition) []entity.CustodyPosition {
+
+	var custodyPositions []entity.CustodyPosition
+	for _, position := range positions {
+		custodyPositions = append(custodyPositions, entity.CustodyPosition{
+			Symbol:   position.Symbol,
+			Quantity: position.Quantity,
+		})
+	}
+
+	return custodyPositions
+}
+
+func ConvertCustodyReconciliationToApiReturn(
+	reconciliation []entity.CustodyReconciliation) []CustodyReconciliation {
+
+	custodyReconciliation := []CustodyReconciliation{}
+	for _, position := range reconciliation {
+		positionApi := CustodyReconciliation{
+			Symbol:          position.Symbol,
+			Brokerage:       position.Brokerage,
+			CustodyQuantity: position.CustodyQuantity,
+			OrdersQuantity:  position.OrdersQuantity,
+			Difference:      position.Difference,
+			Status:          position.Status,
+		}
+
+		if position.Fix != nil {
+			positionApi.Fix = &CustodyFix{
+				Action:   position.Fix.Action,
+				Quantity: position.Fix.Quantity,
+				Ratio:    position.Fix.Ratio,
+				Date:     position.Fix.Date.Format("2006-01-02"),
+			}
+		}
+
+		custodyReconciliation = append(custodyReconciliation, positionApi)
+	}
+
+	return custodyReconciliation
+}
@@ -0,0 +1,56 @@
+package presenter
+
+import (
+	"stockfyApi/entity"
+	"time"
+)
+
+type EarningEventBody struct {
+	Symbol string `json:"symbol"`
+}
+
+type EarningEventApiReturn struct {
+	Id          string          `json:"id"`
+	Type        string          `json:"type,omitempty"`
+	Amount      float64         `json:"amount,omitempty"`
+	Currency    string          `json:"currency,omitempty"`
+	ExDate      *time.Time      `json:"exDate,omitempty"`
+	PaymentDate *time.Time      `json:"paymentDate,omitempty"`
+	Asset       *AssetApiReturn `json:"asset,omitempty"`
+}
+
+func ConvertEarningEventToApiReturn(
+	events []entity.EarningEvent) []EarningEventApiReturn {
+
+	var eventsApi []EarningEventApiReturn
+
+	for _, event := range events {
+		eventApi := EarningEventApiReturn{
+			Id:       event.Id,
+			Type:     event.Type,
+			Amount:   event.Amount,
+			Currency: event.Currency,
+		}
+
+		if !event.ExDate.IsZero() {
+			exDate := event.ExDate
+			eventApi.ExDate = &exDate
+		}
+
+		if !event.PaymentDate.IsZero() {
+			paymentDate := event.PaymentDate
+			eventApi.PaymentDate = &paymentDate
+		}
+
+		if event.Asset != nil {
+			eventApi.Asset = &AssetApiReturn{
+				Id:     event.Asset.Id,
+				Symbol: event.Asset.Symbol,
+			}
+		}
+
+		eventsApi = append(eventsApi, eventApi)
+	}
+
+	return eventsApi
+}
@@ -15,12 +15,13 @@ type EarningsBody struct {
 }
 
 type EarningsApiReturn struct {
-	Id       string          `json:"id"`
-	Type     string          `json:"type,omitempty"`
-	Earning  float64         `json:"earning,omitempty"`
-	Currency string          `json:"currency,omitempty"`
-	Date     *time.Time      `json:"date,omitempty"`
-	Asset    *AssetApiReturn `json:"asset_id,omitempty"`
+	Id          string          `json:"id"`
+	Type        string          `json:"type,omitempty"`
+	Earning     float64         `json:"earning,omitempty"`
+	WithheldTax float64         `json:"withheldTax,omitempty"`
+	Currency    string          `json:"currency,omitempty"`
+	Date        *time.Time      `json:"date,omitempty"`
+	Asset       *AssetApiReturn `json:"asset_id,omitempty"`
 }
 
 func ConvertEarningToApiReturn(earningId string, earningType string,
@@ -55,6 +56,7 @@ func ConvertArrayEarningToApiReturn(earnings []entity.Earnings) []EarningsApiRet
 		earningApi := ConvertEarningToApiReturn(earning.Id, earning.Type,
 			earning.Earning, earning.Currency, earning.Date, earning.Asset.Id,
 			earning.Asset.Symbol)
+		earningApi.WithheldTax = earning.WithheldTax
 		earningsApi = append(earningsApi, earningApi)
 	}
 
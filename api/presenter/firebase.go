@@ -1,5 +1,10 @@
 package presenter
 
+import (
+	"stockfyApi/entity"
+	"time"
+)
+
 type SignUpBody struct {
 	Password    string `json:"password,omitempty"`
 	Email       string `json:"email,omitempty"`
@@ -15,6 +20,10 @@ type ForgotPasswordBody struct {
 	Email string `json:"email,omitempty"`
 }
 
+type LinkAccountBody struct {
+	IdToken string `json:"idToken"`
+}
+
 type UserRefreshIdTokenBody struct {
 	RefreshToken string `json:"refreshToken"`
 }
@@ -32,6 +41,12 @@ type UserLoginApiReturn struct {
 	Expiration   string `json:"expiration"`
 }
 
+type UserIdentityApiReturn struct {
+	Uid        string    `json:"uid"`
+	ProviderId string    `json:"providerId"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
 type UserRefreshTokenApiReturn struct {
 	RefreshToken string `json:"refreshToken"`
 	IdToken      string `json:"idToken"`
@@ -66,3 +81,18 @@ func ConvertUserRefreshTokenToUserRefreshTokenApiReturn(refreshToken string,
 		Expiration:   expiration,
 	}
 }
+
+func ConvertUserIdentitiesToApiReturn(
+	identities []entity.UserIdentity) []UserIdentityApiReturn {
+
+	identitiesApi := []UserIdentityApiReturn{}
+	for _, identity := range identities {
+		identitiesApi = append(identitiesApi, UserIdentityApiReturn{
+			Uid:        identity.FirebaseUid,
+			ProviderId: identity.ProviderId,
+			CreatedAt:  identity.CreatedAt,
+		})
+	}
+
+	return identitiesApi
+}
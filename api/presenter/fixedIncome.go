@@ -0,0 +1,126 @@
+package presenter
+
+import (
+	"stockfyApi/entity"
+	"time"
+)
+
+type FixedIncomeBody struct {
+	Symbol   string  `json:"symbol"`
+	Fullname string  `json:"fullname"`
+	Category string  `json:"category"`
+	Issuer   string  `json:"issuer"`
+	Indexer  string  `json:"indexer"`
+	Rate     float64 `json:"rate"`
+	Maturity string  `json:"maturity"`
+}
+
+type IndexSeriesBody struct {
+	Indexer string  `json:"indexer"`
+	Date    string  `json:"date"`
+	Value   float64 `json:"value"`
+}
+
+type FixedIncomePosition struct {
+	Quantity          float64 `json:"quantity"`
+	InvestedValue     float64 `json:"investedValue"`
+	GrossValue        float64 `json:"grossValue"`
+	IncomeTax         float64 `json:"incomeTax"`
+	NetValue          float64 `json:"netValue"`
+	RedeemedValue     float64 `json:"redeemedValue"`
+	RedeemedIncomeTax float64 `json:"redeemedIncomeTax"`
+}
+
+type FixedIncomeApiReturn struct {
+	Id       string               `json:"id,omitempty"`
+	Category string               `json:"category,omitempty"`
+	Issuer   string               `json:"issuer,omitempty"`
+	Indexer  string               `json:"indexer,omitempty"`
+	Rate     float64              `json:"rate,omitempty"`
+	Maturity *time.Time           `json:"maturity,omitempty"`
+	Asset    *AssetApiReturn      `json:"asset,omitempty"`
+	Position *FixedIncomePosition `json:"position,omitempty"`
+}
+
+type IndexSeriesApiReturn struct {
+	Indexer string    `json:"indexer"`
+	Date    time.Time `json:"date"`
+	Value   float64   `json:"value"`
+}
+
+func ConvertFixedIncomeToApiReturn(
+	fixedIncome *entity.FixedIncome) *FixedIncomeApiReturn {
+
+	var maturity *time.Time
+	var asset *AssetApiReturn
+	var position *FixedIncomePosition
+
+	if fixedIncome == nil {
+		return nil
+	}
+
+	if !fixedIncome.Maturity.IsZero() {
+		maturity = &fixedIncome.Maturity
+	}
+
+	if fixedIncome.Asset != nil {
+		asset = &AssetApiReturn{
+			Id:       fixedIncome.Asset.Id,
+			Symbol:   fixedIncome.Asset.Symbol,
+			Fullname: fixedIncome.Asset.Fullname,
+		}
+	}
+
+	if fixedIncome.Position != nil {
+		position = &FixedIncomePosition{
+			Quantity:          fixedIncome.Position.Quantity,
+			InvestedValue:     fixedIncome.Position.InvestedValue,
+			GrossValue:        fixedIncome.Position.GrossValue,
+			IncomeTax:         fixedIncome.Position.IncomeTax,
+			NetValue:          fixedIncome.Position.NetValue,
+			RedeemedValue:     fixedIncome.Position.RedeemedValue,
+			RedeemedIncomeTax: fixedIncome.Position.RedeemedIncomeTax,
+		}
+	}
+
+	return &FixedIncomeApiReturn{
+		Id:       fixedIncome.Id,
+		Category: fixedIncome.Category,
+		Issuer:   fixedIncome.Issuer,
+		Indexer:  fixedIncome.Indexer,
+		Rate:     fixedIncome.Rate,
+		Maturity: maturity,
+		Asset:    asset,
+		Position: position,
+	}
+}
+
+func ConvertIndexSeriesBodyToEntity(
+	seriesBody []IndexSeriesBody) []entity.IndexSeries {
+
+	series := []entity.IndexSeries{}
+	for _, index := range seriesBody {
+		series = append(series, entity.IndexSeries{
+			Indexer: index.Indexer,
+			Date:    entity.StringToTime(index.Date),
+			Value:   index.Value,
+		})
+	}
+
+	return series
+}
+
+func ConvertArrayIndexSeriesToApiReturn(
+	series []entity.IndexSeries) []IndexSeriesApiReturn {
+
+	seriesApi := []IndexSeriesApiReturn{}
+	for _, index := range series {
+		seriesApi = append(seriesApi, IndexSeriesApiReturn{
+			Indexer: index.Indexer,
+			Date:    index.Date,
+			Value:   index.Value,
+		})
+	}
+
+	return seriesApi
+}
@@ -0,0 +1,76 @@
+package presenter
+
+import (
+	"stockfyApi/entity"
+	"time"
+)
+
+type ProjectedIncomeApiReturn struct {
+	Asset     *AssetApiReturn `json:"asset,omitempty"`
+	Currency  string          `json:"currency"`
+	Confirmed float64         `json:"confirmed"`
+	Estimated float64         `json:"estimated"`
+}
+
+type IncomeProjectionMonthApiReturn struct {
+	Month  time.Time                  `json:"month"`
+	Totals []ProjectedIncomeApiReturn `json:"totals"`
+	Assets []ProjectedIncomeApiReturn `json:"assets"`
+}
+
+type IncomeProjectionApiReturn struct {
+	StartDate time.Time                        `json:"startDate"`
+	EndDate   time.Time                        `json:"endDate"`
+	Totals    []ProjectedIncomeApiReturn       `json:"totals"`
+	Months    []IncomeProjectionMonthApiReturn `json:"months"`
+}
+
+func convertProjectedIncomeToApiReturn(
+	incomes []entity.ProjectedIncome) []ProjectedIncomeApiReturn {
+
+	var incomesApi []ProjectedIncomeApiReturn
+
+	for _, income := range incomes {
+		incomeApi := ProjectedIncomeApiReturn{
+			Currency:  income.Currency,
+			Confirmed: income.Confirmed,
+			Estimated: income.Estimated,
+		}
+
+		if income.Asset != nil {
+			incomeApi.Asset = &AssetApiReturn{
+				Id:     income.Asset.Id,
+				Symbol: income.Asset.Symbol,
+			}
+		}
+
+		incomesApi = append(incomesApi, incomeApi)
+	}
+
+	return incomesApi
+}
+
+func ConvertIncomeProjectionToApiReturn(
+	projection *entity.IncomeProjection) *IncomeProjectionApiReturn {
+
+	if projection == nil {
+		return nil
+	}
+
+	projectionApi := &IncomeProjectionApiReturn{
+		StartDate: projection.StartDate,
+		EndDate:   projection.EndDate,
+		Totals:    convertProjectedIncomeToApiReturn(projection.Totals),
+	}
+
+	for _, month := range projection.Months {
+		projectionApi.Months = append(projectionApi.Months,
+			IncomeProjectionMonthApiReturn{
+				Month:  month.Month,
+				Totals: convertProjectedIncomeToApiReturn(month.Totals),
+				Assets: convertProjectedIncomeToApiReturn(month.Assets),
+			})
+	}
+
+	return projectionApi
+}
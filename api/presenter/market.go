@@ -0,0 +1,31 @@
+package presenter
+
+import "stockfyApi/entity"
+
+type Market struct {
+	Country           string   `json:"country"`
+	Name              string   `json:"name"`
+	Currencies        []string `json:"currencies"`
+	ReferenceCurrency string   `json:"referenceCurrency,omitempty"`
+	SymbolSuffix      string   `json:"symbolSuffix,omitempty"`
+	QuantityDecimals  int      `json:"quantityDecimals"`
+	AssetTypes        []string `json:"assetTypes"`
+}
+
+func ConvertArrayMarketToApiReturn(markets []entity.Market) []Market {
+	var marketsConverted []Market
+
+	for _, market := range markets {
+		marketsConverted = append(marketsConverted, Market{
+			Country:           market.Country,
+			Name:              market.Name,
+			Currencies:        market.Currencies,
+			ReferenceCurrency: market.ReferenceCurrency,
+			SymbolSuffix:      market.SymbolSuffix,
+			QuantityDecimals:  market.QuantityDecimals,
+			AssetTypes:        market.AssetTypes,
+		})
+	}
+
+	return marketsConverted
+}
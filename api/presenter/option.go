@@ -0,0 +1,68 @@
+package presenter
+
+import (
+	"stockfyApi/entity"
+	"time"
+)
+
+type OptionBody struct {
+	Symbol     string  `json:"symbol"`
+	Underlying string  `json:"underlying"`
+	OptionType string  `json:"optionType"`
+	Strike     float64 `json:"strike"`
+	Expiry     string  `json:"expiry"`
+}
+
+type OptionExpiryBody struct {
+	Date string `json:"date"`
+}
+
+type OptionApiReturn struct {
+	Id         string          `json:"id,omitempty"`
+	OptionType string          `json:"optionType,omitempty"`
+	Strike     float64         `json:"strike,omitempty"`
+	Expiry     *time.Time      `json:"expiry,omitempty"`
+	Settled    bool            `json:"settled"`
+	Asset      *AssetApiReturn `json:"asset,omitempty"`
+	Underlying *AssetApiReturn `json:"underlying,omitempty"`
+}
+
+func ConvertOptionToApiReturn(option *entity.Option) *OptionApiReturn {
+	var expiry *time.Time
+	var asset *AssetApiReturn
+	var underlying *AssetApiReturn
+
+	if option == nil {
+		return nil
+	}
+
+	if !option.Expiry.IsZero() {
+		expiry = &option.Expiry
+	}
+
+	if option.Asset != nil {
+		asset = &AssetApiReturn{
+			Id:       option.Asset.Id,
+			Symbol:   option.Asset.Symbol,
+			Fullname: option.Asset.Fullname,
+		}
+	}
+
+	if option.Underlying != nil {
+		underlying = &AssetApiReturn{
+			Id:       option.Underlying.Id,
+			Symbol:   option.Underlying.Symbol,
+			Fullname: option.Underlying.Fullname,
+		}
+	}
+
+	return &OptionApiReturn{
+		Id:         option.Id,
+		OptionType: option.OptionType,
+		Strike:     option.Strike,
+		Expiry:     expiry,
+		Settled:    option.Settled,
+		Asset:      asset,
+		Underlying: underlying,
+	}
+}
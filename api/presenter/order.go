@@ -9,6 +9,7 @@ type OrderBody struct {
 	Symbol    string  `json:"symbol"`
 	Fullname  string  `json:"fullname"`
 	Brokerage string  `json:"brokerage"`
+	AccountId string  `json:"accountId"`
 	Quantity  float64 `json:"quantity"`
 	Price     float64 `json:"price"`
 	Currency  string  `json:"currency"`
@@ -18,15 +19,32 @@ type OrderBody struct {
 	AssetType string  `json:"assetType"`
 }
 
+type OrderImportBody struct {
+	File      string            `json:"file"`
+	Mapping   map[string]string `json:"mapping"`
+	Delimiter string            `json:"delimiter"`
+}
+
+type TradeNoteBody struct {
+	Text      string            `json:"text"`
+	Brokerage string            `json:"brokerage"`
+	Symbols   map[string]string `json:"symbols"`
+	Confirm   bool              `json:"confirm"`
+}
+
 type OrderApiReturn struct {
-	Id        string          `json:"id,omitempty"`
-	Quantity  float64         `json:"quantity,omitempty"`
-	Price     float64         `json:"price,omitempty"`
-	Currency  string          `json:"currency,omitempty"`
-	OrderType string          `json:"orderType,omitempty"`
-	Date      time.Time       `json:"date,omitempty"`
-	Brokerage *Brokerage      `json:"brokerage,omitempty"`
-	Asset     *AssetApiReturn `json:"asset,omitempty"`
+	Id             string            `json:"id,omitempty"`
+	Quantity       float64           `json:"quantity,omitempty"`
+	Price          float64           `json:"price,omitempty"`
+	Currency       string            `json:"currency,omitempty"`
+	OrderType      string            `json:"orderType,omitempty"`
+	Date           time.Time         `json:"date,omitempty"`
+	Fees           float64           `json:"fees,omitempty"`
+	WithheldTax    float64           `json:"withheldTax,omitempty"`
+	SettlementDate *time.Time        `json:"settlementDate,omitempty"`
+	Brokerage      *Brokerage        `json:"brokerage,omitempty"`
+	Account        *BrokerageAccount `json:"account,omitempty"`
+	Asset          *AssetApiReturn   `json:"asset,omitempty"`
 }
 
 type OrderInfos struct {
@@ -43,14 +61,18 @@ func ConvertOrderToApiReturn(orders []entity.Order) []OrderApiReturn {
 
 	for _, o := range orders {
 		convertedOrder := OrderApiReturn{
-			Id:        o.Id,
-			Quantity:  o.Quantity,
-			Price:     o.Price,
-			Currency:  o.Currency,
-			OrderType: o.OrderType,
-			Date:      o.Date,
+			Id:             o.Id,
+			Quantity:       o.Quantity,
+			Price:          o.Price,
+			Currency:       o.Currency,
+			OrderType:      o.OrderType,
+			Date:           o.Date,
+			Fees:           o.Fees,
+			WithheldTax:    o.WithheldTax,
+			SettlementDate: o.SettlementDate,
 			Brokerage: ConvertBrokerageToApiReturn(o.Brokerage.Id,
 				o.Brokerage.Name, o.Brokerage.Country),
+			Account: ConvertBrokerageAccountToApiReturn(o.Account),
 		}
 
 		convertedOrders = append(convertedOrders, convertedOrder)
@@ -63,26 +85,34 @@ func ConvertSingleOrderToApiReturn(order entity.Order) OrderApiReturn {
 
 	if order.Asset == nil {
 		return OrderApiReturn{
-			Id:        order.Id,
-			Quantity:  order.Quantity,
-			Price:     order.Price,
-			Currency:  order.Currency,
-			OrderType: order.OrderType,
-			Date:      order.Date,
+			Id:             order.Id,
+			Quantity:       order.Quantity,
+			Price:          order.Price,
+			Currency:       order.Currency,
+			OrderType:      order.OrderType,
+			Date:           order.Date,
+			Fees:           order.Fees,
+			WithheldTax:    order.WithheldTax,
+			SettlementDate: order.SettlementDate,
 			Brokerage: ConvertBrokerageToApiReturn(order.Brokerage.Id,
 				order.Brokerage.Name, order.Brokerage.Country),
+			Account: ConvertBrokerageAccountToApiReturn(order.Account),
 		}
 	}
 
 	return OrderApiReturn{
-		Id:        order.Id,
-		Quantity:  order.Quantity,
-		Price:     order.Price,
-		Currency:  order.Currency,
-		OrderType: order.OrderType,
-		Date:      order.Date,
+		Id:             order.Id,
+		Quantity:       order.Quantity,
+		Price:          order.Price,
+		Currency:       order.Currency,
+		OrderType:      order.OrderType,
+		Date:           order.Date,
+		Fees:           order.Fees,
+		WithheldTax:    order.WithheldTax,
+		SettlementDate: order.SettlementDate,
 		Brokerage: ConvertBrokerageToApiReturn(order.Brokerage.Id,
 			order.Brokerage.Name, order.Brokerage.Country),
+		Account: ConvertBrokerageAccountToApiReturn(order.Account),
 		Asset: &AssetApiReturn{
 			Id:       order.Asset.Id,
 			Symbol:   order.Asset.Symbol,
@@ -105,3 +135,110 @@ func ConvertOrderInfoToApiReturn(totalQuantity *float64, weightedAdjPrice *float
 		WeightedAveragePrice: *weightedAveragePrice,
 	}
 }
+
+type OrderImportRow struct {
+	Line           int      `json:"line"`
+	Symbol         string   `json:"symbol,omitempty"`
+	Country        string   `json:"country,omitempty"`
+	OrderType      string   `json:"orderType,omitempty"`
+	Quantity       float64  `json:"quantity,omitempty"`
+	Price          float64  `json:"price,omitempty"`
+	Currency       string   `json:"currency,omitempty"`
+	Brokerage      string   `json:"brokerage,omitempty"`
+	Date           string   `json:"date,omitempty"`
+	Fees           float64  `json:"fees,omitempty"`
+	WithheldTax    float64  `json:"withheldTax,omitempty"`
+	SettlementDate string   `json:"settlementDate,omitempty"`
+	NewAsset       bool     `json:"newAsset,omitempty"`
+	Duplicate      bool     `json:"duplicate,omitempty"`
+	Errors         []string `json:"errors,omitempty"`
+}
+
+func ConvertOrderImportRowToApiReturn(rows []entity.OrderImportRow) []OrderImportRow {
+	if rows == nil {
+		return nil
+	}
+
+	convertedRows := []OrderImportRow{}
+	for _, row := range rows {
+		convertedRows = append(convertedRows, OrderImportRow{
+			Line:           row.Line,
+			Symbol:         row.Symbol,
+			Country:        row.Country,
+			OrderType:      row.OrderType,
+			Quantity:       row.Quantity,
+			Price:          row.Price,
+			Currency:       row.Currency,
+			Brokerage:      row.Brokerage,
+			Date:           row.Date,
+			Fees:           row.Fees,
+			WithheldTax:    row.WithheldTax,
+			SettlementDate: row.SettlementDate,
+			NewAsset:       row.NewAsset,
+			Duplicate:      row.Duplicate,
+			Errors:         row.Errors,
+		})
+	}
+
+	return convertedRows
+}
+
+type TradeNote struct {
+	Number         string           `json:"number"`
+	Brokerage      string           `json:"brokerage,omitempty"`
+	TradeDate      time.Time        `json:"tradeDate"`
+	SettlementDate time.Time        `json:"settlementDate"`
+	Fees           float64          `json:"fees,omitempty"`
+	WithheldTax    float64          `json:"withheldTax,omitempty"`
+	NetAmount      float64          `json:"netAmount"`
+	Orders         []TradeNoteOrder `json:"orders"`
+}
+
+type TradeNoteOrder struct {
+	Market        string  `json:"market"`
+	OrderType     string  `json:"orderType"`
+	Specification string  `json:"specification"`
+	Symbol        string  `json:"symbol,omitempty"`
+	Quantity      float64 `json:"quantity"`
+	Price         float64 `json:"price"`
+	Amount        float64 `json:"amount"`
+	Fees          float64 `json:"fees,omitempty"`
+	WithheldTax   float64 `json:"withheldTax,omitempty"`
+}
+
+func ConvertTradeNoteToApiReturn(notes []entity.TradeNote) []TradeNote {
+	if notes == nil {
+		return nil
+	}
+
+	convertedNotes := []TradeNote{}
+	for _, note := range notes {
+		convertedOrders := []TradeNoteOrder{}
+		for _, order := range note.Orders {
+			convertedOrders = append(convertedOrders, TradeNoteOrder{
+				Market:        order.Market,
+				OrderType:     order.OrderType,
+				Specification: order.Specification,
+				Symbol:        order.Symbol,
+				Quantity:      order.Quantity,
+				Price:         order.Price,
+				Amount:        order.Amount,
+				Fees:          order.Fees,
+				WithheldTax:   order.WithheldTax,
+			})
+		}
+
+		convertedNotes = append(convertedNotes, TradeNote{
+			Number:         note.Number,
+			Brokerage:      note.Brokerage,
+			TradeDate:      note.TradeDate,
+			SettlementDate: note.SettlementDate,
+			Fees:           note.Fees,
+			WithheldTax:    note.WithheldTax,
+			NetAmount:      note.NetAmount,
+			Orders:         convertedOrders,
+		})
+	}
+
+	return convertedNotes
+}
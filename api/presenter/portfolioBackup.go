@@ -0,0 +1,24 @@
+package presenter
+
+import "stockfyApi/entity"
+
+type BackupRestore struct {
+	Accounts        int `json:"accounts"`
+	Assets          int `json:"assets"`
+	Orders          int `json:"orders"`
+	Earnings        int `json:"earnings"`
+	SkippedOrders   int `json:"skippedOrders"`
+	SkippedEarnings int `json:"skippedEarnings"`
+}
+
+func ConvertBackupRestoreToApiReturn(
+	restore entity.BackupRestore) BackupRestore {
+	return BackupRestore{
+		Accounts:        restore.Accounts,
+		Assets:          restore.Assets,
+		Orders:          restore.Orders,
+		Earnings:        restore.Earnings,
+		SkippedOrders:   restore.SkippedOrders,
+		SkippedEarnings: restore.SkippedEarnings,
+	}
+}
@@ -0,0 +1,55 @@
+package presenter
+
+import "stockfyApi/entity"
+
+type PortfolioExport struct {
+	Assets    []AssetApiReturn    `json:"assets"`
+	Orders    []OrderApiReturn    `json:"orders"`
+	Earnings  []EarningsApiReturn `json:"earnings"`
+	Positions []AccountPositions  `json:"positions"`
+}
+
+func ConvertPortfolioExportToApiReturn(
+	export entity.PortfolioExport) PortfolioExport {
+
+	exportApi := PortfolioExport{
+		Assets:    []AssetApiReturn{},
+		Orders:    []OrderApiReturn{},
+		Earnings:  []EarningsApiReturn{},
+		Positions: ConvertAccountPositionToApiReturn(export.Positions),
+	}
+
+	for _, asset := range export.Assets {
+		var preference string
+		if asset.Preference != nil {
+			preference = *asset.Preference
+		}
+
+		sector := entity.Sector{}
+		if asset.Sector != nil {
+			sector = *asset.Sector
+		}
+
+		assetType := entity.AssetType{}
+		if asset.AssetType != nil {
+			assetType = *asset.AssetType
+		}
+
+		exportApi.Assets = append(exportApi.Assets, ConvertAssetToApiReturn(
+			asset.Id, preference, asset.Fullname, asset.Symbol, sector.Name,
+			sector.Id, assetType.Id, assetType.Type, assetType.Country,
+			assetType.Name, nil, nil, nil))
+	}
+
+	for _, order := range export.Orders {
+		exportApi.Orders = append(exportApi.Orders,
+			ConvertSingleOrderToApiReturn(order))
+	}
+
+	if earnings := ConvertArrayEarningToApiReturn(
+		export.Earnings); earnings != nil {
+		exportApi.Earnings = earnings
+	}
+
+	return exportApi
+}
@@ -0,0 +1,95 @@
+package presenter
+
+import "stockfyApi/entity"
+
+type RemittanceBody struct {
+	SentAmount     float64 `json:"sentAmount"`
+	SentCurrency   string  `json:"sentCurrency"`
+	ReceivedAmount float64 `json:"receivedAmount"`
+	Iof            float64 `json:"iof"`
+	Fees           float64 `json:"fees"`
+	CommercialRate float64 `json:"commercialRate"`
+	Date           string  `json:"date"`
+	FromAccountId  string  `json:"fromAccountId"`
+	ToAccountId    string  `json:"toAccountId"`
+}
+
+type Remittance struct {
+	Id               string            `json:"id"`
+	SentAmount       float64           `json:"sentAmount"`
+	SentCurrency     string            `json:"sentCurrency"`
+	ReceivedAmount   float64           `json:"receivedAmount"`
+	ReceivedCurrency string            `json:"receivedCurrency"`
+	Iof              float64           `json:"iof"`
+	Fees             float64           `json:"fees"`
+	CommercialRate   float64           `json:"commercialRate,omitempty"`
+	EffectiveRate    float64           `json:"effectiveRate"`
+	ExchangeRate     float64           `json:"exchangeRate"`
+	Spread           float64           `json:"spread,omitempty"`
+	IofRate          float64           `json:"iofRate"`
+	Date             string            `json:"date"`
+	FromAccount      *BrokerageAccount `json:"fromAccount,omitempty"`
+	ToAccount        *BrokerageAccount `json:"toAccount,omitempty"`
+}
+
+type BrlCostBasis struct {
+	Symbol            string  `json:"symbol"`
+	Currency          string  `json:"currency"`
+	Quantity          float64 `json:"quantity"`
+	Invested          float64 `json:"invested"`
+	BrlInvested       float64 `json:"brlInvested"`
+	FxRate            float64 `json:"fxRate"`
+	OrdersWithoutRate int     `json:"ordersWithoutRate,omitempty"`
+}
+
+func ConvertRemittanceToApiReturn(remittance entity.Remittance) Remittance {
+	return Remittance{
+		Id:               remittance.Id,
+		SentAmount:       remittance.SentAmount,
+		SentCurrency:     remittance.SentCurrency,
+		ReceivedAmount:   remittance.ReceivedAmount,
+		ReceivedCurrency: remittance.ReceivedCurrency,
+		Iof:              remittance.Iof,
+		Fees:             remittance.Fees,
+		CommercialRate:   remittance.CommercialRate,
+		EffectiveRate:    remittance.EffectiveRate(),
+		ExchangeRate:     remittance.ExchangeRate(),
+		Spread:           remittance.Spread(),
+		IofRate:          remittance.IofRate(),
+		Date:             remittance.Date.Format("2006-01-02"),
+		FromAccount: ConvertBrokerageAccountToApiReturn(
+			remittance.FromAccount),
+		ToAccount: ConvertBrokerageAccountToApiReturn(remittance.ToAccount),
+	}
+}
+
+func ConvertRemittancesToApiReturn(
+	remittances []entity.Remittance) []Remittance {
+
+	remittancesApi := []Remittance{}
+	for _, remittance := range remittances {
+		remittancesApi = append(remittancesApi,
+			ConvertRemittanceToApiReturn(remittance))
+	}
+
+	return remittancesApi
+}
+
+func ConvertBrlCostBasisToApiReturn(
+	costBasis []entity.BrlCostBasis) []BrlCostBasis {
+
+	costBasisApi := []BrlCostBasis{}
+	for _, position := range costBasis {
+		costBasisApi = append(costBasisApi, BrlCostBasis{
+			Symbol:            position.Symbol,
+			Currency:          position.Currency,
+			Quantity:          position.Quantity,
+			Invested:          position.Invested,
+			BrlInvested:       position.BrlInvested,
+			FxRate:            position.FxRate,
+			OrdersWithoutRate: position.OrdersWithoutRate,
+		})
+	}
+
+	return costBasisApi
+}
@@ -1,7 +1,24 @@
 package presenter
 
+import "stockfyApi/entity"
+
 type SectorBody struct {
-	Sector string `json:"sector"`
+	Sector   string `json:"sector"`
+	Level    string `json:"level"`
+	Code     string `json:"code"`
+	ParentId string `json:"parentId"`
+}
+
+type SectorMappingBody struct {
+	SectorId string `json:"sectorId"`
+}
+
+type SectorUpdateBody struct {
+	Name string `json:"name"`
+}
+
+type SectorMergeBody struct {
+	TargetId string `json:"targetId"`
 }
 
 type Sector struct {
@@ -9,6 +26,30 @@ type Sector struct {
 	Name string `json:"name,omitempty"`
 }
 
+type SectorNodeApiReturn struct {
+	Id       string                `json:"id"`
+	Name     string                `json:"name"`
+	Level    string                `json:"level"`
+	Code     string                `json:"code,omitempty"`
+	ParentId *string               `json:"parentId,omitempty"`
+	Children []SectorNodeApiReturn `json:"children,omitempty"`
+}
+
+type SectorMappingApiReturn struct {
+	Id       string               `json:"id"`
+	Provider string               `json:"provider"`
+	Label    string               `json:"label"`
+	Sector   *SectorNodeApiReturn `json:"sector"`
+}
+
+type SectorAllocationApiReturn struct {
+	Sector     *SectorNodeApiReturn `json:"sector"`
+	Currency   string               `json:"currency"`
+	Invested   float64              `json:"invested"`
+	Percentage float64              `json:"percentage"`
+	Symbols    []string             `json:"symbols"`
+}
+
 func ConvertSectorToApiReturn(id string, name string) *Sector {
 	if id == "" && name == "" {
 		return nil
@@ -19,3 +60,82 @@ func ConvertSectorToApiReturn(id string, name string) *Sector {
 		Name: name,
 	}
 }
+
+func ConvertSectorNodeToApiReturn(sector *entity.Sector) *SectorNodeApiReturn {
+	if sector == nil {
+		return nil
+	}
+
+	return &SectorNodeApiReturn{
+		Id:       sector.Id,
+		Name:     sector.Name,
+		Level:    sector.Level,
+		Code:     sector.Code,
+		ParentId: sector.ParentId,
+	}
+}
+
+// ConvertSectorTreeToApiReturn nests each node of the taxonomy inside its
+// parent. The nodes without a parent are the roots of the tree.
+func ConvertSectorTreeToApiReturn(
+	sectors []entity.Sector) []SectorNodeApiReturn {
+
+	childrenPerParent := map[string][]entity.Sector{}
+	var roots []entity.Sector
+	for _, sector := range sectors {
+		if sector.ParentId == nil {
+			roots = append(roots, sector)
+			continue
+		}
+
+		childrenPerParent[*sector.ParentId] = append(
+			childrenPerParent[*sector.ParentId], sector)
+	}
+
+	var convertNodes func(nodes []entity.Sector) []SectorNodeApiReturn
+	convertNodes = func(nodes []entity.Sector) []SectorNodeApiReturn {
+		var nodesReturn []SectorNodeApiReturn
+		for i := range nodes {
+			node := ConvertSectorNodeToApiReturn(&nodes[i])
+			node.Children = convertNodes(childrenPerParent[nodes[i].Id])
+			nodesReturn = append(nodesReturn, *node)
+		}
+
+		return nodesReturn
+	}
+
+	return convertNodes(roots)
+}
+
+func ConvertSectorMappingToApiReturn(
+	mappings []entity.SectorMapping) []SectorMappingApiReturn {
+
+	mappingsReturn := []SectorMappingApiReturn{}
+	for i := range mappings {
+		mappingsReturn = append(mappingsReturn, SectorMappingApiReturn{
+			Id:       mappings[i].Id,
+			Provider: mappings[i].Provider,
+			Label:    mappings[i].Label,
+			Sector:   ConvertSectorNodeToApiReturn(mappings[i].Sector),
+		})
+	}
+
+	return mappingsReturn
+}
+
+func ConvertSectorAllocationToApiReturn(
+	allocations []entity.SectorAllocation) []SectorAllocationApiReturn {
+
+	allocationsReturn := []SectorAllocationApiReturn{}
+	for _, allocation := range allocations {
+		allocationsReturn = append(allocationsReturn, SectorAllocationApiReturn{
+			Sector:     ConvertSectorNodeToApiReturn(allocation.Sector),
+			Currency:   allocation.Currency,
+			Invested:   allocation.Invested,
+			Percentage: allocation.Percentage,
+			Symbols:    allocation.Symbols,
+		})
+	}
+
+	return allocationsReturn
+}
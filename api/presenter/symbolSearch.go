@@ -0,0 +1,28 @@
+package presenter
+
+import "stockfyApi/entity"
+
+type SymbolSuggestionApiReturn struct {
+	Symbol   string  `json:"symbol"`
+	Fullname string  `json:"fullname"`
+	Type     string  `json:"type"`
+	Country  string  `json:"country"`
+	AssetId  *string `json:"assetId,omitempty"`
+}
+
+func ConvertSymbolSuggestionToApiReturn(
+	suggestions []entity.SymbolSuggestion) []SymbolSuggestionApiReturn {
+
+	suggestionsReturn := []SymbolSuggestionApiReturn{}
+	for _, suggestion := range suggestions {
+		suggestionsReturn = append(suggestionsReturn, SymbolSuggestionApiReturn{
+			Symbol:   suggestion.Symbol,
+			Fullname: suggestion.Fullname,
+			Type:     suggestion.Type,
+			Country:  suggestion.Country,
+			AssetId:  suggestion.AssetId,
+		})
+	}
+
+	return suggestionsReturn
+}
@@ -0,0 +1,70 @@
+package router
+
+import (
+	"log"
+	"sort"
+	"time"
+)
+
+// The options expire at the end of the B3 trading session, so the expired
+// options are settled once a day after the market closes, in the B3 time. The
+// company profiles and the earning events are refreshed overnight to spread
+// the requests to the providers.
+const (
+	optionsExpiryHour   = 19
+	companyProfilesHour = 3
+	earningEventsHour   = 4
+)
+
+// b3Location is the time zone of the B3 sessions. Brazil has no daylight saving
+// time since 2019, so the fixed offset is used when the time zone database is
+// not available.
+var b3Location = loadLocation("America/Sao_Paulo", -3*60*60)
+
+func loadLocation(name string, offset int) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return time.FixedZone(name, offset)
+	}
+
+	return location
+}
+
+func nextRun(now time.Time, hour int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0,
+		now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+
+	return next
+}
+
+// dailyJob executes the job every day at the given hour of the location until
+// the API stops.
+func dailyJob(name string, hour int, location *time.Location,
+	job func() error) {
+	for {
+		time.Sleep(time.Until(nextRun(time.Now().In(location), hour)))
+
+		if err := job(); err != nil {
+			log.Println(name+": ", err)
+		}
+	}
+}
+
+// logSkipped logs the items a job skipped because of their errors, like the
+// options without the close of the underlying asset or the assets whose
+// earning events were not fetched. They are retried by the next run of the
+// job.
+func logSkipped(name string, skipped map[string]error) {
+	items := make([]string, 0, len(skipped))
+	for item := range skipped {
+		items = append(items, item)
+	}
+	sort.Strings(items)
+
+	for _, item := range items {
+		log.Println(name+": "+item+": ", skipped[item])
+	}
+}
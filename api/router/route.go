@@ -10,6 +10,7 @@ import (
 	"stockfyApi/token"
 	"stockfyApi/usecases"
 	"stockfyApi/usecases/logicApi"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -43,6 +44,7 @@ func fiberRoutes(config Config, usecases *usecases.Applications,
 	// REST API Handlers
 	sector := fiberHandlers.SectorApi{
 		ApplicationLogic: *usecases,
+		LogicApi:         logicApiUseCases,
 	}
 	assetTypes := fiberHandlers.AssetTypeApi{
 		ApplicationLogic: *usecases,
@@ -60,11 +62,51 @@ func fiberRoutes(config Config, usecases *usecases.Applications,
 	}
 	brokerage := fiberHandlers.BrokerageApi{
 		ApplicationLogic: *usecases,
+		LogicApi:         logicApiUseCases,
 	}
 	earnings := fiberHandlers.EarningsApi{
 		ApplicationLogic: *usecases,
 		ApiLogic:         logicApiUseCases,
 	}
+	market := fiberHandlers.MarketApi{
+		ApplicationLogic: *usecases,
+	}
+	fixedIncome := fiberHandlers.FixedIncomeApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApiUseCases,
+	}
+	option := fiberHandlers.OptionApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApiUseCases,
+	}
+	earningEvent := fiberHandlers.EarningEventApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApiUseCases,
+	}
+	export := fiberHandlers.ExportApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApiUseCases,
+	}
+	backup := fiberHandlers.BackupApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApiUseCases,
+	}
+	reconciliation := fiberHandlers.ReconciliationApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApiUseCases,
+	}
+	cash := fiberHandlers.CashApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApiUseCases,
+	}
+	remittance := fiberHandlers.RemittanceApi{
+		ApplicationLogic: *usecases,
+		LogicApi:         logicApiUseCases,
+	}
+	calendarApi := fiberHandlers.CalendarApi{}
+	auditLog := fiberHandlers.AuditLogApi{
+		ApplicationLogic: *usecases,
+	}
 	users := fiberHandlers.UsersApi{
 		ApplicationLogic: *usecases,
 		FirebaseWebKey:   config.FirebaseWebKey,
@@ -111,36 +153,144 @@ func fiberRoutes(config Config, usecases *usecases.Applications,
 	api.Delete("/delete-user", users.DeleteUser)
 	api.Put("/update-user", users.UpdateUserInfo)
 
+	// REST API to link the users of other authentication providers
+	api.Post("/link-account", users.LinkAccount)
+	api.Get("/linked-accounts", users.GetLinkedAccounts)
+
 	// REST API for the assets table
 	api.Get("/asset-lookup", asset.GetSymbolLookup)
+	api.Get("/asset-search", asset.SearchSymbols)
 	api.Get("/asset-price", asset.GetSymbolPrice)
+	api.Post("/asset-prices", asset.GetSymbolPrices)
 	api.Get("/asset/:symbol", asset.GetAsset)
+	api.Get("/asset/:symbol/profile", asset.GetAssetProfile)
 	api.Post("/asset", asset.CreateAsset)
+	api.Put("/asset/:symbol", asset.UpdateAsset)
 	api.Delete("/asset/:symbol", asset.DeleteAsset)
 
+	// REST API for the markets table
+	api.Get("/markets", market.GetMarkets)
+
 	// REST API for the asset types table
 	api.Get("/asset-types", assetTypes.GetAssetTypes)
+	api.Post("/asset-types", assetTypes.CreateAssetType)
+	api.Put("/asset-types/:id", assetTypes.UpdateAssetType)
+	api.Delete("/asset-types/:id", assetTypes.DeleteAssetType)
 
 	// REST API to for the sector table
+	api.Get("/sectors", sector.GetSectors)
+	api.Get("/sectors/allocation", sector.GetSectorAllocation)
 	api.Get("/sector/:sector", sector.GetSector)
 	api.Post("/sector", sector.CreateSector)
+	api.Put("/sector/:id", sector.UpdateSector)
+	api.Post("/sector/:id/merge", sector.MergeSector)
+	api.Get("/sector-mappings", sector.GetSectorMappings)
+	api.Put("/sector-mappings/:id", sector.UpdateSectorMapping)
+
+	// REST API for the changes made by the admins
+	api.Get("/audit-logs", auditLog.GetAuditLogs)
 
 	// REST API for the orders table
 	api.Get("/orders", order.GetOrdersFromAssetUser)
 	api.Post("/orders", order.CreateUserOrder)
+	api.Post("/orders/import", order.ImportOrders)
+	api.Post("/orders/trade-notes", order.ImportTradeNotes)
+	api.Post("/orders/b3-report", order.ImportB3Report)
+	api.Post("/orders/broker-statement", order.ImportBrokerStatement)
 	api.Delete("orders/:id", order.DeleteOrderFromUser)
 	api.Put("/orders/:id", order.UpdateOrderFromUser)
 
 	// REST API for the brokerage table
 	api.Get("/brokerage/:name", brokerage.GetBrokerageFirm)
 	api.Get("/brokerage", brokerage.GetBrokerageFirms)
+	api.Post("/brokerage", brokerage.CreateBrokerage)
+	api.Put("/brokerage/:id", brokerage.UpdateBrokerage)
+	api.Delete("/brokerage/:id", brokerage.DeleteBrokerage)
+
+	// REST API for the brokerage accounts of the user
+	api.Get("/brokerage-accounts", brokerage.GetBrokerageAccounts)
+	api.Get("/brokerage-accounts/positions",
+		brokerage.GetBrokerageAccountPositions)
+	api.Post("/brokerage-accounts", brokerage.CreateBrokerageAccount)
+	api.Put("/brokerage-accounts/:id", brokerage.UpdateBrokerageAccount)
+	api.Delete("/brokerage-accounts/:id", brokerage.DeleteBrokerageAccount)
+
+	// REST API for the cash ledger of the brokerage accounts
+	api.Get("/cash-movements", cash.GetCashLedger)
+	api.Get("/cash-movements/balances", cash.GetCashBalances)
+	api.Post("/cash-movements", cash.CreateCashMovement)
+	api.Delete("/cash-movements/:id", cash.DeleteCashMovement)
+
+	// REST API for the remittances between currencies
+	api.Get("/remittances", remittance.GetRemittances)
+	api.Get("/remittances/cost-basis", remittance.GetBrlCostBasis)
+	api.Post("/remittances", remittance.CreateRemittance)
+	api.Delete("/remittances/:id", remittance.DeleteRemittance)
 
 	// REST API for the earning table
 	api.Get("/earnings", earnings.GetEarningsFromAssetUser)
 	api.Post("/earnings", earnings.CreateEarnings)
+	api.Get("/earnings/proposed", earnings.GetProposedEarnings)
+	api.Get("/earnings/projection", earnings.GetIncomeProjection)
+	api.Post("/earnings/from-events", earnings.CreateEarningsFromEvents)
 	api.Put("/earnings/:id", earnings.UpdateEarningFromUser)
 	api.Delete("/earnings/:id", earnings.DeleteEarningFromUser)
 
+	// REST API to export all the portfolio of the user
+	api.Get("/export", export.ExportPortfolio)
+
+	// REST API to back up the portfolio of the user and restore it
+	api.Get("/backup", backup.CreateBackup)
+	api.Post("/backup/restore", backup.RestoreBackup)
+
+	// REST API to reconcile the custody of a brokerage with the orders
+	api.Post("/reconciliation", reconciliation.ReconcileCustody)
+
+	// REST API for the earning events table
+	api.Post("/earning-events/import", earningEvent.ImportEarningEvents)
+
+	// REST API for the exchange trading calendars
+	api.Get("/calendar", calendarApi.GetExchangeCalendars)
+	api.Get("/calendar/:exchange", calendarApi.GetExchangeCalendar)
+
+	// REST API for the fixed income and index series tables
+	api.Post("/fixed-income", fixedIncome.CreateFixedIncome)
+	api.Post("/index-series", fixedIncome.CreateIndexSeries)
+
+	// REST API for the options table
+	api.Post("/option", option.CreateOption)
+	api.Post("/options/expire", option.ExpireOptions)
+
+	// Settle the expired options every day after the market closes
+	go dailyJob("optionsExpiryJob", optionsExpiryHour, b3Location,
+		func() error {
+			b3Date := time.Now().In(b3Location).Format("2006-01-02")
+			_, orders, skipped, err := logicApiUseCases.ApiExpireOptions(
+				entity.StringToTime(b3Date))
+			logSkipped("optionsExpiryJob", skipped)
+			log.Printf("optionsExpiryJob: %d expiry orders created\n",
+				len(orders))
+			return err
+		})
+
+	// Refresh the outdated company profiles once a day
+	go dailyJob("companyProfilesJob", companyProfilesHour, time.Local,
+		func() error {
+			_, profiles, err := logicApiUseCases.ApiRefreshCompanyProfiles()
+			log.Printf("companyProfilesJob: %d profiles refreshed\n", len(profiles))
+			return err
+		})
+
+	// Import the announced earnings and register them for the holders
+	go dailyJob("earningEventsJob", earningEventsHour, time.Local,
+		func() error {
+			_, earnings, skipped, err := logicApiUseCases.
+				ApiRefreshEarningEvents()
+			logSkipped("earningEventsJob", skipped)
+			log.Printf("earningEventsJob: %d earnings created\n", len(earnings))
+			return err
+		})
+
 	app.Listen(":3000")
 
 }
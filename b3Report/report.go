@@ -0,0 +1,323 @@
+package b3report
+
+import (
+	"regexp"
+	"stockfyApi/entity"
+	"stockfyApi/spreadsheet"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Columns that identify each report. The names are normalized, without
+// accents and in upper case.
+var reportColumns = map[string][]string{
+	entity.B3TradesReport: {"DATA DO NEGOCIO", "TIPO DE MOVIMENTACAO",
+		"INSTITUICAO", "CODIGO DE NEGOCIACAO", "QUANTIDADE", "PRECO"},
+	entity.B3MovementsReport: {"ENTRADA/SAIDA", "DATA", "MOVIMENTACAO",
+		"PRODUTO", "INSTITUICAO", "QUANTIDADE", "PRECO UNITARIO",
+		"VALOR DA OPERACAO"},
+	entity.B3PositionReport: {"INSTITUICAO", "CODIGO DE NEGOCIACAO",
+		"QUANTIDADE"},
+}
+
+// The trades report is checked first, since it also has the columns of the
+// custody report.
+var reportTypes = []string{entity.B3TradesReport, entity.B3MovementsReport,
+	entity.B3PositionReport}
+
+// Earning types of the movements of earnings.
+var earningMovements = map[string]string{
+	"DIVIDENDO":                   "Dividendos",
+	"JUROS SOBRE CAPITAL PROPRIO": "JCP",
+	"RENDIMENTO":                  "Rendimentos",
+}
+
+// Movements of the corporate events that change the quantity of shares.
+var eventMovements = map[string]bool{
+	"BONIFICACAO EM ATIVOS": true,
+	"DESDOBRO":              true,
+	"GRUPAMENTO":            true,
+}
+
+// The fractional market symbols end with an F, like ITUB4F.
+var symbolRegex = regexp.MustCompile(`^([A-Z0-9]{4}\d{1,2})F?$`)
+
+var accentReplacer = strings.NewReplacer("Á", "A", "À", "A", "Â", "A", "Ã",
+	"A", "É", "E", "Ê", "E", "Í", "I", "Ó", "O", "Ô", "O", "Õ", "O", "Ú", "U",
+	"Ç", "C")
+
+// The dates of the XLSX files may be stored as the number of days since the
+// epoch of the spreadsheets.
+var spreadsheetEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// Read reads a report exported from the investor area of the B3, as a XLSX or
+// CSV file. The type of the report is found from the columns of its sheets:
+// the trades (negociação), the movements (movimentação) or the custody
+// position (posição), whose products are split in several sheets. The rows
+// are returned with the problems found in each of them.
+func Read(file []byte) (*entity.B3Report, error) {
+	sheets, err := spreadsheet.Read(file)
+	if err != nil {
+		return nil, err
+	}
+
+	report := entity.B3Report{}
+	rows := 0
+	for _, sheet := range sheets {
+		reportType, headerLine, columns := searchHeader(sheet)
+		if reportType == "" || (report.Type != "" && reportType != report.Type) {
+			continue
+		}
+		report.Type = reportType
+
+		for i := headerLine + 1; i < len(sheet.Rows); i++ {
+			value := func(column string) string {
+				index, ok := columns[column]
+				if !ok || index >= len(sheet.Rows[i]) {
+					return ""
+				}
+				return strings.TrimSpace(sheet.Rows[i][index])
+			}
+
+			switch reportType {
+			case entity.B3TradesReport:
+				if value("DATA DO NEGOCIO") == "" &&
+					value("CODIGO DE NEGOCIACAO") == "" {
+					continue
+				}
+				report.Trades = append(report.Trades, newTrade(i+1, value))
+			case entity.B3MovementsReport:
+				if value("DATA") == "" && value("PRODUTO") == "" {
+					continue
+				}
+				report.Movements = append(report.Movements,
+					newMovement(i+1, value))
+			case entity.B3PositionReport:
+				if value("CODIGO DE NEGOCIACAO") == "" {
+					continue
+				}
+				report.Positions = append(report.Positions,
+					newPosition(sheet.Name, i+1, value))
+			}
+
+			rows++
+			if rows > entity.MaxOrderImportRows {
+				return nil, entity.ErrInvalidOrderImportSize
+			}
+		}
+	}
+
+	if report.Type == "" {
+		return nil, entity.ErrInvalidB3Report
+	}
+
+	if rows == 0 {
+		return nil, entity.ErrInvalidB3ReportEmpty
+	}
+
+	return &report, nil
+}
+
+// searchHeader returns the type of the report of the sheet, the index of its
+// header row and the index of each column.
+func searchHeader(sheet spreadsheet.Sheet) (string, int, map[string]int) {
+	for i, row := range sheet.Rows {
+		columns := map[string]int{}
+		for j, column := range row {
+			columns[normalize(column)] = j
+		}
+
+		for _, reportType := range reportTypes {
+			if hasColumns(columns, reportColumns[reportType]) {
+				return reportType, i, columns
+			}
+		}
+	}
+
+	return "", 0, nil
+}
+
+func hasColumns(columns map[string]int, names []string) bool {
+	for _, name := range names {
+		if _, ok := columns[name]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newTrade(line int, value func(string) string) entity.OrderImportRow {
+	row := entity.OrderImportRow{
+		Line:      line,
+		Symbol:    tradeSymbol(value("CODIGO DE NEGOCIACAO")),
+		Country:   "BR",
+		Currency:  "BRL",
+		Brokerage: value("INSTITUICAO"),
+		Date:      reportDate(value("DATA DO NEGOCIO")),
+	}
+
+	switch normalize(value("TIPO DE MOVIMENTACAO")) {
+	case "COMPRA":
+		row.OrderType = "buy"
+	case "VENDA":
+		row.OrderType = "sell"
+	default:
+		row.AddError(entity.ErrInvalidOrderType)
+	}
+
+	var err error
+	if row.Quantity, err = reportNumber(value("QUANTIDADE")); err != nil {
+		row.AddError(err)
+	}
+
+	if row.Price, err = reportNumber(value("PRECO")); err != nil {
+		row.AddError(err)
+	}
+
+	if row.OrderType == "sell" {
+		row.Quantity = -row.Quantity
+	}
+
+	if row.Date == "" {
+		row.AddError(entity.ErrInvalidB3ReportDate)
+	}
+
+	if row.Symbol == "" || row.Brokerage == "" {
+		row.AddError(entity.ErrInvalidOrderImportBlank)
+	}
+
+	return row
+}
+
+func newMovement(line int, value func(string) string) entity.B3Movement {
+	movement := entity.B3Movement{
+		Line:      line,
+		Direction: "credit",
+		Date:      reportDate(value("DATA")),
+		Movement:  value("MOVIMENTACAO"),
+		Symbol:    productSymbol(value("PRODUTO")),
+		Brokerage: value("INSTITUICAO"),
+	}
+
+	if normalize(value("ENTRADA/SAIDA")) == "DEBITO" {
+		movement.Direction = "debit"
+	}
+
+	movementType := normalize(movement.Movement)
+	if earningType, ok := earningMovements[movementType]; ok &&
+		movement.Direction == "credit" {
+		movement.EarningType = earningType
+	} else if eventMovements[movementType] {
+		movement.OrderType = "buy"
+		if movement.Direction == "debit" {
+			movement.OrderType = "sell"
+		}
+	} else {
+		movement.Ignored = true
+		return movement
+	}
+
+	var err error
+	if movement.Quantity, err = reportNumber(value("QUANTIDADE")); err != nil {
+		movement.AddError(err)
+	}
+
+	if movement.Price, err = reportNumber(value("PRECO UNITARIO")); err != nil {
+		movement.AddError(err)
+	}
+
+	if movement.Amount, err = reportNumber(value("VALOR DA OPERACAO")); err != nil {
+		movement.AddError(err)
+	}
+
+	if movement.OrderType == "sell" {
+		movement.Quantity = -movement.Quantity
+	}
+
+	if movement.EarningType != "" && movement.Amount <= 0 {
+		movement.AddError(entity.ErrInvalidEarningsAmount)
+	}
+
+	if movement.Date == "" {
+		movement.AddError(entity.ErrInvalidB3ReportDate)
+	}
+
+	if movement.Symbol == "" {
+		movement.AddError(entity.ErrInvalidB3ReportSymbol)
+	}
+
+	return movement
+}
+
+func newPosition(sheet string, line int,
+	value func(string) string) entity.B3Position {
+
+	position := entity.B3Position{
+		Sheet:     sheet,
+		Line:      line,
+		Symbol:    tradeSymbol(value("CODIGO DE NEGOCIACAO")),
+		Brokerage: value("INSTITUICAO"),
+	}
+
+	var err error
+	if position.Quantity, err = reportNumber(value("QUANTIDADE")); err != nil {
+		position.AddError(err)
+	}
+
+	return position
+}
+
+func tradeSymbol(code string) string {
+	code = strings.ToUpper(code)
+	if match := symbolRegex.FindStringSubmatch(code); match != nil {
+		return match[1]
+	}
+
+	return code
+}
+
+// productSymbol returns the symbol of the products of the movements, like
+// ITUB4 - ITAU UNIBANCO HOLDING S.A. The products without symbol, like the
+// treasury bonds, return a blank symbol.
+func productSymbol(product string) string {
+	code := strings.ToUpper(strings.TrimSpace(strings.SplitN(product, " - ",
+		2)[0]))
+	if match := symbolRegex.FindStringSubmatch(code); match != nil {
+		return match[1]
+	}
+
+	return ""
+}
+
+// reportDate converts the dates of the report to the YYYY-MM-DD layout. The
+// dates that can not be read are returned blank.
+func reportDate(date string) string {
+	if parsedDate, err := time.Parse("02/01/2006", date); err == nil {
+		return parsedDate.Format("2006-01-02")
+	}
+
+	if days, err := strconv.Atoi(date); err == nil && days > 0 {
+		return spreadsheetEpoch.AddDate(0, 0, days).Format("2006-01-02")
+	}
+
+	return ""
+}
+
+// reportNumber reads the numbers of the report, which are blank or a dash
+// when there is no value, like the price of the earnings.
+func reportNumber(value string) (float64, error) {
+	value = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(value),
+		"R$"))
+	if value == "" || value == "-" {
+		return 0, nil
+	}
+
+	return entity.ParseImportNumber(value)
+}
+
+func normalize(value string) string {
+	return accentReplacer.Replace(strings.ToUpper(strings.Join(
+		strings.Fields(value), " ")))
+}
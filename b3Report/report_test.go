@@ -0,0 +1,133 @@
+package b3report
+
+import (
+	"io/ioutil"
+	"stockfyApi/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func readTestdata(t *testing.T, name string) []byte {
+	file, err := ioutil.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return file
+}
+
+func TestReadTrades(t *testing.T) {
+	expectedTrades := []entity.OrderImportRow{
+		{
+			Line:      2,
+			Symbol:    "ITUB4",
+			Country:   "BR",
+			OrderType: "buy",
+			Quantity:  100,
+			Price:     25.50,
+			Currency:  "BRL",
+			Brokerage: "CLEAR CORRETORA - GRUPO XP",
+			Date:      "2021-10-05",
+		},
+		{
+			Line:      3,
+			Symbol:    "PETR4",
+			Country:   "BR",
+			OrderType: "sell",
+			Quantity:  -5,
+			Price:     28.10,
+			Currency:  "BRL",
+			Brokerage: "CLEAR CORRETORA - GRUPO XP",
+			Date:      "2021-10-05",
+		},
+		{
+			Line:      4,
+			Symbol:    "BOVA11",
+			Country:   "BR",
+			OrderType: "buy",
+			Quantity:  10,
+			Currency:  "BRL",
+			Brokerage: "BANCO EXEMPLO S.A.",
+			Date:      "2021-11-08",
+			Errors:    []string{entity.ErrInvalidOrderImportNumber.Error()},
+		},
+	}
+
+	report, err := Read(readTestdata(t, "trades.csv"))
+	assert.Nil(t, err)
+	assert.Equal(t, entity.B3TradesReport, report.Type)
+	assert.Equal(t, expectedTrades, report.Trades)
+}
+
+func TestReadMovements(t *testing.T) {
+	brokerage := "CLEAR CORRETORA - GRUPO XP"
+	expectedMovements := []entity.B3Movement{
+		{Line: 2, Direction: "credit", Date: "2021-10-01",
+			Movement: "Dividendo", Symbol: "ITUB4", Brokerage: brokerage,
+			Quantity: 100, Price: 0.02, Amount: 2, EarningType: "Dividendos"},
+		{Line: 3, Direction: "credit", Date: "2021-10-15",
+			Movement: "Juros Sobre Capital Próprio", Symbol: "ITUB4",
+			Brokerage: brokerage, Quantity: 100, Price: 0.10, Amount: 8.50,
+			EarningType: "JCP"},
+		{Line: 4, Direction: "credit", Date: "2021-10-07",
+			Movement: "Transferência - Liquidação", Symbol: "ITUB4",
+			Brokerage: brokerage, Ignored: true},
+		{Line: 5, Direction: "credit", Date: "2021-12-22",
+			Movement: "Bonificação em Ativos", Symbol: "ITSA4",
+			Brokerage: brokerage, Quantity: 10, Price: 18.47, OrderType: "buy"},
+		{Line: 6, Direction: "debit", Date: "2021-11-10",
+			Movement: "Grupamento", Symbol: "MGLU3", Brokerage: brokerage,
+			Quantity: -90, OrderType: "sell"},
+		{Line: 7, Direction: "credit", Date: "2021-11-10",
+			Movement: "Grupamento", Symbol: "MGLU3", Brokerage: brokerage,
+			Quantity: 9, OrderType: "buy"},
+		{Line: 8, Direction: "credit", Date: "2021-10-14",
+			Movement: "Rendimento", Brokerage: brokerage, Quantity: 1,
+			Amount: 12.30, EarningType: "Rendimentos",
+			Errors: []string{entity.ErrInvalidB3ReportSymbol.Error()}},
+	}
+
+	report, err := Read(readTestdata(t, "movements.csv"))
+	assert.Nil(t, err)
+	assert.Equal(t, entity.B3MovementsReport, report.Type)
+	assert.Equal(t, expectedMovements, report.Movements)
+}
+
+func TestReadPosition(t *testing.T) {
+	brokerage := "CLEAR CORRETORA - GRUPO XP"
+	expectedPositions := []entity.B3Position{
+		{Sheet: "Acoes", Line: 2, Symbol: "ITUB4", Brokerage: brokerage,
+			Quantity: 100},
+		{Sheet: "Acoes", Line: 3, Symbol: "PETR4", Brokerage: brokerage,
+			Quantity: 45},
+		{Sheet: "ETF", Line: 2, Symbol: "BOVA11",
+			Brokerage: "BANCO EXEMPLO S.A.", Quantity: 10},
+	}
+
+	report, err := Read(readTestdata(t, "position.xlsx"))
+	assert.Nil(t, err)
+	assert.Equal(t, entity.B3PositionReport, report.Type)
+	assert.Equal(t, expectedPositions, report.Positions)
+}
+
+func TestReadErrors(t *testing.T) {
+	report, err := Read([]byte("symbol,quantity\nITUB4,10\n"))
+	assert.Nil(t, report)
+	assert.Equal(t, entity.ErrInvalidB3Report, err)
+
+	report, err = Read([]byte("Data do Negócio;Tipo de Movimentação;" +
+		"Instituição;Código de Negociação;Quantidade;Preço\n"))
+	assert.Nil(t, report)
+	assert.Equal(t, entity.ErrInvalidB3ReportEmpty, err)
+
+	report, err = Read([]byte("PK\x03\x04 broken"))
+	assert.Nil(t, report)
+	assert.Equal(t, entity.ErrInvalidSpreadsheet, err)
+}
+
+func TestReportDate(t *testing.T) {
+	assert.Equal(t, "2021-10-05", reportDate("05/10/2021"))
+	assert.Equal(t, "2021-10-05", reportDate("44474"))
+	assert.Equal(t, "", reportDate("2021/10/05"))
+}
@@ -0,0 +1,218 @@
+package brokerstatement
+
+import (
+	"math"
+	"stockfyApi/entity"
+	"strconv"
+	"strings"
+)
+
+// Investment transactions of the OFX files imported as orders.
+var ofxOrderTransactions = map[string]bool{
+	"BUYSTOCK": true, "BUYMF": true, "BUYOTHER": true, "SELLSTOCK": true,
+	"SELLMF": true, "SELLOTHER": true,
+}
+
+// ofxElement is an element of an OFX file. The elements with a value have no
+// children, while the aggregates have only children.
+type ofxElement struct {
+	name     string
+	value    string
+	children []*ofxElement
+}
+
+// readOfx reads the investment transactions of an OFX file, written in SGML,
+// whose elements with a value are not closed, or in XML. The buys and the
+// sells are the orders and the incomes of the DIV type are the dividends,
+// with the tax withheld from them. The line of the rows is the position of
+// the transaction in the statement.
+func readOfx(file []byte) (*entity.BrokerStatement, error) {
+	root := parseOfx(string(file))
+
+	ofx := root.search("OFX")
+	if ofx == nil {
+		return nil, entity.ErrInvalidBrokerStatement
+	}
+
+	statement := &entity.BrokerStatement{
+		Format:    entity.OfxStatement,
+		Brokerage: ofx.searchValue("FI", "ORG"),
+	}
+	if statement.Brokerage == "" {
+		statement.Brokerage = ofx.searchValue("INVACCTFROM", "BROKERID")
+	}
+
+	tickers := map[string]string{}
+	for _, security := range ofx.searchAll("SECINFO") {
+		tickers[security.searchValue("SECID", "UNIQUEID")] =
+			security.searchValue("TICKER")
+	}
+
+	transactionList := ofx.search("INVTRANLIST")
+	if transactionList == nil {
+		return nil, entity.ErrInvalidBrokerStatementEmpty
+	}
+
+	line := 0
+	for _, transaction := range transactionList.children {
+		if transaction.value != "" {
+			continue
+		}
+
+		line++
+		symbol := tickers[transaction.searchValue("SECID", "UNIQUEID")]
+		date := statementDate(ofxDate(transaction.searchValue("DTTRADE")),
+			entity.OfxStatement)
+
+		if ofxOrderTransactions[transaction.name] {
+			row := newOrderRow(line, symbol,
+				strings.HasPrefix(transaction.name, "SELL"),
+				transaction.searchValue("UNITS"),
+				transaction.searchValue("UNITPRICE"), date)
+			row.SettlementDate = statementDate(ofxDate(
+				transaction.searchValue("DTSETTLE")), entity.OfxStatement)
+
+			for _, fee := range []string{"COMMISSION", "FEES"} {
+				if value := transaction.searchValue(fee); value != "" {
+					fees, err := strconv.ParseFloat(value, 64)
+					if err != nil {
+						row.AddError(entity.ErrInvalidOrderImportNumber)
+					}
+					row.Fees += math.Abs(fees)
+				}
+			}
+
+			statement.Orders = append(statement.Orders, row)
+		} else if transaction.name == "INCOME" &&
+			strings.ToUpper(transaction.searchValue("INCOMETYPE")) == "DIV" {
+			row := newEarningRow(line, symbol, transaction.searchValue("TOTAL"),
+				date)
+
+			if value := transaction.searchValue("WITHHOLDING"); value != "" {
+				withheldTax, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					row.AddError(entity.ErrInvalidOrderImportNumber)
+				}
+				row.WithheldTax = math.Abs(withheldTax)
+			}
+
+			statement.Earnings = append(statement.Earnings, row)
+		}
+
+		if len(statement.Orders)+len(statement.Earnings) >
+			entity.MaxOrderImportRows {
+			return nil, entity.ErrInvalidOrderImportSize
+		}
+	}
+
+	return finishStatement(statement, nil)
+}
+
+// parseOfx builds the tree of elements of an OFX file. An element followed by
+// a value has no children, so the missing closing tags of the SGML files are
+// not needed. The headers and the XML declarations are ignored.
+func parseOfx(content string) *ofxElement {
+	root := &ofxElement{}
+	stack := []*ofxElement{root}
+
+	for {
+		start := strings.Index(content, "<")
+		if start == -1 {
+			break
+		}
+
+		end := strings.Index(content[start:], ">")
+		if end == -1 {
+			break
+		}
+
+		tag := strings.TrimSpace(content[start+1 : start+end])
+		content = content[start+end+1:]
+
+		value := content
+		if next := strings.Index(content, "<"); next != -1 {
+			value = content[:next]
+		}
+		value = strings.TrimSpace(value)
+
+		if tag == "" || strings.HasPrefix(tag, "?") ||
+			strings.HasPrefix(tag, "!") {
+			continue
+		}
+
+		parent := stack[len(stack)-1]
+		if strings.HasPrefix(tag, "/") {
+			name := strings.ToUpper(strings.TrimPrefix(tag, "/"))
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].name == name {
+					stack = stack[:i]
+					break
+				}
+			}
+			continue
+		}
+
+		element := &ofxElement{
+			name:  strings.ToUpper(strings.Fields(tag)[0]),
+			value: value,
+		}
+		parent.children = append(parent.children, element)
+		if value == "" {
+			stack = append(stack, element)
+		}
+	}
+
+	return root
+}
+
+// search returns the first element with the name, searched in depth.
+func (e *ofxElement) search(name string) *ofxElement {
+	for _, child := range e.children {
+		if child.name == name {
+			return child
+		}
+
+		if element := child.search(name); element != nil {
+			return element
+		}
+	}
+
+	return nil
+}
+
+// searchAll returns all the elements with the name.
+func (e *ofxElement) searchAll(name string) []*ofxElement {
+	var elements []*ofxElement
+	for _, child := range e.children {
+		if child.name == name {
+			elements = append(elements, child)
+			continue
+		}
+
+		elements = append(elements, child.searchAll(name)...)
+	}
+
+	return elements
+}
+
+// searchValue returns the value of the element found by the path of names,
+// each of them searched inside the previous one.
+func (e *ofxElement) searchValue(path ...string) string {
+	element := e
+	for _, name := range path {
+		if element = element.search(name); element == nil {
+			return ""
+		}
+	}
+
+	return element.value
+}
+
+// ofxDate returns the date of the OFX date times, like 20211005120000.000[-5].
+func ofxDate(dateTime string) string {
+	if len(dateTime) < 8 {
+		return dateTime
+	}
+
+	return dateTime[:8]
+}
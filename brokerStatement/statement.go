@@ -0,0 +1,334 @@
+package brokerstatement
+
+import (
+	"bytes"
+	"math"
+	"regexp"
+	"stockfyApi/entity"
+	"stockfyApi/spreadsheet"
+	"strings"
+	"time"
+)
+
+// Columns that identify the CSV statements. The names are normalized, without
+// accents and in upper case.
+var statementColumns = map[string][]string{
+	entity.AvenueStatement: {"DATA", "LIQUIDACAO", "DESCRICAO", "VALOR (U$)"},
+	entity.PassfolioStatement: {"DATE", "ACTIVITY TYPE", "SYMBOL", "SIDE",
+		"QUANTITY", "PRICE", "NET AMOUNT"},
+}
+
+var statementFormats = []string{entity.AvenueStatement,
+	entity.PassfolioStatement}
+
+// Layouts of the dates of each statement. The Avenue writes the dates in the
+// brazilian layout and the Passfolio in the american one.
+var statementDateLayouts = map[string][]string{
+	entity.AvenueStatement:    {"02/01/2006", "2006-01-02"},
+	entity.PassfolioStatement: {"2006-01-02", "01/02/2006"},
+	entity.OfxStatement:       {"20060102"},
+}
+
+// Brokerages of the statements without the name of the brokerage.
+var statementBrokerages = map[string]string{
+	entity.AvenueStatement:    "Avenue",
+	entity.PassfolioStatement: "Passfolio",
+}
+
+// Descriptions of the entries of the Avenue statements, like "Compra de 0.5
+// AAPL a $ 141.20 cada" or "Impostos sobre dividendos de AAPL".
+var (
+	avenueOrderRegex = regexp.MustCompile(
+		`(?i)^(COMPRA|VENDA) DE ([\d.,]+) ([A-Z0-9.\-]+) A \$ ?([\d.,]+)`)
+	avenueDividendRegex = regexp.MustCompile(
+		`(?i)^DIVIDENDOS DE ([A-Z0-9.\-]+)`)
+	avenueWithholdingRegex = regexp.MustCompile(
+		`(?i)^IMPOSTOS? SOBRE DIVIDENDOS DE ([A-Z0-9.\-]+)`)
+)
+
+var accentReplacer = strings.NewReplacer("Á", "A", "À", "A", "Â", "A", "Ã",
+	"A", "É", "E", "Ê", "E", "Í", "I", "Ó", "O", "Ô", "O", "Õ", "O", "Ú", "U",
+	"Ç", "C")
+
+// Read reads a statement exported by a US brokerage: the CSV files of the
+// Avenue and of the Passfolio, or an OFX file of any brokerage. The format is
+// found from the content of the file. The buys, the sells and the dividends
+// are returned in USD, with the problems found in each of them, and the tax
+// withheld from the dividends is added to the dividend it was withheld from.
+func Read(file []byte) (*entity.BrokerStatement, error) {
+	trimmedFile := bytes.TrimSpace(bytes.TrimPrefix(file, []byte("\ufeff")))
+	if bytes.HasPrefix(trimmedFile, []byte("OFXHEADER")) ||
+		bytes.Contains(trimmedFile, []byte("<OFX>")) {
+		return readOfx(trimmedFile)
+	}
+
+	sheets, err := spreadsheet.Read(file)
+	if err != nil {
+		return nil, entity.ErrInvalidBrokerStatement
+	}
+
+	for _, sheet := range sheets {
+		format, headerLine, columns := searchHeader(sheet)
+		if format == "" {
+			continue
+		}
+
+		statement := &entity.BrokerStatement{
+			Format:    format,
+			Brokerage: statementBrokerages[format],
+		}
+
+		var withholdings []entity.EarningImportRow
+		for i := headerLine + 1; i < len(sheet.Rows); i++ {
+			value := func(column string) string {
+				index, ok := columns[column]
+				if !ok || index >= len(sheet.Rows[i]) {
+					return ""
+				}
+				return strings.TrimSpace(sheet.Rows[i][index])
+			}
+
+			var withholding *entity.EarningImportRow
+			if format == entity.AvenueStatement {
+				withholding = readAvenueRow(statement, i+1, value)
+			} else {
+				withholding = readPassfolioRow(statement, i+1, value)
+			}
+
+			if withholding != nil {
+				withholdings = append(withholdings, *withholding)
+			}
+
+			if len(statement.Orders)+len(statement.Earnings)+
+				len(withholdings) > entity.MaxOrderImportRows {
+				return nil, entity.ErrInvalidOrderImportSize
+			}
+		}
+
+		return finishStatement(statement, withholdings)
+	}
+
+	return nil, entity.ErrInvalidBrokerStatement
+}
+
+// searchHeader returns the format of the statement of the sheet, the index of
+// its header row and the index of each column.
+func searchHeader(sheet spreadsheet.Sheet) (string, int, map[string]int) {
+	for i, row := range sheet.Rows {
+		columns := map[string]int{}
+		for j, column := range row {
+			columns[normalize(column)] = j
+		}
+
+		for _, format := range statementFormats {
+			if hasColumns(columns, statementColumns[format]) {
+				return format, i, columns
+			}
+		}
+	}
+
+	return "", 0, nil
+}
+
+func hasColumns(columns map[string]int, names []string) bool {
+	for _, name := range names {
+		if _, ok := columns[name]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// readAvenueRow adds the order or the dividend of an entry of the Avenue
+// statement. The withheld tax is returned to be added to its dividend, and the
+// other entries, like the deposits, are ignored.
+func readAvenueRow(statement *entity.BrokerStatement, line int,
+	value func(string) string) *entity.EarningImportRow {
+
+	description := strings.Join(strings.Fields(value("DESCRICAO")), " ")
+	date := statementDate(value("DATA"), entity.AvenueStatement)
+
+	if match := avenueOrderRegex.FindStringSubmatch(description); match != nil {
+		row := newOrderRow(line, match[3], strings.ToUpper(match[1]) == "VENDA",
+			match[2], match[4], date)
+		row.SettlementDate = statementDate(value("LIQUIDACAO"),
+			entity.AvenueStatement)
+		statement.Orders = append(statement.Orders, row)
+		return nil
+	}
+
+	if match := avenueWithholdingRegex.FindStringSubmatch(
+		description); match != nil {
+		row := newEarningRow(line, match[1], value("VALOR (U$)"), date)
+		return &row
+	}
+
+	if match := avenueDividendRegex.FindStringSubmatch(
+		description); match != nil {
+		statement.Earnings = append(statement.Earnings, newEarningRow(line,
+			match[1], value("VALOR (U$)"), date))
+	}
+
+	return nil
+}
+
+// readPassfolioRow adds the order or the dividend of an activity of the
+// Passfolio statement, whose fills are the orders, the DIV activities are the
+// dividends and the DIVNRA activities are the tax withheld from the dividends
+// of the non resident aliens.
+func readPassfolioRow(statement *entity.BrokerStatement, line int,
+	value func(string) string) *entity.EarningImportRow {
+
+	date := statementDate(value("DATE"), entity.PassfolioStatement)
+
+	switch strings.ToUpper(value("ACTIVITY TYPE")) {
+	case "FILL":
+		row := newOrderRow(line, value("SYMBOL"),
+			strings.ToUpper(value("SIDE")) == "SELL", value("QUANTITY"),
+			value("PRICE"), date)
+		if side := strings.ToUpper(value("SIDE")); side != "BUY" &&
+			side != "SELL" {
+			row.AddError(entity.ErrInvalidOrderType)
+		}
+		statement.Orders = append(statement.Orders, row)
+	case "DIV":
+		statement.Earnings = append(statement.Earnings, newEarningRow(line,
+			value("SYMBOL"), value("NET AMOUNT"), date))
+	case "DIVNRA":
+		row := newEarningRow(line, value("SYMBOL"), value("NET AMOUNT"), date)
+		return &row
+	}
+
+	return nil
+}
+
+func newOrderRow(line int, symbol string, sell bool, quantity string,
+	price string, date string) entity.OrderImportRow {
+
+	row := entity.OrderImportRow{
+		Line:      line,
+		Symbol:    strings.ToUpper(strings.TrimSpace(symbol)),
+		Country:   "US",
+		OrderType: "buy",
+		Currency:  "USD",
+		Date:      date,
+	}
+
+	var err error
+	if row.Quantity, err = entity.ParseImportNumber(quantity); err != nil {
+		row.AddError(err)
+	}
+
+	if row.Price, err = entity.ParseImportNumber(price); err != nil {
+		row.AddError(err)
+	}
+
+	row.Quantity = math.Abs(row.Quantity)
+	row.Price = math.Abs(row.Price)
+	if sell {
+		row.OrderType = "sell"
+		row.Quantity = -row.Quantity
+	}
+
+	if row.Date == "" {
+		row.AddError(entity.ErrInvalidBrokerStatementDate)
+	}
+
+	if row.Symbol == "" {
+		row.AddError(entity.ErrInvalidOrderImportBlank)
+	}
+
+	return row
+}
+
+func newEarningRow(line int, symbol string, amount string,
+	date string) entity.EarningImportRow {
+
+	row := entity.EarningImportRow{
+		Line:        line,
+		Symbol:      strings.ToUpper(strings.TrimSpace(symbol)),
+		Country:     "US",
+		EarningType: "Dividendos",
+		Currency:    "USD",
+		Date:        date,
+	}
+
+	var err error
+	if row.Amount, err = entity.ParseImportNumber(amount); err != nil {
+		row.AddError(err)
+	}
+
+	if row.Date == "" {
+		row.AddError(entity.ErrInvalidBrokerStatementDate)
+	}
+
+	if row.Symbol == "" {
+		row.AddError(entity.ErrInvalidOrderImportBlank)
+	}
+
+	return row
+}
+
+// finishStatement adds the withheld taxes to the dividends with the same
+// symbol and date. The taxes without a dividend are returned as rows with an
+// error, since the dividend would be registered without them.
+func finishStatement(statement *entity.BrokerStatement,
+	withholdings []entity.EarningImportRow) (*entity.BrokerStatement, error) {
+
+	for _, withholding := range withholdings {
+		dividend := -1
+		for i, earning := range statement.Earnings {
+			if earning.Symbol == withholding.Symbol &&
+				earning.Date == withholding.Date {
+				dividend = i
+				break
+			}
+		}
+
+		if dividend == -1 || !withholding.Valid() {
+			withholding.WithheldTax = math.Abs(withholding.Amount)
+			withholding.Amount = 0
+			if dividend == -1 {
+				withholding.AddError(entity.ErrInvalidBrokerStatementWithholding)
+			}
+			statement.Earnings = append(statement.Earnings, withholding)
+			continue
+		}
+
+		statement.Earnings[dividend].WithheldTax += math.Abs(
+			withholding.Amount)
+	}
+
+	for i := range statement.Earnings {
+		earning := &statement.Earnings[i]
+		earning.WithheldTax = math.Round(earning.WithheldTax*1e8) / 1e8
+		if earning.Amount <= 0 && earning.Valid() {
+			earning.AddError(entity.ErrInvalidEarningsAmount)
+		}
+	}
+
+	if len(statement.Orders) == 0 && len(statement.Earnings) == 0 {
+		return nil, entity.ErrInvalidBrokerStatementEmpty
+	}
+
+	return statement, nil
+}
+
+// statementDate converts the dates of the statements to the YYYY-MM-DD layout.
+// The dates that can not be read are returned blank.
+func statementDate(date string, format string) string {
+	for _, layout := range statementDateLayouts[format] {
+		parsedDate, err := time.Parse(layout, strings.TrimSpace(date))
+		if err == nil {
+			return parsedDate.Format("2006-01-02")
+		}
+	}
+
+	return ""
+}
+
+func normalize(value string) string {
+	return accentReplacer.Replace(strings.ToUpper(strings.Join(
+		strings.Fields(value), " ")))
+}
@@ -0,0 +1,221 @@
+package brokerstatement
+
+import (
+	"io/ioutil"
+	"stockfyApi/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func readTestdata(t *testing.T, name string) []byte {
+	file, err := ioutil.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return file
+}
+
+func TestReadAvenue(t *testing.T) {
+	expectedStatement := &entity.BrokerStatement{
+		Format:    entity.AvenueStatement,
+		Brokerage: "Avenue",
+		Orders: []entity.OrderImportRow{
+			{
+				Line:           4,
+				Symbol:         "MSFT",
+				Country:        "US",
+				OrderType:      "sell",
+				Quantity:       -2,
+				Price:          300.10,
+				Currency:       "USD",
+				Date:           "2021-10-08",
+				SettlementDate: "2021-10-12",
+			},
+			{
+				Line:           5,
+				Symbol:         "AAPL",
+				Country:        "US",
+				OrderType:      "buy",
+				Quantity:       0.5,
+				Price:          141.20,
+				Currency:       "USD",
+				Date:           "2021-10-05",
+				SettlementDate: "2021-10-07",
+			},
+		},
+		Earnings: []entity.EarningImportRow{
+			{
+				Line:        3,
+				Symbol:      "AAPL",
+				Country:     "US",
+				EarningType: "Dividendos",
+				Amount:      0.35,
+				WithheldTax: 0.10,
+				Currency:    "USD",
+				Date:        "2021-11-11",
+			},
+		},
+	}
+
+	statement, err := Read(readTestdata(t, "avenue.csv"))
+	assert.Nil(t, err)
+	assert.Equal(t, expectedStatement, statement)
+}
+
+func TestReadPassfolio(t *testing.T) {
+	expectedOrders := []entity.OrderImportRow{
+		{
+			Line:      2,
+			Symbol:    "AAPL",
+			Country:   "US",
+			OrderType: "buy",
+			Quantity:  0.25,
+			Price:     141.20,
+			Currency:  "USD",
+			Date:      "2021-10-05",
+		},
+		{
+			Line:      3,
+			Symbol:    "TSLA",
+			Country:   "US",
+			OrderType: "sell",
+			Quantity:  -1.5,
+			Price:     782.75,
+			Currency:  "USD",
+			Date:      "2021-10-08",
+		},
+	}
+
+	expectedEarnings := []entity.EarningImportRow{
+		{
+			Line:        4,
+			Symbol:      "AAPL",
+			Country:     "US",
+			EarningType: "Dividendos",
+			Amount:      0.22,
+			WithheldTax: 0.07,
+			Currency:    "USD",
+			Date:        "2021-11-11",
+		},
+		{
+			Line:        6,
+			Symbol:      "KO",
+			Country:     "US",
+			EarningType: "Dividendos",
+			WithheldTax: 0.12,
+			Currency:    "USD",
+			Date:        "2021-12-09",
+			Errors: []string{
+				entity.ErrInvalidBrokerStatementWithholding.Error()},
+		},
+	}
+
+	statement, err := Read(readTestdata(t, "passfolio.csv"))
+	assert.Nil(t, err)
+	assert.Equal(t, entity.PassfolioStatement, statement.Format)
+	assert.Equal(t, "Passfolio", statement.Brokerage)
+	assert.Equal(t, expectedOrders, statement.Orders)
+	assert.Equal(t, expectedEarnings, statement.Earnings)
+}
+
+func TestReadOfx(t *testing.T) {
+	expectedStatement := &entity.BrokerStatement{
+		Format:    entity.OfxStatement,
+		Brokerage: "Avenue Securities",
+		Orders: []entity.OrderImportRow{
+			{
+				Line:           1,
+				Symbol:         "AAPL",
+				Country:        "US",
+				OrderType:      "buy",
+				Quantity:       0.5,
+				Price:          141.20,
+				Currency:       "USD",
+				Date:           "2021-10-05",
+				Fees:           0.52,
+				SettlementDate: "2021-10-07",
+			},
+			{
+				Line:           2,
+				Symbol:         "MSFT",
+				Country:        "US",
+				OrderType:      "sell",
+				Quantity:       -2,
+				Price:          300.10,
+				Currency:       "USD",
+				Date:           "2021-10-08",
+				SettlementDate: "2021-10-12",
+			},
+		},
+		Earnings: []entity.EarningImportRow{
+			{
+				Line:        3,
+				Symbol:      "AAPL",
+				Country:     "US",
+				EarningType: "Dividendos",
+				Amount:      0.35,
+				WithheldTax: 0.10,
+				Currency:    "USD",
+				Date:        "2021-11-11",
+			},
+		},
+	}
+
+	statement, err := Read(readTestdata(t, "statement.ofx"))
+	assert.Nil(t, err)
+	assert.Equal(t, expectedStatement, statement)
+}
+
+func TestReadOfxXml(t *testing.T) {
+	file := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220"?>
+<OFX>
+	<INVSTMTMSGSRSV1><INVSTMTTRNRS><INVSTMTRS>
+		<INVACCTFROM><BROKERID>passfolio.com</BROKERID></INVACCTFROM>
+		<INVTRANLIST>
+			<BUYSTOCK>
+				<INVBUY>
+					<INVTRAN><DTTRADE>20211005</DTTRADE></INVTRAN>
+					<SECID><UNIQUEID>88160R101</UNIQUEID></SECID>
+					<UNITS>0.125</UNITS>
+					<UNITPRICE>790.00</UNITPRICE>
+				</INVBUY>
+			</BUYSTOCK>
+		</INVTRANLIST>
+	</INVSTMTRS></INVSTMTTRNRS></INVSTMTMSGSRSV1>
+	<SECLISTMSGSRSV1><SECLIST><STOCKINFO><SECINFO>
+		<SECID><UNIQUEID>88160R101</UNIQUEID></SECID>
+		<TICKER>TSLA</TICKER>
+	</SECINFO></STOCKINFO></SECLIST></SECLISTMSGSRSV1>
+</OFX>`)
+
+	expectedOrders := []entity.OrderImportRow{
+		{
+			Line:      1,
+			Symbol:    "TSLA",
+			Country:   "US",
+			OrderType: "buy",
+			Quantity:  0.125,
+			Price:     790,
+			Currency:  "USD",
+			Date:      "2021-10-05",
+		},
+	}
+
+	statement, err := Read(file)
+	assert.Nil(t, err)
+	assert.Equal(t, "passfolio.com", statement.Brokerage)
+	assert.Equal(t, expectedOrders, statement.Orders)
+	assert.Nil(t, statement.Earnings)
+}
+
+func TestReadInvalid(t *testing.T) {
+	_, err := Read([]byte("symbol,quantity\nAAPL,1\n"))
+	assert.Equal(t, entity.ErrInvalidBrokerStatement, err)
+
+	_, err = Read([]byte("Data,Hora,Liquidação,Descrição,Valor (U$)\n" +
+		"01/10/2021,09:00:00,01/10/2021,Transferência recebida,377.80\n"))
+	assert.Equal(t, entity.ErrInvalidBrokerStatementEmpty, err)
+}
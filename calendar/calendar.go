@@ -0,0 +1,251 @@
+package calendar
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"stockfyApi/entity"
+	"sync"
+	"time"
+
+	// The exchanges time zones must be available even in the distroless image
+	_ "time/tzdata"
+)
+
+const dateLayout = "2006-01-02"
+
+// Exchange has the regular trading session of an exchange and the days
+// without session. The session times are in the exchange time zone and the
+// early closes replace the close time of the given dates.
+type Exchange struct {
+	Code        string            `json:"code"`
+	Name        string            `json:"name"`
+	Countries   []string          `json:"countries"`
+	Timezone    string            `json:"timezone"`
+	Open        string            `json:"open"`
+	Close       string            `json:"close"`
+	Holidays    []string          `json:"holidays"`
+	EarlyCloses map[string]string `json:"earlyCloses"`
+
+	location *time.Location
+	holidays map[string]bool
+}
+
+// DefaultExchanges are used until the calendars are loaded from the data
+// files. They only know the regular sessions, without the holidays.
+var DefaultExchanges = []Exchange{
+	{
+		Code:      "B3",
+		Name:      "B3 - Brasil, Bolsa, Balcão",
+		Countries: []string{"BR", "BDR"},
+		Timezone:  "America/Sao_Paulo",
+		Open:      "10:00",
+		Close:     "17:00",
+	},
+	{
+		Code:      "NYSE",
+		Name:      "New York Stock Exchange",
+		Countries: []string{"US"},
+		Timezone:  "America/New_York",
+		Open:      "09:30",
+		Close:     "16:00",
+	},
+}
+
+var (
+	exchangesMutex sync.RWMutex
+	exchanges      = mustInit(DefaultExchanges)
+)
+
+// LoadExchanges reads one JSON file per exchange from the data directory.
+func LoadExchanges(dataDir string) ([]Exchange, error) {
+	var loadedExchanges []Exchange
+
+	files, err := filepath.Glob(filepath.Join(dataDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		var exchange Exchange
+
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = json.Unmarshal(content, &exchange); err != nil {
+			return nil, err
+		}
+
+		if err = exchange.init(); err != nil {
+			return nil, err
+		}
+
+		loadedExchanges = append(loadedExchanges, exchange)
+	}
+
+	return loadedExchanges, nil
+}
+
+// SetExchanges replaces the exchanges used by all the calendar verifications.
+func SetExchanges(newExchanges []Exchange) {
+	exchangesMutex.Lock()
+	defer exchangesMutex.Unlock()
+
+	exchanges = newExchanges
+}
+
+func ListExchanges() []Exchange {
+	exchangesMutex.RLock()
+	defer exchangesMutex.RUnlock()
+
+	return append([]Exchange(nil), exchanges...)
+}
+
+func SearchExchange(code string) (*Exchange, error) {
+	for _, exchange := range ListExchanges() {
+		if exchange.Code == code {
+			return &exchange, nil
+		}
+	}
+
+	return nil, entity.ErrInvalidExchange
+}
+
+// SearchExchangeByCountry returns the exchange of the market country. Markets
+// without exchange, like the cryptocurrencies, are open every day.
+func SearchExchangeByCountry(country string) (*Exchange, error) {
+	for _, exchange := range ListExchanges() {
+		for _, exchangeCountry := range exchange.Countries {
+			if exchangeCountry == country {
+				return &exchange, nil
+			}
+		}
+	}
+
+	return nil, entity.ErrInvalidExchange
+}
+
+func (e *Exchange) init() error {
+	location, err := time.LoadLocation(e.Timezone)
+	if err != nil {
+		return err
+	}
+
+	sessionTimes := []string{e.Open, e.Close}
+	for _, earlyClose := range e.EarlyCloses {
+		sessionTimes = append(sessionTimes, earlyClose)
+	}
+
+	for _, sessionTime := range sessionTimes {
+		if _, err := time.Parse("15:04", sessionTime); err != nil {
+			return err
+		}
+	}
+
+	e.location = location
+	e.holidays = make(map[string]bool, len(e.Holidays))
+	for _, holiday := range e.Holidays {
+		e.holidays[holiday] = true
+	}
+	sort.Strings(e.Holidays)
+
+	return nil
+}
+
+func mustInit(defaultExchanges []Exchange) []Exchange {
+	initExchanges := append([]Exchange(nil), defaultExchanges...)
+
+	for i := range initExchanges {
+		if err := initExchanges[i].init(); err != nil {
+			panic(err)
+		}
+	}
+
+	return initExchanges
+}
+
+// Location returns the time zone of the exchange sessions.
+func (e *Exchange) Location() *time.Location {
+	return e.location
+}
+
+// LocalDate returns the calendar date of the instant in the exchange.
+func (e *Exchange) LocalDate(t time.Time) time.Time {
+	local := t.In(e.location)
+
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0,
+		time.UTC)
+}
+
+// IsTradingDay verifies if the exchange has a session on the date. Only the
+// year, month and day of the date are used.
+func (e *Exchange) IsTradingDay(date time.Time) bool {
+	if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		return false
+	}
+
+	return !e.holidays[date.Format(dateLayout)]
+}
+
+// IsOpen verifies if the exchange is in the trading session on the instant.
+func (e *Exchange) IsOpen(t time.Time) bool {
+	date := e.LocalDate(t)
+	if !e.IsTradingDay(date) {
+		return false
+	}
+
+	sessionOpen, sessionClose := e.Session(date)
+
+	return !t.Before(sessionOpen) && t.Before(sessionClose)
+}
+
+// Session returns the open and close instants of the session on the date.
+func (e *Exchange) Session(date time.Time) (time.Time, time.Time) {
+	closeTime := e.Close
+	if earlyClose, ok := e.EarlyCloses[date.Format(dateLayout)]; ok {
+		closeTime = earlyClose
+	}
+
+	return e.sessionTime(date, e.Open), e.sessionTime(date, closeTime)
+}
+
+// PreviousSession returns the date of the last session before the date.
+func (e *Exchange) PreviousSession(date time.Time) time.Time {
+	return e.searchSession(date, -1)
+}
+
+// NextSession returns the date of the first session after the date.
+func (e *Exchange) NextSession(date time.Time) time.Time {
+	return e.searchSession(date, 1)
+}
+
+func (e *Exchange) searchSession(date time.Time, step int) time.Time {
+	session := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0,
+		time.UTC).AddDate(0, 0, step)
+
+	for !e.IsTradingDay(session) {
+		session = session.AddDate(0, 0, step)
+	}
+
+	return session
+}
+
+func (e *Exchange) sessionTime(date time.Time, sessionTime string) time.Time {
+	clock, _ := time.Parse("15:04", sessionTime)
+
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(),
+		clock.Minute(), 0, 0, e.location)
+}
+
+// ParseDate converts a date in the YYYY-MM-DD format used by the API.
+func ParseDate(date string) (time.Time, error) {
+	parsedDate, err := time.Parse(dateLayout, date)
+	if err != nil {
+		return time.Time{}, entity.ErrInvalidCalendarDate
+	}
+
+	return parsedDate, nil
+}
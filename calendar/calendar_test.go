@@ -0,0 +1,142 @@
+package calendar
+
+import (
+	"stockfyApi/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func loadTestExchanges(t *testing.T) {
+	exchanges, err := LoadExchanges("testdata")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(exchanges))
+
+	SetExchanges(exchanges)
+	t.Cleanup(func() { SetExchanges(mustInit(DefaultExchanges)) })
+}
+
+func TestSearchExchange(t *testing.T) {
+	loadTestExchanges(t)
+
+	b3, err := SearchExchangeByCountry("BDR")
+	assert.Nil(t, err)
+	assert.Equal(t, "B3", b3.Code)
+
+	nyse, err := SearchExchange("NYSE")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"US"}, nyse.Countries)
+
+	_, err = SearchExchangeByCountry("CRYPTO")
+	assert.Equal(t, entity.ErrInvalidExchange, err)
+
+	_, err = SearchExchange("LSE")
+	assert.Equal(t, entity.ErrInvalidExchange, err)
+}
+
+func TestIsTradingDay(t *testing.T) {
+	loadTestExchanges(t)
+
+	b3, _ := SearchExchange("B3")
+
+	assert.True(t, b3.IsTradingDay(entity.StringToTime("2021-10-11")))
+	assert.False(t, b3.IsTradingDay(entity.StringToTime("2021-10-12")))
+	assert.False(t, b3.IsTradingDay(entity.StringToTime("2021-10-16")))
+	assert.False(t, b3.IsTradingDay(entity.StringToTime("2021-10-17")))
+
+	// The default exchanges only know the weekends
+	defaultB3 := mustInit(DefaultExchanges)[0]
+	assert.True(t, defaultB3.IsTradingDay(entity.StringToTime("2021-10-12")))
+	assert.False(t, defaultB3.IsTradingDay(entity.StringToTime("2021-10-16")))
+}
+
+func TestIsOpen(t *testing.T) {
+	loadTestExchanges(t)
+
+	type test struct {
+		exchange string
+		instant  time.Time
+		expected bool
+	}
+
+	tests := []test{
+		{
+			exchange: "B3",
+			instant:  time.Date(2021, 10, 11, 13, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			// 09:59 in São Paulo
+			exchange: "B3",
+			instant:  time.Date(2021, 10, 11, 12, 59, 0, 0, time.UTC),
+			expected: false,
+		},
+		{
+			// 17:00 in São Paulo
+			exchange: "B3",
+			instant:  time.Date(2021, 10, 11, 20, 0, 0, 0, time.UTC),
+			expected: false,
+		},
+		{
+			exchange: "B3",
+			instant:  time.Date(2021, 10, 12, 15, 0, 0, 0, time.UTC),
+			expected: false,
+		},
+		{
+			// 12:30 in New York on an early close
+			exchange: "NYSE",
+			instant:  time.Date(2021, 11, 26, 17, 30, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			// 14:00 in New York on an early close
+			exchange: "NYSE",
+			instant:  time.Date(2021, 11, 26, 19, 0, 0, 0, time.UTC),
+			expected: false,
+		},
+		{
+			// 15:00 in New York
+			exchange: "NYSE",
+			instant:  time.Date(2021, 11, 29, 20, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+	}
+
+	for _, testCase := range tests {
+		exchange, _ := SearchExchange(testCase.exchange)
+		assert.Equal(t, testCase.expected, exchange.IsOpen(testCase.instant),
+			testCase.instant.String())
+	}
+}
+
+func TestPreviousAndNextSession(t *testing.T) {
+	loadTestExchanges(t)
+
+	b3, _ := SearchExchange("B3")
+
+	assert.Equal(t, entity.StringToTime("2021-10-11"),
+		b3.PreviousSession(entity.StringToTime("2021-10-13")))
+	assert.Equal(t, entity.StringToTime("2021-10-13"),
+		b3.NextSession(entity.StringToTime("2021-10-11")))
+
+	// Weekend followed by a holiday
+	assert.Equal(t, entity.StringToTime("2021-11-12"),
+		b3.PreviousSession(entity.StringToTime("2021-11-16")))
+	assert.Equal(t, entity.StringToTime("2021-11-16"),
+		b3.NextSession(entity.StringToTime("2021-11-12")))
+
+	// 23:30 in São Paulo is already the next day in UTC
+	assert.Equal(t, entity.StringToTime("2021-10-15"),
+		b3.LocalDate(time.Date(2021, 10, 16, 2, 30, 0, 0, time.UTC)))
+}
+
+func TestLoadExchanges(t *testing.T) {
+	exchanges, err := LoadExchanges("DIRECTORY_DOES_NOT_EXIST")
+	assert.Nil(t, err)
+	assert.Nil(t, exchanges)
+
+	exchanges, err = LoadExchanges("data")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(exchanges))
+}
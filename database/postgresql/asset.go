@@ -86,6 +86,44 @@ func (r *AssetPostgres) Create(assetInsert entity.Asset) entity.Asset {
 	return assetReturn
 }
 
+// createAssetsTx inserts the assets in the transaction, classified by their
+// sector mapping, and returns their ids by symbol. It is used by the
+// repositories that create the assets together with other changes.
+func createAssetsTx(tx pgx.Tx, assets []entity.Asset) (map[string]string,
+	error) {
+
+	assetIds := map[string]string{}
+
+	insertRow := `
+	INSERT INTO
+		assets(preference, fullname, symbol, asset_type_id, sector_id,
+			sector_mapping_id)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id;
+	`
+
+	for _, assetInsert := range assets {
+		var assetId string
+		var sectorMappingId *string
+
+		if assetInsert.SectorMapping != nil {
+			sectorMappingId = &assetInsert.SectorMapping.Id
+		}
+
+		err := tx.QueryRow(context.Background(), insertRow,
+			assetInsert.Preference, assetInsert.Fullname, assetInsert.Symbol,
+			assetInsert.AssetType.Id, assetInsert.Sector.Id,
+			sectorMappingId).Scan(&assetId)
+		if err != nil {
+			return nil, err
+		}
+
+		assetIds[assetInsert.Symbol] = assetId
+	}
+
+	return assetIds, nil
+}
+
 func (r *AssetPostgres) Search(symbol string) ([]entity.Asset, error) {
 
 	var symbolQuery []entity.Asset
@@ -338,6 +376,46 @@ func (r *AssetPostgres) SearchByUser(symbol string, userUid string,
 	return symbolQuery, err
 }
 
+// SearchAllByUser returns all the assets of the user, with their asset type
+// and sector.
+func (r *AssetPostgres) SearchAllByUser(userUid string) ([]entity.Asset,
+	error) {
+
+	var assetsQuery []entity.Asset
+
+	query := `
+	SELECT
+		a.id, symbol, preference, fullname,
+		json_build_object(
+			'id', aty.id,
+			'type', aty."type",
+			'name', aty."name",
+			'country', aty.country
+		) as asset_type,
+		json_build_object(
+			'id', s.id,
+			'name', s."name"
+		) as sector
+	FROM asset_users as au
+	INNER JOIN assets as a
+	ON a.id = au.asset_id
+	INNER JOIN asset_types as aty
+	ON aty.id = a.asset_type_id
+	INNER JOIN sectors as s
+	ON s.id = a.sector_id
+	WHERE au.user_uid = $1
+	ORDER BY aty.country, a.symbol;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &assetsQuery, query,
+		userUid)
+	if err != nil {
+		fmt.Println("entity.SearchAllByUser: ", err)
+	}
+
+	return assetsQuery, err
+}
+
 func (r *AssetPostgres) SearchPerAssetType(assetType string, country string,
 	userUid string, withOrdersInfo bool) []entity.AssetType {
 
@@ -470,9 +548,64 @@ func (r *AssetPostgres) SearchByOrderId(orderId string) []entity.Asset {
 	return assetInfo
 }
 
-func (r *AssetPostgres) Delete(assetId string) ([]entity.Asset, error) {
+// Update changes the asset for every user. When the sector changes, the asset
+// leaves the sector mapping of its provider label, so the admin choice is kept
+// when the label is mapped again.
+func (r *AssetPostgres) Update(assetUpdate entity.Asset,
+	auditLog *entity.AuditLog) ([]entity.Asset, error) {
+
+	var assetInfo []entity.Asset
+
+	query := `
+	UPDATE assets as a
+	SET
+		fullname = $2,
+		preference = $3,
+		sector_id = $4,
+		sector_mapping_id = CASE
+			WHEN a.sector_id = $4 THEN a.sector_mapping_id
+			ELSE NULL
+		END
+	FROM asset_types as aty, sectors as s
+	WHERE a.id = $1 AND aty.id = a.asset_type_id AND s.id = $4
+	RETURNING
+		a.id, a.symbol, a.preference, a.fullname,
+		json_build_object(
+			'id', aty.id,
+			'type', aty."type",
+			'name', aty."name",
+			'country', aty.country
+		) as asset_type,
+		json_build_object(
+			'id', s.id,
+			'name', s."name"
+		) as sector;
+	`
+
+	err := auditChange(r.dbpool, auditLog, func(tx pgx.Tx) (string,
+		interface{}, error) {
+
+		err := pgxscan.Select(context.Background(), tx, &assetInfo, query,
+			assetUpdate.Id, assetUpdate.Fullname, assetUpdate.Preference,
+			assetUpdate.Sector.Id)
+		if err != nil || len(assetInfo) == 0 {
+			return "", nil, err
+		}
+
+		return assetInfo[0].Id, assetInfo[0], nil
+	})
+	if err != nil {
+		fmt.Println("entity.UpdateAsset: ", err)
+		return nil, err
+	}
+
+	return assetInfo, err
+}
+
+func (r *AssetPostgres) Delete(assetId string, auditLog *entity.AuditLog) (
+	[]entity.Asset, error) {
+
 	var assetInfo []entity.Asset
-	var err error
 
 	queryDeleteAsset := `
 	delete from assets as a
@@ -480,10 +613,20 @@ func (r *AssetPostgres) Delete(assetId string) ([]entity.Asset, error) {
 	returning  a.id, a.symbol, a.preference, a.fullname;
 	`
 
-	err = pgxscan.Select(context.Background(), r.dbpool, &assetInfo,
-		queryDeleteAsset, assetId)
+	err := auditChange(r.dbpool, auditLog, func(tx pgx.Tx) (string,
+		interface{}, error) {
+
+		err := pgxscan.Select(context.Background(), tx, &assetInfo,
+			queryDeleteAsset, assetId)
+		if err != nil || len(assetInfo) == 0 {
+			return "", nil, err
+		}
+
+		return assetInfo[0].Id, nil, nil
+	})
 	if err != nil {
 		fmt.Println("entity.DeleteAsset: ", err)
+		return nil, err
 	}
 
 	return assetInfo, err
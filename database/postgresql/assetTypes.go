@@ -3,9 +3,11 @@ package postgresql
 import (
 	"context"
 	"errors"
+	"fmt"
 	"stockfyApi/entity"
 
 	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
 	_ "github.com/lib/pq"
 )
 
@@ -62,3 +64,124 @@ func (r *AssetTypePostgres) Search(searchType string, name string,
 
 	return assetTypeQuery, err
 }
+
+func (r *AssetTypePostgres) SearchById(assetTypeId string) (
+	[]entity.AssetType, error) {
+
+	var assetTypeQuery []entity.AssetType
+
+	query := `
+	SELECT
+		id, "type", "name", country
+	FROM asset_types
+	WHERE id = $1;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &assetTypeQuery,
+		query, assetTypeId)
+	if err != nil {
+		fmt.Println("entity.SearchAssetTypeById: ", err)
+	}
+
+	return assetTypeQuery, err
+}
+
+// Create inserts the asset type only when the country does not have an asset
+// type of the same type yet.
+func (r *AssetTypePostgres) Create(assetType entity.AssetType,
+	auditLog *entity.AuditLog) ([]entity.AssetType, error) {
+
+	var assetTypeQuery []entity.AssetType
+
+	query := `
+	INSERT INTO
+		asset_types("type", "name", country)
+	SELECT $1, $2, $3
+	WHERE NOT EXISTS (
+		SELECT 1 FROM asset_types WHERE "type" = $1 AND country = $3
+	)
+	RETURNING id, "type", "name", country;
+	`
+
+	err := auditChange(r.dbpool, auditLog, func(tx pgx.Tx) (string,
+		interface{}, error) {
+
+		err := pgxscan.Select(context.Background(), tx, &assetTypeQuery, query,
+			assetType.Type, assetType.Name, assetType.Country)
+		if err != nil || len(assetTypeQuery) == 0 {
+			return "", nil, err
+		}
+
+		return assetTypeQuery[0].Id, assetTypeQuery[0], nil
+	})
+	if err != nil {
+		fmt.Println("entity.CreateAssetType: ", err)
+		return nil, err
+	}
+
+	return assetTypeQuery, err
+}
+
+func (r *AssetTypePostgres) Update(assetTypeId string, name string,
+	auditLog *entity.AuditLog) ([]entity.AssetType, error) {
+
+	var assetTypeQuery []entity.AssetType
+
+	query := `
+	UPDATE asset_types
+	SET "name" = $2
+	WHERE id = $1
+	RETURNING id, "type", "name", country;
+	`
+
+	err := auditChange(r.dbpool, auditLog, func(tx pgx.Tx) (string,
+		interface{}, error) {
+
+		err := pgxscan.Select(context.Background(), tx, &assetTypeQuery, query,
+			assetTypeId, name)
+		if err != nil || len(assetTypeQuery) == 0 {
+			return "", nil, err
+		}
+
+		return assetTypeQuery[0].Id, assetTypeQuery[0], nil
+	})
+	if err != nil {
+		fmt.Println("entity.UpdateAssetType: ", err)
+		return nil, err
+	}
+
+	return assetTypeQuery, err
+}
+
+// Delete removes the asset type only when no asset has this type.
+func (r *AssetTypePostgres) Delete(assetTypeId string,
+	auditLog *entity.AuditLog) ([]entity.AssetType, error) {
+
+	var assetTypeQuery []entity.AssetType
+
+	query := `
+	DELETE FROM asset_types as aty
+	WHERE aty.id = $1 AND NOT EXISTS (
+		SELECT 1 FROM assets as a WHERE a.asset_type_id = aty.id
+	)
+	RETURNING aty.id, aty."type", aty."name", aty.country;
+	`
+
+	err := auditChange(r.dbpool, auditLog, func(tx pgx.Tx) (string,
+		interface{}, error) {
+
+		err := pgxscan.Select(context.Background(), tx, &assetTypeQuery, query,
+			assetTypeId)
+		if err != nil || len(assetTypeQuery) == 0 {
+			return "", nil, err
+		}
+
+		return assetTypeQuery[0].Id, nil, nil
+	})
+	if err != nil {
+		fmt.Println("entity.DeleteAssetType: ", err)
+		return nil, err
+	}
+
+	return assetTypeQuery, err
+}
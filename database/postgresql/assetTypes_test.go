@@ -235,3 +235,105 @@ func TestAssetTypeSearchPerType(t *testing.T) {
 	assert.NotNil(t, assetType)
 	assert.Equal(t, expectedAssetTypes, assetType)
 }
+
+func TestAssetTypeCreate(t *testing.T) {
+
+	expectedAssetTypes := []entity.AssetType{
+		{
+			Id:      "7",
+			Type:    "REIT",
+			Country: "US",
+			Name:    "REITs",
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	INSERT INTO
+		asset_types("type", "name", country)
+	SELECT $1, $2, $3
+	WHERE NOT EXISTS (
+		SELECT 1 FROM asset_types WHERE "type" = $1 AND country = $3
+	)
+	RETURNING id, "type", "name", country;
+	`)
+
+	columns := []string{"id", "type", "name", "country"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	auditLog := entity.AuditLog{
+		UserUid:    "TestAdminUID",
+		Action:     entity.AuditActionCreate,
+		EntityType: entity.AuditEntityAssetType,
+	}
+
+	expectedAuditLog := auditLog
+	expectedAuditLog.EntityId = "7"
+
+	rows := mock.NewRows(columns)
+	mock.ExpectBegin()
+	mock.ExpectQuery(query).WithArgs("REIT", "REITs", "US").WillReturnRows(
+		rows.AddRow("7", "REIT", "REITs", "US"))
+	expectAuditLog(mock, expectedAuditLog, expectedAssetTypes[0])
+	mock.ExpectCommit()
+
+	assetTypes := AssetTypePostgres{dbpool: mock}
+	assetTypeInfo, err := assetTypes.Create(entity.AssetType{
+		Type:    "REIT",
+		Name:    "REITs",
+		Country: "US",
+	}, &auditLog)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedAssetTypes, assetTypeInfo)
+}
+
+func TestAssetTypeDelete(t *testing.T) {
+
+	query := regexp.QuoteMeta(`
+	DELETE FROM asset_types as aty
+	WHERE aty.id = $1 AND NOT EXISTS (
+		SELECT 1 FROM assets as a WHERE a.asset_type_id = aty.id
+	)
+	RETURNING aty.id, aty."type", aty."name", aty.country;
+	`)
+
+	columns := []string{"id", "type", "name", "country"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	auditLog := entity.AuditLog{
+		UserUid:    "TestAdminUID",
+		Action:     entity.AuditActionDelete,
+		EntityType: entity.AuditEntityAssetType,
+		EntityId:   "2",
+	}
+
+	// The asset type with assets is not deleted, so nothing is audited
+	mock.ExpectBegin()
+	mock.ExpectQuery(query).WithArgs("2").WillReturnRows(
+		mock.NewRows(columns))
+	mock.ExpectCommit()
+
+	assetTypes := AssetTypePostgres{dbpool: mock}
+	assetTypeInfo, err := assetTypes.Delete("2", &auditLog)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Nil(t, assetTypeInfo)
+}
@@ -2,6 +2,7 @@ package postgresql
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"regexp"
@@ -755,15 +756,27 @@ func TestAssetDelete(t *testing.T) {
 	}
 	defer mock.Close(context.Background())
 
+	auditLog := entity.AuditLog{
+		UserUid:    "TestAdminUID",
+		Action:     entity.AuditActionDelete,
+		EntityType: entity.AuditEntityAsset,
+		EntityId:   "0a52d206-ed8b-11eb-9a03-0242ac130003",
+		Before:     json.RawMessage(`{"Symbol":"ITUB4"}`),
+	}
+
 	rowsDelAsset := mock.NewRows(columnsDelAsset)
 
+	mock.ExpectBegin()
 	mock.ExpectQuery(queryDeleteAsset).WithArgs(
 		"0a52d206-ed8b-11eb-9a03-0242ac130003").WillReturnRows(
 		rowsDelAsset.AddRow("0a52d206-ed8b-11eb-9a03-0242ac130003", "ITUB4",
 			&preference, "Itau Unibanco Holding SA"))
+	expectAuditLog(mock, auditLog, nil)
+	mock.ExpectCommit()
 
 	Asset := AssetPostgres{dbpool: mock}
-	assetInfo, err := Asset.Delete("0a52d206-ed8b-11eb-9a03-0242ac130003")
+	assetInfo, err := Asset.Delete("0a52d206-ed8b-11eb-9a03-0242ac130003",
+		&auditLog)
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -774,6 +787,107 @@ func TestAssetDelete(t *testing.T) {
 	assert.Equal(t, expectedDelAsset, assetInfo)
 }
 
+func TestAssetUpdate(t *testing.T) {
+
+	preference := "PN"
+
+	assetType := entity.AssetType{
+		Id:      "28ccf27a-ed8b-11eb-9a03-0242ac130003",
+		Type:    "STOCK",
+		Name:    "Ações Brasil",
+		Country: "BR",
+	}
+
+	sector := entity.Sector{
+		Id:   "83ae92f8-ed8b-11eb-9a03-0242ac130003",
+		Name: "Banks",
+	}
+
+	assetUpdate := entity.Asset{
+		Id:         "0a52d206-ed8b-11eb-9a03-0242ac130003",
+		Symbol:     "ITUB4",
+		Preference: &preference,
+		Fullname:   "Itau Unibanco Holding SA",
+		AssetType:  &assetType,
+		Sector:     &entity.Sector{Id: "83ae92f8-ed8b-11eb-9a03-0242ac130003"},
+	}
+
+	var expectedAsset = []entity.Asset{
+		{
+			Id:         "0a52d206-ed8b-11eb-9a03-0242ac130003",
+			Symbol:     "ITUB4",
+			Preference: &preference,
+			Fullname:   "Itau Unibanco Holding SA",
+			AssetType:  &assetType,
+			Sector:     &sector,
+		},
+	}
+
+	queryUpdateAsset := regexp.QuoteMeta(`
+	UPDATE assets as a
+	SET
+		fullname = $2,
+		preference = $3,
+		sector_id = $4,
+		sector_mapping_id = CASE
+			WHEN a.sector_id = $4 THEN a.sector_mapping_id
+			ELSE NULL
+		END
+	FROM asset_types as aty, sectors as s
+	WHERE a.id = $1 AND aty.id = a.asset_type_id AND s.id = $4
+	RETURNING
+		a.id, a.symbol, a.preference, a.fullname,
+		json_build_object(
+			'id', aty.id,
+			'type', aty."type",
+			'name', aty."name",
+			'country', aty.country
+		) as asset_type,
+		json_build_object(
+			'id', s.id,
+			'name', s."name"
+		) as sector;
+	`)
+
+	columns := []string{"id", "symbol", "preference", "fullname", "asset_type",
+		"sector"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	auditLog := entity.AuditLog{
+		UserUid:    "TestAdminUID",
+		Action:     entity.AuditActionUpdate,
+		EntityType: entity.AuditEntityAsset,
+		EntityId:   "0a52d206-ed8b-11eb-9a03-0242ac130003",
+		Before:     json.RawMessage(`{"Symbol":"ITUB4"}`),
+	}
+
+	rows := mock.NewRows(columns)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryUpdateAsset).WithArgs(
+		"0a52d206-ed8b-11eb-9a03-0242ac130003", "Itau Unibanco Holding SA",
+		&preference, "83ae92f8-ed8b-11eb-9a03-0242ac130003").WillReturnRows(
+		rows.AddRow("0a52d206-ed8b-11eb-9a03-0242ac130003", "ITUB4",
+			&preference, "Itau Unibanco Holding SA", &assetType, &sector))
+	expectAuditLog(mock, auditLog, expectedAsset[0])
+	mock.ExpectCommit()
+
+	Asset := AssetPostgres{dbpool: mock}
+	assetInfo, err := Asset.Update(assetUpdate, &auditLog)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedAsset, assetInfo)
+}
+
 func TestAssetSearchPerAssetTypeWithoutOrderInfo(t *testing.T) {
 
 	preference := "PN"
@@ -1013,3 +1127,80 @@ func testSearchAssetPerAssetType(userUid string, assetType string,
 
 	return assetTypeInfo, err2
 }
+
+func TestAssetSearchAllByUser(t *testing.T) {
+	userUid := "aji392a"
+
+	assetType := entity.AssetType{
+		Id:      "28ccf27a-ed8b-11eb-9a03-0242ac130003",
+		Type:    "STOCK",
+		Name:    "Ações Brasil",
+		Country: "BR",
+	}
+	preference := "PN"
+
+	sectorInfo := entity.Sector{
+		Id:   "83ae92f8-ed8b-11eb-9a03-0242ac130003",
+		Name: "Finance",
+	}
+
+	var expectedAssets = []entity.Asset{
+		{
+			Id:         "0a52d206-ed8b-11eb-9a03-0242ac130003",
+			Symbol:     "ITUB4",
+			Preference: &preference,
+			Fullname:   "Itau Unibanco Holding SA",
+			AssetType:  &assetType,
+			Sector:     &sectorInfo,
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	SELECT
+		a.id, symbol, preference, fullname,
+		json_build_object(
+			'id', aty.id,
+			'type', aty."type",
+			'name', aty."name",
+			'country', aty.country
+		) as asset_type,
+		json_build_object(
+			'id', s.id,
+			'name', s."name"
+		) as sector
+	FROM asset_users as au
+	INNER JOIN assets as a
+	ON a.id = au.asset_id
+	INNER JOIN asset_types as aty
+	ON aty.id = a.asset_type_id
+	INNER JOIN sectors as s
+	ON s.id = a.sector_id
+	WHERE au.user_uid = $1
+	ORDER BY aty.country, a.symbol;
+	`)
+
+	columns := []string{"id", "symbol", "preference", "fullname", "asset_type",
+		"sector"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(query).WithArgs(userUid).WillReturnRows(
+		rows.AddRow("0a52d206-ed8b-11eb-9a03-0242ac130003", "ITUB4", &preference,
+			"Itau Unibanco Holding SA", &assetType, &sectorInfo))
+
+	Asset := AssetPostgres{dbpool: mock}
+
+	assets, err := Asset.SearchAllByUser(userUid)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedAssets, assets)
+}
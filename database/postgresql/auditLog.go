@@ -0,0 +1,100 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"stockfyApi/entity"
+
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+)
+
+type AuditLogPostgres struct {
+	dbpool PgxIface
+}
+
+func NewAuditLogPostgres(db PgxIface) *AuditLogPostgres {
+	return &AuditLogPostgres{
+		dbpool: db,
+	}
+}
+
+// auditChange runs the change made by the admin in a transaction, storing its
+// audit log in the same transaction. The change returns the id of the entity
+// changed, blank when no entity was changed, and the entity after the change,
+// nil when it was deleted. The change is not audited when the audit log is
+// nil, like the changes of the custom brokerages.
+func auditChange(dbpool PgxIface, auditLog *entity.AuditLog,
+	change func(tx pgx.Tx) (string, interface{}, error)) error {
+
+	tx, err := dbpool.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback(context.Background())
+
+	entityId, after, err := change(tx)
+	if err != nil {
+		return err
+	}
+
+	if auditLog != nil && entityId != "" {
+		if err = auditLog.Record(entityId, after); err != nil {
+			return err
+		}
+
+		if _, err = createAuditLog(tx, *auditLog); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(context.Background())
+}
+
+func createAuditLog(db pgxscan.Querier, auditLog entity.AuditLog) (
+	[]entity.AuditLog, error) {
+
+	var auditLogRow []entity.AuditLog
+
+	insertRow := `
+	INSERT INTO
+		admin_audit_logs(user_uid, "action", entity_type, entity_id, "before",
+			"after")
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, user_uid, "action", entity_type, entity_id, "before",
+		"after", created_at;
+	`
+
+	err := pgxscan.Select(context.Background(), db, &auditLogRow, insertRow,
+		auditLog.UserUid, auditLog.Action, auditLog.EntityType,
+		auditLog.EntityId, auditLog.Before, auditLog.After)
+
+	return auditLogRow, err
+}
+
+// Search returns the most recent changes first. The entity type and the
+// entity are not filtered when they are blank.
+func (r *AuditLogPostgres) Search(entityType string, entityId string,
+	limit int) ([]entity.AuditLog, error) {
+
+	var auditLogRows []entity.AuditLog
+
+	query := `
+	SELECT
+		id, user_uid, "action", entity_type, entity_id, "before", "after",
+		created_at
+	FROM admin_audit_logs
+	WHERE ($1 = '' OR entity_type = $1) AND ($2 = '' OR entity_id = $2)
+	ORDER BY created_at DESC
+	LIMIT $3;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &auditLogRows,
+		query, entityType, entityId, limit)
+	if err != nil {
+		fmt.Println("entity.SearchAuditLog: ", err)
+	}
+
+	return auditLogRows, err
+}
@@ -0,0 +1,179 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"stockfyApi/entity"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLogCreate(t *testing.T) {
+	createdAt := entity.StringToTime("2021-10-01")
+	before := json.RawMessage(`{"name":"Finance"}`)
+	after := json.RawMessage(`{"name":"Financials"}`)
+
+	auditLog := entity.AuditLog{
+		UserUid:    "TestAdminUID",
+		Action:     entity.AuditActionUpdate,
+		EntityType: entity.AuditEntitySector,
+		EntityId:   "83ae92f8",
+		Before:     before,
+		After:      after,
+	}
+
+	expectedAuditLogRow := []entity.AuditLog{
+		{
+			Id:         "5b3d1a7e",
+			UserUid:    "TestAdminUID",
+			Action:     entity.AuditActionUpdate,
+			EntityType: entity.AuditEntitySector,
+			EntityId:   "83ae92f8",
+			Before:     before,
+			After:      after,
+			CreatedAt:  createdAt,
+		},
+	}
+
+	insertRow := regexp.QuoteMeta(`
+	INSERT INTO
+		admin_audit_logs(user_uid, "action", entity_type, entity_id, "before",
+			"after")
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, user_uid, "action", entity_type, entity_id, "before",
+		"after", created_at;
+	`)
+
+	columns := []string{"id", "user_uid", "action", "entity_type", "entity_id",
+		"before", "after", "created_at"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(insertRow).WithArgs("TestAdminUID",
+		entity.AuditActionUpdate, entity.AuditEntitySector, "83ae92f8", before,
+		after).WillReturnRows(rows.AddRow("5b3d1a7e", "TestAdminUID",
+		entity.AuditActionUpdate, entity.AuditEntitySector, "83ae92f8", before,
+		after, createdAt))
+
+	auditLogRow, err := createAuditLog(mock, auditLog)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedAuditLogRow, auditLogRow)
+}
+
+func TestAuditChange(t *testing.T) {
+	sector := entity.Sector{Id: "83ae92f8", Name: "Financials"}
+
+	auditLog := entity.AuditLog{
+		UserUid:    "TestAdminUID",
+		Action:     entity.AuditActionUpdate,
+		EntityType: entity.AuditEntitySector,
+		EntityId:   "83ae92f8",
+		Before:     json.RawMessage(`{"name":"Finance"}`),
+	}
+
+	update := regexp.QuoteMeta(`UPDATE sectors SET name = $2 WHERE id = $1;`)
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	change := func(tx pgx.Tx) (string, interface{}, error) {
+		_, err := tx.Exec(context.Background(),
+			`UPDATE sectors SET name = $2 WHERE id = $1;`, sector.Id,
+			sector.Name)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return sector.Id, sector, nil
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(update).WithArgs(sector.Id, sector.Name).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	expectAuditLog(mock, auditLog, sector)
+	mock.ExpectCommit()
+
+	auditLogChange := auditLog
+	err = auditChange(mock, &auditLogChange, change)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+
+	// The change is rolled back when its audit log is not stored
+	mock.ExpectBegin()
+	mock.ExpectExec(update).WithArgs(sector.Id, sector.Name).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectQuery("INSERT INTO admin_audit_logs").
+		WillReturnError(errors.New("relation does not exist"))
+	mock.ExpectRollback()
+
+	auditLogChange = auditLog
+	err = auditChange(mock, &auditLogChange, change)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.NotNil(t, err)
+
+	// The changes without an audit log are not audited
+	mock.ExpectBegin()
+	mock.ExpectExec(update).WithArgs(sector.Id, sector.Name).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectCommit()
+
+	err = auditChange(mock, nil, change)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+}
+
+// expectAuditLog expects the audit log of the change made by the admin to be
+// stored with the entity after the change, in the transaction of the change.
+func expectAuditLog(mock pgxmock.PgxConnIface, auditLog entity.AuditLog,
+	after interface{}) {
+
+	afterJson, _ := json.Marshal(after)
+	if after == nil {
+		afterJson = nil
+	}
+
+	insertRow := regexp.QuoteMeta(`
+	INSERT INTO
+		admin_audit_logs(user_uid, "action", entity_type, entity_id, "before",
+			"after")`)
+
+	columns := []string{"id", "user_uid", "action", "entity_type", "entity_id",
+		"before", "after", "created_at"}
+
+	mock.ExpectQuery(insertRow).WithArgs(auditLog.UserUid, auditLog.Action,
+		auditLog.EntityType, auditLog.EntityId, auditLog.Before,
+		json.RawMessage(afterJson)).WillReturnRows(mock.NewRows(columns).AddRow(
+		"5b3d1a7e", auditLog.UserUid, auditLog.Action, auditLog.EntityType,
+		auditLog.EntityId, auditLog.Before, json.RawMessage(afterJson),
+		entity.StringToTime("2021-10-01")))
+}
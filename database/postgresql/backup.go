@@ -0,0 +1,267 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"stockfyApi/entity"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type BackupPostgres struct {
+	dbpool PgxIface
+}
+
+func NewBackupPostgres(db PgxIface) *BackupPostgres {
+	return &BackupPostgres{
+		dbpool: db,
+	}
+}
+
+// Restore replays the backup in the portfolio of the user in a single
+// transaction. The brokerages are searched by name and country, and created as
+// custom brokerages of the user when not found, the accounts are searched by
+// nickname and brokerage and the assets by symbol and country. An account
+// whose nickname is taken by an account of another brokerage is created with
+// the brokerage name as suffix, like the accounts merged by Link, and is found
+// by it when the backup is restored again. An order or earning is only
+// created while the user has fewer identical ones than the backup, so a backup
+// can be restored again without duplicating anything.
+func (r *BackupPostgres) Restore(backup entity.PortfolioBackup,
+	userUid string) (*entity.BackupRestore, error) {
+
+	restore := &entity.BackupRestore{}
+
+	tx, err := r.dbpool.Begin(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback(context.Background())
+
+	brokerageRow := `
+	WITH b as (
+		SELECT id FROM brokerages
+		WHERE upper(name) = upper($1) AND country = $2
+			AND (user_uid IS NULL OR user_uid = $4)
+		ORDER BY user_uid NULLS FIRST
+		LIMIT 1
+	), i as (
+		INSERT INTO
+			brokerages(name, fullname, country, user_uid)
+		SELECT $1, $3, $2, $4
+		WHERE NOT EXISTS (SELECT 1 FROM b)
+		RETURNING id
+	)
+	SELECT id FROM i
+	UNION ALL
+	SELECT id FROM b;
+	`
+
+	accountRow := `
+	WITH a as (
+		SELECT id FROM brokerage_accounts
+		WHERE user_uid = $1 AND brokerage_id = $3::uuid
+			AND nickname IN ($2::text, $2::text || ' (' || $4::text || ')')
+		ORDER BY nickname = $2::text DESC
+		LIMIT 1
+	), i as (
+		INSERT INTO
+			brokerage_accounts(user_uid, brokerage_id, nickname)
+		SELECT $1, $3::uuid, CASE WHEN EXISTS (
+			SELECT 1 FROM brokerage_accounts
+			WHERE user_uid = $1 AND nickname = $2::text
+		) THEN $2::text || ' (' || $4::text || ')' ELSE $2::text END
+		WHERE NOT EXISTS (SELECT 1 FROM a)
+		RETURNING id
+	)
+	SELECT id, true as created FROM i
+	UNION ALL
+	SELECT id, false as created FROM a;
+	`
+
+	assetRow := `
+	WITH a as (
+		SELECT a.id FROM assets as a
+		INNER JOIN asset_types as aty
+		ON aty.id = a.asset_type_id
+		WHERE a.symbol = $1 AND aty.country = $2
+		LIMIT 1
+	), s as (
+		SELECT id FROM sectors
+		WHERE name = $6
+		ORDER BY parent_id NULLS FIRST
+		LIMIT 1
+	), si as (
+		INSERT INTO
+			sectors(name)
+		SELECT $6
+		WHERE NOT EXISTS (SELECT 1 FROM a) AND NOT EXISTS (SELECT 1 FROM s)
+		RETURNING id
+	), i as (
+		INSERT INTO
+			assets(symbol, fullname, preference, asset_type_id, sector_id)
+		SELECT $1, $3, $4, aty.id, (
+			SELECT id FROM s UNION ALL SELECT id FROM si LIMIT 1
+		)
+		FROM asset_types as aty
+		WHERE aty."type" = $5 AND aty.country = $2
+			AND NOT EXISTS (SELECT 1 FROM a)
+		RETURNING id
+	)
+	SELECT id, true as created FROM i
+	UNION ALL
+	SELECT id, false as created FROM a;
+	`
+
+	insertAssetUser := `
+	INSERT INTO
+		asset_users(asset_id, user_uid)
+	VALUES ($1, $2)
+	ON CONFLICT (asset_id, user_uid) DO NOTHING;
+	`
+
+	insertOrder := `
+	INSERT INTO
+		orders(quantity, price, currency, order_type, date, fees, withheld_tax,
+			settlement_date, asset_id, account_id, brokerage_id, user_uid
+		)
+	SELECT $1::float8, $2::float8, $3, $4, $5::date, $6::float8, $7::float8,
+		$8::date, $9::uuid, ba.id, ba.brokerage_id, $11
+	FROM brokerage_accounts as ba
+	WHERE ba.id = $10 AND (
+		SELECT count(*) FROM orders as o
+		WHERE o.user_uid = $11 AND o.asset_id = $9 AND o.account_id = $10
+			AND o.date = $5 AND o.order_type = $4 AND o.quantity = $1
+			AND o.price = $2 AND o.currency = $3
+	) < $12;
+	`
+
+	insertEarning := `
+	INSERT INTO
+		earnings("type", earning, withheld_tax, date, currency, asset_id,
+			user_uid
+		)
+	SELECT $1, $2::float8, $3::float8, $4::date, $5, $6::uuid, $7
+	WHERE (
+		SELECT count(*) FROM earnings as e
+		WHERE e.user_uid = $7 AND e.asset_id = $6 AND e."type" = $1
+			AND e.date = $4 AND e.earning = $2 AND e.currency = $5
+	) < $8;
+	`
+
+	accountIds := map[string]string{}
+	for _, account := range backup.Accounts {
+		var brokerageId, accountId string
+		var created bool
+
+		fullname := account.BrokerageFullname
+		if fullname == "" {
+			fullname = account.Brokerage
+		}
+
+		err = tx.QueryRow(context.Background(), brokerageRow,
+			account.Brokerage, account.Country, fullname, userUid).Scan(
+			&brokerageId)
+		if err != nil {
+			fmt.Println("entity.RestoreBackup: ", err)
+			return nil, err
+		}
+
+		err = tx.QueryRow(context.Background(), accountRow, userUid,
+			account.Nickname, brokerageId, account.Brokerage).Scan(&accountId,
+			&created)
+		if err != nil {
+			fmt.Println("entity.RestoreBackup: ", err)
+			return nil, err
+		}
+
+		accountIds[account.Nickname] = accountId
+		if created {
+			restore.Accounts++
+		}
+	}
+
+	assetIds := map[string]string{}
+	for _, asset := range backup.Assets {
+		var assetId string
+		var created bool
+
+		err = tx.QueryRow(context.Background(), assetRow, asset.Symbol,
+			asset.Country, asset.Fullname, asset.Preference, asset.AssetType,
+			asset.Sector).Scan(&assetId, &created)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrInvalidBackupAssetType
+		} else if err != nil {
+			fmt.Println("entity.RestoreBackup: ", err)
+			return nil, err
+		}
+
+		_, err = tx.Exec(context.Background(), insertAssetUser, assetId,
+			userUid)
+		if err != nil {
+			fmt.Println("entity.RestoreBackup: ", err)
+			return nil, err
+		}
+
+		assetIds[asset.Symbol+":"+asset.Country] = assetId
+		if created {
+			restore.Assets++
+		}
+	}
+
+	orderOccurrences := map[entity.BackupOrder]int{}
+	for _, order := range backup.Orders {
+		orderOccurrences[order]++
+
+		var settlementDate interface{}
+		if order.SettlementDate != "" {
+			settlementDate = entity.StringToTime(order.SettlementDate)
+		}
+
+		result, err := tx.Exec(context.Background(), insertOrder,
+			order.Quantity, order.Price, order.Currency, order.OrderType,
+			entity.StringToTime(order.Date), order.Fees, order.WithheldTax,
+			settlementDate, assetIds[order.Symbol+":"+order.Country],
+			accountIds[order.Account], userUid, orderOccurrences[order])
+		if err != nil {
+			fmt.Println("entity.RestoreBackup: ", err)
+			return nil, err
+		}
+
+		if result.RowsAffected() == 1 {
+			restore.Orders++
+		} else {
+			restore.SkippedOrders++
+		}
+	}
+
+	earningOccurrences := map[entity.BackupEarning]int{}
+	for _, earning := range backup.Earnings {
+		earningOccurrences[earning]++
+
+		result, err := tx.Exec(context.Background(), insertEarning,
+			earning.EarningType, earning.Amount, earning.WithheldTax,
+			entity.StringToTime(earning.Date), earning.Currency,
+			assetIds[earning.Symbol+":"+earning.Country], userUid,
+			earningOccurrences[earning])
+		if err != nil {
+			fmt.Println("entity.RestoreBackup: ", err)
+			return nil, err
+		}
+
+		if result.RowsAffected() == 1 {
+			restore.Earnings++
+		} else {
+			restore.SkippedEarnings++
+		}
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return restore, nil
+}
@@ -0,0 +1,152 @@
+package postgresql
+
+import (
+	"context"
+	"regexp"
+	"stockfyApi/entity"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupRestore(t *testing.T) {
+	userUid := "aa48fafh4"
+	brokerageId := "55555555-ed8b-11eb-9a03-0242ac130003"
+	accountId := "66666666-ed8b-11eb-9a03-0242ac130003"
+	assetId := "1111BBBB-ed8b-11eb-9a03-0242ac130003"
+
+	backup := entity.PortfolioBackup{
+		Version: entity.BackupVersion,
+		Accounts: []entity.BackupAccount{
+			{Nickname: "Clear", Brokerage: "Clear", Country: "BR"},
+		},
+		Assets: []entity.BackupAsset{
+			{Symbol: "ITUB4", Country: "BR", Fullname: "Itau Unibanco",
+				Preference: "PN", AssetType: "STOCK", Sector: "Finance"},
+		},
+		Orders: []entity.BackupOrder{
+			{Symbol: "ITUB4", Country: "BR", Account: "Clear", OrderType: "buy",
+				Quantity: 20, Price: 29.29, Currency: "BRL", Date: "2021-10-01",
+				SettlementDate: "2021-10-05"},
+			{Symbol: "ITUB4", Country: "BR", Account: "Clear", OrderType: "buy",
+				Quantity: 20, Price: 29.29, Currency: "BRL", Date: "2021-10-01",
+				SettlementDate: "2021-10-05"},
+		},
+		Earnings: []entity.BackupEarning{
+			{Symbol: "ITUB4", Country: "BR", EarningType: "JCP", Amount: 1.7,
+				Currency: "BRL", Date: "2021-11-03"},
+		},
+	}
+
+	brokerageRow := regexp.QuoteMeta(`
+	WITH b as (
+		SELECT id FROM brokerages
+		WHERE upper(name) = upper($1) AND country = $2
+			AND (user_uid IS NULL OR user_uid = $4)
+		ORDER BY user_uid NULLS FIRST
+		LIMIT 1
+	), i as (`)
+
+	accountRow := regexp.QuoteMeta(`
+	WITH a as (
+		SELECT id FROM brokerage_accounts
+		WHERE user_uid = $1 AND brokerage_id = $3::uuid
+			AND nickname IN ($2::text, $2::text || ' (' || $4::text || ')')
+		ORDER BY nickname = $2::text DESC
+		LIMIT 1
+	), i as (`)
+
+	assetRow := regexp.QuoteMeta(`
+	WITH a as (
+		SELECT a.id FROM assets as a
+		INNER JOIN asset_types as aty
+		ON aty.id = a.asset_type_id
+		WHERE a.symbol = $1 AND aty.country = $2
+		LIMIT 1
+	), s as (`)
+
+	insertAssetUser := regexp.QuoteMeta(`
+	INSERT INTO
+		asset_users(asset_id, user_uid)
+	VALUES ($1, $2)
+	ON CONFLICT (asset_id, user_uid) DO NOTHING;
+	`)
+
+	insertOrder := regexp.QuoteMeta(`
+	INSERT INTO
+		orders(quantity, price, currency, order_type, date, fees, withheld_tax,
+			settlement_date, asset_id, account_id, brokerage_id, user_uid
+		)`)
+
+	insertEarning := regexp.QuoteMeta(`
+	INSERT INTO
+		earnings("type", earning, withheld_tax, date, currency, asset_id,
+			user_uid
+		)`)
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	date := entity.StringToTime("2021-10-01")
+	settlementDate := entity.StringToTime("2021-10-05")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(brokerageRow).WithArgs("Clear", "BR", "Clear", userUid).
+		WillReturnRows(mock.NewRows([]string{"id"}).AddRow(brokerageId))
+	mock.ExpectQuery(accountRow).WithArgs(userUid, "Clear", brokerageId,
+		"Clear").
+		WillReturnRows(mock.NewRows([]string{"id", "created"}).AddRow(
+			accountId, true))
+	mock.ExpectQuery(assetRow).WithArgs("ITUB4", "BR", "Itau Unibanco", "PN",
+		"STOCK", "Finance").WillReturnRows(mock.NewRows(
+		[]string{"id", "created"}).AddRow(assetId, false))
+	mock.ExpectExec(insertAssetUser).WithArgs(assetId, userUid).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(insertOrder).WithArgs(20.0, 29.29, "BRL", "buy", date, 0.0,
+		0.0, settlementDate, assetId, accountId, userUid, 1).
+		WillReturnResult(pgxmock.NewResult("INSERT", 0))
+	mock.ExpectExec(insertOrder).WithArgs(20.0, 29.29, "BRL", "buy", date, 0.0,
+		0.0, settlementDate, assetId, accountId, userUid, 2).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(insertEarning).WithArgs("JCP", 1.7, 0.0,
+		entity.StringToTime("2021-11-03"), "BRL", assetId, userUid, 1).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	Backup := BackupPostgres{dbpool: mock}
+	restore, err := Backup.Restore(backup, userUid)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, &entity.BackupRestore{Accounts: 1, Orders: 1, Earnings: 1,
+		SkippedOrders: 1}, restore)
+
+	// An asset whose asset type does not exist rolls back the restore
+	mock.ExpectBegin()
+	mock.ExpectQuery(brokerageRow).WithArgs("Clear", "BR", "Clear", userUid).
+		WillReturnRows(mock.NewRows([]string{"id"}).AddRow(brokerageId))
+	mock.ExpectQuery(accountRow).WithArgs(userUid, "Clear", brokerageId,
+		"Clear").
+		WillReturnRows(mock.NewRows([]string{"id", "created"}).AddRow(
+			accountId, false))
+	mock.ExpectQuery(assetRow).WithArgs("ITUB4", "BR", "Itau Unibanco", "PN",
+		"STOCK", "Finance").WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	restore, err = Backup.Restore(backup, userUid)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, restore)
+	assert.Equal(t, entity.ErrInvalidBackupAssetType, err)
+}
@@ -2,9 +2,11 @@ package postgresql
 
 import (
 	"context"
+	"fmt"
 	"stockfyApi/entity"
 
 	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
 	_ "github.com/lib/pq"
 )
 
@@ -18,8 +20,10 @@ func NewBrokeragePostgres(db PgxIface) *BrokeragePostgres {
 	}
 }
 
-func (r *BrokeragePostgres) Search(specificFetch string, args ...string) (
-	[]entity.Brokerage, error) {
+// Search returns the brokerages available to everyone and the custom
+// brokerages created by the user.
+func (r *BrokeragePostgres) Search(specificFetch string, userUid string,
+	args ...string) ([]entity.Brokerage, error) {
 
 	var brokerageReturn []entity.Brokerage
 	var err error
@@ -29,25 +33,27 @@ func (r *BrokeragePostgres) Search(specificFetch string, args ...string) (
 		return brokerageReturn, entity.ErrInvalidBrokerageSearchType
 	}
 
-	queryDefault := "SELECT id, name, country FROM brokerages "
+	queryDefault := `
+	SELECT id, name, country, user_uid FROM brokerages
+	WHERE (user_uid IS NULL OR user_uid = $1) `
 
 	if specificFetch == "ALL" {
 		err = pgxscan.Select(context.Background(), r.dbpool, &brokerageReturn,
-			queryDefault)
+			queryDefault, userUid)
 		if err != nil {
 			return nil, err
 		}
 	} else if specificFetch == "SINGLE" {
-		query := queryDefault + "where name=$1"
+		query := queryDefault + "and name=$2 ORDER BY user_uid NULLS FIRST"
 		err = pgxscan.Select(context.Background(), r.dbpool, &brokerageReturn,
-			query, args[0])
+			query, userUid, args[0])
 		if err != nil {
 			return nil, err
 		}
 	} else if specificFetch == "COUNTRY" {
-		query := queryDefault + "where country=$1"
+		query := queryDefault + "and country=$2"
 		err = pgxscan.Select(context.Background(), r.dbpool, &brokerageReturn,
-			query, args[0])
+			query, userUid, args[0])
 		if err != nil {
 			return nil, err
 		}
@@ -55,3 +61,388 @@ func (r *BrokeragePostgres) Search(specificFetch string, args ...string) (
 
 	return brokerageReturn, err
 }
+
+func (r *BrokeragePostgres) SearchById(brokerageId string, userUid string) (
+	[]entity.Brokerage, error) {
+
+	var brokerageReturn []entity.Brokerage
+
+	query := `
+	SELECT id, name, fullname, country, user_uid
+	FROM brokerages
+	WHERE id = $1 and (user_uid IS NULL OR user_uid = $2);
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &brokerageReturn,
+		query, brokerageId, userUid)
+	if err != nil {
+		fmt.Println("entity.SearchBrokerageById: ", err)
+	}
+
+	return brokerageReturn, err
+}
+
+// Create inserts the brokerage only when its name is not used by another
+// brokerage visible to the same users.
+func (r *BrokeragePostgres) Create(brokerage entity.Brokerage,
+	auditLog *entity.AuditLog) ([]entity.Brokerage, error) {
+
+	var brokerageReturn []entity.Brokerage
+
+	query := `
+	INSERT INTO
+		brokerages("name", fullname, country, user_uid)
+	SELECT $1, $2, $3, $4
+	WHERE NOT EXISTS (
+		SELECT 1 FROM brokerages
+		WHERE lower("name") = lower($1)
+		AND (user_uid IS NULL OR user_uid = $4)
+	)
+	RETURNING id, "name", fullname, country, user_uid;
+	`
+
+	err := auditChange(r.dbpool, auditLog, func(tx pgx.Tx) (string,
+		interface{}, error) {
+
+		err := pgxscan.Select(context.Background(), tx, &brokerageReturn, query,
+			brokerage.Name, brokerage.Fullname, brokerage.Country,
+			brokerage.UserUid)
+		if err != nil || len(brokerageReturn) == 0 {
+			return "", nil, err
+		}
+
+		return brokerageReturn[0].Id, brokerageReturn[0], nil
+	})
+	if err != nil {
+		fmt.Println("entity.CreateBrokerage: ", err)
+		return nil, err
+	}
+
+	return brokerageReturn, err
+}
+
+func (r *BrokeragePostgres) Update(brokerage entity.Brokerage,
+	auditLog *entity.AuditLog) ([]entity.Brokerage, error) {
+
+	var brokerageReturn []entity.Brokerage
+
+	query := `
+	UPDATE brokerages as b
+	SET "name" = $2,
+		fullname = $3,
+		country = $4
+	WHERE b.id = $1 AND NOT EXISTS (
+		SELECT 1 FROM brokerages as other
+		WHERE lower(other."name") = lower($2) AND other.id <> b.id
+		AND (other.user_uid IS NULL OR other.user_uid = b.user_uid)
+	)
+	RETURNING b.id, b."name", b.fullname, b.country, b.user_uid;
+	`
+
+	err := auditChange(r.dbpool, auditLog, func(tx pgx.Tx) (string,
+		interface{}, error) {
+
+		err := pgxscan.Select(context.Background(), tx, &brokerageReturn, query,
+			brokerage.Id, brokerage.Name, brokerage.Fullname, brokerage.Country)
+		if err != nil || len(brokerageReturn) == 0 {
+			return "", nil, err
+		}
+
+		return brokerageReturn[0].Id, brokerageReturn[0], nil
+	})
+	if err != nil {
+		fmt.Println("entity.UpdateBrokerage: ", err)
+		return nil, err
+	}
+
+	return brokerageReturn, err
+}
+
+// Delete removes the brokerage only when no account uses it.
+func (r *BrokeragePostgres) Delete(brokerageId string,
+	auditLog *entity.AuditLog) ([]entity.Brokerage, error) {
+
+	var brokerageReturn []entity.Brokerage
+
+	query := `
+	DELETE FROM brokerages as b
+	WHERE b.id = $1 AND NOT EXISTS (
+		SELECT 1 FROM brokerage_accounts as ba WHERE ba.brokerage_id = b.id
+	)
+	RETURNING b.id, b."name", b.fullname, b.country, b.user_uid;
+	`
+
+	err := auditChange(r.dbpool, auditLog, func(tx pgx.Tx) (string,
+		interface{}, error) {
+
+		err := pgxscan.Select(context.Background(), tx, &brokerageReturn, query,
+			brokerageId)
+		if err != nil || len(brokerageReturn) == 0 {
+			return "", nil, err
+		}
+
+		return brokerageReturn[0].Id, nil, nil
+	})
+	if err != nil {
+		fmt.Println("entity.DeleteBrokerage: ", err)
+		return nil, err
+	}
+
+	return brokerageReturn, err
+}
+
+// CreateAccount inserts the account only when the user does not have another
+// account with the same nickname.
+func (r *BrokeragePostgres) CreateAccount(account entity.BrokerageAccount) (
+	[]entity.BrokerageAccount, error) {
+
+	var accountReturn []entity.BrokerageAccount
+
+	query := `
+	WITH inserted as (
+		INSERT INTO
+			brokerage_accounts(nickname, brokerage_id, user_uid)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_uid, nickname) DO NOTHING
+		RETURNING id, nickname, brokerage_id, user_uid
+	)
+	SELECT
+		inserted.id, inserted.nickname, inserted.user_uid,
+		json_build_object(
+			'id', b.id,
+			'name', b."name",
+			'country', b.country
+		) as brokerage
+	FROM inserted
+	INNER JOIN brokerages as b
+	ON b.id = inserted.brokerage_id;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &accountReturn,
+		query, account.Nickname, account.Brokerage.Id, account.UserUid)
+	if err != nil {
+		fmt.Println("entity.CreateBrokerageAccount: ", err)
+	}
+
+	return accountReturn, err
+}
+
+// createAccountsTx inserts the accounts in the transaction and returns their
+// ids by brokerage, since they are the default accounts of the user in each
+// brokerage. It is used by the repositories that create the accounts together
+// with other changes.
+func createAccountsTx(tx pgx.Tx, accounts []entity.BrokerageAccount) (
+	map[string]string, error) {
+
+	accountIds := map[string]string{}
+
+	insertRow := `
+	INSERT INTO
+		brokerage_accounts(nickname, brokerage_id, user_uid)
+	VALUES ($1, $2, $3)
+	RETURNING id;
+	`
+
+	for _, account := range accounts {
+		var accountId string
+
+		err := tx.QueryRow(context.Background(), insertRow, account.Nickname,
+			account.Brokerage.Id, account.UserUid).Scan(&accountId)
+		if err != nil {
+			return nil, err
+		}
+
+		accountIds[account.Brokerage.Id] = accountId
+	}
+
+	return accountIds, nil
+}
+
+func (r *BrokeragePostgres) SearchAccounts(userUid string) (
+	[]entity.BrokerageAccount, error) {
+
+	var accountReturn []entity.BrokerageAccount
+
+	query := `
+	SELECT
+		ba.id, ba.nickname, ba.user_uid,
+		json_build_object(
+			'id', b.id,
+			'name', b."name",
+			'country', b.country
+		) as brokerage
+	FROM brokerage_accounts as ba
+	INNER JOIN brokerages as b
+	ON b.id = ba.brokerage_id
+	WHERE ba.user_uid = $1
+	ORDER BY ba.nickname;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &accountReturn,
+		query, userUid)
+	if err != nil {
+		fmt.Println("entity.SearchBrokerageAccounts: ", err)
+	}
+
+	return accountReturn, err
+}
+
+func (r *BrokeragePostgres) SearchAccountById(accountId string,
+	userUid string) ([]entity.BrokerageAccount, error) {
+
+	var accountReturn []entity.BrokerageAccount
+
+	query := `
+	SELECT
+		ba.id, ba.nickname, ba.user_uid,
+		json_build_object(
+			'id', b.id,
+			'name', b."name",
+			'country', b.country
+		) as brokerage
+	FROM brokerage_accounts as ba
+	INNER JOIN brokerages as b
+	ON b.id = ba.brokerage_id
+	WHERE ba.id = $1 and ba.user_uid = $2;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &accountReturn,
+		query, accountId, userUid)
+	if err != nil {
+		fmt.Println("entity.SearchBrokerageAccountById: ", err)
+	}
+
+	return accountReturn, err
+}
+
+// SearchAccountByBrokerage returns the accounts of the user in the brokerage,
+// starting from the oldest one.
+func (r *BrokeragePostgres) SearchAccountByBrokerage(brokerageId string,
+	userUid string) ([]entity.BrokerageAccount, error) {
+
+	var accountReturn []entity.BrokerageAccount
+
+	query := `
+	SELECT
+		ba.id, ba.nickname, ba.user_uid,
+		json_build_object(
+			'id', b.id,
+			'name', b."name",
+			'country', b.country
+		) as brokerage
+	FROM brokerage_accounts as ba
+	INNER JOIN brokerages as b
+	ON b.id = ba.brokerage_id
+	WHERE ba.brokerage_id = $1 and ba.user_uid = $2
+	ORDER BY ba.created_at;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &accountReturn,
+		query, brokerageId, userUid)
+	if err != nil {
+		fmt.Println("entity.SearchBrokerageAccountByBrokerage: ", err)
+	}
+
+	return accountReturn, err
+}
+
+// UpdateAccount renames the account only when the user does not have another
+// account with the same nickname.
+func (r *BrokeragePostgres) UpdateAccount(accountId string, userUid string,
+	nickname string) ([]entity.BrokerageAccount, error) {
+
+	var accountReturn []entity.BrokerageAccount
+
+	query := `
+	WITH updated as (
+		UPDATE brokerage_accounts as ba
+		SET nickname = $3
+		WHERE ba.id = $1 and ba.user_uid = $2 AND NOT EXISTS (
+			SELECT 1 FROM brokerage_accounts as other
+			WHERE other.user_uid = $2 and other.nickname = $3
+			and other.id <> ba.id
+		)
+		RETURNING ba.id, ba.nickname, ba.brokerage_id, ba.user_uid
+	)
+	SELECT
+		updated.id, updated.nickname, updated.user_uid,
+		json_build_object(
+			'id', b.id,
+			'name', b."name",
+			'country', b.country
+		) as brokerage
+	FROM updated
+	INNER JOIN brokerages as b
+	ON b.id = updated.brokerage_id;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &accountReturn,
+		query, accountId, userUid, nickname)
+	if err != nil {
+		fmt.Println("entity.UpdateBrokerageAccount: ", err)
+	}
+
+	return accountReturn, err
+}
+
+// DeleteAccount removes the account only when it has no orders.
+func (r *BrokeragePostgres) DeleteAccount(accountId string, userUid string) (
+	[]entity.BrokerageAccount, error) {
+
+	var accountReturn []entity.BrokerageAccount
+
+	query := `
+	DELETE FROM brokerage_accounts as ba
+	WHERE ba.id = $1 and ba.user_uid = $2 AND NOT EXISTS (
+		SELECT 1 FROM orders as o WHERE o.account_id = ba.id
+	)
+	RETURNING ba.id, ba.nickname, ba.user_uid;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &accountReturn,
+		query, accountId, userUid)
+	if err != nil {
+		fmt.Println("entity.DeleteBrokerageAccount: ", err)
+	}
+
+	return accountReturn, err
+}
+
+// SearchAccountPositions returns the current quantity of each asset of the
+// user in each brokerage account and the amount invested on it, using the
+// average buy price of the account.
+func (r *BrokeragePostgres) SearchAccountPositions(userUid string) (
+	[]entity.AccountPosition, error) {
+
+	var positions []entity.AccountPosition
+
+	query := `
+	SELECT
+		ba.id as account_id, ba.nickname, b.id as brokerage_id,
+		b."name" as brokerage_name, a.id as asset_id, a.symbol, o.currency,
+		SUM(o.quantity) as quantity,
+		SUM(o.quantity) * COALESCE(
+			SUM(o.quantity * o.price) FILTER(WHERE o.order_type = 'buy')
+			/ NULLIF(SUM(o.quantity) FILTER(WHERE o.order_type = 'buy'), 0),
+			0) as invested
+	FROM orders as o
+	INNER JOIN brokerage_accounts as ba
+	ON ba.id = o.account_id
+	INNER JOIN brokerages as b
+	ON b.id = ba.brokerage_id
+	INNER JOIN assets as a
+	ON a.id = o.asset_id
+	WHERE o.user_uid = $1
+	GROUP BY ba.id, ba.nickname, b.id, b."name", a.id, a.symbol, o.currency
+	HAVING SUM(o.quantity) <> 0
+	ORDER BY ba.nickname, a.symbol;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &positions,
+		query, userUid)
+	if err != nil {
+		fmt.Println("entity.SearchAccountPositions: ", err)
+	}
+
+	return positions, err
+}
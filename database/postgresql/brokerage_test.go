@@ -2,6 +2,7 @@ package postgresql
 
 import (
 	"context"
+	"encoding/json"
 	"regexp"
 	"stockfyApi/entity"
 	"testing"
@@ -23,13 +24,12 @@ func TestBrokerageSearchWithName(t *testing.T) {
 	}
 
 	query := regexp.QuoteMeta(`
-	SELECT
-		id, name, country
-	FROM brokerages
-	where name=$1
+	SELECT id, name, country, user_uid FROM brokerages
+	WHERE (user_uid IS NULL OR user_uid = $1) and name=$2
+	ORDER BY user_uid NULLS FIRST
 	`)
 
-	columns := []string{"id", "name", "country"}
+	columns := []string{"id", "name", "country", "user_uid"}
 
 	mock, err := pgxmock.NewConn()
 	if err != nil {
@@ -38,12 +38,12 @@ func TestBrokerageSearchWithName(t *testing.T) {
 	defer mock.Close(context.Background())
 
 	rows := mock.NewRows(columns)
-	mock.ExpectQuery(query).WithArgs("Clear").WillReturnRows(rows.AddRow(
-		"55555555-ed8b-11eb-9a03-0242ac130003", "Clear", "BR"))
+	mock.ExpectQuery(query).WithArgs("TestUserUID", "Clear").WillReturnRows(
+		rows.AddRow("55555555-ed8b-11eb-9a03-0242ac130003", "Clear", "BR", nil))
 
 	Broker := BrokeragePostgres{dbpool: mock}
 
-	brokerageInfos, err := Broker.Search("SINGLE", "Clear")
+	brokerageInfos, err := Broker.Search("SINGLE", "TestUserUID", "Clear")
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -69,13 +69,11 @@ func TestBrokerageSearchWithCountry(t *testing.T) {
 	}
 
 	query := regexp.QuoteMeta(`
-	SELECT
-		id, name, country
-	FROM brokerages
-	where country=$1
+	SELECT id, name, country, user_uid FROM brokerages
+	WHERE (user_uid IS NULL OR user_uid = $1) and country=$2
 	`)
 
-	columns := []string{"id", "name", "country"}
+	columns := []string{"id", "name", "country", "user_uid"}
 
 	mock, err := pgxmock.NewConn()
 	if err != nil {
@@ -84,12 +82,13 @@ func TestBrokerageSearchWithCountry(t *testing.T) {
 	defer mock.Close(context.Background())
 
 	rows := mock.NewRows(columns)
-	mock.ExpectQuery(query).WithArgs("BR").WillReturnRows(rows.AddRow(
-		"55555555-ed8b-11eb-9a03-0242ac130003", "Clear", "BR").AddRow(
-		"55556666-ed8b-11eb-9a03-0242ac130003", "Rico", "BR"))
+	mock.ExpectQuery(query).WithArgs("TestUserUID", "BR").WillReturnRows(
+		rows.AddRow("55555555-ed8b-11eb-9a03-0242ac130003", "Clear", "BR",
+			nil).AddRow("55556666-ed8b-11eb-9a03-0242ac130003", "Rico", "BR",
+			nil))
 
 	Broker := BrokeragePostgres{dbpool: mock}
-	brokerageInfos, err := Broker.Search("COUNTRY", "BR")
+	brokerageInfos, err := Broker.Search("COUNTRY", "TestUserUID", "BR")
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -119,12 +118,11 @@ func TestBrokerageSearchAll(t *testing.T) {
 	}
 
 	query := regexp.QuoteMeta(`
-	SELECT
-		id, name, country
-	FROM brokerages
+	SELECT id, name, country, user_uid FROM brokerages
+	WHERE (user_uid IS NULL OR user_uid = $1)
 	`)
 
-	columns := []string{"id", "name", "country"}
+	columns := []string{"id", "name", "country", "user_uid"}
 
 	mock, err := pgxmock.NewConn()
 	if err != nil {
@@ -133,13 +131,13 @@ func TestBrokerageSearchAll(t *testing.T) {
 	defer mock.Close(context.Background())
 
 	rows := mock.NewRows(columns)
-	mock.ExpectQuery(query).WillReturnRows(rows.AddRow(
-		"55555555-ed8b-11eb-9a03-0242ac130003", "Clear", "BR").AddRow(
-		"55556666-ed8b-11eb-9a03-0242ac130003", "Rico", "BR").AddRow(
-		"15151515-ed8b-11eb-9a03-0242ac130003", "Avenue", "US"))
+	mock.ExpectQuery(query).WithArgs("TestUserUID").WillReturnRows(rows.AddRow(
+		"55555555-ed8b-11eb-9a03-0242ac130003", "Clear", "BR", nil).AddRow(
+		"55556666-ed8b-11eb-9a03-0242ac130003", "Rico", "BR", nil).AddRow(
+		"15151515-ed8b-11eb-9a03-0242ac130003", "Avenue", "US", nil))
 
 	Broker := BrokeragePostgres{dbpool: mock}
-	brokerageInfos, err := Broker.Search("ALL")
+	brokerageInfos, err := Broker.Search("ALL", "TestUserUID")
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -148,3 +146,280 @@ func TestBrokerageSearchAll(t *testing.T) {
 	assert.NotNil(t, brokerageInfos)
 	assert.Equal(t, expectedBrokerageInfo, brokerageInfos)
 }
+
+func TestBrokerageCreate(t *testing.T) {
+	userUid := "TestUserUID"
+
+	brokerageInsert := entity.Brokerage{
+		Name:     "My Broker",
+		Fullname: "My Broker",
+		Country:  "US",
+		UserUid:  &userUid,
+	}
+
+	expectedBrokerageInfo := []entity.Brokerage{
+		{
+			Id:       "77777777-ed8b-11eb-9a03-0242ac130003",
+			Name:     "My Broker",
+			Fullname: "My Broker",
+			Country:  "US",
+			UserUid:  &userUid,
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	INSERT INTO
+		brokerages("name", fullname, country, user_uid)
+	SELECT $1, $2, $3, $4
+	WHERE NOT EXISTS (
+		SELECT 1 FROM brokerages
+		WHERE lower("name") = lower($1)
+		AND (user_uid IS NULL OR user_uid = $4)
+	)
+	RETURNING id, "name", fullname, country, user_uid;
+	`)
+
+	columns := []string{"id", "name", "fullname", "country", "user_uid"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	// The custom brokerages are not audited
+	rows := mock.NewRows(columns)
+	mock.ExpectBegin()
+	mock.ExpectQuery(query).WithArgs("My Broker", "My Broker", "US", &userUid).
+		WillReturnRows(rows.AddRow("77777777-ed8b-11eb-9a03-0242ac130003",
+			"My Broker", "My Broker", "US", &userUid))
+	mock.ExpectCommit()
+
+	Broker := BrokeragePostgres{dbpool: mock}
+	brokerageInfos, err := Broker.Create(brokerageInsert, nil)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedBrokerageInfo, brokerageInfos)
+}
+
+func TestBrokerageDelete(t *testing.T) {
+	expectedBrokerageInfo := []entity.Brokerage{
+		{
+			Id:       "77777777-ed8b-11eb-9a03-0242ac130003",
+			Name:     "Avenue",
+			Fullname: "Avenue Securities",
+			Country:  "US",
+		},
+	}
+
+	auditLog := entity.AuditLog{
+		UserUid:    "TestAdminUID",
+		Action:     entity.AuditActionDelete,
+		EntityType: entity.AuditEntityBrokerage,
+		EntityId:   "77777777-ed8b-11eb-9a03-0242ac130003",
+		Before:     json.RawMessage(`{"Name":"Avenue"}`),
+	}
+
+	query := regexp.QuoteMeta(`
+	DELETE FROM brokerages as b
+	WHERE b.id = $1 AND NOT EXISTS (
+		SELECT 1 FROM brokerage_accounts as ba WHERE ba.brokerage_id = b.id
+	)
+	RETURNING b.id, b."name", b.fullname, b.country, b.user_uid;
+	`)
+
+	columns := []string{"id", "name", "fullname", "country", "user_uid"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectBegin()
+	mock.ExpectQuery(query).WithArgs("77777777-ed8b-11eb-9a03-0242ac130003").
+		WillReturnRows(rows.AddRow("77777777-ed8b-11eb-9a03-0242ac130003",
+			"Avenue", "Avenue Securities", "US", nil))
+	expectAuditLog(mock, auditLog, nil)
+	mock.ExpectCommit()
+
+	Broker := BrokeragePostgres{dbpool: mock}
+	brokerageInfos, err := Broker.Delete(
+		"77777777-ed8b-11eb-9a03-0242ac130003", &auditLog)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedBrokerageInfo, brokerageInfos)
+}
+
+func TestBrokerageCreateAccount(t *testing.T) {
+	brokerageInfo := entity.Brokerage{
+		Id:      "55555555-ed8b-11eb-9a03-0242ac130003",
+		Name:    "Clear",
+		Country: "BR",
+	}
+
+	accountInsert := entity.BrokerageAccount{
+		Nickname:  "Clear - Retirement",
+		Brokerage: &entity.Brokerage{Id: brokerageInfo.Id},
+		UserUid:   "TestUserUID",
+	}
+
+	expectedAccountInfo := []entity.BrokerageAccount{
+		{
+			Id:        "66666666-ed8b-11eb-9a03-0242ac130003",
+			Nickname:  "Clear - Retirement",
+			Brokerage: &brokerageInfo,
+			UserUid:   "TestUserUID",
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	WITH inserted as (
+		INSERT INTO
+			brokerage_accounts(nickname, brokerage_id, user_uid)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_uid, nickname) DO NOTHING
+		RETURNING id, nickname, brokerage_id, user_uid
+	)
+	SELECT
+		inserted.id, inserted.nickname, inserted.user_uid,
+		json_build_object(
+			'id', b.id,
+			'name', b."name",
+			'country', b.country
+		) as brokerage
+	FROM inserted
+	INNER JOIN brokerages as b
+	ON b.id = inserted.brokerage_id;
+	`)
+
+	columns := []string{"id", "nickname", "user_uid", "brokerage"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(query).WithArgs("Clear - Retirement", brokerageInfo.Id,
+		"TestUserUID").WillReturnRows(rows.AddRow(
+		"66666666-ed8b-11eb-9a03-0242ac130003", "Clear - Retirement",
+		"TestUserUID", &brokerageInfo))
+
+	Broker := BrokeragePostgres{dbpool: mock}
+	accountInfos, err := Broker.CreateAccount(accountInsert)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedAccountInfo, accountInfos)
+}
+
+func TestBrokerageDeleteAccount(t *testing.T) {
+	query := regexp.QuoteMeta(`
+	DELETE FROM brokerage_accounts as ba
+	WHERE ba.id = $1 and ba.user_uid = $2 AND NOT EXISTS (
+		SELECT 1 FROM orders as o WHERE o.account_id = ba.id
+	)
+	RETURNING ba.id, ba.nickname, ba.user_uid;
+	`)
+
+	columns := []string{"id", "nickname", "user_uid"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(query).WithArgs("66666666-ed8b-11eb-9a03-0242ac130003",
+		"TestUserUID").WillReturnRows(rows)
+
+	Broker := BrokeragePostgres{dbpool: mock}
+	accountInfos, err := Broker.DeleteAccount(
+		"66666666-ed8b-11eb-9a03-0242ac130003", "TestUserUID")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Nil(t, accountInfos)
+}
+
+func TestBrokerageSearchAccountPositions(t *testing.T) {
+	expectedPositions := []entity.AccountPosition{
+		{
+			AccountId:     "66666666-ed8b-11eb-9a03-0242ac130003",
+			Nickname:      "Clear",
+			BrokerageId:   "55555555-ed8b-11eb-9a03-0242ac130003",
+			BrokerageName: "Clear",
+			AssetId:       "1111BBBB-ed8b-11eb-9a03-0242ac130003",
+			Symbol:        "ITUB4",
+			Currency:      "BRL",
+			Quantity:      20,
+			Invested:      450.5,
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	SELECT
+		ba.id as account_id, ba.nickname, b.id as brokerage_id,
+		b."name" as brokerage_name, a.id as asset_id, a.symbol, o.currency,
+		SUM(o.quantity) as quantity,
+		SUM(o.quantity) * COALESCE(
+			SUM(o.quantity * o.price) FILTER(WHERE o.order_type = 'buy')
+			/ NULLIF(SUM(o.quantity) FILTER(WHERE o.order_type = 'buy'), 0),
+			0) as invested
+	FROM orders as o
+	INNER JOIN brokerage_accounts as ba
+	ON ba.id = o.account_id
+	INNER JOIN brokerages as b
+	ON b.id = ba.brokerage_id
+	INNER JOIN assets as a
+	ON a.id = o.asset_id
+	WHERE o.user_uid = $1
+	GROUP BY ba.id, ba.nickname, b.id, b."name", a.id, a.symbol, o.currency
+	HAVING SUM(o.quantity) <> 0
+	ORDER BY ba.nickname, a.symbol;
+	`)
+
+	columns := []string{"account_id", "nickname", "brokerage_id",
+		"brokerage_name", "asset_id", "symbol", "currency", "quantity",
+		"invested"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(query).WithArgs("TestUserUID").WillReturnRows(rows.AddRow(
+		"66666666-ed8b-11eb-9a03-0242ac130003", "Clear",
+		"55555555-ed8b-11eb-9a03-0242ac130003", "Clear",
+		"1111BBBB-ed8b-11eb-9a03-0242ac130003", "ITUB4", "BRL", 20.0, 450.5))
+
+	Broker := BrokeragePostgres{dbpool: mock}
+	positions, err := Broker.SearchAccountPositions("TestUserUID")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedPositions, positions)
+}
@@ -0,0 +1,172 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"stockfyApi/entity"
+	"time"
+
+	"github.com/georgysavva/scany/pgxscan"
+)
+
+type CashPostgres struct {
+	dbpool PgxIface
+}
+
+func NewCashPostgres(db PgxIface) *CashPostgres {
+	return &CashPostgres{
+		dbpool: db,
+	}
+}
+
+func (r *CashPostgres) CreateMovement(movement entity.CashMovement) (
+	[]entity.CashMovement, error) {
+
+	var movementReturn []entity.CashMovement
+
+	var toAccountId interface{}
+	if movement.ToAccount != nil {
+		toAccountId = movement.ToAccount.Id
+	}
+
+	query := `
+	INSERT INTO
+		cash_movements(user_uid, account_id, to_account_id, "type", amount,
+			currency, "date", description)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING id, "type", amount, currency, "date", description, user_uid;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &movementReturn,
+		query, movement.UserUid, movement.Account.Id, toAccountId,
+		movement.Type, movement.Amount, movement.Currency, movement.Date,
+		movement.Description)
+	if err != nil {
+		fmt.Println("entity.CreateCashMovement: ", err)
+	}
+
+	return movementReturn, err
+}
+
+func (r *CashPostgres) DeleteMovement(movementId string, userUid string) (
+	[]entity.CashMovement, error) {
+
+	var movementReturn []entity.CashMovement
+
+	query := `
+	DELETE FROM cash_movements
+	WHERE id = $1 AND user_uid = $2
+	RETURNING id, "type", amount, currency, "date", description, user_uid;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &movementReturn,
+		query, movementId, userUid)
+	if err != nil {
+		fmt.Println("entity.DeleteCashMovement: ", err)
+	}
+
+	return movementReturn, err
+}
+
+// SearchEntries returns the entries of the cash ledger of the user until the
+// date, inclusive. Besides the cash movements, where a transfer posts an entry
+// in each account, the buys and sells post their amount with the fees and the
+// taxes withheld at the settlement date, the remittances post the amount sent
+// in the account it was sent from, when informed, and the amount received in
+// the other one, and the earnings post their net amount in the accounts
+// holding the asset before the ex-date of their earning event, or at the
+// earning date when not linked to an event, split by the quantity held in
+// each one.
+func (r *CashPostgres) SearchEntries(date time.Time, userUid string) (
+	[]entity.CashEntry, error) {
+
+	var entries []entity.CashEntry
+
+	query := `
+	WITH movement_entries as (
+		SELECT
+			'MOVEMENT' as source, m.id as source_id, m."type", m.amount,
+			m.currency, m."date", m.description, m.account_id
+		FROM cash_movements as m
+		WHERE m.user_uid = $1
+		UNION ALL
+		SELECT
+			'MOVEMENT', m.id, m."type", -m.amount, m.currency, m."date",
+			m.description, m.to_account_id
+		FROM cash_movements as m
+		WHERE m.user_uid = $1 AND m.to_account_id IS NOT NULL
+	), order_entries as (
+		SELECT
+			'ORDER' as source, o.id as source_id, o.order_type as "type",
+			-(o.quantity * o.price) - o.fees - o.withheld_tax as amount,
+			o.currency, COALESCE(o.settlement_date, o."date") as "date",
+			a.symbol as description, o.account_id
+		FROM orders as o
+		INNER JOIN assets as a
+		ON a.id = o.asset_id
+		WHERE o.user_uid = $1 AND o.order_type IN ('buy', 'sell')
+	), remittance_entries as (
+		SELECT
+			'REMITTANCE' as source, r.id as source_id, 'remittance' as "type",
+			-r.sent_amount as amount, r.sent_currency as currency, r."date",
+			r.sent_currency || ' > ' || r.received_currency as description,
+			r.from_account_id as account_id
+		FROM remittances as r
+		WHERE r.user_uid = $1 AND r.from_account_id IS NOT NULL
+		UNION ALL
+		SELECT
+			'REMITTANCE', r.id, 'remittance', r.received_amount,
+			r.received_currency, r."date",
+			r.sent_currency || ' > ' || r.received_currency, r.to_account_id
+		FROM remittances as r
+		WHERE r.user_uid = $1
+	), earning_holdings as (
+		SELECT e.id, o.account_id, SUM(o.quantity) as quantity
+		FROM earnings as e
+		LEFT JOIN earning_events as ev
+		ON ev.id = e.earning_event_id
+		INNER JOIN orders as o
+		ON o.asset_id = e.asset_id AND o.user_uid = e.user_uid
+			AND CASE WHEN ev.id IS NULL THEN o."date" <= e."date"
+				ELSE o."date" < ev.ex_date END
+		WHERE e.user_uid = $1
+		GROUP BY e.id, o.account_id
+		HAVING SUM(o.quantity) > 0
+	), earning_entries as (
+		SELECT
+			'EARNING' as source, e.id as source_id, e."type",
+			(e.earning - e.withheld_tax) * h.quantity
+				/ SUM(h.quantity) OVER (PARTITION BY e.id) as amount,
+			e.currency, e."date", a.symbol as description, h.account_id
+		FROM earning_holdings as h
+		INNER JOIN earnings as e
+		ON e.id = h.id
+		INNER JOIN assets as a
+		ON a.id = e.asset_id
+	)
+	SELECT
+		c.source, c.source_id, c."type", c.amount, c.currency, c."date",
+		c.description, c.account_id, ba.nickname
+	FROM (
+		SELECT * FROM movement_entries
+		UNION ALL
+		SELECT * FROM order_entries
+		UNION ALL
+		SELECT * FROM remittance_entries
+		UNION ALL
+		SELECT * FROM earning_entries
+	) as c
+	INNER JOIN brokerage_accounts as ba
+	ON ba.id = c.account_id
+	WHERE c."date" <= $2
+	ORDER BY c."date", c.source, c.source_id;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &entries, query,
+		userUid, date)
+	if err != nil {
+		fmt.Println("entity.SearchCashEntries: ", err)
+	}
+
+	return entries, err
+}
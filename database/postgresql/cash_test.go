@@ -0,0 +1,179 @@
+package postgresql
+
+import (
+	"context"
+	"regexp"
+	"stockfyApi/entity"
+	"testing"
+
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCashCreateMovement(t *testing.T) {
+	date := entity.StringToTime("2021-10-01")
+
+	movement := entity.CashMovement{
+		Type:      entity.CashTransfer,
+		Amount:    -500,
+		Currency:  "BRL",
+		Date:      date,
+		Account:   &entity.BrokerageAccount{Id: "TestAccountID1"},
+		ToAccount: &entity.BrokerageAccount{Id: "TestAccountID2"},
+		UserUid:   "TestUserUID",
+	}
+
+	expectedMovement := []entity.CashMovement{
+		{
+			Id:       "TestMovementID",
+			Type:     entity.CashTransfer,
+			Amount:   -500,
+			Currency: "BRL",
+			Date:     date,
+			UserUid:  "TestUserUID",
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	INSERT INTO
+		cash_movements(user_uid, account_id, to_account_id, "type", amount,
+			currency, "date", description)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING id, "type", amount, currency, "date", description, user_uid;
+	`)
+
+	columns := []string{"id", "type", "amount", "currency", "date",
+		"description", "user_uid"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(query).WithArgs("TestUserUID", "TestAccountID1",
+		"TestAccountID2", entity.CashTransfer, -500.0, "BRL", date, "").
+		WillReturnRows(rows.AddRow("TestMovementID", entity.CashTransfer,
+			-500.0, "BRL", date, "", "TestUserUID"))
+
+	Cash := CashPostgres{dbpool: mock}
+	movementCreated, err := Cash.CreateMovement(movement)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedMovement, movementCreated)
+}
+
+func TestCashDeleteMovement(t *testing.T) {
+	date := entity.StringToTime("2021-10-01")
+
+	expectedMovement := []entity.CashMovement{
+		{
+			Id:          "TestMovementID",
+			Type:        entity.CashDeposit,
+			Amount:      1000,
+			Currency:    "BRL",
+			Date:        date,
+			Description: "Monthly deposit",
+			UserUid:     "TestUserUID",
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	DELETE FROM cash_movements
+	WHERE id = $1 AND user_uid = $2
+	RETURNING id, "type", amount, currency, "date", description, user_uid;
+	`)
+
+	columns := []string{"id", "type", "amount", "currency", "date",
+		"description", "user_uid"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(query).WithArgs("TestMovementID", "TestUserUID").
+		WillReturnRows(rows.AddRow("TestMovementID", entity.CashDeposit,
+			1000.0, "BRL", date, "Monthly deposit", "TestUserUID"))
+
+	Cash := CashPostgres{dbpool: mock}
+	movementDeleted, err := Cash.DeleteMovement("TestMovementID",
+		"TestUserUID")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedMovement, movementDeleted)
+}
+
+func TestCashSearchEntries(t *testing.T) {
+	date := entity.StringToTime("2021-12-31")
+
+	expectedEntries := []entity.CashEntry{
+		{
+			Source:      entity.CashSourceMovement,
+			SourceId:    "TestMovementID",
+			Type:        entity.CashDeposit,
+			Amount:      1000,
+			Currency:    "BRL",
+			Date:        entity.StringToTime("2021-10-01"),
+			Description: "Monthly deposit",
+			AccountId:   "TestAccountID",
+			Nickname:    "Clear",
+		},
+		{
+			Source:      entity.CashSourceOrder,
+			SourceId:    "TestOrderID",
+			Type:        "buy",
+			Amount:      -585.8,
+			Currency:    "BRL",
+			Date:        entity.StringToTime("2021-10-05"),
+			Description: "ITUB4",
+			AccountId:   "TestAccountID",
+			Nickname:    "Clear",
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	WITH movement_entries as (
+		SELECT
+			'MOVEMENT' as source, m.id as source_id, m."type", m.amount,
+			m.currency, m."date", m.description, m.account_id
+		FROM cash_movements as m`)
+
+	columns := []string{"source", "source_id", "type", "amount", "currency",
+		"date", "description", "account_id", "nickname"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	for _, entry := range expectedEntries {
+		rows.AddRow(entry.Source, entry.SourceId, entry.Type, entry.Amount,
+			entry.Currency, entry.Date, entry.Description, entry.AccountId,
+			entry.Nickname)
+	}
+	mock.ExpectQuery(query).WithArgs("TestUserUID", date).WillReturnRows(rows)
+
+	Cash := CashPostgres{dbpool: mock}
+	entries, err := Cash.SearchEntries(date, "TestUserUID")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedEntries, entries)
+}
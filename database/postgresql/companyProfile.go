@@ -0,0 +1,129 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"stockfyApi/entity"
+	"time"
+
+	"github.com/georgysavva/scany/pgxscan"
+)
+
+type CompanyProfilePostgres struct {
+	dbpool PgxIface
+}
+
+func NewCompanyProfilePostgres(db PgxIface) *CompanyProfilePostgres {
+	return &CompanyProfilePostgres{
+		dbpool: db,
+	}
+}
+
+func (r *CompanyProfilePostgres) Upsert(profile entity.CompanyProfile) (
+	[]entity.CompanyProfile, error) {
+
+	var profileRow []entity.CompanyProfile
+
+	insertRow := `
+	WITH inserted as (
+	INSERT INTO
+		company_profiles(asset_id, logo, website, exchange, market_cap,
+			pe_ratio, dividend_yield, ipo_date)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	ON CONFLICT (asset_id) DO UPDATE SET
+		logo = EXCLUDED.logo,
+		website = EXCLUDED.website,
+		exchange = EXCLUDED.exchange,
+		market_cap = EXCLUDED.market_cap,
+		pe_ratio = EXCLUDED.pe_ratio,
+		dividend_yield = EXCLUDED.dividend_yield,
+		ipo_date = EXCLUDED.ipo_date,
+		updated_at = now()
+	RETURNING asset_id, logo, website, exchange, market_cap, pe_ratio,
+		dividend_yield, ipo_date, updated_at
+	)
+	SELECT
+		inserted.logo, inserted.website, inserted.exchange, inserted.market_cap,
+		inserted.pe_ratio, inserted.dividend_yield, inserted.ipo_date,
+		inserted.updated_at,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol,
+			'fullname', ast.fullname
+		) asset
+	FROM inserted
+	INNER JOIN assets as ast
+	ON ast.id = inserted.asset_id;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &profileRow,
+		insertRow, profile.Asset.Id, profile.Logo, profile.Website,
+		profile.Exchange, profile.MarketCap, profile.PeRatio,
+		profile.DividendYield, profile.IpoDate)
+	if err != nil {
+		fmt.Println("entity.UpsertCompanyProfile: ", err)
+	}
+
+	return profileRow, err
+}
+
+func (r *CompanyProfilePostgres) SearchByAsset(assetId string) (
+	[]entity.CompanyProfile, error) {
+
+	var profileReturn []entity.CompanyProfile
+
+	query := `
+	SELECT
+		logo, website, exchange, market_cap, pe_ratio, dividend_yield,
+		ipo_date, cp.updated_at,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol,
+			'fullname', ast.fullname
+		) as asset
+	FROM company_profiles as cp
+	INNER JOIN assets as ast
+	ON ast.id = cp.asset_id
+	WHERE asset_id = $1;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &profileReturn,
+		query, assetId)
+	if err != nil {
+		fmt.Println("entity.SearchCompanyProfileByAsset: ", err)
+	}
+
+	return profileReturn, err
+}
+
+func (r *CompanyProfilePostgres) SearchOutdated(updatedBefore time.Time) (
+	[]entity.Asset, error) {
+
+	var assetsReturn []entity.Asset
+
+	query := `
+	SELECT
+		a.id, symbol, fullname,
+		json_build_object(
+			'id', aty.id,
+			'type', aty."type",
+			'name', aty."name",
+			'country', aty.country
+		) as asset_type
+	FROM assets as a
+	INNER JOIN asset_types as aty
+	ON aty.id = a.asset_type_id
+	LEFT JOIN company_profiles as cp
+	ON cp.asset_id = a.id
+	WHERE aty."type" = ANY($1) and
+		(cp.asset_id IS NULL or cp.updated_at < $2);
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &assetsReturn,
+		query, entity.ListCompanyProfileAssetType[:], updatedBefore)
+	if err != nil {
+		fmt.Println("entity.SearchOutdatedCompanyProfiles: ", err)
+	}
+
+	return assetsReturn, err
+}
@@ -0,0 +1,167 @@
+package postgresql
+
+import (
+	"context"
+	"regexp"
+	"stockfyApi/entity"
+	"testing"
+
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompanyProfileUpsert(t *testing.T) {
+	ipoDate := entity.StringToTime("1980-12-12")
+	updatedAt := entity.StringToTime("2021-10-01")
+
+	asset := entity.Asset{
+		Id:       "a69a3",
+		Symbol:   "AAPL",
+		Fullname: "Apple Inc",
+	}
+
+	profile := entity.CompanyProfile{
+		Logo:          "https://finnhub.io/api/logo?symbol=AAPL",
+		Website:       "https://www.apple.com/",
+		Exchange:      "NASDAQ NMS - GLOBAL MARKET",
+		MarketCap:     2462175e6,
+		PeRatio:       28.9,
+		DividendYield: 0.0058,
+		IpoDate:       &ipoDate,
+		Asset:         &entity.Asset{Id: "a69a3"},
+	}
+
+	expectedProfileRow := []entity.CompanyProfile{
+		{
+			Logo:          "https://finnhub.io/api/logo?symbol=AAPL",
+			Website:       "https://www.apple.com/",
+			Exchange:      "NASDAQ NMS - GLOBAL MARKET",
+			MarketCap:     2462175e6,
+			PeRatio:       28.9,
+			DividendYield: 0.0058,
+			IpoDate:       &ipoDate,
+			Asset:         &asset,
+			UpdatedAt:     updatedAt,
+		},
+	}
+
+	insertRow := regexp.QuoteMeta(`
+	WITH inserted as (
+	INSERT INTO
+		company_profiles(asset_id, logo, website, exchange, market_cap,
+			pe_ratio, dividend_yield, ipo_date)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	ON CONFLICT (asset_id) DO UPDATE SET
+		logo = EXCLUDED.logo,
+		website = EXCLUDED.website,
+		exchange = EXCLUDED.exchange,
+		market_cap = EXCLUDED.market_cap,
+		pe_ratio = EXCLUDED.pe_ratio,
+		dividend_yield = EXCLUDED.dividend_yield,
+		ipo_date = EXCLUDED.ipo_date,
+		updated_at = now()
+	RETURNING asset_id, logo, website, exchange, market_cap, pe_ratio,
+		dividend_yield, ipo_date, updated_at
+	)
+	SELECT
+		inserted.logo, inserted.website, inserted.exchange, inserted.market_cap,
+		inserted.pe_ratio, inserted.dividend_yield, inserted.ipo_date,
+		inserted.updated_at,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol,
+			'fullname', ast.fullname
+		) asset
+	FROM inserted
+	INNER JOIN assets as ast
+	ON ast.id = inserted.asset_id;
+	`)
+
+	columns := []string{"logo", "website", "exchange", "market_cap",
+		"pe_ratio", "dividend_yield", "ipo_date", "updated_at", "asset"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(insertRow).WithArgs("a69a3",
+		"https://finnhub.io/api/logo?symbol=AAPL", "https://www.apple.com/",
+		"NASDAQ NMS - GLOBAL MARKET", 2462175e6, 28.9, 0.0058, &ipoDate).
+		WillReturnRows(rows.AddRow("https://finnhub.io/api/logo?symbol=AAPL",
+			"https://www.apple.com/", "NASDAQ NMS - GLOBAL MARKET", 2462175e6,
+			28.9, 0.0058, &ipoDate, updatedAt, &asset))
+
+	profiles := CompanyProfilePostgres{dbpool: mock}
+	profileRow, err := profiles.Upsert(profile)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedProfileRow, profileRow)
+}
+
+func TestCompanyProfileSearchOutdated(t *testing.T) {
+	updatedBefore := entity.StringToTime("2021-10-01")
+
+	assetType := entity.AssetType{
+		Id:      "28ccf27a",
+		Type:    "STOCK",
+		Name:    "Ações EUA",
+		Country: "US",
+	}
+
+	expectedAssets := []entity.Asset{
+		{
+			Id:        "a69a3",
+			Symbol:    "AAPL",
+			Fullname:  "Apple Inc",
+			AssetType: &assetType,
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	SELECT
+		a.id, symbol, fullname,
+		json_build_object(
+			'id', aty.id,
+			'type', aty."type",
+			'name', aty."name",
+			'country', aty.country
+		) as asset_type
+	FROM assets as a
+	INNER JOIN asset_types as aty
+	ON aty.id = a.asset_type_id
+	LEFT JOIN company_profiles as cp
+	ON cp.asset_id = a.id
+	WHERE aty."type" = ANY($1) and
+		(cp.asset_id IS NULL or cp.updated_at < $2);
+	`)
+
+	columns := []string{"id", "symbol", "fullname", "asset_type"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(query).WithArgs([]string{"STOCK", "ETF", "REIT", "FII"},
+		updatedBefore).WillReturnRows(rows.AddRow("a69a3", "AAPL", "Apple Inc",
+		&assetType))
+
+	profiles := CompanyProfilePostgres{dbpool: mock}
+	assets, err := profiles.SearchOutdated(updatedBefore)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedAssets, assets)
+}
@@ -27,14 +27,26 @@ type PgxIface interface {
 
 func NewPostgresInstance(dbpool PgxIface) usecases.Repositories {
 	return usecases.Repositories{
-		AssetRepository:          NewAssetPostgres(dbpool),
-		SectorRepository:         NewSectorPostgres(dbpool),
-		AssetTypeRepository:      NewAssetTypePostgres(dbpool),
-		UserRepository:           NewUserPostgres(dbpool),
-		OrderRepository:          NewOrderPostgres(dbpool),
-		AssetUserRepository:      NewAssetUserPostgres(dbpool),
-		BrokerageRepository:      NewBrokeragePostgres(dbpool),
-		EarningsRepository:       NewEarningPostgres(dbpool),
-		DbVerificationRepository: NewDbVerificationPostgres(dbpool),
+		AssetRepository:            NewAssetPostgres(dbpool),
+		SectorRepository:           NewSectorPostgres(dbpool),
+		AssetTypeRepository:        NewAssetTypePostgres(dbpool),
+		UserRepository:             NewUserPostgres(dbpool),
+		OrderRepository:            NewOrderPostgres(dbpool),
+		AssetUserRepository:        NewAssetUserPostgres(dbpool),
+		BrokerageRepository:        NewBrokeragePostgres(dbpool),
+		EarningsRepository:         NewEarningPostgres(dbpool),
+		DbVerificationRepository:   NewDbVerificationPostgres(dbpool),
+		FixedIncomeRepository:      NewFixedIncomePostgres(dbpool),
+		MarketRepository:           NewMarketPostgres(dbpool),
+		OptionRepository:           NewOptionPostgres(dbpool),
+		CompanyProfileRepository:   NewCompanyProfilePostgres(dbpool),
+		EarningEventRepository:     NewEarningEventPostgres(dbpool),
+		IncomeProjectionRepository: NewIncomeProjectionPostgres(dbpool),
+		SymbolSearchRepository:     NewSymbolSearchPostgres(dbpool),
+		AuditLogRepository:         NewAuditLogPostgres(dbpool),
+		BackupRepository:           NewBackupPostgres(dbpool),
+		ReconciliationRepository:   NewReconciliationPostgres(dbpool),
+		CashRepository:             NewCashPostgres(dbpool),
+		RemittanceRepository:       NewRemittancePostgres(dbpool),
 	}
 }
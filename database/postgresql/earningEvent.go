@@ -0,0 +1,226 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"stockfyApi/entity"
+	"time"
+
+	"github.com/georgysavva/scany/pgxscan"
+)
+
+type EarningEventPostgres struct {
+	dbpool PgxIface
+}
+
+func NewEarningEventPostgres(db PgxIface) *EarningEventPostgres {
+	return &EarningEventPostgres{
+		dbpool: db,
+	}
+}
+
+// CreateEvents stores the earning events. An event already imported for the
+// asset with the same type and ex-date is updated with the new values.
+func (r *EarningEventPostgres) CreateEvents(events []entity.EarningEvent) (
+	[]entity.EarningEvent, error) {
+
+	var eventsRow []entity.EarningEvent
+
+	assetIds := make([]string, len(events))
+	types := make([]string, len(events))
+	amounts := make([]float64, len(events))
+	currencies := make([]string, len(events))
+	exDates := make([]time.Time, len(events))
+	paymentDates := make([]time.Time, len(events))
+	for i, event := range events {
+		assetIds[i] = event.Asset.Id
+		types[i] = event.Type
+		amounts[i] = event.Amount
+		currencies[i] = event.Currency
+		exDates[i] = event.ExDate
+		paymentDates[i] = event.PaymentDate
+	}
+
+	insertRow := `
+	WITH inserted as (
+	INSERT INTO
+		earning_events(asset_id, "type", amount, currency, ex_date,
+			payment_date)
+	SELECT
+		asset_id, "type", amount, currency, ex_date,
+		NULLIF(payment_date, '0001-01-01'::date)
+	FROM unnest($1::uuid[], $2::text[], $3::float8[], $4::text[],
+		$5::date[], $6::date[])
+		as t(asset_id, "type", amount, currency, ex_date, payment_date)
+	ON CONFLICT (asset_id, "type", ex_date) DO UPDATE SET
+		amount = EXCLUDED.amount,
+		currency = EXCLUDED.currency,
+		payment_date = EXCLUDED.payment_date
+	RETURNING id, "type", amount, currency, ex_date, payment_date, asset_id
+	)
+	SELECT
+		inserted.id, inserted.type, inserted.amount, inserted.currency,
+		inserted.ex_date,
+		COALESCE(inserted.payment_date, inserted.ex_date) as payment_date,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol
+		) asset
+	FROM inserted
+	INNER JOIN assets as ast
+	ON ast.id = inserted.asset_id
+	ORDER BY inserted.ex_date;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &eventsRow,
+		insertRow, assetIds, types, amounts, currencies, exDates, paymentDates)
+	if err != nil {
+		fmt.Println("entity.CreateEarningEvents: ", err)
+	}
+
+	return eventsRow, err
+}
+
+// SearchUnclaimed returns the events of the asset without an earning
+// registered for the user. The events dismissed by the user, whose earning was
+// deleted, are not returned.
+func (r *EarningEventPostgres) SearchUnclaimed(assetId string,
+	userUid string) ([]entity.EarningEvent, error) {
+
+	var eventsReturn []entity.EarningEvent
+
+	query := `
+	SELECT
+		ev.id, ev."type", amount, ev.currency, ex_date,
+		COALESCE(payment_date, ex_date) as payment_date,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol
+		) as asset
+	FROM earning_events as ev
+	INNER JOIN assets as ast
+	ON ast.id = ev.asset_id
+	LEFT JOIN earnings as eng
+	ON eng.earning_event_id = ev.id and eng.user_uid = $2
+	WHERE ev.asset_id = $1 and eng.id IS NULL and NOT EXISTS (
+		SELECT 1 FROM dismissed_earning_events as dis
+		WHERE dis.earning_event_id = ev.id and dis.user_uid = $2
+	)
+	ORDER BY ex_date;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &eventsReturn,
+		query, assetId, userUid)
+	if err != nil {
+		fmt.Println("entity.SearchUnclaimedEarningEvents: ", err)
+	}
+
+	return eventsReturn, err
+}
+
+func (r *EarningEventPostgres) SearchHolders(assetId string) ([]string,
+	error) {
+
+	var holders []string
+
+	query := `
+	SELECT
+		user_uid
+	FROM asset_users
+	WHERE asset_id = $1;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &holders, query,
+		assetId)
+	if err != nil {
+		fmt.Println("entity.SearchEarningEventHolders: ", err)
+	}
+
+	return holders, err
+}
+
+func (r *EarningEventPostgres) SearchHeldAssets() ([]entity.Asset, error) {
+	var assetsReturn []entity.Asset
+
+	query := `
+	SELECT
+		a.id, symbol, fullname,
+		json_build_object(
+			'id', aty.id,
+			'type', aty."type",
+			'name', aty."name",
+			'country', aty.country
+		) as asset_type
+	FROM assets as a
+	INNER JOIN asset_types as aty
+	ON aty.id = a.asset_type_id
+	WHERE EXISTS (
+		SELECT 1 FROM asset_users as au WHERE au.asset_id = a.id
+	);
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &assetsReturn,
+		query)
+	if err != nil {
+		fmt.Println("entity.SearchEarningEventHeldAssets: ", err)
+	}
+
+	return assetsReturn, err
+}
+
+// CreateEarnings registers the earnings generated by the events. An earning
+// already registered for the same event and user is not created again.
+func (r *EarningEventPostgres) CreateEarnings(earnings []entity.Earnings) (
+	[]entity.Earnings, error) {
+
+	var earningsRow []entity.Earnings
+
+	types := make([]string, len(earnings))
+	values := make([]float64, len(earnings))
+	dates := make([]time.Time, len(earnings))
+	currencies := make([]string, len(earnings))
+	assetIds := make([]string, len(earnings))
+	userUids := make([]string, len(earnings))
+	eventIds := make([]string, len(earnings))
+	for i, earning := range earnings {
+		types[i] = earning.Type
+		values[i] = earning.Earning
+		dates[i] = earning.Date
+		currencies[i] = earning.Currency
+		assetIds[i] = earning.Asset.Id
+		userUids[i] = earning.UserUid
+		eventIds[i] = *earning.EarningEventId
+	}
+
+	insertRow := `
+	WITH inserted as (
+	INSERT INTO
+		earnings("type", earning, "date", currency, asset_id, user_uid,
+			earning_event_id)
+	SELECT * FROM unnest($1::text[], $2::float8[], $3::date[], $4::text[],
+		$5::uuid[], $6::text[], $7::uuid[])
+	ON CONFLICT (earning_event_id, user_uid) DO NOTHING
+	RETURNING id, "type", earning, "date", currency, asset_id, user_uid,
+		earning_event_id
+	)
+	SELECT
+		inserted.id, inserted.type, inserted.earning, inserted.date,
+		inserted.currency, inserted.user_uid, inserted.earning_event_id,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol
+		) asset
+	FROM inserted
+	INNER JOIN assets as ast
+	ON ast.id = inserted.asset_id;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &earningsRow,
+		insertRow, types, values, dates, currencies, assetIds, userUids,
+		eventIds)
+	if err != nil {
+		fmt.Println("entity.CreateEarningsFromEvents: ", err)
+	}
+
+	return earningsRow, err
+}
@@ -0,0 +1,251 @@
+package postgresql
+
+import (
+	"context"
+	"regexp"
+	"stockfyApi/entity"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEarningEventCreateEvents(t *testing.T) {
+	exDate := entity.StringToTime("2021-10-01")
+	paymentDate := entity.StringToTime("2021-11-01")
+
+	asset := entity.Asset{
+		Id:     "a69a3",
+		Symbol: "ITUB4",
+	}
+
+	events := []entity.EarningEvent{
+		{
+			Type:        "JCP",
+			Amount:      0.01765,
+			Currency:    "BRL",
+			ExDate:      exDate,
+			PaymentDate: paymentDate,
+			Asset:       &entity.Asset{Id: "a69a3"},
+		},
+	}
+
+	expectedEvents := []entity.EarningEvent{
+		{
+			Id:          "ev-1234",
+			Type:        "JCP",
+			Amount:      0.01765,
+			Currency:    "BRL",
+			ExDate:      exDate,
+			PaymentDate: paymentDate,
+			Asset:       &asset,
+		},
+	}
+
+	insertRow := regexp.QuoteMeta(`
+	WITH inserted as (
+	INSERT INTO
+		earning_events(asset_id, "type", amount, currency, ex_date,
+			payment_date)
+	SELECT
+		asset_id, "type", amount, currency, ex_date,
+		NULLIF(payment_date, '0001-01-01'::date)
+	FROM unnest($1::uuid[], $2::text[], $3::float8[], $4::text[],
+		$5::date[], $6::date[])
+		as t(asset_id, "type", amount, currency, ex_date, payment_date)
+	ON CONFLICT (asset_id, "type", ex_date) DO UPDATE SET
+		amount = EXCLUDED.amount,
+		currency = EXCLUDED.currency,
+		payment_date = EXCLUDED.payment_date
+	RETURNING id, "type", amount, currency, ex_date, payment_date, asset_id
+	)
+	SELECT
+		inserted.id, inserted.type, inserted.amount, inserted.currency,
+		inserted.ex_date,
+		COALESCE(inserted.payment_date, inserted.ex_date) as payment_date,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol
+		) asset
+	FROM inserted
+	INNER JOIN assets as ast
+	ON ast.id = inserted.asset_id
+	ORDER BY inserted.ex_date;
+	`)
+
+	columns := []string{"id", "type", "amount", "currency", "ex_date",
+		"payment_date", "asset"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(insertRow).WithArgs([]string{"a69a3"}, []string{"JCP"},
+		[]float64{0.01765}, []string{"BRL"}, []time.Time{exDate},
+		[]time.Time{paymentDate}).WillReturnRows(rows.AddRow("ev-1234", "JCP",
+		0.01765, "BRL", exDate, paymentDate, &asset))
+
+	earningEvents := EarningEventPostgres{dbpool: mock}
+	eventsCreated, err := earningEvents.CreateEvents(events)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedEvents, eventsCreated)
+}
+
+func TestEarningEventSearchUnclaimed(t *testing.T) {
+	exDate := entity.StringToTime("2021-10-01")
+	paymentDate := entity.StringToTime("2021-11-01")
+
+	asset := entity.Asset{
+		Id:     "a69a3",
+		Symbol: "ITUB4",
+	}
+
+	expectedEvents := []entity.EarningEvent{
+		{
+			Id:          "ev-1234",
+			Type:        "JCP",
+			Amount:      0.01765,
+			Currency:    "BRL",
+			ExDate:      exDate,
+			PaymentDate: paymentDate,
+			Asset:       &asset,
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	SELECT
+		ev.id, ev."type", amount, ev.currency, ex_date,
+		COALESCE(payment_date, ex_date) as payment_date,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol
+		) as asset
+	FROM earning_events as ev
+	INNER JOIN assets as ast
+	ON ast.id = ev.asset_id
+	LEFT JOIN earnings as eng
+	ON eng.earning_event_id = ev.id and eng.user_uid = $2
+	WHERE ev.asset_id = $1 and eng.id IS NULL and NOT EXISTS (
+		SELECT 1 FROM dismissed_earning_events as dis
+		WHERE dis.earning_event_id = ev.id and dis.user_uid = $2
+	)
+	ORDER BY ex_date;
+	`)
+
+	columns := []string{"id", "type", "amount", "currency", "ex_date",
+		"payment_date", "asset"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(query).WithArgs("a69a3", "TestUserUID").WillReturnRows(
+		rows.AddRow("ev-1234", "JCP", 0.01765, "BRL", exDate, paymentDate,
+			&asset))
+
+	earningEvents := EarningEventPostgres{dbpool: mock}
+	events, err := earningEvents.SearchUnclaimed("a69a3", "TestUserUID")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedEvents, events)
+}
+
+func TestEarningEventCreateEarnings(t *testing.T) {
+	date := entity.StringToTime("2021-11-01")
+	eventId := "ev-1234"
+
+	asset := entity.Asset{
+		Id:     "a69a3",
+		Symbol: "ITUB4",
+	}
+
+	earnings := []entity.Earnings{
+		{
+			Type:           "JCP",
+			Earning:        1.765,
+			Currency:       "BRL",
+			Date:           date,
+			Asset:          &entity.Asset{Id: "a69a3"},
+			UserUid:        "TestUserUID",
+			EarningEventId: &eventId,
+		},
+	}
+
+	expectedEarnings := []entity.Earnings{
+		{
+			Id:             "eng-5678",
+			Type:           "JCP",
+			Earning:        1.765,
+			Currency:       "BRL",
+			Date:           date,
+			Asset:          &asset,
+			UserUid:        "TestUserUID",
+			EarningEventId: &eventId,
+		},
+	}
+
+	insertRow := regexp.QuoteMeta(`
+	WITH inserted as (
+	INSERT INTO
+		earnings("type", earning, "date", currency, asset_id, user_uid,
+			earning_event_id)
+	SELECT * FROM unnest($1::text[], $2::float8[], $3::date[], $4::text[],
+		$5::uuid[], $6::text[], $7::uuid[])
+	ON CONFLICT (earning_event_id, user_uid) DO NOTHING
+	RETURNING id, "type", earning, "date", currency, asset_id, user_uid,
+		earning_event_id
+	)
+	SELECT
+		inserted.id, inserted.type, inserted.earning, inserted.date,
+		inserted.currency, inserted.user_uid, inserted.earning_event_id,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol
+		) asset
+	FROM inserted
+	INNER JOIN assets as ast
+	ON ast.id = inserted.asset_id;
+	`)
+
+	columns := []string{"id", "type", "earning", "date", "currency",
+		"user_uid", "earning_event_id", "asset"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(insertRow).WithArgs([]string{"JCP"}, []float64{1.765},
+		[]time.Time{date}, []string{"BRL"}, []string{"a69a3"},
+		[]string{"TestUserUID"}, []string{"ev-1234"}).WillReturnRows(
+		rows.AddRow("eng-5678", "JCP", 1.765, date, "BRL", "TestUserUID",
+			&eventId, &asset))
+
+	earningEvents := EarningEventPostgres{dbpool: mock}
+	earningsCreated, err := earningEvents.CreateEarnings(earnings)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedEarnings, earningsCreated)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"stockfyApi/entity"
 	"strings"
+	"time"
 
 	"github.com/georgysavva/scany/pgxscan"
 )
@@ -53,6 +54,72 @@ func (r *EarningPostgres) Create(earningOrder entity.Earnings) ([]entity.Earning
 	return earningRow, err
 }
 
+// CreateBulk inserts all the earnings in a single statement, so none of them
+// is created when one of them fails.
+func (r *EarningPostgres) CreateBulk(earnings []entity.Earnings) (
+	[]entity.Earnings, error) {
+
+	earningsRow, err := createEarnings(r.dbpool, earnings)
+	if err != nil {
+		fmt.Println("entity.CreateBulkEarnings: ", err)
+	}
+
+	return earningsRow, err
+}
+
+// createEarnings inserts the earnings in a single statement. It is shared by
+// the repositories that store earnings together with other changes, in their
+// transaction.
+func createEarnings(db pgxscan.Querier, earnings []entity.Earnings) (
+	[]entity.Earnings, error) {
+
+	var earningsRow []entity.Earnings
+
+	types := make([]string, len(earnings))
+	values := make([]float64, len(earnings))
+	withheldTaxes := make([]float64, len(earnings))
+	dates := make([]time.Time, len(earnings))
+	currencies := make([]string, len(earnings))
+	assetIds := make([]string, len(earnings))
+	userUids := make([]string, len(earnings))
+	for i, earning := range earnings {
+		types[i] = earning.Type
+		values[i] = earning.Earning
+		withheldTaxes[i] = earning.WithheldTax
+		dates[i] = earning.Date
+		currencies[i] = earning.Currency
+		assetIds[i] = earning.Asset.Id
+		userUids[i] = earning.UserUid
+	}
+
+	insertRow := `
+	WITH inserted as (
+	INSERT INTO
+		earnings("type", earning, withheld_tax, "date", currency, asset_id,
+			user_uid)
+	SELECT * FROM unnest($1::text[], $2::float8[], $3::float8[], $4::date[],
+		$5::text[], $6::uuid[], $7::text[])
+	RETURNING id, "type", earning, withheld_tax, "date", currency, asset_id,
+		user_uid
+	)
+	SELECT
+		inserted.id, inserted.type, inserted.earning, inserted.withheld_tax,
+		inserted.date, inserted.currency, inserted.user_uid,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol
+		) asset
+	FROM inserted
+	INNER JOIN assets as ast
+	ON ast.id = inserted.asset_id;
+	`
+
+	err := pgxscan.Select(context.Background(), db, &earningsRow,
+		insertRow, types, values, withheldTaxes, dates, currencies, assetIds, userUids)
+
+	return earningsRow, err
+}
+
 func (r *EarningPostgres) SearchFromAssetUser(assetId string, userUid string) (
 	[]entity.Earnings, error) {
 
@@ -80,6 +147,36 @@ func (r *EarningPostgres) SearchFromAssetUser(assetId string, userUid string) (
 	return earningsReturn, err
 }
 
+// SearchAllFromUser returns all the earnings of the user, from the oldest to
+// the newest.
+func (r *EarningPostgres) SearchAllFromUser(userUid string) ([]entity.Earnings,
+	error) {
+
+	var earningsReturn []entity.Earnings
+
+	query := `
+	SELECT
+		eng.id, type, earning, withheld_tax, date, currency,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol
+		) as asset
+	FROM earnings as eng
+	INNER JOIN assets as ast
+	ON ast.id = eng.asset_id
+	WHERE user_uid = $1
+	ORDER BY date, ast.symbol;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &earningsReturn, query,
+		userUid)
+	if err != nil {
+		fmt.Println("entity.SearchAllEarningsFromUser: ", err)
+	}
+
+	return earningsReturn, err
+}
+
 func (r *EarningPostgres) SearchFromAssetUserEarningsByDate(assetId string,
 	userUid string, orderBy string, limit int, offset int) (
 	[]entity.Earnings, error) {
@@ -175,13 +272,25 @@ func (r *EarningPostgres) DeleteFromAssetUser(assetId string, userUid string) (
 
 }
 
+// DeleteFromUser deletes the earning of the user. When the earning was created
+// from an earning event, the event is dismissed for the user in the same
+// statement, so the earning is not created again from it.
 func (r *EarningPostgres) DeleteFromUser(id string, userUid string) (string, error) {
 	var orderId string
 
 	query := `
-	delete from earnings as e
-	where e.id = $1 and e.user_uid = $2
-	returning e.id;
+	with deleted as (
+		delete from earnings as e
+		where e.id = $1 and e.user_uid = $2
+		returning e.id, e.earning_event_id, e.user_uid
+	), dismissed as (
+		insert into
+			dismissed_earning_events(earning_event_id, user_uid)
+		select earning_event_id, user_uid from deleted
+		where earning_event_id is not null
+		on conflict (earning_event_id, user_uid) do nothing
+	)
+	select id from deleted;
 	`
 	row := r.dbpool.QueryRow(context.Background(), query, id, userUid)
 	err := row.Scan(&orderId)
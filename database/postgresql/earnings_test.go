@@ -85,6 +85,89 @@ func TestEarningCreate(t *testing.T) {
 	assert.Equal(t, expectedEarningRow, earningRow)
 }
 
+func TestEarningCreateBulk(t *testing.T) {
+	tr, _ := time.Parse("2006-01-02", "2021-10-01")
+
+	userUid := "eji90vl5"
+
+	asset := entity.Asset{
+		Id:     "a69a3",
+		Symbol: "ITUB4",
+	}
+
+	earnings := []entity.Earnings{
+		{
+			Type:        "Dividendos",
+			Earning:     2,
+			WithheldTax: 0.3,
+			Currency:    "BRL",
+			Date:        tr,
+			Asset:       &asset,
+			UserUid:     userUid,
+		},
+	}
+
+	expectedEarningsRow := []entity.Earnings{
+		{
+			Id:          "akxn-1234",
+			Type:        "Dividendos",
+			Earning:     2,
+			WithheldTax: 0.3,
+			Date:        tr,
+			Currency:    "BRL",
+			UserUid:     userUid,
+			Asset:       &asset,
+		},
+	}
+
+	insertRow := regexp.QuoteMeta(`
+	WITH inserted as (
+	INSERT INTO
+		earnings("type", earning, withheld_tax, "date", currency, asset_id,
+			user_uid)
+	SELECT * FROM unnest($1::text[], $2::float8[], $3::float8[], $4::date[],
+		$5::text[], $6::uuid[], $7::text[])
+	RETURNING id, "type", earning, withheld_tax, "date", currency, asset_id,
+		user_uid
+	)
+	SELECT
+		inserted.id, inserted.type, inserted.earning, inserted.withheld_tax,
+		inserted.date, inserted.currency, inserted.user_uid,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol
+		) asset
+	FROM inserted
+	INNER JOIN assets as ast
+	ON ast.id = inserted.asset_id;
+	`)
+
+	columns := []string{"id", "type", "earning", "withheld_tax", "date",
+		"currency", "user_uid", "asset"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(insertRow).WithArgs([]string{"Dividendos"},
+		[]float64{2}, []float64{0.3}, []time.Time{tr}, []string{"BRL"}, []string{"a69a3"},
+		[]string{userUid}).WillReturnRows(rows.AddRow("akxn-1234",
+		"Dividendos", 2.0, 0.3, tr, "BRL", userUid, &asset))
+
+	Earnings := EarningPostgres{dbpool: mock}
+	earningsRow, err := Earnings.CreateBulk(earnings)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedEarningsRow, earningsRow)
+}
+
 func TestEarningSearchFromAssetUser(t *testing.T) {
 
 	tr, err := time.Parse("2021-07-05", "2020-04-02")
@@ -322,9 +405,18 @@ func TestEarningDeleteFromUser(t *testing.T) {
 	userUid := "eji90vl5"
 
 	query := regexp.QuoteMeta(`
-	delete from earnings as e
-	where e.id = $1 and e.user_uid = $2
-	returning e.id
+	with deleted as (
+		delete from earnings as e
+		where e.id = $1 and e.user_uid = $2
+		returning e.id, e.earning_event_id, e.user_uid
+	), dismissed as (
+		insert into
+			dismissed_earning_events(earning_event_id, user_uid)
+		select earning_event_id, user_uid from deleted
+		where earning_event_id is not null
+		on conflict (earning_event_id, user_uid) do nothing
+	)
+	select id from deleted;
 	`)
 
 	columns := []string{"id"}
@@ -530,3 +622,62 @@ func TestEarningUpdateFromUser(t *testing.T) {
 	assert.NotNil(t, updatedOrder)
 	assert.Equal(t, expectedEarningsReturn, updatedOrder)
 }
+
+func TestEarningSearchAllFromUser(t *testing.T) {
+	tr := entity.StringToTime("2021-11-11")
+	userUid := "eji90vl5"
+
+	asset := entity.Asset{
+		Id:     "a69a3",
+		Symbol: "AAPL",
+	}
+
+	expectedEarnings := []entity.Earnings{
+		{
+			Id:          "akxn-1234",
+			Type:        "Dividendos",
+			Earning:     0.35,
+			WithheldTax: 0.10,
+			Date:        tr,
+			Currency:    "USD",
+			Asset:       &asset,
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	SELECT
+		eng.id, type, earning, withheld_tax, date, currency,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol
+		) as asset
+	FROM earnings as eng
+	INNER JOIN assets as ast
+	ON ast.id = eng.asset_id
+	WHERE user_uid = $1
+	ORDER BY date, ast.symbol;
+	`)
+
+	columns := []string{"id", "type", "earning", "withheld_tax", "date",
+		"currency", "asset"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(query).WithArgs(userUid).WillReturnRows(rows.AddRow(
+		"akxn-1234", "Dividendos", 0.35, 0.10, tr, "USD", &asset))
+
+	Earnings := EarningPostgres{dbpool: mock}
+	earnings, err := Earnings.SearchAllFromUser(userUid)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedEarnings, earnings)
+}
@@ -0,0 +1,136 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"stockfyApi/entity"
+	"time"
+
+	"github.com/georgysavva/scany/pgxscan"
+)
+
+type FixedIncomePostgres struct {
+	dbpool PgxIface
+}
+
+func NewFixedIncomePostgres(db PgxIface) *FixedIncomePostgres {
+	return &FixedIncomePostgres{
+		dbpool: db,
+	}
+}
+
+func (r *FixedIncomePostgres) Create(fixedIncome entity.FixedIncome) (
+	[]entity.FixedIncome, error) {
+
+	var fixedIncomeRow []entity.FixedIncome
+
+	insertRow := `
+	WITH inserted as (
+	INSERT INTO
+		fixed_incomes(category, issuer, indexer, rate, maturity, asset_id)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, category, issuer, indexer, rate, maturity, asset_id
+	)
+	SELECT
+		inserted.id, inserted.category, inserted.issuer, inserted.indexer,
+		inserted.rate, inserted.maturity,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol,
+			'fullname', ast.fullname
+		) asset
+	FROM inserted
+	INNER JOIN assets as ast
+	ON ast.id = inserted.asset_id;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &fixedIncomeRow,
+		insertRow, fixedIncome.Category, fixedIncome.Issuer, fixedIncome.Indexer,
+		fixedIncome.Rate, fixedIncome.Maturity, fixedIncome.Asset.Id)
+	if err != nil {
+		fmt.Println("entity.CreateFixedIncome: ", err)
+	}
+
+	return fixedIncomeRow, err
+}
+
+func (r *FixedIncomePostgres) SearchByAsset(assetId string) (
+	[]entity.FixedIncome, error) {
+
+	var fixedIncomeReturn []entity.FixedIncome
+
+	query := `
+	SELECT
+		fi.id, category, issuer, indexer, rate, maturity,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol,
+			'fullname', ast.fullname
+		) as asset
+	FROM fixed_incomes as fi
+	INNER JOIN assets as ast
+	ON ast.id = fi.asset_id
+	WHERE asset_id = $1;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &fixedIncomeReturn,
+		query, assetId)
+	if err != nil {
+		fmt.Println("entity.SearchFixedIncomeByAsset: ", err)
+	}
+
+	return fixedIncomeReturn, err
+}
+
+func (r *FixedIncomePostgres) CreateIndexSeries(series []entity.IndexSeries) (
+	[]entity.IndexSeries, error) {
+
+	var seriesRow []entity.IndexSeries
+
+	indexers := make([]string, len(series))
+	dates := make([]time.Time, len(series))
+	values := make([]float64, len(series))
+	for i, index := range series {
+		indexers[i] = index.Indexer
+		dates[i] = index.Date
+		values[i] = index.Value
+	}
+
+	insertRow := `
+	INSERT INTO
+		index_series(indexer, "date", value)
+	SELECT * FROM unnest($1::text[], $2::date[], $3::float8[])
+	ON CONFLICT (indexer, "date") DO UPDATE SET value = EXCLUDED.value
+	RETURNING indexer, "date", value;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &seriesRow, insertRow,
+		indexers, dates, values)
+	if err != nil {
+		fmt.Println("entity.CreateIndexSeries: ", err)
+	}
+
+	return seriesRow, err
+}
+
+func (r *FixedIncomePostgres) SearchIndexSeries(indexer string,
+	startDate time.Time, endDate time.Time) ([]entity.IndexSeries, error) {
+
+	var seriesReturn []entity.IndexSeries
+
+	query := `
+	SELECT
+		indexer, "date", value
+	FROM index_series
+	WHERE indexer = $1 and "date" > $2 and "date" <= $3
+	ORDER BY "date";
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &seriesReturn, query,
+		indexer, startDate, endDate)
+	if err != nil {
+		fmt.Println("entity.SearchIndexSeries: ", err)
+	}
+
+	return seriesReturn, err
+}
@@ -0,0 +1,224 @@
+package postgresql
+
+import (
+	"context"
+	"regexp"
+	"stockfyApi/entity"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixedIncomeCreate(t *testing.T) {
+	maturity := entity.StringToTime("2026-01-02")
+
+	asset := entity.Asset{
+		Id:       "a69a3",
+		Symbol:   "CDB-BANCOX-2026",
+		Fullname: "CDB Banco X 2026",
+	}
+
+	fixedIncome := entity.FixedIncome{
+		Category: "CDB",
+		Issuer:   "Banco X",
+		Indexer:  "CDI",
+		Rate:     110,
+		Maturity: maturity,
+		Asset:    &entity.Asset{Id: "a69a3"},
+	}
+
+	expectedFixedIncomeRow := []entity.FixedIncome{
+		{
+			Id:       "akxn-1234",
+			Category: "CDB",
+			Issuer:   "Banco X",
+			Indexer:  "CDI",
+			Rate:     110,
+			Maturity: maturity,
+			Asset:    &asset,
+		},
+	}
+
+	insertRow := regexp.QuoteMeta(`
+	WITH inserted as (
+	INSERT INTO
+		fixed_incomes(category, issuer, indexer, rate, maturity, asset_id)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, category, issuer, indexer, rate, maturity, asset_id
+	)
+	SELECT
+		inserted.id, inserted.category, inserted.issuer, inserted.indexer,
+		inserted.rate, inserted.maturity,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol,
+			'fullname', ast.fullname
+		) asset
+	FROM inserted
+	INNER JOIN assets as ast
+	ON ast.id = inserted.asset_id;
+	`)
+
+	columns := []string{"id", "category", "issuer", "indexer", "rate",
+		"maturity", "asset"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(insertRow).WithArgs("CDB", "Banco X", "CDI", 110.0,
+		maturity, "a69a3").WillReturnRows(rows.AddRow("akxn-1234", "CDB",
+		"Banco X", "CDI", 110.0, maturity, &asset))
+
+	fixedIncomes := FixedIncomePostgres{dbpool: mock}
+	fixedIncomeRow, err := fixedIncomes.Create(fixedIncome)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedFixedIncomeRow, fixedIncomeRow)
+}
+
+func TestFixedIncomeSearchByAsset(t *testing.T) {
+	maturity := entity.StringToTime("2026-01-02")
+
+	asset := entity.Asset{
+		Id:       "a69a3",
+		Symbol:   "CDB-BANCOX-2026",
+		Fullname: "CDB Banco X 2026",
+	}
+
+	expectedFixedIncome := []entity.FixedIncome{
+		{
+			Id:       "akxn-1234",
+			Category: "CDB",
+			Issuer:   "Banco X",
+			Indexer:  "CDI",
+			Rate:     110,
+			Maturity: maturity,
+			Asset:    &asset,
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	SELECT
+		fi.id, category, issuer, indexer, rate, maturity,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol,
+			'fullname', ast.fullname
+		) as asset
+	FROM fixed_incomes as fi
+	INNER JOIN assets as ast
+	ON ast.id = fi.asset_id
+	WHERE asset_id = $1;
+	`)
+
+	columns := []string{"id", "category", "issuer", "indexer", "rate",
+		"maturity", "asset"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(query).WithArgs("a69a3").WillReturnRows(rows.AddRow(
+		"akxn-1234", "CDB", "Banco X", "CDI", 110.0, maturity, &asset))
+
+	fixedIncomes := FixedIncomePostgres{dbpool: mock}
+	fixedIncome, err := fixedIncomes.SearchByAsset("a69a3")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedFixedIncome, fixedIncome)
+}
+
+func TestFixedIncomeCreateIndexSeries(t *testing.T) {
+	series := []entity.IndexSeries{
+		{Indexer: "CDI", Date: entity.StringToTime("2021-10-04"), Value: 6.15},
+		{Indexer: "CDI", Date: entity.StringToTime("2021-10-05"), Value: 6.15},
+	}
+
+	insertRow := regexp.QuoteMeta(`
+	INSERT INTO
+		index_series(indexer, "date", value)
+	SELECT * FROM unnest($1::text[], $2::date[], $3::float8[])
+	ON CONFLICT (indexer, "date") DO UPDATE SET value = EXCLUDED.value
+	RETURNING indexer, "date", value;
+	`)
+
+	columns := []string{"indexer", "date", "value"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(insertRow).WithArgs([]string{"CDI", "CDI"},
+		[]time.Time{series[0].Date, series[1].Date},
+		[]float64{6.15, 6.15}).WillReturnRows(rows.AddRow("CDI",
+		series[0].Date, 6.15).AddRow("CDI", series[1].Date, 6.15))
+
+	fixedIncomes := FixedIncomePostgres{dbpool: mock}
+	seriesCreated, err := fixedIncomes.CreateIndexSeries(series)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, series, seriesCreated)
+}
+
+func TestFixedIncomeSearchIndexSeries(t *testing.T) {
+	startDate := entity.StringToTime("2021-10-01")
+	endDate := entity.StringToTime("2021-10-31")
+
+	expectedSeries := []entity.IndexSeries{
+		{Indexer: "CDI", Date: entity.StringToTime("2021-10-04"), Value: 6.15},
+	}
+
+	query := regexp.QuoteMeta(`
+	SELECT
+		indexer, "date", value
+	FROM index_series
+	WHERE indexer = $1 and "date" > $2 and "date" <= $3
+	ORDER BY "date";
+	`)
+
+	columns := []string{"indexer", "date", "value"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(query).WithArgs("CDI", startDate, endDate).WillReturnRows(
+		rows.AddRow("CDI", expectedSeries[0].Date, 6.15))
+
+	fixedIncomes := FixedIncomePostgres{dbpool: mock}
+	series, err := fixedIncomes.SearchIndexSeries("CDI", startDate, endDate)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedSeries, series)
+}
@@ -0,0 +1,113 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"stockfyApi/entity"
+	"time"
+
+	"github.com/georgysavva/scany/pgxscan"
+)
+
+type IncomeProjectionPostgres struct {
+	dbpool PgxIface
+}
+
+func NewIncomeProjectionPostgres(db PgxIface) *IncomeProjectionPostgres {
+	return &IncomeProjectionPostgres{
+		dbpool: db,
+	}
+}
+
+func (r *IncomeProjectionPostgres) SearchOrders(userUid string) (
+	[]entity.Order, error) {
+
+	var ordersReturn []entity.Order
+
+	query := `
+	SELECT
+		o.id, quantity, price, currency, order_type, "date",
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol
+		) as asset
+	FROM orders as o
+	INNER JOIN assets as ast
+	ON ast.id = o.asset_id
+	WHERE user_uid = $1
+	ORDER BY "date";
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &ordersReturn,
+		query, userUid)
+	if err != nil {
+		fmt.Println("entity.SearchIncomeProjectionOrders: ", err)
+	}
+
+	return ordersReturn, err
+}
+
+// SearchEarnings returns the earnings of the user received from the start date,
+// with the ex-date of the earning event they are linked to.
+func (r *IncomeProjectionPostgres) SearchEarnings(userUid string,
+	startDate time.Time) ([]entity.Earnings, error) {
+
+	var earningsReturn []entity.Earnings
+
+	query := `
+	SELECT
+		eng.id, eng."type", earning, eng."date", eng.currency, ev.ex_date,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol
+		) as asset
+	FROM earnings as eng
+	INNER JOIN assets as ast
+	ON ast.id = eng.asset_id
+	LEFT JOIN earning_events as ev
+	ON ev.id = eng.earning_event_id
+	WHERE eng.user_uid = $1 and eng."date" >= $2
+	ORDER BY eng."date";
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &earningsReturn,
+		query, userUid, startDate)
+	if err != nil {
+		fmt.Println("entity.SearchIncomeProjectionEarnings: ", err)
+	}
+
+	return earningsReturn, err
+}
+
+// SearchAnnouncedEvents returns the earning events of the assets of the user
+// paid from the given date.
+func (r *IncomeProjectionPostgres) SearchAnnouncedEvents(userUid string,
+	date time.Time) ([]entity.EarningEvent, error) {
+
+	var eventsReturn []entity.EarningEvent
+
+	query := `
+	SELECT
+		ev.id, ev."type", amount, ev.currency, ex_date,
+		COALESCE(payment_date, ex_date) as payment_date,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol
+		) as asset
+	FROM earning_events as ev
+	INNER JOIN assets as ast
+	ON ast.id = ev.asset_id
+	INNER JOIN asset_users as au
+	ON au.asset_id = ev.asset_id
+	WHERE au.user_uid = $1 and COALESCE(payment_date, ex_date) >= $2
+	ORDER BY ex_date;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &eventsReturn,
+		query, userUid, date)
+	if err != nil {
+		fmt.Println("entity.SearchIncomeProjectionEvents: ", err)
+	}
+
+	return eventsReturn, err
+}
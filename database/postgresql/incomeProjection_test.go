@@ -0,0 +1,196 @@
+package postgresql
+
+import (
+	"context"
+	"regexp"
+	"stockfyApi/entity"
+	"testing"
+
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncomeProjectionSearchOrders(t *testing.T) {
+	date := entity.StringToTime("2021-06-01")
+
+	asset := entity.Asset{
+		Id:     "a69a3",
+		Symbol: "ITUB4",
+	}
+
+	expectedOrders := []entity.Order{
+		{
+			Id:        "ord-1234",
+			Quantity:  100,
+			Price:     25.5,
+			Currency:  "BRL",
+			OrderType: "buy",
+			Date:      date,
+			Asset:     &asset,
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	SELECT
+		o.id, quantity, price, currency, order_type, "date",
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol
+		) as asset
+	FROM orders as o
+	INNER JOIN assets as ast
+	ON ast.id = o.asset_id
+	WHERE user_uid = $1
+	ORDER BY "date";
+	`)
+
+	columns := []string{"id", "quantity", "price", "currency", "order_type",
+		"date", "asset"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(query).WithArgs("TestUserUID").WillReturnRows(
+		rows.AddRow("ord-1234", 100.0, 25.5, "BRL", "buy", date, &asset))
+
+	incomeProjection := IncomeProjectionPostgres{dbpool: mock}
+	orders, err := incomeProjection.SearchOrders("TestUserUID")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedOrders, orders)
+}
+
+func TestIncomeProjectionSearchEarnings(t *testing.T) {
+	startDate := entity.StringToTime("2020-10-01")
+	date := entity.StringToTime("2020-11-10")
+	exDate := entity.StringToTime("2020-10-29")
+
+	asset := entity.Asset{
+		Id:     "a69a3",
+		Symbol: "ITUB4",
+	}
+
+	expectedEarnings := []entity.Earnings{
+		{
+			Id:       "eng-1234",
+			Type:     "Dividendos",
+			Earning:  50,
+			Date:     date,
+			Currency: "BRL",
+			ExDate:   &exDate,
+			Asset:    &asset,
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	SELECT
+		eng.id, eng."type", earning, eng."date", eng.currency, ev.ex_date,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol
+		) as asset
+	FROM earnings as eng
+	INNER JOIN assets as ast
+	ON ast.id = eng.asset_id
+	LEFT JOIN earning_events as ev
+	ON ev.id = eng.earning_event_id
+	WHERE eng.user_uid = $1 and eng."date" >= $2
+	ORDER BY eng."date";
+	`)
+
+	columns := []string{"id", "type", "earning", "date", "currency",
+		"ex_date", "asset"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(query).WithArgs("TestUserUID", startDate).WillReturnRows(
+		rows.AddRow("eng-1234", "Dividendos", 50.0, date, "BRL", &exDate,
+			&asset))
+
+	incomeProjection := IncomeProjectionPostgres{dbpool: mock}
+	earnings, err := incomeProjection.SearchEarnings("TestUserUID", startDate)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedEarnings, earnings)
+}
+
+func TestIncomeProjectionSearchAnnouncedEvents(t *testing.T) {
+	date := entity.StringToTime("2021-10-19")
+	exDate := entity.StringToTime("2021-10-29")
+	paymentDate := entity.StringToTime("2021-11-16")
+
+	asset := entity.Asset{
+		Id:     "a69a3",
+		Symbol: "ITUB4",
+	}
+
+	expectedEvents := []entity.EarningEvent{
+		{
+			Id:          "ev-1234",
+			Type:        "JCP",
+			Amount:      0.01765,
+			Currency:    "BRL",
+			ExDate:      exDate,
+			PaymentDate: paymentDate,
+			Asset:       &asset,
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	SELECT
+		ev.id, ev."type", amount, ev.currency, ex_date,
+		COALESCE(payment_date, ex_date) as payment_date,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol
+		) as asset
+	FROM earning_events as ev
+	INNER JOIN assets as ast
+	ON ast.id = ev.asset_id
+	INNER JOIN asset_users as au
+	ON au.asset_id = ev.asset_id
+	WHERE au.user_uid = $1 and COALESCE(payment_date, ex_date) >= $2
+	ORDER BY ex_date;
+	`)
+
+	columns := []string{"id", "type", "amount", "currency", "ex_date",
+		"payment_date", "asset"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(query).WithArgs("TestUserUID", date).WillReturnRows(
+		rows.AddRow("ev-1234", "JCP", 0.01765, "BRL", exDate, paymentDate,
+			&asset))
+
+	incomeProjection := IncomeProjectionPostgres{dbpool: mock}
+	events, err := incomeProjection.SearchAnnouncedEvents("TestUserUID", date)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedEvents, events)
+}
@@ -0,0 +1,40 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"stockfyApi/entity"
+
+	"github.com/georgysavva/scany/pgxscan"
+)
+
+type MarketPostgres struct {
+	dbpool PgxIface
+}
+
+func NewMarketPostgres(db PgxIface) *MarketPostgres {
+	return &MarketPostgres{
+		dbpool: db,
+	}
+}
+
+func (r *MarketPostgres) SearchAll() ([]entity.Market, error) {
+
+	var marketsReturn []entity.Market
+
+	query := `
+	SELECT
+		country, name, currencies, reference_currency, symbol_suffix,
+		quantity_decimals, provider, asset_types
+	FROM markets
+	ORDER BY created_at;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &marketsReturn,
+		query)
+	if err != nil {
+		fmt.Println("entity.SearchAllMarkets: ", err)
+	}
+
+	return marketsReturn, err
+}
@@ -0,0 +1,71 @@
+package postgresql
+
+import (
+	"context"
+	"regexp"
+	"stockfyApi/entity"
+	"testing"
+
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarketSearchAll(t *testing.T) {
+
+	var expectedMarkets = []entity.Market{
+		{
+			Country:          "BR",
+			Name:             "Brasil",
+			Currencies:       []string{"BRL"},
+			SymbolSuffix:     ".SA",
+			QuantityDecimals: 0,
+			Provider:         entity.ProviderAlphaVantage,
+			AssetTypes:       []string{"STOCK", "ETF", "FII"},
+		},
+		{
+			Country:           "BDR",
+			Name:              "BDRs",
+			Currencies:        []string{"BRL"},
+			ReferenceCurrency: "USD",
+			SymbolSuffix:      ".SA",
+			QuantityDecimals:  0,
+			Provider:          entity.ProviderAlphaVantage,
+			AssetTypes:        []string{"STOCK", "ETF"},
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	SELECT
+		country, name, currencies, reference_currency, symbol_suffix,
+		quantity_decimals, provider, asset_types
+	FROM markets
+	ORDER BY created_at;
+	`)
+
+	columns := []string{"country", "name", "currencies", "reference_currency",
+		"symbol_suffix", "quantity_decimals", "provider", "asset_types"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(query).WillReturnRows(
+		rows.AddRow("BR", "Brasil", []string{"BRL"}, "", ".SA", 0,
+			entity.ProviderAlphaVantage, []string{"STOCK", "ETF", "FII"}).
+			AddRow("BDR", "BDRs", []string{"BRL"}, "USD", ".SA", 0,
+				entity.ProviderAlphaVantage, []string{"STOCK", "ETF"}))
+
+	Market := MarketPostgres{dbpool: mock}
+
+	markets, err := Market.SearchAll()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedMarkets, markets)
+}
@@ -0,0 +1,196 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"stockfyApi/entity"
+	"time"
+
+	"github.com/georgysavva/scany/pgxscan"
+)
+
+type OptionPostgres struct {
+	dbpool PgxIface
+}
+
+func NewOptionPostgres(db PgxIface) *OptionPostgres {
+	return &OptionPostgres{
+		dbpool: db,
+	}
+}
+
+func (r *OptionPostgres) Create(option entity.Option) ([]entity.Option,
+	error) {
+
+	var optionRow []entity.Option
+
+	insertRow := `
+	WITH inserted as (
+	INSERT INTO
+		options(option_type, strike, expiry, asset_id, underlying_id)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id, option_type, strike, expiry, settled, asset_id, underlying_id
+	)
+	SELECT
+		inserted.id, inserted.option_type, inserted.strike, inserted.expiry,
+		inserted.settled,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol,
+			'fullname', ast.fullname
+		) asset,
+		jsonb_build_object(
+			'id', und.id,
+			'symbol', und.symbol,
+			'fullname', und.fullname
+		) underlying
+	FROM inserted
+	INNER JOIN assets as ast
+	ON ast.id = inserted.asset_id
+	INNER JOIN assets as und
+	ON und.id = inserted.underlying_id;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &optionRow,
+		insertRow, option.OptionType, option.Strike, option.Expiry,
+		option.Asset.Id, option.Underlying.Id)
+	if err != nil {
+		fmt.Println("entity.CreateOption: ", err)
+	}
+
+	return optionRow, err
+}
+
+func (r *OptionPostgres) SearchByAsset(assetId string) ([]entity.Option,
+	error) {
+
+	var optionReturn []entity.Option
+
+	query := `
+	SELECT
+		opt.id, option_type, strike, expiry, settled,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol,
+			'fullname', ast.fullname
+		) as asset,
+		jsonb_build_object(
+			'id', und.id,
+			'symbol', und.symbol,
+			'fullname', und.fullname
+		) as underlying
+	FROM options as opt
+	INNER JOIN assets as ast
+	ON ast.id = opt.asset_id
+	INNER JOIN assets as und
+	ON und.id = opt.underlying_id
+	WHERE asset_id = $1;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &optionReturn,
+		query, assetId)
+	if err != nil {
+		fmt.Println("entity.SearchOptionByAsset: ", err)
+	}
+
+	return optionReturn, err
+}
+
+func (r *OptionPostgres) SearchExpired(date time.Time) ([]entity.Option,
+	error) {
+
+	var optionReturn []entity.Option
+
+	query := `
+	SELECT
+		opt.id, option_type, strike, expiry, settled,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol,
+			'fullname', ast.fullname
+		) as asset,
+		jsonb_build_object(
+			'id', und.id,
+			'symbol', und.symbol,
+			'fullname', und.fullname
+		) as underlying
+	FROM options as opt
+	INNER JOIN assets as ast
+	ON ast.id = opt.asset_id
+	INNER JOIN assets as und
+	ON und.id = opt.underlying_id
+	WHERE not settled and expiry <= $1
+	ORDER BY expiry;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &optionReturn,
+		query, date)
+	if err != nil {
+		fmt.Println("entity.SearchExpiredOptions: ", err)
+	}
+
+	return optionReturn, err
+}
+
+func (r *OptionPostgres) SearchHolders(assetId string) ([]string, error) {
+	var holders []string
+
+	query := `
+	SELECT
+		DISTINCT user_uid
+	FROM orders
+	WHERE asset_id = $1;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &holders, query,
+		assetId)
+	if err != nil {
+		fmt.Println("entity.SearchOptionHolders: ", err)
+	}
+
+	return holders, err
+}
+
+// Settle marks the option as settled and stores the orders that close the
+// positions of its holders in a single transaction. An option already settled
+// is not changed and none of the orders are stored, so settling it again does
+// not duplicate its orders.
+func (r *OptionPostgres) Settle(optionId string, orders []entity.Order) (
+	[]entity.Order, error) {
+
+	tx, err := r.dbpool.Begin(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback(context.Background())
+
+	updateSettled := `
+	UPDATE options
+	SET settled = true
+	WHERE id = $1 AND NOT settled;
+	`
+
+	commandTag, err := tx.Exec(context.Background(), updateSettled, optionId)
+	if err != nil {
+		fmt.Println("entity.SettleOption: ", err)
+		return nil, err
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return nil, nil
+	}
+
+	ordersReturn, err := createOrdersTx(tx, orders)
+	if err != nil {
+		fmt.Println("entity.SettleOption: ", err)
+		return nil, err
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return ordersReturn, nil
+}
@@ -0,0 +1,238 @@
+package postgresql
+
+import (
+	"context"
+	"regexp"
+	"stockfyApi/entity"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionCreate(t *testing.T) {
+	expiry := entity.StringToTime("2021-12-17")
+
+	asset := entity.Asset{
+		Id:       "a69a3",
+		Symbol:   "PETRL300",
+		Fullname: "PETR4 CALL 30 2021-12-17",
+	}
+
+	underlying := entity.Asset{
+		Id:       "b72c1",
+		Symbol:   "PETR4",
+		Fullname: "Petrobras PN",
+	}
+
+	option := entity.Option{
+		OptionType: "CALL",
+		Strike:     30,
+		Expiry:     expiry,
+		Asset:      &entity.Asset{Id: "a69a3"},
+		Underlying: &entity.Asset{Id: "b72c1"},
+	}
+
+	expectedOptionRow := []entity.Option{
+		{
+			Id:         "akxn-1234",
+			OptionType: "CALL",
+			Strike:     30,
+			Expiry:     expiry,
+			Asset:      &asset,
+			Underlying: &underlying,
+		},
+	}
+
+	insertRow := regexp.QuoteMeta(`
+	WITH inserted as (
+	INSERT INTO
+		options(option_type, strike, expiry, asset_id, underlying_id)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id, option_type, strike, expiry, settled, asset_id, underlying_id
+	)
+	SELECT
+		inserted.id, inserted.option_type, inserted.strike, inserted.expiry,
+		inserted.settled,
+		jsonb_build_object(
+			'id', ast.id,
+			'symbol', ast.symbol,
+			'fullname', ast.fullname
+		) asset,
+		jsonb_build_object(
+			'id', und.id,
+			'symbol', und.symbol,
+			'fullname', und.fullname
+		) underlying
+	FROM inserted
+	INNER JOIN assets as ast
+	ON ast.id = inserted.asset_id
+	INNER JOIN assets as und
+	ON und.id = inserted.underlying_id;
+	`)
+
+	columns := []string{"id", "option_type", "strike", "expiry", "settled",
+		"asset", "underlying"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(insertRow).WithArgs("CALL", 30.0, expiry, "a69a3",
+		"b72c1").WillReturnRows(rows.AddRow("akxn-1234", "CALL", 30.0, expiry,
+		false, &asset, &underlying))
+
+	options := OptionPostgres{dbpool: mock}
+	optionRow, err := options.Create(option)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedOptionRow, optionRow)
+}
+
+func TestOptionSearchHolders(t *testing.T) {
+	query := regexp.QuoteMeta(`
+	SELECT
+		DISTINCT user_uid
+	FROM orders
+	WHERE asset_id = $1;
+	`)
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows([]string{"user_uid"})
+	mock.ExpectQuery(query).WithArgs("a69a3").WillReturnRows(
+		rows.AddRow("userUid1").AddRow("userUid2"))
+
+	options := OptionPostgres{dbpool: mock}
+	holders, err := options.SearchHolders("a69a3")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"userUid1", "userUid2"}, holders)
+}
+
+func TestOptionSettle(t *testing.T) {
+	expiry := entity.StringToTime("2021-12-17")
+	userUid := "aa48fafh4"
+
+	brokerageInfo := entity.Brokerage{
+		Id:      "55555555-ed8b-11eb-9a03-0242ac130003",
+		Name:    "Clear",
+		Country: "BR",
+	}
+
+	assetInfo := entity.Asset{
+		Id:     "1111BBBB-ed8b-11eb-9a03-0242ac130003",
+		Symbol: "ITUBL300",
+	}
+
+	accountInfo := entity.BrokerageAccount{
+		Id:       "66666666-ed8b-11eb-9a03-0242ac130003",
+		Nickname: "Clear",
+	}
+
+	orders := []entity.Order{
+		{
+			Quantity:  -100,
+			Price:     0,
+			Currency:  "BRL",
+			OrderType: "sell",
+			Date:      expiry,
+			Asset:     &assetInfo,
+			Account:   &accountInfo,
+			UserUid:   userUid,
+		},
+	}
+
+	updateSettled := regexp.QuoteMeta(`
+	UPDATE options
+	SET settled = true
+	WHERE id = $1 AND NOT settled;
+	`)
+
+	insertAssetUser := regexp.QuoteMeta(`
+	INSERT INTO
+		asset_users(asset_id, user_uid)`)
+
+	insertRow := regexp.QuoteMeta(`
+	WITH inserted as (
+		INSERT INTO
+			orders(quantity, price, currency, order_type, date, fees,`)
+
+	columns := []string{"id", "quantity", "price", "currency", "order_type",
+		"date", "fees", "withheld_tax", "settlement_date", "brokerage", "account",
+		"asset"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	var settlementDate *time.Time
+
+	mock.ExpectBegin()
+	mock.ExpectExec(updateSettled).WithArgs("akxn-1234").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(insertAssetUser).WithArgs(assetInfo.Id, userUid).
+		WillReturnResult(pgxmock.NewResult("INSERT", 0))
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(insertRow).WithArgs(-100.0, 0.0, "BRL", "sell", expiry,
+		0.0, 0.0, settlementDate, assetInfo.Id, accountInfo.Id, userUid).
+		WillReturnRows(rows.AddRow("a8a8a8a8-ed8b-11eb-9a03-0242ac130003",
+			-100.0, 0.0, "BRL", "sell", expiry, 0.0, 0.0, settlementDate,
+			&brokerageInfo, &accountInfo, &assetInfo))
+	mock.ExpectCommit()
+
+	options := OptionPostgres{dbpool: mock}
+	ordersCreated, err := options.Settle("akxn-1234", orders)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, []entity.Order{
+		{
+			Id:        "a8a8a8a8-ed8b-11eb-9a03-0242ac130003",
+			Quantity:  -100,
+			Price:     0,
+			Currency:  "BRL",
+			OrderType: "sell",
+			Date:      expiry,
+			Brokerage: &brokerageInfo,
+			Account:   &accountInfo,
+			Asset:     &assetInfo,
+		},
+	}, ordersCreated)
+
+	// An option settled by a previous run does not store its orders again
+	mock.ExpectBegin()
+	mock.ExpectExec(updateSettled).WithArgs("akxn-1234").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectRollback()
+
+	ordersCreated, err = options.Settle("akxn-1234", orders)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Nil(t, ordersCreated)
+}
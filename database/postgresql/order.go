@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
 	_ "github.com/lib/pq"
 )
 
@@ -36,11 +37,15 @@ func (r *OrderPostgres) Create(orderInsert entity.Order) entity.Order {
 	WITH inserted as (
 		INSERT INTO
 			orders(quantity, price, currency, order_type, date, asset_id,
-				brokerage_id, user_uid
+				account_id, brokerage_id, user_uid
 			)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, (
+			SELECT ba.brokerage_id
+			FROM brokerage_accounts as ba
+			WHERE ba.id = $7 and ba.user_uid = $8
+		), $8)
 		RETURNING id, quantity, price, currency, order_type, date, asset_id,
-			brokerage_id
+			account_id, brokerage_id
 	)
 	SELECT
 		inserted.id, inserted.quantity, inserted.price, inserted.currency,
@@ -50,6 +55,10 @@ func (r *OrderPostgres) Create(orderInsert entity.Order) entity.Order {
 			'name', b.name,
 			'country', b.country
 		) as brokerage,
+		json_build_object(
+			'id', ba.id,
+			'nickname', ba.nickname
+		) as account,
 		json_build_object(
 			'id', a.id,
 			'symbol', a.symbol,
@@ -59,6 +68,8 @@ func (r *OrderPostgres) Create(orderInsert entity.Order) entity.Order {
 	FROM inserted
 	INNER JOIN brokerages as b
 	ON inserted.brokerage_id = b.id
+	INNER JOIN brokerage_accounts as ba
+	ON inserted.account_id = ba.id
 	INNER JOIN assets as a
 	ON inserted.asset_id = a.id;
 	`
@@ -66,11 +77,11 @@ func (r *OrderPostgres) Create(orderInsert entity.Order) entity.Order {
 	row := tx.QueryRow(context.Background(), insertRow,
 		orderInsert.Quantity, orderInsert.Price, orderInsert.Currency,
 		orderInsert.OrderType, orderInsert.Date, orderInsert.Asset.Id,
-		orderInsert.Brokerage.Id, orderInsert.UserUid)
+		orderInsert.Account.Id, orderInsert.UserUid)
 	err = row.Scan(&orderReturn.Id, &orderReturn.Quantity,
 		&orderReturn.Price, &orderReturn.Currency,
 		&orderReturn.OrderType, &orderReturn.Date, &orderReturn.Brokerage,
-		&orderReturn.Asset)
+		&orderReturn.Account, &orderReturn.Asset)
 	if err != nil {
 		log.Panic(err)
 	}
@@ -96,13 +107,19 @@ func (r *OrderPostgres) SearchByOrderAndUserId(orderId string, userUid string) (
 			'name', b."name",
 			'country', b.country
 		) as brokerage,
+		json_build_object(
+			'id', ba.id,
+			'nickname', ba.nickname
+		) as account,
 		json_build_object(
 			'id', asset_id
 		) as asset
 	FROM orders as o
 	INNER JOIN brokerages as b
 	ON b.id = o.brokerage_id
-	WHERE o.id = $1 and user_uid = $2;
+	INNER JOIN brokerage_accounts as ba
+	ON ba.id = o.account_id
+	WHERE o.id = $1 and o.user_uid = $2;
 	`
 	err := pgxscan.Select(context.Background(), r.dbpool, &orderReturn, query,
 		orderId, userUid)
@@ -125,11 +142,17 @@ func (r *OrderPostgres) SearchFromAssetUser(assetId string, userUid string) (
 			'id', b.id,
 			'name', b."name",
 			'country', b.country
-		) as brokerage
+		) as brokerage,
+		json_build_object(
+			'id', ba.id,
+			'nickname', ba.nickname
+		) as account
 	FROM orders as o
 	INNER JOIN brokerages as b
 	ON b.id = o.brokerage_id
-	WHERE asset_id = $1 and user_uid = $2;
+	INNER JOIN brokerage_accounts as ba
+	ON ba.id = o.account_id
+	WHERE asset_id = $1 and o.user_uid = $2;
 	`
 
 	err := pgxscan.Select(context.Background(), r.dbpool, &ordersReturn, query,
@@ -141,6 +164,50 @@ func (r *OrderPostgres) SearchFromAssetUser(assetId string, userUid string) (
 	return ordersReturn, err
 }
 
+// SearchFromUser returns all the orders of the user, from the oldest to the
+// newest.
+func (r *OrderPostgres) SearchFromUser(userUid string) ([]entity.Order,
+	error) {
+
+	var ordersReturn []entity.Order
+
+	query := `
+	SELECT
+		o.id, quantity, price, o.currency, order_type, date, fees,
+		withheld_tax, settlement_date,
+		json_build_object(
+			'id', b.id,
+			'name', b."name",
+			'country', b.country
+		) as brokerage,
+		json_build_object(
+			'id', ba.id,
+			'nickname', ba.nickname
+		) as account,
+		json_build_object(
+			'id', a.id,
+			'symbol', a.symbol
+		) as asset
+	FROM orders as o
+	INNER JOIN brokerages as b
+	ON b.id = o.brokerage_id
+	INNER JOIN brokerage_accounts as ba
+	ON ba.id = o.account_id
+	INNER JOIN assets as a
+	ON a.id = o.asset_id
+	WHERE o.user_uid = $1
+	ORDER BY date, a.symbol;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &ordersReturn, query,
+		userUid)
+	if err != nil {
+		fmt.Println("entity.SearchOrdersFromUser: ", err)
+	}
+
+	return ordersReturn, err
+}
+
 func (r *OrderPostgres) SearchFromAssetUserOrderByDate(assetId string,
 	userUid string, orderBy string, limit int, offset int) ([]entity.Order,
 	error) {
@@ -157,11 +224,17 @@ func (r *OrderPostgres) SearchFromAssetUserOrderByDate(assetId string,
 				'id', b.id,
 				'name', b."name",
 				'country', b.country
-			) as brokerage
+			) as brokerage,
+			json_build_object(
+				'id', ba.id,
+				'nickname', ba.nickname
+			) as account
 		FROM orders as o
 		INNER JOIN brokerages as b
 		ON b.id = o.brokerage_id
-		WHERE asset_id = $1 and user_uid = $2
+		INNER JOIN brokerage_accounts as ba
+		ON ba.id = o.account_id
+		WHERE asset_id = $1 and o.user_uid = $2
 		ORDER BY "date" ` + upperOrderBy + `
 		LIMIT $3
 		OFFSET $4;
@@ -254,10 +327,12 @@ func (r *OrderPostgres) UpdateFromUser(orderUpdate entity.Order) []entity.Order
 		price = $4,
 		order_type = $5,
 		"date" = $6,
-		brokerage_id = $7
-	where o.id = $1 and o.user_uid = $2
-	returning o.id, o.quantity, o.price, o."date", o.order_type, brokerage_id,
-		o.currency
+		account_id = ba.id,
+		brokerage_id = ba.brokerage_id
+	from brokerage_accounts as ba
+	where o.id = $1 and o.user_uid = $2 and ba.id = $7 and ba.user_uid = $2
+	returning o.id, o.quantity, o.price, o."date", o.order_type,
+		o.account_id, o.brokerage_id, o.currency
 	)
 	select
 		updated.id, updated.quantity, updated.price, updated.order_type,
@@ -266,18 +341,213 @@ func (r *OrderPostgres) UpdateFromUser(orderUpdate entity.Order) []entity.Order
 			'id', updated.brokerage_id,
 			'name', b."name",
 			'country', b.country
-		) as brokerage
+		) as brokerage,
+		json_build_object(
+			'id', updated.account_id,
+			'nickname', ba.nickname
+		) as account
 	from updated
 	inner join brokerages as b
-	on b.id = updated.brokerage_id;
+	on b.id = updated.brokerage_id
+	inner join brokerage_accounts as ba
+	on ba.id = updated.account_id;
 	`
 	err := pgxscan.Select(context.Background(), r.dbpool, &orderInfo,
 		query, orderUpdate.Id, orderUpdate.UserUid, orderUpdate.Quantity,
 		orderUpdate.Price, orderUpdate.OrderType, orderUpdate.Date,
-		orderUpdate.Brokerage.Id)
+		orderUpdate.Account.Id)
 	if err != nil {
 		return nil
 	}
 
 	return orderInfo
 }
+
+// CreateBulk inserts the orders in a single transaction, relating each asset to
+// its user. When an order fails, none of them is stored.
+func (r *OrderPostgres) CreateBulk(orders []entity.Order) ([]entity.Order,
+	error) {
+
+	tx, err := r.dbpool.Begin(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback(context.Background())
+
+	ordersReturn, err := createOrdersTx(tx, orders)
+	if err != nil {
+		fmt.Println("entity.CreateBulkOrders: ", err)
+		return nil, err
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return ordersReturn, nil
+}
+
+// CreateImport inserts the rows of an import in a single transaction: the
+// assets and the default brokerage accounts not registered yet, then the
+// orders and the earnings. The orders and the earnings without the Id of their
+// asset or account are related to the new ones by the symbol of the asset and
+// by the brokerage of the account. When one of them fails, nothing is stored.
+func (r *OrderPostgres) CreateImport(assets []entity.Asset,
+	accounts []entity.BrokerageAccount, orders []entity.Order,
+	earnings []entity.Earnings) ([]entity.Order, []entity.Earnings, error) {
+
+	tx, err := r.dbpool.Begin(context.Background())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer tx.Rollback(context.Background())
+
+	assetIds, err := createAssetsTx(tx, assets)
+	if err != nil {
+		fmt.Println("entity.CreateImport: ", err)
+		return nil, nil, err
+	}
+
+	accountIds, err := createAccountsTx(tx, accounts)
+	if err != nil {
+		fmt.Println("entity.CreateImport: ", err)
+		return nil, nil, err
+	}
+
+	ordersInsert := make([]entity.Order, len(orders))
+	for i, order := range orders {
+		if order.Asset.Id == "" {
+			order.Asset = &entity.Asset{Id: assetIds[order.Asset.Symbol],
+				Symbol: order.Asset.Symbol}
+		}
+
+		if order.Account.Id == "" {
+			order.Account = &entity.BrokerageAccount{
+				Id: accountIds[order.Account.Brokerage.Id]}
+		}
+
+		ordersInsert[i] = order
+	}
+
+	earningsInsert := make([]entity.Earnings, len(earnings))
+	for i, earning := range earnings {
+		if earning.Asset.Id == "" {
+			earning.Asset = &entity.Asset{Id: assetIds[earning.Asset.Symbol],
+				Symbol: earning.Asset.Symbol}
+		}
+
+		earningsInsert[i] = earning
+	}
+
+	ordersReturn, err := createOrdersTx(tx, ordersInsert)
+	if err != nil {
+		fmt.Println("entity.CreateImport: ", err)
+		return nil, nil, err
+	}
+
+	var earningsReturn []entity.Earnings
+	if len(earningsInsert) > 0 {
+		earningsReturn, err = createEarnings(tx, earningsInsert)
+		if err != nil {
+			fmt.Println("entity.CreateImport: ", err)
+			return nil, nil, err
+		}
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ordersReturn, earningsReturn, nil
+}
+
+// createOrdersTx inserts the orders in the transaction, relating each asset to
+// its user. It is shared by the repositories that store orders together with
+// other changes.
+func createOrdersTx(tx pgx.Tx, orders []entity.Order) ([]entity.Order,
+	error) {
+
+	var ordersReturn []entity.Order
+
+	insertAssetUser := `
+	INSERT INTO
+		asset_users(asset_id, user_uid)
+	VALUES ($1, $2)
+	ON CONFLICT (asset_id, user_uid) DO NOTHING;
+	`
+
+	insertRow := `
+	WITH inserted as (
+		INSERT INTO
+			orders(quantity, price, currency, order_type, date, fees,
+				withheld_tax, settlement_date, asset_id, account_id,
+				brokerage_id, user_uid
+			)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, (
+			SELECT ba.brokerage_id
+			FROM brokerage_accounts as ba
+			WHERE ba.id = $10 and ba.user_uid = $11
+		), $11)
+		RETURNING id, quantity, price, currency, order_type, date, fees,
+			withheld_tax, settlement_date, asset_id, account_id, brokerage_id
+	)
+	SELECT
+		inserted.id, inserted.quantity, inserted.price, inserted.currency,
+		inserted.order_type, inserted.date, inserted.fees,
+		inserted.withheld_tax, inserted.settlement_date,
+		json_build_object(
+			'id', b.id,
+			'name', b.name,
+			'country', b.country
+		) as brokerage,
+		json_build_object(
+			'id', ba.id,
+			'nickname', ba.nickname
+		) as account,
+		json_build_object(
+			'id', a.id,
+			'symbol', a.symbol,
+			'preference', a.preference,
+			'fullname', a.fullname
+		) as asset
+	FROM inserted
+	INNER JOIN brokerages as b
+	ON inserted.brokerage_id = b.id
+	INNER JOIN brokerage_accounts as ba
+	ON inserted.account_id = ba.id
+	INNER JOIN assets as a
+	ON inserted.asset_id = a.id;
+	`
+
+	for _, orderInsert := range orders {
+		var orderReturn entity.Order
+
+		_, err := tx.Exec(context.Background(), insertAssetUser,
+			orderInsert.Asset.Id, orderInsert.UserUid)
+		if err != nil {
+			return nil, err
+		}
+
+		row := tx.QueryRow(context.Background(), insertRow,
+			orderInsert.Quantity, orderInsert.Price, orderInsert.Currency,
+			orderInsert.OrderType, orderInsert.Date, orderInsert.Fees,
+			orderInsert.WithheldTax, orderInsert.SettlementDate,
+			orderInsert.Asset.Id, orderInsert.Account.Id, orderInsert.UserUid)
+		err = row.Scan(&orderReturn.Id, &orderReturn.Quantity,
+			&orderReturn.Price, &orderReturn.Currency, &orderReturn.OrderType,
+			&orderReturn.Date, &orderReturn.Fees, &orderReturn.WithheldTax,
+			&orderReturn.SettlementDate, &orderReturn.Brokerage,
+			&orderReturn.Account, &orderReturn.Asset)
+		if err != nil {
+			return nil, err
+		}
+
+		ordersReturn = append(ordersReturn, orderReturn)
+	}
+
+	return ordersReturn, nil
+}
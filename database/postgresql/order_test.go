@@ -2,6 +2,7 @@ package postgresql
 
 import (
 	"context"
+	"errors"
 	"regexp"
 	"stockfyApi/entity"
 	"strings"
@@ -28,12 +29,17 @@ func TestOrderCreate(t *testing.T) {
 		Fullname: "Vanguard Total Stock Market US",
 	}
 
+	accountInfo := entity.BrokerageAccount{
+		Id:       "66666666-ed8b-11eb-9a03-0242ac130003",
+		Nickname: "Avenue",
+	}
+
 	orderInsert := entity.Order{
 		// Symbol:    "VTI",
 		// Fullname:  "Vanguard Total Stock Market US",
 		Id:        "3e3e3e3w-ed8b-11eb-9a03-0242ac130003",
 		Asset:     &assetInfo,
-		Brokerage: &brokerageInfo,
+		Account:   &accountInfo,
 		Quantity:  10.0,
 		Price:     20.29,
 		Currency:  "USD",
@@ -50,6 +56,7 @@ func TestOrderCreate(t *testing.T) {
 		OrderType: "buy",
 		Date:      tr,
 		Brokerage: &brokerageInfo,
+		Account:   &accountInfo,
 		Asset:     &assetInfo,
 	}
 
@@ -57,11 +64,15 @@ func TestOrderCreate(t *testing.T) {
 	WITH inserted as (
 		INSERT INTO
 			orders(quantity, price, currency, order_type, date, asset_id,
-				brokerage_id, user_uid
+				account_id, brokerage_id, user_uid
 			)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, (
+			SELECT ba.brokerage_id
+			FROM brokerage_accounts as ba
+			WHERE ba.id = $7 and ba.user_uid = $8
+		), $8)
 		RETURNING id, quantity, price, currency, order_type, date, asset_id,
-			brokerage_id
+			account_id, brokerage_id
 	)
 	SELECT
 		inserted.id, inserted.quantity, inserted.price, inserted.currency,
@@ -71,6 +82,10 @@ func TestOrderCreate(t *testing.T) {
 			'name', b.name,
 			'country', b.country
 		) as brokerage,
+		json_build_object(
+			'id', ba.id,
+			'nickname', ba.nickname
+		) as account,
 		json_build_object(
 			'id', a.id,
 			'symbol', a.symbol,
@@ -80,12 +95,14 @@ func TestOrderCreate(t *testing.T) {
 	FROM inserted
 	INNER JOIN brokerages as b
 	ON inserted.brokerage_id = b.id
+	INNER JOIN brokerage_accounts as ba
+	ON inserted.account_id = ba.id
 	INNER JOIN assets as a
 	ON inserted.asset_id = a.id;
 	`)
 
 	columns := []string{"id", "quantity", "price", "currency", "order_type",
-		"date", "brokerage", "asset"}
+		"date", "brokerage", "account", "asset"}
 
 	mock, err := pgxmock.NewConn()
 	if err != nil {
@@ -98,9 +115,9 @@ func TestOrderCreate(t *testing.T) {
 	rows := mock.NewRows(columns)
 	mock.ExpectQuery(insertRow).WithArgs(10.0, 20.29, "USD", "buy",
 		tr, "1111BBBB-ed8b-11eb-9a03-0242ac130003",
-		"55555555-ed8b-11eb-9a03-0242ac130003", userUid).
+		"66666666-ed8b-11eb-9a03-0242ac130003", userUid).
 		WillReturnRows(rows.AddRow("a8a8a8a8-ed8b-11eb-9a03-0242ac130003", 10.0,
-			20.29, "USD", "buy", tr, &brokerageInfo, &assetInfo))
+			20.29, "USD", "buy", tr, &brokerageInfo, &accountInfo, &assetInfo))
 	mock.ExpectCommit()
 
 	Orders := OrderPostgres{dbpool: mock}
@@ -124,6 +141,11 @@ func TestOrderSearchFromAssetUser(t *testing.T) {
 		Country: "US",
 	}
 
+	account := entity.BrokerageAccount{
+		Id:       "66666666-ed8b-11eb-9a03-0242ac130003",
+		Nickname: "Test Account",
+	}
+
 	expectedOrderReturn := []entity.Order{
 		{
 			Id:        "a8a8a8a8-ed8b-11eb-9a03-0242ac130003",
@@ -133,6 +155,7 @@ func TestOrderSearchFromAssetUser(t *testing.T) {
 			OrderType: "buy",
 			Date:      tr,
 			Brokerage: &brokerage,
+			Account:   &account,
 		},
 		{
 			Id:        "a9a999a9-ed8b-11eb-9a03-0242ac130003",
@@ -142,6 +165,7 @@ func TestOrderSearchFromAssetUser(t *testing.T) {
 			OrderType: "buy",
 			Date:      tr,
 			Brokerage: &brokerage,
+			Account:   &account,
 		},
 	}
 
@@ -152,15 +176,21 @@ func TestOrderSearchFromAssetUser(t *testing.T) {
 			'id', b.id,
 			'name', b."name",
 			'country', b.country
-		) as brokerage
+		) as brokerage,
+		json_build_object(
+			'id', ba.id,
+			'nickname', ba.nickname
+		) as account
 	FROM orders as o
 	INNER JOIN brokerages as b
 	ON b.id = o.brokerage_id
-	WHERE asset_id = $1 and user_uid = $2;
+	INNER JOIN brokerage_accounts as ba
+	ON ba.id = o.account_id
+	WHERE asset_id = $1 and o.user_uid = $2;
 	`)
 
 	columns := []string{"id", "quantity", "price", "currency", "order_type",
-		"date", "brokerage"}
+		"date", "brokerage", "account"}
 
 	mock, err := pgxmock.NewConn()
 	if err != nil {
@@ -173,10 +203,12 @@ func TestOrderSearchFromAssetUser(t *testing.T) {
 		rows.AddRow(expectedOrderReturn[0].Id, expectedOrderReturn[0].Quantity,
 			expectedOrderReturn[0].Price, expectedOrderReturn[0].Currency,
 			expectedOrderReturn[0].OrderType, expectedOrderReturn[0].Date,
-			expectedOrderReturn[0].Brokerage).AddRow(expectedOrderReturn[1].Id,
-			expectedOrderReturn[1].Quantity, expectedOrderReturn[1].Price,
-			expectedOrderReturn[1].Currency, expectedOrderReturn[1].OrderType,
-			expectedOrderReturn[1].Date, expectedOrderReturn[1].Brokerage))
+			expectedOrderReturn[0].Brokerage, expectedOrderReturn[0].Account).
+			AddRow(expectedOrderReturn[1].Id,
+				expectedOrderReturn[1].Quantity, expectedOrderReturn[1].Price,
+				expectedOrderReturn[1].Currency, expectedOrderReturn[1].OrderType,
+				expectedOrderReturn[1].Date, expectedOrderReturn[1].Brokerage,
+				expectedOrderReturn[1].Account))
 
 	Orders := OrderPostgres{dbpool: mock}
 	ordersReturn, err := Orders.SearchFromAssetUser("aak49", userUid)
@@ -201,6 +233,11 @@ func TestOrderSearchFromAssetUserOrderByDateSuccess(t *testing.T) {
 		Country: "US",
 	}
 
+	account := entity.BrokerageAccount{
+		Id:       "66666666-ed8b-11eb-9a03-0242ac130003",
+		Nickname: "Test Account",
+	}
+
 	expectedOrderReturn := []entity.Order{
 		{
 			Id:        "a8a8a8a8-ed8b-11eb-9a03-0242ac130003",
@@ -210,6 +247,7 @@ func TestOrderSearchFromAssetUserOrderByDateSuccess(t *testing.T) {
 			OrderType: "buy",
 			Date:      tr,
 			Brokerage: &brokerage,
+			Account:   &account,
 		},
 		{
 			Id:        "a9a999a9-ed8b-11eb-9a03-0242ac130003",
@@ -219,6 +257,7 @@ func TestOrderSearchFromAssetUserOrderByDateSuccess(t *testing.T) {
 			OrderType: "buy",
 			Date:      tr,
 			Brokerage: &brokerage,
+			Account:   &account,
 		},
 	}
 
@@ -231,18 +270,24 @@ func TestOrderSearchFromAssetUserOrderByDateSuccess(t *testing.T) {
 				'id', b.id,
 				'name', b."name",
 				'country', b.country
-			) as brokerage
+			) as brokerage,
+			json_build_object(
+				'id', ba.id,
+				'nickname', ba.nickname
+			) as account
 		FROM orders as o
 		INNER JOIN brokerages as b
 		ON b.id = o.brokerage_id
-		WHERE asset_id = $1 and user_uid = $2
+		INNER JOIN brokerage_accounts as ba
+		ON ba.id = o.account_id
+		WHERE asset_id = $1 and o.user_uid = $2
 		ORDER BY "date" ` + upperOrderBy + `
 		LIMIT $3
 		OFFSET $4;
 	`)
 
 	columns := []string{"id", "quantity", "price", "currency", "order_type",
-		"date", "brokerage"}
+		"date", "brokerage", "account"}
 
 	mock, err := pgxmock.NewConn()
 	if err != nil {
@@ -255,10 +300,12 @@ func TestOrderSearchFromAssetUserOrderByDateSuccess(t *testing.T) {
 		rows.AddRow(expectedOrderReturn[0].Id, expectedOrderReturn[0].Quantity,
 			expectedOrderReturn[0].Price, expectedOrderReturn[0].Currency,
 			expectedOrderReturn[0].OrderType, expectedOrderReturn[0].Date,
-			expectedOrderReturn[0].Brokerage).AddRow(expectedOrderReturn[1].Id,
-			expectedOrderReturn[1].Quantity, expectedOrderReturn[1].Price,
-			expectedOrderReturn[1].Currency, expectedOrderReturn[1].OrderType,
-			expectedOrderReturn[1].Date, expectedOrderReturn[1].Brokerage))
+			expectedOrderReturn[0].Brokerage, expectedOrderReturn[0].Account).
+			AddRow(expectedOrderReturn[1].Id,
+				expectedOrderReturn[1].Quantity, expectedOrderReturn[1].Price,
+				expectedOrderReturn[1].Currency, expectedOrderReturn[1].OrderType,
+				expectedOrderReturn[1].Date, expectedOrderReturn[1].Brokerage,
+				expectedOrderReturn[1].Account))
 
 	Orders := OrderPostgres{dbpool: mock}
 	ordersReturn, err := Orders.SearchFromAssetUserOrderByDate("aak49", userUid,
@@ -447,9 +494,14 @@ func TestOrderSingleUpdateFromUser(t *testing.T) {
 		Country: "US",
 	}
 
+	accountInfo := entity.BrokerageAccount{
+		Id:       "66666666-ed8b-11eb-9a03-0242ac130003",
+		Nickname: "Avenue",
+	}
+
 	orderInsert := entity.Order{
 		Id:        "3e3e3e3w-ed8b-11eb-9a03-0242ac130003",
-		Brokerage: &brokerageInfo,
+		Account:   &accountInfo,
 		Quantity:  20.0,
 		Price:     20.29,
 		Currency:  "USD",
@@ -467,6 +519,7 @@ func TestOrderSingleUpdateFromUser(t *testing.T) {
 			OrderType: "buy",
 			Currency:  "USD",
 			Brokerage: &brokerageInfo,
+			Account:   &accountInfo,
 		},
 	}
 
@@ -477,10 +530,12 @@ func TestOrderSingleUpdateFromUser(t *testing.T) {
 		price = $4,
 		order_type = $5,
 		"date" = $6,
-		brokerage_id = $7
-	where o.id = $1 and o.user_uid = $2
-	returning o.id, o.quantity, o.price, o."date", o.order_type, brokerage_id,
-		o.currency
+		account_id = ba.id,
+		brokerage_id = ba.brokerage_id
+	from brokerage_accounts as ba
+	where o.id = $1 and o.user_uid = $2 and ba.id = $7 and ba.user_uid = $2
+	returning o.id, o.quantity, o.price, o."date", o.order_type,
+		o.account_id, o.brokerage_id, o.currency
 	)
 	select
 		updated.id, updated.quantity, updated.price, updated.order_type,
@@ -489,14 +544,20 @@ func TestOrderSingleUpdateFromUser(t *testing.T) {
 			'id', updated.brokerage_id,
 			'name', b."name",
 			'country', b.country
-		) as brokerage
+		) as brokerage,
+		json_build_object(
+			'id', updated.account_id,
+			'nickname', ba.nickname
+		) as account
 	from updated
 	inner join brokerages as b
-	on b.id = updated.brokerage_id;
+	on b.id = updated.brokerage_id
+	inner join brokerage_accounts as ba
+	on ba.id = updated.account_id;
 	`)
 
 	columns := []string{"id", "quantity", "price", "date", "order_type",
-		"currency", "brokerage"}
+		"currency", "brokerage", "account"}
 
 	mock, err := pgxmock.NewConn()
 	if err != nil {
@@ -506,9 +567,9 @@ func TestOrderSingleUpdateFromUser(t *testing.T) {
 
 	rows := mock.NewRows(columns)
 	mock.ExpectQuery(query).WithArgs("3e3e3e3w-ed8b-11eb-9a03-0242ac130003",
-		userUid, 20.0, 20.29, "buy", tr, "55555555-ed8b-11eb-9a03-0242ac130003").
+		userUid, 20.0, 20.29, "buy", tr, "66666666-ed8b-11eb-9a03-0242ac130003").
 		WillReturnRows(rows.AddRow("3e3e3e3w-ed8b-11eb-9a03-0242ac130003", 20.0,
-			20.29, tr, "buy", "USD", &brokerageInfo))
+			20.29, tr, "buy", "USD", &brokerageInfo, &accountInfo))
 
 	Orders := OrderPostgres{dbpool: mock}
 	updatedOrder := Orders.UpdateFromUser(orderInsert)
@@ -532,6 +593,11 @@ func TestSearchByOrderAndUserId(t *testing.T) {
 		Country: "US",
 	}
 
+	accountInfo := entity.BrokerageAccount{
+		Id:       "66666666-ed8b-11eb-9a03-0242ac130003",
+		Nickname: "Avenue",
+	}
+
 	assetInfo := entity.Asset{Id: "39823-3DNC894"}
 	expectedOrderInfo := []entity.Order{
 		{
@@ -542,6 +608,7 @@ func TestSearchByOrderAndUserId(t *testing.T) {
 			OrderType: "buy",
 			Currency:  "USD",
 			Brokerage: &brokerageInfo,
+			Account:   &accountInfo,
 			Asset:     &assetInfo,
 		},
 	}
@@ -554,17 +621,23 @@ func TestSearchByOrderAndUserId(t *testing.T) {
 			'name', b."name",
 			'country', b.country
 		) as brokerage,
+		json_build_object(
+			'id', ba.id,
+			'nickname', ba.nickname
+		) as account,
 		json_build_object(
 			'id', asset_id
 		) as asset
 	FROM orders as o
 	INNER JOIN brokerages as b
 	ON b.id = o.brokerage_id
-	WHERE o.id = $1 and user_uid = $2;
+	INNER JOIN brokerage_accounts as ba
+	ON ba.id = o.account_id
+	WHERE o.id = $1 and o.user_uid = $2;
 	`)
 
 	columns := []string{"id", "quantity", "price", "date", "order_type",
-		"currency", "brokerage", "asset"}
+		"currency", "brokerage", "account", "asset"}
 
 	mock, err := pgxmock.NewConn()
 	if err != nil {
@@ -575,7 +648,7 @@ func TestSearchByOrderAndUserId(t *testing.T) {
 	rows := mock.NewRows(columns)
 	mock.ExpectQuery(query).WithArgs("3e3e3e3w-ed8b-11eb-9a03-0242ac130003",
 		userUid).WillReturnRows(rows.AddRow("3e3e3e3w-ed8b-11eb-9a03-0242ac130003",
-		20.0, 20.29, tr, "buy", "USD", &brokerageInfo, &assetInfo))
+		20.0, 20.29, tr, "buy", "USD", &brokerageInfo, &accountInfo, &assetInfo))
 
 	Orders := OrderPostgres{dbpool: mock}
 	orderInfo, _ := Orders.SearchByOrderAndUserId("3e3e3e3w-ed8b-11eb-9a03-0242ac130003",
@@ -588,3 +661,454 @@ func TestSearchByOrderAndUserId(t *testing.T) {
 	assert.NotNil(t, orderInfo)
 	assert.Equal(t, expectedOrderInfo, orderInfo)
 }
+
+func TestOrderCreateBulk(t *testing.T) {
+	tr, _ := time.Parse("2006-01-02", "2021-10-05")
+	settlement, _ := time.Parse("2006-01-02", "2021-10-07")
+	userUid := "aa48fafh4"
+
+	brokerageInfo := entity.Brokerage{
+		Id:      "55555555-ed8b-11eb-9a03-0242ac130003",
+		Name:    "Clear",
+		Country: "BR",
+	}
+
+	assetInfo := entity.Asset{
+		Id:       "1111BBBB-ed8b-11eb-9a03-0242ac130003",
+		Symbol:   "ITUB4",
+		Fullname: "Itau Unibanco Holding S.A.",
+	}
+
+	accountInfo := entity.BrokerageAccount{
+		Id:       "66666666-ed8b-11eb-9a03-0242ac130003",
+		Nickname: "Clear",
+	}
+
+	ordersInsert := []entity.Order{
+		{
+			Asset:          &assetInfo,
+			Account:        &accountInfo,
+			Quantity:       100,
+			Price:          25.50,
+			Currency:       "BRL",
+			OrderType:      "buy",
+			Date:           tr,
+			Fees:           4.90,
+			WithheldTax:    0.01,
+			SettlementDate: &settlement,
+			UserUid:        userUid,
+		},
+	}
+
+	expectedOrdersReturn := []entity.Order{
+		{
+			Id:             "a8a8a8a8-ed8b-11eb-9a03-0242ac130003",
+			Quantity:       100,
+			Price:          25.50,
+			Currency:       "BRL",
+			OrderType:      "buy",
+			Date:           tr,
+			Fees:           4.90,
+			WithheldTax:    0.01,
+			SettlementDate: &settlement,
+			Brokerage:      &brokerageInfo,
+			Account:        &accountInfo,
+			Asset:          &assetInfo,
+		},
+	}
+
+	insertAssetUser := regexp.QuoteMeta(`
+	INSERT INTO
+		asset_users(asset_id, user_uid)
+	VALUES ($1, $2)
+	ON CONFLICT (asset_id, user_uid) DO NOTHING;
+	`)
+
+	insertRow := regexp.QuoteMeta(`
+	WITH inserted as (
+		INSERT INTO
+			orders(quantity, price, currency, order_type, date, fees,
+				withheld_tax, settlement_date, asset_id, account_id,
+				brokerage_id, user_uid
+			)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, (
+			SELECT ba.brokerage_id
+			FROM brokerage_accounts as ba
+			WHERE ba.id = $10 and ba.user_uid = $11
+		), $11)
+		RETURNING id, quantity, price, currency, order_type, date, fees,
+			withheld_tax, settlement_date, asset_id, account_id, brokerage_id
+	)
+	SELECT
+		inserted.id, inserted.quantity, inserted.price, inserted.currency,
+		inserted.order_type, inserted.date, inserted.fees,
+		inserted.withheld_tax, inserted.settlement_date,
+		json_build_object(
+			'id', b.id,
+			'name', b.name,
+			'country', b.country
+		) as brokerage,
+		json_build_object(
+			'id', ba.id,
+			'nickname', ba.nickname
+		) as account,
+		json_build_object(
+			'id', a.id,
+			'symbol', a.symbol,
+			'preference', a.preference,
+			'fullname', a.fullname
+		) as asset
+	FROM inserted
+	INNER JOIN brokerages as b
+	ON inserted.brokerage_id = b.id
+	INNER JOIN brokerage_accounts as ba
+	ON inserted.account_id = ba.id
+	INNER JOIN assets as a
+	ON inserted.asset_id = a.id;
+	`)
+
+	columns := []string{"id", "quantity", "price", "currency", "order_type",
+		"date", "fees", "withheld_tax", "settlement_date", "brokerage", "account",
+		"asset"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	mock.ExpectBegin()
+	mock.ExpectExec(insertAssetUser).WithArgs(assetInfo.Id, userUid).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(insertRow).WithArgs(100.0, 25.50, "BRL", "buy", tr, 4.90,
+		0.01, &settlement, assetInfo.Id, accountInfo.Id, userUid).
+		WillReturnRows(rows.AddRow("a8a8a8a8-ed8b-11eb-9a03-0242ac130003", 100.0,
+			25.50, "BRL", "buy", tr, 4.90, 0.01, &settlement, &brokerageInfo,
+			&accountInfo, &assetInfo))
+	mock.ExpectCommit()
+
+	Orders := OrderPostgres{dbpool: mock}
+	ordersReturn, err := Orders.CreateBulk(ordersInsert)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedOrdersReturn, ordersReturn)
+
+	// An order of an account from another user rolls back the import
+	mock.ExpectBegin()
+	mock.ExpectExec(insertAssetUser).WithArgs(assetInfo.Id, userUid).
+		WillReturnResult(pgxmock.NewResult("INSERT", 0))
+	mock.ExpectQuery(insertRow).WithArgs(100.0, 25.50, "BRL", "buy", tr, 4.90,
+		0.01, &settlement, assetInfo.Id, accountInfo.Id, userUid).
+		WillReturnError(errors.New("null value in column \"brokerage_id\""))
+	mock.ExpectRollback()
+
+	ordersReturn, err = Orders.CreateBulk(ordersInsert)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.NotNil(t, err)
+	assert.Nil(t, ordersReturn)
+}
+
+func TestOrderCreateImport(t *testing.T) {
+	tr, _ := time.Parse("2006-01-02", "2021-10-05")
+	userUid := "aa48fafh4"
+
+	brokerageInfo := entity.Brokerage{
+		Id:      "55555555-ed8b-11eb-9a03-0242ac130003",
+		Name:    "Avenue",
+		Country: "US",
+	}
+
+	assetInfo := entity.Asset{
+		Id:     "1111BBBB-ed8b-11eb-9a03-0242ac130003",
+		Symbol: "AAPL",
+	}
+
+	accountInfo := entity.BrokerageAccount{
+		Id:       "66666666-ed8b-11eb-9a03-0242ac130003",
+		Nickname: "Avenue",
+	}
+
+	assetsInsert := []entity.Asset{
+		{
+			Symbol:        "AAPL",
+			Fullname:      "Apple Inc",
+			AssetType:     &entity.AssetType{Id: "28ccf27a-ed8b-11eb-9a03-0242ac130003"},
+			Sector:        &entity.Sector{Id: "83ae92f8-ed8b-11eb-9a03-0242ac130003"},
+			SectorMapping: &entity.SectorMapping{Id: "77777777-ed8b-11eb-9a03-0242ac130003"},
+		},
+	}
+
+	accountsInsert := []entity.BrokerageAccount{
+		{
+			Nickname:  "Avenue",
+			Brokerage: &entity.Brokerage{Id: brokerageInfo.Id},
+			UserUid:   userUid,
+		},
+	}
+
+	ordersInsert := []entity.Order{
+		{
+			Asset:     &entity.Asset{Symbol: "AAPL"},
+			Account:   &accountsInsert[0],
+			Quantity:  2,
+			Price:     150,
+			Currency:  "USD",
+			OrderType: "buy",
+			Date:      tr,
+			UserUid:   userUid,
+		},
+	}
+
+	earningsInsert := []entity.Earnings{
+		{
+			Type:        "Dividendos",
+			Earning:     0.44,
+			WithheldTax: 0.13,
+			Currency:    "USD",
+			Date:        tr,
+			Asset:       &entity.Asset{Symbol: "AAPL"},
+			UserUid:     userUid,
+		},
+	}
+
+	expectedOrdersReturn := []entity.Order{
+		{
+			Id:        "a8a8a8a8-ed8b-11eb-9a03-0242ac130003",
+			Quantity:  2,
+			Price:     150,
+			Currency:  "USD",
+			OrderType: "buy",
+			Date:      tr,
+			Brokerage: &brokerageInfo,
+			Account:   &accountInfo,
+			Asset:     &assetInfo,
+		},
+	}
+
+	expectedEarningsReturn := []entity.Earnings{
+		{
+			Id:          "akxn-1234",
+			Type:        "Dividendos",
+			Earning:     0.44,
+			WithheldTax: 0.13,
+			Currency:    "USD",
+			Date:        tr,
+			UserUid:     userUid,
+			Asset:       &assetInfo,
+		},
+	}
+
+	insertAsset := regexp.QuoteMeta(`
+	INSERT INTO
+		assets(preference, fullname, symbol, asset_type_id, sector_id,`)
+
+	insertAccount := regexp.QuoteMeta(`
+	INSERT INTO
+		brokerage_accounts(nickname, brokerage_id, user_uid)`)
+
+	insertAssetUser := regexp.QuoteMeta(`
+	INSERT INTO
+		asset_users(asset_id, user_uid)`)
+
+	insertOrder := regexp.QuoteMeta(`
+	WITH inserted as (
+		INSERT INTO
+			orders(quantity, price, currency, order_type, date, fees,`)
+
+	insertEarning := regexp.QuoteMeta(`
+	WITH inserted as (
+	INSERT INTO
+		earnings("type", earning, withheld_tax, "date", currency, asset_id,`)
+
+	orderColumns := []string{"id", "quantity", "price", "currency",
+		"order_type", "date", "fees", "withheld_tax", "settlement_date",
+		"brokerage", "account", "asset"}
+
+	earningColumns := []string{"id", "type", "earning", "withheld_tax",
+		"date", "currency", "user_uid", "asset"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	var preference *string
+	var settlementDate *time.Time
+	sectorMappingId := &assetsInsert[0].SectorMapping.Id
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(insertAsset).WithArgs(preference, "Apple Inc", "AAPL",
+		assetsInsert[0].AssetType.Id, assetsInsert[0].Sector.Id,
+		sectorMappingId).
+		WillReturnRows(mock.NewRows([]string{"id"}).AddRow(assetInfo.Id))
+	mock.ExpectQuery(insertAccount).WithArgs("Avenue", brokerageInfo.Id,
+		userUid).
+		WillReturnRows(mock.NewRows([]string{"id"}).AddRow(accountInfo.Id))
+	mock.ExpectExec(insertAssetUser).WithArgs(assetInfo.Id, userUid).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectQuery(insertOrder).WithArgs(2.0, 150.0, "USD", "buy", tr, 0.0,
+		0.0, settlementDate, assetInfo.Id, accountInfo.Id, userUid).
+		WillReturnRows(mock.NewRows(orderColumns).AddRow(
+			"a8a8a8a8-ed8b-11eb-9a03-0242ac130003", 2.0, 150.0, "USD", "buy",
+			tr, 0.0, 0.0, settlementDate, &brokerageInfo, &accountInfo,
+			&assetInfo))
+	mock.ExpectQuery(insertEarning).WithArgs([]string{"Dividendos"},
+		[]float64{0.44}, []float64{0.13}, []time.Time{tr}, []string{"USD"},
+		[]string{assetInfo.Id}, []string{userUid}).
+		WillReturnRows(mock.NewRows(earningColumns).AddRow("akxn-1234",
+			"Dividendos", 0.44, 0.13, tr, "USD", userUid, &assetInfo))
+	mock.ExpectCommit()
+
+	Orders := OrderPostgres{dbpool: mock}
+	ordersReturn, earningsReturn, err := Orders.CreateImport(assetsInsert,
+		accountsInsert, ordersInsert, earningsInsert)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedOrdersReturn, ordersReturn)
+	assert.Equal(t, expectedEarningsReturn, earningsReturn)
+	assert.Equal(t, "", ordersInsert[0].Asset.Id)
+	assert.Equal(t, "", ordersInsert[0].Account.Id)
+
+	// An earning that fails rolls back the assets, the accounts and the orders
+	// already inserted
+	mock.ExpectBegin()
+	mock.ExpectQuery(insertAsset).WithArgs(preference, "Apple Inc", "AAPL",
+		assetsInsert[0].AssetType.Id, assetsInsert[0].Sector.Id,
+		sectorMappingId).
+		WillReturnRows(mock.NewRows([]string{"id"}).AddRow(assetInfo.Id))
+	mock.ExpectQuery(insertAccount).WithArgs("Avenue", brokerageInfo.Id,
+		userUid).
+		WillReturnRows(mock.NewRows([]string{"id"}).AddRow(accountInfo.Id))
+	mock.ExpectExec(insertAssetUser).WithArgs(assetInfo.Id, userUid).
+		WillReturnResult(pgxmock.NewResult("INSERT", 0))
+	mock.ExpectQuery(insertOrder).WithArgs(2.0, 150.0, "USD", "buy", tr, 0.0,
+		0.0, settlementDate, assetInfo.Id, accountInfo.Id, userUid).
+		WillReturnRows(mock.NewRows(orderColumns).AddRow(
+			"a8a8a8a8-ed8b-11eb-9a03-0242ac130003", 2.0, 150.0, "USD", "buy",
+			tr, 0.0, 0.0, settlementDate, &brokerageInfo, &accountInfo,
+			&assetInfo))
+	mock.ExpectQuery(insertEarning).WithArgs([]string{"Dividendos"},
+		[]float64{0.44}, []float64{0.13}, []time.Time{tr}, []string{"USD"},
+		[]string{assetInfo.Id}, []string{userUid}).
+		WillReturnError(errors.New("invalid input syntax for type uuid"))
+	mock.ExpectRollback()
+
+	ordersReturn, earningsReturn, err = Orders.CreateImport(assetsInsert,
+		accountsInsert, ordersInsert, earningsInsert)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.NotNil(t, err)
+	assert.Nil(t, ordersReturn)
+	assert.Nil(t, earningsReturn)
+}
+
+func TestOrderSearchFromUser(t *testing.T) {
+	tr := entity.StringToTime("2021-10-05")
+	settlement := entity.StringToTime("2021-10-07")
+	userUid := "aji392a"
+
+	brokerage := entity.Brokerage{
+		Id:      "55555555-ed8b-11eb-9a03-0242ac130003",
+		Name:    "Test Brokerage",
+		Country: "US",
+	}
+
+	account := entity.BrokerageAccount{
+		Id:       "66666666-ed8b-11eb-9a03-0242ac130003",
+		Nickname: "Test Account",
+	}
+
+	asset := entity.Asset{
+		Id:     "0a52d206-ed8b-11eb-9a03-0242ac130003",
+		Symbol: "AAPL",
+	}
+
+	expectedOrderReturn := []entity.Order{
+		{
+			Id:             "a8a8a8a8-ed8b-11eb-9a03-0242ac130003",
+			Quantity:       0.5,
+			Price:          141.20,
+			Currency:       "USD",
+			OrderType:      "buy",
+			Date:           tr,
+			Fees:           0.52,
+			SettlementDate: &settlement,
+			Brokerage:      &brokerage,
+			Account:        &account,
+			Asset:          &asset,
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	SELECT
+		o.id, quantity, price, o.currency, order_type, date, fees,
+		withheld_tax, settlement_date,
+		json_build_object(
+			'id', b.id,
+			'name', b."name",
+			'country', b.country
+		) as brokerage,
+		json_build_object(
+			'id', ba.id,
+			'nickname', ba.nickname
+		) as account,
+		json_build_object(
+			'id', a.id,
+			'symbol', a.symbol
+		) as asset
+	FROM orders as o
+	INNER JOIN brokerages as b
+	ON b.id = o.brokerage_id
+	INNER JOIN brokerage_accounts as ba
+	ON ba.id = o.account_id
+	INNER JOIN assets as a
+	ON a.id = o.asset_id
+	WHERE o.user_uid = $1
+	ORDER BY date, a.symbol;
+	`)
+
+	columns := []string{"id", "quantity", "price", "currency", "order_type",
+		"date", "fees", "withheld_tax", "settlement_date", "brokerage",
+		"account", "asset"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(query).WithArgs(userUid).WillReturnRows(
+		rows.AddRow(expectedOrderReturn[0].Id, expectedOrderReturn[0].Quantity,
+			expectedOrderReturn[0].Price, expectedOrderReturn[0].Currency,
+			expectedOrderReturn[0].OrderType, expectedOrderReturn[0].Date,
+			expectedOrderReturn[0].Fees, expectedOrderReturn[0].WithheldTax,
+			&settlement, &brokerage, &account, &asset))
+
+	Orders := OrderPostgres{dbpool: mock}
+	ordersReturn, err := Orders.SearchFromUser(userUid)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedOrderReturn, ordersReturn)
+}
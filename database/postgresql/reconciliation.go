@@ -0,0 +1,53 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"stockfyApi/entity"
+	"time"
+
+	"github.com/georgysavva/scany/pgxscan"
+)
+
+type ReconciliationPostgres struct {
+	dbpool PgxIface
+}
+
+func NewReconciliationPostgres(db PgxIface) *ReconciliationPostgres {
+	return &ReconciliationPostgres{
+		dbpool: db,
+	}
+}
+
+// SearchPositionsAtDate returns the quantity of each asset of the user in the
+// accounts of a brokerage computed from the orders until the date, inclusive.
+func (r *ReconciliationPostgres) SearchPositionsAtDate(brokerageId string,
+	date time.Time, userUid string) ([]entity.AccountPosition, error) {
+
+	var positions []entity.AccountPosition
+
+	query := `
+	SELECT
+		b.id as brokerage_id, b."name" as brokerage_name, a.id as asset_id,
+		a.symbol, SUM(o.quantity) as quantity
+	FROM orders as o
+	INNER JOIN brokerage_accounts as ba
+	ON ba.id = o.account_id
+	INNER JOIN brokerages as b
+	ON b.id = ba.brokerage_id
+	INNER JOIN assets as a
+	ON a.id = o.asset_id
+	WHERE o.user_uid = $1 AND b.id = $2 AND o.date <= $3
+	GROUP BY b.id, b."name", a.id, a.symbol
+	HAVING SUM(o.quantity) <> 0
+	ORDER BY a.symbol;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &positions,
+		query, userUid, brokerageId, date)
+	if err != nil {
+		fmt.Println("entity.SearchPositionsAtDate: ", err)
+	}
+
+	return positions, err
+}
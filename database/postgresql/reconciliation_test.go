@@ -0,0 +1,68 @@
+package postgresql
+
+import (
+	"context"
+	"regexp"
+	"stockfyApi/entity"
+	"testing"
+
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconciliationSearchPositionsAtDate(t *testing.T) {
+	brokerageId := "55555555-ed8b-11eb-9a03-0242ac130003"
+	date := entity.StringToTime("2021-12-30")
+
+	expectedPositions := []entity.AccountPosition{
+		{
+			BrokerageId:   brokerageId,
+			BrokerageName: "Clear",
+			AssetId:       "1111BBBB-ed8b-11eb-9a03-0242ac130003",
+			Symbol:        "ITUB4",
+			Quantity:      20,
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	SELECT
+		b.id as brokerage_id, b."name" as brokerage_name, a.id as asset_id,
+		a.symbol, SUM(o.quantity) as quantity
+	FROM orders as o
+	INNER JOIN brokerage_accounts as ba
+	ON ba.id = o.account_id
+	INNER JOIN brokerages as b
+	ON b.id = ba.brokerage_id
+	INNER JOIN assets as a
+	ON a.id = o.asset_id
+	WHERE o.user_uid = $1 AND b.id = $2 AND o.date <= $3
+	GROUP BY b.id, b."name", a.id, a.symbol
+	HAVING SUM(o.quantity) <> 0
+	ORDER BY a.symbol;
+	`)
+
+	columns := []string{"brokerage_id", "brokerage_name", "asset_id", "symbol",
+		"quantity"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(query).WithArgs("TestUserUID", brokerageId, date).
+		WillReturnRows(rows.AddRow(brokerageId, "Clear",
+			"1111BBBB-ed8b-11eb-9a03-0242ac130003", "ITUB4", 20.0))
+
+	Reconciliation := ReconciliationPostgres{dbpool: mock}
+	positions, err := Reconciliation.SearchPositionsAtDate(brokerageId, date,
+		"TestUserUID")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedPositions, positions)
+}
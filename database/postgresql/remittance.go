@@ -0,0 +1,110 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"stockfyApi/entity"
+
+	"github.com/georgysavva/scany/pgxscan"
+)
+
+type RemittancePostgres struct {
+	dbpool PgxIface
+}
+
+func NewRemittancePostgres(db PgxIface) *RemittancePostgres {
+	return &RemittancePostgres{
+		dbpool: db,
+	}
+}
+
+func (r *RemittancePostgres) Create(remittance entity.Remittance) (
+	[]entity.Remittance, error) {
+
+	var remittanceReturn []entity.Remittance
+
+	var fromAccountId interface{}
+	if remittance.FromAccount != nil {
+		fromAccountId = remittance.FromAccount.Id
+	}
+
+	query := `
+	INSERT INTO
+		remittances(user_uid, from_account_id, to_account_id, sent_amount,
+			sent_currency, received_amount, received_currency, iof, fees,
+			commercial_rate, "date")
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	RETURNING id, sent_amount, sent_currency, received_amount,
+		received_currency, iof, fees, commercial_rate, "date", user_uid;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &remittanceReturn,
+		query, remittance.UserUid, fromAccountId, remittance.ToAccount.Id,
+		remittance.SentAmount, remittance.SentCurrency,
+		remittance.ReceivedAmount, remittance.ReceivedCurrency, remittance.Iof,
+		remittance.Fees, remittance.CommercialRate, remittance.Date)
+	if err != nil {
+		fmt.Println("entity.CreateRemittance: ", err)
+	}
+
+	return remittanceReturn, err
+}
+
+// SearchFromUser returns all the remittances of the user, from the oldest to
+// the newest. The account it was sent from is null when not informed.
+func (r *RemittancePostgres) SearchFromUser(userUid string) (
+	[]entity.Remittance, error) {
+
+	var remittancesReturn []entity.Remittance
+
+	query := `
+	SELECT
+		r.id, r.sent_amount, r.sent_currency, r.received_amount,
+		r.received_currency, r.iof, r.fees, r.commercial_rate, r."date",
+		r.user_uid,
+		CASE WHEN fa.id IS NULL THEN NULL ELSE json_build_object(
+			'id', fa.id,
+			'nickname', fa.nickname
+		) END as from_account,
+		json_build_object(
+			'id', ta.id,
+			'nickname', ta.nickname
+		) as to_account
+	FROM remittances as r
+	LEFT JOIN brokerage_accounts as fa
+	ON fa.id = r.from_account_id
+	INNER JOIN brokerage_accounts as ta
+	ON ta.id = r.to_account_id
+	WHERE r.user_uid = $1
+	ORDER BY r."date", r.created_at;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &remittancesReturn,
+		query, userUid)
+	if err != nil {
+		fmt.Println("entity.SearchRemittancesFromUser: ", err)
+	}
+
+	return remittancesReturn, err
+}
+
+func (r *RemittancePostgres) Delete(remittanceId string, userUid string) (
+	[]entity.Remittance, error) {
+
+	var remittanceReturn []entity.Remittance
+
+	query := `
+	DELETE FROM remittances
+	WHERE id = $1 AND user_uid = $2
+	RETURNING id, sent_amount, sent_currency, received_amount,
+		received_currency, iof, fees, commercial_rate, "date", user_uid;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &remittanceReturn,
+		query, remittanceId, userUid)
+	if err != nil {
+		fmt.Println("entity.DeleteRemittance: ", err)
+	}
+
+	return remittanceReturn, err
+}
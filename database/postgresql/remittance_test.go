@@ -0,0 +1,203 @@
+package postgresql
+
+import (
+	"context"
+	"regexp"
+	"stockfyApi/entity"
+	"testing"
+
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemittanceCreate(t *testing.T) {
+	date := entity.StringToTime("2021-10-01")
+
+	remittance := entity.Remittance{
+		SentAmount:       5066,
+		SentCurrency:     "BRL",
+		ReceivedAmount:   1000,
+		ReceivedCurrency: "USD",
+		Iof:              55,
+		Fees:             11,
+		CommercialRate:   4.9,
+		Date:             date,
+		FromAccount:      &entity.BrokerageAccount{Id: "TestAccountID1"},
+		ToAccount:        &entity.BrokerageAccount{Id: "TestAccountID2"},
+		UserUid:          "TestUserUID",
+	}
+
+	expectedRemittance := []entity.Remittance{
+		{
+			Id:               "TestRemittanceID",
+			SentAmount:       5066,
+			SentCurrency:     "BRL",
+			ReceivedAmount:   1000,
+			ReceivedCurrency: "USD",
+			Iof:              55,
+			Fees:             11,
+			CommercialRate:   4.9,
+			Date:             date,
+			UserUid:          "TestUserUID",
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	INSERT INTO
+		remittances(user_uid, from_account_id, to_account_id, sent_amount,
+			sent_currency, received_amount, received_currency, iof, fees,
+			commercial_rate, "date")
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	RETURNING id, sent_amount, sent_currency, received_amount,
+		received_currency, iof, fees, commercial_rate, "date", user_uid;
+	`)
+
+	columns := []string{"id", "sent_amount", "sent_currency",
+		"received_amount", "received_currency", "iof", "fees",
+		"commercial_rate", "date", "user_uid"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(query).WithArgs("TestUserUID", "TestAccountID1",
+		"TestAccountID2", 5066.0, "BRL", 1000.0, "USD", 55.0, 11.0, 4.9, date).
+		WillReturnRows(rows.AddRow("TestRemittanceID", 5066.0, "BRL", 1000.0,
+			"USD", 55.0, 11.0, 4.9, date, "TestUserUID"))
+
+	Remittances := RemittancePostgres{dbpool: mock}
+	remittanceCreated, err := Remittances.Create(remittance)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedRemittance, remittanceCreated)
+}
+
+func TestRemittanceSearchFromUser(t *testing.T) {
+	expectedRemittances := []entity.Remittance{
+		{
+			Id:               "TestRemittanceID1",
+			SentAmount:       5066,
+			SentCurrency:     "BRL",
+			ReceivedAmount:   1000,
+			ReceivedCurrency: "USD",
+			Iof:              55,
+			Fees:             11,
+			CommercialRate:   4.9,
+			Date:             entity.StringToTime("2021-10-01"),
+			UserUid:          "TestUserUID",
+			FromAccount: &entity.BrokerageAccount{
+				Id:       "TestAccountID1",
+				Nickname: "Clear",
+			},
+			ToAccount: &entity.BrokerageAccount{
+				Id:       "TestAccountID2",
+				Nickname: "Avenue",
+			},
+		},
+		{
+			Id:               "TestRemittanceID2",
+			SentAmount:       2750,
+			SentCurrency:     "BRL",
+			ReceivedAmount:   500,
+			ReceivedCurrency: "USD",
+			Date:             entity.StringToTime("2021-11-01"),
+			UserUid:          "TestUserUID",
+			ToAccount: &entity.BrokerageAccount{
+				Id:       "TestAccountID2",
+				Nickname: "Avenue",
+			},
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	SELECT
+		r.id, r.sent_amount, r.sent_currency, r.received_amount,
+		r.received_currency, r.iof, r.fees, r.commercial_rate, r."date",
+		r.user_uid,`)
+
+	columns := []string{"id", "sent_amount", "sent_currency",
+		"received_amount", "received_currency", "iof", "fees",
+		"commercial_rate", "date", "user_uid", "from_account", "to_account"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	for _, remittance := range expectedRemittances {
+		rows.AddRow(remittance.Id, remittance.SentAmount,
+			remittance.SentCurrency, remittance.ReceivedAmount,
+			remittance.ReceivedCurrency, remittance.Iof, remittance.Fees,
+			remittance.CommercialRate, remittance.Date, remittance.UserUid,
+			remittance.FromAccount, remittance.ToAccount)
+	}
+	mock.ExpectQuery(query).WithArgs("TestUserUID").WillReturnRows(rows)
+
+	Remittances := RemittancePostgres{dbpool: mock}
+	remittances, err := Remittances.SearchFromUser("TestUserUID")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedRemittances, remittances)
+}
+
+func TestRemittanceDelete(t *testing.T) {
+	date := entity.StringToTime("2021-10-01")
+
+	expectedRemittance := []entity.Remittance{
+		{
+			Id:               "TestRemittanceID",
+			SentAmount:       5066,
+			SentCurrency:     "BRL",
+			ReceivedAmount:   1000,
+			ReceivedCurrency: "USD",
+			Date:             date,
+			UserUid:          "TestUserUID",
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	DELETE FROM remittances
+	WHERE id = $1 AND user_uid = $2
+	RETURNING id, sent_amount, sent_currency, received_amount,
+		received_currency, iof, fees, commercial_rate, "date", user_uid;
+	`)
+
+	columns := []string{"id", "sent_amount", "sent_currency",
+		"received_amount", "received_currency", "iof", "fees",
+		"commercial_rate", "date", "user_uid"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(query).WithArgs("TestRemittanceID", "TestUserUID").
+		WillReturnRows(rows.AddRow("TestRemittanceID", 5066.0, "BRL", 1000.0,
+			"USD", 0.0, 0.0, 0.0, date, "TestUserUID"))
+
+	Remittances := RemittancePostgres{dbpool: mock}
+	remittanceDeleted, err := Remittances.Delete("TestRemittanceID",
+		"TestUserUID")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedRemittance, remittanceDeleted)
+}
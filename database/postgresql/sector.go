@@ -7,6 +7,7 @@ import (
 	"stockfyApi/entity"
 
 	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
 	_ "github.com/lib/pq"
 )
 
@@ -20,12 +21,14 @@ func NewSectorPostgres(db PgxIface) *SectorPostgres {
 	}
 }
 
-func (r *SectorPostgres) Create(sector string) ([]entity.Sector, error) {
+// Create returns the node of the taxonomy with the same name and parent,
+// creating it when it does not exist yet.
+func (r *SectorPostgres) Create(sector entity.Sector) ([]entity.Sector, error) {
 
 	var sectorInfo []entity.Sector
 	var err error
 
-	if sector == "" {
+	if sector.Name == "" {
 		err = errors.New("CreateSector: Impossible to create a blank sector")
 		return nil, err
 	}
@@ -33,28 +36,28 @@ func (r *SectorPostgres) Create(sector string) ([]entity.Sector, error) {
 	var sectorQuery = `
 	WITH s as (
 		SELECT
-			id, name
+			id, name, "level", code, parent_id
 		FROM sectors
-		WHERE name=$1
+		WHERE name=$1 AND parent_id IS NOT DISTINCT FROM $4::uuid
 	), i as (
 		INSERT INTO
-			sectors(name)
-		SELECT $1
+			sectors(name, "level", code, parent_id)
+		SELECT $1, $2, $3, $4::uuid
 		WHERE NOT EXISTS (SELECT 1 FROM s)
-		returning id, name
+		returning id, name, "level", code, parent_id
 	)
 	SELECT
-		id, name from i
+		id, name, "level", code, parent_id from i
 	UNION ALL
 	SELECT
-		id, name
+		id, name, "level", code, parent_id
 	from s;
 	`
 
 	err = pgxscan.Select(context.Background(), r.dbpool, &sectorInfo,
-		sectorQuery, sector)
+		sectorQuery, sector.Name, sector.Level, sector.Code, sector.ParentId)
 	if err != nil {
-		panic(err)
+		fmt.Println("entity.CreateSector: ", err)
 	}
 
 	return sectorInfo, err
@@ -66,9 +69,10 @@ func (r *SectorPostgres) SearchByName(sector string) ([]entity.Sector, error) {
 
 	query := `
 	SELECT
-		id, name
+		id, name, "level", code, parent_id
 	FROM sectors
 	WHERE name = $1
+	ORDER BY code;
 	`
 
 	err := pgxscan.Select(context.Background(), r.dbpool, &sectorQuery,
@@ -80,6 +84,309 @@ func (r *SectorPostgres) SearchByName(sector string) ([]entity.Sector, error) {
 	return sectorQuery, err
 }
 
+func (r *SectorPostgres) SearchById(sectorId string) ([]entity.Sector, error) {
+
+	var sectorQuery []entity.Sector
+
+	query := `
+	SELECT
+		id, name, "level", code, parent_id
+	FROM sectors
+	WHERE id = $1;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &sectorQuery,
+		query, sectorId)
+	if err != nil {
+		fmt.Println("entity.SearchSectorById: ", err)
+	}
+
+	return sectorQuery, err
+}
+
+// SearchAll returns every node of the taxonomy, ordered by the code, so the
+// parents come before their children.
+func (r *SectorPostgres) SearchAll() ([]entity.Sector, error) {
+
+	var sectorQuery []entity.Sector
+
+	query := `
+	SELECT
+		id, name, "level", code, parent_id
+	FROM sectors
+	ORDER BY code, name;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &sectorQuery,
+		query)
+	if err != nil {
+		fmt.Println("entity.SearchSectors: ", err)
+	}
+
+	return sectorQuery, err
+}
+
+func (r *SectorPostgres) Update(sectorId string, name string,
+	auditLog *entity.AuditLog) ([]entity.Sector, error) {
+
+	var sectorQuery []entity.Sector
+
+	query := `
+	UPDATE sectors
+	SET name = $2
+	WHERE id = $1
+	RETURNING id, name, "level", code, parent_id;
+	`
+
+	err := auditChange(r.dbpool, auditLog, func(tx pgx.Tx) (string,
+		interface{}, error) {
+
+		err := pgxscan.Select(context.Background(), tx, &sectorQuery, query,
+			sectorId, name)
+		if err != nil || len(sectorQuery) == 0 {
+			return "", nil, err
+		}
+
+		return sectorQuery[0].Id, sectorQuery[0], nil
+	})
+	if err != nil {
+		fmt.Println("entity.UpdateSector: ", err)
+		return nil, err
+	}
+
+	return sectorQuery, err
+}
+
+// Merge moves the assets, the label mappings and the children of the source
+// sector to the target sector and deletes the source sector in a single
+// statement, so the foreign keys are only verified after every move.
+func (r *SectorPostgres) Merge(sourceId string, targetId string,
+	auditLog *entity.AuditLog) ([]entity.Sector, error) {
+
+	var sectorQuery []entity.Sector
+
+	query := `
+	WITH moved_assets as (
+		UPDATE assets
+		SET sector_id = $2
+		WHERE sector_id = $1
+		RETURNING id
+	), moved_mappings as (
+		UPDATE sector_mappings
+		SET sector_id = $2
+		WHERE sector_id = $1
+		RETURNING id
+	), moved_children as (
+		UPDATE sectors
+		SET parent_id = $2
+		WHERE parent_id = $1
+		RETURNING id
+	), deleted as (
+		DELETE FROM sectors
+		WHERE id = $1
+		RETURNING id
+	)
+	SELECT
+		id, name, "level", code, parent_id
+	FROM sectors
+	WHERE id = $2 AND EXISTS (SELECT 1 FROM deleted);
+	`
+
+	err := auditChange(r.dbpool, auditLog, func(tx pgx.Tx) (string,
+		interface{}, error) {
+
+		err := pgxscan.Select(context.Background(), tx, &sectorQuery, query,
+			sourceId, targetId)
+		if err != nil || len(sectorQuery) == 0 {
+			return "", nil, err
+		}
+
+		return sourceId, sectorQuery[0], nil
+	})
+	if err != nil {
+		fmt.Println("entity.MergeSector: ", err)
+		return nil, err
+	}
+
+	return sectorQuery, err
+}
+
+// CreateMapping returns the mapping of the provider label, storing the label
+// without a sector when it was never seen before.
+func (r *SectorPostgres) CreateMapping(provider string, label string) (
+	[]entity.SectorMapping, error) {
+
+	var mappingInfo []entity.SectorMapping
+
+	query := `
+	WITH m as (
+		SELECT
+			id, provider, label, sector_id
+		FROM sector_mappings
+		WHERE provider=$1 AND label=$2
+	), i as (
+		INSERT INTO
+			sector_mappings(provider, label)
+		SELECT $1, $2
+		WHERE NOT EXISTS (SELECT 1 FROM m)
+		returning id, provider, label, sector_id
+	), mapping as (
+		SELECT
+			id, provider, label, sector_id from i
+		UNION ALL
+		SELECT
+			id, provider, label, sector_id from m
+	)
+	SELECT
+		mapping.id, mapping.provider, mapping.label,
+		CASE WHEN s.id IS NULL THEN NULL ELSE json_build_object(
+			'id', s.id,
+			'name', s.name,
+			'level', s."level",
+			'code', s.code,
+			'parentId', s.parent_id
+		) END as sector
+	FROM mapping
+	LEFT JOIN sectors as s
+	ON s.id = mapping.sector_id;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &mappingInfo,
+		query, provider, label)
+	if err != nil {
+		fmt.Println("entity.CreateSectorMapping: ", err)
+	}
+
+	return mappingInfo, err
+}
+
+func (r *SectorPostgres) SearchMappings(provider string, unmappedOnly bool) (
+	[]entity.SectorMapping, error) {
+
+	var mappingInfo []entity.SectorMapping
+
+	query := `
+	SELECT
+		sm.id, sm.provider, sm.label,
+		CASE WHEN s.id IS NULL THEN NULL ELSE json_build_object(
+			'id', s.id,
+			'name', s.name,
+			'level', s."level",
+			'code', s.code,
+			'parentId', s.parent_id
+		) END as sector
+	FROM sector_mappings as sm
+	LEFT JOIN sectors as s
+	ON s.id = sm.sector_id
+	WHERE ($1 = '' OR sm.provider = $1)
+		AND (NOT $2::boolean OR sm.sector_id IS NULL)
+	ORDER BY sm.provider, sm.label;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &mappingInfo,
+		query, provider, unmappedOnly)
+	if err != nil {
+		fmt.Println("entity.SearchSectorMappings: ", err)
+	}
+
+	return mappingInfo, err
+}
+
+// UpdateMapping maps the label to the sector and moves the assets classified
+// by this label to the new sector.
+func (r *SectorPostgres) UpdateMapping(mappingId string, sectorId string) (
+	[]entity.SectorMapping, error) {
+
+	var mappingInfo []entity.SectorMapping
+
+	query := `
+	WITH updated as (
+		UPDATE sector_mappings
+		SET sector_id = $2
+		WHERE id = $1
+		RETURNING id, provider, label, sector_id
+	), reclassified as (
+		UPDATE assets
+		SET sector_id = $2
+		WHERE sector_mapping_id IN (SELECT id FROM updated)
+	)
+	SELECT
+		updated.id, updated.provider, updated.label,
+		json_build_object(
+			'id', s.id,
+			'name', s.name,
+			'level', s."level",
+			'code', s.code,
+			'parentId', s.parent_id
+		) as sector
+	FROM updated
+	INNER JOIN sectors as s
+	ON s.id = updated.sector_id;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &mappingInfo,
+		query, mappingId, sectorId)
+	if err != nil {
+		fmt.Println("entity.UpdateSectorMapping: ", err)
+	}
+
+	return mappingInfo, err
+}
+
+func (r *SectorPostgres) UpdateAssetMapping(assetId string, mappingId string) (
+	[]entity.Asset, error) {
+
+	var assetInfo []entity.Asset
+
+	query := `
+	UPDATE assets
+	SET sector_mapping_id = $2
+	WHERE id = $1
+	RETURNING id, symbol;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &assetInfo,
+		query, assetId, mappingId)
+	if err != nil {
+		fmt.Println("entity.UpdateAssetSectorMapping: ", err)
+	}
+
+	return assetInfo, err
+}
+
+// SearchPositions returns the current quantity of each asset of the user and
+// the amount invested on it, using the average buy price.
+func (r *SectorPostgres) SearchPositions(userUid string) (
+	[]entity.SectorPosition, error) {
+
+	var positions []entity.SectorPosition
+
+	query := `
+	SELECT
+		a.id as asset_id, a.symbol, a.sector_id, o.currency,
+		SUM(o.quantity) as quantity,
+		SUM(o.quantity) * COALESCE(
+			SUM(o.quantity * o.price) FILTER(WHERE o.order_type = 'buy')
+			/ NULLIF(SUM(o.quantity) FILTER(WHERE o.order_type = 'buy'), 0),
+			0) as invested
+	FROM orders as o
+	INNER JOIN assets as a
+	ON a.id = o.asset_id
+	WHERE o.user_uid = $1
+	GROUP BY a.id, a.symbol, a.sector_id, o.currency
+	HAVING SUM(o.quantity) > 0
+	ORDER BY a.symbol;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &positions,
+		query, userUid)
+	if err != nil {
+		fmt.Println("entity.SearchSectorPositions: ", err)
+	}
+
+	return positions, err
+}
+
 func (r *SectorPostgres) SearchByAsset(symbol string) ([]entity.Sector, error) {
 	var sectorQuery []entity.Sector
 	var dbReturnError error
@@ -2,6 +2,7 @@ package postgresql
 
 import (
 	"context"
+	"encoding/json"
 	"regexp"
 	"stockfyApi/entity"
 	"testing"
@@ -11,36 +12,40 @@ import (
 )
 
 func TestSectorCreate(t *testing.T) {
+	parentId := "b52ad206-ed8b-11eb-9a03-0242ac130003"
 
 	var expectedSectorInfo = []entity.Sector{
 		{
-			Id:   "0a52d206-ed8b-11eb-9a03-0242ac130003",
-			Name: "Finance",
+			Id:       "0a52d206-ed8b-11eb-9a03-0242ac130003",
+			Name:     "Banks",
+			Level:    "INDUSTRY",
+			Code:     "401010",
+			ParentId: &parentId,
 		},
 	}
 
 	query := regexp.QuoteMeta(`
 	WITH s as (
 		SELECT
-			id, name
+			id, name, "level", code, parent_id
 		FROM sectors
-		WHERE name=$1
+		WHERE name=$1 AND parent_id IS NOT DISTINCT FROM $4::uuid
 	), i as (
 		INSERT INTO
-			sectors(name)
-		SELECT $1
+			sectors(name, "level", code, parent_id)
+		SELECT $1, $2, $3, $4::uuid
 		WHERE NOT EXISTS (SELECT 1 FROM s)
-		returning id, name
+		returning id, name, "level", code, parent_id
 	)
 	SELECT
-		id, name from i
+		id, name, "level", code, parent_id from i
 	UNION ALL
 	SELECT
-		id, name
+		id, name, "level", code, parent_id
 	from s;
 	`)
 
-	columns := []string{"id", "name"}
+	columns := []string{"id", "name", "level", "code", "parent_id"}
 
 	mock, err := pgxmock.NewConn()
 	if err != nil {
@@ -49,12 +54,19 @@ func TestSectorCreate(t *testing.T) {
 	defer mock.Close(context.Background())
 
 	rows := mock.NewRows(columns)
-	mock.ExpectQuery(query).WithArgs("Finance").WillReturnRows(
-		rows.AddRow("0a52d206-ed8b-11eb-9a03-0242ac130003", "Finance"))
+	mock.ExpectQuery(query).WithArgs("Banks", "INDUSTRY", "401010",
+		&parentId).WillReturnRows(rows.AddRow(
+		"0a52d206-ed8b-11eb-9a03-0242ac130003", "Banks", "INDUSTRY", "401010",
+		&parentId))
 
 	Sector := SectorPostgres{dbpool: mock}
 
-	sectorInfo, _ := Sector.Create("Finance")
+	sectorInfo, _ := Sector.Create(entity.Sector{
+		Name:     "Banks",
+		Level:    "INDUSTRY",
+		Code:     "401010",
+		ParentId: &parentId,
+	})
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -68,19 +80,21 @@ func TestSectorSearchByName(t *testing.T) {
 
 	var expectedSectorInfo = []entity.Sector{
 		{
-			Id:   "0a52d206-ed8b-11eb-9a03-0242ac130003",
-			Name: "Finance",
+			Id:    "0a52d206-ed8b-11eb-9a03-0242ac130003",
+			Name:  "Finance",
+			Level: "SECTOR",
 		},
 	}
 
 	query := regexp.QuoteMeta(`
 	SELECT
-		id, name
+		id, name, "level", code, parent_id
 	FROM sectors
 	WHERE name = $1
+	ORDER BY code;
 	`)
 
-	columns := []string{"id", "name"}
+	columns := []string{"id", "name", "level", "code", "parent_id"}
 
 	mock, err := pgxmock.NewConn()
 	if err != nil {
@@ -90,7 +104,8 @@ func TestSectorSearchByName(t *testing.T) {
 
 	rows := mock.NewRows(columns)
 	mock.ExpectQuery(query).WithArgs("Finance").WillReturnRows(
-		rows.AddRow("0a52d206-ed8b-11eb-9a03-0242ac130003", "Finance"))
+		rows.AddRow("0a52d206-ed8b-11eb-9a03-0242ac130003", "Finance",
+			"SECTOR", "", nil))
 
 	Sector := SectorPostgres{dbpool: mock}
 
@@ -103,3 +118,274 @@ func TestSectorSearchByName(t *testing.T) {
 	assert.NotNil(t, sectorInfo)
 	assert.Equal(t, expectedSectorInfo, sectorInfo)
 }
+
+func TestSectorMerge(t *testing.T) {
+
+	var expectedSectorInfo = []entity.Sector{
+		{
+			Id:    "0a52d206-ed8b-11eb-9a03-0242ac130003",
+			Name:  "Financials",
+			Level: "SECTOR",
+			Code:  "40",
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	WITH moved_assets as (
+		UPDATE assets
+		SET sector_id = $2
+		WHERE sector_id = $1
+		RETURNING id
+	), moved_mappings as (
+		UPDATE sector_mappings
+		SET sector_id = $2
+		WHERE sector_id = $1
+		RETURNING id
+	), moved_children as (
+		UPDATE sectors
+		SET parent_id = $2
+		WHERE parent_id = $1
+		RETURNING id
+	), deleted as (
+		DELETE FROM sectors
+		WHERE id = $1
+		RETURNING id
+	)
+	SELECT
+		id, name, "level", code, parent_id
+	FROM sectors
+	WHERE id = $2 AND EXISTS (SELECT 1 FROM deleted);
+	`)
+
+	columns := []string{"id", "name", "level", "code", "parent_id"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	auditLog := entity.AuditLog{
+		UserUid:    "TestAdminUID",
+		Action:     entity.AuditActionMerge,
+		EntityType: entity.AuditEntitySector,
+		EntityId:   "83ae92f8-ed8b-11eb-9a03-0242ac130003",
+		Before:     json.RawMessage(`{"Name":"Banks"}`),
+	}
+
+	rows := mock.NewRows(columns)
+	mock.ExpectBegin()
+	mock.ExpectQuery(query).WithArgs("83ae92f8-ed8b-11eb-9a03-0242ac130003",
+		"0a52d206-ed8b-11eb-9a03-0242ac130003").WillReturnRows(
+		rows.AddRow("0a52d206-ed8b-11eb-9a03-0242ac130003", "Financials",
+			"SECTOR", "40", nil))
+	expectAuditLog(mock, auditLog, expectedSectorInfo[0])
+	mock.ExpectCommit()
+
+	Sector := SectorPostgres{dbpool: mock}
+
+	sectorInfo, err := Sector.Merge("83ae92f8-ed8b-11eb-9a03-0242ac130003",
+		"0a52d206-ed8b-11eb-9a03-0242ac130003", &auditLog)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedSectorInfo, sectorInfo)
+}
+
+func TestSectorCreateMapping(t *testing.T) {
+	sector := entity.Sector{
+		Id:    "0a52d206-ed8b-11eb-9a03-0242ac130003",
+		Name:  "Banks",
+		Level: "INDUSTRY",
+		Code:  "401010",
+	}
+
+	expectedMappings := []entity.SectorMapping{
+		{
+			Id:       "c1a2b3",
+			Provider: "FINNHUB",
+			Label:    "Banking",
+			Sector:   &sector,
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	WITH m as (
+		SELECT
+			id, provider, label, sector_id
+		FROM sector_mappings
+		WHERE provider=$1 AND label=$2
+	), i as (
+		INSERT INTO
+			sector_mappings(provider, label)
+		SELECT $1, $2
+		WHERE NOT EXISTS (SELECT 1 FROM m)
+		returning id, provider, label, sector_id
+	), mapping as (
+		SELECT
+			id, provider, label, sector_id from i
+		UNION ALL
+		SELECT
+			id, provider, label, sector_id from m
+	)
+	SELECT
+		mapping.id, mapping.provider, mapping.label,
+		CASE WHEN s.id IS NULL THEN NULL ELSE json_build_object(
+			'id', s.id,
+			'name', s.name,
+			'level', s."level",
+			'code', s.code,
+			'parentId', s.parent_id
+		) END as sector
+	FROM mapping
+	LEFT JOIN sectors as s
+	ON s.id = mapping.sector_id;
+	`)
+
+	columns := []string{"id", "provider", "label", "sector"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(query).WithArgs("FINNHUB", "Banking").WillReturnRows(
+		rows.AddRow("c1a2b3", "FINNHUB", "Banking", &sector))
+
+	Sector := SectorPostgres{dbpool: mock}
+
+	mappings, err := Sector.CreateMapping("FINNHUB", "Banking")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedMappings, mappings)
+}
+
+func TestSectorUpdateMapping(t *testing.T) {
+	sector := entity.Sector{
+		Id:    "0a52d206-ed8b-11eb-9a03-0242ac130003",
+		Name:  "Banks",
+		Level: "INDUSTRY",
+		Code:  "401010",
+	}
+
+	expectedMappings := []entity.SectorMapping{
+		{
+			Id:       "c1a2b3",
+			Provider: "FINNHUB",
+			Label:    "Fintech",
+			Sector:   &sector,
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	WITH updated as (
+		UPDATE sector_mappings
+		SET sector_id = $2
+		WHERE id = $1
+		RETURNING id, provider, label, sector_id
+	), reclassified as (
+		UPDATE assets
+		SET sector_id = $2
+		WHERE sector_mapping_id IN (SELECT id FROM updated)
+	)
+	SELECT
+		updated.id, updated.provider, updated.label,
+		json_build_object(
+			'id', s.id,
+			'name', s.name,
+			'level', s."level",
+			'code', s.code,
+			'parentId', s.parent_id
+		) as sector
+	FROM updated
+	INNER JOIN sectors as s
+	ON s.id = updated.sector_id;
+	`)
+
+	columns := []string{"id", "provider", "label", "sector"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(query).WithArgs("c1a2b3", sector.Id).WillReturnRows(
+		rows.AddRow("c1a2b3", "FINNHUB", "Fintech", &sector))
+
+	Sector := SectorPostgres{dbpool: mock}
+
+	mappings, err := Sector.UpdateMapping("c1a2b3", sector.Id)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedMappings, mappings)
+}
+
+func TestSectorSearchPositions(t *testing.T) {
+	expectedPositions := []entity.SectorPosition{
+		{
+			AssetId:  "a69a3",
+			Symbol:   "ITUB4",
+			SectorId: "0a52d206-ed8b-11eb-9a03-0242ac130003",
+			Currency: "BRL",
+			Quantity: 20,
+			Invested: 580,
+		},
+	}
+
+	query := regexp.QuoteMeta(`
+	SELECT
+		a.id as asset_id, a.symbol, a.sector_id, o.currency,
+		SUM(o.quantity) as quantity,
+		SUM(o.quantity) * COALESCE(
+			SUM(o.quantity * o.price) FILTER(WHERE o.order_type = 'buy')
+			/ NULLIF(SUM(o.quantity) FILTER(WHERE o.order_type = 'buy'), 0),
+			0) as invested
+	FROM orders as o
+	INNER JOIN assets as a
+	ON a.id = o.asset_id
+	WHERE o.user_uid = $1
+	GROUP BY a.id, a.symbol, a.sector_id, o.currency
+	HAVING SUM(o.quantity) > 0
+	ORDER BY a.symbol;
+	`)
+
+	columns := []string{"asset_id", "symbol", "sector_id", "currency",
+		"quantity", "invested"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(query).WithArgs("TestUserUID").WillReturnRows(
+		rows.AddRow("a69a3", "ITUB4", "0a52d206-ed8b-11eb-9a03-0242ac130003",
+			"BRL", 20.0, 580.0))
+
+	Sector := SectorPostgres{dbpool: mock}
+
+	positions, err := Sector.SearchPositions("TestUserUID")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedPositions, positions)
+}
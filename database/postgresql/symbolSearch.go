@@ -0,0 +1,123 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"stockfyApi/entity"
+	"strings"
+
+	"github.com/georgysavva/scany/pgxscan"
+)
+
+type SymbolSearchPostgres struct {
+	dbpool PgxIface
+}
+
+func NewSymbolSearchPostgres(db PgxIface) *SymbolSearchPostgres {
+	return &SymbolSearchPostgres{
+		dbpool: db,
+	}
+}
+
+// CreateSymbols stores the symbols in the local index. A symbol already
+// indexed in the same country is updated with the new fullname and type.
+func (r *SymbolSearchPostgres) CreateSymbols(
+	symbols []entity.SymbolSuggestion) ([]entity.SymbolSuggestion, error) {
+
+	var symbolsRow []entity.SymbolSuggestion
+
+	codes := make([]string, len(symbols))
+	fullnames := make([]string, len(symbols))
+	types := make([]string, len(symbols))
+	countries := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		codes[i] = symbol.Symbol
+		fullnames[i] = symbol.Fullname
+		types[i] = symbol.Type
+		countries[i] = symbol.Country
+	}
+
+	insertRow := `
+	INSERT INTO
+		symbols(symbol, fullname, "type", country)
+	SELECT DISTINCT ON (symbol, country)
+		symbol, fullname, "type", country
+	FROM unnest($1::text[], $2::text[], $3::text[], $4::text[])
+		as t(symbol, fullname, "type", country)
+	ON CONFLICT (symbol, country) DO UPDATE SET
+		fullname = EXCLUDED.fullname,
+		"type" = EXCLUDED.type
+	RETURNING symbol, fullname, "type", country;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &symbolsRow,
+		insertRow, codes, fullnames, types, countries)
+	if err != nil {
+		fmt.Println("entity.CreateSymbols: ", err)
+	}
+
+	return symbolsRow, err
+}
+
+// Search looks for the query in the symbols and company names of the assets
+// and of the symbol index. The exact symbol comes first, followed by the
+// symbols and the company names starting with the query and then by the
+// trigram similarity. The assets already registered have their id returned.
+func (r *SymbolSearchPostgres) Search(query string, country string,
+	limit int) ([]entity.SymbolSuggestion, error) {
+
+	var suggestionsReturn []entity.SymbolSuggestion
+
+	searchQuery := `
+	WITH candidates as (
+		SELECT
+			ast.symbol, ast.fullname, ast_type."type", ast_type.country,
+			ast.id::text as asset_id
+		FROM assets as ast
+		INNER JOIN asset_types as ast_type
+		ON ast_type.id = ast.asset_type_id
+		UNION ALL
+		SELECT
+			sym.symbol, sym.fullname, sym."type", sym.country,
+			NULL as asset_id
+		FROM symbols as sym
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM assets as ast
+			INNER JOIN asset_types as ast_type
+			ON ast_type.id = ast.asset_type_id
+			WHERE ast.symbol = sym.symbol AND ast_type.country = sym.country
+		)
+	)
+	SELECT
+		symbol, fullname, "type", country, asset_id,
+		CASE
+			WHEN upper(symbol) = upper($1) THEN 3
+			WHEN symbol ILIKE $2 || '%' THEN 2
+			WHEN fullname ILIKE $2 || '%' THEN 1
+			ELSE 0
+		END + greatest(similarity(symbol, $1), similarity(fullname, $1))
+			as rank
+	FROM candidates
+	WHERE ($3 = '' OR country = $3)
+		AND (symbol ILIKE $2 || '%' OR fullname ILIKE '%' || $2 || '%'
+			OR fullname % $1)
+	ORDER BY rank DESC, symbol
+	LIMIT $4;
+	`
+
+	err := pgxscan.Select(context.Background(), r.dbpool, &suggestionsReturn,
+		searchQuery, query, escapeLikePattern(query), country, limit)
+	if err != nil {
+		fmt.Println("entity.SearchSymbols: ", err)
+	}
+
+	return suggestionsReturn, err
+}
+
+// escapeLikePattern makes the wildcards of the LIKE operator be searched as
+// literal characters.
+func escapeLikePattern(pattern string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(
+		pattern)
+}
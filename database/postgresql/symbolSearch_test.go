@@ -0,0 +1,144 @@
+package postgresql
+
+import (
+	"context"
+	"regexp"
+	"stockfyApi/entity"
+	"testing"
+
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSymbolSearchCreateSymbols(t *testing.T) {
+	symbols := []entity.SymbolSuggestion{
+		{
+			Symbol:   "ITUB4",
+			Fullname: "Itau Unibanco Holding S.A",
+			Type:     "STOCK",
+			Country:  "BR",
+		},
+	}
+
+	insertRow := regexp.QuoteMeta(`
+	INSERT INTO
+		symbols(symbol, fullname, "type", country)
+	SELECT DISTINCT ON (symbol, country)
+		symbol, fullname, "type", country
+	FROM unnest($1::text[], $2::text[], $3::text[], $4::text[])
+		as t(symbol, fullname, "type", country)
+	ON CONFLICT (symbol, country) DO UPDATE SET
+		fullname = EXCLUDED.fullname,
+		"type" = EXCLUDED.type
+	RETURNING symbol, fullname, "type", country;
+	`)
+
+	columns := []string{"symbol", "fullname", "type", "country"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(insertRow).WithArgs([]string{"ITUB4"},
+		[]string{"Itau Unibanco Holding S.A"}, []string{"STOCK"},
+		[]string{"BR"}).WillReturnRows(rows.AddRow("ITUB4",
+		"Itau Unibanco Holding S.A", "STOCK", "BR"))
+
+	symbolSearch := SymbolSearchPostgres{dbpool: mock}
+	symbolsCreated, err := symbolSearch.CreateSymbols(symbols)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, symbols, symbolsCreated)
+}
+
+func TestSymbolSearchSearch(t *testing.T) {
+	assetId := "a69a3"
+
+	expectedSuggestions := []entity.SymbolSuggestion{
+		{
+			Symbol:   "ITUB4",
+			Fullname: "Itau Unibanco Holding S.A",
+			Type:     "STOCK",
+			Country:  "BR",
+			AssetId:  &assetId,
+			Rank:     2.5,
+		},
+		{
+			Symbol:   "ITUB3",
+			Fullname: "Itau Unibanco Holding S.A",
+			Type:     "STOCK",
+			Country:  "BR",
+			Rank:     2.5,
+		},
+	}
+
+	searchQuery := regexp.QuoteMeta(`
+	WITH candidates as (
+		SELECT
+			ast.symbol, ast.fullname, ast_type."type", ast_type.country,
+			ast.id::text as asset_id
+		FROM assets as ast
+		INNER JOIN asset_types as ast_type
+		ON ast_type.id = ast.asset_type_id
+		UNION ALL
+		SELECT
+			sym.symbol, sym.fullname, sym."type", sym.country,
+			NULL as asset_id
+		FROM symbols as sym
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM assets as ast
+			INNER JOIN asset_types as ast_type
+			ON ast_type.id = ast.asset_type_id
+			WHERE ast.symbol = sym.symbol AND ast_type.country = sym.country
+		)
+	)
+	SELECT
+		symbol, fullname, "type", country, asset_id,
+		CASE
+			WHEN upper(symbol) = upper($1) THEN 3
+			WHEN symbol ILIKE $2 || '%' THEN 2
+			WHEN fullname ILIKE $2 || '%' THEN 1
+			ELSE 0
+		END + greatest(similarity(symbol, $1), similarity(fullname, $1))
+			as rank
+	FROM candidates
+	WHERE ($3 = '' OR country = $3)
+		AND (symbol ILIKE $2 || '%' OR fullname ILIKE '%' || $2 || '%'
+			OR fullname % $1)
+	ORDER BY rank DESC, symbol
+	LIMIT $4;
+	`)
+
+	columns := []string{"symbol", "fullname", "type", "country", "asset_id",
+		"rank"}
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	rows := mock.NewRows(columns)
+	mock.ExpectQuery(searchQuery).WithArgs("ITUB_", `ITUB\_`, "BR", 10).
+		WillReturnRows(rows.AddRow("ITUB4", "Itau Unibanco Holding S.A",
+			"STOCK", "BR", &assetId, 2.5).AddRow("ITUB3",
+			"Itau Unibanco Holding S.A", "STOCK", "BR", nil, 2.5))
+
+	symbolSearch := SymbolSearchPostgres{dbpool: mock}
+	suggestions, err := symbolSearch.Search("ITUB_", "BR", 10)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedSuggestions, suggestions)
+}
@@ -98,3 +98,177 @@ func (r *UserPostgres) Search(userUid string) ([]entity.Users, error) {
 
 	return userRow, err
 }
+
+func (r *UserPostgres) SearchByEmail(email string) ([]entity.Users, error) {
+	var userRow []entity.Users
+
+	query := `
+	SELECT
+		uid, email, username, "type"
+	FROM users
+	WHERE lower(email) = lower($1);
+	`
+	err := pgxscan.Select(context.Background(), r.dbpool, &userRow, query, email)
+	if err != nil {
+		fmt.Println("entity.SearchUserByEmail: ", err)
+	}
+
+	return userRow, err
+}
+
+func (r *UserPostgres) SearchIdentity(firebaseUid string) (
+	[]entity.UserIdentity, error) {
+	var identityRow []entity.UserIdentity
+
+	query := `
+	SELECT
+		firebase_uid, user_uid, provider_id, created_at
+	FROM user_identities
+	WHERE firebase_uid = $1;
+	`
+	err := pgxscan.Select(context.Background(), r.dbpool, &identityRow, query,
+		firebaseUid)
+	if err != nil {
+		fmt.Println("entity.SearchUserIdentity: ", err)
+	}
+
+	return identityRow, err
+}
+
+func (r *UserPostgres) SearchIdentities(userUid string) (
+	[]entity.UserIdentity, error) {
+	var identityRows []entity.UserIdentity
+
+	query := `
+	SELECT
+		firebase_uid, user_uid, provider_id, created_at
+	FROM user_identities
+	WHERE user_uid = $1
+	ORDER BY created_at;
+	`
+	err := pgxscan.Select(context.Background(), r.dbpool, &identityRows, query,
+		userUid)
+	if err != nil {
+		fmt.Println("entity.SearchUserIdentities: ", err)
+	}
+
+	return identityRows, err
+}
+
+// Link links the Firebase user of the identity to the user in a single
+// transaction. The portfolio stored under the UID of the identity is merged
+// into the one of the user: the accounts with a nickname already used by the
+// user get the provider as suffix, the earnings created from an earning event
+// already registered by the user are discarded and the events dismissed by the
+// identity are also dismissed for the user. The users row of the identity is
+// deleted and its identities are moved to the user.
+func (r *UserPostgres) Link(userUid string, identity entity.UserIdentity) (
+	[]entity.UserIdentity, error) {
+	var identityRows []entity.UserIdentity
+
+	tx, err := r.dbpool.Begin(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback(context.Background())
+
+	insertIdentity := `
+	INSERT INTO
+		user_identities(firebase_uid, user_uid, provider_id)
+	VALUES ($1, $2, $3);
+	`
+	_, err = tx.Exec(context.Background(), insertIdentity, identity.FirebaseUid,
+		userUid, identity.ProviderId)
+	if err != nil {
+		fmt.Println("entity.LinkUser: ", err)
+		return nil, err
+	}
+
+	mergeQueries := []string{`
+	INSERT INTO
+		asset_users(asset_id, user_uid)
+	SELECT asset_id, $1 FROM asset_users
+	WHERE user_uid = $2
+	ON CONFLICT (asset_id, user_uid) DO NOTHING;
+	`, `
+	UPDATE brokerages
+	SET user_uid = $1
+	WHERE user_uid = $2;
+	`, `
+	UPDATE brokerage_accounts as ba
+	SET user_uid = $1,
+		nickname = CASE WHEN EXISTS (
+			SELECT 1 FROM brokerage_accounts as b
+			WHERE b.user_uid = $1 AND b.nickname = ba.nickname
+		) THEN ba.nickname || ' (' || (
+			SELECT provider_id FROM user_identities WHERE firebase_uid = $2
+		) || ')' ELSE ba.nickname END
+	WHERE ba.user_uid = $2;
+	`, `
+	UPDATE orders
+	SET user_uid = $1
+	WHERE user_uid = $2;
+	`, `
+	DELETE FROM earnings as e
+	WHERE e.user_uid = $2 AND e.earning_event_id IN (
+		SELECT earning_event_id FROM earnings
+		WHERE user_uid = $1 AND earning_event_id IS NOT NULL
+	);
+	`, `
+	UPDATE earnings
+	SET user_uid = $1
+	WHERE user_uid = $2;
+	`, `
+	INSERT INTO
+		dismissed_earning_events(earning_event_id, user_uid)
+	SELECT earning_event_id, $1 FROM dismissed_earning_events
+	WHERE user_uid = $2
+	ON CONFLICT (earning_event_id, user_uid) DO NOTHING;
+	`, `
+	UPDATE cash_movements
+	SET user_uid = $1
+	WHERE user_uid = $2;
+	`, `
+	UPDATE remittances
+	SET user_uid = $1
+	WHERE user_uid = $2;
+	`, `
+	UPDATE user_identities
+	SET user_uid = $1
+	WHERE user_uid = $2;
+	`, `
+	DELETE FROM users
+	WHERE uid = $2;
+	`}
+
+	for _, query := range mergeQueries {
+		_, err = tx.Exec(context.Background(), query, userUid,
+			identity.FirebaseUid)
+		if err != nil {
+			fmt.Println("entity.LinkUser: ", err)
+			return nil, err
+		}
+	}
+
+	query := `
+	SELECT
+		firebase_uid, user_uid, provider_id, created_at
+	FROM user_identities
+	WHERE user_uid = $1
+	ORDER BY created_at;
+	`
+	err = pgxscan.Select(context.Background(), tx, &identityRows, query,
+		userUid)
+	if err != nil {
+		fmt.Println("entity.LinkUser: ", err)
+		return nil, err
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return identityRows, nil
+}
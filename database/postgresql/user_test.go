@@ -150,3 +150,141 @@ func TestUserSearch(t *testing.T) {
 	assert.NotNil(t, userRow)
 	assert.Equal(t, expectedSectorInfo, userRow)
 }
+
+func TestUserSearchIdentity(t *testing.T) {
+
+	query := regexp.QuoteMeta(`
+	SELECT
+		firebase_uid, user_uid, provider_id, created_at
+	FROM user_identities
+	WHERE firebase_uid = $1;
+	`)
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	createdAt := entity.StringToTime("2021-10-01")
+	rows := mock.NewRows([]string{"firebase_uid", "user_uid", "provider_id",
+		"created_at"}).AddRow("b58c93kdjfaj4a", userCreate.Uid, "google.com",
+		createdAt)
+
+	mock.ExpectQuery(query).WithArgs("b58c93kdjfaj4a").WillReturnRows(rows)
+
+	Users := UserPostgres{dbpool: mock}
+	identityRow, err := Users.SearchIdentity("b58c93kdjfaj4a")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, []entity.UserIdentity{
+		{
+			FirebaseUid: "b58c93kdjfaj4a",
+			UserUid:     userCreate.Uid,
+			ProviderId:  "google.com",
+			CreatedAt:   createdAt,
+		},
+	}, identityRow)
+}
+
+func TestUserLink(t *testing.T) {
+	identity := entity.UserIdentity{
+		FirebaseUid: "b58c93kdjfaj4a",
+		ProviderId:  "google.com",
+	}
+
+	insertIdentity := regexp.QuoteMeta(`
+	INSERT INTO
+		user_identities(firebase_uid, user_uid, provider_id)
+	VALUES ($1, $2, $3);
+	`)
+
+	mergeQueries := []string{
+		regexp.QuoteMeta(`
+	INSERT INTO
+		asset_users(asset_id, user_uid)
+	SELECT asset_id, $1 FROM asset_users`),
+		regexp.QuoteMeta(`
+	UPDATE brokerages
+	SET user_uid = $1`),
+		regexp.QuoteMeta(`
+	UPDATE brokerage_accounts as ba
+	SET user_uid = $1,`),
+		regexp.QuoteMeta(`
+	UPDATE orders
+	SET user_uid = $1`),
+		regexp.QuoteMeta(`
+	DELETE FROM earnings as e
+	WHERE e.user_uid = $2 AND e.earning_event_id IN (`),
+		regexp.QuoteMeta(`
+	UPDATE earnings
+	SET user_uid = $1`),
+		regexp.QuoteMeta(`
+	INSERT INTO
+		dismissed_earning_events(earning_event_id, user_uid)
+	SELECT earning_event_id, $1 FROM dismissed_earning_events`),
+		regexp.QuoteMeta(`
+	UPDATE cash_movements
+	SET user_uid = $1`),
+		regexp.QuoteMeta(`
+	UPDATE remittances
+	SET user_uid = $1`),
+		regexp.QuoteMeta(`
+	UPDATE user_identities
+	SET user_uid = $1`),
+		regexp.QuoteMeta(`
+	DELETE FROM users
+	WHERE uid = $2;`),
+	}
+
+	query := regexp.QuoteMeta(`
+	SELECT
+		firebase_uid, user_uid, provider_id, created_at
+	FROM user_identities
+	WHERE user_uid = $1
+	ORDER BY created_at;
+	`)
+
+	mock, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub entity connection", err)
+	}
+	defer mock.Close(context.Background())
+
+	createdAt := entity.StringToTime("2021-10-01")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(insertIdentity).WithArgs(identity.FirebaseUid,
+		userCreate.Uid, identity.ProviderId).WillReturnResult(
+		pgxmock.NewResult("INSERT", 1))
+	for _, mergeQuery := range mergeQueries {
+		mock.ExpectExec(mergeQuery).WithArgs(userCreate.Uid,
+			identity.FirebaseUid).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	}
+	mock.ExpectQuery(query).WithArgs(userCreate.Uid).WillReturnRows(
+		mock.NewRows([]string{"firebase_uid", "user_uid", "provider_id",
+			"created_at"}).AddRow(identity.FirebaseUid, userCreate.Uid,
+			identity.ProviderId, createdAt))
+	mock.ExpectCommit()
+
+	Users := UserPostgres{dbpool: mock}
+	identityRows, err := Users.Link(userCreate.Uid, identity)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, []entity.UserIdentity{
+		{
+			FirebaseUid: identity.FirebaseUid,
+			UserUid:     userCreate.Uid,
+			ProviderId:  identity.ProviderId,
+			CreatedAt:   createdAt,
+		},
+	}, identityRows)
+}
@@ -125,6 +125,26 @@ func TestNewAssetValidation(t *testing.T) {
 			country:      "AAAA",
 			respExpected: ErrInvalidAssetEntityValues,
 		},
+		{
+			symbol:       "BTC",
+			fullname:     "Bitcoin",
+			preference:   &preference,
+			sectorId:     "13a48ajp4",
+			assetTypeId:  "avn48ak",
+			assetType:    "CRYPTO",
+			country:      "CRYPTO",
+			respExpected: nil,
+		},
+		{
+			symbol:       "BTC",
+			fullname:     "Bitcoin",
+			preference:   &preference,
+			sectorId:     "13a48ajp4",
+			assetTypeId:  "avn48ak",
+			assetType:    "CRYPTO",
+			country:      "US",
+			respExpected: ErrInvalidAssetEntityValues,
+		},
 	}
 
 	for _, testCase := range tests {
@@ -1,5 +1,25 @@
 package entity
 
+// Cryptocurrencies are not listed in a specific country, so their asset type
+// uses CRYPTO as the country code. The quantity of a crypto order accepts up
+// to 8 decimal places and the price can be quoted in any currency of the
+// CRYPTO market.
+const (
+	CryptoCountry              = "CRYPTO"
+	CryptoAssetType            = "CRYPTO"
+	CryptoQuantityDecimals     = 8
+	DefaultCryptoQuoteCurrency = "USD"
+)
+
+func IsValidCryptoCurrency(currency string) bool {
+	market, err := SearchMarket(CryptoCountry)
+	if err != nil {
+		return false
+	}
+
+	return market.HasCurrency(currency)
+}
+
 func NewAsset(symbol string, fullname string, preference *string,
 	sectorId string, assetTypeId string, assetType string, country string) (
 	*Asset, error) {
@@ -31,8 +51,8 @@ func (a *Asset) Validate(assetType string, country string) error {
 		return ErrInvalidAssetPreferenceUndefined
 	}
 
-	if (assetType != "STOCK" && assetType != "ETF" && assetType != "REIT" &&
-		assetType != "FII") || (country != "BR" && country != "US") {
+	market, err := SearchMarket(country)
+	if err != nil || !market.HasAssetType(assetType) {
 		return ErrInvalidAssetEntityValues
 	}
 
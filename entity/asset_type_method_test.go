@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAssetType(t *testing.T) {
+	type test struct {
+		assetType         string
+		name              string
+		country           string
+		expectedAssetType *AssetType
+		expectedError     error
+	}
+
+	tests := []test{
+		{
+			assetType: "reit",
+			name:      " REITs ",
+			country:   "us",
+			expectedAssetType: &AssetType{
+				Type:    "REIT",
+				Name:    "REITs",
+				Country: "US",
+			},
+			expectedError: nil,
+		},
+		{
+			assetType:     "ETF",
+			name:          "",
+			country:       "BR",
+			expectedError: ErrInvalidAssetTypeBlank,
+		},
+		{
+			assetType:     "FII",
+			name:          "Fundos Imobiliários",
+			country:       "US",
+			expectedError: ErrInvalidAssetTypeName,
+		},
+		{
+			assetType:     "STOCK",
+			name:          "Ações Europa",
+			country:       "EU",
+			expectedError: ErrInvalidCountryCode,
+		},
+	}
+
+	for _, testCase := range tests {
+		assetType, err := NewAssetType(testCase.assetType, testCase.name,
+			testCase.country)
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expectedAssetType, assetType)
+	}
+}
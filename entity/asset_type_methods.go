@@ -0,0 +1,38 @@
+package entity
+
+import "strings"
+
+func NewAssetType(assetType string, name string, country string) (
+	*AssetType, error) {
+
+	newAssetType := &AssetType{
+		Type:    strings.ToUpper(strings.TrimSpace(assetType)),
+		Name:    strings.TrimSpace(name),
+		Country: strings.ToUpper(strings.TrimSpace(country)),
+	}
+
+	if err := newAssetType.Validate(); err != nil {
+		return nil, err
+	}
+
+	return newAssetType, nil
+}
+
+// Validate verifies if the type is accepted by the market of the country,
+// since the assets of this type are validated against the same market.
+func (a *AssetType) Validate() error {
+	if a.Type == "" || a.Name == "" || a.Country == "" {
+		return ErrInvalidAssetTypeBlank
+	}
+
+	market, err := SearchMarket(a.Country)
+	if err != nil {
+		return err
+	}
+
+	if !market.HasAssetType(a.Type) {
+		return ErrInvalidAssetTypeName
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuditLog(t *testing.T) {
+	type test struct {
+		userUid          string
+		action           string
+		entityType       string
+		entityId         string
+		before           interface{}
+		after            interface{}
+		expectedAuditLog *AuditLog
+		expectedError    error
+	}
+
+	tests := []test{
+		{
+			userUid:    "TestAdminUID",
+			action:     "update",
+			entityType: AuditEntitySector,
+			entityId:   "TestSectorID",
+			before:     map[string]string{"name": "Finance"},
+			after:      map[string]string{"name": "Financials"},
+			expectedAuditLog: &AuditLog{
+				UserUid:    "TestAdminUID",
+				Action:     AuditActionUpdate,
+				EntityType: AuditEntitySector,
+				EntityId:   "TestSectorID",
+				Before:     json.RawMessage(`{"name":"Finance"}`),
+				After:      json.RawMessage(`{"name":"Financials"}`),
+			},
+			expectedError: nil,
+		},
+		{
+			userUid:    "TestAdminUID",
+			action:     AuditActionCreate,
+			entityType: AuditEntityAssetType,
+			entityId:   "TestAssetTypeID",
+			before:     nil,
+			after:      map[string]string{"type": "REIT"},
+			expectedAuditLog: &AuditLog{
+				UserUid:    "TestAdminUID",
+				Action:     AuditActionCreate,
+				EntityType: AuditEntityAssetType,
+				EntityId:   "TestAssetTypeID",
+				After:      json.RawMessage(`{"type":"REIT"}`),
+			},
+			expectedError: nil,
+		},
+		{
+			userUid:    "TestAdminUID",
+			action:     AuditActionCreate,
+			entityType: AuditEntityAssetType,
+			entityId:   "",
+			expectedAuditLog: &AuditLog{
+				UserUid:    "TestAdminUID",
+				Action:     AuditActionCreate,
+				EntityType: AuditEntityAssetType,
+			},
+			expectedError: nil,
+		},
+		{
+			userUid:       "TestAdminUID",
+			action:        AuditActionUpdate,
+			entityType:    AuditEntitySector,
+			entityId:      "",
+			expectedError: ErrInvalidAuditLogBlank,
+		},
+		{
+			userUid:       "",
+			action:        AuditActionDelete,
+			entityType:    AuditEntityAsset,
+			entityId:      "TestAssetID",
+			expectedError: ErrInvalidAuditLogBlank,
+		},
+		{
+			userUid:       "TestAdminUID",
+			action:        "RENAME",
+			entityType:    AuditEntityAsset,
+			entityId:      "TestAssetID",
+			expectedError: ErrInvalidAuditLogAction,
+		},
+		{
+			userUid:       "TestAdminUID",
+			action:        AuditActionDelete,
+			entityType:    "ORDER",
+			entityId:      "TestOrderID",
+			expectedError: ErrInvalidAuditLogEntityType,
+		},
+	}
+
+	for _, testCase := range tests {
+		auditLog, err := NewAuditLog(testCase.userUid, testCase.action,
+			testCase.entityType, testCase.entityId, testCase.before,
+			testCase.after)
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expectedAuditLog, auditLog)
+	}
+}
+
+func TestAuditLogRecord(t *testing.T) {
+	auditLog := &AuditLog{
+		UserUid:    "TestAdminUID",
+		Action:     AuditActionCreate,
+		EntityType: AuditEntityAssetType,
+	}
+
+	err := auditLog.Record("TestAssetTypeID", map[string]string{"type": "REIT"})
+	assert.Nil(t, err)
+	assert.Equal(t, &AuditLog{
+		UserUid:    "TestAdminUID",
+		Action:     AuditActionCreate,
+		EntityType: AuditEntityAssetType,
+		EntityId:   "TestAssetTypeID",
+		After:      json.RawMessage(`{"type":"REIT"}`),
+	}, auditLog)
+
+	err = auditLog.Record("TestAssetTypeID", nil)
+	assert.Nil(t, err)
+	assert.Nil(t, auditLog.After)
+}
+
+func TestAuditLogLimit(t *testing.T) {
+	limit, err := AuditLogLimit(0)
+	assert.Equal(t, AuditLogDefaultLimit, limit)
+	assert.Nil(t, err)
+
+	limit, err = AuditLogLimit(10)
+	assert.Equal(t, 10, limit)
+	assert.Nil(t, err)
+
+	_, err = AuditLogLimit(AuditLogMaxLimit + 1)
+	assert.Equal(t, ErrInvalidAuditLogLimit, err)
+}
@@ -0,0 +1,135 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Actions of the admins recorded in the audit log
+const (
+	AuditActionCreate = "CREATE"
+	AuditActionUpdate = "UPDATE"
+	AuditActionDelete = "DELETE"
+	AuditActionMerge  = "MERGE"
+)
+
+var auditActions = []string{AuditActionCreate, AuditActionUpdate,
+	AuditActionDelete, AuditActionMerge}
+
+// Entities shared by every user, which only the admins are able to change
+const (
+	AuditEntityAsset     = "ASSET"
+	AuditEntitySector    = "SECTOR"
+	AuditEntityAssetType = "ASSET_TYPE"
+	AuditEntityBrokerage = "BROKERAGE"
+)
+
+var auditEntityTypes = []string{AuditEntityAsset, AuditEntitySector,
+	AuditEntityAssetType, AuditEntityBrokerage}
+
+// The audit log search returns the 50 most recent changes by default.
+const (
+	AuditLogDefaultLimit = 50
+	AuditLogMaxLimit     = 200
+)
+
+// NewAuditLog records the change of an entity made by the admin. The before
+// and after values are encoded as JSON and a nil value means that the entity
+// did not exist before or after the change. The id of a created entity is
+// blank until the audit log is recorded with the change.
+func NewAuditLog(userUid string, action string, entityType string,
+	entityId string, before interface{}, after interface{}) (*AuditLog, error) {
+
+	auditLog := &AuditLog{
+		UserUid:    userUid,
+		Action:     strings.ToUpper(action),
+		EntityType: strings.ToUpper(entityType),
+		EntityId:   entityId,
+	}
+
+	if err := auditLog.Validate(); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if auditLog.Before, err = marshalAuditValue(before); err != nil {
+		return nil, err
+	}
+
+	if auditLog.After, err = marshalAuditValue(after); err != nil {
+		return nil, err
+	}
+
+	return auditLog, nil
+}
+
+// Record completes the audit log with the entity after the change, so it is
+// stored in the same transaction as the change.
+func (a *AuditLog) Record(entityId string, after interface{}) error {
+	var err error
+	if a.After, err = marshalAuditValue(after); err != nil {
+		return err
+	}
+
+	a.EntityId = entityId
+
+	return nil
+}
+
+func (a *AuditLog) Validate() error {
+	if a.UserUid == "" || (a.EntityId == "" && a.Action != AuditActionCreate) {
+		return ErrInvalidAuditLogBlank
+	}
+
+	if !IsValidAuditAction(a.Action) {
+		return ErrInvalidAuditLogAction
+	}
+
+	if !IsValidAuditEntityType(a.EntityType) {
+		return ErrInvalidAuditLogEntityType
+	}
+
+	return nil
+}
+
+func IsValidAuditAction(action string) bool {
+	for _, auditAction := range auditActions {
+		if auditAction == action {
+			return true
+		}
+	}
+
+	return false
+}
+
+func IsValidAuditEntityType(entityType string) bool {
+	for _, auditEntityType := range auditEntityTypes {
+		if auditEntityType == entityType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func marshalAuditValue(value interface{}) (json.RawMessage, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(value)
+}
+
+// AuditLogLimit returns the number of changes returned by the audit log
+// search, using the default limit when the limit is not informed.
+func AuditLogLimit(limit int) (int, error) {
+	if limit == 0 {
+		return AuditLogDefaultLimit, nil
+	}
+
+	if limit < 0 || limit > AuditLogMaxLimit {
+		return 0, ErrInvalidAuditLogLimit
+	}
+
+	return limit, nil
+}
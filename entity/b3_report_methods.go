@@ -0,0 +1,44 @@
+package entity
+
+import "math"
+
+// Types of the reports exported from the investor area of the B3.
+const (
+	B3TradesReport    = "trades"
+	B3MovementsReport = "movements"
+	B3PositionReport  = "position"
+)
+
+// AddError reports a problem of the movement, ignoring repeated problems.
+func (m *B3Movement) AddError(err error) {
+	m.Errors = addRowError(m.Errors, err)
+}
+
+func (m *B3Movement) Valid() bool {
+	return len(m.Errors) == 0
+}
+
+// AddError reports a problem of the position, ignoring repeated problems.
+func (p *B3Position) AddError(err error) {
+	p.Errors = addRowError(p.Errors, err)
+}
+
+func (p *B3Position) Valid() bool {
+	return len(p.Errors) == 0
+}
+
+func addRowError(errors []string, err error) []string {
+	for _, rowErr := range errors {
+		if rowErr == err.Error() {
+			return errors
+		}
+	}
+
+	return append(errors, err.Error())
+}
+
+// roundQuantity removes the floating point residue of the sums of
+// quantities, keeping the fractions of the fractional shares.
+func roundQuantity(quantity float64) float64 {
+	return math.Round(quantity*1e8) / 1e8
+}
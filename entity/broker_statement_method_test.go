@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBrokerStatementSetBrokerage(t *testing.T) {
+	statement := BrokerStatement{
+		Format:    OfxStatement,
+		Brokerage: "avenue.us",
+		Orders: []OrderImportRow{
+			{Symbol: "AAPL", Brokerage: "avenue.us"},
+			{Symbol: "MSFT", Brokerage: "avenue.us"},
+		},
+	}
+
+	statement.SetBrokerage("Avenue")
+
+	assert.Equal(t, "Avenue", statement.Brokerage)
+	assert.Equal(t, "Avenue", statement.Orders[0].Brokerage)
+	assert.Equal(t, "Avenue", statement.Orders[1].Brokerage)
+
+	statement.SetBrokerage("")
+	assert.Equal(t, []string{ErrInvalidOrderImportBlank.Error()},
+		statement.Orders[0].Errors)
+}
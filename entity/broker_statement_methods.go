@@ -0,0 +1,20 @@
+package entity
+
+// Formats of the statements exported by the US brokerages.
+const (
+	AvenueStatement    = "avenue"
+	PassfolioStatement = "passfolio"
+	OfxStatement       = "ofx"
+)
+
+// SetBrokerage sets the brokerage of the statement and of all its orders. The
+// orders are invalid without a brokerage.
+func (s *BrokerStatement) SetBrokerage(brokerage string) {
+	s.Brokerage = brokerage
+	for i := range s.Orders {
+		s.Orders[i].Brokerage = brokerage
+		if brokerage == "" {
+			s.Orders[i].AddError(ErrInvalidOrderImportBlank)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBrokerage(t *testing.T) {
+	type test struct {
+		name              string
+		fullname          string
+		country           string
+		userUid           *string
+		expectedBrokerage *Brokerage
+		expectedError     error
+	}
+
+	userUid := "TestUserUID"
+
+	tests := []test{
+		{
+			name:     " XP ",
+			fullname: "XP Investimentos CCTVM S.A.",
+			country:  "br",
+			expectedBrokerage: &Brokerage{
+				Name:     "XP",
+				Fullname: "XP Investimentos CCTVM S.A.",
+				Country:  "BR",
+			},
+			expectedError: nil,
+		},
+		{
+			name:    "My Broker",
+			country: "US",
+			userUid: &userUid,
+			expectedBrokerage: &Brokerage{
+				Name:     "My Broker",
+				Fullname: "My Broker",
+				Country:  "US",
+				UserUid:  &userUid,
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "",
+			country:       "BR",
+			expectedError: ErrInvalidBrokerageBlank,
+		},
+		{
+			name:          "Degiro",
+			country:       "EU",
+			expectedError: ErrInvalidCountryCode,
+		},
+	}
+
+	for _, testCase := range tests {
+		brokerage, err := NewBrokerage(testCase.name, testCase.fullname,
+			testCase.country, testCase.userUid)
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expectedBrokerage, brokerage)
+	}
+}
+
+func TestBrokerageIsOwner(t *testing.T) {
+	type test struct {
+		brokerage        Brokerage
+		userUid          string
+		expectedIsCustom bool
+		expectedIsOwner  bool
+	}
+
+	userUid := "TestUserUID"
+	blankUid := ""
+
+	tests := []test{
+		{
+			brokerage:        Brokerage{Name: "Clear"},
+			userUid:          userUid,
+			expectedIsCustom: false,
+			expectedIsOwner:  false,
+		},
+		{
+			brokerage:        Brokerage{Name: "Clear", UserUid: &blankUid},
+			userUid:          "",
+			expectedIsCustom: false,
+			expectedIsOwner:  false,
+		},
+		{
+			brokerage:        Brokerage{Name: "My Broker", UserUid: &userUid},
+			userUid:          userUid,
+			expectedIsCustom: true,
+			expectedIsOwner:  true,
+		},
+		{
+			brokerage:        Brokerage{Name: "My Broker", UserUid: &userUid},
+			userUid:          "AnotherUserUID",
+			expectedIsCustom: true,
+			expectedIsOwner:  false,
+		},
+	}
+
+	for _, testCase := range tests {
+		assert.Equal(t, testCase.expectedIsCustom, testCase.brokerage.IsCustom())
+		assert.Equal(t, testCase.expectedIsOwner,
+			testCase.brokerage.IsOwner(testCase.userUid))
+	}
+}
+
+func TestNewBrokerageAccount(t *testing.T) {
+	type test struct {
+		nickname        string
+		brokerageId     string
+		expectedAccount *BrokerageAccount
+		expectedError   error
+	}
+
+	tests := []test{
+		{
+			nickname:    " Clear - Retirement ",
+			brokerageId: "TestBrokerageID",
+			expectedAccount: &BrokerageAccount{
+				Nickname:  "Clear - Retirement",
+				Brokerage: &Brokerage{Id: "TestBrokerageID"},
+				UserUid:   "TestUserUID",
+			},
+			expectedError: nil,
+		},
+		{
+			nickname:      " ",
+			brokerageId:   "TestBrokerageID",
+			expectedError: ErrInvalidBrokerageAccountNickname,
+		},
+		{
+			nickname:      "Clear",
+			brokerageId:   "",
+			expectedError: ErrInvalidBrokerageId,
+		},
+	}
+
+	for _, testCase := range tests {
+		account, err := NewBrokerageAccount(testCase.nickname,
+			testCase.brokerageId, "TestUserUID")
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expectedAccount, account)
+	}
+}
+
+func TestBrokerageAccountCurrency(t *testing.T) {
+	account := BrokerageAccount{Brokerage: &Brokerage{Country: "BR"}}
+	assert.Equal(t, "BRL", account.Currency())
+
+	account = BrokerageAccount{Brokerage: &Brokerage{Country: "US"}}
+	assert.Equal(t, "USD", account.Currency())
+
+	account = BrokerageAccount{Brokerage: &Brokerage{Country: "XX"}}
+	assert.Equal(t, "", account.Currency())
+
+	account = BrokerageAccount{}
+	assert.Equal(t, "", account.Currency())
+}
+
+func TestSearchBrokerageByInstitution(t *testing.T) {
+	brokerages := []Brokerage{
+		{Id: "1", Name: "Clear", Fullname: "Clear Corretora"},
+		{Id: "2", Name: "XP", Fullname: "XP Investimentos"},
+		{Id: "3", Name: "Rico"},
+	}
+
+	assert.Equal(t, &brokerages[0], SearchBrokerageByInstitution(
+		"CLEAR CORRETORA - GRUPO XP", brokerages))
+	assert.Equal(t, &brokerages[1], SearchBrokerageByInstitution(
+		"XP  INVESTIMENTOS", brokerages))
+	assert.Equal(t, &brokerages[2], SearchBrokerageByInstitution(
+		"RICO INVESTIMENTOS - GRUPO XP", brokerages))
+	assert.Nil(t, SearchBrokerageByInstitution("BTG PACTUAL", brokerages))
+	assert.Nil(t, SearchBrokerageByInstitution("", brokerages))
+}
@@ -0,0 +1,114 @@
+package entity
+
+import "strings"
+
+// NewBrokerage creates a brokerage firm. A brokerage with user is a custom
+// brokerage, visible only to this user. Otherwise, it is available to all the
+// users.
+func NewBrokerage(name string, fullname string, country string,
+	userUid *string) (*Brokerage, error) {
+
+	brokerage := &Brokerage{
+		Name:     strings.TrimSpace(name),
+		Fullname: strings.TrimSpace(fullname),
+		Country:  strings.ToUpper(strings.TrimSpace(country)),
+		UserUid:  userUid,
+	}
+
+	if brokerage.Fullname == "" {
+		brokerage.Fullname = brokerage.Name
+	}
+
+	if err := brokerage.Validate(); err != nil {
+		return nil, err
+	}
+
+	return brokerage, nil
+}
+
+func (b *Brokerage) Validate() error {
+	if b.Name == "" || b.Country == "" {
+		return ErrInvalidBrokerageBlank
+	}
+
+	if _, err := SearchMarket(b.Country); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// IsCustom returns true when the brokerage was created by a user and it is
+// not part of the brokerages available to everyone.
+func (b *Brokerage) IsCustom() bool {
+	return b.UserUid != nil && *b.UserUid != ""
+}
+
+// IsOwner returns true when the user is allowed to change a custom brokerage.
+func (b *Brokerage) IsOwner(userUid string) bool {
+	return b.IsCustom() && *b.UserUid == userUid
+}
+
+func NewBrokerageAccount(nickname string, brokerageId string, userUid string) (
+	*BrokerageAccount, error) {
+
+	account := &BrokerageAccount{
+		Nickname:  strings.TrimSpace(nickname),
+		Brokerage: &Brokerage{Id: brokerageId},
+		UserUid:   userUid,
+	}
+
+	if account.Nickname == "" {
+		return nil, ErrInvalidBrokerageAccountNickname
+	}
+
+	if brokerageId == "" {
+		return nil, ErrInvalidBrokerageId
+	}
+
+	return account, nil
+}
+
+// Currency returns the currency of the money kept in the account, which is the
+// default currency of the market of its brokerage.
+func (a *BrokerageAccount) Currency() string {
+	if a.Brokerage == nil {
+		return ""
+	}
+
+	market, err := SearchMarket(a.Brokerage.Country)
+	if err != nil {
+		return ""
+	}
+
+	return market.DefaultCurrency()
+}
+
+// SearchBrokerageByInstitution returns the brokerage of an institution named
+// by the B3, like CLEAR CORRETORA - GRUPO XP for the Clear. The institution
+// has the full name of the brokerage or starts with its name.
+func SearchBrokerageByInstitution(institution string,
+	brokerages []Brokerage) *Brokerage {
+
+	institution = strings.ToUpper(strings.Join(strings.Fields(institution),
+		" "))
+	if institution == "" {
+		return nil
+	}
+
+	for i, brokerage := range brokerages {
+		if strings.ToUpper(brokerage.Fullname) == institution {
+			return &brokerages[i]
+		}
+	}
+
+	for i, brokerage := range brokerages {
+		name := strings.ToUpper(brokerage.Name)
+		if name != "" && (institution == name ||
+			strings.HasPrefix(institution, name+" ")) {
+			return &brokerages[i]
+		}
+	}
+
+	return nil
+}
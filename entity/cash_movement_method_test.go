@@ -0,0 +1,187 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCashMovement(t *testing.T) {
+	type test struct {
+		movementType     string
+		amount           float64
+		currency         string
+		date             string
+		toAccountId      string
+		expectedMovement *CashMovement
+		expectedError    error
+	}
+
+	date := StringToTime("2021-10-01")
+
+	tests := []test{
+		{
+			movementType: "Deposit",
+			amount:       1000,
+			currency:     "BRL",
+			date:         "2021-10-01",
+			expectedMovement: &CashMovement{
+				Type:        CashDeposit,
+				Amount:      1000,
+				Currency:    "BRL",
+				Date:        date,
+				Description: "Monthly deposit",
+				Account:     &BrokerageAccount{Id: "TestAccountID1"},
+				UserUid:     "TestUserUID",
+			},
+		},
+		{
+			movementType: "fee",
+			amount:       9.9,
+			currency:     "BRL",
+			date:         "2021-10-01",
+			expectedMovement: &CashMovement{
+				Type:        CashFee,
+				Amount:      -9.9,
+				Currency:    "BRL",
+				Date:        date,
+				Description: "Monthly deposit",
+				Account:     &BrokerageAccount{Id: "TestAccountID1"},
+				UserUid:     "TestUserUID",
+			},
+		},
+		{
+			movementType: "transfer",
+			amount:       500,
+			currency:     "BRL",
+			date:         "2021-10-01",
+			toAccountId:  "TestAccountID2",
+			expectedMovement: &CashMovement{
+				Type:        CashTransfer,
+				Amount:      -500,
+				Currency:    "BRL",
+				Date:        date,
+				Description: "Monthly deposit",
+				Account:     &BrokerageAccount{Id: "TestAccountID1"},
+				ToAccount:   &BrokerageAccount{Id: "TestAccountID2"},
+				UserUid:     "TestUserUID",
+			},
+		},
+		{
+			movementType:  "dividend",
+			amount:        10,
+			currency:      "BRL",
+			date:          "2021-10-01",
+			expectedError: ErrInvalidCashMovementType,
+		},
+		{
+			movementType:  "withdrawal",
+			amount:        -10,
+			currency:      "BRL",
+			date:          "2021-10-01",
+			expectedError: ErrInvalidCashMovementAmount,
+		},
+		{
+			movementType:  "withdrawal",
+			amount:        10,
+			currency:      "BRL",
+			expectedError: ErrInvalidCashMovementDate,
+		},
+		{
+			movementType:  "withdrawal",
+			amount:        10,
+			currency:      "EUR",
+			date:          "2021-10-01",
+			expectedError: ErrInvalidCurrency,
+		},
+		{
+			movementType:  "transfer",
+			amount:        10,
+			currency:      "BRL",
+			date:          "2021-10-01",
+			expectedError: ErrInvalidCashMovementTransfer,
+		},
+		{
+			movementType:  "transfer",
+			amount:        10,
+			currency:      "BRL",
+			date:          "2021-10-01",
+			toAccountId:   "TestAccountID1",
+			expectedError: ErrInvalidCashMovementTransfer,
+		},
+		{
+			movementType:  "deposit",
+			amount:        10,
+			currency:      "BRL",
+			date:          "2021-10-01",
+			toAccountId:   "TestAccountID2",
+			expectedError: ErrInvalidCashMovementTransfer,
+		},
+	}
+
+	for _, testCase := range tests {
+		var movementDate time.Time
+		if testCase.date != "" {
+			movementDate = StringToTime(testCase.date)
+		}
+
+		movement, err := NewCashMovement(testCase.movementType,
+			testCase.amount, testCase.currency, movementDate,
+			" Monthly deposit ", "TestAccountID1", testCase.toAccountId,
+			"TestUserUID")
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expectedMovement, movement)
+	}
+}
+
+func testCashEntries() []CashEntry {
+	return []CashEntry{
+		{Source: CashSourceOrder, SourceId: "TestOrderID", Type: "buy",
+			Amount: -585.8, Currency: "BRL", Date: StringToTime("2021-10-05"),
+			AccountId: "TestAccountID1", Nickname: "Clear"},
+		{Source: CashSourceMovement, SourceId: "TestMovementID1",
+			Type: CashDeposit, Amount: 1000, Currency: "BRL",
+			Date: StringToTime("2021-10-01"), AccountId: "TestAccountID1",
+			Nickname: "Clear"},
+		{Source: CashSourceMovement, SourceId: "TestMovementID2",
+			Type: CashDeposit, Amount: 200, Currency: "USD",
+			Date: StringToTime("2021-10-02"), AccountId: "TestAccountID2",
+			Nickname: "Avenue"},
+		{Source: CashSourceEarning, SourceId: "TestEarningID", Type: "JCP",
+			Amount: 1.7, Currency: "BRL", Date: StringToTime("2021-11-03"),
+			AccountId: "TestAccountID1", Nickname: "Clear"},
+	}
+}
+
+func TestNewCashLedger(t *testing.T) {
+	entries := testCashEntries()
+
+	ledger := NewCashLedger(entries, "TestAccountID1")
+	assert.Equal(t, 3, len(ledger))
+	assert.Equal(t, "TestMovementID1", ledger[0].SourceId)
+	assert.Equal(t, 1000.0, ledger[0].Balance)
+	assert.Equal(t, "TestOrderID", ledger[1].SourceId)
+	assert.Equal(t, 414.2, ledger[1].Balance)
+	assert.Equal(t, "TestEarningID", ledger[2].SourceId)
+	assert.Equal(t, 415.9, ledger[2].Balance)
+
+	ledger = NewCashLedger(entries, "")
+	assert.Equal(t, 4, len(ledger))
+	assert.Equal(t, "TestMovementID2", ledger[1].SourceId)
+	assert.Equal(t, 200.0, ledger[1].Balance)
+	assert.Equal(t, 414.2, ledger[2].Balance)
+
+	assert.Equal(t, []CashEntry{}, NewCashLedger(nil, ""))
+}
+
+func TestNewCashBalances(t *testing.T) {
+	assert.Equal(t, []CashBalance{
+		{AccountId: "TestAccountID2", Nickname: "Avenue", Currency: "USD",
+			Balance: 200},
+		{AccountId: "TestAccountID1", Nickname: "Clear", Currency: "BRL",
+			Balance: 415.9},
+	}, NewCashBalances(testCashEntries()))
+
+	assert.Equal(t, []CashBalance{}, NewCashBalances(nil))
+}
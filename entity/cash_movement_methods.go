@@ -0,0 +1,145 @@
+package entity
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Types of the cash movements registered by the user.
+const (
+	CashDeposit    = "deposit"
+	CashWithdrawal = "withdrawal"
+	CashTransfer   = "transfer"
+	CashFee        = "fee"
+	CashInterest   = "interest"
+)
+
+// Sources of the entries of the cash ledger. The orders, the remittances and
+// the earnings post their entries automatically.
+const (
+	CashSourceMovement   = "MOVEMENT"
+	CashSourceOrder      = "ORDER"
+	CashSourceRemittance = "REMITTANCE"
+	CashSourceEarning    = "EARNING"
+)
+
+// NewCashMovement creates a movement of a positive amount. The amount is kept
+// as the change in the balance of the account, so it is negative for the
+// withdrawals, the fees and the transfers to another account.
+func NewCashMovement(movementType string, amount float64, currency string,
+	date time.Time, description string, accountId string, toAccountId string,
+	userUid string) (*CashMovement, error) {
+
+	movement := &CashMovement{
+		Type:        strings.ToLower(movementType),
+		Amount:      amount,
+		Currency:    currency,
+		Date:        date,
+		Description: strings.TrimSpace(description),
+		Account:     &BrokerageAccount{Id: accountId},
+		UserUid:     userUid,
+	}
+
+	switch movement.Type {
+	case CashDeposit, CashInterest:
+	case CashWithdrawal, CashFee, CashTransfer:
+		movement.Amount = -amount
+	default:
+		return nil, ErrInvalidCashMovementType
+	}
+
+	if amount <= 0 {
+		return nil, ErrInvalidCashMovementAmount
+	}
+
+	if date.IsZero() {
+		return nil, ErrInvalidCashMovementDate
+	}
+
+	if !IsValidCurrency(currency) {
+		return nil, ErrInvalidCurrency
+	}
+
+	if accountId == "" {
+		return nil, ErrInvalidCashMovementAccount
+	}
+
+	if (movement.Type == CashTransfer) != (toAccountId != "") ||
+		toAccountId == accountId {
+		return nil, ErrInvalidCashMovementTransfer
+	}
+
+	if toAccountId != "" {
+		movement.ToAccount = &BrokerageAccount{Id: toAccountId}
+	}
+
+	return movement, nil
+}
+
+// NewCashLedger sorts the entries by date and computes the balance of the
+// account in the currency after each entry. When an account is informed, only
+// its entries are kept.
+func NewCashLedger(entries []CashEntry, accountId string) []CashEntry {
+	type balanceKey struct {
+		accountId string
+		currency  string
+	}
+
+	ledger := []CashEntry{}
+	for _, entry := range entries {
+		if accountId == "" || entry.AccountId == accountId {
+			ledger = append(ledger, entry)
+		}
+	}
+
+	sort.SliceStable(ledger, func(i, j int) bool {
+		return ledger[i].Date.Before(ledger[j].Date)
+	})
+
+	balances := map[balanceKey]float64{}
+	for i, entry := range ledger {
+		key := balanceKey{entry.AccountId, entry.Currency}
+		balances[key] = roundQuantity(balances[key] + entry.Amount)
+		ledger[i].Balance = balances[key]
+	}
+
+	return ledger
+}
+
+// NewCashBalances sums the entries of each account in each currency. The
+// balances are sorted by the nickname of the account and the currency.
+func NewCashBalances(entries []CashEntry) []CashBalance {
+	type balanceKey struct {
+		accountId string
+		currency  string
+	}
+
+	balances := map[balanceKey]*CashBalance{}
+	for _, entry := range entries {
+		key := balanceKey{entry.AccountId, entry.Currency}
+		if balances[key] == nil {
+			balances[key] = &CashBalance{
+				AccountId: entry.AccountId,
+				Nickname:  entry.Nickname,
+				Currency:  entry.Currency,
+			}
+		}
+		balances[key].Balance = roundQuantity(balances[key].Balance +
+			entry.Amount)
+	}
+
+	cashBalances := []CashBalance{}
+	for _, balance := range balances {
+		cashBalances = append(cashBalances, *balance)
+	}
+
+	sort.Slice(cashBalances, func(i, j int) bool {
+		if cashBalances[i].Nickname != cashBalances[j].Nickname {
+			return cashBalances[i].Nickname < cashBalances[j].Nickname
+		}
+		return cashBalances[i].Currency < cashBalances[j].Currency
+	})
+
+	return cashBalances
+}
@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCompanyProfile(t *testing.T) {
+	ipoDate := StringToTime("1980-12-12")
+
+	type test struct {
+		finnhubOverview map[string]string
+		alphaOverview   map[string]string
+		expectedProfile *CompanyProfile
+	}
+
+	tests := []test{
+		{
+			finnhubOverview: map[string]string{
+				"exchange":             "NASDAQ NMS - GLOBAL MARKET",
+				"ipo":                  "1980-12-12",
+				"logo":                 "https://finnhub.io/api/logo?symbol=AAPL",
+				"marketCapitalization": "2462175",
+				"weburl":               "https://www.apple.com/",
+			},
+			alphaOverview: map[string]string{
+				"Exchange":             "NASDAQ",
+				"MarketCapitalization": "2462175000000",
+				"PERatio":              "28.9",
+				"DividendYield":        "0.0058",
+			},
+			expectedProfile: &CompanyProfile{
+				Logo:          "https://finnhub.io/api/logo?symbol=AAPL",
+				Website:       "https://www.apple.com/",
+				Exchange:      "NASDAQ NMS - GLOBAL MARKET",
+				MarketCap:     2462175e6,
+				PeRatio:       28.9,
+				DividendYield: 0.0058,
+				IpoDate:       &ipoDate,
+				Asset:         &Asset{Id: "TestAssetID"},
+			},
+		},
+		{
+			finnhubOverview: map[string]string{},
+			alphaOverview: map[string]string{
+				"Exchange":             "BOVESPA",
+				"MarketCapitalization": "250000000000",
+				"PERatio":              "None",
+				"DividendYield":        "0.12",
+			},
+			expectedProfile: &CompanyProfile{
+				Exchange:      "BOVESPA",
+				MarketCap:     250000000000,
+				DividendYield: 0.12,
+				Asset:         &Asset{Id: "TestAssetID"},
+			},
+		},
+		{
+			finnhubOverview: nil,
+			alphaOverview:   nil,
+			expectedProfile: &CompanyProfile{
+				Asset: &Asset{Id: "TestAssetID"},
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		profile := NewCompanyProfile("TestAssetID", testCase.finnhubOverview,
+			testCase.alphaOverview)
+		assert.Equal(t, testCase.expectedProfile, profile)
+	}
+}
+
+func TestCompanyProfileIsOutdated(t *testing.T) {
+	updatedAt := StringToTime("2021-10-01")
+	profile := CompanyProfile{UpdatedAt: updatedAt}
+
+	assert.False(t, profile.IsOutdated(updatedAt.Add(24*time.Hour)))
+	assert.True(t, profile.IsOutdated(updatedAt.Add(8*24*time.Hour)))
+
+	assert.True(t, HasCompanyProfile("STOCK"))
+	assert.False(t, HasCompanyProfile("CRYPTO"))
+}
@@ -0,0 +1,57 @@
+package entity
+
+import "time"
+
+// The company profiles are refreshed from the market data providers once they
+// are older than the refresh interval.
+const CompanyProfileRefreshInterval = 7 * 24 * time.Hour
+
+// Only listed companies and funds have a profile in the market data providers.
+var ListCompanyProfileAssetType = [4]string{"STOCK", "ETF", "REIT", "FII"}
+
+func HasCompanyProfile(assetType string) bool {
+	for _, profileAssetType := range ListCompanyProfileAssetType {
+		if profileAssetType == assetType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewCompanyProfile merges the company overviews returned by Finnhub and
+// Alpha Vantage. Finnhub returns the market capitalization in millions and
+// is the preferred source of the company information, while the valuation
+// ratios are only returned by Alpha Vantage.
+func NewCompanyProfile(assetId string, finnhubOverview map[string]string,
+	alphaOverview map[string]string) *CompanyProfile {
+
+	profile := &CompanyProfile{
+		Logo:          finnhubOverview["logo"],
+		Website:       finnhubOverview["weburl"],
+		Exchange:      finnhubOverview["exchange"],
+		MarketCap:     StringToFloat64(finnhubOverview["marketCapitalization"]) * 1e6,
+		PeRatio:       StringToFloat64(alphaOverview["PERatio"]),
+		DividendYield: StringToFloat64(alphaOverview["DividendYield"]),
+		Asset:         &Asset{Id: assetId},
+	}
+
+	if profile.Exchange == "" {
+		profile.Exchange = alphaOverview["Exchange"]
+	}
+
+	if profile.MarketCap == 0 {
+		profile.MarketCap = StringToFloat64(alphaOverview["MarketCapitalization"])
+	}
+
+	if ipoDate := StringToTime(finnhubOverview["ipo"]); !ipoDate.IsZero() {
+		profile.IpoDate = &ipoDate
+	}
+
+	return profile
+}
+
+// IsOutdated verifies if the profile must be refreshed at the given date.
+func (c *CompanyProfile) IsOutdated(date time.Time) bool {
+	return c.UpdatedAt.Add(CompanyProfileRefreshInterval).Before(date)
+}
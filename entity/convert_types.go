@@ -2,6 +2,7 @@ package entity
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"time"
 )
@@ -36,6 +37,13 @@ func IsIntegral(val float64) bool {
 	return val == float64(int(val))
 }
 
+// HasMaxDecimalPlaces verifies if the value has at most the given number of
+// decimal places, ignoring the floating point representation error.
+func HasMaxDecimalPlaces(val float64, places int) bool {
+	scaled := val * math.Pow10(places)
+	return math.Abs(scaled-math.Round(scaled)) < 1e-6
+}
+
 func StringToTime(dateStr string) time.Time {
 
 	layout := "2006-01-02"
@@ -92,3 +92,41 @@ func TestInterfaceToString(t *testing.T) {
 	}
 
 }
+
+func TestHasMaxDecimalPlaces(t *testing.T) {
+
+	type test struct {
+		input          float64
+		places         int
+		expectedOutput bool
+	}
+
+	tests := []test{
+		{
+			input:          0.12345678,
+			places:         8,
+			expectedOutput: true,
+		},
+		{
+			input:          -1.5,
+			places:         8,
+			expectedOutput: true,
+		},
+		{
+			input:          0.123456789,
+			places:         8,
+			expectedOutput: false,
+		},
+		{
+			input:          20,
+			places:         0,
+			expectedOutput: true,
+		},
+	}
+
+	for _, testCase := range tests {
+		value := HasMaxDecimalPlaces(testCase.input, testCase.places)
+		assert.Equal(t, testCase.expectedOutput, value)
+	}
+
+}
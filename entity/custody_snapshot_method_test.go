@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustodySnapshotValidate(t *testing.T) {
+	snapshot := CustodySnapshot{
+		Brokerage: &Brokerage{Id: "TestBrokerageID", Name: "Clear"},
+		Date:      StringToTime("2021-12-30"),
+		Positions: []CustodyPosition{{Symbol: "ITUB4", Quantity: 20}},
+	}
+	assert.Nil(t, snapshot.Validate())
+
+	invalidBrokerage := snapshot
+	invalidBrokerage.Brokerage = nil
+	assert.Equal(t, ErrInvalidCustodyBrokerage, invalidBrokerage.Validate())
+
+	futureDate := snapshot
+	futureDate.Date = StringToTime("2999-01-01")
+	assert.Equal(t, ErrInvalidCustodyDate, futureDate.Validate())
+
+	invalidPosition := snapshot
+	invalidPosition.Positions = []CustodyPosition{{Symbol: " ", Quantity: 1}}
+	assert.Equal(t, ErrInvalidCustodyPosition, invalidPosition.Validate())
+
+	invalidPosition.Positions = []CustodyPosition{
+		{Symbol: "ITUB4", Quantity: -1}}
+	assert.Equal(t, ErrInvalidCustodyPosition, invalidPosition.Validate())
+}
+
+func TestReconcileCustody(t *testing.T) {
+	date := StringToTime("2021-12-30")
+
+	snapshot := CustodySnapshot{
+		Brokerage: &Brokerage{Id: "TestBrokerageID", Name: "Clear"},
+		Date:      date,
+		Positions: []CustodyPosition{
+			{Symbol: "ITUB4", Quantity: 20},
+			{Symbol: "bbdc4", Quantity: 30},
+			{Symbol: "MGLU3", Quantity: 40},
+			{Symbol: "WEGE3", Quantity: 5},
+			{Symbol: "VALE3", Quantity: 12},
+			{Symbol: "PETR4", Quantity: 7.5},
+		},
+	}
+
+	positions := []AccountPosition{
+		{Nickname: "Clear", Symbol: "ITUB4", Quantity: 12},
+		{Nickname: "Clear 2", Symbol: "ITUB4", Quantity: 8},
+		{Symbol: "BBDC4", Quantity: 10},
+		{Symbol: "MGLU3", Quantity: 10},
+		{Symbol: "MGLU3", Quantity: 5},
+		{Symbol: "WEGE3", Quantity: 50},
+		{Symbol: "VALE3", Quantity: 15},
+		{Symbol: "B3SA3", Quantity: 100},
+	}
+
+	expectedReconciliation := []CustodyReconciliation{
+		{Symbol: "B3SA3", Brokerage: "Clear", CustodyQuantity: 0,
+			OrdersQuantity: 100, Difference: -100,
+			Status: CustodyMissingCustody, Fix: &CustodyFix{
+				Action: CustodyFixSellOrder, Quantity: -100, Date: date}},
+		{Symbol: "BBDC4", Brokerage: "Clear", CustodyQuantity: 30,
+			OrdersQuantity: 10, Difference: 20,
+			Status: CustodyQuantityMismatch, Fix: &CustodyFix{
+				Action: CustodyFixSplit, Ratio: 3, Date: date}},
+		{Symbol: "ITUB4", Brokerage: "Clear", CustodyQuantity: 20,
+			OrdersQuantity: 20, Status: CustodyMatch},
+		{Symbol: "MGLU3", Brokerage: "Clear", CustodyQuantity: 40,
+			OrdersQuantity: 15, Difference: 25,
+			Status: CustodyQuantityMismatch, Fix: &CustodyFix{
+				Action: CustodyFixBuyOrder, Quantity: 25, Date: date}},
+		{Symbol: "PETR4", Brokerage: "Clear", CustodyQuantity: 7.5,
+			OrdersQuantity: 0, Difference: 7.5, Status: CustodyMissingOrders,
+			Fix: &CustodyFix{Action: CustodyFixBuyOrder, Quantity: 7.5,
+				Date: date}},
+		{Symbol: "VALE3", Brokerage: "Clear", CustodyQuantity: 12,
+			OrdersQuantity: 15, Difference: -3,
+			Status: CustodyQuantityMismatch, Fix: &CustodyFix{
+				Action: CustodyFixSellOrder, Quantity: -3, Date: date}},
+		{Symbol: "WEGE3", Brokerage: "Clear", CustodyQuantity: 5,
+			OrdersQuantity: 50, Difference: -45,
+			Status: CustodyQuantityMismatch, Fix: &CustodyFix{
+				Action: CustodyFixReverseSplit, Ratio: 10, Date: date}},
+	}
+
+	assert.Equal(t, expectedReconciliation, ReconcileCustody(snapshot,
+		positions))
+
+	// Every position is flagged when nothing is in custody
+	snapshot.Positions = nil
+	assert.Equal(t, []CustodyReconciliation{
+		{Symbol: "ITUB4", Brokerage: "Clear", OrdersQuantity: 12,
+			Difference: -12, Status: CustodyMissingCustody,
+			Fix: &CustodyFix{Action: CustodyFixSellOrder, Quantity: -12,
+				Date: date}},
+	}, ReconcileCustody(snapshot, positions[:1]))
+
+	assert.Equal(t, []CustodyReconciliation{}, ReconcileCustody(snapshot, nil))
+}
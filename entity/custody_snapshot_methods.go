@@ -0,0 +1,154 @@
+package entity
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Status of the custody reconciliation.
+const (
+	CustodyMatch            = "MATCH"
+	CustodyQuantityMismatch = "QUANTITY_MISMATCH"
+	CustodyMissingOrders    = "MISSING_ORDERS"
+	CustodyMissingCustody   = "MISSING_CUSTODY"
+)
+
+const (
+	CustodyFixBuyOrder     = "CREATE_BUY_ORDER"
+	CustodyFixSellOrder    = "CREATE_SELL_ORDER"
+	CustodyFixSplit        = "REGISTER_SPLIT"
+	CustodyFixReverseSplit = "REGISTER_REVERSE_SPLIT"
+)
+
+// Validate returns an error when the snapshot can not be reconciled: it has no
+// brokerage, its date is in the future or a position has no symbol or has a
+// negative quantity.
+func (s *CustodySnapshot) Validate() error {
+	if s.Brokerage == nil || s.Brokerage.Id == "" {
+		return ErrInvalidCustodyBrokerage
+	}
+
+	if s.Date.IsZero() || s.Date.After(time.Now()) {
+		return ErrInvalidCustodyDate
+	}
+
+	for _, position := range s.Positions {
+		if strings.TrimSpace(position.Symbol) == "" || position.Quantity < 0 {
+			return ErrInvalidCustodyPosition
+		}
+	}
+
+	return nil
+}
+
+// ReconcileCustody compares the custody snapshot of a brokerage with the
+// positions of its accounts computed from the orders until the date of the
+// snapshot. The positions of the accounts are summed. Every asset is returned,
+// sorted by symbol, and the assets whose quantities differ come with the fix
+// that makes the orders match the custody.
+func ReconcileCustody(snapshot CustodySnapshot,
+	accountPositions []AccountPosition) []CustodyReconciliation {
+
+	custody := map[string]float64{}
+	orders := map[string]float64{}
+
+	for _, position := range snapshot.Positions {
+		custody[strings.ToUpper(position.Symbol)] += position.Quantity
+	}
+
+	for _, position := range accountPositions {
+		orders[strings.ToUpper(position.Symbol)] += position.Quantity
+	}
+
+	symbols := map[string]bool{}
+	for symbol := range custody {
+		symbols[symbol] = true
+	}
+	for symbol := range orders {
+		symbols[symbol] = true
+	}
+
+	var brokerage string
+	if snapshot.Brokerage != nil {
+		brokerage = snapshot.Brokerage.Name
+	}
+
+	reconciliation := []CustodyReconciliation{}
+	for symbol := range symbols {
+		custodyQuantity := roundQuantity(custody[symbol])
+		ordersQuantity := roundQuantity(orders[symbol])
+
+		position := CustodyReconciliation{
+			Symbol:          symbol,
+			Brokerage:       brokerage,
+			CustodyQuantity: custodyQuantity,
+			OrdersQuantity:  ordersQuantity,
+			Difference:      roundQuantity(custodyQuantity - ordersQuantity),
+		}
+
+		switch {
+		case position.Difference == 0:
+			position.Status = CustodyMatch
+		case ordersQuantity == 0:
+			position.Status = CustodyMissingOrders
+		case custodyQuantity == 0:
+			position.Status = CustodyMissingCustody
+		default:
+			position.Status = CustodyQuantityMismatch
+		}
+
+		if position.Status != CustodyMatch {
+			fix := suggestCustodyFix(custodyQuantity, ordersQuantity,
+				position.Difference, snapshot.Date)
+			position.Fix = &fix
+		}
+
+		reconciliation = append(reconciliation, position)
+	}
+
+	sort.Slice(reconciliation, func(i, j int) bool {
+		return reconciliation[i].Symbol < reconciliation[j].Symbol
+	})
+
+	return reconciliation
+}
+
+// suggestCustodyFix suggests a split when one quantity is a whole multiple of
+// the other, which is what a split or a reverse split not registered leaves,
+// and otherwise an order of the difference at the date of the snapshot.
+func suggestCustodyFix(custodyQuantity float64, ordersQuantity float64,
+	difference float64, date time.Time) CustodyFix {
+
+	if ratio, ok := wholeRatio(custodyQuantity, ordersQuantity); ok {
+		return CustodyFix{Action: CustodyFixSplit, Ratio: ratio, Date: date}
+	}
+
+	if ratio, ok := wholeRatio(ordersQuantity, custodyQuantity); ok {
+		return CustodyFix{Action: CustodyFixReverseSplit, Ratio: ratio,
+			Date: date}
+	}
+
+	action := CustodyFixBuyOrder
+	if difference < 0 {
+		action = CustodyFixSellOrder
+	}
+
+	return CustodyFix{Action: action, Quantity: difference, Date: date}
+}
+
+// wholeRatio returns the ratio between the quantities when it is a whole
+// number greater than one.
+func wholeRatio(quantity float64, base float64) (float64, bool) {
+	if base <= 0 || quantity <= base {
+		return 0, false
+	}
+
+	ratio := quantity / base
+	if ratio < 2 || math.Abs(ratio-math.Round(ratio)) > 1e-8 {
+		return 0, false
+	}
+
+	return math.Round(ratio), true
+}
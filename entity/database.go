@@ -1,6 +1,9 @@
 package entity
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type OrderInfos struct {
 	TotalQuantity        float64 `json:"totalQuantity,omitempty"`
@@ -11,19 +14,155 @@ type OrderInfos struct {
 type Sector struct {
 	Id        string    `db:"id" json:",omitempty"`
 	Name      string    `db:"name" json:",omitempty"`
+	Level     string    `db:"level" json:",omitempty"`
+	Code      string    `db:"code" json:",omitempty"`
+	ParentId  *string   `db:"parent_id" json:",omitempty"`
 	CreatedAt time.Time `db:"created_at" json:",omitempty"`
 	UpdatedAt time.Time `db:"updated_at" json:",omitempty"`
 }
 
+// SectorMapping links a sector label returned by a provider to a node of the
+// sector taxonomy. The Sector is nil while the label is not mapped.
+type SectorMapping struct {
+	Id        string    `db:"id" json:",omitempty"`
+	Provider  string    `db:"provider" json:",omitempty"`
+	Label     string    `db:"label" json:",omitempty"`
+	Sector    *Sector   `db:"sector" json:",omitempty"`
+	CreatedAt time.Time `db:"created_at" json:",omitempty"`
+	UpdatedAt time.Time `db:"updated_at" json:",omitempty"`
+}
+
+// SectorPosition is the amount invested by the user in an asset, used to
+// calculate the allocation per sector.
+type SectorPosition struct {
+	AssetId  string  `db:"asset_id" json:",omitempty"`
+	Symbol   string  `db:"symbol" json:",omitempty"`
+	SectorId string  `db:"sector_id" json:",omitempty"`
+	Currency string  `db:"currency" json:",omitempty"`
+	Quantity float64 `db:"quantity" json:",omitempty"`
+	Invested float64 `db:"invested" json:",omitempty"`
+}
+
+type SectorAllocation struct {
+	Sector     *Sector  `json:",omitempty"`
+	Currency   string   `json:",omitempty"`
+	Invested   float64  `json:",omitempty"`
+	Percentage float64  `json:",omitempty"`
+	Symbols    []string `json:",omitempty"`
+}
+
 type Brokerage struct {
 	Id        string    `db:"id" json:",omitempty"`
 	Name      string    `db:"name" json:",omitempty"`
 	Fullname  string    `db:"fullname" json:",omitempty"`
 	Country   string    `db:"country" json:",omitempty"`
+	UserUid   *string   `db:"user_uid" json:",omitempty"`
 	CreatedAt time.Time `db:"created_at" json:",omitempty"`
 	UpdatedAt time.Time `db:"updated_at" json:",omitempty"`
 }
 
+// BrokerageAccount is the custody location of the user assets, identified by
+// the brokerage and a nickname chosen by the user.
+type BrokerageAccount struct {
+	Id        string     `db:"id" json:",omitempty"`
+	Nickname  string     `db:"nickname" json:",omitempty"`
+	Brokerage *Brokerage `db:"brokerage" json:",omitempty"`
+	UserUid   string     `db:"user_uid" json:",omitempty"`
+	CreatedAt time.Time  `db:"created_at" json:",omitempty"`
+	UpdatedAt time.Time  `db:"updated_at" json:",omitempty"`
+}
+
+// AccountPosition is the current quantity of an asset held by the user in a
+// brokerage account and the amount invested on it.
+type AccountPosition struct {
+	AccountId     string  `db:"account_id" json:",omitempty"`
+	Nickname      string  `db:"nickname" json:",omitempty"`
+	BrokerageId   string  `db:"brokerage_id" json:",omitempty"`
+	BrokerageName string  `db:"brokerage_name" json:",omitempty"`
+	AssetId       string  `db:"asset_id" json:",omitempty"`
+	Symbol        string  `db:"symbol" json:",omitempty"`
+	Currency      string  `db:"currency" json:",omitempty"`
+	Quantity      float64 `db:"quantity" json:",omitempty"`
+	Invested      float64 `db:"invested" json:",omitempty"`
+}
+
+// CashMovement is a movement of money registered by the user in a brokerage
+// account. The amount is negative when the money leaves the account. A
+// transfer leaves the Account and enters the ToAccount.
+type CashMovement struct {
+	Id          string            `db:"id" json:",omitempty"`
+	Type        string            `db:"type" json:",omitempty"`
+	Amount      float64           `db:"amount" json:",omitempty"`
+	Currency    string            `db:"currency" json:",omitempty"`
+	Date        time.Time         `db:"date" json:",omitempty"`
+	Description string            `db:"description" json:",omitempty"`
+	Account     *BrokerageAccount `db:"account" json:",omitempty"`
+	ToAccount   *BrokerageAccount `db:"to_account" json:",omitempty"`
+	UserUid     string            `db:"user_uid" json:",omitempty"`
+	CreatedAt   time.Time         `db:"created_at" json:",omitempty"`
+	UpdatedAt   time.Time         `db:"updated_at" json:",omitempty"`
+}
+
+// CashEntry is an entry of the cash ledger of a brokerage account, posted by a
+// cash movement, an order or an earning. The Balance is the balance of the
+// account in the currency after the entry.
+type CashEntry struct {
+	Source      string    `db:"source" json:",omitempty"`
+	SourceId    string    `db:"source_id" json:",omitempty"`
+	Type        string    `db:"type" json:",omitempty"`
+	Amount      float64   `db:"amount" json:",omitempty"`
+	Currency    string    `db:"currency" json:",omitempty"`
+	Date        time.Time `db:"date" json:",omitempty"`
+	Description string    `db:"description" json:",omitempty"`
+	AccountId   string    `db:"account_id" json:",omitempty"`
+	Nickname    string    `db:"nickname" json:",omitempty"`
+	Balance     float64   `db:"-" json:",omitempty"`
+}
+
+// CashBalance is the money of the user in a brokerage account in a currency.
+type CashBalance struct {
+	AccountId string
+	Nickname  string
+	Currency  string
+	Balance   float64
+}
+
+// Remittance is money sent from one currency to another, like BRL sent from
+// Brazil to a US brokerage account. The SentAmount has the IOF and the fees
+// paid, and the FromAccount is nil when the money did not leave a brokerage
+// account.
+type Remittance struct {
+	Id               string            `db:"id" json:",omitempty"`
+	SentAmount       float64           `db:"sent_amount" json:",omitempty"`
+	SentCurrency     string            `db:"sent_currency" json:",omitempty"`
+	ReceivedAmount   float64           `db:"received_amount" json:",omitempty"`
+	ReceivedCurrency string            `db:"received_currency" json:",omitempty"`
+	Iof              float64           `db:"iof" json:",omitempty"`
+	Fees             float64           `db:"fees" json:",omitempty"`
+	CommercialRate   float64           `db:"commercial_rate" json:",omitempty"`
+	Date             time.Time         `db:"date" json:",omitempty"`
+	FromAccount      *BrokerageAccount `db:"from_account" json:",omitempty"`
+	ToAccount        *BrokerageAccount `db:"to_account" json:",omitempty"`
+	UserUid          string            `db:"user_uid" json:",omitempty"`
+	CreatedAt        time.Time         `db:"created_at" json:",omitempty"`
+	UpdatedAt        time.Time         `db:"updated_at" json:",omitempty"`
+}
+
+// BrlCostBasis is the cost of a position bought in a foreign currency, in that
+// currency and in BRL. The FxRate is the average rate paid for the currency
+// used in the buys. The buys made before any remittance of the currency are
+// counted in OrdersWithoutRate, and are not in the BrlInvested nor in the
+// FxRate.
+type BrlCostBasis struct {
+	Symbol            string
+	Currency          string
+	Quantity          float64
+	Invested          float64
+	BrlInvested       float64
+	FxRate            float64
+	OrdersWithoutRate int
+}
+
 type AssetType struct {
 	Id        string    `db:"id" json:",omitempty"`
 	Type      string    `db:"type" json:",omitempty"`
@@ -34,44 +173,401 @@ type AssetType struct {
 	Assets    []Asset   `db:"assets" json:",omitempty"`
 }
 
+type Market struct {
+	Country           string    `db:"country" json:",omitempty"`
+	Name              string    `db:"name" json:",omitempty"`
+	Currencies        []string  `db:"currencies" json:",omitempty"`
+	ReferenceCurrency string    `db:"reference_currency" json:",omitempty"`
+	SymbolSuffix      string    `db:"symbol_suffix" json:",omitempty"`
+	QuantityDecimals  int       `db:"quantity_decimals" json:",omitempty"`
+	Provider          string    `db:"provider" json:",omitempty"`
+	AssetTypes        []string  `db:"asset_types" json:",omitempty"`
+	CreatedAt         time.Time `db:"created_at" json:",omitempty"`
+	UpdatedAt         time.Time `db:"updated_at" json:",omitempty"`
+}
+
 type Asset struct {
-	Id         string       `db:"id"`
-	Preference *string      `db:"preference"`
-	Fullname   string       `db:"fullname"`
-	Symbol     string       `db:"symbol"`
-	Sector     *Sector      `db:"sector" json:",omitempty"`
-	AssetType  *AssetType   `db:"asset_type" json:",omitempty"`
-	CreatedAt  time.Time    `db:"created_at" json:",omitempty"`
-	UpdatedAt  time.Time    `db:"updated_at" json:",omitempty"`
-	OrderInfo  *OrderInfos  `db:"orders_info" json:",omitempty"`
-	OrdersList []Order      `db:"orders_list" json:",omitempty"`
-	Price      *SymbolPrice `json:",omitempty"`
+	Id          string       `db:"id"`
+	Preference  *string      `db:"preference"`
+	Fullname    string       `db:"fullname"`
+	Symbol      string       `db:"symbol"`
+	Sector      *Sector      `db:"sector" json:",omitempty"`
+	AssetType   *AssetType   `db:"asset_type" json:",omitempty"`
+	CreatedAt   time.Time    `db:"created_at" json:",omitempty"`
+	UpdatedAt   time.Time    `db:"updated_at" json:",omitempty"`
+	OrderInfo   *OrderInfos  `db:"orders_info" json:",omitempty"`
+	OrdersList  []Order      `db:"orders_list" json:",omitempty"`
+	Price       *SymbolPrice `json:",omitempty"`
+	FixedIncome *FixedIncome `db:"-" json:",omitempty"`
+	// SectorMapping is the label of the provider that classified the sector
+	// of an asset not created yet.
+	SectorMapping *SectorMapping `db:"-" json:",omitempty"`
 }
 
 type Order struct {
-	Id        string     `db:"id" json:",omitempty"`
-	Quantity  float64    `db:"quantity" json:",omitempty"`
-	Price     float64    `db:"price" json:",omitempty"`
-	Currency  string     `db:"currency" json:",omitempty"`
-	OrderType string     `db:"order_type" json:",omitempty"`
-	Date      time.Time  `db:"date" json:",omitempty"`
-	Brokerage *Brokerage `db:"brokerage" json:",omitempty"`
-	Asset     *Asset     `db:"asset" json:",omitempty"`
-	UserUid   string     `db:"user_uid" json:",omitempty"`
-	CreatedAt time.Time  `db:"created_at" json:",omitempty"`
-	UpdatedAt time.Time  `db:"updated_at" json:",omitempty"`
+	Id             string            `db:"id" json:",omitempty"`
+	Quantity       float64           `db:"quantity" json:",omitempty"`
+	Price          float64           `db:"price" json:",omitempty"`
+	Currency       string            `db:"currency" json:",omitempty"`
+	OrderType      string            `db:"order_type" json:",omitempty"`
+	Date           time.Time         `db:"date" json:",omitempty"`
+	Fees           float64           `db:"fees" json:",omitempty"`
+	WithheldTax    float64           `db:"withheld_tax" json:",omitempty"`
+	SettlementDate *time.Time        `db:"settlement_date" json:",omitempty"`
+	Brokerage      *Brokerage        `db:"brokerage" json:",omitempty"`
+	Account        *BrokerageAccount `db:"account" json:",omitempty"`
+	Asset          *Asset            `db:"asset" json:",omitempty"`
+	UserUid        string            `db:"user_uid" json:",omitempty"`
+	CreatedAt      time.Time         `db:"created_at" json:",omitempty"`
+	UpdatedAt      time.Time         `db:"updated_at" json:",omitempty"`
+}
+
+// OrderImportRow is a row of an imported orders file, with the problems found
+// while validating it. NewAsset marks the symbols created by the import and
+// Duplicate marks the orders already registered, which are not imported
+// again. The WithheldTax is the income tax withheld on the sell orders, the
+// IRRF.
+type OrderImportRow struct {
+	Line           int
+	Symbol         string
+	Country        string
+	OrderType      string
+	Quantity       float64
+	Price          float64
+	Currency       string
+	Brokerage      string
+	Date           string
+	Fees           float64
+	WithheldTax    float64
+	SettlementDate string
+	NewAsset       bool
+	Duplicate      bool
+	Errors         []string
+}
+
+// EarningImportRow is an earning read from an imported file. The dividends
+// have the income tax withheld at the source in the WithheldTax.
+type EarningImportRow struct {
+	Line        int
+	Symbol      string
+	Country     string
+	EarningType string
+	Amount      float64
+	WithheldTax float64
+	Currency    string
+	Date        string
+	NewAsset    bool
+	Duplicate   bool
+	Errors      []string
+}
+
+// BrokerStatement is the activity of an account exported by a brokerage,
+// with the orders and earnings of the account.
+type BrokerStatement struct {
+	Format    string
+	Brokerage string
+	Orders    []OrderImportRow
+	Earnings  []EarningImportRow
+}
+
+// PortfolioExport has all the data of a user: the assets, the orders, the
+// earnings and the current positions computed from the orders.
+type PortfolioExport struct {
+	Assets    []Asset
+	Orders    []Order
+	Earnings  []Earnings
+	Positions []AccountPosition
+}
+
+// PortfolioBackup is the portfolio of a user without the ids of the database,
+// so it can be restored in another account or in another instance. The assets
+// are identified by their symbol and country and the accounts by their
+// nickname. The dates are written in the YYYY-MM-DD layout.
+type PortfolioBackup struct {
+	Version   int             `json:"version"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Accounts  []BackupAccount `json:"accounts"`
+	Assets    []BackupAsset   `json:"assets"`
+	Orders    []BackupOrder   `json:"orders"`
+	Earnings  []BackupEarning `json:"earnings"`
+}
+
+type BackupAccount struct {
+	Nickname          string `json:"nickname"`
+	Brokerage         string `json:"brokerage"`
+	BrokerageFullname string `json:"brokerageFullname,omitempty"`
+	Country           string `json:"country"`
+}
+
+type BackupAsset struct {
+	Symbol     string `json:"symbol"`
+	Country    string `json:"country"`
+	Fullname   string `json:"fullname"`
+	Preference string `json:"preference,omitempty"`
+	AssetType  string `json:"assetType"`
+	Sector     string `json:"sector"`
+}
+
+type BackupOrder struct {
+	Symbol         string  `json:"symbol"`
+	Country        string  `json:"country"`
+	Account        string  `json:"account"`
+	OrderType      string  `json:"orderType"`
+	Quantity       float64 `json:"quantity"`
+	Price          float64 `json:"price"`
+	Currency       string  `json:"currency"`
+	Date           string  `json:"date"`
+	Fees           float64 `json:"fees,omitempty"`
+	WithheldTax    float64 `json:"withheldTax,omitempty"`
+	SettlementDate string  `json:"settlementDate,omitempty"`
+}
+
+type BackupEarning struct {
+	Symbol      string  `json:"symbol"`
+	Country     string  `json:"country"`
+	EarningType string  `json:"earningType"`
+	Amount      float64 `json:"amount"`
+	WithheldTax float64 `json:"withheldTax,omitempty"`
+	Currency    string  `json:"currency"`
+	Date        string  `json:"date"`
+}
+
+// BackupRestore counts what was created by the restore of a backup. The orders
+// and earnings already registered are skipped.
+type BackupRestore struct {
+	Accounts        int
+	Assets          int
+	Orders          int
+	Earnings        int
+	SkippedOrders   int
+	SkippedEarnings int
+}
+
+// TradeNote is a brokerage trade note (nota de corretagem) with the orders of a
+// trading day. The fees and the withheld income tax of the note are split
+// between its orders.
+type TradeNote struct {
+	Number         string
+	Brokerage      string
+	TradeDate      time.Time
+	SettlementDate time.Time
+	Fees           float64
+	WithheldTax    float64
+	NetAmount      float64
+	Orders         []TradeNoteOrder
+}
+
+// TradeNoteOrder is a trade of a note. The Symbol is blank when the note has
+// only the name of the company, which is used to search the symbol with the
+// SymbolSuffix of its share class.
+type TradeNoteOrder struct {
+	Market        string
+	OrderType     string
+	Specification string
+	Symbol        string
+	Company       string
+	SymbolSuffix  string
+	Quantity      float64
+	Price         float64
+	Amount        float64
+	Fees          float64
+	WithheldTax   float64
+}
+
+// B3Report is a report exported from the investor area of the B3. The trades
+// report fills the Trades, the movements report fills the Movements and the
+// custody report fills the Positions. The other fields are the result of its
+// import, like the Date of the custody.
+type B3Report struct {
+	Type           string
+	Date           time.Time
+	Trades         []OrderImportRow
+	Movements      []B3Movement
+	Positions      []B3Position
+	Orders         []Order
+	Earnings       []Earnings
+	Reconciliation []CustodyReconciliation
+}
+
+// B3Movement is a movement of the custody of the user, like an earning or a
+// corporate event. The movements which are neither are Ignored, like the
+// settlement of the trades, already imported from the trades report.
+type B3Movement struct {
+	Line        int
+	Direction   string
+	Date        string
+	Movement    string
+	Symbol      string
+	Brokerage   string
+	Quantity    float64
+	Price       float64
+	Amount      float64
+	EarningType string
+	OrderType   string
+	Ignored     bool
+	NewAsset    bool
+	Duplicate   bool
+	Errors      []string
+}
+
+// B3Position is the quantity of an asset in custody in a brokerage.
+type B3Position struct {
+	Sheet     string
+	Line      int
+	Symbol    string
+	Brokerage string
+	Quantity  float64
+	Errors    []string
+}
+
+// CustodyReconciliation compares the quantity of an asset in custody in a
+// brokerage with the quantity computed from the orders until the date of the
+// custody. The assets whose quantities differ have the fix suggested for them.
+type CustodyReconciliation struct {
+	Symbol          string
+	Brokerage       string
+	CustodyQuantity float64
+	OrdersQuantity  float64
+	Difference      float64
+	Status          string
+	Fix             *CustodyFix
+}
+
+// CustodySnapshot is the custody of the user in a brokerage at a date, read
+// from a custody report or informed by the user.
+type CustodySnapshot struct {
+	Brokerage *Brokerage
+	Date      time.Time
+	Positions []CustodyPosition
+}
+
+// CustodyPosition is the quantity of an asset in custody.
+type CustodyPosition struct {
+	Symbol   string
+	Quantity float64
+}
+
+// CustodyFix is the change suggested for the orders to match the custody: an
+// order of the Quantity, negative for the sells, or a split of the Ratio.
+type CustodyFix struct {
+	Action   string
+	Quantity float64
+	Ratio    float64
+	Date     time.Time
 }
 
 type Earnings struct {
-	Id        string    `json:"id"`
-	Type      string    `json:"type"`
-	Earning   float64   `json:"earning"`
-	Currency  string    `json:"currency"`
-	Date      time.Time `json:"date"`
-	Asset     *Asset    `db:"asset" json:",omitempty"`
-	UserUid   string    `db:"user_uid" json:",omitempty"`
-	CreatedAt time.Time `db:"created_at" json:",omitempty"`
-	UpdatedAt time.Time `db:"updated_at" json:",omitempty"`
+	Id             string     `json:"id"`
+	Type           string     `json:"type"`
+	Earning        float64    `json:"earning"`
+	WithheldTax    float64    `db:"withheld_tax" json:",omitempty"`
+	Currency       string     `json:"currency"`
+	Date           time.Time  `json:"date"`
+	Asset          *Asset     `db:"asset" json:",omitempty"`
+	UserUid        string     `db:"user_uid" json:",omitempty"`
+	EarningEventId *string    `db:"earning_event_id" json:",omitempty"`
+	ExDate         *time.Time `db:"ex_date" json:",omitempty"`
+	CreatedAt      time.Time  `db:"created_at" json:",omitempty"`
+	UpdatedAt      time.Time  `db:"updated_at" json:",omitempty"`
+}
+
+type EarningEvent struct {
+	Id          string    `db:"id" json:",omitempty"`
+	Type        string    `db:"type" json:",omitempty"`
+	Amount      float64   `db:"amount" json:",omitempty"`
+	Currency    string    `db:"currency" json:",omitempty"`
+	ExDate      time.Time `db:"ex_date" json:",omitempty"`
+	PaymentDate time.Time `db:"payment_date" json:",omitempty"`
+	Asset       *Asset    `db:"asset" json:",omitempty"`
+	CreatedAt   time.Time `db:"created_at" json:",omitempty"`
+	UpdatedAt   time.Time `db:"updated_at" json:",omitempty"`
+}
+
+type FixedIncome struct {
+	Id        string               `db:"id" json:",omitempty"`
+	Category  string               `db:"category" json:",omitempty"`
+	Issuer    string               `db:"issuer" json:",omitempty"`
+	Indexer   string               `db:"indexer" json:",omitempty"`
+	Rate      float64              `db:"rate" json:",omitempty"`
+	Maturity  time.Time            `db:"maturity" json:",omitempty"`
+	Asset     *Asset               `db:"asset" json:",omitempty"`
+	CreatedAt time.Time            `db:"created_at" json:",omitempty"`
+	UpdatedAt time.Time            `db:"updated_at" json:",omitempty"`
+	Position  *FixedIncomePosition `db:"-" json:",omitempty"`
+}
+
+type Option struct {
+	Id         string    `db:"id" json:",omitempty"`
+	OptionType string    `db:"option_type" json:",omitempty"`
+	Strike     float64   `db:"strike" json:",omitempty"`
+	Expiry     time.Time `db:"expiry" json:",omitempty"`
+	Settled    bool      `db:"settled" json:",omitempty"`
+	Asset      *Asset    `db:"asset" json:",omitempty"`
+	Underlying *Asset    `db:"underlying" json:",omitempty"`
+	CreatedAt  time.Time `db:"created_at" json:",omitempty"`
+	UpdatedAt  time.Time `db:"updated_at" json:",omitempty"`
+}
+
+type CompanyProfile struct {
+	Logo          string     `db:"logo" json:",omitempty"`
+	Website       string     `db:"website" json:",omitempty"`
+	Exchange      string     `db:"exchange" json:",omitempty"`
+	MarketCap     float64    `db:"market_cap" json:",omitempty"`
+	PeRatio       float64    `db:"pe_ratio" json:",omitempty"`
+	DividendYield float64    `db:"dividend_yield" json:",omitempty"`
+	IpoDate       *time.Time `db:"ipo_date" json:",omitempty"`
+	Asset         *Asset     `db:"asset" json:",omitempty"`
+	CreatedAt     time.Time  `db:"created_at" json:",omitempty"`
+	UpdatedAt     time.Time  `db:"updated_at" json:",omitempty"`
+}
+
+type IndexSeries struct {
+	Indexer string    `db:"indexer" json:",omitempty"`
+	Date    time.Time `db:"date" json:",omitempty"`
+	Value   float64   `db:"value" json:",omitempty"`
+}
+
+type FixedIncomePosition struct {
+	Quantity          float64 `json:",omitempty"`
+	InvestedValue     float64 `json:",omitempty"`
+	GrossValue        float64 `json:",omitempty"`
+	IncomeTax         float64 `json:",omitempty"`
+	NetValue          float64 `json:",omitempty"`
+	RedeemedValue     float64 `json:",omitempty"`
+	RedeemedIncomeTax float64 `json:",omitempty"`
+}
+
+type ProjectedIncome struct {
+	Asset     *Asset  `json:",omitempty"`
+	Currency  string  `json:",omitempty"`
+	Confirmed float64 `json:",omitempty"`
+	Estimated float64 `json:",omitempty"`
+}
+
+type IncomeProjectionMonth struct {
+	Month  time.Time         `json:",omitempty"`
+	Totals []ProjectedIncome `json:",omitempty"`
+	Assets []ProjectedIncome `json:",omitempty"`
+}
+
+type IncomeProjection struct {
+	StartDate time.Time               `json:",omitempty"`
+	EndDate   time.Time               `json:",omitempty"`
+	Totals    []ProjectedIncome       `json:",omitempty"`
+	Months    []IncomeProjectionMonth `json:",omitempty"`
+}
+
+// AuditLog records a change made by an admin in the data shared by every
+// user. Before and After hold the JSON of the entity around the change.
+type AuditLog struct {
+	Id         string          `db:"id" json:",omitempty"`
+	UserUid    string          `db:"user_uid" json:",omitempty"`
+	Action     string          `db:"action" json:",omitempty"`
+	EntityType string          `db:"entity_type" json:",omitempty"`
+	EntityId   string          `db:"entity_id" json:",omitempty"`
+	Before     json.RawMessage `db:"before" json:",omitempty"`
+	After      json.RawMessage `db:"after" json:",omitempty"`
+	CreatedAt  time.Time       `db:"created_at" json:",omitempty"`
 }
 
 type AssetUsers struct {
@@ -88,12 +584,32 @@ type Users struct {
 	UpdatedAt time.Time `db:"updated_at" json:",omitempty"`
 }
 
+// UserIdentity is a Firebase user, of another authentication provider, linked
+// to an user. It accesses the portfolio of the user it was linked to.
+type UserIdentity struct {
+	FirebaseUid string    `db:"firebase_uid"`
+	UserUid     string    `db:"user_uid"`
+	ProviderId  string    `db:"provider_id"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
 type SymbolLookup struct {
 	Fullname string `json:",omitempty"`
 	Symbol   string `json:",omitempty"`
 	Type     string `json:",omitempty"`
 }
 
+// SymbolSuggestion is a result of the local symbol search. The AssetId is only
+// filled when the symbol is already registered in the assets table.
+type SymbolSuggestion struct {
+	Symbol   string  `db:"symbol" json:",omitempty"`
+	Fullname string  `db:"fullname" json:",omitempty"`
+	Type     string  `db:"type" json:",omitempty"`
+	Country  string  `db:"country" json:",omitempty"`
+	AssetId  *string `db:"asset_id" json:",omitempty"`
+	Rank     float64 `db:"rank" json:",omitempty"`
+}
+
 type SymbolPrice struct {
 	Symbol         string  `json:",omitempty"`
 	CurrentPrice   float64 `json:",omitempty"`
@@ -102,6 +618,29 @@ type SymbolPrice struct {
 	OpenPrice      float64 `json:",omitempty"`
 	PrevClosePrice float64 `json:",omitempty"`
 	MarketCap      float64 `json:",omitempty"`
+	Currency       string  `json:",omitempty"`
+}
+
+type SymbolPriceHistory struct {
+	Symbol     string    `json:",omitempty"`
+	Date       time.Time `json:",omitempty"`
+	OpenPrice  float64   `json:",omitempty"`
+	HighPrice  float64   `json:",omitempty"`
+	LowPrice   float64   `json:",omitempty"`
+	ClosePrice float64   `json:",omitempty"`
+	Volume     float64   `json:",omitempty"`
+}
+
+type SymbolPriceQuery struct {
+	Symbol  string
+	Country string
+}
+
+type SymbolPriceResult struct {
+	Symbol  string
+	Country string
+	Price   *SymbolPrice
+	Err     error
 }
 
 type UserInfo struct {
@@ -133,6 +672,7 @@ type UserTokenInfo struct {
 	Email         string
 	EmailVerified bool
 	UserID        string
+	ProviderId    string
 }
 
 type UserLoginResponse struct {
@@ -154,17 +694,18 @@ type UserRefreshTokenResponse struct {
 }
 
 type UserInfoOAuth2 struct {
-	Email           string                 `json:"email"`
-	EmailVerified   bool                   `json:"emailVerified"`
-	Fullname        string                 `json:"fullName"`
-	UserUid         string                 `json:"localId"`
-	IdToken         string                 `json:"idToken"`
-	RefreshToken    string                 `json:"refreshToken"`
-	Expiration      string                 `json:"expiresIn"`
-	OAuthIdToken    string                 `json:"oauthIdToken,omitempty"`
-	OAuthAccesToken string                 `json:"oauthAccessToken,omitempty"`
-	IsNewUser       bool                   `json:"isNewUser"`
-	Error           map[string]interface{} `json:"error,omitempty"`
+	Email            string                 `json:"email"`
+	EmailVerified    bool                   `json:"emailVerified"`
+	Fullname         string                 `json:"fullName"`
+	UserUid          string                 `json:"localId"`
+	IdToken          string                 `json:"idToken"`
+	RefreshToken     string                 `json:"refreshToken"`
+	Expiration       string                 `json:"expiresIn"`
+	OAuthIdToken     string                 `json:"oauthIdToken,omitempty"`
+	OAuthAccesToken  string                 `json:"oauthAccessToken,omitempty"`
+	IsNewUser        bool                   `json:"isNewUser"`
+	NeedConfirmation bool                   `json:"needConfirmation"`
+	Error            map[string]interface{} `json:"error,omitempty"`
 }
 
 var ValidEarningTypes map[string]bool = map[string]bool{"Dividendos": true,
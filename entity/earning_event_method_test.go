@@ -0,0 +1,123 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEarningEvent(t *testing.T) {
+	type test struct {
+		earningType   string
+		amount        float64
+		currency      string
+		exDate        string
+		expectedError error
+	}
+
+	tests := []test{
+		{
+			earningType:   "JCP",
+			amount:        0.35,
+			currency:      "BRL",
+			exDate:        "2021-10-05",
+			expectedError: nil,
+		},
+		{
+			earningType:   "Bonus",
+			amount:        0.35,
+			currency:      "BRL",
+			exDate:        "2021-10-05",
+			expectedError: ErrInvalidEarningType,
+		},
+		{
+			earningType:   "Dividendos",
+			amount:        0,
+			currency:      "BRL",
+			exDate:        "2021-10-05",
+			expectedError: ErrInvalidEarningsAmount,
+		},
+		{
+			earningType:   "Dividendos",
+			amount:        0.35,
+			currency:      "BRL",
+			exDate:        "",
+			expectedError: ErrInvalidEarningEventExDate,
+		},
+		{
+			earningType:   "Dividendos",
+			amount:        0.35,
+			currency:      "EUR",
+			exDate:        "2021-10-05",
+			expectedError: ErrInvalidCurrency,
+		},
+	}
+
+	for _, testCase := range tests {
+		_, err := NewEarningEvent(testCase.earningType, testCase.amount,
+			testCase.currency, StringToTime(testCase.exDate),
+			StringToTime("2021-10-20"), "TestAssetID")
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+func TestEarningEventEarning(t *testing.T) {
+	orders := []Order{
+		{Quantity: 100, Date: StringToTime("2021-09-01")},
+		{Quantity: -20, Date: StringToTime("2021-10-04")},
+		{Quantity: 50, Date: StringToTime("2021-10-05")},
+	}
+
+	event := EarningEvent{
+		Id:       "TestEventID",
+		Type:     "Dividendos",
+		Amount:   0.5,
+		Currency: "BRL",
+		ExDate:   StringToTime("2021-10-05"),
+		Asset:    &Asset{Id: "TestAssetID"},
+	}
+
+	quantity := QuantityOnExDate(orders, event.ExDate)
+	assert.Equal(t, 80.0, quantity)
+
+	eventId := "TestEventID"
+	assert.Equal(t, &Earnings{
+		Type:           "Dividendos",
+		Earning:        40,
+		Currency:       "BRL",
+		Date:           StringToTime("2021-10-05"),
+		Asset:          &Asset{Id: "TestAssetID"},
+		UserUid:        "TestUserUID",
+		EarningEventId: &eventId,
+	}, event.Earning(quantity, "TestUserUID"))
+
+	event.PaymentDate = StringToTime("2021-10-20")
+	assert.Equal(t, StringToTime("2021-10-20"),
+		event.Earning(quantity, "TestUserUID").Date)
+}
+
+func TestUnregisteredEarnings(t *testing.T) {
+	eventId := "TestEventID"
+	otherEventId := "OtherEventID"
+
+	earnings := []Earnings{
+		{Type: "Dividendos", Earning: 40, Currency: "BRL",
+			Date: StringToTime("2021-10-20"), EarningEventId: &eventId},
+		{Type: "JCP", Earning: 12.5, Currency: "BRL",
+			Date: StringToTime("2021-10-20"), EarningEventId: &otherEventId},
+	}
+
+	// The dividend was imported from the B3 statement and the JCP was entered
+	// with another date
+	registered := []Earnings{
+		{Type: "Dividendos", Earning: 40.0000001, Currency: "BRL",
+			Date: StringToTime("2021-10-20")},
+		{Type: "JCP", Earning: 12.5, Currency: "BRL",
+			Date: StringToTime("2021-10-21")},
+	}
+
+	assert.Equal(t, []Earnings{earnings[1]},
+		UnregisteredEarnings(earnings, registered))
+	assert.Equal(t, earnings, UnregisteredEarnings(earnings, nil))
+	assert.Nil(t, UnregisteredEarnings(earnings[:1], registered[:1]))
+}
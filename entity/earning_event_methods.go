@@ -0,0 +1,117 @@
+package entity
+
+import "time"
+
+func NewEarningEvent(earningType string, amount float64, currency string,
+	exDate time.Time, paymentDate time.Time, assetId string) (*EarningEvent,
+	error) {
+
+	event := &EarningEvent{
+		Type:        earningType,
+		Amount:      amount,
+		Currency:    currency,
+		ExDate:      exDate,
+		PaymentDate: paymentDate,
+		Asset:       &Asset{Id: assetId},
+	}
+
+	err := event.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+func (e *EarningEvent) Validate() error {
+	if !ValidEarningTypes[e.Type] {
+		return ErrInvalidEarningType
+	}
+
+	if e.Amount <= 0 {
+		return ErrInvalidEarningsAmount
+	}
+
+	if e.ExDate.IsZero() {
+		return ErrInvalidEarningEventExDate
+	}
+
+	if !IsValidCurrency(e.Currency) {
+		return ErrInvalidCurrency
+	}
+
+	return nil
+}
+
+// Earning returns the earning received by a user holding the given quantity
+// of the asset on the ex-date. The earning is registered on the payment date,
+// or on the ex-date when the payment date was not announced yet.
+func (e *EarningEvent) Earning(quantity float64, userUid string) *Earnings {
+	date := e.PaymentDate
+	if date.IsZero() {
+		date = e.ExDate
+	}
+
+	eventId := e.Id
+
+	return &Earnings{
+		Type:           e.Type,
+		Earning:        quantity * e.Amount,
+		Currency:       e.Currency,
+		Date:           date,
+		Asset:          e.Asset,
+		UserUid:        userUid,
+		EarningEventId: &eventId,
+	}
+}
+
+// QuantityOnExDate sums the orders executed before the ex-date, since only
+// the shares held at the end of the previous trading day receive the earning.
+func QuantityOnExDate(orders []Order, exDate time.Time) float64 {
+	var quantity float64
+
+	for _, order := range orders {
+		if order.Date.Before(exDate) {
+			quantity += order.Quantity
+		}
+	}
+
+	return quantity
+}
+
+// UnregisteredEarnings returns the earnings not registered by the user yet.
+// An earning is already registered when the user has an earning of the asset
+// with the same type, currency, date and amount, like the earnings entered
+// manually or imported from the B3 and broker statements, which are not
+// linked to the earning event. Each registered earning matches one earning.
+func UnregisteredEarnings(earnings []Earnings,
+	registered []Earnings) []Earnings {
+
+	var unregistered []Earnings
+
+	matched := map[int]bool{}
+	for _, earning := range earnings {
+		duplicate := false
+		for j, registeredEarning := range registered {
+			if matched[j] || !earning.sameEarning(registeredEarning) {
+				continue
+			}
+
+			matched[j] = true
+			duplicate = true
+			break
+		}
+
+		if !duplicate {
+			unregistered = append(unregistered, earning)
+		}
+	}
+
+	return unregistered
+}
+
+func (e *Earnings) sameEarning(earning Earnings) bool {
+	return e.Date.Format("2006-01-02") == earning.Date.Format("2006-01-02") &&
+		e.Type == earning.Type && e.Currency == earning.Currency &&
+		sameImportNumber(e.Earning, earning.Earning)
+}
@@ -26,17 +26,26 @@ func NewEarnings(earningType string, earnings float64, currency string,
 }
 
 func (a *Earnings) Validate(country string) error {
-	if a.Currency != "BRL" && a.Currency != "USD" {
+	if !IsValidCurrency(a.Currency) {
 		return ErrInvalidCurrency
 	}
 
-	if a.Currency == "BRL" && country != "BR" {
+	market, err := SearchMarket(country)
+	if err != nil {
+		return err
+	}
+
+	if market.HasCurrency(a.Currency) {
+		return nil
+	}
+
+	if a.Currency == "BRL" {
 		return ErrInvalidBrazilCurrency
 	}
 
-	if a.Currency == "USD" && country != "US" {
+	if a.Currency == "USD" {
 		return ErrInvalidUsaCurrency
 	}
 
-	return nil
+	return market.ValidateCurrency(a.Currency)
 }
@@ -15,6 +15,7 @@ var (
 	ErrInvalidCurrency       error = errors.New("currency: INVALID_CURRENCY_CODE")
 	ErrInvalidBrazilCurrency error = errors.New("currency: must be BRL")
 	ErrInvalidUsaCurrency    error = errors.New("currency: must be USD")
+	ErrInvalidCryptoCurrency error = errors.New("currency: must be BRL or USD")
 )
 
 // Asset
@@ -25,10 +26,79 @@ var (
 	ErrInvalidAssetSymbol              error = errors.New("asset: SYMBOL_NOT_EXIST")
 	ErrInvalidAssetSymbolExist         error = errors.New("asset: SYMBOL_ALREADY_EXIST.")
 	ErrInvalidDeleteAsset              error = errors.New("deleteAsset: ASSET_NOT_EXIST")
+	ErrInvalidAssetPricesBlank         error = errors.New("assetPrices: BLANK_SYMBOL_LIST")
+	ErrInvalidAssetPricesLimit         error = errors.New("assetPrices: TOO_MANY_SYMBOLS")
+	ErrInvalidAssetCryptoProvider      error = errors.New("asset: CRYPTO_PROVIDER_UNAVAILABLE")
+	ErrInvalidAssetClosePrice          error = errors.New("asset: CLOSE_PRICE_NOT_FOUND")
+)
+
+// Symbol Search
+var (
+	ErrInvalidSymbolSearchQuery error = errors.New("symbolSearch: INVALID_QUERY")
+	ErrInvalidSymbolSearchLimit error = errors.New("symbolSearch: INVALID_LIMIT")
+	ErrInvalidSymbolListEntry   error = errors.New("symbolSearch: INVALID_SYMBOL_LIST_ENTRY")
+)
+
+// Market
+var (
+	ErrInvalidMarketProvider       error = errors.New("market: PROVIDER_UNAVAILABLE")
+	ErrInvalidMarketCurrency       error = errors.New("currency: INVALID_MARKET_CURRENCY")
+	ErrInvalidMarketQuantityDigits error = errors.New("orders: QUANTITY_EXCEEDS_DECIMAL_PLACES")
+)
+
+// Calendar
+var (
+	ErrInvalidExchange     error = errors.New("calendar: EXCHANGE_NOT_FOUND")
+	ErrInvalidCalendarDate error = errors.New("calendar: INVALID_DATE")
+)
+
+// Fixed Income
+var (
+	ErrInvalidFixedIncomeBlank    error = errors.New("fixedIncome: BLANK_FIELDS")
+	ErrInvalidFixedIncomeCategory error = errors.New("fixedIncome: INVALID_CATEGORY")
+	ErrInvalidFixedIncomeIndexer  error = errors.New("fixedIncome: INVALID_INDEXER")
+	ErrInvalidFixedIncomeRate     error = errors.New("fixedIncome: RATE_MUST_BE_POSITIVE")
+	ErrInvalidFixedIncomeMaturity error = errors.New("fixedIncome: INVALID_MATURITY_DATE")
+	ErrInvalidFixedIncome         error = errors.New("fixedIncome: NO_FIXED_INCOME_EXIST")
+)
+
+// Option
+var (
+	ErrInvalidOptionType       error = errors.New("option: TYPE_MUST_BE_CALL_OR_PUT")
+	ErrInvalidOptionStrike     error = errors.New("option: STRIKE_MUST_BE_POSITIVE")
+	ErrInvalidOptionExpiry     error = errors.New("option: INVALID_EXPIRY_DATE")
+	ErrInvalidOptionUnderlying error = errors.New("option: UNDERLYING_ASSET_NOT_EXIST")
+	ErrInvalidOptionSymbol     error = errors.New("option: BLANK_SYMBOL")
+)
+
+// Earning Event
+var (
+	ErrInvalidEarningEventExDate   error = errors.New("earningEvent: INVALID_EX_DATE")
+	ErrInvalidEarningEventProvider error = errors.New("earningEvent: MARKET_PROVIDER_WITHOUT_EARNING_EVENTS")
+)
+
+// Company Profile
+var (
+	ErrInvalidCompanyProfileAssetType error = errors.New("companyProfile: ASSET_TYPE_WITHOUT_PROFILE")
+	ErrInvalidCompanyProfile          error = errors.New("companyProfile: PROFILE_NOT_FOUND")
 )
 
 // AssetType
-var ErrInvalidAssetTypeName = errors.New("assetTypeName: INVALID_NAME")
+var (
+	ErrInvalidAssetTypeName  error = errors.New("assetTypeName: INVALID_NAME")
+	ErrInvalidAssetTypeBlank error = errors.New("assetType: BLANK_FIELDS")
+	ErrInvalidAssetTypeId    error = errors.New("assetType: ASSET_TYPE_NOT_EXIST")
+	ErrInvalidAssetTypeExist error = errors.New("assetType: ASSET_TYPE_ALREADY_EXIST")
+	ErrInvalidAssetTypeInUse error = errors.New("assetType: ASSET_TYPE_HAS_ASSETS")
+)
+
+// Audit Log
+var (
+	ErrInvalidAuditLogBlank      error = errors.New("auditLog: BLANK_FIELDS")
+	ErrInvalidAuditLogAction     error = errors.New("auditLog: INVALID_ACTION")
+	ErrInvalidAuditLogEntityType error = errors.New("auditLog: INVALID_ENTITY_TYPE")
+	ErrInvalidAuditLogLimit      error = errors.New("auditLog: INVALID_LIMIT")
+)
 
 // User
 var (
@@ -40,6 +110,10 @@ var (
 	ErrInvalidUserSendEmail      error = errors.New("user: EMAIL_NOT_SENT")
 	ErrInvalidUserAdminPrivilege error = errors.New("user: WITHOUT_ADMIN_PERMISSION")
 	ErrInvalidUserSearch         error = errors.New("searchUser: INVALID_UID")
+	ErrInvalidUserLinkSameUser   error = errors.New("user: LINK_SAME_USER")
+	ErrInvalidUserLinkEmail      error = errors.New("user: LINK_EMAIL_DOES_NOT_MATCH")
+	ErrInvalidUserLinked         error = errors.New("user: ALREADY_LINKED")
+	ErrUserLinkRequired          error = errors.New("user: EMAIL_ALREADY_REGISTERED_LINK_REQUIRED")
 )
 
 // Order
@@ -47,12 +121,75 @@ var (
 	ErrInvalidOrder               error = errors.New("orders: NO_ORDER_EXIST")
 	ErrInvalidOrderType           error = errors.New("orders: INVALID_TYPE_VALUE")
 	ErrInvalidOrderQuantityBrazil error = errors.New("orders: QUANTITY_MUST_BE_INTEGER")
+	ErrInvalidOrderQuantityCrypto error = errors.New("orders: QUANTITY_MAX_8_DECIMAL_PLACES")
 	ErrInvalidOrderBuyQuantity    error = errors.New("orders: QUANTITY_MUST_BE_POSITIVE")
 	ErrInvalidOrderSellQuantity   error = errors.New("orders: QUANTITY_MUST_BE_NEGATIVE")
 	ErrInvalidOrderPrice          error = errors.New("orders: PRICE_MUST_BE_POSITIVE")
+	ErrInvalidOrderDate           error = errors.New("orders: INVALID_DATE")
+	ErrInvalidOrderDateNoSession  error = errors.New("orders: DATE_WITHOUT_TRADING_SESSION")
 	ErrInvalidOrderOrderBy        error = errors.New("orders: INVALID_ORDER_BY_VALUE")
 	ErrInvalidOrderLimit          error = errors.New("orders: LIMIT_MUST_BE_INTEGER")
 	ErrInvalidOrderOffset         error = errors.New("orders: OFFSET_MUST_BE_INTEGER")
+	ErrInvalidOrderFees           error = errors.New("orders: FEES_MUST_BE_POSITIVE")
+)
+
+// Order Import
+var (
+	ErrInvalidOrderImportFile    error = errors.New("orderImport: INVALID_CSV_FILE")
+	ErrInvalidOrderImportEmpty   error = errors.New("orderImport: FILE_WITHOUT_ORDERS")
+	ErrInvalidOrderImportSize    error = errors.New("orderImport: TOO_MANY_ROWS")
+	ErrInvalidOrderImportMapping error = errors.New("orderImport: UNKNOWN_MAPPING_FIELD")
+	ErrInvalidOrderImportColumn  error = errors.New("orderImport: MISSING_COLUMN")
+	ErrInvalidOrderImportNumber  error = errors.New("orderImport: INVALID_NUMBER")
+	ErrInvalidOrderImportBlank   error = errors.New("orderImport: MISSING_VALUES")
+	ErrInvalidOrderImportRows    error = errors.New("orderImport: ROWS_WITH_ERRORS")
+)
+
+// Trade Note
+var (
+	ErrInvalidTradeNote      error = errors.New("tradeNote: NOTE_NOT_FOUND")
+	ErrInvalidTradeNoteTotal error = errors.New("tradeNote: NET_AMOUNT_MISMATCH")
+	ErrInvalidTradeNoteBlank error = errors.New("tradeNote: MISSING_NOTE_TEXT")
+)
+
+// Broker Statement
+var (
+	ErrInvalidBrokerStatement            error = errors.New("brokerStatement: UNKNOWN_FORMAT")
+	ErrInvalidBrokerStatementEmpty       error = errors.New("brokerStatement: STATEMENT_WITHOUT_ROWS")
+	ErrInvalidBrokerStatementDate        error = errors.New("brokerStatement: INVALID_DATE")
+	ErrInvalidBrokerStatementWithholding error = errors.New("brokerStatement: WITHHOLDING_WITHOUT_DIVIDEND")
+)
+
+// Spreadsheet
+var ErrInvalidSpreadsheet = errors.New("spreadsheet: INVALID_FILE")
+
+// Portfolio Export
+var ErrInvalidExportFormat = errors.New("export: INVALID_FORMAT")
+
+// Portfolio Backup
+var (
+	ErrInvalidBackupVersion   error = errors.New("backup: UNSUPPORTED_VERSION")
+	ErrInvalidBackupAccount   error = errors.New("backup: INVALID_ACCOUNT")
+	ErrInvalidBackupAsset     error = errors.New("backup: INVALID_ASSET")
+	ErrInvalidBackupAssetType error = errors.New("backup: ASSET_TYPE_NOT_FOUND")
+	ErrInvalidBackupOrder     error = errors.New("backup: INVALID_ORDER")
+	ErrInvalidBackupEarning   error = errors.New("backup: INVALID_EARNING")
+)
+
+// Custody Reconciliation
+var (
+	ErrInvalidCustodyBrokerage error = errors.New("reconciliation: INVALID_BROKERAGE")
+	ErrInvalidCustodyDate      error = errors.New("reconciliation: INVALID_DATE")
+	ErrInvalidCustodyPosition  error = errors.New("reconciliation: INVALID_POSITION")
+	ErrInvalidCustodyReport    error = errors.New("reconciliation: NOT_A_CUSTODY_REPORT")
+)
+
+// B3 Report
+var (
+	ErrInvalidB3Report       error = errors.New("b3Report: UNKNOWN_REPORT_TYPE")
+	ErrInvalidB3ReportEmpty  error = errors.New("b3Report: REPORT_WITHOUT_ROWS")
+	ErrInvalidB3ReportDate   error = errors.New("b3Report: INVALID_DATE")
+	ErrInvalidB3ReportSymbol error = errors.New("b3Report: PRODUCT_WITHOUT_SYMBOL")
 )
 
 // Earning
@@ -70,10 +207,54 @@ var (
 	ErrInvalidBrokerageSearchType      error = errors.New("brokerage: INVALID_SEARCH_TYPE")
 	ErrInvalidBrokerageNameSearch      error = errors.New("brokerage: INVALID_NAME")
 	ErrInvalidBrokerageNameSearchBlank error = errors.New("brokerage: BLANK_NAME")
+	ErrInvalidBrokerageBlank           error = errors.New("brokerage: MISSING_FIELDS")
+	ErrInvalidBrokerageId              error = errors.New("brokerage: BROKERAGE_NOT_EXIST")
+	ErrInvalidBrokerageExist           error = errors.New("brokerage: NAME_ALREADY_EXIST")
+	ErrInvalidBrokerageInUse           error = errors.New("brokerage: BROKERAGE_WITH_ACCOUNTS")
+	ErrInvalidBrokerageOwner           error = errors.New("brokerage: USER_IS_NOT_OWNER")
+)
+
+// Brokerage Account
+var (
+	ErrInvalidBrokerageAccount         error = errors.New("brokerageAccount: ACCOUNT_NOT_EXIST")
+	ErrInvalidBrokerageAccountNickname error = errors.New("brokerageAccount: BLANK_NICKNAME")
+	ErrInvalidBrokerageAccountExist    error = errors.New("brokerageAccount: NICKNAME_ALREADY_EXIST")
+	ErrInvalidBrokerageAccountInUse    error = errors.New("brokerageAccount: ACCOUNT_WITH_ORDERS")
+)
+
+// Cash Movement
+var (
+	ErrInvalidCashMovement         error = errors.New("cashMovement: MOVEMENT_NOT_EXIST")
+	ErrInvalidCashMovementType     error = errors.New("cashMovement: INVALID_TYPE")
+	ErrInvalidCashMovementAmount   error = errors.New("cashMovement: AMOUNT_MUST_BE_POSITIVE")
+	ErrInvalidCashMovementDate     error = errors.New("cashMovement: INVALID_DATE")
+	ErrInvalidCashMovementCurrency error = errors.New("cashMovement: CURRENCY_DIFFERENT_FROM_ACCOUNT")
+	ErrInvalidCashMovementAccount  error = errors.New("cashMovement: MISSING_ACCOUNT")
+	ErrInvalidCashMovementTransfer error = errors.New("cashMovement: INVALID_TRANSFER_ACCOUNT")
+)
+
+// Remittance
+var (
+	ErrInvalidRemittance         error = errors.New("remittance: REMITTANCE_NOT_EXIST")
+	ErrInvalidRemittanceAmount   error = errors.New("remittance: AMOUNT_MUST_BE_POSITIVE")
+	ErrInvalidRemittanceTaxes    error = errors.New("remittance: INVALID_IOF_OR_FEES")
+	ErrInvalidRemittanceRate     error = errors.New("remittance: INVALID_COMMERCIAL_RATE")
+	ErrInvalidRemittanceCurrency error = errors.New("remittance: INVALID_CURRENCY")
+	ErrInvalidRemittanceDate     error = errors.New("remittance: INVALID_DATE")
+	ErrInvalidRemittanceAccount  error = errors.New("remittance: INVALID_ACCOUNT")
 )
 
 // Sector
-var ErrInvalidSectorSearchName = errors.New("sector: NAME_NOT_EXIST")
+var (
+	ErrInvalidSectorSearchName error = errors.New("sector: NAME_NOT_EXIST")
+	ErrInvalidSectorBlank      error = errors.New("sector: BLANK_NAME")
+	ErrInvalidSectorLevel      error = errors.New("sector: INVALID_LEVEL")
+	ErrInvalidSectorParent     error = errors.New("sector: INVALID_PARENT_FOR_LEVEL")
+	ErrInvalidSector           error = errors.New("sector: SECTOR_NOT_EXIST")
+	ErrInvalidSectorProvider   error = errors.New("sector: INVALID_PROVIDER")
+	ErrInvalidSectorMapping    error = errors.New("sector: MAPPING_NOT_EXIST")
+	ErrInvalidSectorMerge      error = errors.New("sector: MERGE_MUST_BE_BETWEEN_DIFFERENT_SECTORS_OF_SAME_LEVEL")
+)
 
 // AssetUser
 var ErrInvalidAssetUser = errors.New("assetUser: RELATION_NOT_EXIST")
@@ -89,6 +270,7 @@ var (
 	ErrInvalidApiQueryCountryBlank      error = errors.New("query: BLANK_COUNTRY_VALUE")
 	ErrInvalidApiQueryWithOrderResume   error = errors.New("query: INVALID_WITH_ORDER_RESUME_VALUE")
 	ErrInvalidApiQueryWithPrice         error = errors.New("query: INVALID_WITH_PRICE_VALUE")
+	ErrInvalidApiQueryWithProfile       error = errors.New("query: INVALID_WITH_PROFILE_VALUE")
 	ErrInvalidApiQueryWithOrders        error = errors.New("query: INVALID_WITH_ORDERS_VALUE")
 	ErrInvalidApiQueryMyUser            error = errors.New("query: INVALID_MY_USER_VALUE")
 	ErrInvalidApiQueryLoginType         error = errors.New("query: INVALID_LOGIN_TYPE_VALUE")
@@ -121,4 +303,5 @@ var (
 	ErrMessageApiEarningId        error = errors.New("The authenticated user does not have this earning with the requested ID")
 	ErrMessageApiSectorName       error = errors.New("The database does not have this sector")
 	ErrMessageApiEmail            error = errors.New("The email for password reset was not found")
+	ErrMessageApiLinkAccount      error = errors.New("This email is already registered. Please sign in with the method used to register it and link this account")
 )
@@ -51,10 +51,12 @@ func ConvertUserInfo(email string, displayName string, userUid string) UserInfo
 	}
 }
 
-func ConvertUserTokenInfo(idToken string, email string, emailVerified bool) UserTokenInfo {
+func ConvertUserTokenInfo(idToken string, email string, emailVerified bool,
+	providerId string) UserTokenInfo {
 	return UserTokenInfo{
 		UserID:        idToken,
 		Email:         email,
 		EmailVerified: emailVerified,
+		ProviderId:    providerId,
 	}
 }
@@ -0,0 +1,170 @@
+package entity
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFixedIncome(t *testing.T) {
+	type test struct {
+		category     string
+		issuer       string
+		indexer      string
+		rate         float64
+		maturity     time.Time
+		respExpected error
+	}
+
+	maturity := StringToTime("2026-01-05")
+
+	tests := []test{
+		{
+			category:     "CDB",
+			issuer:       "Banco Inter",
+			indexer:      "CDI",
+			rate:         110,
+			maturity:     maturity,
+			respExpected: nil,
+		},
+		{
+			category:     "CDB",
+			issuer:       "",
+			indexer:      "CDI",
+			rate:         110,
+			maturity:     maturity,
+			respExpected: ErrInvalidFixedIncomeBlank,
+		},
+		{
+			category:     "POUPANCA",
+			issuer:       "Banco Inter",
+			indexer:      "CDI",
+			rate:         110,
+			maturity:     maturity,
+			respExpected: ErrInvalidFixedIncomeCategory,
+		},
+		{
+			category:     "TESOURO",
+			issuer:       "Tesouro Nacional",
+			indexer:      "SELIC",
+			rate:         100,
+			maturity:     maturity,
+			respExpected: ErrInvalidFixedIncomeIndexer,
+		},
+		{
+			category:     "LCI",
+			issuer:       "Banco Inter",
+			indexer:      "PRE",
+			rate:         -1,
+			maturity:     maturity,
+			respExpected: ErrInvalidFixedIncomeRate,
+		},
+		{
+			category:     "LCA",
+			issuer:       "Banco Inter",
+			indexer:      "IPCA",
+			rate:         5.5,
+			maturity:     time.Time{},
+			respExpected: ErrInvalidFixedIncomeMaturity,
+		},
+	}
+
+	for _, testCase := range tests {
+		_, err := NewFixedIncome(testCase.category, testCase.issuer,
+			testCase.indexer, testCase.rate, testCase.maturity, "a8a8a8")
+		assert.Equal(t, testCase.respExpected, err)
+	}
+}
+
+func TestAccrualFactor(t *testing.T) {
+	series := []IndexSeries{
+		{Indexer: "CDI", Date: StringToTime("2021-10-01"), Value: 6.15},
+		{Indexer: "CDI", Date: StringToTime("2021-10-04"), Value: 6.15},
+		{Indexer: "CDI", Date: StringToTime("2021-10-05"), Value: 6.15},
+		{Indexer: "IPCA", Date: StringToTime("2021-10-01"), Value: 1.25},
+		{Indexer: "IPCA", Date: StringToTime("2021-11-01"), Value: 0.95},
+	}
+
+	dailyCdi := math.Pow(1.0615, 1.0/252) - 1
+
+	type test struct {
+		fixedIncome    FixedIncome
+		start          time.Time
+		end            time.Time
+		expectedFactor float64
+	}
+
+	tests := []test{
+		{
+			fixedIncome: FixedIncome{Indexer: "CDI", Rate: 100,
+				Maturity: StringToTime("2026-01-05")},
+			start:          StringToTime("2021-10-01"),
+			end:            StringToTime("2021-10-05"),
+			expectedFactor: math.Pow(1+dailyCdi, 2),
+		},
+		{
+			fixedIncome: FixedIncome{Indexer: "CDI", Rate: 110,
+				Maturity: StringToTime("2021-10-04")},
+			start:          StringToTime("2021-09-30"),
+			end:            StringToTime("2021-10-05"),
+			expectedFactor: math.Pow(1+dailyCdi*1.1, 2),
+		},
+		{
+			fixedIncome: FixedIncome{Indexer: "IPCA", Rate: 5,
+				Maturity: StringToTime("2035-05-15")},
+			start: StringToTime("2021-09-30"),
+			end:   StringToTime("2021-11-01"),
+			expectedFactor: 1.0125 * 1.0095 *
+				math.Pow(1.05, 21.0/252),
+		},
+		{
+			fixedIncome: FixedIncome{Indexer: "PRE", Rate: 12,
+				Maturity: StringToTime("2027-01-01")},
+			start:          StringToTime("2021-10-01"),
+			end:            StringToTime("2021-10-08"),
+			expectedFactor: math.Pow(1.12, 5.0/252),
+		},
+		{
+			fixedIncome: FixedIncome{Indexer: "PRE", Rate: 12,
+				Maturity: StringToTime("2027-01-01")},
+			start:          StringToTime("2021-10-08"),
+			end:            StringToTime("2021-10-01"),
+			expectedFactor: 1,
+		},
+		{
+			fixedIncome: FixedIncome{Indexer: "PRE", Rate: 12,
+				Maturity: StringToTime("2027-01-01")},
+			start:          StringToTime("2021-10-08"),
+			end:            StringToTime("2021-10-15"),
+			expectedFactor: math.Pow(1.12, 4.0/252),
+		},
+	}
+
+	// 2021-10-12 is a Brazilian national holiday
+	isBusinessDay := func(date time.Time) bool {
+		return date.Weekday() != time.Saturday &&
+			date.Weekday() != time.Sunday &&
+			!date.Equal(StringToTime("2021-10-12"))
+	}
+
+	for _, testCase := range tests {
+		factor := testCase.fixedIncome.AccrualFactor(series, testCase.start,
+			testCase.end, isBusinessDay)
+		assert.InDelta(t, testCase.expectedFactor, factor, 1e-12)
+	}
+}
+
+func TestIncomeTaxRegressiveRate(t *testing.T) {
+	assert.Equal(t, 0.225, IncomeTaxRegressiveRate(30))
+	assert.Equal(t, 0.225, IncomeTaxRegressiveRate(180))
+	assert.Equal(t, 0.20, IncomeTaxRegressiveRate(181))
+	assert.Equal(t, 0.175, IncomeTaxRegressiveRate(720))
+	assert.Equal(t, 0.15, IncomeTaxRegressiveRate(721))
+}
+
+func TestIsTaxExempt(t *testing.T) {
+	assert.True(t, (&FixedIncome{Category: "LCI"}).IsTaxExempt())
+	assert.False(t, (&FixedIncome{Category: "CDB"}).IsTaxExempt())
+}
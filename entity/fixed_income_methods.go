@@ -0,0 +1,177 @@
+package entity
+
+import (
+	"math"
+	"time"
+)
+
+// Fixed income assets are Brazilian assets without a market quote. Their
+// value is accrued from the index series stored in our database.
+const (
+	FixedIncomeAssetType  = "FIXED_INCOME"
+	FixedIncomeSectorName = "Fixed Income"
+)
+
+// The rate of a fixed income asset depends on its indexer. For CDI it is the
+// percentage of the CDI rate (e.g. 110 for 110% of the CDI), for IPCA it is
+// the annual spread over the inflation (e.g. 5.5 for IPCA+5.5%) and for PRE
+// it is the annual fixed rate.
+const (
+	IndexerCdi  = "CDI"
+	IndexerIpca = "IPCA"
+	IndexerPre  = "PRE"
+)
+
+// Brazilian fixed income rates are annualized over 252 business days.
+const businessDaysPerYear = 252
+
+var ListValidFixedIncomeCategory = [5]string{"TESOURO", "CDB", "LCI", "LCA",
+	"DEBENTURE"}
+
+// LCI and LCA are exempt of income tax for individuals.
+var ListTaxExemptFixedIncomeCategory = [2]string{"LCI", "LCA"}
+
+func NewFixedIncome(category string, issuer string, indexer string,
+	rate float64, maturity time.Time, assetId string) (*FixedIncome, error) {
+
+	fixedIncome := &FixedIncome{
+		Category: category,
+		Issuer:   issuer,
+		Indexer:  indexer,
+		Rate:     rate,
+		Maturity: maturity,
+		Asset:    &Asset{Id: assetId},
+	}
+
+	err := fixedIncome.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return fixedIncome, nil
+}
+
+func (f *FixedIncome) Validate() error {
+	if f.Category == "" || f.Issuer == "" || f.Indexer == "" {
+		return ErrInvalidFixedIncomeBlank
+	}
+
+	validCategory := false
+	for _, category := range ListValidFixedIncomeCategory {
+		if f.Category == category {
+			validCategory = true
+		}
+	}
+	if !validCategory {
+		return ErrInvalidFixedIncomeCategory
+	}
+
+	if f.Indexer != IndexerCdi && f.Indexer != IndexerIpca &&
+		f.Indexer != IndexerPre {
+		return ErrInvalidFixedIncomeIndexer
+	}
+
+	if f.Rate <= 0 {
+		return ErrInvalidFixedIncomeRate
+	}
+
+	if f.Maturity.IsZero() {
+		return ErrInvalidFixedIncomeMaturity
+	}
+
+	return nil
+}
+
+func (f *FixedIncome) IsTaxExempt() bool {
+	for _, category := range ListTaxExemptFixedIncomeCategory {
+		if f.Category == category {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AccrualFactor returns the factor applied to a value invested on the start
+// date to get its gross value on the end date, which is limited by the
+// maturity. The CDI series has the annual CDI rate of each business day and
+// the IPCA series has the inflation of each month, both in percentage. The
+// business days of the fixed rates are the days accepted by isBusinessDay,
+// usually the trading days of the B3 calendar.
+func (f *FixedIncome) AccrualFactor(series []IndexSeries, start time.Time,
+	end time.Time, isBusinessDay func(time.Time) bool) float64 {
+
+	factor := 1.0
+
+	if end.After(f.Maturity) {
+		end = f.Maturity
+	}
+
+	if !end.After(start) {
+		return factor
+	}
+
+	switch f.Indexer {
+	case IndexerCdi:
+		for _, index := range series {
+			if index.Indexer != IndexerCdi || !index.Date.After(start) ||
+				index.Date.After(end) {
+				continue
+			}
+
+			dailyCdi := math.Pow(1+index.Value/100, 1.0/businessDaysPerYear) - 1
+			factor *= 1 + dailyCdi*f.Rate/100
+		}
+		break
+	case IndexerIpca:
+		for _, index := range series {
+			if index.Indexer != IndexerIpca || !index.Date.After(start) ||
+				index.Date.After(end) {
+				continue
+			}
+
+			factor *= 1 + index.Value/100
+		}
+		businessDays := BusinessDaysBetween(start, end, isBusinessDay)
+		factor *= math.Pow(1+f.Rate/100,
+			float64(businessDays)/businessDaysPerYear)
+		break
+	case IndexerPre:
+		businessDays := BusinessDaysBetween(start, end, isBusinessDay)
+		factor *= math.Pow(1+f.Rate/100,
+			float64(businessDays)/businessDaysPerYear)
+		break
+	}
+
+	return factor
+}
+
+// BusinessDaysBetween counts the business days after the start date until the
+// end date, including the end date.
+func BusinessDaysBetween(start time.Time, end time.Time,
+	isBusinessDay func(time.Time) bool) int {
+	businessDays := 0
+
+	for day := start.AddDate(0, 0, 1); !day.After(end); day = day.AddDate(0,
+		0, 1) {
+		if isBusinessDay(day) {
+			businessDays++
+		}
+	}
+
+	return businessDays
+}
+
+// IncomeTaxRegressiveRate returns the rate of the Brazilian regressive income
+// tax table for an investment held for the given number of days.
+func IncomeTaxRegressiveRate(days int) float64 {
+	if days <= 180 {
+		return 0.225
+	} else if days <= 360 {
+		return 0.20
+	} else if days <= 720 {
+		return 0.175
+	}
+
+	return 0.15
+}
@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIncomeProjection(t *testing.T) {
+	itub4 := &Asset{Id: "TestITUB4", Symbol: "ITUB4"}
+	aapl := &Asset{Id: "TestAAPL", Symbol: "AAPL"}
+	exDate := StringToTime("2021-05-20")
+
+	orders := []Order{
+		{Quantity: 100, Date: StringToTime("2020-09-01"), Asset: itub4},
+		{Quantity: 100, Date: StringToTime("2021-06-01"), Asset: itub4},
+		{Quantity: 10, Date: StringToTime("2021-01-04"), Asset: aapl},
+	}
+
+	earnings := []Earnings{
+		// Received before today in the current month
+		{Earning: 10, Currency: "BRL", Date: StringToTime("2020-10-05"),
+			Asset: itub4},
+		// Estimated with the 100 shares held and the 200 shares held today
+		{Earning: 50, Currency: "BRL", Date: StringToTime("2020-11-10"),
+			Asset: itub4},
+		// Month with an announced event
+		{Earning: 30, Currency: "BRL", Date: StringToTime("2021-02-10"),
+			Asset: itub4},
+		// Estimated with the 100 shares held on the ex-date of its event
+		{Earning: 40, Currency: "BRL", Date: StringToTime("2021-06-10"),
+			ExDate: &exDate, Asset: itub4},
+	}
+
+	events := []EarningEvent{
+		{Type: "JCP", Amount: 0.1, Currency: "BRL",
+			ExDate:      StringToTime("2022-01-30"),
+			PaymentDate: StringToTime("2022-02-15"), Asset: itub4},
+		{Type: "Dividendos", Amount: 0.25, Currency: "USD",
+			ExDate:      StringToTime("2021-11-05"),
+			PaymentDate: StringToTime("2021-11-11"), Asset: aapl},
+		{Type: "Dividendos", Amount: 1, Currency: "BRL",
+			ExDate: StringToTime("2021-11-05"),
+			Asset:  &Asset{Id: "AssetNotHeld"}},
+	}
+
+	projection := NewIncomeProjection(orders, earnings, events,
+		StringToTime("2021-10-19"))
+
+	assert.Equal(t, StringToTime("2021-10-01"), projection.StartDate)
+	assert.Equal(t, StringToTime("2022-09-30"), projection.EndDate)
+	assert.Equal(t, IncomeProjectionMonths, len(projection.Months))
+
+	assert.Equal(t, []ProjectedIncome{
+		{Currency: "BRL", Confirmed: 20, Estimated: 180},
+		{Currency: "USD", Confirmed: 2.5},
+	}, projection.Totals)
+
+	assert.Nil(t, projection.Months[0].Assets)
+
+	assert.Equal(t, StringToTime("2021-11-01"), projection.Months[1].Month)
+	assert.Equal(t, []ProjectedIncome{
+		{Asset: &Asset{Id: "TestAAPL", Symbol: "AAPL"}, Currency: "USD",
+			Confirmed: 2.5},
+		{Asset: &Asset{Id: "TestITUB4", Symbol: "ITUB4"}, Currency: "BRL",
+			Estimated: 100},
+	}, projection.Months[1].Assets)
+	assert.Equal(t, []ProjectedIncome{
+		{Currency: "BRL", Estimated: 100},
+		{Currency: "USD", Confirmed: 2.5},
+	}, projection.Months[1].Totals)
+
+	assert.Equal(t, []ProjectedIncome{
+		{Asset: &Asset{Id: "TestITUB4", Symbol: "ITUB4"}, Currency: "BRL",
+			Confirmed: 20},
+	}, projection.Months[4].Assets)
+
+	assert.Equal(t, []ProjectedIncome{
+		{Asset: &Asset{Id: "TestITUB4", Symbol: "ITUB4"}, Currency: "BRL",
+			Estimated: 80},
+	}, projection.Months[8].Assets)
+}
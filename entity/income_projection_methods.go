@@ -0,0 +1,175 @@
+package entity
+
+import (
+	"sort"
+	"time"
+)
+
+// The income is projected for the current month and the next 11 months.
+const IncomeProjectionMonths = 12
+
+// NewIncomeProjection projects the earnings the user will receive in the next
+// months from the assets held on the given date. The earning events already
+// announced are confirmed incomes, using the quantity held on the ex-date. The
+// earnings received in the last 12 months are repeated one year later as
+// estimated incomes, proportionally to the quantity held today and on the
+// ex-date of their earning event, or on the payment date when the earning
+// isn't linked to an event, except in the months where the asset already has
+// a confirmed income.
+func NewIncomeProjection(orders []Order, earnings []Earnings,
+	events []EarningEvent, date time.Time) *IncomeProjection {
+
+	start := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0,
+		date.Location())
+
+	projection := &IncomeProjection{
+		StartDate: start,
+		EndDate:   start.AddDate(0, IncomeProjectionMonths, -1),
+		Months:    make([]IncomeProjectionMonth, IncomeProjectionMonths),
+	}
+	for i := range projection.Months {
+		projection.Months[i].Month = start.AddDate(0, i, 0)
+	}
+
+	assets := map[string]*Asset{}
+	ordersPerAsset := map[string][]Order{}
+	for _, order := range orders {
+		assets[order.Asset.Id] = order.Asset
+		ordersPerAsset[order.Asset.Id] = append(ordersPerAsset[order.Asset.Id],
+			order)
+	}
+
+	type assetMonth struct {
+		assetId string
+		month   int
+	}
+	confirmedMonths := map[assetMonth]bool{}
+
+	for _, event := range events {
+		asset, ok := assets[event.Asset.Id]
+		if !ok {
+			continue
+		}
+
+		paymentDate := event.PaymentDate
+		if paymentDate.IsZero() {
+			paymentDate = event.ExDate
+		}
+
+		month := projectionMonth(start, paymentDate)
+		if month < 0 || paymentDate.Before(date) {
+			continue
+		}
+
+		// The quantity held on an ex-date in the future is the current one
+		quantity := QuantityOnExDate(ordersPerAsset[asset.Id], event.ExDate)
+		if event.ExDate.After(date) {
+			quantity = QuantityOnExDate(ordersPerAsset[asset.Id],
+				date.AddDate(0, 0, 1))
+		}
+
+		if quantity <= 0 {
+			continue
+		}
+
+		income := projection.Months[month].assetIncome(asset, event.Currency)
+		income.Confirmed += event.Amount * quantity
+		confirmedMonths[assetMonth{asset.Id, month}] = true
+	}
+
+	for _, earning := range earnings {
+		asset, ok := assets[earning.Asset.Id]
+		if !ok {
+			continue
+		}
+
+		projectedDate := earning.Date.AddDate(1, 0, 0)
+		month := projectionMonth(start, projectedDate)
+		if month < 0 || projectedDate.Before(date) ||
+			confirmedMonths[assetMonth{asset.Id, month}] {
+			continue
+		}
+
+		quantity := QuantityOnExDate(ordersPerAsset[asset.Id],
+			date.AddDate(0, 0, 1))
+		exDate := earning.Date
+		if earning.ExDate != nil {
+			exDate = *earning.ExDate
+		}
+		quantityReceived := QuantityOnExDate(ordersPerAsset[asset.Id], exDate)
+		if quantity <= 0 || quantityReceived <= 0 {
+			continue
+		}
+
+		income := projection.Months[month].assetIncome(asset, earning.Currency)
+		income.Estimated += earning.Earning / quantityReceived * quantity
+	}
+
+	for i := range projection.Months {
+		month := &projection.Months[i]
+
+		sort.Slice(month.Assets, func(a, b int) bool {
+			return month.Assets[a].Asset.Symbol < month.Assets[b].Asset.Symbol
+		})
+
+		for _, income := range month.Assets {
+			month.Totals = addIncomeTotal(month.Totals, income)
+			projection.Totals = addIncomeTotal(projection.Totals, income)
+		}
+	}
+
+	return projection
+}
+
+// projectionMonth returns the index of the month of the date in the
+// projection, or -1 when the date is outside of the projection.
+func projectionMonth(start time.Time, date time.Time) int {
+	month := (date.Year()-start.Year())*12 + int(date.Month()-start.Month())
+	if month < 0 || month >= IncomeProjectionMonths {
+		return -1
+	}
+
+	return month
+}
+
+func (m *IncomeProjectionMonth) assetIncome(asset *Asset,
+	currency string) *ProjectedIncome {
+
+	for i, income := range m.Assets {
+		if income.Asset.Id == asset.Id && income.Currency == currency {
+			return &m.Assets[i]
+		}
+	}
+
+	m.Assets = append(m.Assets, ProjectedIncome{
+		Asset:    &Asset{Id: asset.Id, Symbol: asset.Symbol},
+		Currency: currency,
+	})
+
+	return &m.Assets[len(m.Assets)-1]
+}
+
+// addIncomeTotal sums the income in the total of its currency, keeping the
+// totals ordered by currency.
+func addIncomeTotal(totals []ProjectedIncome,
+	income ProjectedIncome) []ProjectedIncome {
+
+	for i, total := range totals {
+		if total.Currency == income.Currency {
+			totals[i].Confirmed += income.Confirmed
+			totals[i].Estimated += income.Estimated
+			return totals
+		}
+	}
+
+	totals = append(totals, ProjectedIncome{
+		Currency:  income.Currency,
+		Confirmed: income.Confirmed,
+		Estimated: income.Estimated,
+	})
+	sort.Slice(totals, func(a, b int) bool {
+		return totals[a].Currency < totals[b].Currency
+	})
+
+	return totals
+}
@@ -0,0 +1,158 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchMarket(t *testing.T) {
+	type test struct {
+		country        string
+		expectedMarket *Market
+		expectedError  error
+	}
+
+	tests := []test{
+		{
+			country:        "BR",
+			expectedMarket: &DefaultMarkets[0],
+			expectedError:  nil,
+		},
+		{
+			country:        "CRYPTO",
+			expectedMarket: &DefaultMarkets[2],
+			expectedError:  nil,
+		},
+		{
+			country:        "EU",
+			expectedMarket: nil,
+			expectedError:  ErrInvalidCountryCode,
+		},
+	}
+
+	for _, testCase := range tests {
+		market, err := SearchMarket(testCase.country)
+		assert.Equal(t, testCase.expectedMarket, market)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+func TestSetMarkets(t *testing.T) {
+	europeMarket := Market{
+		Country:          "EU",
+		Name:             "Europa",
+		Currencies:       []string{"EUR"},
+		SymbolSuffix:     ".PA",
+		QuantityDecimals: 0,
+		Provider:         ProviderAlphaVantage,
+		AssetTypes:       []string{"STOCK", "ETF"},
+	}
+
+	SetMarkets(append(DefaultMarkets, europeMarket))
+	defer SetMarkets(DefaultMarkets)
+
+	market, err := SearchMarket("EU")
+	assert.Nil(t, err)
+	assert.Equal(t, &europeMarket, market)
+	assert.True(t, IsValidCurrency("EUR"))
+	assert.Equal(t, "EUR", market.DefaultCurrency())
+	assert.Equal(t, "MC.PA", market.ProviderSymbol("MC"))
+	assert.Equal(t, "MC", market.MarketSymbol("MC.PA"))
+}
+
+func TestMarketValidateCurrency(t *testing.T) {
+	type test struct {
+		country       string
+		currency      string
+		expectedError error
+	}
+
+	tests := []test{
+		{
+			country:       "BR",
+			currency:      "BRL",
+			expectedError: nil,
+		},
+		{
+			country:       "BR",
+			currency:      "USD",
+			expectedError: ErrInvalidBrazilCurrency,
+		},
+		{
+			country:       "US",
+			currency:      "BRL",
+			expectedError: ErrInvalidUsaCurrency,
+		},
+		{
+			country:       "CRYPTO",
+			currency:      "EUR",
+			expectedError: ErrInvalidCryptoCurrency,
+		},
+	}
+
+	// The markets added to the markets table wrap the market currency error
+	market := Market{Country: "EU", Currencies: []string{"EUR"}}
+	err := market.ValidateCurrency("BRL")
+	assert.True(t, errors.Is(err, ErrInvalidMarketCurrency))
+	assert.Contains(t, err.Error(), "must be EUR")
+
+	for _, testCase := range tests {
+		market, _ := SearchMarket(testCase.country)
+		err := market.ValidateCurrency(testCase.currency)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+func TestMarketValidateQuantity(t *testing.T) {
+	type test struct {
+		country       string
+		quantity      float64
+		expectedError error
+	}
+
+	tests := []test{
+		{
+			country:       "BR",
+			quantity:      100,
+			expectedError: nil,
+		},
+		{
+			country:       "BR",
+			quantity:      10.5,
+			expectedError: ErrInvalidOrderQuantityBrazil,
+		},
+		{
+			country:       "US",
+			quantity:      0.123456789,
+			expectedError: nil,
+		},
+		{
+			country:       "CRYPTO",
+			quantity:      0.00000001,
+			expectedError: nil,
+		},
+		{
+			country:       "CRYPTO",
+			quantity:      0.000000001,
+			expectedError: ErrInvalidOrderQuantityCrypto,
+		},
+	}
+
+	market := Market{Country: "EU", QuantityDecimals: 2}
+	err := market.ValidateQuantity(0.123)
+	assert.True(t, errors.Is(err, ErrInvalidMarketQuantityDigits))
+
+	for _, testCase := range tests {
+		market, _ := SearchMarket(testCase.country)
+		err := market.ValidateQuantity(testCase.quantity)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+func TestIsValidAssetTypeName(t *testing.T) {
+	assert.True(t, IsValidAssetTypeName("FII"))
+	assert.True(t, IsValidAssetTypeName(CryptoAssetType))
+	assert.False(t, IsValidAssetTypeName("BOND"))
+}
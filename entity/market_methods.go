@@ -0,0 +1,180 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// External providers used to verify and quote the symbols of a market.
+const (
+	ProviderAlphaVantage = "ALPHA_VANTAGE"
+	ProviderFinnhub      = "FINNHUB"
+	ProviderCrypto       = "CRYPTO"
+)
+
+// UnlimitedQuantityDecimals is used by the markets accepting orders with any
+// fractional quantity. A market with zero decimals only accepts integer lots.
+const UnlimitedQuantityDecimals = -1
+
+// DefaultMarkets are used until the markets are loaded from the database. New
+// markets are added in the markets table, not here.
+var DefaultMarkets = []Market{
+	{
+		Country:          "BR",
+		Name:             "Brasil",
+		Currencies:       []string{"BRL"},
+		SymbolSuffix:     ".SA",
+		QuantityDecimals: 0,
+		Provider:         ProviderAlphaVantage,
+		AssetTypes: []string{"STOCK", "ETF", "FII", FixedIncomeAssetType,
+			OptionAssetType},
+	},
+	{
+		Country:          "US",
+		Name:             "Estados Unidos",
+		Currencies:       []string{"USD"},
+		QuantityDecimals: UnlimitedQuantityDecimals,
+		Provider:         ProviderFinnhub,
+		AssetTypes:       []string{"STOCK", "ETF", "REIT"},
+	},
+	{
+		Country:          CryptoCountry,
+		Name:             "Criptomoedas",
+		Currencies:       []string{"BRL", "USD"},
+		QuantityDecimals: CryptoQuantityDecimals,
+		Provider:         ProviderCrypto,
+		AssetTypes:       []string{CryptoAssetType},
+	},
+}
+
+var (
+	marketsMutex sync.RWMutex
+	markets      = DefaultMarkets
+)
+
+// SetMarkets replaces the markets used by all the validations of the API.
+func SetMarkets(newMarkets []Market) {
+	marketsMutex.Lock()
+	defer marketsMutex.Unlock()
+
+	markets = newMarkets
+}
+
+func ListMarkets() []Market {
+	marketsMutex.RLock()
+	defer marketsMutex.RUnlock()
+
+	return append([]Market(nil), markets...)
+}
+
+func SearchMarket(country string) (*Market, error) {
+	for _, market := range ListMarkets() {
+		if market.Country == country {
+			return &market, nil
+		}
+	}
+
+	return nil, ErrInvalidCountryCode
+}
+
+// IsValidCurrency verifies if the currency is accepted by any market.
+func IsValidCurrency(currency string) bool {
+	for _, market := range ListMarkets() {
+		if market.HasCurrency(currency) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsValidAssetTypeName verifies if the asset type is traded in any market.
+func IsValidAssetTypeName(assetType string) bool {
+	for _, market := range ListMarkets() {
+		if market.HasAssetType(assetType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *Market) HasCurrency(currency string) bool {
+	for _, validCurrency := range m.Currencies {
+		if currency == validCurrency {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *Market) HasAssetType(assetType string) bool {
+	for _, validAssetType := range m.AssetTypes {
+		if assetType == validAssetType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DefaultCurrency is the first currency of the market.
+func (m *Market) DefaultCurrency() string {
+	if len(m.Currencies) == 0 {
+		return ""
+	}
+
+	return m.Currencies[0]
+}
+
+// ValidateCurrency returns the currency error of the default markets, or
+// ErrInvalidMarketCurrency wrapped with the accepted currencies for the
+// markets added to the markets table.
+func (m *Market) ValidateCurrency(currency string) error {
+	if m.HasCurrency(currency) {
+		return nil
+	}
+
+	switch m.Country {
+	case "BR":
+		return ErrInvalidBrazilCurrency
+	case "US":
+		return ErrInvalidUsaCurrency
+	case CryptoCountry:
+		return ErrInvalidCryptoCurrency
+	}
+
+	return fmt.Errorf("%w: must be %s", ErrInvalidMarketCurrency,
+		strings.Join(m.Currencies, " or "))
+}
+
+func (m *Market) ValidateQuantity(quantity float64) error {
+	if m.QuantityDecimals == 0 && !IsIntegral(quantity) {
+		return ErrInvalidOrderQuantityBrazil
+	}
+
+	if m.QuantityDecimals > 0 && !HasMaxDecimalPlaces(quantity,
+		m.QuantityDecimals) {
+		if m.QuantityDecimals == CryptoQuantityDecimals {
+			return ErrInvalidOrderQuantityCrypto
+		}
+
+		return fmt.Errorf("%w: max %d", ErrInvalidMarketQuantityDigits,
+			m.QuantityDecimals)
+	}
+
+	return nil
+}
+
+// ProviderSymbol returns the symbol as it is known by the market provider.
+func (m *Market) ProviderSymbol(symbol string) string {
+	return symbol + m.SymbolSuffix
+}
+
+// MarketSymbol removes the market suffix from a symbol returned by the
+// market provider.
+func (m *Market) MarketSymbol(symbol string) string {
+	return strings.TrimSuffix(symbol, m.SymbolSuffix)
+}
@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOption(t *testing.T) {
+	type test struct {
+		optionType     string
+		strike         float64
+		expiry         string
+		expectedOption *Option
+		expectedError  error
+	}
+
+	tests := []test{
+		{
+			optionType: "PUT",
+			strike:     30.5,
+			expiry:     "2021-12-17",
+			expectedOption: &Option{
+				OptionType: "PUT",
+				Strike:     30.5,
+				Expiry:     StringToTime("2021-12-17"),
+				Asset:      &Asset{Id: "TestAssetID"},
+				Underlying: &Asset{Id: "TestUnderlyingID"},
+			},
+			expectedError: nil,
+		},
+		{
+			optionType:     "call",
+			strike:         30.5,
+			expiry:         "2021-12-17",
+			expectedOption: nil,
+			expectedError:  ErrInvalidOptionType,
+		},
+		{
+			optionType:     "CALL",
+			strike:         -1,
+			expiry:         "2021-12-17",
+			expectedOption: nil,
+			expectedError:  ErrInvalidOptionStrike,
+		},
+		{
+			optionType:     "CALL",
+			strike:         30.5,
+			expiry:         "17/12/2021",
+			expectedOption: nil,
+			expectedError:  ErrInvalidOptionExpiry,
+		},
+	}
+
+	for _, testCase := range tests {
+		option, err := NewOption(testCase.optionType, testCase.strike,
+			StringToTime(testCase.expiry), "TestAssetID", "TestUnderlyingID")
+		assert.Equal(t, testCase.expectedOption, option)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+func TestOptionExercise(t *testing.T) {
+	call := Option{OptionType: OptionCall, Strike: 30,
+		Expiry: StringToTime("2021-12-17")}
+	put := Option{OptionType: OptionPut, Strike: 30}
+
+	assert.True(t, call.InTheMoney(30.01))
+	assert.False(t, call.InTheMoney(30))
+	assert.True(t, put.InTheMoney(29.99))
+	assert.False(t, put.InTheMoney(30))
+
+	assert.Equal(t, 100.0, call.ExerciseQuantity(100))
+	assert.Equal(t, -100.0, call.ExerciseQuantity(-100))
+	assert.Equal(t, -100.0, put.ExerciseQuantity(100))
+	assert.Equal(t, 100.0, put.ExerciseQuantity(-100))
+
+	assert.Equal(t, "PETR4 CALL 30 2021-12-17", call.Fullname("PETR4"))
+}
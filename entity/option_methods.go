@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"strconv"
+	"time"
+)
+
+// Options are listed in the brazilian market and each contract gives the
+// right to buy (CALL) or sell (PUT) one unit of the underlying asset at the
+// strike price until the expiry date.
+const (
+	OptionAssetType = "OPTION"
+	OptionCall      = "CALL"
+	OptionPut       = "PUT"
+)
+
+func NewOption(optionType string, strike float64, expiry time.Time,
+	assetId string, underlyingId string) (*Option, error) {
+
+	option := &Option{
+		OptionType: optionType,
+		Strike:     strike,
+		Expiry:     expiry,
+		Asset:      &Asset{Id: assetId},
+		Underlying: &Asset{Id: underlyingId},
+	}
+
+	err := option.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return option, nil
+}
+
+func (o *Option) Validate() error {
+	if o.OptionType != OptionCall && o.OptionType != OptionPut {
+		return ErrInvalidOptionType
+	}
+
+	if o.Strike <= 0 {
+		return ErrInvalidOptionStrike
+	}
+
+	if o.Expiry.IsZero() {
+		return ErrInvalidOptionExpiry
+	}
+
+	return nil
+}
+
+// InTheMoney verifies if the option is exercised for the given price of the
+// underlying asset.
+func (o *Option) InTheMoney(underlyingPrice float64) bool {
+	if o.OptionType == OptionCall {
+		return underlyingPrice > o.Strike
+	}
+
+	return underlyingPrice < o.Strike
+}
+
+// ExerciseQuantity returns the quantity traded on the underlying asset when
+// an option position with the given quantity is exercised. A long call buys
+// and a short call (assignment) sells the underlying, while a long put sells
+// and a short put buys it.
+func (o *Option) ExerciseQuantity(quantity float64) float64 {
+	if o.OptionType == OptionCall {
+		return quantity
+	}
+
+	return -quantity
+}
+
+// Fullname describes the option as "PETR4 CALL 30.5 2021-12-17".
+func (o *Option) Fullname(underlyingSymbol string) string {
+	return underlyingSymbol + " " + o.OptionType + " " +
+		strconv.FormatFloat(o.Strike, 'f', -1, 64) + " " +
+		o.Expiry.Format("2006-01-02")
+}
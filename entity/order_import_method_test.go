@@ -0,0 +1,202 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderImportColumns(t *testing.T) {
+	header := []string{"Ticker", "Country", "Type", "Quantity", "Price",
+		"Currency", "Brokerage", "Data"}
+
+	columns, err := OrderImportColumns(header, map[string]string{
+		"symbol": "ticker",
+		"date":   "Data",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int{
+		"symbol":    0,
+		"country":   1,
+		"type":      2,
+		"quantity":  3,
+		"price":     4,
+		"currency":  5,
+		"brokerage": 6,
+		"date":      7,
+	}, columns)
+
+	_, err = OrderImportColumns(header, nil)
+	assert.Equal(t, ErrInvalidOrderImportColumn, err)
+
+	_, err = OrderImportColumns(header, map[string]string{"ticker": "symbol"})
+	assert.Equal(t, ErrInvalidOrderImportMapping, err)
+}
+
+func TestNewOrderImportRow(t *testing.T) {
+	columns := map[string]int{
+		"symbol":    0,
+		"country":   1,
+		"type":      2,
+		"quantity":  3,
+		"price":     4,
+		"currency":  5,
+		"brokerage": 6,
+		"date":      7,
+		"fees":      8,
+	}
+
+	row := NewOrderImportRow(2, []string{" itub4", "br", "BUY", "100",
+		"1.025,50", "brl", "Clear", "05/10/2021", "4,90"}, columns)
+	assert.Equal(t, OrderImportRow{
+		Line:      2,
+		Symbol:    "ITUB4",
+		Country:   "BR",
+		OrderType: "buy",
+		Quantity:  100,
+		Price:     1025.50,
+		Currency:  "BRL",
+		Brokerage: "Clear",
+		Date:      "2021-10-05",
+		Fees:      4.90,
+	}, row)
+	assert.True(t, row.Valid())
+
+	row = NewOrderImportRow(3, []string{"", "US", "sell", "ten", "20",
+		"USD", "Avenue", "2021-10-05", "-1"}, columns)
+	assert.Equal(t, []string{ErrInvalidOrderImportNumber.Error(),
+		ErrInvalidOrderFees.Error(), ErrInvalidOrderImportBlank.Error()},
+		row.Errors)
+	assert.False(t, row.Valid())
+}
+
+func TestParseImportNumber(t *testing.T) {
+	type test struct {
+		value          string
+		expectedNumber float64
+		expectedError  error
+	}
+
+	tests := []test{
+		{value: "10", expectedNumber: 10, expectedError: nil},
+		{value: "-2.5", expectedNumber: -2.5, expectedError: nil},
+		{value: "1,234.56", expectedNumber: 1234.56, expectedError: nil},
+		{value: "1.234,56", expectedNumber: 1234.56, expectedError: nil},
+		{value: "0,00000001", expectedNumber: 0.00000001, expectedError: nil},
+		{value: "", expectedNumber: 0, expectedError: ErrInvalidOrderImportNumber},
+		{value: "R$ 10", expectedNumber: 0, expectedError: ErrInvalidOrderImportNumber},
+	}
+
+	for _, testCase := range tests {
+		number, err := ParseImportNumber(testCase.value)
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expectedNumber, number)
+	}
+}
+
+func TestTradeNoteImportRows(t *testing.T) {
+	tradeDate, _ := time.Parse("2006-01-02", "2021-10-05")
+	settlementDate, _ := time.Parse("2006-01-02", "2021-10-07")
+
+	notes := []TradeNote{
+		{
+			Number:         "123456",
+			Brokerage:      "Clear",
+			TradeDate:      tradeDate,
+			SettlementDate: settlementDate,
+			Orders: []TradeNoteOrder{
+				{OrderType: "buy", Symbol: "itub4", Quantity: 100, Price: 25.50,
+					Fees: 0.82},
+				{OrderType: "sell", Company: "PETROBRAS", SymbolSuffix: "4",
+					Quantity: -5, Price: 28.10, Fees: 0.05, WithheldTax: 0.01},
+			},
+		},
+	}
+
+	expectedRows := []OrderImportRow{
+		{
+			Line:           1,
+			Symbol:         "ITUB4",
+			Country:        "BR",
+			OrderType:      "buy",
+			Quantity:       100,
+			Price:          25.50,
+			Currency:       "BRL",
+			Brokerage:      "Rico",
+			Date:           "2021-10-05",
+			Fees:           0.82,
+			SettlementDate: "2021-10-07",
+		},
+		{
+			Line:           2,
+			Country:        "BR",
+			OrderType:      "sell",
+			Quantity:       -5,
+			Price:          28.10,
+			Currency:       "BRL",
+			Brokerage:      "Rico",
+			Date:           "2021-10-05",
+			Fees:           0.05,
+			WithheldTax:    0.01,
+			SettlementDate: "2021-10-07",
+			Errors:         []string{ErrInvalidOrderImportBlank.Error()},
+		},
+	}
+
+	assert.Equal(t, expectedRows, TradeNoteImportRows(notes, "Rico"))
+	assert.Equal(t, "Clear", TradeNoteImportRows(notes, "")[0].Brokerage)
+}
+
+func TestDuplicateOrderRows(t *testing.T) {
+	date := StringToTime("2021-10-05")
+	brokerage := &Brokerage{Name: "Avenue"}
+	orders := map[string][]Order{
+		"AAPL": {
+			{Quantity: 0.5, Price: 141.2, Currency: "USD", OrderType: "buy",
+				Date: date, Brokerage: brokerage},
+		},
+	}
+
+	rows := []OrderImportRow{
+		{Symbol: "AAPL", OrderType: "buy", Quantity: 0.5, Price: 141.2,
+			Currency: "USD", Brokerage: "avenue", Date: "2021-10-05"},
+		{Symbol: "AAPL", OrderType: "buy", Quantity: 0.5, Price: 141.2,
+			Currency: "USD", Brokerage: "Avenue", Date: "2021-10-05"},
+		{Symbol: "AAPL", OrderType: "buy", Quantity: 0.5, Price: 141.2,
+			Currency: "USD", Brokerage: "Passfolio", Date: "2021-10-05"},
+		{Symbol: "MSFT", OrderType: "buy", Quantity: 0.5, Price: 141.2,
+			Currency: "USD", Brokerage: "Avenue", Date: "2021-10-05"},
+	}
+
+	DuplicateOrderRows(rows, orders)
+
+	assert.True(t, rows[0].Duplicate)
+	assert.False(t, rows[1].Duplicate)
+	assert.False(t, rows[2].Duplicate)
+	assert.False(t, rows[3].Duplicate)
+}
+
+func TestDuplicateEarningRows(t *testing.T) {
+	earnings := map[string][]Earnings{
+		"AAPL": {
+			{Type: "Dividendos", Earning: 0.35, Currency: "USD",
+				Date: StringToTime("2021-11-11")},
+		},
+	}
+
+	rows := []EarningImportRow{
+		{Symbol: "AAPL", EarningType: "Dividendos", Amount: 0.35,
+			Currency: "USD", Date: "2021-11-11"},
+		{Symbol: "AAPL", EarningType: "Dividendos", Amount: 0.35,
+			Currency: "USD", Date: "2021-11-11"},
+		{Symbol: "AAPL", EarningType: "Dividendos", Amount: 0.35,
+			Currency: "USD", Date: "2022-02-10"},
+	}
+
+	DuplicateEarningRows(rows, earnings)
+
+	assert.True(t, rows[0].Duplicate)
+	assert.False(t, rows[1].Duplicate)
+	assert.False(t, rows[2].Duplicate)
+}
@@ -0,0 +1,272 @@
+package entity
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Maximum number of orders in an imported file.
+const MaxOrderImportRows = 5000
+
+// Fields of an imported order. The fees column is optional.
+var OrderImportFields = []string{"symbol", "country", "type", "quantity",
+	"price", "currency", "brokerage", "date", "fees"}
+
+var orderImportOptionalFields = map[string]bool{"fees": true}
+
+// OrderImportColumns returns the index of each order field in the header of
+// the imported file. The mapping renames the column of a field, otherwise the
+// column has the name of the field.
+func OrderImportColumns(header []string, mapping map[string]string) (
+	map[string]int, error) {
+
+	for field := range mapping {
+		if !isOrderImportField(field) {
+			return nil, ErrInvalidOrderImportMapping
+		}
+	}
+
+	headerIndex := map[string]int{}
+	for i, column := range header {
+		headerIndex[normalizeImportColumn(column)] = i
+	}
+
+	columns := map[string]int{}
+	for _, field := range OrderImportFields {
+		column := field
+		if mapping[field] != "" {
+			column = mapping[field]
+		}
+
+		index, ok := headerIndex[normalizeImportColumn(column)]
+		if !ok {
+			if orderImportOptionalFields[field] {
+				continue
+			}
+			return nil, ErrInvalidOrderImportColumn
+		}
+		columns[field] = index
+	}
+
+	return columns, nil
+}
+
+// NewOrderImportRow reads the order fields of a record. The values that can
+// not be read are reported in the Errors of the row.
+func NewOrderImportRow(line int, record []string,
+	columns map[string]int) OrderImportRow {
+
+	value := func(field string) string {
+		index, ok := columns[field]
+		if !ok || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	row := OrderImportRow{
+		Line:      line,
+		Symbol:    strings.ToUpper(value("symbol")),
+		Country:   strings.ToUpper(value("country")),
+		OrderType: strings.ToLower(value("type")),
+		Currency:  strings.ToUpper(value("currency")),
+		Brokerage: value("brokerage"),
+		Date:      orderImportDate(value("date")),
+	}
+
+	var err error
+	if row.Quantity, err = ParseImportNumber(value("quantity")); err != nil {
+		row.AddError(err)
+	}
+
+	if row.Price, err = ParseImportNumber(value("price")); err != nil {
+		row.AddError(err)
+	}
+
+	if value("fees") != "" {
+		if row.Fees, err = ParseImportNumber(value("fees")); err != nil {
+			row.AddError(err)
+		} else if row.Fees < 0 {
+			row.AddError(ErrInvalidOrderFees)
+		}
+	}
+
+	if row.Symbol == "" || row.Brokerage == "" {
+		row.AddError(ErrInvalidOrderImportBlank)
+	}
+
+	return row
+}
+
+// TradeNoteImportRows converts the orders of the trade notes to imported
+// orders of the brazilian market. The brokerage, when informed, replaces the
+// brokerage found in the notes.
+func TradeNoteImportRows(notes []TradeNote, brokerage string) []OrderImportRow {
+	var rows []OrderImportRow
+
+	for _, note := range notes {
+		noteBrokerage := note.Brokerage
+		if brokerage != "" {
+			noteBrokerage = brokerage
+		}
+
+		for _, order := range note.Orders {
+			row := OrderImportRow{
+				Line:           len(rows) + 1,
+				Symbol:         strings.ToUpper(order.Symbol),
+				Country:        "BR",
+				OrderType:      order.OrderType,
+				Quantity:       order.Quantity,
+				Price:          order.Price,
+				Currency:       "BRL",
+				Brokerage:      noteBrokerage,
+				Date:           note.TradeDate.Format("2006-01-02"),
+				Fees:           order.Fees,
+				WithheldTax:    order.WithheldTax,
+				SettlementDate: note.SettlementDate.Format("2006-01-02"),
+			}
+
+			if row.Symbol == "" || row.Brokerage == "" {
+				row.AddError(ErrInvalidOrderImportBlank)
+			}
+
+			rows = append(rows, row)
+		}
+	}
+
+	return rows
+}
+
+// AddError reports a problem of the row, ignoring repeated problems.
+func (r *OrderImportRow) AddError(err error) {
+	r.Errors = addRowError(r.Errors, err)
+}
+
+func (r *OrderImportRow) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// ParseImportNumber reads a number written with a dot or a comma as decimal
+// separator, like 1,234.56 or 1.234,56.
+func ParseImportNumber(value string) (float64, error) {
+	number := strings.ReplaceAll(strings.TrimSpace(value), " ", "")
+
+	lastComma := strings.LastIndex(number, ",")
+	lastDot := strings.LastIndex(number, ".")
+	if lastComma > lastDot {
+		number = strings.ReplaceAll(number, ".", "")
+		number = strings.Replace(number, ",", ".", 1)
+	} else {
+		number = strings.ReplaceAll(number, ",", "")
+	}
+
+	parsed, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, ErrInvalidOrderImportNumber
+	}
+
+	return parsed, nil
+}
+
+// orderImportDate converts the dates written as DD/MM/YYYY to the YYYY-MM-DD
+// layout used by the orders.
+func orderImportDate(date string) string {
+	parsedDate, err := time.Parse("02/01/2006", date)
+	if err != nil {
+		return date
+	}
+
+	return parsedDate.Format("2006-01-02")
+}
+
+func isOrderImportField(field string) bool {
+	for _, orderField := range OrderImportFields {
+		if field == orderField {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizeImportColumn(column string) string {
+	return strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column,
+		"\ufeff")))
+}
+
+// AddError reports a problem of the row, ignoring repeated problems.
+func (r *EarningImportRow) AddError(err error) {
+	r.Errors = addRowError(r.Errors, err)
+}
+
+func (r *EarningImportRow) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// DuplicateOrderRows marks the rows of the orders already registered by the
+// user, searched by the symbol of the rows. Each registered order matches a
+// single row, so the repeated orders of a file are imported until the file
+// has more of them than the registered orders.
+func DuplicateOrderRows(rows []OrderImportRow,
+	ordersBySymbol map[string][]Order) {
+
+	matched := map[string]map[int]bool{}
+	for i, row := range rows {
+		if matched[row.Symbol] == nil {
+			matched[row.Symbol] = map[int]bool{}
+		}
+
+		for j, order := range ordersBySymbol[row.Symbol] {
+			if matched[row.Symbol][j] || !row.matchesOrder(order) {
+				continue
+			}
+
+			matched[row.Symbol][j] = true
+			rows[i].Duplicate = true
+			break
+		}
+	}
+}
+
+// DuplicateEarningRows marks the rows of the earnings already registered by
+// the user, like the DuplicateOrderRows.
+func DuplicateEarningRows(rows []EarningImportRow,
+	earningsBySymbol map[string][]Earnings) {
+
+	matched := map[string]map[int]bool{}
+	for i, row := range rows {
+		if matched[row.Symbol] == nil {
+			matched[row.Symbol] = map[int]bool{}
+		}
+
+		for j, earning := range earningsBySymbol[row.Symbol] {
+			if matched[row.Symbol][j] || !row.matchesEarning(earning) {
+				continue
+			}
+
+			matched[row.Symbol][j] = true
+			rows[i].Duplicate = true
+			break
+		}
+	}
+}
+
+func (r *OrderImportRow) matchesOrder(order Order) bool {
+	return r.Date == order.Date.Format("2006-01-02") &&
+		r.OrderType == order.OrderType && r.Currency == order.Currency &&
+		sameImportNumber(r.Quantity, order.Quantity) &&
+		sameImportNumber(r.Price, order.Price) && order.Brokerage != nil &&
+		strings.EqualFold(r.Brokerage, order.Brokerage.Name)
+}
+
+func (r *EarningImportRow) matchesEarning(earning Earnings) bool {
+	return r.Date == earning.Date.Format("2006-01-02") &&
+		r.EarningType == earning.Type && r.Currency == earning.Currency &&
+		sameImportNumber(r.Amount, earning.Earning)
+}
+
+func sameImportNumber(number float64, otherNumber float64) bool {
+	return math.Abs(number-otherNumber) < 1e-6
+}
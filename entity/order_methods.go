@@ -3,7 +3,7 @@ package entity
 import "time"
 
 func NewOrder(quantity float64, price float64, currency string, orderType string,
-	date time.Time, brokerageId, assetId, userUid string) (*Order, error) {
+	date time.Time, accountId, assetId, userUid string) (*Order, error) {
 
 	order := &Order{
 		Quantity:  quantity,
@@ -11,7 +11,7 @@ func NewOrder(quantity float64, price float64, currency string, orderType string
 		Currency:  currency,
 		OrderType: orderType,
 		Date:      date,
-		Brokerage: &Brokerage{Id: brokerageId},
+		Account:   &BrokerageAccount{Id: accountId},
 		Asset:     &Asset{Id: assetId},
 		UserUid:   userUid,
 	}
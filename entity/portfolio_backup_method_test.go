@@ -0,0 +1,144 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testBackup() PortfolioBackup {
+	return PortfolioBackup{
+		Version: BackupVersion,
+		Accounts: []BackupAccount{
+			{Nickname: "Clear", Brokerage: "Clear", Country: "BR"},
+		},
+		Assets: []BackupAsset{
+			{Symbol: "ITUB4", Country: "BR", Fullname: "Itau Unibanco",
+				Preference: "PN", AssetType: "STOCK", Sector: "Finance"},
+		},
+		Orders: []BackupOrder{
+			{Symbol: "ITUB4", Country: "BR", Account: "Clear", OrderType: "buy",
+				Quantity: 20, Price: 29.29, Currency: "BRL", Date: "2021-10-01"},
+		},
+		Earnings: []BackupEarning{
+			{Symbol: "ITUB4", Country: "BR", EarningType: "JCP", Amount: 1.7,
+				Currency: "BRL", Date: "2021-11-03"},
+		},
+	}
+}
+
+func TestNewPortfolioBackup(t *testing.T) {
+	userUid := "TestUserUID"
+	preference := "PN"
+	settlementDate := StringToTime("2021-10-05")
+	createdAt := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	assets := []Asset{
+		{
+			Id:         "TestAssetID",
+			Symbol:     "ITUB4",
+			Fullname:   "Itau Unibanco",
+			Preference: &preference,
+			AssetType:  &AssetType{Type: "STOCK", Country: "BR"},
+			Sector:     &Sector{Name: "Finance"},
+		},
+	}
+
+	brokerages := []Brokerage{
+		{Id: "TestBrokerageID1", Name: "Clear", Country: "BR"},
+		{Id: "TestBrokerageID2", Name: "Inter", Fullname: "Banco Inter",
+			Country: "BR", UserUid: &userUid},
+	}
+
+	accounts := []BrokerageAccount{
+		{Id: "TestAccountID1", Nickname: "Clear", Brokerage: &brokerages[0]},
+		{Id: "TestAccountID2", Nickname: "Inter", Brokerage: &Brokerage{
+			Id: "TestBrokerageID2", Name: "Inter", Country: "BR"}},
+	}
+
+	orders := []Order{
+		{
+			Quantity:       20,
+			Price:          29.29,
+			Currency:       "BRL",
+			OrderType:      "buy",
+			Date:           StringToTime("2021-10-01"),
+			Fees:           0.5,
+			SettlementDate: &settlementDate,
+			Account:        &accounts[1],
+			Asset:          &Asset{Id: "TestAssetID", Symbol: "ITUB4"},
+		},
+	}
+
+	earnings := []Earnings{
+		{
+			Type:     "JCP",
+			Earning:  1.7,
+			Currency: "BRL",
+			Date:     StringToTime("2021-11-03"),
+			Asset:    &Asset{Id: "TestAssetID", Symbol: "ITUB4"},
+		},
+	}
+
+	expectedBackup := &PortfolioBackup{
+		Version:   BackupVersion,
+		CreatedAt: createdAt,
+		Accounts: []BackupAccount{
+			{Nickname: "Clear", Brokerage: "Clear", Country: "BR"},
+			{Nickname: "Inter", Brokerage: "Inter",
+				BrokerageFullname: "Banco Inter", Country: "BR"},
+		},
+		Assets: []BackupAsset{
+			{Symbol: "ITUB4", Country: "BR", Fullname: "Itau Unibanco",
+				Preference: "PN", AssetType: "STOCK", Sector: "Finance"},
+		},
+		Orders: []BackupOrder{
+			{Symbol: "ITUB4", Country: "BR", Account: "Inter", OrderType: "buy",
+				Quantity: 20, Price: 29.29, Currency: "BRL", Date: "2021-10-01",
+				Fees: 0.5, SettlementDate: "2021-10-05"},
+		},
+		Earnings: []BackupEarning{
+			{Symbol: "ITUB4", Country: "BR", EarningType: "JCP", Amount: 1.7,
+				Currency: "BRL", Date: "2021-11-03"},
+		},
+	}
+
+	backup := NewPortfolioBackup(assets, brokerages, accounts, orders,
+		earnings, createdAt)
+	assert.Equal(t, expectedBackup, backup)
+	assert.Nil(t, backup.Validate())
+}
+
+func TestPortfolioBackupValidate(t *testing.T) {
+	backup := testBackup()
+	assert.Nil(t, backup.Validate())
+
+	backup = testBackup()
+	backup.Version = BackupVersion + 1
+	assert.Equal(t, ErrInvalidBackupVersion, backup.Validate())
+
+	backup = testBackup()
+	backup.Accounts = append(backup.Accounts, backup.Accounts[0])
+	assert.Equal(t, ErrInvalidBackupAccount, backup.Validate())
+
+	backup = testBackup()
+	backup.Assets[0].Country = "XX"
+	assert.Equal(t, ErrInvalidBackupAsset, backup.Validate())
+
+	backup = testBackup()
+	backup.Orders[0].Account = "Rico"
+	assert.Equal(t, ErrInvalidBackupOrder, backup.Validate())
+
+	backup = testBackup()
+	backup.Orders[0].Date = "01/10/2021"
+	assert.Equal(t, ErrInvalidBackupOrder, backup.Validate())
+
+	backup = testBackup()
+	backup.Earnings[0].Country = "US"
+	assert.Equal(t, ErrInvalidBackupEarning, backup.Validate())
+
+	backup = testBackup()
+	backup.Earnings[0].Amount = 0
+	assert.Equal(t, ErrInvalidBackupEarning, backup.Validate())
+}
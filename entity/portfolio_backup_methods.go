@@ -0,0 +1,164 @@
+package entity
+
+import "time"
+
+// BackupVersion is the version of the backups created. The backups of this
+// version and of the previous ones can be restored.
+const BackupVersion = 1
+
+// NewPortfolioBackup builds the backup of the portfolio of a user. The orders
+// and the earnings refer to their assets by the symbol and the country and the
+// orders refer to their accounts by the nickname. The fullname is kept only
+// for the custom brokerages, which may not exist where the backup is restored.
+func NewPortfolioBackup(assets []Asset, brokerages []Brokerage,
+	accounts []BrokerageAccount, orders []Order, earnings []Earnings,
+	createdAt time.Time) *PortfolioBackup {
+
+	backup := &PortfolioBackup{
+		Version:   BackupVersion,
+		CreatedAt: createdAt,
+		Accounts:  []BackupAccount{},
+		Assets:    []BackupAsset{},
+		Orders:    []BackupOrder{},
+		Earnings:  []BackupEarning{},
+	}
+
+	customBrokerages := map[string]Brokerage{}
+	for _, brokerage := range brokerages {
+		if brokerage.IsCustom() {
+			customBrokerages[brokerage.Id] = brokerage
+		}
+	}
+
+	for _, account := range accounts {
+		backupAccount := BackupAccount{Nickname: account.Nickname}
+		if account.Brokerage != nil {
+			backupAccount.Brokerage = account.Brokerage.Name
+			backupAccount.Country = account.Brokerage.Country
+			custom := customBrokerages[account.Brokerage.Id]
+			backupAccount.BrokerageFullname = custom.Fullname
+		}
+
+		backup.Accounts = append(backup.Accounts, backupAccount)
+	}
+
+	assetsById := map[string]BackupAsset{}
+	for _, asset := range assets {
+		backupAsset := BackupAsset{
+			Symbol:   asset.Symbol,
+			Fullname: asset.Fullname,
+		}
+		if asset.Preference != nil {
+			backupAsset.Preference = *asset.Preference
+		}
+		if asset.AssetType != nil {
+			backupAsset.AssetType = asset.AssetType.Type
+			backupAsset.Country = asset.AssetType.Country
+		}
+		if asset.Sector != nil {
+			backupAsset.Sector = asset.Sector.Name
+		}
+
+		assetsById[asset.Id] = backupAsset
+		backup.Assets = append(backup.Assets, backupAsset)
+	}
+
+	for _, order := range orders {
+		backupOrder := BackupOrder{
+			OrderType:   order.OrderType,
+			Quantity:    order.Quantity,
+			Price:       order.Price,
+			Currency:    order.Currency,
+			Date:        order.Date.Format("2006-01-02"),
+			Fees:        order.Fees,
+			WithheldTax: order.WithheldTax,
+		}
+		if order.Asset != nil {
+			backupOrder.Symbol = assetsById[order.Asset.Id].Symbol
+			backupOrder.Country = assetsById[order.Asset.Id].Country
+		}
+		if order.Account != nil {
+			backupOrder.Account = order.Account.Nickname
+		}
+		if order.SettlementDate != nil {
+			backupOrder.SettlementDate = order.SettlementDate.Format(
+				"2006-01-02")
+		}
+
+		backup.Orders = append(backup.Orders, backupOrder)
+	}
+
+	for _, earning := range earnings {
+		backupEarning := BackupEarning{
+			EarningType: earning.Type,
+			Amount:      earning.Earning,
+			WithheldTax: earning.WithheldTax,
+			Currency:    earning.Currency,
+			Date:        earning.Date.Format("2006-01-02"),
+		}
+		if earning.Asset != nil {
+			backupEarning.Symbol = assetsById[earning.Asset.Id].Symbol
+			backupEarning.Country = assetsById[earning.Asset.Id].Country
+		}
+
+		backup.Earnings = append(backup.Earnings, backupEarning)
+	}
+
+	return backup
+}
+
+// Validate returns an error when the backup can not be restored: its version
+// is unknown, an account or an asset is incomplete or repeated, or an order or
+// an earning refers to an account or an asset that is not in the backup.
+func (b *PortfolioBackup) Validate() error {
+	if b.Version < 1 || b.Version > BackupVersion {
+		return ErrInvalidBackupVersion
+	}
+
+	accounts := map[string]bool{}
+	for _, account := range b.Accounts {
+		if _, err := SearchMarket(account.Country); err != nil ||
+			account.Nickname == "" || account.Brokerage == "" ||
+			accounts[account.Nickname] {
+			return ErrInvalidBackupAccount
+		}
+		accounts[account.Nickname] = true
+	}
+
+	assets := map[string]bool{}
+	for _, asset := range b.Assets {
+		key := asset.Symbol + ":" + asset.Country
+		if _, err := SearchMarket(asset.Country); err != nil ||
+			asset.Symbol == "" || asset.AssetType == "" || asset.Sector == "" ||
+			assets[key] {
+			return ErrInvalidBackupAsset
+		}
+		assets[key] = true
+	}
+
+	for _, order := range b.Orders {
+		if !assets[order.Symbol+":"+order.Country] ||
+			!accounts[order.Account] || order.OrderType == "" ||
+			!IsValidCurrency(order.Currency) || !validBackupDate(order.Date) ||
+			(order.SettlementDate != "" &&
+				!validBackupDate(order.SettlementDate)) {
+			return ErrInvalidBackupOrder
+		}
+	}
+
+	for _, earning := range b.Earnings {
+		if !assets[earning.Symbol+":"+earning.Country] ||
+			earning.EarningType == "" || earning.Amount <= 0 ||
+			!IsValidCurrency(earning.Currency) ||
+			!validBackupDate(earning.Date) {
+			return ErrInvalidBackupEarning
+		}
+	}
+
+	return nil
+}
+
+func validBackupDate(date string) bool {
+	_, err := time.Parse("2006-01-02", date)
+	return err == nil
+}
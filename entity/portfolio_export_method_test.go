@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportFormatVerification(t *testing.T) {
+	for _, format := range []string{"", ExportCsv, ExportJson, ExportXlsx} {
+		assert.Nil(t, ExportFormatVerification(format))
+	}
+
+	assert.Equal(t, ErrInvalidExportFormat, ExportFormatVerification("pdf"))
+	assert.Equal(t, ErrInvalidExportFormat, ExportFormatVerification("XLSX"))
+}
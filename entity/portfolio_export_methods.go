@@ -0,0 +1,19 @@
+package entity
+
+// Formats of the portfolio export.
+const (
+	ExportCsv  = "csv"
+	ExportJson = "json"
+	ExportXlsx = "xlsx"
+)
+
+// ExportFormatVerification returns an error when the export format is not
+// one of the formats available. The JSON format is the default.
+func ExportFormatVerification(format string) error {
+	switch format {
+	case "", ExportCsv, ExportJson, ExportXlsx:
+		return nil
+	}
+
+	return ErrInvalidExportFormat
+}
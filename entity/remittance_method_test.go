@@ -0,0 +1,143 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRemittance(t *testing.T) {
+	date := StringToTime("2021-10-01")
+
+	remittance, err := NewRemittance(5066, "brl", 1000, "usd", 55, 11, 4.9,
+		date, "TestAccountID1", "TestAccountID2", "TestUserUid")
+	assert.Nil(t, err)
+	assert.Equal(t, &Remittance{
+		SentAmount:       5066,
+		SentCurrency:     "BRL",
+		ReceivedAmount:   1000,
+		ReceivedCurrency: "USD",
+		Iof:              55,
+		Fees:             11,
+		CommercialRate:   4.9,
+		Date:             date,
+		FromAccount:      &BrokerageAccount{Id: "TestAccountID1"},
+		ToAccount:        &BrokerageAccount{Id: "TestAccountID2"},
+		UserUid:          "TestUserUid",
+	}, remittance)
+
+	remittance, err = NewRemittance(5066, "BRL", 1000, "USD", 0, 0, 0, date,
+		"", "TestAccountID2", "TestUserUid")
+	assert.Nil(t, err)
+	assert.Nil(t, remittance.FromAccount)
+
+	invalidRemittances := []struct {
+		sentAmount       float64
+		sentCurrency     string
+		receivedAmount   float64
+		receivedCurrency string
+		iof              float64
+		fees             float64
+		commercialRate   float64
+		date             time.Time
+		fromAccountId    string
+		toAccountId      string
+		expectedError    error
+	}{
+		{0, "BRL", 1000, "USD", 0, 0, 0, date, "", "TestAccountID2",
+			ErrInvalidRemittanceAmount},
+		{5066, "BRL", -1, "USD", 0, 0, 0, date, "", "TestAccountID2",
+			ErrInvalidRemittanceAmount},
+		{5066, "BRL", 1000, "USD", -1, 0, 0, date, "", "TestAccountID2",
+			ErrInvalidRemittanceTaxes},
+		{5066, "BRL", 1000, "USD", 5000, 66, 0, date, "", "TestAccountID2",
+			ErrInvalidRemittanceTaxes},
+		{5066, "BRL", 1000, "USD", 0, 0, -1, date, "", "TestAccountID2",
+			ErrInvalidRemittanceRate},
+		{5066, "BRL", 1000, "BRL", 0, 0, 0, date, "", "TestAccountID2",
+			ErrInvalidRemittanceCurrency},
+		{5066, "BRL", 1000, "XYZ", 0, 0, 0, date, "", "TestAccountID2",
+			ErrInvalidRemittanceCurrency},
+		{5066, "BRL", 1000, "USD", 0, 0, 0, time.Time{}, "", "TestAccountID2",
+			ErrInvalidRemittanceDate},
+		{5066, "BRL", 1000, "USD", 0, 0, 0, date, "", "",
+			ErrInvalidRemittanceAccount},
+		{5066, "BRL", 1000, "USD", 0, 0, 0, date, "TestAccountID2",
+			"TestAccountID2", ErrInvalidRemittanceAccount},
+	}
+
+	for _, invalid := range invalidRemittances {
+		remittance, err := NewRemittance(invalid.sentAmount,
+			invalid.sentCurrency, invalid.receivedAmount,
+			invalid.receivedCurrency, invalid.iof, invalid.fees,
+			invalid.commercialRate, invalid.date, invalid.fromAccountId,
+			invalid.toAccountId, "TestUserUid")
+		assert.Nil(t, remittance)
+		assert.Equal(t, invalid.expectedError, err)
+	}
+}
+
+func TestRemittanceRates(t *testing.T) {
+	remittance := Remittance{
+		SentAmount:     5066,
+		ReceivedAmount: 1000,
+		Iof:            55,
+		Fees:           11,
+		CommercialRate: 4.9,
+	}
+
+	assert.Equal(t, 5.066, remittance.EffectiveRate())
+	assert.Equal(t, 5.0, remittance.ExchangeRate())
+	assert.Equal(t, 2.04, remittance.Spread())
+	assert.Equal(t, 1.1, remittance.IofRate())
+
+	remittance.CommercialRate = 0
+	assert.Equal(t, 0.0, remittance.Spread())
+}
+
+func TestNewBrlCostBasis(t *testing.T) {
+	apple := &Asset{Symbol: "AAPL"}
+
+	remittances := []Remittance{
+		{SentAmount: 2750, SentCurrency: "BRL", ReceivedAmount: 500,
+			ReceivedCurrency: "USD", Date: StringToTime("2021-11-01")},
+		{SentAmount: 5066, SentCurrency: "BRL", ReceivedAmount: 1000,
+			ReceivedCurrency: "USD", Date: StringToTime("2021-10-01")},
+		// Only the remittances sent from BRL change the rate
+		{SentAmount: 1000, SentCurrency: "EUR", ReceivedAmount: 1200,
+			ReceivedCurrency: "USD", Date: StringToTime("2021-10-02")},
+	}
+
+	orders := []Order{
+		{Quantity: -3, Price: 200, Currency: "USD", OrderType: "sell",
+			Date: StringToTime("2021-12-01"), Asset: apple},
+		{Quantity: 4, Price: 100, Currency: "USD", OrderType: "buy",
+			Date: StringToTime("2021-10-05"), Asset: apple},
+		{Quantity: 2, Price: 150, Fees: 1, Currency: "USD", OrderType: "buy",
+			Date: StringToTime("2021-11-05"), Asset: apple},
+		{Quantity: 1, Price: 50, Currency: "USD", OrderType: "buy",
+			Date: StringToTime("2021-09-01"), Asset: &Asset{Symbol: "TSLA"}},
+		// Sold entirely, so the buy without a rate is not counted again
+		{Quantity: 2, Price: 300, Currency: "USD", OrderType: "buy",
+			Date: StringToTime("2021-09-10"), Asset: &Asset{Symbol: "MSFT"}},
+		{Quantity: -2, Price: 310, Currency: "USD", OrderType: "sell",
+			Date: StringToTime("2021-10-20"), Asset: &Asset{Symbol: "MSFT"}},
+		{Quantity: 1, Price: 320, Currency: "USD", OrderType: "buy",
+			Date: StringToTime("2021-11-10"), Asset: &Asset{Symbol: "MSFT"}},
+		{Quantity: 10, Price: 30, Currency: "BRL", OrderType: "buy",
+			Date: StringToTime("2021-10-10"), Asset: &Asset{Symbol: "ITUB4"}},
+	}
+
+	expectedCostBasis := []BrlCostBasis{
+		{Symbol: "AAPL", Currency: "USD", Quantity: 3, Invested: 350.5,
+			BrlInvested: 1797.40533333, FxRate: 5.12811793},
+		{Symbol: "MSFT", Currency: "USD", Quantity: 1, Invested: 320,
+			BrlInvested: 1667.41333333, FxRate: 5.21066667},
+		{Symbol: "TSLA", Currency: "USD", Quantity: 1, Invested: 50,
+			OrdersWithoutRate: 1},
+	}
+
+	assert.Equal(t, expectedCostBasis, NewBrlCostBasis(orders, remittances))
+	assert.Equal(t, []BrlCostBasis{}, NewBrlCostBasis(nil, remittances))
+}
@@ -0,0 +1,211 @@
+package entity
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CostBasisCurrency is the currency of the cost basis of the positions bought
+// in foreign currencies, converted with the remittances sent from it.
+const CostBasisCurrency = "BRL"
+
+// NewRemittance creates a remittance of an amount sent from a currency and
+// received in another one, in the account informed. The account it was sent
+// from is optional, since the money may come from a bank account.
+func NewRemittance(sentAmount float64, sentCurrency string,
+	receivedAmount float64, receivedCurrency string, iof float64, fees float64,
+	commercialRate float64, date time.Time, fromAccountId string,
+	toAccountId string, userUid string) (*Remittance, error) {
+
+	remittance := &Remittance{
+		SentAmount:       sentAmount,
+		SentCurrency:     strings.ToUpper(sentCurrency),
+		ReceivedAmount:   receivedAmount,
+		ReceivedCurrency: strings.ToUpper(receivedCurrency),
+		Iof:              iof,
+		Fees:             fees,
+		CommercialRate:   commercialRate,
+		Date:             date,
+		ToAccount:        &BrokerageAccount{Id: toAccountId},
+		UserUid:          userUid,
+	}
+
+	if fromAccountId != "" {
+		remittance.FromAccount = &BrokerageAccount{Id: fromAccountId}
+	}
+
+	if err := remittance.Validate(); err != nil {
+		return nil, err
+	}
+
+	return remittance, nil
+}
+
+// Validate returns an error when the amounts are not positive, the IOF and the
+// fees are negative or take the whole amount sent, the currencies are the
+// same, or the money is received in the account it was sent from.
+func (r *Remittance) Validate() error {
+	if r.SentAmount <= 0 || r.ReceivedAmount <= 0 {
+		return ErrInvalidRemittanceAmount
+	}
+
+	if r.Iof < 0 || r.Fees < 0 || r.Iof+r.Fees >= r.SentAmount {
+		return ErrInvalidRemittanceTaxes
+	}
+
+	if r.CommercialRate < 0 {
+		return ErrInvalidRemittanceRate
+	}
+
+	if !IsValidCurrency(r.SentCurrency) || !IsValidCurrency(r.ReceivedCurrency) ||
+		r.SentCurrency == r.ReceivedCurrency {
+		return ErrInvalidRemittanceCurrency
+	}
+
+	if r.Date.IsZero() {
+		return ErrInvalidRemittanceDate
+	}
+
+	if r.ToAccount == nil || r.ToAccount.Id == "" || (r.FromAccount != nil &&
+		r.FromAccount.Id == r.ToAccount.Id) {
+		return ErrInvalidRemittanceAccount
+	}
+
+	return nil
+}
+
+// EffectiveRate is the amount sent, with the IOF and the fees, paid for each
+// unit of the currency received.
+func (r *Remittance) EffectiveRate() float64 {
+	return roundQuantity(r.SentAmount / r.ReceivedAmount)
+}
+
+// ExchangeRate is the rate of the exchange, without the IOF and the fees.
+func (r *Remittance) ExchangeRate() float64 {
+	return roundQuantity((r.SentAmount - r.Iof - r.Fees) / r.ReceivedAmount)
+}
+
+// Spread is the percentage of the exchange rate over the commercial rate, or
+// zero when the commercial rate was not informed.
+func (r *Remittance) Spread() float64 {
+	if r.CommercialRate == 0 {
+		return 0
+	}
+
+	return math.Round((r.ExchangeRate()/r.CommercialRate-1)*10000) / 100
+}
+
+// IofRate is the percentage of the IOF over the amount exchanged.
+func (r *Remittance) IofRate() float64 {
+	return math.Round(r.Iof/(r.SentAmount-r.Iof-r.Fees)*10000) / 100
+}
+
+// NewBrlCostBasis converts to BRL the cost of the positions bought in foreign
+// currencies. Each buy, with its fees, is converted by the effective rate of
+// all the remittances from BRL to its currency made until its date, weighted
+// by the amount received. The sells take out of the position its average cost
+// in both currencies, keeping the rate of the position, and a position sold
+// entirely starts again without the buys that had no rate.
+func NewBrlCostBasis(orders []Order, remittances []Remittance) []BrlCostBasis {
+	type positionKey struct {
+		symbol   string
+		currency string
+	}
+
+	sortedRemittances := append([]Remittance(nil), remittances...)
+	sort.SliceStable(sortedRemittances, func(i, j int) bool {
+		return sortedRemittances[i].Date.Before(sortedRemittances[j].Date)
+	})
+
+	sortedOrders := append([]Order(nil), orders...)
+	sort.SliceStable(sortedOrders, func(i, j int) bool {
+		return sortedOrders[i].Date.Before(sortedOrders[j].Date)
+	})
+
+	sent := map[string]float64{}
+	received := map[string]float64{}
+	nextRemittance := 0
+
+	positions := map[positionKey]*BrlCostBasis{}
+	for _, order := range sortedOrders {
+		if order.Currency == CostBasisCurrency || order.Asset == nil ||
+			(order.OrderType != "buy" && order.OrderType != "sell") {
+			continue
+		}
+
+		for nextRemittance < len(sortedRemittances) {
+			remittance := sortedRemittances[nextRemittance]
+			if remittance.Date.After(order.Date) {
+				break
+			}
+
+			if remittance.SentCurrency == CostBasisCurrency {
+				sent[remittance.ReceivedCurrency] += remittance.SentAmount
+				received[remittance.ReceivedCurrency] +=
+					remittance.ReceivedAmount
+			}
+			nextRemittance++
+		}
+
+		key := positionKey{order.Asset.Symbol, order.Currency}
+		if positions[key] == nil {
+			positions[key] = &BrlCostBasis{
+				Symbol:   order.Asset.Symbol,
+				Currency: order.Currency,
+			}
+		}
+		position := positions[key]
+
+		if order.Quantity < 0 {
+			if position.Quantity > 0 {
+				remaining := math.Max(position.Quantity+order.Quantity, 0) /
+					position.Quantity
+				position.Invested *= remaining
+				position.BrlInvested *= remaining
+			}
+			position.Quantity = roundQuantity(position.Quantity + order.Quantity)
+			if position.Quantity <= 0 {
+				position.OrdersWithoutRate = 0
+			}
+			continue
+		}
+
+		cost := order.Quantity*order.Price + order.Fees
+		position.Quantity = roundQuantity(position.Quantity + order.Quantity)
+		position.Invested += cost
+
+		if received[order.Currency] > 0 {
+			position.BrlInvested += cost * sent[order.Currency] /
+				received[order.Currency]
+		} else {
+			position.OrdersWithoutRate++
+		}
+	}
+
+	costBasis := []BrlCostBasis{}
+	for _, position := range positions {
+		if position.Quantity <= 0 {
+			continue
+		}
+
+		position.Invested = roundQuantity(position.Invested)
+		position.BrlInvested = roundQuantity(position.BrlInvested)
+		if position.Invested > 0 && position.OrdersWithoutRate == 0 {
+			position.FxRate = roundQuantity(position.BrlInvested /
+				position.Invested)
+		}
+
+		costBasis = append(costBasis, *position)
+	}
+
+	sort.Slice(costBasis, func(i, j int) bool {
+		if costBasis[i].Currency != costBasis[j].Currency {
+			return costBasis[i].Currency < costBasis[j].Currency
+		}
+		return costBasis[i].Symbol < costBasis[j].Symbol
+	})
+
+	return costBasis
+}
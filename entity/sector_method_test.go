@@ -0,0 +1,99 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSector(t *testing.T) {
+	sector := &Sector{Id: "TestSectorID", Level: SectorLevelSector}
+	industryGroup := &Sector{Id: "TestGroupID", Level: SectorLevelIndustryGroup}
+
+	type test struct {
+		name          string
+		level         string
+		parent        *Sector
+		expectedError error
+	}
+
+	tests := []test{
+		{name: "Financials", level: "", parent: nil, expectedError: nil},
+		{name: "Banks", level: SectorLevelIndustryGroup, parent: sector,
+			expectedError: nil},
+		{name: "Banks", level: SectorLevelIndustry, parent: industryGroup,
+			expectedError: nil},
+		{name: " ", level: SectorLevelSector, parent: nil,
+			expectedError: ErrInvalidSectorBlank},
+		{name: "Banks", level: "SUB_INDUSTRY", parent: industryGroup,
+			expectedError: ErrInvalidSectorLevel},
+		{name: "Banks", level: SectorLevelIndustry, parent: sector,
+			expectedError: ErrInvalidSectorParent},
+		{name: "Banks", level: SectorLevelIndustryGroup, parent: nil,
+			expectedError: ErrInvalidSectorParent},
+		{name: "Financials", level: SectorLevelSector, parent: sector,
+			expectedError: ErrInvalidSectorParent},
+	}
+
+	for _, testCase := range tests {
+		_, err := NewSector(testCase.name, testCase.level, "", testCase.parent)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+func TestNewSectorAllocation(t *testing.T) {
+	financialsId := "TestFinancialsID"
+	banksGroupId := "TestBanksGroupID"
+
+	sectors := []Sector{
+		{Id: financialsId, Name: "Financials", Level: SectorLevelSector},
+		{Id: banksGroupId, Name: "Banks", Level: SectorLevelIndustryGroup,
+			ParentId: &financialsId},
+		{Id: "TestBanksID", Name: "Banks", Level: SectorLevelIndustry,
+			ParentId: &banksGroupId},
+		{Id: "TestUnclassifiedID", Name: UnclassifiedSectorName,
+			Level: SectorLevelSector},
+	}
+
+	positions := []SectorPosition{
+		{Symbol: "ITUB4", SectorId: "TestBanksID", Currency: "BRL",
+			Invested: 300},
+		{Symbol: "BBDC4", SectorId: "TestBanksID", Currency: "BRL",
+			Invested: 100},
+		{Symbol: "XPTO3", SectorId: "TestUnclassifiedID", Currency: "BRL",
+			Invested: 100},
+		{Symbol: "JPM", SectorId: "TestBanksID", Currency: "USD",
+			Invested: 50},
+	}
+
+	allocations, err := NewSectorAllocation(positions, sectors,
+		SectorLevelSector)
+	assert.Nil(t, err)
+	assert.Equal(t, []SectorAllocation{
+		{Sector: &sectors[0], Currency: "BRL", Invested: 400, Percentage: 80,
+			Symbols: []string{"BBDC4", "ITUB4"}},
+		{Sector: &sectors[3], Currency: "BRL", Invested: 100, Percentage: 20,
+			Symbols: []string{"XPTO3"}},
+		{Sector: &sectors[0], Currency: "USD", Invested: 50, Percentage: 100,
+			Symbols: []string{"JPM"}},
+	}, allocations)
+
+	allocations, err = NewSectorAllocation(positions, sectors,
+		SectorLevelIndustry)
+	assert.Nil(t, err)
+	assert.Equal(t, &sectors[2], allocations[0].Sector)
+	assert.Equal(t, &sectors[3], allocations[1].Sector)
+
+	_, err = NewSectorAllocation(positions, sectors, "SUB_INDUSTRY")
+	assert.Equal(t, ErrInvalidSectorLevel, err)
+}
+
+func TestSectorValidateMerge(t *testing.T) {
+	banks := &Sector{Id: "TestBanksID", Level: SectorLevelIndustry}
+	insurance := &Sector{Id: "TestInsuranceID", Level: SectorLevelIndustry}
+	financials := &Sector{Id: "TestFinancialsID", Level: SectorLevelSector}
+
+	assert.Nil(t, banks.ValidateMerge(insurance))
+	assert.Equal(t, ErrInvalidSectorMerge, banks.ValidateMerge(banks))
+	assert.Equal(t, ErrInvalidSectorMerge, banks.ValidateMerge(financials))
+}
@@ -0,0 +1,198 @@
+package entity
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// The sector taxonomy has three levels, like the GICS: sector, industry group
+// and industry. Each node, except the sectors, has a parent in the previous
+// level.
+const (
+	SectorLevelSector        = "SECTOR"
+	SectorLevelIndustryGroup = "INDUSTRY_GROUP"
+	SectorLevelIndustry      = "INDUSTRY"
+)
+
+var sectorLevels = []string{SectorLevelSector, SectorLevelIndustryGroup,
+	SectorLevelIndustry}
+
+// The sector labels are mapped per provider. FINNHUB labels are the industry
+// returned in the company profile, while STOCKFY labels are given by the API
+// to the asset types without a company profile, e.g. the ETFs.
+const (
+	SectorProviderFinnhub = "FINNHUB"
+	SectorProviderStockfy = "STOCKFY"
+)
+
+var sectorProviders = []string{SectorProviderFinnhub, SectorProviderStockfy}
+
+// Assets whose sector label is not mapped yet are classified in this sector,
+// until an admin maps the label to a node of the taxonomy.
+const UnclassifiedSectorName = "Unclassified"
+
+func NewSector(name string, level string, code string, parent *Sector) (
+	*Sector, error) {
+
+	if level == "" {
+		level = SectorLevelSector
+	}
+
+	sector := &Sector{
+		Name:  strings.TrimSpace(name),
+		Level: level,
+		Code:  strings.TrimSpace(code),
+	}
+
+	if parent != nil {
+		sector.ParentId = &parent.Id
+	}
+
+	if err := sector.Validate(parent); err != nil {
+		return nil, err
+	}
+
+	return sector, nil
+}
+
+// Validate verifies if the parent is a node of the level above the sector.
+func (s *Sector) Validate(parent *Sector) error {
+	if s.Name == "" {
+		return ErrInvalidSectorBlank
+	}
+
+	depth := SectorLevelDepth(s.Level)
+	if depth < 0 {
+		return ErrInvalidSectorLevel
+	}
+
+	if depth == 0 && parent == nil {
+		return nil
+	}
+
+	if parent == nil || SectorLevelDepth(parent.Level) != depth-1 {
+		return ErrInvalidSectorParent
+	}
+
+	return nil
+}
+
+// SectorLevelDepth returns the position of the level in the taxonomy, starting
+// from 0 for the sectors, or -1 for an invalid level.
+func SectorLevelDepth(level string) int {
+	for depth, sectorLevel := range sectorLevels {
+		if sectorLevel == level {
+			return depth
+		}
+	}
+
+	return -1
+}
+
+func IsValidSectorProvider(provider string) bool {
+	for _, sectorProvider := range sectorProviders {
+		if sectorProvider == provider {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SectorLabelProvider returns the provider of the sector label of an asset.
+// Only the stocks have the sector label returned by the market providers.
+func SectorLabelProvider(assetType string) string {
+	if assetType == "STOCK" {
+		return SectorProviderFinnhub
+	}
+
+	return SectorProviderStockfy
+}
+
+// NewSectorAllocation sums the amount invested per currency in each node of
+// the given level of the taxonomy. The assets classified in a node below the
+// level are summed in its ancestor, while the assets classified in a node
+// above the level are kept in their own node.
+func NewSectorAllocation(positions []SectorPosition, sectors []Sector,
+	level string) ([]SectorAllocation, error) {
+
+	depth := SectorLevelDepth(level)
+	if depth < 0 {
+		return nil, ErrInvalidSectorLevel
+	}
+
+	sectorsPerId := map[string]Sector{}
+	for _, sector := range sectors {
+		sectorsPerId[sector.Id] = sector
+	}
+
+	type sectorCurrency struct {
+		sectorId string
+		currency string
+	}
+
+	var allocations []SectorAllocation
+	allocationIndex := map[sectorCurrency]int{}
+	totals := map[string]float64{}
+
+	for _, position := range positions {
+		sector, ok := sectorsPerId[position.SectorId]
+		if !ok {
+			continue
+		}
+
+		for SectorLevelDepth(sector.Level) > depth && sector.ParentId != nil {
+			parent, ok := sectorsPerId[*sector.ParentId]
+			if !ok {
+				break
+			}
+			sector = parent
+		}
+
+		key := sectorCurrency{sector.Id, position.Currency}
+		i, ok := allocationIndex[key]
+		if !ok {
+			allocationSector := sector
+			allocations = append(allocations, SectorAllocation{
+				Sector:   &allocationSector,
+				Currency: position.Currency,
+			})
+			i = len(allocations) - 1
+			allocationIndex[key] = i
+		}
+
+		allocations[i].Invested += position.Invested
+		allocations[i].Symbols = append(allocations[i].Symbols,
+			position.Symbol)
+		totals[position.Currency] += position.Invested
+	}
+
+	for i := range allocations {
+		if total := totals[allocations[i].Currency]; total > 0 {
+			allocations[i].Percentage = math.Round(
+				allocations[i].Invested/total*10000) / 100
+		}
+		sort.Strings(allocations[i].Symbols)
+	}
+
+	sort.Slice(allocations, func(a, b int) bool {
+		if allocations[a].Currency != allocations[b].Currency {
+			return allocations[a].Currency < allocations[b].Currency
+		}
+		return allocations[a].Invested > allocations[b].Invested
+	})
+
+	return allocations, nil
+}
+
+// ValidateMerge verifies if the sector can be merged into the target. Only
+// different nodes of the same level are merged, so the children of the sector
+// keep a parent in the level above them.
+func (s *Sector) ValidateMerge(target *Sector) error {
+	if s.Id == target.Id || s.Level != target.Level {
+		return ErrInvalidSectorMerge
+	}
+
+	return nil
+}
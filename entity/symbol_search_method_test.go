@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSymbolQuery(t *testing.T) {
+	query, err := NormalizeSymbolQuery("  itau ")
+	assert.Nil(t, err)
+	assert.Equal(t, "itau", query)
+
+	_, err = NormalizeSymbolQuery("   ")
+	assert.Equal(t, ErrInvalidSymbolSearchQuery, err)
+
+	_, err = NormalizeSymbolQuery(strings.Repeat("A", 51))
+	assert.Equal(t, ErrInvalidSymbolSearchQuery, err)
+}
+
+func TestSymbolSearchLimit(t *testing.T) {
+	type test struct {
+		limit         int
+		expectedLimit int
+		expectedError error
+	}
+
+	tests := []test{
+		{limit: 0, expectedLimit: 10, expectedError: nil},
+		{limit: 25, expectedLimit: 25, expectedError: nil},
+		{limit: -1, expectedLimit: 0, expectedError: ErrInvalidSymbolSearchLimit},
+		{limit: 51, expectedLimit: 0, expectedError: ErrInvalidSymbolSearchLimit},
+	}
+
+	for _, testCase := range tests {
+		limit, err := SymbolSearchLimit(testCase.limit)
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expectedLimit, limit)
+	}
+}
+
+func TestNewSymbolSuggestion(t *testing.T) {
+	type test struct {
+		symbol        string
+		fullname      string
+		assetType     string
+		country       string
+		expected      *SymbolSuggestion
+		expectedError error
+	}
+
+	tests := []test{
+		{
+			symbol:    "itub4.sa",
+			fullname:  "Itau Unibanco Holding S.A",
+			assetType: "stock",
+			country:   "BR",
+			expected: &SymbolSuggestion{
+				Symbol:   "ITUB4",
+				Fullname: "Itau Unibanco Holding S.A",
+				Type:     "STOCK",
+				Country:  "BR",
+			},
+			expectedError: nil,
+		},
+		{
+			symbol:    "O",
+			fullname:  "Realty Income Corp",
+			assetType: "REIT",
+			country:   "US",
+			expected: &SymbolSuggestion{
+				Symbol:   "O",
+				Fullname: "Realty Income Corp",
+				Type:     "REIT",
+				Country:  "US",
+			},
+			expectedError: nil,
+		},
+		{
+			symbol:        "KNRI11",
+			fullname:      "Kinea Renda Imobiliaria",
+			assetType:     "REIT",
+			country:       "BR",
+			expectedError: ErrInvalidSymbolListEntry,
+		},
+		{
+			symbol:        "",
+			fullname:      "Apple Inc",
+			assetType:     "STOCK",
+			country:       "US",
+			expectedError: ErrInvalidSymbolListEntry,
+		},
+		{
+			symbol:        "AAPL",
+			fullname:      "Apple Inc",
+			assetType:     "STOCK",
+			country:       "EU",
+			expectedError: ErrInvalidSymbolListEntry,
+		},
+	}
+
+	for _, testCase := range tests {
+		suggestion, err := NewSymbolSuggestion(testCase.symbol,
+			testCase.fullname, testCase.assetType, testCase.country)
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expected, suggestion)
+	}
+}
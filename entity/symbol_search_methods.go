@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// The symbol search is used in the autocomplete of the symbol fields, so only
+// the first suggestions are returned.
+const (
+	SymbolSearchDefaultLimit   = 10
+	SymbolSearchMaxLimit       = 50
+	SymbolSearchMaxQueryLength = 50
+)
+
+// NormalizeSymbolQuery removes the blank spaces around the text typed by the
+// user and verifies if it can be searched.
+func NormalizeSymbolQuery(query string) (string, error) {
+	query = strings.TrimSpace(query)
+
+	if query == "" || utf8.RuneCountInString(query) > SymbolSearchMaxQueryLength {
+		return "", ErrInvalidSymbolSearchQuery
+	}
+
+	return query, nil
+}
+
+// SymbolSearchLimit returns the number of suggestions of the search, using the
+// default limit when the limit is not informed.
+func SymbolSearchLimit(limit int) (int, error) {
+	if limit == 0 {
+		return SymbolSearchDefaultLimit, nil
+	}
+
+	if limit < 0 || limit > SymbolSearchMaxLimit {
+		return 0, ErrInvalidSymbolSearchLimit
+	}
+
+	return limit, nil
+}
+
+// NewSymbolSuggestion creates an entry of the symbol index from a line of the
+// symbol list of an exchange. The symbol is stored without the suffix used by
+// the market data providers, like the assets.
+func NewSymbolSuggestion(symbol string, fullname string, assetType string,
+	country string) (*SymbolSuggestion, error) {
+
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	fullname = strings.TrimSpace(fullname)
+	assetType = strings.ToUpper(strings.TrimSpace(assetType))
+
+	market, err := SearchMarket(country)
+	if err != nil || symbol == "" || fullname == "" ||
+		!market.HasAssetType(assetType) {
+		return nil, ErrInvalidSymbolListEntry
+	}
+
+	return &SymbolSuggestion{
+		Symbol:   market.MarketSymbol(symbol),
+		Fullname: fullname,
+		Type:     assetType,
+		Country:  country,
+	}, nil
+}
@@ -2,11 +2,17 @@ package alphaVantage
 
 import (
 	"io"
+	"sort"
 	"stockfyApi/entity"
 	"strings"
 )
 
+// Default address of the Alpha Vantage REST API. It can be replaced through
+// the BaseUrl field, which is useful to test this client against a fake server.
+const AlphaVantageBaseUrl = "https://www.alphavantage.co"
+
 type AlphaApi struct {
+	BaseUrl            string
 	Token              string
 	HttpOutsideRequest func(method string, url string, contentType string,
 		bodyReq io.Reader, bodyResp interface{})
@@ -15,14 +21,29 @@ type AlphaApi struct {
 func NewAlphaVantageApi(token string, httpClient func(method string,
 	url string, contentType string, bodyReq io.Reader,
 	bodyResp interface{})) *AlphaApi {
+	return NewAlphaVantageApiWithBaseUrl(AlphaVantageBaseUrl, token, httpClient)
+}
+
+func NewAlphaVantageApiWithBaseUrl(baseUrl string, token string,
+	httpClient func(method string, url string, contentType string,
+		bodyReq io.Reader, bodyResp interface{})) *AlphaApi {
 	return &AlphaApi{
+		BaseUrl:            baseUrl,
 		Token:              token,
 		HttpOutsideRequest: httpClient,
 	}
 }
 
+func (a *AlphaApi) baseUrl() string {
+	if a.BaseUrl == "" {
+		return AlphaVantageBaseUrl
+	}
+
+	return a.BaseUrl
+}
+
 func (a *AlphaApi) VerifySymbol2(symbol string) entity.SymbolLookup {
-	url := "https://www.alphavantage.co/query?function=SYMBOL_SEARCH&keywords=" +
+	url := a.baseUrl() + "/query?function=SYMBOL_SEARCH&keywords=" +
 		symbol + "&apikey=" + a.Token
 
 	var symbolLookupAlpha SymbolLookupAlpha
@@ -45,7 +66,7 @@ func (a *AlphaApi) VerifySymbol2(symbol string) entity.SymbolLookup {
 }
 
 func (a *AlphaApi) GetPrice(symbol string) entity.SymbolPrice {
-	url := "https://www.alphavantage.co/query?function=GLOBAL_QUOTE&symbol=" +
+	url := a.baseUrl() + "/query?function=GLOBAL_QUOTE&symbol=" +
 		symbol + "&apikey=" + a.Token
 
 	var symbolPriceNotFormatted SymbolPriceAlpha
@@ -62,8 +83,79 @@ func (a *AlphaApi) GetPrice(symbol string) entity.SymbolPrice {
 	return symbolPrice
 }
 
+// The Alpha Vantage bulk quote endpoint accepts at most 100 symbols for each
+// request.
+const maxBulkQuoteSymbols = 100
+
+func (a *AlphaApi) GetPrices(symbols []string) []entity.SymbolPrice {
+	var symbolPrices []entity.SymbolPrice
+
+	for start := 0; start < len(symbols); start += maxBulkQuoteSymbols {
+		end := start + maxBulkQuoteSymbols
+		if end > len(symbols) {
+			end = len(symbols)
+		}
+
+		url := a.baseUrl() + "/query?function=REALTIME_BULK_QUOTES" +
+			"&symbol=" + strings.Join(symbols[start:end], ",") + "&apikey=" +
+			a.Token
+
+		var bulkPriceNotFormatted SymbolBulkPriceAlpha
+
+		a.HttpOutsideRequest("GET", url, "", nil, &bulkPriceNotFormatted)
+
+		for _, quote := range bulkPriceNotFormatted.Data {
+			symbolPrice := entity.ConvertAssetPrice(
+				strings.ReplaceAll(quote.Symbol, ".SAO", ".SA"), quote.Open,
+				quote.High, quote.Low, quote.Close, quote.PrevClose)
+
+			symbolPrices = append(symbolPrices, symbolPrice)
+		}
+	}
+
+	return symbolPrices
+}
+
+func (a *AlphaApi) getCurrencyExchangeRate(fromCurrency string,
+	toCurrency string) CurrencyExchangeRateInfo {
+	url := a.baseUrl() + "/query?function=CURRENCY_EXCHANGE_RATE" +
+		"&from_currency=" + fromCurrency + "&to_currency=" + toCurrency +
+		"&apikey=" + a.Token
+
+	var exchangeRate CurrencyExchangeRateAlpha
+
+	a.HttpOutsideRequest("GET", url, "", nil, &exchangeRate)
+
+	return exchangeRate.ExchangeRate
+}
+
+// VerifyCryptoSymbol uses the exchange rate to the default quote currency,
+// since the Alpha Vantage API does not have a search for cryptocurrencies.
+func (a *AlphaApi) VerifyCryptoSymbol(symbol string) entity.SymbolLookup {
+	exchangeRate := a.getCurrencyExchangeRate(symbol,
+		entity.DefaultCryptoQuoteCurrency)
+
+	if exchangeRate.FromCurrencyCode == "" {
+		return entity.SymbolLookup{}
+	}
+
+	return entity.ConvertAssetLookup(exchangeRate.FromCurrencyCode,
+		exchangeRate.FromCurrencyName, entity.CryptoAssetType)
+}
+
+func (a *AlphaApi) GetCryptoPrice(symbol string,
+	currency string) entity.SymbolPrice {
+	exchangeRate := a.getCurrencyExchangeRate(symbol, currency)
+
+	return entity.SymbolPrice{
+		Symbol:       symbol,
+		CurrentPrice: entity.StringToFloat64(exchangeRate.ExchangeRate),
+		Currency:     currency,
+	}
+}
+
 func (a *AlphaApi) CompanyOverview(symbol string) map[string]string {
-	url := "https://www.alphavantage.co/query?function=OVERVIEW&symbol=" +
+	url := a.baseUrl() + "/query?function=OVERVIEW&symbol=" +
 		symbol + "&apikey=" + a.Token
 
 	var companyOverview map[string]string
@@ -72,3 +164,56 @@ func (a *AlphaApi) CompanyOverview(symbol string) map[string]string {
 
 	return companyOverview
 }
+
+// GetEarningEvents returns the dividends announced for the symbol. Alpha
+// Vantage does not inform the currency, which is the currency of the market.
+func (a *AlphaApi) GetEarningEvents(symbol string) []entity.EarningEvent {
+	url := a.baseUrl() + "/query?function=DIVIDENDS&symbol=" + symbol +
+		"&apikey=" + a.Token
+
+	var dividends DividendsAlpha
+	var earningEvents []entity.EarningEvent
+
+	a.HttpOutsideRequest("GET", url, "", nil, &dividends)
+
+	for _, dividend := range dividends.Data {
+		earningEvents = append(earningEvents, entity.EarningEvent{
+			Type:        "Dividendos",
+			Amount:      entity.StringToFloat64(dividend.Amount),
+			ExDate:      entity.StringToTime(dividend.ExDividendDate),
+			PaymentDate: entity.StringToTime(dividend.PaymentDate),
+		})
+	}
+
+	return earningEvents
+}
+
+// GetPriceHistory returns the daily prices of the last 100 trading days of the
+// symbol, from the oldest to the newest.
+func (a *AlphaApi) GetPriceHistory(symbol string) []entity.SymbolPriceHistory {
+	url := a.baseUrl() + "/query?function=TIME_SERIES_DAILY&symbol=" + symbol +
+		"&apikey=" + a.Token
+
+	var timeSeries TimeSeriesDailyAlpha
+	var priceHistory []entity.SymbolPriceHistory
+
+	a.HttpOutsideRequest("GET", url, "", nil, &timeSeries)
+
+	for date, price := range timeSeries.TimeSeries {
+		priceHistory = append(priceHistory, entity.SymbolPriceHistory{
+			Symbol:     strings.ReplaceAll(symbol, ".SA", ""),
+			Date:       entity.StringToTime(date),
+			OpenPrice:  entity.StringToFloat64(price.Open),
+			HighPrice:  entity.StringToFloat64(price.High),
+			LowPrice:   entity.StringToFloat64(price.Low),
+			ClosePrice: entity.StringToFloat64(price.Close),
+			Volume:     entity.StringToFloat64(price.Volume),
+		})
+	}
+
+	sort.Slice(priceHistory, func(i, j int) bool {
+		return priceHistory[i].Date.Before(priceHistory[j].Date)
+	})
+
+	return priceHistory
+}
@@ -472,3 +472,123 @@ func TestGetPrice(t *testing.T) {
 	}
 
 }
+
+func TestGetPrices(t *testing.T) {
+	MockDoFunc := func(req *http.Request) (*http.Response, error) {
+
+		var symbols []string
+		bodyResp := SymbolBulkPriceAlpha{}
+
+		// Treat body from the request to get the symbol list from the URL query
+		urlQuery := strings.Split(req.URL.RawQuery, "&")
+		for _, query := range urlQuery {
+			queryParams := strings.Split(string(query), "=")
+
+			if queryParams[0] == "symbol" {
+				symbols = strings.Split(queryParams[1], ",")
+			}
+		}
+
+		// Only the known symbols are returned in the data list, as the Alpha
+		// Vantage API does for symbols that it does not find.
+		for _, symbol := range symbols {
+			switch symbol {
+			case "ITUB4.SA":
+				bodyResp.Data = append(bodyResp.Data, SymbolBulkPriceInfo{
+					Symbol:        symbol + "O",
+					Timestamp:     "2021-11-23 16:00:00",
+					Open:          "22.5000",
+					High:          "22.4400",
+					Low:           "21.9100",
+					Close:         "22.4400",
+					Volume:        "95434007",
+					PrevClose:     "22.0700",
+					Change:        "0.3700",
+					ChangePercent: "1.6765",
+				})
+				break
+			case "KNRI11.SA":
+				bodyResp.Data = append(bodyResp.Data, SymbolBulkPriceInfo{
+					Symbol:        symbol + "O",
+					Timestamp:     "2021-11-23 16:00:00",
+					Open:          "133.6000",
+					High:          "134.0000",
+					Low:           "132.7001",
+					Close:         "132.9500",
+					Volume:        "23140",
+					PrevClose:     "134.0000",
+					Change:        "-1.0500",
+					ChangePercent: "-0.7836",
+				})
+				break
+			}
+		}
+
+		bodyByte, _ := json.Marshal(bodyResp)
+
+		respHeader := http.Header{
+			"Content-Type": {"application/json"},
+		}
+		return &http.Response{
+			Status:     "200 OK",
+			StatusCode: 200,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     respHeader,
+			Body:       ioutil.NopCloser(bytes.NewReader(bodyByte)),
+			Request:    req,
+		}, nil
+	}
+
+	type test struct {
+		symbols              []string
+		expectedSymbolPrices []entity.SymbolPrice
+	}
+
+	tests := []test{
+		{
+			symbols: []string{"ITUB4.SA", "UNKNOWN.SA", "KNRI11.SA"},
+			expectedSymbolPrices: []entity.SymbolPrice{
+				{
+					Symbol:         "ITUB4",
+					OpenPrice:      22.5000,
+					HighPrice:      22.4400,
+					LowPrice:       21.9100,
+					CurrentPrice:   22.4400,
+					PrevClosePrice: 22.0700,
+				},
+				{
+					Symbol:         "KNRI11",
+					OpenPrice:      133.6000,
+					HighPrice:      134.0000,
+					LowPrice:       132.7001,
+					CurrentPrice:   132.9500,
+					PrevClosePrice: 134.0000,
+				},
+			},
+		},
+		{
+			symbols:              []string{"UNKNOWN.SA"},
+			expectedSymbolPrices: nil,
+		},
+	}
+
+	mockAlphaClient := MockClient{
+		Client: fiberHandlers.MockClient{
+			DoFunc: MockDoFunc,
+		},
+	}
+
+	alpha := AlphaApi{
+		Token:              "Test",
+		HttpOutsideRequest: mockAlphaClient.HttpOutsideClientRequest,
+	}
+
+	for _, testCase := range tests {
+		symbolPrices := alpha.GetPrices(testCase.symbols)
+
+		assert.Equal(t, testCase.expectedSymbolPrices, symbolPrices)
+	}
+
+}
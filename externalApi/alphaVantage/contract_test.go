@@ -0,0 +1,245 @@
+package alphaVantage
+
+import (
+	"net/http"
+	"stockfyApi/client"
+	"stockfyApi/entity"
+	"stockfyApi/externalApi/fakeApi"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Payloads copied from the Alpha Vantage API responses.
+const (
+	searchItub4Body = `{"bestMatches":[{"1. symbol":"ITUB4.SAO",` +
+		`"2. name":"Itau Unibanco Holding S.A.","3. type":"Equity",` +
+		`"4. region":"Brazil/Sao Paolo","5. marketOpen":"10:00",` +
+		`"6. marketClose":"17:30","7. timezone":"UTC-03",` +
+		`"8. currency":"BRL","9. matchScore":"1.0000"}]}`
+	globalQuoteItub4Body = `{"Global Quote":{"01. symbol":"ITUB4.SAO",` +
+		`"02. open":"22.5000","03. high":"22.8900","04. low":"22.3100",` +
+		`"05. price":"22.7000","06. volume":"35121500",` +
+		`"07. latest trading day":"2021-10-29",` +
+		`"08. previous close":"22.4400","09. change":"0.2600",` +
+		`"10. change percent":"1.1586%"}}`
+	overviewItub4Body = `{"Symbol":"ITUB4.SAO","AssetType":"Common Stock",` +
+		`"Name":"Itau Unibanco Holding S.A.","Exchange":"BOVESPA",` +
+		`"Currency":"BRL","Country":"Brazil","Sector":"FINANCE",` +
+		`"Industry":"BANKS"}`
+	exchangeRateBtcBrlBody = `{"Realtime Currency Exchange Rate":` +
+		`{"1. From_Currency Code":"BTC","2. From_Currency Name":"Bitcoin",` +
+		`"3. To_Currency Code":"BRL","4. To_Currency Name":"Brazilian Real",` +
+		`"5. Exchange Rate":"341890.27000000",` +
+		`"6. Last Refreshed":"2021-10-29 18:01:02","7. Time Zone":"UTC",` +
+		`"8. Bid Price":"341887.11000000","9. Ask Price":"341893.42000000"}}`
+	exchangeRateBtcUsdBody = `{"Realtime Currency Exchange Rate":` +
+		`{"1. From_Currency Code":"BTC","2. From_Currency Name":"Bitcoin",` +
+		`"3. To_Currency Code":"USD","4. To_Currency Name":` +
+		`"United States Dollar","5. Exchange Rate":"61870.44000000",` +
+		`"6. Last Refreshed":"2021-10-29 18:01:02","7. Time Zone":"UTC",` +
+		`"8. Bid Price":"61870.43000000","9. Ask Price":"61870.44000000"}}`
+	dividendsItub4Body = `{"symbol":"ITUB4.SAO","data":[` +
+		`{"ex_dividend_date":"2021-10-01","declaration_date":"2021-09-20",` +
+		`"record_date":"2021-09-30","payment_date":"2021-11-01",` +
+		`"amount":"0.01765"},` +
+		`{"ex_dividend_date":"2021-11-01","declaration_date":"None",` +
+		`"record_date":"None","payment_date":"None","amount":"0.01765"}]}`
+	timeSeriesDailyItub4Body = `{"Meta Data":{"2. Symbol":"ITUB4.SAO"},` +
+		`"Time Series (Daily)":{"2021-10-29":{"1. open":"22.5000",` +
+		`"2. high":"22.8900","3. low":"22.3100","4. close":"22.7000",` +
+		`"5. volume":"35121500"},"2021-10-28":{"1. open":"22.1000",` +
+		`"2. high":"22.5500","3. low":"22.0100","4. close":"22.4400",` +
+		`"5. volume":"30112300"}}}`
+)
+
+func TestAlphaVantageContract(t *testing.T) {
+	server := fakeApi.NewAlphaVantageServer()
+	defer server.Close()
+
+	alphaApi := NewAlphaVantageApiWithBaseUrl(server.URL, "Test",
+		client.RequestAndAssignToBody)
+
+	server.Script(fakeApi.AlphaSymbolSearch, "ITUB4.SA", http.StatusOK,
+		searchItub4Body)
+	server.Script(fakeApi.AlphaGlobalQuote, "ITUB4.SA", http.StatusOK,
+		globalQuoteItub4Body)
+	server.Script(fakeApi.AlphaOverview, "ITUB4.SA", http.StatusOK,
+		overviewItub4Body)
+
+	assert.Equal(t, entity.SymbolLookup{
+		Fullname: "Itau Unibanco Holding S.A.",
+		Symbol:   "ITUB4",
+		Type:     "Equity",
+	}, alphaApi.VerifySymbol2("ITUB4.SA"))
+	assert.Equal(t, entity.SymbolPrice{
+		Symbol:         "ITUB4",
+		OpenPrice:      22.5,
+		HighPrice:      22.89,
+		LowPrice:       22.31,
+		CurrentPrice:   22.7,
+		PrevClosePrice: 22.44,
+	}, alphaApi.GetPrice("ITUB4.SA"))
+	assert.Equal(t, "FINANCE", alphaApi.CompanyOverview("ITUB4.SA")["Sector"])
+
+	assert.Equal(t, []string{
+		"/query?function=SYMBOL_SEARCH&keywords=ITUB4.SA&apikey=Test",
+		"/query?function=GLOBAL_QUOTE&symbol=ITUB4.SA&apikey=Test",
+		"/query?function=OVERVIEW&symbol=ITUB4.SA&apikey=Test",
+	}, server.Requests())
+
+	// Unknown symbols receive the default Alpha Vantage payloads
+	assert.Equal(t, entity.SymbolLookup{}, alphaApi.VerifySymbol2("UNKNOWN"))
+	assert.Equal(t, entity.SymbolPrice{Symbol: "UNKNOWN"},
+		alphaApi.GetPrice("UNKNOWN"))
+	assert.Equal(t, map[string]string{}, alphaApi.CompanyOverview("UNKNOWN"))
+
+	// The bulk quote endpoint is only available on premium plans
+	assert.Nil(t, alphaApi.GetPrices([]string{"ITUB4.SA"}))
+}
+
+func TestAlphaVantageDividendsContract(t *testing.T) {
+	server := fakeApi.NewAlphaVantageServer()
+	defer server.Close()
+
+	alphaApi := NewAlphaVantageApiWithBaseUrl(server.URL, "Test",
+		client.RequestAndAssignToBody)
+
+	server.Script(fakeApi.AlphaDividends, "ITUB4.SA", http.StatusOK,
+		dividendsItub4Body)
+
+	assert.Equal(t, []entity.EarningEvent{
+		{
+			Type:        "Dividendos",
+			Amount:      0.01765,
+			ExDate:      entity.StringToTime("2021-10-01"),
+			PaymentDate: entity.StringToTime("2021-11-01"),
+		},
+		{
+			Type:   "Dividendos",
+			Amount: 0.01765,
+			ExDate: entity.StringToTime("2021-11-01"),
+		},
+	}, alphaApi.GetEarningEvents("ITUB4.SA"))
+
+	assert.Equal(t, []string{
+		"/query?function=DIVIDENDS&symbol=ITUB4.SA&apikey=Test",
+	}, server.Requests())
+
+	// Symbols without dividends receive an empty list
+	assert.Nil(t, alphaApi.GetEarningEvents("UNKNOWN"))
+}
+
+func TestAlphaVantagePriceHistoryContract(t *testing.T) {
+	server := fakeApi.NewAlphaVantageServer()
+	defer server.Close()
+
+	alphaApi := NewAlphaVantageApiWithBaseUrl(server.URL, "Test",
+		client.RequestAndAssignToBody)
+
+	server.Script(fakeApi.AlphaTimeSeriesDaily, "ITUB4.SA", http.StatusOK,
+		timeSeriesDailyItub4Body)
+
+	assert.Equal(t, []entity.SymbolPriceHistory{
+		{
+			Symbol:     "ITUB4",
+			Date:       entity.StringToTime("2021-10-28"),
+			OpenPrice:  22.1,
+			HighPrice:  22.55,
+			LowPrice:   22.01,
+			ClosePrice: 22.44,
+			Volume:     30112300,
+		},
+		{
+			Symbol:     "ITUB4",
+			Date:       entity.StringToTime("2021-10-29"),
+			OpenPrice:  22.5,
+			HighPrice:  22.89,
+			LowPrice:   22.31,
+			ClosePrice: 22.7,
+			Volume:     35121500,
+		},
+	}, alphaApi.GetPriceHistory("ITUB4.SA"))
+
+	assert.Equal(t, []string{
+		"/query?function=TIME_SERIES_DAILY&symbol=ITUB4.SA&apikey=Test",
+	}, server.Requests())
+
+	// Invalid symbols receive an error body without any price
+	assert.Nil(t, alphaApi.GetPriceHistory("UNKNOWN"))
+}
+
+func TestAlphaVantageCryptoContract(t *testing.T) {
+	server := fakeApi.NewAlphaVantageServer()
+	defer server.Close()
+
+	alphaApi := NewAlphaVantageApiWithBaseUrl(server.URL, "Test",
+		client.RequestAndAssignToBody)
+
+	server.Script(fakeApi.AlphaExchangeRate, "BTC-BRL", http.StatusOK,
+		exchangeRateBtcBrlBody)
+	server.Script(fakeApi.AlphaExchangeRate, "BTC-USD", http.StatusOK,
+		exchangeRateBtcUsdBody)
+
+	assert.Equal(t, entity.SymbolLookup{
+		Fullname: "Bitcoin",
+		Symbol:   "BTC",
+		Type:     "CRYPTO",
+	}, alphaApi.VerifyCryptoSymbol("BTC"))
+	assert.Equal(t, entity.SymbolPrice{
+		Symbol:       "BTC",
+		CurrentPrice: 341890.27,
+		Currency:     "BRL",
+	}, alphaApi.GetCryptoPrice("BTC", "BRL"))
+
+	assert.Equal(t, []string{
+		"/query?function=CURRENCY_EXCHANGE_RATE&from_currency=BTC&" +
+			"to_currency=USD&apikey=Test",
+		"/query?function=CURRENCY_EXCHANGE_RATE&from_currency=BTC&" +
+			"to_currency=BRL&apikey=Test",
+	}, server.Requests())
+
+	assert.Equal(t, entity.SymbolLookup{}, alphaApi.VerifyCryptoSymbol("XXXX"))
+	assert.Equal(t, entity.SymbolPrice{Symbol: "XXXX", Currency: "USD"},
+		alphaApi.GetCryptoPrice("XXXX", "USD"))
+}
+
+func TestAlphaVantageContractErrors(t *testing.T) {
+	server := fakeApi.NewAlphaVantageServer()
+	defer server.Close()
+
+	tests := []struct {
+		token string
+		body  string
+	}{
+		{
+			token: "",
+		},
+		{
+			token: "Test",
+			body:  fakeApi.AlphaRateLimitBody,
+		},
+		{
+			token: "Test",
+			body:  fakeApi.AlphaInvalidCallBody,
+		},
+	}
+
+	for _, testCase := range tests {
+		server.Reset()
+		server.Script(fakeApi.AlphaGlobalQuote, "ITUB4.SA", http.StatusOK,
+			globalQuoteItub4Body)
+		if testCase.body != "" {
+			server.ScriptAll(http.StatusOK, testCase.body)
+		}
+
+		alphaApi := NewAlphaVantageApiWithBaseUrl(server.URL, testCase.token,
+			client.RequestAndAssignToBody)
+
+		assert.Equal(t, entity.SymbolLookup{}, alphaApi.VerifySymbol2("ITUB4.SA"))
+		assert.Equal(t, entity.SymbolPrice{Symbol: "ITUB4"},
+			alphaApi.GetPrice("ITUB4.SA"))
+		assert.Equal(t, "", alphaApi.CompanyOverview("ITUB4.SA")["Sector"])
+		assert.Nil(t, alphaApi.GetPrices([]string{"ITUB4.SA"}))
+	}
+}
@@ -33,4 +33,64 @@ type SymbolPriceInfo struct {
 	ChangePercent string `json:"10. change percent"`
 }
 
+type SymbolBulkPriceAlpha struct {
+	Endpoint string                `json:"endpoint"`
+	Message  string                `json:"message"`
+	Data     []SymbolBulkPriceInfo `json:"data"`
+}
+
+type SymbolBulkPriceInfo struct {
+	Symbol        string `json:"symbol"`
+	Timestamp     string `json:"timestamp"`
+	Open          string `json:"open"`
+	High          string `json:"high"`
+	Low           string `json:"low"`
+	Close         string `json:"close"`
+	Volume        string `json:"volume"`
+	PrevClose     string `json:"previous_close"`
+	Change        string `json:"change"`
+	ChangePercent string `json:"change_percent"`
+}
+
+type CurrencyExchangeRateAlpha struct {
+	ExchangeRate CurrencyExchangeRateInfo `json:"Realtime Currency Exchange Rate"`
+}
+
+type CurrencyExchangeRateInfo struct {
+	FromCurrencyCode string `json:"1. From_Currency Code"`
+	FromCurrencyName string `json:"2. From_Currency Name"`
+	ToCurrencyCode   string `json:"3. To_Currency Code"`
+	ToCurrencyName   string `json:"4. To_Currency Name"`
+	ExchangeRate     string `json:"5. Exchange Rate"`
+	LastRefreshed    string `json:"6. Last Refreshed"`
+	TimeZone         string `json:"7. Time Zone"`
+	BidPrice         string `json:"8. Bid Price"`
+	AskPrice         string `json:"9. Ask Price"`
+}
+
 var ListValidBrETF = [5]string{"BOVA11", "SMAL11", "IVVB11", "HASH11", "ECOO11"}
+
+type DividendsAlpha struct {
+	Symbol string              `json:"symbol"`
+	Data   []DividendInfoAlpha `json:"data"`
+}
+
+type DividendInfoAlpha struct {
+	ExDividendDate  string `json:"ex_dividend_date"`
+	DeclarationDate string `json:"declaration_date"`
+	RecordDate      string `json:"record_date"`
+	PaymentDate     string `json:"payment_date"`
+	Amount          string `json:"amount"`
+}
+
+type TimeSeriesDailyAlpha struct {
+	TimeSeries map[string]DailyPriceAlpha `json:"Time Series (Daily)"`
+}
+
+type DailyPriceAlpha struct {
+	Open   string `json:"1. open"`
+	High   string `json:"2. high"`
+	Low    string `json:"3. low"`
+	Close  string `json:"4. close"`
+	Volume string `json:"5. volume"`
+}
@@ -0,0 +1,104 @@
+package fakeApi
+
+import (
+	"net/http"
+)
+
+// Alpha Vantage functions. Every endpoint uses the /query path and the
+// function query value to choose the returned information.
+const (
+	AlphaSymbolSearch      = "SYMBOL_SEARCH"
+	AlphaGlobalQuote       = "GLOBAL_QUOTE"
+	AlphaRealtimeBulkQuote = "REALTIME_BULK_QUOTES"
+	AlphaOverview          = "OVERVIEW"
+	AlphaExchangeRate      = "CURRENCY_EXCHANGE_RATE"
+	AlphaDividends         = "DIVIDENDS"
+	AlphaTimeSeriesDaily   = "TIME_SERIES_DAILY"
+)
+
+// Bodies returned by the Alpha Vantage API for invalid requests and rate
+// limits. Unlike Finnhub, these bodies are sent with the 200 status code.
+const (
+	AlphaInvalidApiKeyBody = `{"Error Message": "the parameter apikey is ` +
+		`invalid or missing. Please claim your free API key on ` +
+		`(https://www.alphavantage.co/support/#api-key)."}`
+	AlphaInvalidCallBody = `{"Error Message": "Invalid API call. Please ` +
+		`retry or visit the documentation (https://www.alphavantage.co/` +
+		`documentation/) for GLOBAL_QUOTE."}`
+	AlphaRateLimitBody = `{"Note": "Thank you for using Alpha Vantage! Our ` +
+		`standard API call frequency is 5 calls per minute and 500 calls per ` +
+		`day. Please visit https://www.alphavantage.co/premium/ if you would ` +
+		`like to target a higher API call frequency."}`
+	AlphaPremiumEndpointBody = `{"Information": "Thank you for using Alpha ` +
+		`Vantage! This is a premium endpoint. You may subscribe to any of the ` +
+		`premium plans at https://www.alphavantage.co/premium/ to instantly ` +
+		`unlock all premium endpoints"}`
+)
+
+// NewAlphaVantageServer starts a fake Alpha Vantage API. The server URL is
+// the base URL of the Alpha Vantage client.
+func NewAlphaVantageServer() *Server {
+	return newServer(alphaRouteKey, alphaAuthorizedRequest,
+		Response{
+			StatusCode: http.StatusOK,
+			Body:       AlphaInvalidApiKeyBody,
+		},
+		map[string]Response{
+			AlphaSymbolSearch: {
+				StatusCode: http.StatusOK,
+				Body:       `{"bestMatches": []}`,
+			},
+			AlphaGlobalQuote: {
+				StatusCode: http.StatusOK,
+				Body:       `{"Global Quote": {}}`,
+			},
+			AlphaRealtimeBulkQuote: {
+				StatusCode: http.StatusOK,
+				Body:       AlphaPremiumEndpointBody,
+			},
+			AlphaOverview: {
+				StatusCode: http.StatusOK,
+				Body:       `{}`,
+			},
+			AlphaDividends: {
+				StatusCode: http.StatusOK,
+				Body:       `{"data": []}`,
+			},
+			AlphaTimeSeriesDaily: {
+				StatusCode: http.StatusOK,
+				Body: `{"Error Message": "Invalid API call. Please retry ` +
+					`or visit the documentation (https://www.alphavantage.co/` +
+					`documentation/) for TIME_SERIES_DAILY."}`,
+			},
+			AlphaExchangeRate: {
+				StatusCode: http.StatusOK,
+				Body: `{"Error Message": "Invalid API call. Please retry ` +
+					`or visit the documentation (https://www.alphavantage.co/` +
+					`documentation/) for CURRENCY_EXCHANGE_RATE."}`,
+			},
+		})
+}
+
+func alphaRouteKey(r *http.Request) (string, string) {
+	query := r.URL.Query()
+
+	if r.URL.Path != "/query" {
+		return r.URL.Path, ""
+	}
+
+	if query.Get("function") == AlphaSymbolSearch {
+		return AlphaSymbolSearch, query.Get("keywords")
+	}
+
+	// Exchange rates are scripted with the FROM-TO pair as the symbol
+	if query.Get("function") == AlphaExchangeRate {
+		return AlphaExchangeRate, query.Get("from_currency") + "-" +
+			query.Get("to_currency")
+	}
+
+	return query.Get("function"), query.Get("symbol")
+}
+
+func alphaAuthorizedRequest(r *http.Request) bool {
+	return r.URL.Query().Get("apikey") != ""
+}
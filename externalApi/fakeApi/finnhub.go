@@ -0,0 +1,68 @@
+package fakeApi
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Finnhub endpoints, relative to the server URL.
+const (
+	FinnhubSearch   = "/api/v1/search"
+	FinnhubQuote    = "/api/v1/quote"
+	FinnhubProfile2 = "/api/v1/stock/profile2"
+	FinnhubDividend = "/api/v1/stock/dividend"
+)
+
+// Error bodies returned by the Finnhub API.
+const (
+	FinnhubInvalidTokenBody = `{"error": "Invalid API key"}`
+	FinnhubRateLimitBody    = `{"error": "API limit reached. Please try again later. Remaining Limit: 0"}`
+)
+
+// NewFinnhubServer starts a fake Finnhub API. The address to be used as the
+// base URL of the Finnhub client is FinnhubBaseUrl.
+func NewFinnhubServer() *Server {
+	return newServer(finnhubRouteKey, finnhubAuthorizedRequest,
+		Response{
+			StatusCode: http.StatusUnauthorized,
+			Body:       FinnhubInvalidTokenBody,
+		},
+		map[string]Response{
+			FinnhubSearch: {
+				StatusCode: http.StatusOK,
+				Body:       `{"count": 0, "result": []}`,
+			},
+			FinnhubQuote: {
+				StatusCode: http.StatusOK,
+				Body: `{"c": 0, "d": null, "dp": null, "h": 0, "l": 0, ` +
+					`"o": 0, "pc": 0, "t": 0}`,
+			},
+			FinnhubProfile2: {
+				StatusCode: http.StatusOK,
+				Body:       `{}`,
+			},
+			FinnhubDividend: {
+				StatusCode: http.StatusOK,
+				Body:       `[]`,
+			},
+		})
+}
+
+// FinnhubBaseUrl returns the base URL of the Finnhub client for this server.
+func (s *Server) FinnhubBaseUrl() string {
+	return s.URL + strings.TrimSuffix(FinnhubSearch, "/search")
+}
+
+func finnhubRouteKey(r *http.Request) (string, string) {
+	query := r.URL.Query()
+
+	if r.URL.Path == FinnhubSearch {
+		return r.URL.Path, query.Get("q")
+	}
+
+	return r.URL.Path, query.Get("symbol")
+}
+
+func finnhubAuthorizedRequest(r *http.Request) bool {
+	return r.URL.Query().Get("token") != ""
+}
@@ -0,0 +1,127 @@
+package fakeApi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+// Response is a scripted answer of the fake server. The body is written as it
+// is, so it should be a copy of the payload sent by the real API.
+type Response struct {
+	StatusCode int
+	Body       string
+}
+
+// Server is an httptest server that imitates the URL shapes of an external
+// API. Each request is identified by its endpoint (the URL path for Finnhub
+// and the function query value for Alpha Vantage) and the requested symbol.
+// Requests without a scripted response receive the default response of the
+// endpoint, which is the payload returned by the real API for an unknown
+// symbol.
+type Server struct {
+	*httptest.Server
+
+	mu                sync.Mutex
+	responses         map[string]Response
+	defaultResponses  map[string]Response
+	overrideResponse  *Response
+	requests          []string
+	routeKey          func(r *http.Request) (string, string)
+	authorizedRequest func(r *http.Request) bool
+	unauthorized      Response
+}
+
+func newServer(routeKey func(r *http.Request) (string, string),
+	authorizedRequest func(r *http.Request) bool, unauthorized Response,
+	defaultResponses map[string]Response) *Server {
+
+	s := &Server{
+		responses:         map[string]Response{},
+		defaultResponses:  defaultResponses,
+		routeKey:          routeKey,
+		authorizedRequest: authorizedRequest,
+		unauthorized:      unauthorized,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+// Script defines the response for a symbol in a specific endpoint.
+func (s *Server) Script(endpoint string, symbol string, statusCode int,
+	body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responses[endpoint+":"+symbol] = Response{
+		StatusCode: statusCode,
+		Body:       body,
+	}
+}
+
+// ScriptAll makes the server answer every request with the same response,
+// regardless of the endpoint. It is useful to simulate rate limits.
+func (s *Server) ScriptAll(statusCode int, body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.overrideResponse = &Response{
+		StatusCode: statusCode,
+		Body:       body,
+	}
+}
+
+// Reset removes every scripted response and the recorded requests.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responses = map[string]Response{}
+	s.overrideResponse = nil
+	s.requests = nil
+}
+
+// Requests returns the path and query of every request received by the server.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.URL.RequestURI())
+	response := s.response(r)
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+	w.Write([]byte(response.Body))
+}
+
+func (s *Server) response(r *http.Request) Response {
+	if s.overrideResponse != nil {
+		return *s.overrideResponse
+	}
+
+	if !s.authorizedRequest(r) {
+		return s.unauthorized
+	}
+
+	endpoint, symbol := s.routeKey(r)
+
+	if response, ok := s.responses[endpoint+":"+symbol]; ok {
+		return response
+	}
+
+	if response, ok := s.defaultResponses[endpoint]; ok {
+		return response
+	}
+
+	return Response{
+		StatusCode: http.StatusNotFound,
+		Body:       `{"error": "Not found"}`,
+	}
+}
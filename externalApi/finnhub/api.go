@@ -3,9 +3,16 @@ package finnhub
 import (
 	"io"
 	"stockfyApi/entity"
+	"strconv"
+	"time"
 )
 
+// Default address of the Finnhub REST API. It can be replaced through the
+// BaseUrl field, which is useful to test this client against a fake server.
+const FinnhubBaseUrl = "https://finnhub.io/api/v1"
+
 type FinnhubApi struct {
+	BaseUrl            string
 	Token              string
 	HttpOutsideRequest func(method string, url string, contentType string,
 		bodyReq io.Reader, bodyResp interface{})
@@ -14,14 +21,29 @@ type FinnhubApi struct {
 func NewFinnhubApi(token string, httpClient func(method string,
 	url string, contentType string, bodyReq io.Reader,
 	bodyResp interface{})) *FinnhubApi {
+	return NewFinnhubApiWithBaseUrl(FinnhubBaseUrl, token, httpClient)
+}
+
+func NewFinnhubApiWithBaseUrl(baseUrl string, token string,
+	httpClient func(method string, url string, contentType string,
+		bodyReq io.Reader, bodyResp interface{})) *FinnhubApi {
 	return &FinnhubApi{
+		BaseUrl:            baseUrl,
 		Token:              token,
 		HttpOutsideRequest: httpClient,
 	}
 }
 
+func (f *FinnhubApi) baseUrl() string {
+	if f.BaseUrl == "" {
+		return FinnhubBaseUrl
+	}
+
+	return f.BaseUrl
+}
+
 func (f *FinnhubApi) VerifySymbol2(symbol string) entity.SymbolLookup {
-	url := "https://finnhub.io/api/v1/search?q=" + symbol + "&token=" +
+	url := f.baseUrl() + "/search?q=" + symbol + "&token=" +
 		f.Token
 
 	var symbolLookupFinnhub SymbolLookupFinnhub
@@ -42,29 +64,34 @@ func (f *FinnhubApi) VerifySymbol2(symbol string) entity.SymbolLookup {
 }
 
 func (f *FinnhubApi) CompanyOverview(symbol string) map[string]string {
-	url := "https://finnhub.io/api/v1/stock/profile2?symbol=" + symbol +
+	url := f.baseUrl() + "/stock/profile2?symbol=" + symbol +
 		"&token=" + f.Token
 
 	var companyProfile2 CompanyProfile2
 
 	f.HttpOutsideRequest("GET", url, "", nil, &companyProfile2)
 
+	// The market capitalization is returned in millions
+	marketCap := strconv.FormatFloat(companyProfile2.MarketCapitalization, 'f',
+		-1, 64)
+
 	return map[string]string{
-		"country":         companyProfile2.Country,
-		"currency":        companyProfile2.Currency,
-		"exchange":        companyProfile2.Exchange,
-		"finnhubIndustry": companyProfile2.FinnhubIndustry,
-		"ipo":             "",
-		"logo":            companyProfile2.Logo,
-		"name":            companyProfile2.Name,
-		"phone":           companyProfile2.Phone,
-		"ticker":          companyProfile2.Ticker,
-		"weburl":          companyProfile2.Weburl,
+		"country":              companyProfile2.Country,
+		"currency":             companyProfile2.Currency,
+		"exchange":             companyProfile2.Exchange,
+		"finnhubIndustry":      companyProfile2.FinnhubIndustry,
+		"ipo":                  companyProfile2.Ipo,
+		"logo":                 companyProfile2.Logo,
+		"marketCapitalization": marketCap,
+		"name":                 companyProfile2.Name,
+		"phone":                companyProfile2.Phone,
+		"ticker":               companyProfile2.Ticker,
+		"weburl":               companyProfile2.Weburl,
 	}
 }
 
 func (f *FinnhubApi) GetPrice(symbol string) entity.SymbolPrice {
-	url := "https://finnhub.io/api/v1/quote?symbol=" + symbol + "&token=" +
+	url := f.baseUrl() + "/quote?symbol=" + symbol + "&token=" +
 		f.Token
 
 	symbolPrice := SymbolPriceFinnhub{}
@@ -80,3 +107,33 @@ func (f *FinnhubApi) GetPrice(symbol string) entity.SymbolPrice {
 		PrevClosePrice: symbolPrice.PC,
 	}
 }
+
+// Window of the dividends requested to Finnhub, counted from today. Future
+// ex-dates are included because the dividends are announced in advance.
+const earningEventsWindow = 365 * 24 * time.Hour
+
+// GetEarningEvents returns the dividends with the ex-date in the last year
+// or already announced for the next year.
+func (f *FinnhubApi) GetEarningEvents(symbol string) []entity.EarningEvent {
+	now := time.Now()
+	url := f.baseUrl() + "/stock/dividend?symbol=" + symbol + "&from=" +
+		now.Add(-earningEventsWindow).Format("2006-01-02") + "&to=" +
+		now.Add(earningEventsWindow).Format("2006-01-02") + "&token=" + f.Token
+
+	var dividends []DividendFinnhub
+	var earningEvents []entity.EarningEvent
+
+	f.HttpOutsideRequest("GET", url, "", nil, &dividends)
+
+	for _, dividend := range dividends {
+		earningEvents = append(earningEvents, entity.EarningEvent{
+			Type:        "Dividendos",
+			Amount:      dividend.Amount,
+			Currency:    dividend.Currency,
+			ExDate:      entity.StringToTime(dividend.Date),
+			PaymentDate: entity.StringToTime(dividend.PayDate),
+		})
+	}
+
+	return earningEvents
+}
@@ -0,0 +1,148 @@
+package finnhub
+
+import (
+	"net/http"
+	"stockfyApi/client"
+	"stockfyApi/entity"
+	"stockfyApi/externalApi/fakeApi"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Payloads copied from the Finnhub API responses.
+const (
+	searchAaplBody = `{"count":4,"result":[` +
+		`{"description":"APPLE INC","displaySymbol":"AAPL","symbol":"AAPL",` +
+		`"type":"Common Stock"},` +
+		`{"description":"APPLE INC","displaySymbol":"AAPL.SW","symbol":` +
+		`"AAPL.SW","type":"Common Stock"},` +
+		`{"description":"APPLE INC","displaySymbol":"APC.BE","symbol":` +
+		`"APC.BE","type":"Common Stock"},` +
+		`{"description":"APPLE INC","displaySymbol":"APC.DE","symbol":` +
+		`"APC.DE","type":"Common Stock"}]}`
+	quoteAaplBody = `{"c":148.96,"d":-0.84,"dp":-0.5607,"h":149.7,` +
+		`"l":147.8,"o":148.7,"pc":149.8,"t":1635451202}`
+	profile2AaplBody = `{"country":"US","currency":"USD","exchange":` +
+		`"NASDAQ NMS - GLOBAL MARKET","finnhubIndustry":"Technology",` +
+		`"ipo":"1980-12-12","logo":"https://finnhub.io/api/logo?symbol=AAPL",` +
+		`"marketCapitalization":2462175,"name":"Apple Inc","phone":` +
+		`"14089961010.0","shareOutstanding":16406.4,"ticker":"AAPL",` +
+		`"weburl":"https://www.apple.com/"}`
+	dividendAaplBody = `[{"symbol":"AAPL","date":"2021-11-05",` +
+		`"amount":0.22,"adjustedAmount":0.22,"payDate":"2021-11-11",` +
+		`"recordDate":"2021-11-08","declarationDate":"2021-10-28",` +
+		`"currency":"USD"}]`
+)
+
+func TestFinnhubContract(t *testing.T) {
+	server := fakeApi.NewFinnhubServer()
+	defer server.Close()
+
+	finnhubApi := NewFinnhubApiWithBaseUrl(server.FinnhubBaseUrl(), "Test",
+		client.RequestAndAssignToBody)
+
+	server.Script(fakeApi.FinnhubSearch, "AAPL", http.StatusOK, searchAaplBody)
+	server.Script(fakeApi.FinnhubQuote, "AAPL", http.StatusOK, quoteAaplBody)
+	server.Script(fakeApi.FinnhubProfile2, "AAPL", http.StatusOK,
+		profile2AaplBody)
+
+	assert.Equal(t, entity.SymbolLookup{
+		Fullname: "Apple Inc",
+		Symbol:   "AAPL",
+		Type:     "Common Stock",
+	}, finnhubApi.VerifySymbol2("AAPL"))
+	assert.Equal(t, entity.SymbolPrice{
+		Symbol:         "AAPL",
+		OpenPrice:      148.7,
+		HighPrice:      149.7,
+		LowPrice:       147.8,
+		CurrentPrice:   148.96,
+		PrevClosePrice: 149.8,
+	}, finnhubApi.GetPrice("AAPL"))
+	assert.Equal(t, map[string]string{
+		"country":              "US",
+		"currency":             "USD",
+		"exchange":             "NASDAQ NMS - GLOBAL MARKET",
+		"finnhubIndustry":      "Technology",
+		"ipo":                  "1980-12-12",
+		"logo":                 "https://finnhub.io/api/logo?symbol=AAPL",
+		"marketCapitalization": "2462175",
+		"name":                 "Apple Inc",
+		"phone":                "14089961010.0",
+		"ticker":               "AAPL",
+		"weburl":               "https://www.apple.com/",
+	}, finnhubApi.CompanyOverview("AAPL"))
+
+	assert.Equal(t, []string{
+		"/api/v1/search?q=AAPL&token=Test",
+		"/api/v1/quote?symbol=AAPL&token=Test",
+		"/api/v1/stock/profile2?symbol=AAPL&token=Test",
+	}, server.Requests())
+
+	// Unknown symbols receive the default Finnhub payloads
+	assert.Equal(t, entity.SymbolLookup{}, finnhubApi.VerifySymbol2("UNKNOWN"))
+	assert.Equal(t, entity.SymbolPrice{Symbol: "UNKNOWN"},
+		finnhubApi.GetPrice("UNKNOWN"))
+	assert.Equal(t, "", finnhubApi.CompanyOverview("UNKNOWN")["name"])
+}
+
+func TestFinnhubDividendContract(t *testing.T) {
+	server := fakeApi.NewFinnhubServer()
+	defer server.Close()
+
+	finnhubApi := NewFinnhubApiWithBaseUrl(server.FinnhubBaseUrl(), "Test",
+		client.RequestAndAssignToBody)
+
+	server.Script(fakeApi.FinnhubDividend, "AAPL", http.StatusOK,
+		dividendAaplBody)
+
+	assert.Equal(t, []entity.EarningEvent{
+		{
+			Type:        "Dividendos",
+			Amount:      0.22,
+			Currency:    "USD",
+			ExDate:      entity.StringToTime("2021-11-05"),
+			PaymentDate: entity.StringToTime("2021-11-11"),
+		},
+	}, finnhubApi.GetEarningEvents("AAPL"))
+
+	// Symbols without dividends receive an empty list
+	assert.Nil(t, finnhubApi.GetEarningEvents("UNKNOWN"))
+}
+
+func TestFinnhubContractErrors(t *testing.T) {
+	server := fakeApi.NewFinnhubServer()
+	defer server.Close()
+
+	tests := []struct {
+		token      string
+		statusCode int
+		body       string
+	}{
+		{
+			token: "",
+		},
+		{
+			token:      "Test",
+			statusCode: http.StatusTooManyRequests,
+			body:       fakeApi.FinnhubRateLimitBody,
+		},
+	}
+
+	for _, testCase := range tests {
+		server.Reset()
+		server.Script(fakeApi.FinnhubQuote, "AAPL", http.StatusOK, quoteAaplBody)
+		if testCase.body != "" {
+			server.ScriptAll(testCase.statusCode, testCase.body)
+		}
+
+		finnhubApi := NewFinnhubApiWithBaseUrl(server.FinnhubBaseUrl(),
+			testCase.token, client.RequestAndAssignToBody)
+
+		assert.Equal(t, entity.SymbolLookup{}, finnhubApi.VerifySymbol2("AAPL"))
+		assert.Equal(t, entity.SymbolPrice{Symbol: "AAPL"},
+			finnhubApi.GetPrice("AAPL"))
+		assert.Equal(t, "", finnhubApi.CompanyOverview("AAPL")["name"])
+	}
+}
@@ -35,3 +35,14 @@ type CompanyProfile2 struct {
 	Logo                 string  `json:"logo,omitempty"`
 	FinnhubIndustry      string  `json:"finnhubIndustry,omitempty"`
 }
+
+type DividendFinnhub struct {
+	Symbol          string  `json:"symbol"`
+	Date            string  `json:"date"`
+	Amount          float64 `json:"amount"`
+	AdjustedAmount  float64 `json:"adjustedAmount"`
+	PayDate         string  `json:"payDate"`
+	RecordDate      string  `json:"recordDate"`
+	DeclarationDate string  `json:"declarationDate"`
+	Currency        string  `json:"currency"`
+}
@@ -180,7 +180,8 @@ func (authClient *Firebase) VerifyIDToken(idToken string) (entity.UserTokenInfo,
 	}
 
 	userTokenInfo := entity.ConvertUserTokenInfo(firebaseToken.Claims["user_id"].(string),
-		firebaseToken.Claims["email"].(string), firebaseToken.Claims["email_verified"].(bool))
+		firebaseToken.Claims["email"].(string), firebaseToken.Claims["email_verified"].(bool),
+		firebaseToken.Firebase.SignInProvider)
 
 	return userTokenInfo, nil
 }
@@ -0,0 +1,182 @@
+package localMarketData
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"stockfyApi/entity"
+	"strings"
+)
+
+type LocalMarketDataApi struct {
+	DataDir string
+}
+
+func NewLocalMarketDataApi(dataDir string) *LocalMarketDataApi {
+	return &LocalMarketDataApi{
+		DataDir: dataDir,
+	}
+}
+
+func (l *LocalMarketDataApi) VerifySymbol2(symbol string) entity.SymbolLookup {
+	var symbolsInfo []SymbolLookupInfo
+	var symbolLookupInfo SymbolLookupInfo
+
+	l.readJsonFile(symbolsFilename, &symbolsInfo)
+
+	for _, s := range symbolsInfo {
+		if s.Symbol == symbol {
+			symbolLookupInfo = s
+		}
+	}
+
+	symbolLookup := entity.ConvertAssetLookup(symbolLookupInfo.Symbol,
+		symbolLookupInfo.Fullname, symbolLookupInfo.Type)
+
+	return symbolLookup
+}
+
+func (l *LocalMarketDataApi) GetPrice(symbol string) entity.SymbolPrice {
+	var quotes map[string]SymbolPriceInfo
+
+	l.readJsonFile(quotesFilename, &quotes)
+
+	return convertQuote(symbol, quotes[symbol])
+}
+
+func (l *LocalMarketDataApi) GetPrices(symbols []string) []entity.SymbolPrice {
+	var quotes map[string]SymbolPriceInfo
+	var symbolPrices []entity.SymbolPrice
+
+	l.readJsonFile(quotesFilename, &quotes)
+
+	for _, symbol := range symbols {
+		quote, ok := quotes[symbol]
+		if !ok {
+			continue
+		}
+
+		symbolPrices = append(symbolPrices, convertQuote(symbol, quote))
+	}
+
+	return symbolPrices
+}
+
+func (l *LocalMarketDataApi) VerifyCryptoSymbol(
+	symbol string) entity.SymbolLookup {
+	var symbolsInfo []SymbolLookupInfo
+
+	l.readJsonFile(symbolsFilename, &symbolsInfo)
+
+	for _, s := range symbolsInfo {
+		if s.Symbol == symbol && s.Type == cryptoSymbolType {
+			return entity.ConvertAssetLookup(s.Symbol, s.Fullname,
+				entity.CryptoAssetType)
+		}
+	}
+
+	return entity.SymbolLookup{}
+}
+
+// GetCryptoPrice reads the quote of the pair SYMBOL-CURRENCY, e.g. BTC-BRL.
+func (l *LocalMarketDataApi) GetCryptoPrice(symbol string,
+	currency string) entity.SymbolPrice {
+	var quotes map[string]SymbolPriceInfo
+
+	l.readJsonFile(quotesFilename, &quotes)
+
+	symbolPrice := convertQuote(symbol, quotes[symbol+"-"+currency])
+	symbolPrice.Currency = currency
+
+	return symbolPrice
+}
+
+func (l *LocalMarketDataApi) CompanyOverview(symbol string) map[string]string {
+	var profiles map[string]map[string]string
+
+	l.readJsonFile(profilesFilename, &profiles)
+
+	return profiles[symbol]
+}
+
+func (l *LocalMarketDataApi) GetEarningEvents(
+	symbol string) []entity.EarningEvent {
+	var earnings map[string][]EarningEventInfo
+	var earningEvents []entity.EarningEvent
+
+	l.readJsonFile(earningsFilename, &earnings)
+
+	for _, earning := range earnings[symbol] {
+		earningEvents = append(earningEvents, entity.EarningEvent{
+			Type:        earning.Type,
+			Amount:      earning.Amount,
+			Currency:    earning.Currency,
+			ExDate:      entity.StringToTime(earning.ExDate),
+			PaymentDate: entity.StringToTime(earning.PaymentDate),
+		})
+	}
+
+	return earningEvents
+}
+
+func (l *LocalMarketDataApi) GetPriceHistory(symbol string) []entity.SymbolPriceHistory {
+	var priceHistory []entity.SymbolPriceHistory
+
+	file, err := os.Open(filepath.Join(l.DataDir, historyFolder, symbol+".csv"))
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil
+	}
+
+	for i, record := range records {
+		// Skip the header and the lines without all the expected columns
+		if i == 0 || len(record) < 6 {
+			continue
+		}
+
+		priceHistory = append(priceHistory, entity.SymbolPriceHistory{
+			Symbol:     strings.ReplaceAll(symbol, ".SA", ""),
+			Date:       entity.StringToTime(record[0]),
+			OpenPrice:  entity.StringToFloat64(record[1]),
+			HighPrice:  entity.StringToFloat64(record[2]),
+			LowPrice:   entity.StringToFloat64(record[3]),
+			ClosePrice: entity.StringToFloat64(record[4]),
+			Volume:     entity.StringToFloat64(record[5]),
+		})
+	}
+
+	return priceHistory
+}
+
+// readJsonFile unmarshals a fixture file into bodyResp. A missing or invalid
+// file leaves bodyResp untouched, which is handled as an unknown symbol, like
+// an empty response from the external APIs.
+func (l *LocalMarketDataApi) readJsonFile(filename string,
+	bodyResp interface{}) {
+
+	body, err := ioutil.ReadFile(filepath.Join(l.DataDir, filename))
+	if err != nil {
+		return
+	}
+
+	json.Unmarshal(body, bodyResp)
+}
+
+func convertQuote(symbol string, quote SymbolPriceInfo) entity.SymbolPrice {
+	return entity.SymbolPrice{
+		Symbol:         strings.ReplaceAll(symbol, ".SA", ""),
+		OpenPrice:      quote.Open,
+		HighPrice:      quote.High,
+		LowPrice:       quote.Low,
+		CurrentPrice:   quote.Current,
+		PrevClosePrice: quote.PrevClose,
+		MarketCap:      quote.MarketCap,
+	}
+}
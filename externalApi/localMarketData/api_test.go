@@ -0,0 +1,179 @@
+package localMarketData
+
+import (
+	"stockfyApi/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifySymbol2(t *testing.T) {
+	type test struct {
+		symbol               string
+		expectedSymbolLookup entity.SymbolLookup
+	}
+
+	tests := []test{
+		{
+			symbol: "ITUB4.SA",
+			expectedSymbolLookup: entity.SymbolLookup{
+				Symbol:   "ITUB4",
+				Fullname: "Itau Unibanco Holding SA",
+				Type:     "Equity",
+			},
+		},
+		{
+			symbol: "AAPL",
+			expectedSymbolLookup: entity.SymbolLookup{
+				Symbol:   "AAPL",
+				Fullname: "Apple Inc",
+				Type:     "Common Stock",
+			},
+		},
+		{
+			symbol:               "UNKNOWN",
+			expectedSymbolLookup: entity.SymbolLookup{},
+		},
+	}
+
+	local := NewLocalMarketDataApi("testdata")
+
+	for _, testCase := range tests {
+		symbolLookup := local.VerifySymbol2(testCase.symbol)
+		assert.Equal(t, testCase.expectedSymbolLookup, symbolLookup)
+	}
+}
+
+func TestGetPrice(t *testing.T) {
+	type test struct {
+		symbol              string
+		expectedSymbolPrice entity.SymbolPrice
+	}
+
+	tests := []test{
+		{
+			symbol: "ITUB4.SA",
+			expectedSymbolPrice: entity.SymbolPrice{
+				Symbol:         "ITUB4",
+				OpenPrice:      22.50,
+				HighPrice:      22.94,
+				LowPrice:       21.91,
+				CurrentPrice:   22.44,
+				PrevClosePrice: 22.07,
+			},
+		},
+		{
+			symbol: "AAPL",
+			expectedSymbolPrice: entity.SymbolPrice{
+				Symbol:         "AAPL",
+				OpenPrice:      161.20,
+				HighPrice:      161.80,
+				LowPrice:       159.06,
+				CurrentPrice:   161.41,
+				PrevClosePrice: 161.20,
+				MarketCap:      2640000,
+			},
+		},
+		{
+			symbol: "UNKNOWN",
+			expectedSymbolPrice: entity.SymbolPrice{
+				Symbol: "UNKNOWN",
+			},
+		},
+	}
+
+	local := NewLocalMarketDataApi("testdata")
+
+	for _, testCase := range tests {
+		symbolPrice := local.GetPrice(testCase.symbol)
+		assert.Equal(t, testCase.expectedSymbolPrice, symbolPrice)
+	}
+
+	symbolPrices := local.GetPrices([]string{"UNKNOWN", "ITUB4.SA"})
+	assert.Equal(t, []entity.SymbolPrice{tests[0].expectedSymbolPrice},
+		symbolPrices)
+}
+
+func TestCrypto(t *testing.T) {
+	local := NewLocalMarketDataApi("testdata")
+
+	assert.Equal(t, entity.SymbolLookup{
+		Symbol:   "BTC",
+		Fullname: "Bitcoin",
+		Type:     "CRYPTO",
+	}, local.VerifyCryptoSymbol("BTC"))
+	assert.Equal(t, entity.SymbolLookup{}, local.VerifyCryptoSymbol("AAPL"))
+
+	assert.Equal(t, entity.SymbolPrice{
+		Symbol:         "BTC",
+		OpenPrice:      61250.32,
+		HighPrice:      62100.00,
+		LowPrice:       60890.15,
+		CurrentPrice:   61870.44,
+		PrevClosePrice: 61250.32,
+		Currency:       "USD",
+	}, local.GetCryptoPrice("BTC", "USD"))
+	assert.Equal(t, entity.SymbolPrice{
+		Symbol:   "BTC",
+		Currency: "BRL",
+	}, local.GetCryptoPrice("BTC", "BRL"))
+}
+
+func TestCompanyOverview(t *testing.T) {
+	local := NewLocalMarketDataApi("testdata")
+
+	companyOverview := local.CompanyOverview("AAPL")
+	assert.Equal(t, "Technology", companyOverview["finnhubIndustry"])
+	assert.Equal(t, "US", companyOverview["country"])
+
+	assert.Nil(t, local.CompanyOverview("UNKNOWN"))
+
+	// A data directory without fixtures behaves like an empty API response
+	emptyLocal := NewLocalMarketDataApi("DIRECTORY_DOES_NOT_EXIST")
+	assert.Nil(t, emptyLocal.CompanyOverview("AAPL"))
+	assert.Equal(t, entity.SymbolLookup{}, emptyLocal.VerifySymbol2("AAPL"))
+}
+
+func TestGetEarningEvents(t *testing.T) {
+	local := NewLocalMarketDataApi("testdata")
+
+	assert.Equal(t, []entity.EarningEvent{
+		{
+			Type:        "JCP",
+			Amount:      0.01765,
+			Currency:    "BRL",
+			ExDate:      entity.StringToTime("2021-10-01"),
+			PaymentDate: entity.StringToTime("2021-11-01"),
+		},
+	}, local.GetEarningEvents("ITUB4.SA"))
+
+	assert.Nil(t, local.GetEarningEvents("UNKNOWN"))
+}
+
+func TestGetPriceHistory(t *testing.T) {
+	expectedPriceHistory := []entity.SymbolPriceHistory{
+		{
+			Symbol:     "AAPL",
+			Date:       entity.StringToTime("2021-11-22"),
+			OpenPrice:  161.68,
+			HighPrice:  165.70,
+			LowPrice:   161.00,
+			ClosePrice: 161.02,
+			Volume:     117467900,
+		},
+		{
+			Symbol:     "AAPL",
+			Date:       entity.StringToTime("2021-11-23"),
+			OpenPrice:  161.12,
+			HighPrice:  161.80,
+			LowPrice:   159.06,
+			ClosePrice: 161.41,
+			Volume:     96041900,
+		},
+	}
+
+	local := NewLocalMarketDataApi("testdata")
+
+	assert.Equal(t, expectedPriceHistory, local.GetPriceHistory("AAPL"))
+	assert.Nil(t, local.GetPriceHistory("UNKNOWN"))
+}
@@ -0,0 +1,39 @@
+package localMarketData
+
+type SymbolLookupInfo struct {
+	Symbol   string `json:"symbol"`
+	Fullname string `json:"fullname"`
+	Type     string `json:"type"`
+}
+
+type EarningEventInfo struct {
+	Type        string  `json:"type"`
+	Amount      float64 `json:"amount"`
+	Currency    string  `json:"currency"`
+	ExDate      string  `json:"exDate"`
+	PaymentDate string  `json:"paymentDate"`
+}
+
+type SymbolPriceInfo struct {
+	Open      float64 `json:"open"`
+	High      float64 `json:"high"`
+	Low       float64 `json:"low"`
+	Current   float64 `json:"current"`
+	PrevClose float64 `json:"previousClose"`
+	MarketCap float64 `json:"marketCap,omitempty"`
+}
+
+// Names of the fixture files inside the data directory. The price history of
+// each symbol is a CSV file in the history folder with the columns: date,
+// open, high, low, close and volume.
+const (
+	symbolsFilename  = "symbols.json"
+	quotesFilename   = "quotes.json"
+	profilesFilename = "profiles.json"
+	earningsFilename = "earnings.json"
+	historyFolder    = "history"
+)
+
+// Type of the cryptocurrencies in the symbols file. Their quotes are saved
+// for each quote currency with the SYMBOL-CURRENCY key.
+const cryptoSymbolType = "Crypto"
@@ -10,7 +10,15 @@ type thirdPartyInterface interface {
 	CompanyOverview(symbol string) map[string]string
 }
 
+// cryptoInterface is implemented by the providers that can verify and quote
+// cryptocurrencies, where the price is returned in the requested currency.
+type cryptoInterface interface {
+	VerifyCryptoSymbol(symbol string) entity.SymbolLookup
+	GetCryptoPrice(symbol string, currency string) entity.SymbolPrice
+}
+
 type ThirdPartyInterfaces struct {
 	FinnhubApi      thirdPartyInterface
 	AlphaVantageApi thirdPartyInterface
+	CryptoApi       cryptoInterface
 }
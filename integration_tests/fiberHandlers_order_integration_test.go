@@ -90,7 +90,7 @@ func TestFiberHandlersIntegrationTestCreateOrder(t *testing.T) {
 		expectedResponse body
 	}
 
-	dateString := "2021-10-02"
+	dateString := "2021-10-01"
 	layout := "2006-01-02"
 	dateFormatted, _ := time.Parse(layout, dateString)
 	tests := []test{
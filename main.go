@@ -3,14 +3,17 @@ package main
 import (
 	"context"
 	"fmt"
+	"log"
 	"os"
 	"stockfyApi/api/router"
+	"stockfyApi/calendar"
 	"stockfyApi/client"
 	"stockfyApi/database/postgresql"
 	externalapi "stockfyApi/externalApi"
 	"stockfyApi/externalApi/alphaVantage"
 	"stockfyApi/externalApi/finnhub"
 	"stockfyApi/externalApi/firebaseApi"
+	"stockfyApi/externalApi/localMarketData"
 	"stockfyApi/externalApi/oauth2"
 	"stockfyApi/usecases"
 	"stockfyApi/usecases/utils"
@@ -33,10 +36,22 @@ func main() {
 	// Access tokens or keys for third-party APIs
 	FIREBASE_API_WEB_KEY := utils.ViperReadEnvVariable(filenamePath, filename,
 		"FIREBASE_API_WEB_KEY")
-	ALPHA_VANTAGE_TOKEN := utils.ViperReadEnvVariable(filenamePath, filename,
-		"ALPHA_VANTAGE_TOKEN")
-	FINNHUB_TOKEN := utils.ViperReadEnvVariable(filenamePath, filename,
-		"FINNHUB_TOKEN")
+
+	// Market data provider. EXTERNAL uses the Finnhub and Alpha Vantage APIs,
+	// while LOCAL serves the market data from the fixture files in the
+	// MARKET_DATA_DIR folder, without any third-party token.
+	MARKET_DATA_PROVIDER := utils.ViperReadOptionalEnvVariable(filenamePath,
+		filename, "MARKET_DATA_PROVIDER", "EXTERNAL")
+	MARKET_DATA_DIR := utils.ViperReadOptionalEnvVariable(filenamePath,
+		filename, "MARKET_DATA_DIR", "./market_data")
+
+	// Trading sessions and holidays of each exchange
+	CALENDAR_DATA_DIR := utils.ViperReadOptionalEnvVariable(filenamePath,
+		filename, "CALENDAR_DATA_DIR", "./calendar/data")
+
+	// Symbol lists of each exchange used to seed the local symbol search
+	SYMBOL_DATA_DIR := utils.ViperReadOptionalEnvVariable(filenamePath,
+		filename, "SYMBOL_DATA_DIR", "./symbol_data")
 
 	// Google OAuth2 Configuration
 	GOOGLE_CLIENT_ID := utils.ViperReadEnvVariable(filenamePath, filename,
@@ -89,14 +104,67 @@ func main() {
 
 	applicationLogics := usecases.NewApplications(dbInterfaces, firebaseInterface)
 
-	finnhubInterface := finnhub.NewFinnhubApi(FINNHUB_TOKEN,
-		client.RequestAndAssignToBody)
-	alphaInterface := alphaVantage.NewAlphaVantageApi(ALPHA_VANTAGE_TOKEN,
-		client.RequestAndAssignToBody)
+	// The validations of countries, currencies and orders use the markets
+	// registered in the database
+	if _, err := applicationLogics.MarketApp.LoadMarkets(); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to load the markets: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Without calendar files, only the weekends are days without session
+	exchanges, err := calendar.LoadExchanges(CALENDAR_DATA_DIR)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to load the exchange calendars: %v\n", err)
+		os.Exit(1)
+	}
+	if exchanges != nil {
+		calendar.SetExchanges(exchanges)
+	}
+
+	// The symbol lists are upserted, so only new or renamed symbols change
+	if _, err := applicationLogics.SymbolSearchApp.ImportSymbolLists(
+		SYMBOL_DATA_DIR); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to import the symbol lists: %v\n", err)
+		os.Exit(1)
+	}
 
-	externalInt := externalapi.ThirdPartyInterfaces{
-		FinnhubApi:      finnhubInterface,
-		AlphaVantageApi: alphaInterface,
+	var externalInt externalapi.ThirdPartyInterfaces
+
+	switch MARKET_DATA_PROVIDER {
+	case "EXTERNAL":
+		ALPHA_VANTAGE_TOKEN := utils.ViperReadEnvVariable(filenamePath, filename,
+			"ALPHA_VANTAGE_TOKEN")
+		FINNHUB_TOKEN := utils.ViperReadEnvVariable(filenamePath, filename,
+			"FINNHUB_TOKEN")
+		FINNHUB_BASE_URL := utils.ViperReadOptionalEnvVariable(filenamePath,
+			filename, "FINNHUB_BASE_URL", finnhub.FinnhubBaseUrl)
+		ALPHA_VANTAGE_BASE_URL := utils.ViperReadOptionalEnvVariable(
+			filenamePath, filename, "ALPHA_VANTAGE_BASE_URL",
+			alphaVantage.AlphaVantageBaseUrl)
+
+		alphaInterface := alphaVantage.NewAlphaVantageApiWithBaseUrl(
+			ALPHA_VANTAGE_BASE_URL, ALPHA_VANTAGE_TOKEN,
+			client.RequestAndAssignToBody)
+
+		externalInt = externalapi.ThirdPartyInterfaces{
+			FinnhubApi: finnhub.NewFinnhubApiWithBaseUrl(FINNHUB_BASE_URL,
+				FINNHUB_TOKEN, client.RequestAndAssignToBody),
+			AlphaVantageApi: alphaInterface,
+			CryptoApi:       alphaInterface,
+		}
+		break
+	case "LOCAL":
+		localInterface := localMarketData.NewLocalMarketDataApi(MARKET_DATA_DIR)
+
+		externalInt = externalapi.ThirdPartyInterfaces{
+			FinnhubApi:      localInterface,
+			AlphaVantageApi: localInterface,
+			CryptoApi:       localInterface,
+		}
+		break
+	default:
+		log.Fatalf("Invalid MARKET_DATA_PROVIDER %s. Use EXTERNAL or LOCAL.",
+			MARKET_DATA_PROVIDER)
 	}
 
 	routerConfig := router.Config{
@@ -0,0 +1,221 @@
+package portfolioexport
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"io"
+	"math"
+	"stockfyApi/entity"
+	"stockfyApi/spreadsheet"
+	"strconv"
+	"time"
+)
+
+// table is an entity of the export, written as a CSV file or as a sheet of
+// the XLSX file. The rows are given one by one to the write function, so they
+// are written as soon as they are built.
+type table struct {
+	name   string
+	header []interface{}
+	rows   func(export *entity.PortfolioExport,
+		write func([]interface{}) error) error
+}
+
+var tables = []table{
+	{
+		name: "assets",
+		header: []interface{}{"symbol", "fullname", "preference", "asset_type",
+			"country", "sector"},
+		rows: assetRows,
+	},
+	{
+		name: "orders",
+		header: []interface{}{"date", "symbol", "order_type", "quantity",
+			"price", "currency", "fees", "withheld_tax", "settlement_date",
+			"brokerage", "account"},
+		rows: orderRows,
+	},
+	{
+		name: "earnings",
+		header: []interface{}{"date", "symbol", "earning_type", "amount",
+			"withheld_tax", "currency"},
+		rows: earningRows,
+	},
+	{
+		name: "positions",
+		header: []interface{}{"account", "brokerage", "symbol", "currency",
+			"quantity", "invested", "average_price"},
+		rows: positionRows,
+	},
+}
+
+// WriteXlsx writes the export as an XLSX file, with a sheet for each entity.
+func WriteXlsx(w io.Writer, export *entity.PortfolioExport) error {
+	var sheets []string
+	for _, table := range tables {
+		sheets = append(sheets, table.name)
+	}
+
+	writer, err := spreadsheet.NewXlsxWriter(w, sheets)
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if err = writer.NextSheet(); err != nil {
+			return err
+		}
+
+		if err = writer.WriteRow(table.header); err != nil {
+			return err
+		}
+
+		if err = table.rows(export, writer.WriteRow); err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+// WriteCsv writes the export as a ZIP file with a CSV file for each entity.
+func WriteCsv(w io.Writer, export *entity.PortfolioExport) error {
+	archive := zip.NewWriter(w)
+
+	for _, table := range tables {
+		file, err := archive.Create(table.name + ".csv")
+		if err != nil {
+			return err
+		}
+
+		writer := csv.NewWriter(file)
+		writeRow := func(values []interface{}) error {
+			record := make([]string, len(values))
+			for i, value := range values {
+				record[i] = csvValue(value)
+			}
+			return writer.Write(record)
+		}
+
+		if err = writeRow(table.header); err != nil {
+			return err
+		}
+
+		if err = table.rows(export, writeRow); err != nil {
+			return err
+		}
+
+		writer.Flush()
+		if err = writer.Error(); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+func assetRows(export *entity.PortfolioExport,
+	write func([]interface{}) error) error {
+
+	for _, asset := range export.Assets {
+		row := []interface{}{asset.Symbol, asset.Fullname, nil, nil, nil, nil}
+		if asset.Preference != nil {
+			row[2] = *asset.Preference
+		}
+		if asset.AssetType != nil {
+			row[3] = asset.AssetType.Type
+			row[4] = asset.AssetType.Country
+		}
+		if asset.Sector != nil {
+			row[5] = asset.Sector.Name
+		}
+
+		if err := write(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func orderRows(export *entity.PortfolioExport,
+	write func([]interface{}) error) error {
+
+	for _, order := range export.Orders {
+		row := []interface{}{order.Date, nil, order.OrderType, order.Quantity,
+			order.Price, order.Currency, order.Fees, order.WithheldTax, nil, nil,
+			nil}
+		if order.Asset != nil {
+			row[1] = order.Asset.Symbol
+		}
+		if order.SettlementDate != nil {
+			row[8] = *order.SettlementDate
+		}
+		if order.Brokerage != nil {
+			row[9] = order.Brokerage.Name
+		}
+		if order.Account != nil {
+			row[10] = order.Account.Nickname
+		}
+
+		if err := write(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func earningRows(export *entity.PortfolioExport,
+	write func([]interface{}) error) error {
+
+	for _, earning := range export.Earnings {
+		row := []interface{}{earning.Date, nil, earning.Type, earning.Earning,
+			earning.WithheldTax, earning.Currency}
+		if earning.Asset != nil {
+			row[1] = earning.Asset.Symbol
+		}
+
+		if err := write(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// positionRows writes the positions with their average price, which is the
+// amount invested divided by the quantity, rounded to 8 decimals.
+func positionRows(export *entity.PortfolioExport,
+	write func([]interface{}) error) error {
+
+	for _, position := range export.Positions {
+		row := []interface{}{position.Nickname, position.BrokerageName,
+			position.Symbol, position.Currency, position.Quantity,
+			position.Invested, nil}
+		if position.Quantity != 0 {
+			row[6] = math.Round(position.Invested/position.Quantity*1e8) / 1e8
+		}
+
+		if err := write(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func csvValue(value interface{}) string {
+	switch typedValue := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typedValue
+	case float64:
+		return strconv.FormatFloat(typedValue, 'f', -1, 64)
+	case time.Time:
+		return typedValue.Format("2006-01-02")
+	}
+
+	return ""
+}
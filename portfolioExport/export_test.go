@@ -0,0 +1,158 @@
+package portfolioexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"stockfyApi/entity"
+	"stockfyApi/spreadsheet"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testExport() *entity.PortfolioExport {
+	preference := "PN"
+	settlementDate := entity.StringToTime("2021-10-05")
+
+	return &entity.PortfolioExport{
+		Assets: []entity.Asset{
+			{
+				Symbol:     "ITUB4",
+				Fullname:   "Itau Unibanco Holding S.A.",
+				Preference: &preference,
+				AssetType:  &entity.AssetType{Type: "STOCK", Country: "BR"},
+				Sector:     &entity.Sector{Name: "Finance"},
+			},
+			{
+				Symbol:   "IVVB11",
+				Fullname: "iShares S&P 500",
+			},
+		},
+		Orders: []entity.Order{
+			{
+				Quantity:       20,
+				Price:          29.29,
+				Currency:       "BRL",
+				OrderType:      "buy",
+				Date:           entity.StringToTime("2021-10-01"),
+				Fees:           0.5,
+				SettlementDate: &settlementDate,
+				Brokerage:      &entity.Brokerage{Name: "Clear"},
+				Account:        &entity.BrokerageAccount{Nickname: "Clear, main"},
+				Asset:          &entity.Asset{Symbol: "ITUB4"},
+			},
+		},
+		Earnings: []entity.Earnings{
+			{
+				Type:     "JCP",
+				Earning:  1.7,
+				Currency: "BRL",
+				Date:     entity.StringToTime("2021-11-03"),
+				Asset:    &entity.Asset{Symbol: "ITUB4"},
+			},
+		},
+		Positions: []entity.AccountPosition{
+			{
+				Nickname:      "Clear, main",
+				BrokerageName: "Clear",
+				Symbol:        "ITUB4",
+				Currency:      "BRL",
+				Quantity:      20,
+				Invested:      586.3,
+			},
+			{
+				Nickname:      "Clear, main",
+				BrokerageName: "Clear",
+				Symbol:        "IVVB11",
+				Currency:      "BRL",
+			},
+		},
+	}
+}
+
+func TestWriteCsv(t *testing.T) {
+	expectedFiles := map[string]string{
+		"assets.csv": "symbol,fullname,preference,asset_type,country,sector\n" +
+			"ITUB4,Itau Unibanco Holding S.A.,PN,STOCK,BR,Finance\n" +
+			"IVVB11,iShares S&P 500,,,,\n",
+		"orders.csv": "date,symbol,order_type,quantity,price,currency,fees," +
+			"withheld_tax,settlement_date,brokerage,account\n" +
+			"2021-10-01,ITUB4,buy,20,29.29,BRL,0.5,0,2021-10-05,Clear," +
+			"\"Clear, main\"\n",
+		"earnings.csv": "date,symbol,earning_type,amount,withheld_tax," +
+			"currency\n2021-11-03,ITUB4,JCP,1.7,0,BRL\n",
+		"positions.csv": "account,brokerage,symbol,currency,quantity," +
+			"invested,average_price\n" +
+			"\"Clear, main\",Clear,ITUB4,BRL,20,586.3,29.315\n" +
+			"\"Clear, main\",Clear,IVVB11,BRL,0,0,\n",
+	}
+
+	var buffer bytes.Buffer
+	assert.Nil(t, WriteCsv(&buffer, testExport()))
+
+	archive, err := zip.NewReader(bytes.NewReader(buffer.Bytes()),
+		int64(buffer.Len()))
+	assert.Nil(t, err)
+
+	files := map[string]string{}
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		assert.Nil(t, err)
+
+		content, err := ioutil.ReadAll(reader)
+		assert.Nil(t, err)
+		files[file.Name] = string(content)
+	}
+
+	assert.Equal(t, expectedFiles, files)
+}
+
+func TestWriteXlsx(t *testing.T) {
+	expectedSheets := []spreadsheet.Sheet{
+		{
+			Name: "assets",
+			Rows: [][]string{
+				{"symbol", "fullname", "preference", "asset_type", "country",
+					"sector"},
+				{"ITUB4", "Itau Unibanco Holding S.A.", "PN", "STOCK", "BR",
+					"Finance"},
+				{"IVVB11", "iShares S&P 500"},
+			},
+		},
+		{
+			Name: "orders",
+			Rows: [][]string{
+				{"date", "symbol", "order_type", "quantity", "price", "currency",
+					"fees", "withheld_tax", "settlement_date", "brokerage",
+					"account"},
+				{"2021-10-01", "ITUB4", "buy", "20", "29.29", "BRL", "0.5", "0",
+					"2021-10-05", "Clear", "Clear, main"},
+			},
+		},
+		{
+			Name: "earnings",
+			Rows: [][]string{
+				{"date", "symbol", "earning_type", "amount", "withheld_tax",
+					"currency"},
+				{"2021-11-03", "ITUB4", "JCP", "1.7", "0", "BRL"},
+			},
+		},
+		{
+			Name: "positions",
+			Rows: [][]string{
+				{"account", "brokerage", "symbol", "currency", "quantity",
+					"invested", "average_price"},
+				{"Clear, main", "Clear", "ITUB4", "BRL", "20", "586.3", "29.315"},
+				{"Clear, main", "Clear", "IVVB11", "BRL", "0", "0"},
+			},
+		},
+	}
+
+	var buffer bytes.Buffer
+	assert.Nil(t, WriteXlsx(&buffer, testExport()))
+
+	sheets, err := spreadsheet.ReadXlsx(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, expectedSheets, sheets)
+}
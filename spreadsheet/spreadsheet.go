@@ -0,0 +1,264 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"path"
+	"stockfyApi/entity"
+	"strings"
+)
+
+// Sheet is a sheet of a spreadsheet, with the values of its cells as text.
+// The rows keep their position in the sheet, so the blank rows are empty.
+type Sheet struct {
+	Name string
+	Rows [][]string
+}
+
+// Read reads the sheets of an XLSX file or the single sheet of a CSV file,
+// whose delimiter can be a comma or a semicolon.
+func Read(file []byte) ([]Sheet, error) {
+	if bytes.HasPrefix(file, []byte("PK\x03\x04")) {
+		return ReadXlsx(file)
+	}
+
+	return ReadCsv(file)
+}
+
+func ReadCsv(file []byte) ([]Sheet, error) {
+	file = bytes.TrimPrefix(file, []byte("\ufeff"))
+
+	firstLine := file
+	if end := bytes.IndexByte(file, '\n'); end != -1 {
+		firstLine = file[:end]
+	}
+
+	reader := csv.NewReader(bytes.NewReader(file))
+	reader.FieldsPerRecord = -1
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	var rows [][]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, entity.ErrInvalidSpreadsheet
+		}
+
+		line, _ := reader.FieldPos(0)
+		for len(rows) < line-1 {
+			rows = append(rows, nil)
+		}
+		rows = append(rows, record)
+	}
+
+	if rows == nil {
+		return nil, entity.ErrInvalidSpreadsheet
+	}
+
+	return []Sheet{{Rows: rows}}, nil
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		Id   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		Id     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	text := t.Text
+	for _, run := range t.Runs {
+		text += run.Text
+	}
+
+	return text
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Number int `xml:"r,attr"`
+		Cells  []struct {
+			Reference  string   `xml:"r,attr"`
+			Type       string   `xml:"t,attr"`
+			Value      string   `xml:"v"`
+			InlineText xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// ReadXlsx reads the values of the cells of each sheet of an XLSX file. The
+// numbers and dates are returned as they are stored, without their format.
+func ReadXlsx(file []byte) ([]Sheet, error) {
+	archive, err := zip.NewReader(bytes.NewReader(file), int64(len(file)))
+	if err != nil {
+		return nil, entity.ErrInvalidSpreadsheet
+	}
+
+	files := map[string]*zip.File{}
+	for _, archiveFile := range archive.File {
+		files[archiveFile.Name] = archiveFile
+	}
+
+	var workbook xlsxWorkbook
+	if err := readXml(files, "xl/workbook.xml", &workbook); err != nil {
+		return nil, err
+	}
+
+	var relationships xlsxRelationships
+	err = readXml(files, "xl/_rels/workbook.xml.rels", &relationships)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := map[string]string{}
+	for _, relationship := range relationships.Relationships {
+		target := strings.TrimPrefix(relationship.Target, "/")
+		if !strings.HasPrefix(target, "xl/") {
+			target = path.Join("xl", target)
+		}
+		targets[relationship.Id] = target
+	}
+
+	var sharedStrings xlsxSharedStrings
+	if files["xl/sharedStrings.xml"] != nil {
+		err = readXml(files, "xl/sharedStrings.xml", &sharedStrings)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var sheets []Sheet
+	for _, workbookSheet := range workbook.Sheets {
+		var worksheet xlsxWorksheet
+		err = readXml(files, targets[workbookSheet.Id], &worksheet)
+		if err != nil {
+			return nil, err
+		}
+
+		sheet := Sheet{Name: workbookSheet.Name}
+		for _, row := range worksheet.Rows {
+			rowNumber := row.Number
+			if rowNumber == 0 {
+				rowNumber = len(sheet.Rows) + 1
+			}
+			for len(sheet.Rows) < rowNumber-1 {
+				sheet.Rows = append(sheet.Rows, nil)
+			}
+
+			var values []string
+			for j, cell := range row.Cells {
+				column := cellColumn(cell.Reference)
+				if column == -1 {
+					column = j
+				}
+				for len(values) < column {
+					values = append(values, "")
+				}
+
+				value := cell.Value
+				switch cell.Type {
+				case "s":
+					index, err := cellIndex(cell.Value, len(sharedStrings.Items))
+					if err != nil {
+						return nil, err
+					}
+					value = sharedStrings.Items[index].String()
+				case "inlineStr":
+					value = cell.InlineText.String()
+				}
+
+				values = append(values, value)
+			}
+
+			sheet.Rows = append(sheet.Rows, values)
+		}
+
+		sheets = append(sheets, sheet)
+	}
+
+	if sheets == nil {
+		return nil, entity.ErrInvalidSpreadsheet
+	}
+
+	return sheets, nil
+}
+
+func readXml(files map[string]*zip.File, name string, value interface{}) error {
+	file := files[name]
+	if file == nil {
+		return entity.ErrInvalidSpreadsheet
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return entity.ErrInvalidSpreadsheet
+	}
+	defer reader.Close()
+
+	if err := xml.NewDecoder(reader).Decode(value); err != nil {
+		return entity.ErrInvalidSpreadsheet
+	}
+
+	return nil
+}
+
+// cellColumn returns the index of the column of a cell reference, like 0 for
+// A1 and 27 for AB3.
+func cellColumn(reference string) int {
+	column := 0
+	letters := 0
+	for _, char := range reference {
+		if char < 'A' || char > 'Z' {
+			break
+		}
+		column = column*26 + int(char-'A'+1)
+		letters++
+	}
+
+	if letters == 0 {
+		return -1
+	}
+
+	return column - 1
+}
+
+func cellIndex(value string, size int) (int, error) {
+	index := 0
+	for _, char := range value {
+		if char < '0' || char > '9' {
+			return 0, entity.ErrInvalidSpreadsheet
+		}
+		index = index*10 + int(char-'0')
+	}
+
+	if value == "" || index >= size {
+		return 0, entity.ErrInvalidSpreadsheet
+	}
+
+	return index, nil
+}
@@ -0,0 +1,145 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"stockfyApi/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func xlsxFile(t *testing.T, files map[string]string) []byte {
+	var buffer bytes.Buffer
+
+	archive := zip.NewWriter(&buffer)
+	for name, content := range files {
+		writer, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		writer.Write([]byte(content))
+	}
+
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buffer.Bytes()
+}
+
+func TestReadXlsx(t *testing.T) {
+	file := xlsxFile(t, map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Acoes" sheetId="1" r:id="rId1"/><sheet name="ETF" sheetId="2" r:id="rId2"/></sheets>
+</workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="worksheet" Target="/xl/worksheets/sheet2.xml"/>
+</Relationships>`,
+		"xl/sharedStrings.xml": `<sst><si><t>Produto</t></si><si><r><t>Quanti</t></r><r><t>dade</t></r></si><si><t>ITUB4 - ITAU UNIBANCO</t></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="s"><v>1</v></c></row>
+<row r="3"><c r="A3" t="s"><v>2</v></c><c r="C3"><v>100</v></c></row>
+</sheetData></worksheet>`,
+		"xl/worksheets/sheet2.xml": `<worksheet><sheetData>
+<row r="1"><c r="A1" t="inlineStr"><is><t>BOVA11</t></is></c><c r="B1" t="str"><v>10,5</v></c></row>
+</sheetData></worksheet>`,
+	})
+
+	expectedSheets := []Sheet{
+		{
+			Name: "Acoes",
+			Rows: [][]string{
+				{"Produto", "", "Quantidade"},
+				nil,
+				{"ITUB4 - ITAU UNIBANCO", "", "100"},
+			},
+		},
+		{
+			Name: "ETF",
+			Rows: [][]string{{"BOVA11", "10,5"}},
+		},
+	}
+
+	sheets, err := Read(file)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedSheets, sheets)
+
+	sheets, err = ReadXlsx(xlsxFile(t, map[string]string{
+		"xl/workbook.xml": "<workbook></workbook>",
+	}))
+	assert.Nil(t, sheets)
+	assert.Equal(t, entity.ErrInvalidSpreadsheet, err)
+}
+
+func TestReadCsv(t *testing.T) {
+	file := []byte("\ufeffProduto;Quantidade\n\nITUB4;\"1.000,5\"\n")
+
+	sheets, err := Read(file)
+	assert.Nil(t, err)
+	assert.Equal(t, []Sheet{{Rows: [][]string{{"Produto", "Quantidade"}, nil,
+		{"ITUB4", "1.000,5"}}}}, sheets)
+
+	sheets, err = ReadCsv([]byte(""))
+	assert.Nil(t, sheets)
+	assert.Equal(t, entity.ErrInvalidSpreadsheet, err)
+}
+
+func TestCellColumn(t *testing.T) {
+	assert.Equal(t, 0, cellColumn("A1"))
+	assert.Equal(t, 27, cellColumn("AB3"))
+	assert.Equal(t, -1, cellColumn("12"))
+}
+
+func TestXlsxWriter(t *testing.T) {
+	var buffer bytes.Buffer
+
+	writer, err := NewXlsxWriter(&buffer, []string{"Assets", "Orders", "Earnings"})
+	assert.Nil(t, err)
+
+	assert.Equal(t, entity.ErrInvalidSpreadsheet, writer.WriteRow(
+		[]interface{}{"symbol"}))
+
+	assert.Nil(t, writer.NextSheet())
+	assert.Nil(t, writer.WriteRow([]interface{}{"symbol", "name"}))
+	assert.Nil(t, writer.WriteRow([]interface{}{"ITUB4", " Itaú <Unibanco> & Co"}))
+
+	assert.Nil(t, writer.NextSheet())
+	assert.Nil(t, writer.WriteRow([]interface{}{"symbol", "quantity", "date",
+		"fees", "duplicate"}))
+	assert.Nil(t, writer.WriteRow([]interface{}{"ITUB4", -20.5,
+		time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC), nil, true}))
+	assert.Nil(t, writer.Close())
+
+	expectedSheets := []Sheet{
+		{
+			Name: "Assets",
+			Rows: [][]string{{"symbol", "name"},
+				{"ITUB4", " Itaú <Unibanco> & Co"}},
+		},
+		{
+			Name: "Orders",
+			Rows: [][]string{{"symbol", "quantity", "date", "fees", "duplicate"},
+				{"ITUB4", "-20.5", "2021-10-01", "", "1"}},
+		},
+		{
+			Name: "Earnings",
+		},
+	}
+
+	sheets, err := ReadXlsx(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, expectedSheets, sheets)
+
+	_, err = NewXlsxWriter(&buffer, nil)
+	assert.Equal(t, entity.ErrInvalidSpreadsheet, err)
+}
+
+func TestColumnName(t *testing.T) {
+	assert.Equal(t, "A", columnName(0))
+	assert.Equal(t, "Z", columnName(25))
+	assert.Equal(t, "AB", columnName(27))
+	assert.Equal(t, 27, cellColumn(columnName(27)+"1"))
+}
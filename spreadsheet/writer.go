@@ -0,0 +1,204 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"stockfyApi/entity"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	xlsxMainNamespace         = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	xlsxRelationshipNamespace = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+	xlsxPackageNamespace      = "http://schemas.openxmlformats.org/package/2006/relationships"
+)
+
+// XlsxWriter writes an XLSX file sheet by sheet. The rows are written to the
+// file as they are received, so the file is never kept whole in memory. The
+// names of the sheets are known up front, because the workbook is written
+// before them.
+type XlsxWriter struct {
+	archive *zip.Writer
+	sheets  []string
+	sheet   io.Writer
+	current int
+	rows    int
+}
+
+// NewXlsxWriter starts an XLSX file with the sheets named, which are written
+// in the same order by NextSheet.
+func NewXlsxWriter(w io.Writer, sheets []string) (*XlsxWriter, error) {
+	if len(sheets) == 0 {
+		return nil, entity.ErrInvalidSpreadsheet
+	}
+
+	writer := &XlsxWriter{
+		archive: zip.NewWriter(w),
+		sheets:  sheets,
+		current: -1,
+	}
+
+	var contentTypes, workbook, relationships strings.Builder
+	for i, name := range sheets {
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`,
+			escapeXml(name), i+1, i+1)
+		fmt.Fprintf(&relationships, `<Relationship Id="rId%d" Type="%s/worksheet" Target="worksheets/sheet%d.xml"/>`,
+			i+1, xlsxRelationshipNamespace, i+1)
+	}
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			contentTypes.String() + `</Types>`},
+		{"_rels/.rels", `<Relationships xmlns="` + xlsxPackageNamespace + `">` +
+			`<Relationship Id="rId1" Type="` + xlsxRelationshipNamespace +
+			`/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+		{"xl/workbook.xml", `<workbook xmlns="` + xlsxMainNamespace +
+			`" xmlns:r="` + xlsxRelationshipNamespace + `"><sheets>` +
+			workbook.String() + `</sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", `<Relationships xmlns="` +
+			xlsxPackageNamespace + `">` + relationships.String() +
+			`</Relationships>`},
+	}
+
+	for _, file := range files {
+		fileWriter, err := writer.archive.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = io.WriteString(fileWriter, xml.Header+file.content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return writer, nil
+}
+
+// NextSheet finishes the current sheet and starts the next one.
+func (x *XlsxWriter) NextSheet() error {
+	if x.current+1 >= len(x.sheets) {
+		return entity.ErrInvalidSpreadsheet
+	}
+
+	if err := x.finishSheet(); err != nil {
+		return err
+	}
+
+	x.current++
+	x.rows = 0
+
+	sheet, err := x.archive.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml",
+		x.current+1))
+	if err != nil {
+		return err
+	}
+	x.sheet = sheet
+
+	_, err = io.WriteString(x.sheet, xml.Header+`<worksheet xmlns="`+
+		xlsxMainNamespace+`"><sheetData>`)
+
+	return err
+}
+
+// WriteRow writes a row in the current sheet. The numbers are written as
+// numeric cells, the dates in the YYYY-MM-DD layout and the nil values as
+// blank cells.
+func (x *XlsxWriter) WriteRow(values []interface{}) error {
+	if x.sheet == nil {
+		return entity.ErrInvalidSpreadsheet
+	}
+
+	x.rows++
+
+	var row strings.Builder
+	fmt.Fprintf(&row, `<row r="%d">`, x.rows)
+	for i, value := range values {
+		reference := columnName(i) + strconv.Itoa(x.rows)
+
+		switch typedValue := value.(type) {
+		case nil:
+			continue
+		case float64:
+			fmt.Fprintf(&row, `<c r="%s"><v>%s</v></c>`, reference,
+				strconv.FormatFloat(typedValue, 'f', -1, 64))
+		case int:
+			fmt.Fprintf(&row, `<c r="%s"><v>%d</v></c>`, reference, typedValue)
+		case bool:
+			boolValue := 0
+			if typedValue {
+				boolValue = 1
+			}
+			fmt.Fprintf(&row, `<c r="%s" t="b"><v>%d</v></c>`, reference,
+				boolValue)
+		case time.Time:
+			fmt.Fprintf(&row, `<c r="%s" t="inlineStr"><is><t>%s</t></is></c>`,
+				reference, typedValue.Format("2006-01-02"))
+		default:
+			fmt.Fprintf(&row,
+				`<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`,
+				reference, escapeXml(fmt.Sprint(typedValue)))
+		}
+	}
+	row.WriteString(`</row>`)
+
+	_, err := io.WriteString(x.sheet, row.String())
+
+	return err
+}
+
+// Close finishes the file. The sheets not started are written blank.
+func (x *XlsxWriter) Close() error {
+	for x.current+1 < len(x.sheets) {
+		if err := x.NextSheet(); err != nil {
+			return err
+		}
+	}
+
+	if err := x.finishSheet(); err != nil {
+		return err
+	}
+
+	return x.archive.Close()
+}
+
+func (x *XlsxWriter) finishSheet() error {
+	if x.sheet == nil {
+		return nil
+	}
+
+	_, err := io.WriteString(x.sheet, `</sheetData></worksheet>`)
+	x.sheet = nil
+
+	return err
+}
+
+// columnName returns the letters of a column index, like A for 0 and AB for
+// 27.
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+
+	return name
+}
+
+func escapeXml(value string) string {
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(value))
+
+	return escaped.String()
+}
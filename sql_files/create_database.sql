@@ -5,6 +5,9 @@ CREATE DATABASE stockfy;
 -- Create functions extension to generate the UUID values
 CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
 
+-- Create trigram extension used by the symbol search
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
 -- Create functions to enable timestamp trigger
 CREATE OR REPLACE FUNCTION trigger_set_timestamp()
 RETURNS TRIGGER AS $$
@@ -20,12 +23,36 @@ CREATE TABLE public.sectors (
 	created_at timestamp without time zone NOT NULL DEFAULT now(),
 	updated_at timestamp without time zone NOT NULL DEFAULT now(),
 	"name" text NOT NULL,
-	CONSTRAINT sectors_pk PRIMARY KEY (id)
+	"level" text NOT NULL DEFAULT 'SECTOR',
+	code text NOT NULL DEFAULT '',
+	parent_id uuid NULL,
+	CONSTRAINT sectors_pk PRIMARY KEY (id),
+	CONSTRAINT sectors_parent_fk FOREIGN KEY (parent_id) REFERENCES public.sectors(id)
 );
 CREATE TRIGGER set_timestamp
 BEFORE UPDATE ON public.sectors
 FOR EACH ROW
 EXECUTE PROCEDURE trigger_set_timestamp();
+CREATE UNIQUE INDEX sectors_code_idx ON public.sectors (code) WHERE code <> '';
+
+-- Create Sector Mappings table. The sector classification labels of each
+-- provider are mapped to a node of the taxonomy. New labels are stored without
+-- sector until an admin maps them.
+CREATE TABLE public.sector_mappings (
+	id uuid NOT NULL DEFAULT uuid_generate_v4(),
+	created_at timestamp without time zone NOT NULL DEFAULT now(),
+	updated_at timestamp without time zone NOT NULL DEFAULT now(),
+	provider text NOT NULL,
+	label text NOT NULL,
+	sector_id uuid NULL,
+	CONSTRAINT sector_mappings_pk PRIMARY KEY (id),
+	CONSTRAINT sector_mappings_sectors_fk FOREIGN KEY (sector_id) REFERENCES public.sectors(id),
+	UNIQUE(provider, label)
+);
+CREATE TRIGGER set_timestamp
+BEFORE UPDATE ON public.sector_mappings
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
 
 -- Create Asset Types table
 CREATE TABLE public.asset_types (
@@ -35,7 +62,8 @@ CREATE TABLE public.asset_types (
 	"type" text NOT NULL,
     "name" text NOT NULL,
 	country text NOT NULL,
-	CONSTRAINT asset_types_pk PRIMARY KEY (id)
+	CONSTRAINT asset_types_pk PRIMARY KEY (id),
+	CONSTRAINT asset_types_type_country_un UNIQUE ("type", country)
 );
 CREATE TRIGGER set_timestamp
 BEFORE UPDATE ON public.asset_types
@@ -52,9 +80,11 @@ CREATE TABLE public.assets (
 	preference text NOT NULL,
     asset_type_id uuid NOT NULL,
     sector_id uuid NOT NULL,
+    sector_mapping_id uuid NULL,
 	CONSTRAINT assets_pk PRIMARY KEY (id),
     CONSTRAINT assets_types_fk FOREIGN KEY (asset_type_id) REFERENCES public.asset_types(id),
-    CONSTRAINT sectors_fk FOREIGN KEY (sector_id) REFERENCES public.sectors(id)
+    CONSTRAINT sectors_fk FOREIGN KEY (sector_id) REFERENCES public.sectors(id),
+    CONSTRAINT sector_mappings_fk FOREIGN KEY (sector_mapping_id) REFERENCES public.sector_mappings(id)
 );
 CREATE TRIGGER set_timestamp
 BEFORE UPDATE ON public.assets
@@ -77,6 +107,19 @@ BEFORE UPDATE ON public.users
 FOR EACH ROW
 EXECUTE PROCEDURE trigger_set_timestamp();
 
+-- Create User Identities table with the Firebase users, of other authentication
+-- providers, linked to an user. They access the portfolio of the user they
+-- were linked to.
+CREATE TABLE public.user_identities (
+	firebase_uid text NOT NULL,
+	created_at timestamp without time zone NOT NULL DEFAULT now(),
+	user_uid text NOT NULL,
+	provider_id text NOT NULL,
+	CONSTRAINT user_identities_pk PRIMARY KEY (firebase_uid),
+	CONSTRAINT user_identities_user_fk FOREIGN KEY (user_uid) REFERENCES public.users("uid") ON DELETE CASCADE
+);
+CREATE INDEX user_identities_user_idx ON public.user_identities (user_uid);
+
 -- Create Asset Users table
 CREATE TABLE public.asset_users (
     asset_id uuid NOT NULL,
@@ -93,7 +136,8 @@ BEFORE UPDATE ON public.asset_users
 FOR EACH ROW
 EXECUTE PROCEDURE trigger_set_timestamp();
 
--- Create Brokerages table
+-- Create Brokerages table. The brokerages with an user_uid are custom
+-- brokerages, visible only to the user who created them.
 CREATE TABLE public.brokerages (
 	id uuid NOT NULL DEFAULT uuid_generate_v4(),
 	created_at timestamp without time zone NOT NULL DEFAULT now(),
@@ -101,13 +145,35 @@ CREATE TABLE public.brokerages (
 	"name" text NOT NULL,
     fullname text NOT NULL,
 	country text NOT NULL,
-	CONSTRAINT brokerages_pk PRIMARY KEY (id)
+	user_uid text NULL,
+	CONSTRAINT brokerages_pk PRIMARY KEY (id),
+	CONSTRAINT brokerages_user_fk FOREIGN KEY (user_uid) REFERENCES public.users("uid") ON DELETE CASCADE
 );
 CREATE TRIGGER set_timestamp
 BEFORE UPDATE ON public.brokerages
 FOR EACH ROW
 EXECUTE PROCEDURE trigger_set_timestamp();
 
+-- Create Brokerage Accounts table with the accounts of each user in the
+-- brokerages. The orders keep the brokerage of their account.
+CREATE TABLE public.brokerage_accounts (
+	id uuid NOT NULL DEFAULT uuid_generate_v4(),
+	created_at timestamp without time zone NOT NULL DEFAULT now(),
+	updated_at timestamp without time zone NOT NULL DEFAULT now(),
+	user_uid text NOT NULL,
+	brokerage_id uuid NOT NULL,
+	nickname text NOT NULL,
+	CONSTRAINT brokerage_accounts_pk PRIMARY KEY (id),
+	CONSTRAINT brokerage_accounts_brokerage_fk FOREIGN KEY (brokerage_id) REFERENCES public.brokerages(id),
+	CONSTRAINT brokerage_accounts_user_fk FOREIGN KEY (user_uid) REFERENCES public.users("uid") ON DELETE CASCADE,
+	UNIQUE(user_uid, nickname),
+	UNIQUE(id, brokerage_id)
+);
+CREATE TRIGGER set_timestamp
+BEFORE UPDATE ON public.brokerage_accounts
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
 -- Create Orders table
 CREATE TABLE public.orders (
 	id uuid NOT NULL DEFAULT uuid_generate_v4(),
@@ -116,13 +182,18 @@ CREATE TABLE public.orders (
 	asset_id uuid NOT NULL,
 	user_uid text NOT NULL,
 	brokerage_id uuid NOT NULL,
+	account_id uuid NOT NULL,
 	quantity float8 NOT NULL,
 	price float8 NOT NULL,
 	currency text NOT NULL,
 	order_type text NOT NULL,
 	"date" date NOT NULL,
+	fees float8 NOT NULL DEFAULT 0,
+	withheld_tax float8 NOT NULL DEFAULT 0,
+	settlement_date date NULL,
 	CONSTRAINT orders_pk PRIMARY KEY (id),
 	CONSTRAINT orders_brokerage_fk FOREIGN KEY (brokerage_id) REFERENCES public.brokerages(id),
+	CONSTRAINT orders_account_fk FOREIGN KEY (account_id, brokerage_id) REFERENCES public.brokerage_accounts(id, brokerage_id),
 	CONSTRAINT orders_asset_fk FOREIGN KEY (asset_id) REFERENCES public.assets(id) ON DELETE CASCADE,
 	CONSTRAINT orders_user_fk FOREIGN KEY (user_uid) REFERENCES public.users("uid") ON DELETE CASCADE
 );
@@ -131,6 +202,27 @@ BEFORE UPDATE ON public.orders
 FOR EACH ROW
 EXECUTE PROCEDURE trigger_set_timestamp();
 
+-- Create Earning Events table with the dividends and JCP announced by the
+-- market data providers. The earnings of each user are created from them.
+CREATE TABLE public.earning_events (
+	id uuid NOT NULL DEFAULT uuid_generate_v4(),
+	created_at timestamp NOT NULL DEFAULT now(),
+	updated_at timestamp NOT NULL DEFAULT now(),
+	asset_id uuid NOT NULL,
+	"type" text NOT NULL,
+	amount float8 NOT NULL,
+	currency text NOT NULL,
+	ex_date date NOT NULL,
+	payment_date date,
+	CONSTRAINT earning_events_pk PRIMARY KEY (id),
+	CONSTRAINT earning_events_asset_fk FOREIGN KEY (asset_id) REFERENCES public.assets(id) ON DELETE CASCADE,
+	UNIQUE(asset_id, "type", ex_date)
+);
+CREATE TRIGGER set_timestamp
+BEFORE UPDATE ON public.earning_events
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
 -- Create Earnings table
 CREATE TABLE public.earnings (
 	id uuid NOT NULL DEFAULT uuid_generate_v4(),
@@ -140,17 +232,365 @@ CREATE TABLE public.earnings (
 	user_uid text NOT NULL,
 	"type" text NOT NULL,
 	earning float8 NOT NULL,
+	withheld_tax float8 NOT NULL DEFAULT 0,
 	"date" date NOT NULL,
 	currency text NOT NULL,
+	earning_event_id uuid,
 	CONSTRAINT earnings_pk PRIMARY KEY (id),
 	CONSTRAINT earnings_asset_fk FOREIGN KEY (asset_id) REFERENCES public.assets(id) ON DELETE CASCADE,
-	CONSTRAINT earnings_user_fk FOREIGN KEY (user_uid) REFERENCES public.users("uid") ON DELETE CASCADE
+	CONSTRAINT earnings_user_fk FOREIGN KEY (user_uid) REFERENCES public.users("uid") ON DELETE CASCADE,
+	CONSTRAINT earnings_earning_event_fk FOREIGN KEY (earning_event_id) REFERENCES public.earning_events(id) ON DELETE SET NULL,
+	UNIQUE(earning_event_id, user_uid)
 );
 CREATE TRIGGER set_timestamp
 BEFORE UPDATE ON public.earnings
 FOR EACH ROW
 EXECUTE PROCEDURE trigger_set_timestamp();
 
+-- Create Dismissed Earning Events table with the events whose earning was
+-- deleted by the user, so the earning is not created again from the event.
+CREATE TABLE public.dismissed_earning_events (
+	created_at timestamp NOT NULL DEFAULT now(),
+	earning_event_id uuid NOT NULL,
+	user_uid text NOT NULL,
+	CONSTRAINT dismissed_earning_events_pk PRIMARY KEY (earning_event_id, user_uid),
+	CONSTRAINT dismissed_earning_events_event_fk FOREIGN KEY (earning_event_id) REFERENCES public.earning_events(id) ON DELETE CASCADE,
+	CONSTRAINT dismissed_earning_events_user_fk FOREIGN KEY (user_uid) REFERENCES public.users("uid") ON DELETE CASCADE
+);
+
+-- Create Cash Movements table with the deposits, withdrawals, transfers, fees
+-- and interests registered by the user in the brokerage accounts. The amount
+-- is negative when the money leaves the account. The orders and the earnings
+-- are posted in the cash ledger from their own tables.
+CREATE TABLE public.cash_movements (
+	id uuid NOT NULL DEFAULT uuid_generate_v4(),
+	created_at timestamp NOT NULL DEFAULT now(),
+	updated_at timestamp NOT NULL DEFAULT now(),
+	user_uid text NOT NULL,
+	account_id uuid NOT NULL,
+	to_account_id uuid NULL,
+	"type" text NOT NULL,
+	amount float8 NOT NULL,
+	currency text NOT NULL,
+	"date" date NOT NULL,
+	description text NOT NULL DEFAULT '',
+	CONSTRAINT cash_movements_pk PRIMARY KEY (id),
+	CONSTRAINT cash_movements_account_fk FOREIGN KEY (account_id) REFERENCES public.brokerage_accounts(id) ON DELETE CASCADE,
+	CONSTRAINT cash_movements_to_account_fk FOREIGN KEY (to_account_id) REFERENCES public.brokerage_accounts(id) ON DELETE CASCADE,
+	CONSTRAINT cash_movements_user_fk FOREIGN KEY (user_uid) REFERENCES public.users("uid") ON DELETE CASCADE
+);
+CREATE INDEX cash_movements_user_idx ON public.cash_movements (user_uid, "date");
+CREATE TRIGGER set_timestamp
+BEFORE UPDATE ON public.cash_movements
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+-- Create Remittances table. The money may be sent from a bank account, so the
+-- account it was sent from is optional.
+CREATE TABLE public.remittances (
+	id uuid NOT NULL DEFAULT uuid_generate_v4(),
+	created_at timestamp NOT NULL DEFAULT now(),
+	updated_at timestamp NOT NULL DEFAULT now(),
+	user_uid text NOT NULL,
+	from_account_id uuid NULL,
+	to_account_id uuid NOT NULL,
+	sent_amount float8 NOT NULL,
+	sent_currency text NOT NULL,
+	received_amount float8 NOT NULL,
+	received_currency text NOT NULL,
+	iof float8 NOT NULL DEFAULT 0,
+	fees float8 NOT NULL DEFAULT 0,
+	commercial_rate float8 NOT NULL DEFAULT 0,
+	"date" date NOT NULL,
+	CONSTRAINT remittances_pk PRIMARY KEY (id),
+	CONSTRAINT remittances_from_account_fk FOREIGN KEY (from_account_id) REFERENCES public.brokerage_accounts(id) ON DELETE SET NULL,
+	CONSTRAINT remittances_to_account_fk FOREIGN KEY (to_account_id) REFERENCES public.brokerage_accounts(id) ON DELETE CASCADE,
+	CONSTRAINT remittances_user_fk FOREIGN KEY (user_uid) REFERENCES public.users("uid") ON DELETE CASCADE
+);
+CREATE INDEX remittances_user_idx ON public.remittances (user_uid, "date");
+CREATE TRIGGER set_timestamp
+BEFORE UPDATE ON public.remittances
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+
+-- Create Markets table. The quantity decimals is 0 for markets accepting only
+-- integer lots and -1 for markets accepting any fractional quantity.
+CREATE TABLE public.markets (
+	country text NOT NULL,
+	created_at timestamp without time zone NOT NULL DEFAULT now(),
+	updated_at timestamp without time zone NOT NULL DEFAULT now(),
+	"name" text NOT NULL,
+	currencies text[] NOT NULL,
+	reference_currency text NOT NULL DEFAULT '',
+	symbol_suffix text NOT NULL DEFAULT '',
+	quantity_decimals integer NOT NULL DEFAULT 0,
+	provider text NOT NULL,
+	asset_types text[] NOT NULL,
+	CONSTRAINT markets_pk PRIMARY KEY (country)
+);
+CREATE TRIGGER set_timestamp
+BEFORE UPDATE ON public.markets
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+-- Create Fixed Incomes table
+CREATE TABLE public.fixed_incomes (
+	id uuid NOT NULL DEFAULT uuid_generate_v4(),
+	created_at timestamp NOT NULL DEFAULT now(),
+	updated_at timestamp NOT NULL DEFAULT now(),
+	asset_id uuid NOT NULL,
+	category text NOT NULL,
+	issuer text NOT NULL,
+	indexer text NOT NULL,
+	rate float8 NOT NULL,
+	maturity date NOT NULL,
+	CONSTRAINT fixed_incomes_pk PRIMARY KEY (id),
+	CONSTRAINT fixed_incomes_asset_fk FOREIGN KEY (asset_id) REFERENCES public.assets(id) ON DELETE CASCADE,
+	UNIQUE(asset_id)
+);
+CREATE TRIGGER set_timestamp
+BEFORE UPDATE ON public.fixed_incomes
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+-- Create Options table. Each option is an asset linked to its underlying asset
+-- and is settled once the expiry orders are registered for its holders.
+CREATE TABLE public.options (
+	id uuid NOT NULL DEFAULT uuid_generate_v4(),
+	created_at timestamp NOT NULL DEFAULT now(),
+	updated_at timestamp NOT NULL DEFAULT now(),
+	asset_id uuid NOT NULL,
+	underlying_id uuid NOT NULL,
+	option_type text NOT NULL,
+	strike float8 NOT NULL,
+	expiry date NOT NULL,
+	settled boolean NOT NULL DEFAULT false,
+	CONSTRAINT options_pk PRIMARY KEY (id),
+	CONSTRAINT options_asset_fk FOREIGN KEY (asset_id) REFERENCES public.assets(id) ON DELETE CASCADE,
+	CONSTRAINT options_underlying_fk FOREIGN KEY (underlying_id) REFERENCES public.assets(id) ON DELETE CASCADE,
+	UNIQUE(asset_id)
+);
+CREATE TRIGGER set_timestamp
+BEFORE UPDATE ON public.options
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+-- Create Company Profiles table with the company information returned by the
+-- market data providers. Each profile is refreshed periodically.
+CREATE TABLE public.company_profiles (
+	asset_id uuid NOT NULL,
+	created_at timestamp NOT NULL DEFAULT now(),
+	updated_at timestamp NOT NULL DEFAULT now(),
+	logo text NOT NULL DEFAULT '',
+	website text NOT NULL DEFAULT '',
+	exchange text NOT NULL DEFAULT '',
+	market_cap float8 NOT NULL DEFAULT 0,
+	pe_ratio float8 NOT NULL DEFAULT 0,
+	dividend_yield float8 NOT NULL DEFAULT 0,
+	ipo_date date,
+	CONSTRAINT company_profiles_pk PRIMARY KEY (asset_id),
+	CONSTRAINT company_profiles_asset_fk FOREIGN KEY (asset_id) REFERENCES public.assets(id) ON DELETE CASCADE
+);
+CREATE TRIGGER set_timestamp
+BEFORE UPDATE ON public.company_profiles
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+-- Create Index Series table with the daily CDI and monthly IPCA values
+CREATE TABLE public.index_series (
+	indexer text NOT NULL,
+	"date" date NOT NULL,
+	value float8 NOT NULL,
+	CONSTRAINT index_series_pk PRIMARY KEY (indexer, "date")
+);
+
+-- Create Symbols table with the symbols listed in each exchange. Together with
+-- the assets table, it is the local index of the symbol search.
+CREATE TABLE public.symbols (
+	symbol text NOT NULL,
+	country text NOT NULL,
+	created_at timestamp NOT NULL DEFAULT now(),
+	updated_at timestamp NOT NULL DEFAULT now(),
+	fullname text NOT NULL,
+	"type" text NOT NULL,
+	CONSTRAINT symbols_pk PRIMARY KEY (symbol, country)
+);
+CREATE TRIGGER set_timestamp
+BEFORE UPDATE ON public.symbols
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+-- Create trigram indexes for the symbol search by symbol and company name
+CREATE INDEX symbols_symbol_trgm_idx ON public.symbols USING gin (symbol gin_trgm_ops);
+CREATE INDEX symbols_fullname_trgm_idx ON public.symbols USING gin (fullname gin_trgm_ops);
+CREATE INDEX assets_symbol_trgm_idx ON public.assets USING gin (symbol gin_trgm_ops);
+CREATE INDEX assets_fullname_trgm_idx ON public.assets USING gin (fullname gin_trgm_ops);
+
+-- Create Admin Audit Logs table with the changes made by the admins in the
+-- assets, sectors and asset types shared by all users
+CREATE TABLE public.admin_audit_logs (
+	id uuid NOT NULL DEFAULT uuid_generate_v4(),
+	created_at timestamp without time zone NOT NULL DEFAULT now(),
+	user_uid text NOT NULL,
+	"action" text NOT NULL,
+	entity_type text NOT NULL,
+	entity_id text NOT NULL,
+	"before" jsonb NULL,
+	"after" jsonb NULL,
+	CONSTRAINT admin_audit_logs_pk PRIMARY KEY (id)
+);
+CREATE INDEX admin_audit_logs_entity_idx ON public.admin_audit_logs (entity_type, entity_id, created_at DESC);
+
+-- Populate database with the markets supported by the API
+INSERT INTO
+	public.markets (country, "name", currencies, reference_currency,
+		symbol_suffix, quantity_decimals, provider, asset_types)
+VALUES
+	('BR', 'Brasil', '{BRL}', '', '.SA', 0, 'ALPHA_VANTAGE',
+		'{STOCK,ETF,FII,FIXED_INCOME,OPTION}'),
+	('US', 'Estados Unidos', '{USD}', '', '', -1, 'FINNHUB',
+		'{STOCK,ETF,REIT}'),
+	('CRYPTO', 'Criptomoedas', '{BRL,USD}', '', '', 8, 'CRYPTO', '{CRYPTO}'),
+	('BDR', 'BDRs', '{BRL}', 'USD', '.SA', 0, 'ALPHA_VANTAGE', '{STOCK,ETF}');
+
+-- Populate database with the sector taxonomy. The codes of the sectors,
+-- industry groups and industries follow the GICS hierarchy.
+INSERT INTO
+	public.sectors ("name", "level", code)
+VALUES
+	('Energy', 'SECTOR', '10'),
+	('Materials', 'SECTOR', '15'),
+	('Industrials', 'SECTOR', '20'),
+	('Consumer Discretionary', 'SECTOR', '25'),
+	('Consumer Staples', 'SECTOR', '30'),
+	('Health Care', 'SECTOR', '35'),
+	('Financials', 'SECTOR', '40'),
+	('Information Technology', 'SECTOR', '45'),
+	('Communication Services', 'SECTOR', '50'),
+	('Utilities', 'SECTOR', '55'),
+	('Real Estate', 'SECTOR', '60'),
+	('Diversified', 'SECTOR', '80'),
+	('Cryptocurrency', 'SECTOR', '85'),
+	('Fixed Income', 'SECTOR', '90'),
+	('Unclassified', 'SECTOR', '99');
+
+INSERT INTO
+	public.sectors ("name", "level", code, parent_id)
+SELECT
+	child."name", child."level", child.code, s.id
+FROM (VALUES
+	('Energy', 'INDUSTRY_GROUP', '1010'),
+	('Materials', 'INDUSTRY_GROUP', '1510'),
+	('Capital Goods', 'INDUSTRY_GROUP', '2010'),
+	('Commercial & Professional Services', 'INDUSTRY_GROUP', '2020'),
+	('Transportation', 'INDUSTRY_GROUP', '2030'),
+	('Automobiles & Components', 'INDUSTRY_GROUP', '2510'),
+	('Consumer Durables & Apparel', 'INDUSTRY_GROUP', '2520'),
+	('Consumer Services', 'INDUSTRY_GROUP', '2530'),
+	('Retailing', 'INDUSTRY_GROUP', '2550'),
+	('Food & Staples Retailing', 'INDUSTRY_GROUP', '3010'),
+	('Food, Beverage & Tobacco', 'INDUSTRY_GROUP', '3020'),
+	('Household & Personal Products', 'INDUSTRY_GROUP', '3030'),
+	('Health Care Equipment & Services', 'INDUSTRY_GROUP', '3510'),
+	('Pharmaceuticals, Biotechnology & Life Sciences', 'INDUSTRY_GROUP', '3520'),
+	('Banks', 'INDUSTRY_GROUP', '4010'),
+	('Diversified Financials', 'INDUSTRY_GROUP', '4020'),
+	('Insurance', 'INDUSTRY_GROUP', '4030'),
+	('Software & Services', 'INDUSTRY_GROUP', '4510'),
+	('Technology Hardware & Equipment', 'INDUSTRY_GROUP', '4520'),
+	('Semiconductors & Semiconductor Equipment', 'INDUSTRY_GROUP', '4530'),
+	('Telecommunication Services', 'INDUSTRY_GROUP', '5010'),
+	('Media & Entertainment', 'INDUSTRY_GROUP', '5020'),
+	('Utilities', 'INDUSTRY_GROUP', '5510'),
+	('Real Estate', 'INDUSTRY_GROUP', '6010')
+) as child("name", "level", code)
+INNER JOIN public.sectors as s
+ON s.code = left(child.code, 2);
+
+INSERT INTO
+	public.sectors ("name", "level", code, parent_id)
+SELECT
+	child."name", child."level", child.code, s.id
+FROM (VALUES
+	('Oil, Gas & Consumable Fuels', 'INDUSTRY', '101020'),
+	('Chemicals', 'INDUSTRY', '151010'),
+	('Metals & Mining', 'INDUSTRY', '151040'),
+	('Paper & Forest Products', 'INDUSTRY', '151050'),
+	('Aerospace & Defense', 'INDUSTRY', '201010'),
+	('Machinery', 'INDUSTRY', '201060'),
+	('Airlines', 'INDUSTRY', '203020'),
+	('Road & Rail', 'INDUSTRY', '203040'),
+	('Automobiles', 'INDUSTRY', '251020'),
+	('Hotels, Restaurants & Leisure', 'INDUSTRY', '253010'),
+	('Internet & Direct Marketing Retail', 'INDUSTRY', '255020'),
+	('Food & Staples Retailing', 'INDUSTRY', '301010'),
+	('Beverages', 'INDUSTRY', '302010'),
+	('Food Products', 'INDUSTRY', '302020'),
+	('Tobacco', 'INDUSTRY', '302030'),
+	('Health Care Providers & Services', 'INDUSTRY', '351020'),
+	('Biotechnology', 'INDUSTRY', '352010'),
+	('Pharmaceuticals', 'INDUSTRY', '352020'),
+	('Banks', 'INDUSTRY', '401010'),
+	('Capital Markets', 'INDUSTRY', '402030'),
+	('Insurance', 'INDUSTRY', '403010'),
+	('IT Services', 'INDUSTRY', '451020'),
+	('Software', 'INDUSTRY', '451030'),
+	('Communications Equipment', 'INDUSTRY', '452010'),
+	('Technology Hardware, Storage & Peripherals', 'INDUSTRY', '452020'),
+	('Semiconductors & Semiconductor Equipment', 'INDUSTRY', '453010'),
+	('Diversified Telecommunication Services', 'INDUSTRY', '501010'),
+	('Media', 'INDUSTRY', '502010'),
+	('Interactive Media & Services', 'INDUSTRY', '502030'),
+	('Electric Utilities', 'INDUSTRY', '551010'),
+	('Water Utilities', 'INDUSTRY', '551040'),
+	('Equity Real Estate Investment Trusts (REITs)', 'INDUSTRY', '601010')
+) as child("name", "level", code)
+INNER JOIN public.sectors as s
+ON s.code = left(child.code, 4);
+
+-- Populate database with the sector labels of the providers. The STOCKFY
+-- labels are the ones given by the API to the assets without a provider
+-- classification.
+INSERT INTO
+	public.sector_mappings (provider, label, sector_id)
+SELECT
+	m.provider, m.label, s.id
+FROM (VALUES
+	('FINNHUB', 'Aerospace & Defense', '201010'),
+	('FINNHUB', 'Airlines', '203020'),
+	('FINNHUB', 'Automobiles', '251020'),
+	('FINNHUB', 'Banking', '401010'),
+	('FINNHUB', 'Beverages', '302010'),
+	('FINNHUB', 'Biotechnology', '352010'),
+	('FINNHUB', 'Chemicals', '151010'),
+	('FINNHUB', 'Communications', '452010'),
+	('FINNHUB', 'Energy', '101020'),
+	('FINNHUB', 'Financial Services', '402030'),
+	('FINNHUB', 'Food Products', '302020'),
+	('FINNHUB', 'Health Care', '351020'),
+	('FINNHUB', 'Hotels, Restaurants & Leisure', '253010'),
+	('FINNHUB', 'Insurance', '403010'),
+	('FINNHUB', 'Machinery', '201060'),
+	('FINNHUB', 'Media', '502010'),
+	('FINNHUB', 'Metals & Mining', '151040'),
+	('FINNHUB', 'Paper & Forest', '151050'),
+	('FINNHUB', 'Pharmaceuticals', '352020'),
+	('FINNHUB', 'Real Estate', '601010'),
+	('FINNHUB', 'Retail', '255020'),
+	('FINNHUB', 'Road & Rail', '203040'),
+	('FINNHUB', 'Semiconductors', '453010'),
+	('FINNHUB', 'Technology', '451030'),
+	('FINNHUB', 'Telecommunication', '501010'),
+	('FINNHUB', 'Tobacco', '302030'),
+	('FINNHUB', 'Utilities', '551010'),
+	('STOCKFY', 'Blend', '80'),
+	('STOCKFY', 'Real Estate', '60'),
+	('STOCKFY', 'Cryptocurrency', '85'),
+	('STOCKFY', 'Fixed Income', '90')
+) as m(provider, label, code)
+INNER JOIN public.sectors as s
+ON s.code = m.code;
 
 -- Populate database with important datas regarding the asset types
 INSERT INTO
@@ -161,7 +601,12 @@ VALUES
 	('STOCK', 'Ações Brasil', 'BR'),
 	('STOCK', 'Ações EUA', 'US'),
 	('REIT', 'REITs', 'US'),
-	('FII', 'Fundos Imobiliários', 'BR');
+	('FII', 'Fundos Imobiliários', 'BR'),
+	('CRYPTO', 'Criptomoedas', 'CRYPTO'),
+	('FIXED_INCOME', 'Renda Fixa', 'BR'),
+	('OPTION', 'Opções', 'BR'),
+	('STOCK', 'BDRs', 'BDR'),
+	('ETF', 'BDRs de ETFs', 'BDR');
 
 -- -- Populate database with initial Brokerage Firms information
 INSERT INTO
@@ -170,4 +615,6 @@ VALUES
 	('Clear', 'Clear Corretora', 'BR'),
 	('Rico', 'Rico Corretora - Grupo XP', 'BR'),
 	('Passfolio', 'Passfolio Securities', 'US'),
-	('Avenue', 'Avenue Securities', 'US');
\ No newline at end of file
+	('Avenue', 'Avenue Securities', 'US'),
+	('Binance', 'Binance', 'CRYPTO'),
+	('Mercado Bitcoin', 'Mercado Bitcoin Servicos Digitais', 'CRYPTO');
\ No newline at end of file
@@ -83,81 +83,102 @@ VALUES
      WHERE u.uid = 'TestAdminID'
     ));
 
+-- Insert Brokerage Accounts
+INSERT INTO
+    public.brokerage_accounts (user_uid, brokerage_id, nickname)
+VALUES
+    ('TestAdminID', (
+     SELECT b.id FROM brokerages as b WHERE b.name = 'Avenue'), 'Avenue'),
+    ('TestAdminID', (
+     SELECT b.id FROM brokerages as b WHERE b.name = 'Clear'), 'Clear');
+
 -- Insert Orders
 INSERT INTO
-    public.orders (asset_id, user_uid, brokerage_id, quantity, price, currency,
-        order_type, "date")
+    public.orders (asset_id, user_uid, brokerage_id, account_id, quantity, price,
+        currency, order_type, "date")
 VALUES
     (
      (SELECT a.id FROM assets as a WHERE a.symbol = 'ITUB4'), (
      SELECT u.uid FROM users as u WHERE u.uid = 'TestAdminID'), (
-     SELECT b.id FROM brokerages as b WHERE b.name = 'Avenue'), 5, 20.39, 'BRL',
+     SELECT b.id FROM brokerages as b WHERE b.name = 'Avenue'), (
+     SELECT ba.id FROM brokerage_accounts as ba WHERE ba.nickname = 'Avenue'), 5, 20.39, 'BRL',
      'buy', '2021-10-05'
     ),
     (
      (SELECT a.id FROM assets as a WHERE a.symbol = 'ITUB4'), (
      SELECT u.uid FROM users as u WHERE u.uid = 'TestAdminID'), (
-     SELECT b.id FROM brokerages as b WHERE b.name = 'Avenue'), 4, 29.39, 'BRL',
+     SELECT b.id FROM brokerages as b WHERE b.name = 'Avenue'), (
+     SELECT ba.id FROM brokerage_accounts as ba WHERE ba.nickname = 'Avenue'), 4, 29.39, 'BRL',
      'buy', '2019-12-06'
     ),
     (
      (SELECT a.id FROM assets as a WHERE a.symbol = 'ITUB4'), (
      SELECT u.uid FROM users as u WHERE u.uid = 'TestAdminID'), (
-     SELECT b.id FROM brokerages as b WHERE b.name = 'Avenue'), -2, 19.1, 'BRL',
+     SELECT b.id FROM brokerages as b WHERE b.name = 'Avenue'), (
+     SELECT ba.id FROM brokerage_accounts as ba WHERE ba.nickname = 'Avenue'), -2, 19.1, 'BRL',
      'sell', '2020-04-01'
     ),
     (
      (SELECT a.id FROM assets as a WHERE a.symbol = 'ITUB4'), (
      SELECT u.uid FROM users as u WHERE u.uid = 'TestAdminID'), (
-     SELECT b.id FROM brokerages as b WHERE b.name = 'Avenue'), 2, 20.05, 'BRL',
+     SELECT b.id FROM brokerages as b WHERE b.name = 'Avenue'), (
+     SELECT ba.id FROM brokerage_accounts as ba WHERE ba.nickname = 'Avenue'), 2, 20.05, 'BRL',
      'buy', '2020-04-20'
     ),
     (
      (SELECT a.id FROM assets as a WHERE a.symbol = 'ITUB4'), (
      SELECT u.uid FROM users as u WHERE u.uid = 'TestAdminID'), (
-     SELECT b.id FROM brokerages as b WHERE b.name = 'Avenue'), 8, 22.20, 'BRL',
+     SELECT b.id FROM brokerages as b WHERE b.name = 'Avenue'), (
+     SELECT ba.id FROM brokerage_accounts as ba WHERE ba.nickname = 'Avenue'), 8, 22.20, 'BRL',
      'buy', '2021-08-10'
     ),
     (
      (SELECT a.id FROM assets as a WHERE a.symbol = 'ITUB4'), (
      SELECT u.uid FROM users as u WHERE u.uid = 'TestAdminID'), (
-     SELECT b.id FROM brokerages as b WHERE b.name = 'Avenue'), 12, 25.58, 'BRL',
+     SELECT b.id FROM brokerages as b WHERE b.name = 'Avenue'), (
+     SELECT ba.id FROM brokerage_accounts as ba WHERE ba.nickname = 'Avenue'), 12, 25.58, 'BRL',
      'buy', '2021-09-13'
     ),
     (
      (SELECT a.id FROM assets as a WHERE a.symbol = 'EGIE3'), (
      SELECT u.uid FROM users as u WHERE u.uid = 'TestAdminID'), (
-     SELECT b.id FROM brokerages as b WHERE b.name = 'Avenue'), 8, 22.20, 'BRL',
+     SELECT b.id FROM brokerages as b WHERE b.name = 'Avenue'), (
+     SELECT ba.id FROM brokerage_accounts as ba WHERE ba.nickname = 'Avenue'), 8, 22.20, 'BRL',
      'buy', '2021-08-10'
     ),
     (
      (SELECT a.id FROM assets as a WHERE a.symbol = 'EGIE3'), (
      SELECT u.uid FROM users as u WHERE u.uid = 'TestAdminID'), (
-     SELECT b.id FROM brokerages as b WHERE b.name = 'Clear'), 12, 25.58, 'BRL',
+     SELECT b.id FROM brokerages as b WHERE b.name = 'Clear'), (
+     SELECT ba.id FROM brokerage_accounts as ba WHERE ba.nickname = 'Clear'), 12, 25.58, 'BRL',
      'buy', '2021-09-13'
     ),
         (
      (SELECT a.id FROM assets as a WHERE a.symbol = 'EGIE3'), (
      SELECT u.uid FROM users as u WHERE u.uid = 'TestAdminID'), (
-     SELECT b.id FROM brokerages as b WHERE b.name = 'Clear'), 8, 22.20, 'BRL',
+     SELECT b.id FROM brokerages as b WHERE b.name = 'Clear'), (
+     SELECT ba.id FROM brokerage_accounts as ba WHERE ba.nickname = 'Clear'), 8, 22.20, 'BRL',
      'buy', '2021-08-10'
     ),
     (
      (SELECT a.id FROM assets as a WHERE a.symbol = 'EGIE3'), (
      SELECT u.uid FROM users as u WHERE u.uid = 'TestAdminID'), (
-     SELECT b.id FROM brokerages as b WHERE b.name = 'Clear'), 12, 25.58, 'BRL',
+     SELECT b.id FROM brokerages as b WHERE b.name = 'Clear'), (
+     SELECT ba.id FROM brokerage_accounts as ba WHERE ba.nickname = 'Clear'), 12, 25.58, 'BRL',
      'buy', '2021-09-13'
     ),
     (
      (SELECT a.id FROM assets as a WHERE a.symbol = 'EGIE3'), (
      SELECT u.uid FROM users as u WHERE u.uid = 'TestAdminID'), (
-     SELECT b.id FROM brokerages as b WHERE b.name = 'Clear'), 8, 22.20, 'BRL',
+     SELECT b.id FROM brokerages as b WHERE b.name = 'Clear'), (
+     SELECT ba.id FROM brokerage_accounts as ba WHERE ba.nickname = 'Clear'), 8, 22.20, 'BRL',
      'buy', '2021-08-10'
     ),
     (
      (SELECT a.id FROM assets as a WHERE a.symbol = 'EGIE3'), (
      SELECT u.uid FROM users as u WHERE u.uid = 'TestAdminID'), (
-     SELECT b.id FROM brokerages as b WHERE b.name = 'Clear'), 12, 25.58, 'BRL',
+     SELECT b.id FROM brokerages as b WHERE b.name = 'Clear'), (
+     SELECT ba.id FROM brokerage_accounts as ba WHERE ba.nickname = 'Clear'), 12, 25.58, 'BRL',
      'buy', '2021-09-13'
     );
 
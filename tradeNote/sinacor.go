@@ -0,0 +1,300 @@
+package tradenote
+
+import (
+	"math"
+	"regexp"
+	"stockfyApi/entity"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layout of the dates in the trade notes.
+const noteDateLayout = "02/01/2006"
+
+// Names of the brokerages in the header of their trade notes.
+var brokerageHeaders = map[string]string{
+	"CLEAR CORRETORA":    "Clear",
+	"RICO INVESTIMENTOS": "Rico",
+}
+
+// Labels of the costs in the financial summary of the trade notes. The totals
+// of the summary are not costs.
+var feeLabels = []string{"TAXA DE LIQUIDACAO", "TAXA DE REGISTRO",
+	"TAXA DE TERMO/OPCOES", "TAXA A.N.A.", "EMOLUMENTOS", "TAXA OPERACIONAL",
+	"CORRETAGEM", "EXECUCAO", "TAXA DE CUSTODIA", "IMPOSTOS", "ISS", "OUTROS",
+	"OUTRAS"}
+
+// Digits at the end of the B3 symbols for each share class.
+var shareClassSuffixes = map[string]string{
+	"ON":  "3",
+	"PN":  "4",
+	"PNA": "5",
+	"PNB": "6",
+	"UNT": "11",
+	"CI":  "11",
+}
+
+var (
+	noteHeaderRegex = regexp.MustCompile(`^NR\. ?NOTA\b`)
+	noteNumberRegex = regexp.MustCompile(
+		`^(\d+) \d+ (\d{2}/\d{2}/\d{4})$`)
+	tradeRegex = regexp.MustCompile(`^(?:1-BOVESPA|B3 RV LISTADO) ([CV]) ` +
+		`(VISTA|FRACIONARIO|OPCAO DE COMPRA|OPCAO DE VENDA|EXERC OPC COMPRA|` +
+		`EXERC OPC VENDA|TERMO) (?:(\d{2}/\d{2}) )?(.+?) ` +
+		`(?:(#\S*|[DFBAHXPYLTI28]) )?([\d.]+) ([\d.]+,\d+) ([\d.]+,\d{2}) ([DC])$`)
+	settlementRegex = regexp.MustCompile(
+		`LIQUIDO PARA (\d{2}/\d{2}/\d{4}) ([\d.]+,\d{2}) ([DC])`)
+	withheldTaxRegex = regexp.MustCompile(
+		`I\.R\.R\.F\..* ([\d.]+,\d{2})(?: [DC])?$`)
+	symbolRegex = regexp.MustCompile(`^([A-Z]{4}\d{1,2})F?$`)
+	feeRegexes  = feeLabelRegexes()
+)
+
+var accentReplacer = strings.NewReplacer("Á", "A", "À", "A", "Â", "A", "Ã",
+	"A", "É", "E", "Ê", "E", "Í", "I", "Ó", "O", "Ô", "O", "Õ", "O", "Ú", "U",
+	"Ç", "C")
+
+// ParseSinacor reads the trade notes in the SINACOR layout, used by most of
+// the brazilian brokerages, from the text extracted of their PDF. The pages of
+// a note are joined by the number of the note. The net amount of each note is
+// checked against its orders, fees and withheld tax.
+func ParseSinacor(text string) ([]entity.TradeNote, error) {
+	var notes []*entity.TradeNote
+	var note *entity.TradeNote
+	notesByNumber := map[string]*entity.TradeNote{}
+	settled := map[string]bool{}
+	expectNumber := false
+
+	for _, rawLine := range strings.Split(text, "\n") {
+		line := normalizeLine(rawLine)
+		if line == "" {
+			continue
+		}
+
+		if noteHeaderRegex.MatchString(line) {
+			expectNumber = true
+			continue
+		}
+
+		if expectNumber {
+			expectNumber = false
+			match := noteNumberRegex.FindStringSubmatch(line)
+			if match == nil {
+				note = nil
+				continue
+			}
+
+			tradeDate, err := time.Parse(noteDateLayout, match[2])
+			if err != nil {
+				return nil, entity.ErrInvalidTradeNote
+			}
+
+			note = notesByNumber[match[1]]
+			if note == nil {
+				note = &entity.TradeNote{Number: match[1], TradeDate: tradeDate}
+				notesByNumber[match[1]] = note
+				notes = append(notes, note)
+			}
+			continue
+		}
+
+		if note == nil {
+			continue
+		}
+
+		if note.Brokerage == "" {
+			note.Brokerage = searchBrokerage(line)
+		}
+
+		if match := tradeRegex.FindStringSubmatch(line); match != nil {
+			note.Orders = append(note.Orders, newTradeNoteOrder(match))
+			continue
+		}
+
+		if match := settlementRegex.FindStringSubmatch(line); match != nil {
+			settlementDate, err := time.Parse(noteDateLayout, match[1])
+			if err != nil {
+				return nil, entity.ErrInvalidTradeNote
+			}
+
+			note.SettlementDate = settlementDate
+			note.NetAmount = parseNoteNumber(match[2])
+			if match[3] == "D" {
+				note.NetAmount = -note.NetAmount
+			}
+			settled[note.Number] = true
+			continue
+		}
+
+		if match := withheldTaxRegex.FindStringSubmatch(line); match != nil {
+			note.WithheldTax = parseNoteNumber(match[1])
+			continue
+		}
+
+		for _, feeRegex := range feeRegexes {
+			if match := feeRegex.FindStringSubmatch(line); match != nil {
+				note.Fees = roundCents(note.Fees + parseNoteNumber(match[1]))
+			}
+		}
+	}
+
+	if notes == nil {
+		return nil, entity.ErrInvalidTradeNote
+	}
+
+	var tradeNotes []entity.TradeNote
+	for _, note := range notes {
+		if note.Orders == nil || !settled[note.Number] {
+			return nil, entity.ErrInvalidTradeNote
+		}
+
+		if err := splitNoteCosts(note); err != nil {
+			return nil, err
+		}
+
+		tradeNotes = append(tradeNotes, *note)
+	}
+
+	return tradeNotes, nil
+}
+
+func newTradeNoteOrder(match []string) entity.TradeNoteOrder {
+	order := entity.TradeNoteOrder{
+		Market:        match[2],
+		OrderType:     "buy",
+		Specification: match[4],
+		Quantity:      parseNoteNumber(match[6]),
+		Price:         parseNoteNumber(match[7]),
+		Amount:        parseNoteNumber(match[8]),
+	}
+
+	if match[1] == "V" {
+		order.OrderType = "sell"
+		order.Quantity = -order.Quantity
+	}
+
+	specification := strings.Fields(match[4])
+
+	// The options are identified by their symbol
+	if strings.HasPrefix(order.Market, "OPCAO") ||
+		strings.HasPrefix(order.Market, "EXERC") {
+		order.Symbol = specification[0]
+		return order
+	}
+
+	for i, field := range specification {
+		if symbol := symbolRegex.FindStringSubmatch(field); symbol != nil {
+			order.Symbol = symbol[1]
+			return order
+		}
+
+		if suffix, ok := shareClassSuffixes[field]; ok && i > 0 {
+			order.Company = strings.Join(specification[:i], " ")
+			order.SymbolSuffix = suffix
+			return order
+		}
+	}
+
+	order.Company = match[4]
+
+	return order
+}
+
+// splitNoteCosts splits the fees of the note between its orders and the
+// withheld tax between its sell orders, in proportion to their amount.
+func splitNoteCosts(note *entity.TradeNote) error {
+	var netAmount float64
+	amounts := make([]float64, len(note.Orders))
+	sellAmounts := make([]float64, len(note.Orders))
+
+	for i, order := range note.Orders {
+		amounts[i] = order.Amount
+		if order.OrderType == "sell" {
+			sellAmounts[i] = order.Amount
+			netAmount += order.Amount
+		} else {
+			netAmount -= order.Amount
+		}
+	}
+
+	netAmount -= note.Fees + note.WithheldTax
+	if math.Abs(netAmount-note.NetAmount) > 0.005 {
+		return entity.ErrInvalidTradeNoteTotal
+	}
+
+	fees := splitAmount(note.Fees, amounts)
+	withheldTaxes := splitAmount(note.WithheldTax, sellAmounts)
+	for i := range note.Orders {
+		note.Orders[i].Fees = fees[i]
+		note.Orders[i].WithheldTax = withheldTaxes[i]
+	}
+
+	return nil
+}
+
+// splitAmount splits the amount in proportion to the weights, rounded to the
+// cents. The rounding difference goes to the last weighted part.
+func splitAmount(amount float64, weights []float64) []float64 {
+	var totalWeight, splitTotal float64
+	parts := make([]float64, len(weights))
+	last := -1
+
+	for i, weight := range weights {
+		totalWeight += weight
+		if weight > 0 {
+			last = i
+		}
+	}
+
+	if last == -1 {
+		return parts
+	}
+
+	for i, weight := range weights {
+		parts[i] = roundCents(amount * weight / totalWeight)
+		splitTotal += parts[i]
+	}
+	parts[last] = roundCents(parts[last] + amount - splitTotal)
+
+	return parts
+}
+
+func searchBrokerage(line string) string {
+	for header, brokerage := range brokerageHeaders {
+		if strings.Contains(line, header) {
+			return brokerage
+		}
+	}
+
+	return ""
+}
+
+func feeLabelRegexes() []*regexp.Regexp {
+	var regexes []*regexp.Regexp
+	for _, label := range feeLabels {
+		regexes = append(regexes, regexp.MustCompile(`(?:^| )`+
+			regexp.QuoteMeta(label)+` ([\d.]+,\d{2})(?: [DC])?(?: |$)`))
+	}
+
+	return regexes
+}
+
+// normalizeLine removes the accents and the repeated spaces of the line, which
+// depend on the tool used to extract the text of the PDF.
+func normalizeLine(line string) string {
+	return accentReplacer.Replace(strings.ToUpper(strings.Join(
+		strings.Fields(line), " ")))
+}
+
+// parseNoteNumber reads the numbers of the notes, like 2.550,00.
+func parseNoteNumber(number string) float64 {
+	parsed, _ := strconv.ParseFloat(strings.Replace(
+		strings.ReplaceAll(number, ".", ""), ",", ".", 1), 64)
+
+	return parsed
+}
+
+func roundCents(value float64) float64 {
+	return math.Round(value*100) / 100
+}
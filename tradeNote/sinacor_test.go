@@ -0,0 +1,138 @@
+package tradenote
+
+import (
+	"io/ioutil"
+	"stockfyApi/entity"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func readNote(t *testing.T, fileName string) string {
+	note, err := ioutil.ReadFile("testdata/" + fileName)
+	assert.Nil(t, err)
+
+	return string(note)
+}
+
+func TestParseSinacor(t *testing.T) {
+	notes, err := ParseSinacor(readNote(t, "clear_note.txt"))
+	assert.Nil(t, err)
+	assert.Equal(t, []entity.TradeNote{
+		{
+			Number:         "123456",
+			Brokerage:      "Clear",
+			TradeDate:      entity.StringToTime("2021-10-05"),
+			SettlementDate: entity.StringToTime("2021-10-07"),
+			Fees:           0.87,
+			NetAmount:      -2410.37,
+			Orders: []entity.TradeNoteOrder{
+				{
+					Market:        "VISTA",
+					OrderType:     "buy",
+					Specification: "ITAUUNIBANCO PN N1",
+					Company:       "ITAUUNIBANCO",
+					SymbolSuffix:  "4",
+					Quantity:      100,
+					Price:         25.50,
+					Amount:        2550,
+					Fees:          0.82,
+				},
+				{
+					Market:        "FRACIONARIO",
+					OrderType:     "sell",
+					Specification: "PETROBRAS PN N2",
+					Company:       "PETROBRAS",
+					SymbolSuffix:  "4",
+					Quantity:      -5,
+					Price:         28.10,
+					Amount:        140.50,
+					Fees:          0.05,
+				},
+			},
+		},
+	}, notes)
+}
+
+func TestParseSinacorPages(t *testing.T) {
+	notes, err := ParseSinacor(readNote(t, "rico_note.txt"))
+	assert.Nil(t, err)
+	assert.Equal(t, []entity.TradeNote{
+		{
+			Number:         "7890123",
+			Brokerage:      "Rico",
+			TradeDate:      entity.StringToTime("2021-11-08"),
+			SettlementDate: entity.StringToTime("2021-11-10"),
+			Fees:           9.44,
+			WithheldTax:    1.20,
+			NetAmount:      18939.36,
+			Orders: []entity.TradeNoteOrder{
+				{
+					Market:        "VISTA",
+					OrderType:     "sell",
+					Specification: "VALE ON NM",
+					Company:       "VALE",
+					SymbolSuffix:  "3",
+					Quantity:      -300,
+					Price:         80,
+					Amount:        24000,
+					Fees:          7.80,
+					WithheldTax:   1.20,
+				},
+				{
+					Market:        "VISTA",
+					OrderType:     "buy",
+					Specification: "FII XP LOG XPLG11 CI",
+					Symbol:        "XPLG11",
+					Quantity:      10,
+					Price:         105,
+					Amount:        1050,
+					Fees:          0.34,
+				},
+				{
+					Market:        "VISTA",
+					OrderType:     "buy",
+					Specification: "BRADESCO PN N1",
+					Company:       "BRADESCO",
+					SymbolSuffix:  "4",
+					Quantity:      200,
+					Price:         20,
+					Amount:        4000,
+					Fees:          1.30,
+				},
+			},
+		},
+	}, notes)
+
+	// Both notes of the file are read
+	notes, err = ParseSinacor(readNote(t, "clear_note.txt") +
+		readNote(t, "rico_note.txt"))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(notes))
+}
+
+func TestParseSinacorErrors(t *testing.T) {
+	_, err := ParseSinacor("Extrato de conta corrente")
+	assert.Equal(t, entity.ErrInvalidTradeNote, err)
+
+	// The last page of the note is missing
+	ricoNote := readNote(t, "rico_note.txt")
+	firstPage := ricoNote[:strings.LastIndex(ricoNote, "NOTA DE CORRETAGEM")]
+	_, err = ParseSinacor(firstPage)
+	assert.Equal(t, entity.ErrInvalidTradeNote, err)
+
+	// A trade of the note was not read
+	clearNote := strings.Replace(readNote(t, "clear_note.txt"),
+		"1-BOVESPA   V", "1-BOVESPA   X", 1)
+	_, err = ParseSinacor(clearNote)
+	assert.Equal(t, entity.ErrInvalidTradeNoteTotal, err)
+}
+
+func TestSplitAmount(t *testing.T) {
+	assert.Equal(t, []float64{0.33, 0.33, 0.34},
+		splitAmount(1, []float64{1, 1, 1}))
+	assert.Equal(t, []float64{0, 1.20, 0},
+		splitAmount(1.20, []float64{0, 24000, 0}))
+	assert.Equal(t, []float64{0, 0}, splitAmount(1, []float64{0, 0}))
+}
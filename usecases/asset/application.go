@@ -5,6 +5,16 @@ import (
 	externalapi "stockfyApi/externalApi"
 	assettype "stockfyApi/usecases/assetType"
 	"stockfyApi/usecases/general"
+	"sync"
+	"time"
+)
+
+// Maximum number of symbols accepted by AssetVerificationPrices and the
+// maximum number of single price requests sent at the same time to the
+// external APIs.
+const (
+	MaxAssetPricesPerRequest   = 100
+	maxConcurrentPriceRequests = 5
 )
 
 type Application struct {
@@ -42,8 +52,47 @@ func (a *Application) SearchAsset(symbol string) (*entity.Asset, error) {
 	return &asset[0], nil
 }
 
-func (a *Application) DeleteAsset(assetId string) (*entity.Asset, error) {
-	deletedAsset, err := a.repo.Delete(assetId)
+// UpdateAsset changes the fullname, preference or sector of an asset shared by
+// every user. The blank values keep the current ones. The audit log of the
+// admin is stored with the change.
+func (a *Application) UpdateAsset(asset entity.Asset, fullname string,
+	preference *string, sectorId string, auditLog *entity.AuditLog) (
+	*entity.Asset, error) {
+
+	assetUpdate := asset
+	if fullname != "" {
+		assetUpdate.Fullname = fullname
+	}
+
+	if preference != nil {
+		assetUpdate.Preference = preference
+	}
+
+	if sectorId != "" {
+		assetUpdate.Sector = &entity.Sector{Id: sectorId}
+	}
+
+	err := assetUpdate.Validate(asset.AssetType.Type, asset.AssetType.Country)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedAsset, err := a.repo.Update(assetUpdate, auditLog)
+	if err != nil {
+		return nil, err
+	}
+
+	if updatedAsset == nil {
+		return nil, nil
+	}
+
+	return &updatedAsset[0], nil
+}
+
+func (a *Application) DeleteAsset(assetId string,
+	auditLog *entity.AuditLog) (*entity.Asset, error) {
+
+	deletedAsset, err := a.repo.Delete(assetId, auditLog)
 	if err != nil {
 		return nil, err
 	}
@@ -55,6 +104,13 @@ func (a *Application) DeleteAsset(assetId string) (*entity.Asset, error) {
 	return &deletedAsset[0], nil
 }
 
+// SearchAssetsByUser returns all the assets of the user.
+func (a *Application) SearchAssetsByUser(userUid string) ([]entity.Asset,
+	error) {
+
+	return a.repo.SearchAllByUser(userUid)
+}
+
 func (a *Application) SearchAssetByUser(symbol string, userUid string,
 	withOrders bool, withOrderResume bool) (*entity.Asset, error) {
 	orderType := ""
@@ -137,20 +193,28 @@ func (a *Application) AssetVerificationExistence(symbol string, country string,
 		return nil, entity.ErrInvalidApiQuerySymbolBlank
 	}
 
-	if err := general.CountryValidation(country); err != nil {
+	market, err := entity.SearchMarket(country)
+	if err != nil {
 		return nil, err
 	}
 
-	if country == "BR" {
-		symbol = symbol + ".SA"
-		symbolLookup = extApi.AlphaVantageApi.VerifySymbol2(symbol)
+	if market.Provider == entity.ProviderCrypto {
+		if extApi.CryptoApi == nil {
+			return nil, entity.ErrInvalidAssetCryptoProvider
+		}
+		symbolLookup = extApi.CryptoApi.VerifyCryptoSymbol(symbol)
 	} else {
-		symbolLookup = extApi.FinnhubApi.VerifySymbol2(symbol)
+		marketApi := MarketProviderApi(market, extApi)
+		if marketApi == nil {
+			return nil, entity.ErrInvalidMarketProvider
+		}
+		symbolLookup = marketApi.VerifySymbol2(market.ProviderSymbol(symbol))
 	}
 
 	if symbolLookup.Symbol == "" {
 		return nil, entity.ErrInvalidAssetSymbol
 	}
+	symbolLookup.Symbol = market.MarketSymbol(symbolLookup.Symbol)
 
 	return &symbolLookup, nil
 }
@@ -158,8 +222,8 @@ func (a *Application) AssetVerificationExistence(symbol string, country string,
 func (a *Application) AssetVerificationSector(assetType string, symbol string,
 	country string, extInterface ExternalApiRepository) string {
 
-	if country == "BR" {
-		symbol = symbol + ".SA"
+	if market, err := entity.SearchMarket(country); err == nil {
+		symbol = market.ProviderSymbol(symbol)
 	}
 
 	if assetType == "STOCK" {
@@ -167,6 +231,8 @@ func (a *Application) AssetVerificationSector(assetType string, symbol string,
 		return companyOverview["finnhubIndustry"]
 	} else if assetType == "ETF" {
 		return "Blend"
+	} else if assetType == entity.CryptoAssetType {
+		return "Cryptocurrency"
 	} else {
 		return "Real Estate"
 	}
@@ -177,7 +243,8 @@ func (a *Application) AssetVerificationPrice(symbol string, country string,
 
 	var symbolPrice entity.SymbolPrice
 
-	if err := general.CountryValidation(country); err != nil {
+	market, err := entity.SearchMarket(country)
+	if err != nil {
 		return nil, err
 	}
 
@@ -185,16 +252,239 @@ func (a *Application) AssetVerificationPrice(symbol string, country string,
 		return nil, entity.ErrInvalidApiQuerySymbolBlank
 	}
 
-	if country == "BR" {
-		symbol = symbol + ".SA"
-		symbolPrice = extInterface.AlphaVantageApi.GetPrice(symbol)
-	} else {
-		symbolPrice = extInterface.FinnhubApi.GetPrice(symbol)
+	if market.Provider == entity.ProviderCrypto {
+		return a.AssetVerificationCryptoPrice(symbol,
+			entity.DefaultCryptoQuoteCurrency, extInterface)
+	}
+
+	marketApi := MarketProviderApi(market, extInterface)
+	if marketApi == nil {
+		return nil, entity.ErrInvalidMarketProvider
+	}
+
+	symbolPrice = marketApi.GetPrice(market.ProviderSymbol(symbol))
+	if symbolPrice.CurrentPrice == 0 {
+		return nil, entity.ErrInvalidAssetSymbol
+	}
+	symbolPrice.Symbol = market.MarketSymbol(symbolPrice.Symbol)
+
+	return &symbolPrice, nil
+}
+
+// AssetVerificationCryptoPrice returns the price of a cryptocurrency quoted
+// in the given currency.
+func (a *Application) AssetVerificationCryptoPrice(symbol string,
+	currency string, extInterface externalapi.ThirdPartyInterfaces) (
+	*entity.SymbolPrice, error) {
+
+	if symbol == "" {
+		return nil, entity.ErrInvalidApiQuerySymbolBlank
+	}
+
+	if !entity.IsValidCryptoCurrency(currency) {
+		return nil, entity.ErrInvalidCryptoCurrency
 	}
 
+	if extInterface.CryptoApi == nil {
+		return nil, entity.ErrInvalidAssetCryptoProvider
+	}
+
+	symbolPrice := extInterface.CryptoApi.GetCryptoPrice(symbol, currency)
 	if symbolPrice.CurrentPrice == 0 {
 		return nil, entity.ErrInvalidAssetSymbol
 	}
 
 	return &symbolPrice, nil
 }
+
+func (a *Application) AssetVerificationPrices(assets []entity.SymbolPriceQuery,
+	extInterface externalapi.ThirdPartyInterfaces) ([]entity.SymbolPriceResult,
+	error) {
+
+	var cryptoIndexes []int
+	var results []entity.SymbolPriceResult
+
+	// The symbols of each market are quoted together by the market provider
+	marketIndexes := map[string][]int{}
+	marketsInfo := map[string]*entity.Market{}
+
+	if len(assets) == 0 {
+		return nil, entity.ErrInvalidAssetPricesBlank
+	}
+
+	if len(assets) > MaxAssetPricesPerRequest {
+		return nil, entity.ErrInvalidAssetPricesLimit
+	}
+
+	// Remove the duplicated symbols keeping the order of the first appearance
+	// and validate each one of them before any external request
+	seenAssets := map[entity.SymbolPriceQuery]bool{}
+	for _, assetQuery := range assets {
+		if seenAssets[assetQuery] {
+			continue
+		}
+		seenAssets[assetQuery] = true
+
+		result := entity.SymbolPriceResult{
+			Symbol:  assetQuery.Symbol,
+			Country: assetQuery.Country,
+		}
+
+		market, err := entity.SearchMarket(assetQuery.Country)
+		if err != nil {
+			result.Err = err
+		} else if assetQuery.Symbol == "" {
+			result.Err = entity.ErrInvalidApiQuerySymbolBlank
+		} else if market.Provider == entity.ProviderCrypto {
+			cryptoIndexes = append(cryptoIndexes, len(results))
+		} else if MarketProviderApi(market, extInterface) == nil {
+			result.Err = entity.ErrInvalidMarketProvider
+		} else {
+			marketsInfo[market.Country] = market
+			marketIndexes[market.Country] = append(
+				marketIndexes[market.Country], len(results))
+		}
+
+		results = append(results, result)
+	}
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, maxConcurrentPriceRequests)
+
+	for country, indexes := range marketIndexes {
+		wg.Add(1)
+		go func(market *entity.Market, indexes []int) {
+			defer wg.Done()
+			fetchPrices(MarketProviderApi(market, extInterface), market,
+				indexes, results, semaphore)
+		}(marketsInfo[country], indexes)
+	}
+
+	// The cryptocurrencies are quoted in the default currency, since the
+	// providers do not have a batch endpoint for them
+	for _, i := range cryptoIndexes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			results[i].Price, results[i].Err = a.AssetVerificationCryptoPrice(
+				results[i].Symbol, entity.DefaultCryptoQuoteCurrency,
+				extInterface)
+			<-semaphore
+		}(i)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// fetchPrices fills the price of the results in the given indexes. If the
+// external API has a batch endpoint, it is used first and only the symbols
+// not returned by it are requested one by one, limited by the semaphore size.
+func fetchPrices(extInterface ExternalApiRepository, market *entity.Market,
+	indexes []int, results []entity.SymbolPriceResult,
+	semaphore chan struct{}) {
+
+	pendingIndexes := indexes
+
+	if batchInterface, ok := extInterface.(ExternalBatchPriceRepository); ok &&
+		len(indexes) > 0 {
+		var symbols []string
+		for _, i := range indexes {
+			symbols = append(symbols, market.ProviderSymbol(results[i].Symbol))
+		}
+
+		batchPrices := map[string]entity.SymbolPrice{}
+		for _, symbolPrice := range batchInterface.GetPrices(symbols) {
+			symbolPrice.Symbol = market.MarketSymbol(symbolPrice.Symbol)
+			batchPrices[symbolPrice.Symbol] = symbolPrice
+		}
+
+		pendingIndexes = nil
+		for _, i := range indexes {
+			symbolPrice, ok := batchPrices[results[i].Symbol]
+			if !ok || symbolPrice.CurrentPrice == 0 {
+				pendingIndexes = append(pendingIndexes, i)
+				continue
+			}
+
+			results[i].Price = &symbolPrice
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, i := range pendingIndexes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			symbolPrice := extInterface.GetPrice(
+				market.ProviderSymbol(results[i].Symbol))
+			<-semaphore
+
+			if symbolPrice.CurrentPrice == 0 {
+				results[i].Err = entity.ErrInvalidAssetSymbol
+				return
+			}
+			symbolPrice.Symbol = market.MarketSymbol(symbolPrice.Symbol)
+
+			results[i].Price = &symbolPrice
+		}(i)
+	}
+	wg.Wait()
+}
+
+// AssetClosePrice returns the close price of the symbol at the date. It fails
+// when the provider has no quote of that trading day yet, instead of
+// returning the price of another day.
+func (a *Application) AssetClosePrice(symbol string, country string,
+	date time.Time, extInterface externalapi.ThirdPartyInterfaces) (float64,
+	error) {
+
+	market, err := entity.SearchMarket(country)
+	if err != nil {
+		return 0, err
+	}
+
+	if symbol == "" {
+		return 0, entity.ErrInvalidApiQuerySymbolBlank
+	}
+
+	providerApi := MarketProviderApi(market, extInterface)
+	historyApi, ok := providerApi.(ExternalPriceHistoryRepository)
+	if !ok {
+		return 0, entity.ErrInvalidMarketProvider
+	}
+
+	closeDate := date.Format("2006-01-02")
+	for _, price := range historyApi.GetPriceHistory(
+		market.ProviderSymbol(symbol)) {
+		if price.Date.Format("2006-01-02") == closeDate &&
+			price.ClosePrice > 0 {
+			return price.ClosePrice, nil
+		}
+	}
+
+	return 0, entity.ErrInvalidAssetClosePrice
+}
+
+// MarketProviderApi returns the external API that verifies and quotes the
+// symbols of the market, or nil if the provider is not configured.
+func MarketProviderApi(market *entity.Market,
+	extInterface externalapi.ThirdPartyInterfaces) ExternalApiRepository {
+
+	switch market.Provider {
+	case entity.ProviderAlphaVantage:
+		if extInterface.AlphaVantageApi != nil {
+			return extInterface.AlphaVantageApi
+		}
+	case entity.ProviderFinnhub:
+		if extInterface.FinnhubApi != nil {
+			return extInterface.FinnhubApi
+		}
+	}
+
+	return nil
+}
@@ -67,12 +67,95 @@ func TestDeleteAsset(t *testing.T) {
 	assetApp := NewApplication(mockedRepo)
 
 	for _, testCase := range tests {
-		deletedAsset, err := assetApp.DeleteAsset(testCase.assetId)
+		deletedAsset, err := assetApp.DeleteAsset(testCase.assetId, nil)
 		assert.Equal(t, testCase.expectedError, err)
 		assert.Equal(t, testCase.expectedDeletedAsset, deletedAsset)
 	}
 }
 
+func TestUpdateAsset(t *testing.T) {
+	type test struct {
+		asset         entity.Asset
+		fullname      string
+		preference    *string
+		sectorId      string
+		expectedAsset *entity.Asset
+		expectedError error
+	}
+
+	preference := "ON"
+	newPreference := "PN"
+	assetType := entity.AssetType{
+		Id:      "TestAssetTypeID",
+		Type:    "STOCK",
+		Name:    "Ações Brasil",
+		Country: "BR",
+	}
+	asset := entity.Asset{
+		Id:         "TestID",
+		Symbol:     "ITUB4",
+		Preference: &preference,
+		Fullname:   "Itau",
+		AssetType:  &assetType,
+		Sector:     &entity.Sector{Id: "TestSectorID", Name: "Finance"},
+	}
+	unknownAsset := asset
+	unknownAsset.Id = "DO_NOT_EXIST"
+
+	tests := []test{
+		{
+			asset:      asset,
+			fullname:   "Itau Unibanco Holding SA",
+			preference: &newPreference,
+			sectorId:   "TestFinancialsID",
+			expectedAsset: &entity.Asset{
+				Id:         "TestID",
+				Symbol:     "ITUB4",
+				Preference: &newPreference,
+				Fullname:   "Itau Unibanco Holding SA",
+				AssetType:  &assetType,
+				Sector: &entity.Sector{
+					Id:   "TestFinancialsID",
+					Name: "Financials",
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			asset:    asset,
+			fullname: "",
+			expectedAsset: &entity.Asset{
+				Id:         "TestID",
+				Symbol:     "ITUB4",
+				Preference: &preference,
+				Fullname:   "Itau",
+				AssetType:  &assetType,
+				Sector: &entity.Sector{
+					Id:   "TestSectorID",
+					Name: "Financials",
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			asset:         unknownAsset,
+			fullname:      "Itau Unibanco Holding SA",
+			expectedAsset: nil,
+			expectedError: nil,
+		},
+	}
+
+	mockedRepo := NewMockRepo()
+	assetApp := NewApplication(mockedRepo)
+
+	for _, testCase := range tests {
+		updatedAsset, err := assetApp.UpdateAsset(testCase.asset,
+			testCase.fullname, testCase.preference, testCase.sectorId, nil)
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expectedAsset, updatedAsset)
+	}
+}
+
 func TestSearchAsset(t *testing.T) {
 	type test struct {
 		symbol        string
@@ -485,12 +568,29 @@ func TestAssetVerificationExistence(t *testing.T) {
 			expectedSymbolLookup: nil,
 			expectedError:        entity.ErrInvalidCountryCode,
 		},
+		{
+			symbol:  "BTC",
+			country: "CRYPTO",
+			expectedSymbolLookup: &entity.SymbolLookup{
+				Fullname: "Bitcoin",
+				Symbol:   "BTC",
+				Type:     "CRYPTO",
+			},
+			expectedError: nil,
+		},
+		{
+			symbol:               "XXXX",
+			country:              "CRYPTO",
+			expectedSymbolLookup: nil,
+			expectedError:        entity.ErrInvalidAssetSymbol,
+		},
 	}
 
 	mockedDb := NewMockRepo()
 	extApiMocked := externalapi.ThirdPartyInterfaces{
 		FinnhubApi:      NewExternalApi(),
 		AlphaVantageApi: NewExternalApi(),
+		CryptoApi:       NewExternalCryptoApi(),
 	}
 	assetApp := NewApplication(mockedDb)
 
@@ -542,6 +642,12 @@ func TestAssetVerificationSector(t *testing.T) {
 			country:        "US",
 			expectedSector: "Real Estate",
 		},
+		{
+			assetType:      "CRYPTO",
+			symbol:         "BTC",
+			country:        "CRYPTO",
+			expectedSector: "Cryptocurrency",
+		},
 	}
 
 	mockedDb := NewMockRepo()
@@ -597,12 +703,92 @@ func TestAssetVerificationPrice(t *testing.T) {
 			expectedSymbolPrice: nil,
 			expectedError:       entity.ErrInvalidCountryCode,
 		},
+		{
+			symbol:  "BTC",
+			country: "CRYPTO",
+			expectedSymbolPrice: &entity.SymbolPrice{
+				Symbol:       "BTC",
+				CurrentPrice: 61870.44,
+				Currency:     "USD",
+			},
+			expectedError: nil,
+		},
+	}
+
+	mockedDb := NewMockRepo()
+	extApiMocked := externalapi.ThirdPartyInterfaces{
+		FinnhubApi:      NewExternalApi(),
+		AlphaVantageApi: NewExternalApi(),
+		CryptoApi:       NewExternalCryptoApi(),
+	}
+	assetApp := NewApplication(mockedDb)
+
+	for _, testCase := range tests {
+		symbolPrice, err := assetApp.AssetVerificationPrice(testCase.symbol,
+			testCase.country, extApiMocked)
+		assert.Equal(t, testCase.expectedSymbolPrice, symbolPrice)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+func TestAssetVerificationPriceConfiguredMarket(t *testing.T) {
+	type test struct {
+		symbol              string
+		country             string
+		expectedSymbolPrice *entity.SymbolPrice
+		expectedError       error
+	}
+
+	// BDRs are listed in Brazil and quoted by the same provider of the
+	// brazilian assets. The European market has no provider configured.
+	entity.SetMarkets(append(entity.DefaultMarkets,
+		entity.Market{
+			Country:           "BDR",
+			Currencies:        []string{"BRL"},
+			ReferenceCurrency: "USD",
+			SymbolSuffix:      ".SA",
+			Provider:          entity.ProviderAlphaVantage,
+			AssetTypes:        []string{"STOCK"},
+		},
+		entity.Market{
+			Country:          "EU",
+			Currencies:       []string{"EUR"},
+			SymbolSuffix:     ".PA",
+			QuantityDecimals: 0,
+			Provider:         "EURONEXT",
+			AssetTypes:       []string{"STOCK"},
+		},
+	))
+	defer entity.SetMarkets(entity.DefaultMarkets)
+
+	tests := []test{
+		{
+			symbol:  "ITUB3",
+			country: "BDR",
+			expectedSymbolPrice: &entity.SymbolPrice{
+				Symbol:         "ITUB3",
+				CurrentPrice:   29.93,
+				HighPrice:      31.00,
+				LowPrice:       29.56,
+				OpenPrice:      30.99,
+				PrevClosePrice: 30.99,
+				MarketCap:      1478481948,
+			},
+			expectedError: nil,
+		},
+		{
+			symbol:              "MC",
+			country:             "EU",
+			expectedSymbolPrice: nil,
+			expectedError:       entity.ErrInvalidMarketProvider,
+		},
 	}
 
 	mockedDb := NewMockRepo()
 	extApiMocked := externalapi.ThirdPartyInterfaces{
 		FinnhubApi:      NewExternalApi(),
 		AlphaVantageApi: NewExternalApi(),
+		CryptoApi:       NewExternalCryptoApi(),
 	}
 	assetApp := NewApplication(mockedDb)
 
@@ -613,3 +799,205 @@ func TestAssetVerificationPrice(t *testing.T) {
 		assert.Equal(t, testCase.expectedError, err)
 	}
 }
+
+func TestAssetVerificationCryptoPrice(t *testing.T) {
+	type test struct {
+		symbol              string
+		currency            string
+		extApi              externalapi.ThirdPartyInterfaces
+		expectedSymbolPrice *entity.SymbolPrice
+		expectedError       error
+	}
+
+	extApiMocked := externalapi.ThirdPartyInterfaces{
+		CryptoApi: NewExternalCryptoApi(),
+	}
+
+	tests := []test{
+		{
+			symbol:   "BTC",
+			currency: "BRL",
+			extApi:   extApiMocked,
+			expectedSymbolPrice: &entity.SymbolPrice{
+				Symbol:       "BTC",
+				CurrentPrice: 341890.27,
+				Currency:     "BRL",
+			},
+			expectedError: nil,
+		},
+		{
+			symbol:              "XXXX",
+			currency:            "USD",
+			extApi:              extApiMocked,
+			expectedSymbolPrice: nil,
+			expectedError:       entity.ErrInvalidAssetSymbol,
+		},
+		{
+			symbol:              "BTC",
+			currency:            "EUR",
+			extApi:              extApiMocked,
+			expectedSymbolPrice: nil,
+			expectedError:       entity.ErrInvalidCryptoCurrency,
+		},
+		{
+			symbol:              "",
+			currency:            "USD",
+			extApi:              extApiMocked,
+			expectedSymbolPrice: nil,
+			expectedError:       entity.ErrInvalidApiQuerySymbolBlank,
+		},
+		{
+			symbol:              "BTC",
+			currency:            "USD",
+			extApi:              externalapi.ThirdPartyInterfaces{},
+			expectedSymbolPrice: nil,
+			expectedError:       entity.ErrInvalidAssetCryptoProvider,
+		},
+	}
+
+	mockedDb := NewMockRepo()
+	assetApp := NewApplication(mockedDb)
+
+	for _, testCase := range tests {
+		symbolPrice, err := assetApp.AssetVerificationCryptoPrice(
+			testCase.symbol, testCase.currency, testCase.extApi)
+		assert.Equal(t, testCase.expectedSymbolPrice, symbolPrice)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+func TestAssetVerificationPrices(t *testing.T) {
+	type test struct {
+		assets          []entity.SymbolPriceQuery
+		expectedResults []entity.SymbolPriceResult
+		expectedError   error
+	}
+
+	tooManyAssets := make([]entity.SymbolPriceQuery,
+		MaxAssetPricesPerRequest+1)
+
+	tests := []test{
+		{
+			assets: []entity.SymbolPriceQuery{
+				{Symbol: "ITUB3", Country: "BR"},
+				{Symbol: "BBDC4", Country: "BR"},
+				{Symbol: "ITUB3", Country: "BR"},
+				{Symbol: "AAAPDK", Country: "US"},
+				{Symbol: "", Country: "BR"},
+				{Symbol: "ITUB4", Country: "AOS"},
+				{Symbol: "BTC", Country: "CRYPTO"},
+			},
+			expectedResults: []entity.SymbolPriceResult{
+				{
+					Symbol:  "ITUB3",
+					Country: "BR",
+					Price: &entity.SymbolPrice{
+						Symbol:         "ITUB3",
+						CurrentPrice:   29.93,
+						HighPrice:      31.00,
+						LowPrice:       29.56,
+						OpenPrice:      30.99,
+						PrevClosePrice: 30.99,
+						MarketCap:      1478481948,
+					},
+				},
+				{
+					Symbol:  "BBDC4",
+					Country: "BR",
+					Price: &entity.SymbolPrice{
+						Symbol:         "BBDC4",
+						CurrentPrice:   19.21,
+						HighPrice:      19.50,
+						LowPrice:       18.95,
+						OpenPrice:      19.00,
+						PrevClosePrice: 19.02,
+					},
+				},
+				{
+					Symbol:  "AAAPDK",
+					Country: "US",
+					Err:     entity.ErrInvalidAssetSymbol,
+				},
+				{
+					Symbol:  "",
+					Country: "BR",
+					Err:     entity.ErrInvalidApiQuerySymbolBlank,
+				},
+				{
+					Symbol:  "ITUB4",
+					Country: "AOS",
+					Err:     entity.ErrInvalidCountryCode,
+				},
+				{
+					Symbol:  "BTC",
+					Country: "CRYPTO",
+					Price: &entity.SymbolPrice{
+						Symbol:       "BTC",
+						CurrentPrice: 61870.44,
+						Currency:     "USD",
+					},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			assets:          []entity.SymbolPriceQuery{},
+			expectedResults: nil,
+			expectedError:   entity.ErrInvalidAssetPricesBlank,
+		},
+		{
+			assets:          tooManyAssets,
+			expectedResults: nil,
+			expectedError:   entity.ErrInvalidAssetPricesLimit,
+		},
+	}
+
+	mockedDb := NewMockRepo()
+	extApiMocked := externalapi.ThirdPartyInterfaces{
+		FinnhubApi:      NewExternalApi(),
+		AlphaVantageApi: NewExternalBatchApi(),
+		CryptoApi:       NewExternalCryptoApi(),
+	}
+	assetApp := NewApplication(mockedDb)
+
+	for _, testCase := range tests {
+		results, err := assetApp.AssetVerificationPrices(testCase.assets,
+			extApiMocked)
+		assert.Equal(t, testCase.expectedResults, results)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+func TestAssetClosePrice(t *testing.T) {
+	type test struct {
+		symbol        string
+		country       string
+		date          string
+		expectedPrice float64
+		expectedError error
+	}
+
+	tests := []test{
+		{"ITUB3", "BR", "2021-10-15", 29.93, nil},
+		{"ITUB3", "BR", "2021-10-14", 29.47, nil},
+		// The close of a day without quote is not replaced by another day
+		{"ITUB3", "BR", "2021-10-18", 0, entity.ErrInvalidAssetClosePrice},
+		{"AAAPDK", "US", "2021-10-15", 0, entity.ErrInvalidAssetClosePrice},
+		{"", "BR", "2021-10-15", 0, entity.ErrInvalidApiQuerySymbolBlank},
+		{"ITUB4", "AOS", "2021-10-15", 0, entity.ErrInvalidCountryCode},
+	}
+
+	extApiMocked := externalapi.ThirdPartyInterfaces{
+		FinnhubApi:      NewExternalApi(),
+		AlphaVantageApi: NewExternalApi(),
+		CryptoApi:       NewExternalCryptoApi(),
+	}
+	assetApp := NewApplication(NewMockRepo())
+
+	for _, testCase := range tests {
+		closePrice, err := assetApp.AssetClosePrice(testCase.symbol,
+			testCase.country, entity.StringToTime(testCase.date), extApiMocked)
+		assert.Equal(t, testCase.expectedPrice, closePrice)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"stockfyApi/entity"
 	externalapi "stockfyApi/externalApi"
 	assettype "stockfyApi/usecases/assetType"
+	"time"
 )
 
 type Repository interface {
@@ -11,10 +12,13 @@ type Repository interface {
 	Search(symbol string) ([]entity.Asset, error)
 	SearchByUser(symbol string, userUid string, orderType string) (
 		[]entity.Asset, error)
+	SearchAllByUser(userUid string) ([]entity.Asset, error)
 	SearchPerAssetType(assetType string, country string, userUid string,
 		withOrdersInfo bool) []entity.AssetType
 	// SearchByOrderId(orderId string) []entity.Asset
-	Delete(assetId string) ([]entity.Asset, error)
+	Update(assetUpdate entity.Asset, auditLog *entity.AuditLog) (
+		[]entity.Asset, error)
+	Delete(assetId string, auditLog *entity.AuditLog) ([]entity.Asset, error)
 }
 
 type ExternalApiRepository interface {
@@ -23,13 +27,29 @@ type ExternalApiRepository interface {
 	CompanyOverview(symbol string) map[string]string
 }
 
+// ExternalBatchPriceRepository is implemented by the external APIs that are
+// able to return the price of several symbols in a single request.
+type ExternalBatchPriceRepository interface {
+	GetPrices(symbols []string) []entity.SymbolPrice
+}
+
+// ExternalPriceHistoryRepository is implemented by the external APIs that are
+// able to return the daily prices of a symbol.
+type ExternalPriceHistoryRepository interface {
+	GetPriceHistory(symbol string) []entity.SymbolPriceHistory
+}
+
 type UseCases interface {
 	CreateAsset(symbol string, fullname string, preference *string,
 		sectorId string, assetType assettype.AssetType) (entity.Asset, error)
 	SearchAsset(symbol string) (*entity.Asset, error)
-	DeleteAsset(assetId string) (*entity.Asset, error)
+	UpdateAsset(asset entity.Asset, fullname string, preference *string,
+		sectorId string, auditLog *entity.AuditLog) (*entity.Asset, error)
+	DeleteAsset(assetId string, auditLog *entity.AuditLog) (*entity.Asset,
+		error)
 	SearchAssetByUser(symbol string, userUid string, withOrders bool,
 		withOrderResume bool) (*entity.Asset, error)
+	SearchAssetsByUser(userUid string) ([]entity.Asset, error)
 	SearchAssetPerAssetType(assetType string, country string, userUid string,
 		withOrdersInfo bool) (*entity.AssetType, error)
 	AssetPreferenceType(symbol string, country string, assetType string) string
@@ -39,4 +59,11 @@ type UseCases interface {
 		extInterface ExternalApiRepository) string
 	AssetVerificationPrice(symbol string, country string,
 		extInterface externalapi.ThirdPartyInterfaces) (*entity.SymbolPrice, error)
+	AssetVerificationCryptoPrice(symbol string, currency string,
+		extInterface externalapi.ThirdPartyInterfaces) (*entity.SymbolPrice, error)
+	AssetVerificationPrices(assets []entity.SymbolPriceQuery,
+		extInterface externalapi.ThirdPartyInterfaces) (
+		[]entity.SymbolPriceResult, error)
+	AssetClosePrice(symbol string, country string, date time.Time,
+		extInterface externalapi.ThirdPartyInterfaces) (float64, error)
 }
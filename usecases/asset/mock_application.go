@@ -7,6 +7,7 @@ import (
 	assettype "stockfyApi/usecases/assetType"
 	"stockfyApi/usecases/general"
 	"strings"
+	"time"
 )
 
 type MockApplication struct {
@@ -56,7 +57,31 @@ func (a *MockApplication) SearchAsset(symbol string) (*entity.Asset, error) {
 
 }
 
-func (a *MockApplication) DeleteAsset(assetId string) (*entity.Asset, error) {
+func (a *MockApplication) UpdateAsset(asset entity.Asset, fullname string,
+	preference *string, sectorId string, auditLog *entity.AuditLog) (
+	*entity.Asset, error) {
+
+	if asset.Id == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown repository error")
+	}
+
+	if fullname != "" {
+		asset.Fullname = fullname
+	}
+
+	if preference != nil {
+		asset.Preference = preference
+	}
+
+	if sectorId != "" {
+		asset.Sector = &entity.Sector{Id: sectorId, Name: "Test Sector"}
+	}
+
+	return &asset, nil
+}
+
+func (a *MockApplication) DeleteAsset(assetId string,
+	auditLog *entity.AuditLog) (*entity.Asset, error) {
 	preference := "ON"
 
 	switch assetId {
@@ -74,6 +99,34 @@ func (a *MockApplication) DeleteAsset(assetId string) (*entity.Asset, error) {
 	}
 }
 
+func (a *MockApplication) SearchAssetsByUser(userUid string) ([]entity.Asset,
+	error) {
+
+	if userUid == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown repository error")
+	}
+
+	preference := "TestPref"
+	return []entity.Asset{
+		{
+			Id:         "TestID",
+			Symbol:     "TEST3",
+			Preference: &preference,
+			Fullname:   "Test Name",
+			AssetType: &entity.AssetType{
+				Id:      "TestAssetTypeID",
+				Type:    "STOCK",
+				Name:    "Test Stocks",
+				Country: "BR",
+			},
+			Sector: &entity.Sector{
+				Id:   "TestSectorID",
+				Name: "Test Sector",
+			},
+		},
+	}, nil
+}
+
 func (a *MockApplication) SearchAssetByUser(symbol string, userUid string,
 	withOrders bool, withOrderResume bool) (*entity.Asset, error) {
 
@@ -401,6 +454,11 @@ func (a *MockApplication) AssetVerificationPrice(symbol string, country string,
 		return nil, entity.ErrInvalidApiQuerySymbolBlank
 	}
 
+	if country == entity.CryptoCountry {
+		return a.AssetVerificationCryptoPrice(symbol,
+			entity.DefaultCryptoQuoteCurrency, extInterface)
+	}
+
 	if country == "BR" {
 		symbol = symbol + ".SA"
 	}
@@ -419,3 +477,73 @@ func (a *MockApplication) AssetVerificationPrice(symbol string, country string,
 		}, nil
 	}
 }
+
+func (a *MockApplication) AssetVerificationCryptoPrice(symbol string,
+	currency string, extInterface externalapi.ThirdPartyInterfaces) (
+	*entity.SymbolPrice, error) {
+
+	if symbol == "" {
+		return nil, entity.ErrInvalidApiQuerySymbolBlank
+	}
+
+	if !entity.IsValidCryptoCurrency(currency) {
+		return nil, entity.ErrInvalidCryptoCurrency
+	}
+
+	if symbol == "UNKNOWN_SYMBOL" {
+		return nil, entity.ErrInvalidAssetSymbol
+	}
+
+	return &entity.SymbolPrice{
+		Symbol:       symbol,
+		CurrentPrice: 61870.44,
+		Currency:     currency,
+	}, nil
+}
+
+func (a *MockApplication) AssetVerificationPrices(
+	assets []entity.SymbolPriceQuery,
+	extInterface externalapi.ThirdPartyInterfaces) ([]entity.SymbolPriceResult,
+	error) {
+
+	var results []entity.SymbolPriceResult
+
+	if len(assets) == 0 {
+		return nil, entity.ErrInvalidAssetPricesBlank
+	}
+
+	if len(assets) > MaxAssetPricesPerRequest {
+		return nil, entity.ErrInvalidAssetPricesLimit
+	}
+
+	seenAssets := map[entity.SymbolPriceQuery]bool{}
+	for _, assetQuery := range assets {
+		if seenAssets[assetQuery] {
+			continue
+		}
+		seenAssets[assetQuery] = true
+
+		symbolPrice, err := a.AssetVerificationPrice(assetQuery.Symbol,
+			assetQuery.Country, extInterface)
+
+		results = append(results, entity.SymbolPriceResult{
+			Symbol:  assetQuery.Symbol,
+			Country: assetQuery.Country,
+			Price:   symbolPrice,
+			Err:     err,
+		})
+	}
+
+	return results, nil
+}
+
+func (a *MockApplication) AssetClosePrice(symbol string, country string,
+	date time.Time, extInterface externalapi.ThirdPartyInterfaces) (float64,
+	error) {
+
+	if symbol == "UNKNOWN_SYMBOL" {
+		return 0, entity.ErrInvalidAssetClosePrice
+	}
+
+	return 29.29, nil
+}
@@ -178,6 +178,10 @@ func (m *MockDb) SearchByUser(symbol string, userUid string, orderType string) (
 	return []entity.Asset{}, nil
 }
 
+func (m *MockDb) SearchAllByUser(userUid string) ([]entity.Asset, error) {
+	return []entity.Asset{}, nil
+}
+
 func (m *MockDb) SearchPerAssetType(assetType string, country string,
 	userUid string, withOrdersInfo bool) []entity.AssetType {
 
@@ -229,7 +233,22 @@ func (m *MockDb) SearchPerAssetType(assetType string, country string,
 	return searchedAssetType
 }
 
-func (m *MockDb) Delete(assetId string) ([]entity.Asset, error) {
+func (m *MockDb) Update(assetUpdate entity.Asset,
+	auditLog *entity.AuditLog) ([]entity.Asset, error) {
+	if assetUpdate.Id == "DO_NOT_EXIST" {
+		return nil, nil
+	}
+
+	assetUpdate.Sector = &entity.Sector{
+		Id:   assetUpdate.Sector.Id,
+		Name: "Financials",
+	}
+
+	return []entity.Asset{assetUpdate}, nil
+}
+
+func (m *MockDb) Delete(assetId string, auditLog *entity.AuditLog) (
+	[]entity.Asset, error) {
 	preference := "PN"
 
 	if assetId == "DO_NOT_EXIST" {
@@ -277,6 +296,27 @@ func (m *MockExternal) GetPrice(symbol string) entity.SymbolPrice {
 	}
 }
 
+func (m *MockExternal) GetPriceHistory(
+	symbol string) []entity.SymbolPriceHistory {
+
+	if symbol != "ITUB3.SA" {
+		return nil
+	}
+
+	return []entity.SymbolPriceHistory{
+		{
+			Symbol:     "ITUB3",
+			Date:       entity.StringToTime("2021-10-14"),
+			ClosePrice: 29.47,
+		},
+		{
+			Symbol:     "ITUB3",
+			Date:       entity.StringToTime("2021-10-15"),
+			ClosePrice: 29.93,
+		},
+	}
+}
+
 func (m *MockExternal) VerifySymbol2(symbol string) entity.SymbolLookup {
 	if symbol != "ITUB4.SA" {
 		return entity.SymbolLookup{}
@@ -289,3 +329,68 @@ func (m *MockExternal) VerifySymbol2(symbol string) entity.SymbolLookup {
 	}
 
 }
+
+type MockExternalBatch struct {
+	MockExternal
+}
+
+func NewExternalBatchApi() *MockExternalBatch {
+	return &MockExternalBatch{}
+}
+
+func (m *MockExternalBatch) GetPrices(symbols []string) []entity.SymbolPrice {
+	var symbolPrices []entity.SymbolPrice
+
+	for _, symbol := range symbols {
+		if symbol == "BBDC4.SA" {
+			symbolPrices = append(symbolPrices, entity.SymbolPrice{
+				Symbol:         "BBDC4",
+				CurrentPrice:   19.21,
+				HighPrice:      19.50,
+				LowPrice:       18.95,
+				OpenPrice:      19.00,
+				PrevClosePrice: 19.02,
+			})
+		}
+	}
+
+	return symbolPrices
+}
+
+type MockExternalCrypto struct {
+}
+
+func NewExternalCryptoApi() *MockExternalCrypto {
+	return &MockExternalCrypto{}
+}
+
+func (m *MockExternalCrypto) VerifyCryptoSymbol(
+	symbol string) entity.SymbolLookup {
+	if symbol != "BTC" {
+		return entity.SymbolLookup{}
+	}
+
+	return entity.SymbolLookup{
+		Fullname: "Bitcoin",
+		Symbol:   "BTC",
+		Type:     "CRYPTO",
+	}
+}
+
+func (m *MockExternalCrypto) GetCryptoPrice(symbol string,
+	currency string) entity.SymbolPrice {
+	if symbol != "BTC" {
+		return entity.SymbolPrice{Symbol: symbol, Currency: currency}
+	}
+
+	currentPrice := 61870.44
+	if currency == "BRL" {
+		currentPrice = 341890.27
+	}
+
+	return entity.SymbolPrice{
+		Symbol:       "BTC",
+		CurrentPrice: currentPrice,
+		Currency:     currency,
+	}
+}
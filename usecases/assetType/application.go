@@ -3,6 +3,7 @@ package assettype
 import (
 	"stockfyApi/entity"
 	"stockfyApi/usecases/general"
+	"strings"
 )
 
 type AssetType struct {
@@ -58,6 +59,86 @@ func (a *Application) SearchAssetType(name string, country string) (
 	return assetTypeReturn, nil
 }
 
+func (a *Application) SearchAssetTypeById(assetTypeId string) (
+	*entity.AssetType, error) {
+
+	assetTypes, err := a.repo.SearchById(assetTypeId)
+	if err != nil {
+		return nil, err
+	}
+
+	if assetTypes == nil {
+		return nil, entity.ErrInvalidAssetTypeId
+	}
+
+	return &assetTypes[0], nil
+}
+
+// CreateAssetType registers a type accepted by the market of the country. Each
+// country has a single asset type of each type. The audit log of the admin is
+// stored with the asset type created.
+func (a *Application) CreateAssetType(assetType string, name string,
+	country string, auditLog *entity.AuditLog) (*entity.AssetType, error) {
+
+	newAssetType, err := entity.NewAssetType(assetType, name, country)
+	if err != nil {
+		return nil, err
+	}
+
+	assetTypes, err := a.repo.Create(*newAssetType, auditLog)
+	if err != nil {
+		return nil, err
+	}
+
+	if assetTypes == nil {
+		return nil, entity.ErrInvalidAssetTypeExist
+	}
+
+	return &assetTypes[0], nil
+}
+
+// UpdateAssetType renames the asset type. The type and the country are kept,
+// since the assets of this type were validated against them.
+func (a *Application) UpdateAssetType(assetTypeId string, name string,
+	auditLog *entity.AuditLog) (*entity.AssetType, error) {
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, entity.ErrInvalidAssetTypeBlank
+	}
+
+	assetTypes, err := a.repo.Update(assetTypeId, name, auditLog)
+	if err != nil {
+		return nil, err
+	}
+
+	if assetTypes == nil {
+		return nil, entity.ErrInvalidAssetTypeId
+	}
+
+	return &assetTypes[0], nil
+}
+
+// DeleteAssetType deletes an asset type without assets.
+func (a *Application) DeleteAssetType(assetTypeId string,
+	auditLog *entity.AuditLog) (*entity.AssetType, error) {
+
+	if _, err := a.SearchAssetTypeById(assetTypeId); err != nil {
+		return nil, err
+	}
+
+	assetTypes, err := a.repo.Delete(assetTypeId, auditLog)
+	if err != nil {
+		return nil, err
+	}
+
+	if assetTypes == nil {
+		return nil, entity.ErrInvalidAssetTypeInUse
+	}
+
+	return &assetTypes[0], nil
+}
+
 func (a *Application) AssetTypeConversionToUseCaseStruct(id string,
 	assetType string, country string) AssetType {
 	return AssetType{
@@ -69,7 +150,9 @@ func (a *Application) AssetTypeConversionToUseCaseStruct(id string,
 
 func (a *Application) AssetTypeConversion(assetType string, country string,
 	symbol string) string {
-	if assetType == "ETP" {
+	if assetType == entity.CryptoAssetType {
+		return entity.CryptoAssetType
+	} else if assetType == "ETP" {
 		return "ETF"
 	} else if assetType == "REIT" {
 		return "REIT"
@@ -81,6 +81,82 @@ func TestSearch(t *testing.T) {
 
 }
 
+func TestCreateAssetType(t *testing.T) {
+	type test struct {
+		assetType         string
+		name              string
+		country           string
+		expectedAssetType *entity.AssetType
+		expectedError     error
+	}
+
+	tests := []test{
+		{
+			assetType: "REIT",
+			name:      "REITs",
+			country:   "US",
+			expectedAssetType: &entity.AssetType{
+				Id:      "TestAssetTypeID",
+				Type:    "REIT",
+				Name:    "REITs",
+				Country: "US",
+			},
+			expectedError: nil,
+		},
+		{
+			assetType:     "STOCK",
+			name:          "Ações EUA",
+			country:       "US",
+			expectedError: entity.ErrInvalidAssetTypeExist,
+		},
+		{
+			assetType:     "FII",
+			name:          "FIIs",
+			country:       "US",
+			expectedError: entity.ErrInvalidAssetTypeName,
+		},
+	}
+
+	astApp := createApp()
+
+	for _, testCase := range tests {
+		assetType, err := astApp.CreateAssetType(testCase.assetType,
+			testCase.name, testCase.country, nil)
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expectedAssetType, assetType)
+	}
+}
+
+func TestUpdateAssetType(t *testing.T) {
+	astApp := createApp()
+
+	assetType, err := astApp.UpdateAssetType("TestAssetTypeID",
+		" REITs EUA ", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "REITs EUA", assetType.Name)
+	assert.Equal(t, "REIT", assetType.Type)
+
+	_, err = astApp.UpdateAssetType("TestAssetTypeID", "", nil)
+	assert.Equal(t, entity.ErrInvalidAssetTypeBlank, err)
+
+	_, err = astApp.UpdateAssetType("UNKNOWN_ID", "REITs EUA", nil)
+	assert.Equal(t, entity.ErrInvalidAssetTypeId, err)
+}
+
+func TestDeleteAssetType(t *testing.T) {
+	astApp := createApp()
+
+	assetType, err := astApp.DeleteAssetType("TestAssetTypeID", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "TestAssetTypeID", assetType.Id)
+
+	_, err = astApp.DeleteAssetType("UNKNOWN_ID", nil)
+	assert.Equal(t, entity.ErrInvalidAssetTypeId, err)
+
+	_, err = astApp.DeleteAssetType("WITH_ASSETS", nil)
+	assert.Equal(t, entity.ErrInvalidAssetTypeInUse, err)
+}
+
 func TestAssetTypeConversion(t *testing.T) {
 	type test struct {
 		assetType                  string
@@ -96,6 +172,12 @@ func TestAssetTypeConversion(t *testing.T) {
 			symbol:                     "VTI",
 			expectedAssetTypeConverted: "ETF",
 		},
+		{
+			assetType:                  "CRYPTO",
+			country:                    "CRYPTO",
+			symbol:                     "BTC",
+			expectedAssetTypeConverted: "CRYPTO",
+		},
 		{
 			assetType:                  "Common Stock",
 			country:                    "US",
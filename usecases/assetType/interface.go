@@ -5,10 +5,24 @@ import "stockfyApi/entity"
 type Repository interface {
 	Search(searchType string, name string,
 		country string) ([]entity.AssetType, error)
+	SearchById(assetTypeId string) ([]entity.AssetType, error)
+	Create(assetType entity.AssetType, auditLog *entity.AuditLog) (
+		[]entity.AssetType, error)
+	Update(assetTypeId string, name string, auditLog *entity.AuditLog) (
+		[]entity.AssetType, error)
+	Delete(assetTypeId string, auditLog *entity.AuditLog) ([]entity.AssetType,
+		error)
 }
 
 type UseCases interface {
 	SearchAssetType(name string, country string) ([]entity.AssetType, error)
+	SearchAssetTypeById(assetTypeId string) (*entity.AssetType, error)
+	CreateAssetType(assetType string, name string, country string,
+		auditLog *entity.AuditLog) (*entity.AssetType, error)
+	UpdateAssetType(assetTypeId string, name string,
+		auditLog *entity.AuditLog) (*entity.AssetType, error)
+	DeleteAssetType(assetTypeId string, auditLog *entity.AuditLog) (
+		*entity.AssetType, error)
 	AssetTypeConversionToUseCaseStruct(id string, assetType string,
 		country string) AssetType
 	AssetTypeConversion(assetType string, country string, symbol string) string
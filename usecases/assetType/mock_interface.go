@@ -1,6 +1,9 @@
 package assettype
 
-import "stockfyApi/entity"
+import (
+	"errors"
+	"stockfyApi/entity"
+)
 
 type Mock struct {
 }
@@ -104,3 +107,53 @@ func (m *Mock) Search(searchType string, name string,
 	}
 
 }
+
+func (m *Mock) SearchById(assetTypeId string) ([]entity.AssetType, error) {
+	switch assetTypeId {
+	case "ERROR_REPOSITORY":
+		return nil, errors.New("Unknown asset type repository error")
+	case "UNKNOWN_ID":
+		return nil, nil
+	}
+
+	return []entity.AssetType{
+		{
+			Id:      assetTypeId,
+			Type:    "REIT",
+			Country: "US",
+			Name:    "REITs",
+		},
+	}, nil
+}
+
+func (m *Mock) Create(assetType entity.AssetType,
+	auditLog *entity.AuditLog) ([]entity.AssetType, error) {
+	if assetType.Type == "STOCK" && assetType.Country == "US" {
+		return nil, nil
+	}
+
+	assetType.Id = "TestAssetTypeID"
+
+	return []entity.AssetType{assetType}, nil
+}
+
+func (m *Mock) Update(assetTypeId string, name string,
+	auditLog *entity.AuditLog) ([]entity.AssetType, error) {
+	assetTypes, err := m.SearchById(assetTypeId)
+	if assetTypes == nil {
+		return nil, err
+	}
+
+	assetTypes[0].Name = name
+
+	return assetTypes, nil
+}
+
+func (m *Mock) Delete(assetTypeId string, auditLog *entity.AuditLog) (
+	[]entity.AssetType, error) {
+	if assetTypeId == "WITH_ASSETS" {
+		return nil, nil
+	}
+
+	return m.SearchById(assetTypeId)
+}
@@ -0,0 +1,35 @@
+package auditlog
+
+import (
+	"stockfyApi/entity"
+	"strings"
+)
+
+type Application struct {
+	repo Repository
+}
+
+//NewApplication create new use case
+func NewApplication(r Repository) *Application {
+	return &Application{
+		repo: r,
+	}
+}
+
+// SearchAuditLogs returns the most recent changes, optionally filtered by the
+// entity type and by the entity.
+func (a *Application) SearchAuditLogs(entityType string, entityId string,
+	limit int) ([]entity.AuditLog, error) {
+
+	entityType = strings.ToUpper(entityType)
+	if entityType != "" && !entity.IsValidAuditEntityType(entityType) {
+		return nil, entity.ErrInvalidAuditLogEntityType
+	}
+
+	limit, err := entity.AuditLogLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.repo.Search(entityType, entityId, limit)
+}
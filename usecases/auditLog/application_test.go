@@ -0,0 +1,35 @@
+package auditlog
+
+import (
+	"stockfyApi/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchAuditLogs(t *testing.T) {
+	type test struct {
+		entityType    string
+		limit         int
+		expectedLen   int
+		expectedError error
+	}
+
+	tests := []test{
+		{entityType: "", limit: 0, expectedLen: 1, expectedError: nil},
+		{entityType: "sector", limit: 10, expectedLen: 1, expectedError: nil},
+		{entityType: "ORDER", limit: 0, expectedLen: 0,
+			expectedError: entity.ErrInvalidAuditLogEntityType},
+		{entityType: "", limit: 1000, expectedLen: 0,
+			expectedError: entity.ErrInvalidAuditLogLimit},
+	}
+
+	auditLogApp := NewApplication(NewMockRepo())
+
+	for _, testCase := range tests {
+		auditLogs, err := auditLogApp.SearchAuditLogs(testCase.entityType, "",
+			testCase.limit)
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Len(t, auditLogs, testCase.expectedLen)
+	}
+}
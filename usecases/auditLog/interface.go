@@ -0,0 +1,13 @@
+package auditlog
+
+import "stockfyApi/entity"
+
+type Repository interface {
+	Search(entityType string, entityId string, limit int) ([]entity.AuditLog,
+		error)
+}
+
+type UseCases interface {
+	SearchAuditLogs(entityType string, entityId string, limit int) (
+		[]entity.AuditLog, error)
+}
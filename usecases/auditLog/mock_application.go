@@ -0,0 +1,29 @@
+package auditlog
+
+import (
+	"stockfyApi/entity"
+	"strings"
+)
+
+type MockApplication struct {
+	repo MockDb
+}
+
+func NewMockApplication() *MockApplication {
+	return &MockApplication{}
+}
+
+func (a *MockApplication) SearchAuditLogs(entityType string, entityId string,
+	limit int) ([]entity.AuditLog, error) {
+
+	entityType = strings.ToUpper(entityType)
+	if entityType != "" && !entity.IsValidAuditEntityType(entityType) {
+		return nil, entity.ErrInvalidAuditLogEntityType
+	}
+
+	if _, err := entity.AuditLogLimit(limit); err != nil {
+		return nil, err
+	}
+
+	return a.repo.Search(entityType, entityId, limit)
+}
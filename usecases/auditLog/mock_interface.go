@@ -0,0 +1,34 @@
+package auditlog
+
+import (
+	"encoding/json"
+	"errors"
+	"stockfyApi/entity"
+)
+
+type MockDb struct {
+}
+
+func NewMockRepo() *MockDb {
+	return &MockDb{}
+}
+
+func (m *MockDb) Search(entityType string, entityId string, limit int) (
+	[]entity.AuditLog, error) {
+	if entityId == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown audit log repository error")
+	}
+
+	return []entity.AuditLog{
+		{
+			Id:         "TestAuditLogID",
+			UserUid:    "TestAdminUID",
+			Action:     entity.AuditActionUpdate,
+			EntityType: entity.AuditEntitySector,
+			EntityId:   "TestSectorID",
+			Before:     json.RawMessage(`{"name":"Finance"}`),
+			After:      json.RawMessage(`{"name":"Financials"}`),
+			CreatedAt:  entity.StringToTime("2021-10-01"),
+		},
+	}, nil
+}
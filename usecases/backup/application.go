@@ -0,0 +1,26 @@
+package backup
+
+import "stockfyApi/entity"
+
+type Application struct {
+	repo Repository
+}
+
+//NewApplication create new use case
+func NewApplication(r Repository) *Application {
+	return &Application{
+		repo: r,
+	}
+}
+
+// RestoreBackup restores the backup in the portfolio of the user, after
+// verifying that it can be restored.
+func (a *Application) RestoreBackup(backup entity.PortfolioBackup,
+	userUid string) (*entity.BackupRestore, error) {
+
+	if err := backup.Validate(); err != nil {
+		return nil, err
+	}
+
+	return a.repo.Restore(backup, userUid)
+}
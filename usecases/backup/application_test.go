@@ -0,0 +1,42 @@
+package backup
+
+import (
+	"errors"
+	"stockfyApi/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestoreBackup(t *testing.T) {
+	backupApp := NewApplication(NewMockRepo())
+
+	backup := entity.PortfolioBackup{
+		Version: entity.BackupVersion,
+		Accounts: []entity.BackupAccount{
+			{Nickname: "Clear", Brokerage: "Clear", Country: "BR"},
+		},
+		Assets: []entity.BackupAsset{
+			{Symbol: "ITUB4", Country: "BR", Fullname: "Itau Unibanco",
+				AssetType: "STOCK", Sector: "Finance"},
+		},
+		Orders: []entity.BackupOrder{
+			{Symbol: "ITUB4", Country: "BR", Account: "Clear", OrderType: "buy",
+				Quantity: 20, Price: 29.29, Currency: "BRL", Date: "2021-10-01"},
+		},
+	}
+
+	restore, err := backupApp.RestoreBackup(backup, "TestUserUID")
+	assert.Nil(t, err)
+	assert.Equal(t, &entity.BackupRestore{Accounts: 1, Assets: 1, Orders: 1},
+		restore)
+
+	restore, err = backupApp.RestoreBackup(backup, "ERROR_REPOSITORY")
+	assert.Nil(t, restore)
+	assert.Equal(t, errors.New("Unknown backup repository error"), err)
+
+	backup.Version = 0
+	restore, err = backupApp.RestoreBackup(backup, "TestUserUID")
+	assert.Nil(t, restore)
+	assert.Equal(t, entity.ErrInvalidBackupVersion, err)
+}
@@ -0,0 +1,13 @@
+package backup
+
+import "stockfyApi/entity"
+
+type Repository interface {
+	Restore(backup entity.PortfolioBackup, userUid string) (
+		*entity.BackupRestore, error)
+}
+
+type UseCases interface {
+	RestoreBackup(backup entity.PortfolioBackup, userUid string) (
+		*entity.BackupRestore, error)
+}
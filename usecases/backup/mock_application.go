@@ -0,0 +1,21 @@
+package backup
+
+import "stockfyApi/entity"
+
+type MockApplication struct {
+	repo MockDb
+}
+
+func NewMockApplication() *MockApplication {
+	return &MockApplication{}
+}
+
+func (a *MockApplication) RestoreBackup(backup entity.PortfolioBackup,
+	userUid string) (*entity.BackupRestore, error) {
+
+	if err := backup.Validate(); err != nil {
+		return nil, err
+	}
+
+	return a.repo.Restore(backup, userUid)
+}
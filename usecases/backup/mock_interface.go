@@ -0,0 +1,28 @@
+package backup
+
+import (
+	"errors"
+	"stockfyApi/entity"
+)
+
+type MockDb struct {
+}
+
+func NewMockRepo() *MockDb {
+	return &MockDb{}
+}
+
+func (m *MockDb) Restore(backup entity.PortfolioBackup, userUid string) (
+	*entity.BackupRestore, error) {
+
+	if userUid == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown backup repository error")
+	}
+
+	return &entity.BackupRestore{
+		Accounts: len(backup.Accounts),
+		Assets:   len(backup.Assets),
+		Orders:   len(backup.Orders),
+		Earnings: len(backup.Earnings),
+	}, nil
+}
@@ -1,6 +1,9 @@
 package brokerage
 
-import "stockfyApi/entity"
+import (
+	"stockfyApi/entity"
+	"strings"
+)
 
 type Application struct {
 	repo Repository
@@ -13,13 +16,17 @@ func NewApplication(r Repository) *Application {
 	}
 }
 
+// SearchBrokerage returns the brokerages available to everyone and the custom
+// brokerages of the user.
 func (a *Application) SearchBrokerage(searchType string, name string,
-	country string) ([]entity.Brokerage, error) {
+	country string, userUid string) ([]entity.Brokerage, error) {
 	var brokerageInfo []entity.Brokerage
 	var err error
 
-	if searchType == "COUNTRY" && country != "BR" && country != "US" {
-		return nil, entity.ErrInvalidCountryCode
+	if searchType == "COUNTRY" {
+		if _, err := entity.SearchMarket(country); err != nil {
+			return nil, err
+		}
 	}
 
 	if searchType == "SINGLE" && name == "" {
@@ -28,13 +35,13 @@ func (a *Application) SearchBrokerage(searchType string, name string,
 
 	switch searchType {
 	case "ALL":
-		brokerageInfo, err = a.repo.Search(searchType)
+		brokerageInfo, err = a.repo.Search(searchType, userUid)
 		break
 	case "SINGLE":
-		brokerageInfo, err = a.repo.Search(searchType, name)
+		brokerageInfo, err = a.repo.Search(searchType, userUid, name)
 		break
 	case "COUNTRY":
-		brokerageInfo, err = a.repo.Search(searchType, country)
+		brokerageInfo, err = a.repo.Search(searchType, userUid, country)
 		break
 	default:
 		return nil, entity.ErrInvalidBrokerageSearchType
@@ -51,3 +58,238 @@ func (a *Application) SearchBrokerage(searchType string, name string,
 	return brokerageInfo, nil
 
 }
+
+func (a *Application) SearchBrokerageById(brokerageId string, userUid string) (
+	*entity.Brokerage, error) {
+
+	brokerageInfo, err := a.repo.SearchById(brokerageId, userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	if brokerageInfo == nil {
+		return nil, entity.ErrInvalidBrokerageId
+	}
+
+	return &brokerageInfo[0], nil
+}
+
+// CreateBrokerage creates a brokerage available to everyone when the user is
+// nil. Otherwise, it creates a custom brokerage visible only to the user. The
+// audit log of the admin, when informed, is stored with the brokerage.
+func (a *Application) CreateBrokerage(name string, fullname string,
+	country string, userUid *string, auditLog *entity.AuditLog) (
+	*entity.Brokerage, error) {
+
+	brokerage, err := entity.NewBrokerage(name, fullname, country, userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	brokerageCreated, err := a.repo.Create(*brokerage, auditLog)
+	if err != nil {
+		return nil, err
+	}
+
+	if brokerageCreated == nil {
+		return nil, entity.ErrInvalidBrokerageExist
+	}
+
+	return &brokerageCreated[0], nil
+}
+
+// UpdateBrokerage changes the informed fields of the brokerage, keeping the
+// current value of the blank ones.
+func (a *Application) UpdateBrokerage(brokerage entity.Brokerage, name string,
+	fullname string, country string, auditLog *entity.AuditLog) (
+	*entity.Brokerage, error) {
+
+	if strings.TrimSpace(name) != "" {
+		brokerage.Name = strings.TrimSpace(name)
+	}
+
+	if strings.TrimSpace(fullname) != "" {
+		brokerage.Fullname = strings.TrimSpace(fullname)
+	}
+
+	if strings.TrimSpace(country) != "" {
+		brokerage.Country = strings.ToUpper(strings.TrimSpace(country))
+	}
+
+	if brokerage.Fullname == "" {
+		brokerage.Fullname = brokerage.Name
+	}
+
+	if err := brokerage.Validate(); err != nil {
+		return nil, err
+	}
+
+	brokerageUpdated, err := a.repo.Update(brokerage, auditLog)
+	if err != nil {
+		return nil, err
+	}
+
+	if brokerageUpdated == nil {
+		return nil, entity.ErrInvalidBrokerageExist
+	}
+
+	return &brokerageUpdated[0], nil
+}
+
+// DeleteBrokerage removes the brokerage. A brokerage used by any account can
+// not be removed, since the orders of these accounts would lose their custody.
+func (a *Application) DeleteBrokerage(brokerageId string,
+	auditLog *entity.AuditLog) (*entity.Brokerage, error) {
+
+	brokerageDeleted, err := a.repo.Delete(brokerageId, auditLog)
+	if err != nil {
+		return nil, err
+	}
+
+	if brokerageDeleted == nil {
+		return nil, entity.ErrInvalidBrokerageInUse
+	}
+
+	return &brokerageDeleted[0], nil
+}
+
+func (a *Application) CreateAccount(nickname string, brokerageId string,
+	userUid string) (*entity.BrokerageAccount, error) {
+
+	account, err := entity.NewBrokerageAccount(nickname, brokerageId, userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	// The accounts can only be opened in the brokerages visible to the user.
+	_, err = a.SearchBrokerageById(brokerageId, userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	accountCreated, err := a.repo.CreateAccount(*account)
+	if err != nil {
+		return nil, err
+	}
+
+	if accountCreated == nil {
+		return nil, entity.ErrInvalidBrokerageAccountExist
+	}
+
+	return &accountCreated[0], nil
+}
+
+// SearchDefaultAccount returns the default account of the user in the
+// brokerage, like DefaultAccount, but without creating it. The account without
+// Id is the one to be created, so it can be created together with other
+// changes, like the imported orders.
+func (a *Application) SearchDefaultAccount(brokerage entity.Brokerage,
+	userUid string) (*entity.BrokerageAccount, error) {
+
+	accounts, err := a.repo.SearchAccountByBrokerage(brokerage.Id, userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	if accounts != nil {
+		return &accounts[0], nil
+	}
+
+	return entity.NewBrokerageAccount(brokerage.Name, brokerage.Id, userUid)
+}
+
+func (a *Application) SearchAccounts(userUid string) (
+	[]entity.BrokerageAccount, error) {
+	return a.repo.SearchAccounts(userUid)
+}
+
+func (a *Application) SearchAccountById(accountId string, userUid string) (
+	*entity.BrokerageAccount, error) {
+
+	account, err := a.repo.SearchAccountById(accountId, userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	if account == nil {
+		return nil, entity.ErrInvalidBrokerageAccount
+	}
+
+	return &account[0], nil
+}
+
+// DefaultAccount returns the oldest account of the user in the brokerage. When
+// the user does not have any account there, an account named after the
+// brokerage is created. It is used by the orders informing only the brokerage.
+func (a *Application) DefaultAccount(brokerage entity.Brokerage,
+	userUid string) (*entity.BrokerageAccount, error) {
+
+	account, err := a.SearchDefaultAccount(brokerage, userUid)
+	if err != nil || account.Id != "" {
+		return account, err
+	}
+
+	accountCreated, err := a.repo.CreateAccount(*account)
+	if err != nil {
+		return nil, err
+	}
+
+	if accountCreated == nil {
+		return nil, entity.ErrInvalidBrokerageAccountExist
+	}
+
+	return &accountCreated[0], nil
+}
+
+func (a *Application) UpdateAccount(accountId string, userUid string,
+	nickname string) (*entity.BrokerageAccount, error) {
+
+	nickname = strings.TrimSpace(nickname)
+	if nickname == "" {
+		return nil, entity.ErrInvalidBrokerageAccountNickname
+	}
+
+	_, err := a.SearchAccountById(accountId, userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	accountUpdated, err := a.repo.UpdateAccount(accountId, userUid, nickname)
+	if err != nil {
+		return nil, err
+	}
+
+	if accountUpdated == nil {
+		return nil, entity.ErrInvalidBrokerageAccountExist
+	}
+
+	return &accountUpdated[0], nil
+}
+
+// DeleteAccount removes an account of the user. An account with orders can
+// not be removed.
+func (a *Application) DeleteAccount(accountId string, userUid string) (
+	*entity.BrokerageAccount, error) {
+
+	_, err := a.SearchAccountById(accountId, userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	accountDeleted, err := a.repo.DeleteAccount(accountId, userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	if accountDeleted == nil {
+		return nil, entity.ErrInvalidBrokerageAccountInUse
+	}
+
+	return &accountDeleted[0], nil
+}
+
+// AccountPositions returns the assets of the user in each brokerage account.
+func (a *Application) AccountPositions(userUid string) (
+	[]entity.AccountPosition, error) {
+	return a.repo.SearchAccountPositions(userUid)
+}
@@ -93,9 +93,312 @@ func TestSearchBrokerage(t *testing.T) {
 
 	for _, testCase := range tests {
 		searchedBrokerage, err := app.SearchBrokerage(testCase.searchType,
-			testCase.name, testCase.country)
+			testCase.name, testCase.country, "TestUserUID")
 		assert.Equal(t, testCase.expectedSearchBrokerage, searchedBrokerage)
 		assert.Equal(t, testCase.expectedError, err)
 	}
 
 }
+
+func TestCreateBrokerage(t *testing.T) {
+	type test struct {
+		name              string
+		country           string
+		userUid           *string
+		expectedBrokerage *entity.Brokerage
+		expectedError     error
+	}
+
+	userUid := "TestUserUID"
+
+	tests := []test{
+		{
+			name:    "My Broker",
+			country: "us",
+			userUid: &userUid,
+			expectedBrokerage: &entity.Brokerage{
+				Id:       "TestBrokerageID",
+				Name:     "My Broker",
+				Fullname: "My Broker",
+				Country:  "US",
+				UserUid:  &userUid,
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "Clear",
+			country:       "BR",
+			expectedError: entity.ErrInvalidBrokerageExist,
+		},
+		{
+			name:          "",
+			country:       "BR",
+			expectedError: entity.ErrInvalidBrokerageBlank,
+		},
+	}
+
+	mocked := NewMockRepo()
+	app := NewApplication(mocked)
+
+	for _, testCase := range tests {
+		brokerage, err := app.CreateBrokerage(testCase.name, "",
+			testCase.country, testCase.userUid, nil)
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expectedBrokerage, brokerage)
+	}
+}
+
+func TestUpdateBrokerage(t *testing.T) {
+	type test struct {
+		name              string
+		fullname          string
+		country           string
+		expectedBrokerage *entity.Brokerage
+		expectedError     error
+	}
+
+	currentBrokerage := entity.Brokerage{
+		Id:       "55555555-ed8b-11eb-9a03-0242ac130003",
+		Name:     "Clear",
+		Fullname: "Clear Corretora",
+		Country:  "BR",
+	}
+
+	tests := []test{
+		{
+			fullname: "Clear Corretora - Grupo XP",
+			expectedBrokerage: &entity.Brokerage{
+				Id:       "55555555-ed8b-11eb-9a03-0242ac130003",
+				Name:     "Clear",
+				Fullname: "Clear Corretora - Grupo XP",
+				Country:  "BR",
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "Rico",
+			expectedError: entity.ErrInvalidBrokerageExist,
+		},
+		{
+			country:       "EU",
+			expectedError: entity.ErrInvalidCountryCode,
+		},
+	}
+
+	mocked := NewMockRepo()
+	app := NewApplication(mocked)
+
+	for _, testCase := range tests {
+		brokerage, err := app.UpdateBrokerage(currentBrokerage, testCase.name,
+			testCase.fullname, testCase.country, nil)
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expectedBrokerage, brokerage)
+	}
+}
+
+func TestDeleteBrokerage(t *testing.T) {
+	mocked := NewMockRepo()
+	app := NewApplication(mocked)
+
+	brokerage, err := app.DeleteBrokerage("WITH_ACCOUNTS", nil)
+	assert.Nil(t, brokerage)
+	assert.Equal(t, entity.ErrInvalidBrokerageInUse, err)
+
+	brokerage, err = app.DeleteBrokerage("TestBrokerageID", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "TestBrokerageID", brokerage.Id)
+}
+
+func TestCreateAccount(t *testing.T) {
+	type test struct {
+		nickname        string
+		brokerageId     string
+		expectedAccount *entity.BrokerageAccount
+		expectedError   error
+	}
+
+	tests := []test{
+		{
+			nickname:    "Clear - Retirement",
+			brokerageId: "55555555-ed8b-11eb-9a03-0242ac130003",
+			expectedAccount: &entity.BrokerageAccount{
+				Id:       "TestAccountID",
+				Nickname: "Clear - Retirement",
+				Brokerage: &entity.Brokerage{
+					Id:      "55555555-ed8b-11eb-9a03-0242ac130003",
+					Name:    "Clear",
+					Country: "BR",
+				},
+				UserUid: "TestUserUID",
+			},
+			expectedError: nil,
+		},
+		{
+			nickname:      "Clear - Retirement",
+			brokerageId:   "UNKNOWN_ID",
+			expectedError: entity.ErrInvalidBrokerageId,
+		},
+		{
+			nickname:      "Rico",
+			brokerageId:   "55556666-ed8b-11eb-9a03-0242ac130003",
+			expectedError: entity.ErrInvalidBrokerageAccountExist,
+		},
+		{
+			nickname:      "",
+			brokerageId:   "55555555-ed8b-11eb-9a03-0242ac130003",
+			expectedError: entity.ErrInvalidBrokerageAccountNickname,
+		},
+	}
+
+	mocked := NewMockRepo()
+	app := NewApplication(mocked)
+
+	for _, testCase := range tests {
+		account, err := app.CreateAccount(testCase.nickname,
+			testCase.brokerageId, "TestUserUID")
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expectedAccount, account)
+	}
+}
+
+func TestDefaultAccount(t *testing.T) {
+	type test struct {
+		brokerage       entity.Brokerage
+		expectedAccount *entity.BrokerageAccount
+	}
+
+	tests := []test{
+		{
+			brokerage: entity.Brokerage{Id: "BrokerageID", Name: "Clear"},
+			expectedAccount: &entity.BrokerageAccount{
+				Id:       "TestAccountID",
+				Nickname: "Clear",
+				Brokerage: &entity.Brokerage{
+					Id:      "BrokerageID",
+					Name:    "Clear",
+					Country: "BR",
+				},
+				UserUid: "TestUserUID",
+			},
+		},
+		{
+			brokerage: entity.Brokerage{Id: "WITHOUT_ACCOUNT", Name: "Inter"},
+			expectedAccount: &entity.BrokerageAccount{
+				Id:       "TestAccountID",
+				Nickname: "Inter",
+				Brokerage: &entity.Brokerage{
+					Id:      "WITHOUT_ACCOUNT",
+					Name:    "Clear",
+					Country: "BR",
+				},
+				UserUid: "TestUserUID",
+			},
+		},
+	}
+
+	mocked := NewMockRepo()
+	app := NewApplication(mocked)
+
+	for _, testCase := range tests {
+		account, err := app.DefaultAccount(testCase.brokerage, "TestUserUID")
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.expectedAccount, account)
+	}
+}
+
+func TestSearchDefaultAccount(t *testing.T) {
+	mocked := NewMockRepo()
+	app := NewApplication(mocked)
+
+	account, err := app.SearchDefaultAccount(entity.Brokerage{
+		Id: "BrokerageID", Name: "Clear"}, "TestUserUID")
+	assert.Nil(t, err)
+	assert.Equal(t, "TestAccountID", account.Id)
+
+	// The account of a brokerage without account is not created
+	account, err = app.SearchDefaultAccount(entity.Brokerage{
+		Id: "WITHOUT_ACCOUNT", Name: "Inter"}, "TestUserUID")
+	assert.Nil(t, err)
+	assert.Equal(t, &entity.BrokerageAccount{
+		Nickname:  "Inter",
+		Brokerage: &entity.Brokerage{Id: "WITHOUT_ACCOUNT"},
+		UserUid:   "TestUserUID",
+	}, account)
+}
+
+func TestUpdateAccount(t *testing.T) {
+	type test struct {
+		accountId        string
+		nickname         string
+		expectedNickname string
+		expectedError    error
+	}
+
+	tests := []test{
+		{
+			accountId:        "TestAccountID",
+			nickname:         " Clear - Retirement ",
+			expectedNickname: "Clear - Retirement",
+			expectedError:    nil,
+		},
+		{
+			accountId:     "TestAccountID",
+			nickname:      " ",
+			expectedError: entity.ErrInvalidBrokerageAccountNickname,
+		},
+		{
+			accountId:     "UNKNOWN_ACCOUNT",
+			nickname:      "Clear",
+			expectedError: entity.ErrInvalidBrokerageAccount,
+		},
+		{
+			accountId:     "TestAccountID",
+			nickname:      "Rico",
+			expectedError: entity.ErrInvalidBrokerageAccountExist,
+		},
+	}
+
+	mocked := NewMockRepo()
+	app := NewApplication(mocked)
+
+	for _, testCase := range tests {
+		account, err := app.UpdateAccount(testCase.accountId, "TestUserUID",
+			testCase.nickname)
+		assert.Equal(t, testCase.expectedError, err)
+		if testCase.expectedError == nil {
+			assert.Equal(t, testCase.expectedNickname, account.Nickname)
+		} else {
+			assert.Nil(t, account)
+		}
+	}
+}
+
+func TestDeleteAccount(t *testing.T) {
+	type test struct {
+		accountId     string
+		expectedError error
+	}
+
+	tests := []test{
+		{
+			accountId:     "TestAccountID",
+			expectedError: nil,
+		},
+		{
+			accountId:     "UNKNOWN_ACCOUNT",
+			expectedError: entity.ErrInvalidBrokerageAccount,
+		},
+		{
+			accountId:     "WITH_ORDERS",
+			expectedError: entity.ErrInvalidBrokerageAccountInUse,
+		},
+	}
+
+	mocked := NewMockRepo()
+	app := NewApplication(mocked)
+
+	for _, testCase := range tests {
+		_, err := app.DeleteAccount(testCase.accountId, "TestUserUID")
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
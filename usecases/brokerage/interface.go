@@ -3,10 +3,52 @@ package brokerage
 import "stockfyApi/entity"
 
 type Repository interface {
-	Search(specificFetch string, args ...string) ([]entity.Brokerage, error)
+	Search(specificFetch string, userUid string, args ...string) (
+		[]entity.Brokerage, error)
+	SearchById(brokerageId string, userUid string) ([]entity.Brokerage, error)
+	Create(brokerage entity.Brokerage, auditLog *entity.AuditLog) (
+		[]entity.Brokerage, error)
+	Update(brokerage entity.Brokerage, auditLog *entity.AuditLog) (
+		[]entity.Brokerage, error)
+	Delete(brokerageId string, auditLog *entity.AuditLog) ([]entity.Brokerage,
+		error)
+	CreateAccount(account entity.BrokerageAccount) ([]entity.BrokerageAccount,
+		error)
+	SearchAccounts(userUid string) ([]entity.BrokerageAccount, error)
+	SearchAccountById(accountId string, userUid string) (
+		[]entity.BrokerageAccount, error)
+	SearchAccountByBrokerage(brokerageId string, userUid string) (
+		[]entity.BrokerageAccount, error)
+	UpdateAccount(accountId string, userUid string, nickname string) (
+		[]entity.BrokerageAccount, error)
+	DeleteAccount(accountId string, userUid string) ([]entity.BrokerageAccount,
+		error)
+	SearchAccountPositions(userUid string) ([]entity.AccountPosition, error)
 }
 
 type UseCases interface {
-	SearchBrokerage(searchType string, name string, country string) (
-		[]entity.Brokerage, error)
+	SearchBrokerage(searchType string, name string, country string,
+		userUid string) ([]entity.Brokerage, error)
+	SearchBrokerageById(brokerageId string, userUid string) (*entity.Brokerage,
+		error)
+	CreateBrokerage(name string, fullname string, country string,
+		userUid *string, auditLog *entity.AuditLog) (*entity.Brokerage, error)
+	UpdateBrokerage(brokerage entity.Brokerage, name string, fullname string,
+		country string, auditLog *entity.AuditLog) (*entity.Brokerage, error)
+	DeleteBrokerage(brokerageId string, auditLog *entity.AuditLog) (
+		*entity.Brokerage, error)
+	CreateAccount(nickname string, brokerageId string, userUid string) (
+		*entity.BrokerageAccount, error)
+	SearchAccounts(userUid string) ([]entity.BrokerageAccount, error)
+	SearchAccountById(accountId string, userUid string) (
+		*entity.BrokerageAccount, error)
+	DefaultAccount(brokerage entity.Brokerage, userUid string) (
+		*entity.BrokerageAccount, error)
+	SearchDefaultAccount(brokerage entity.Brokerage, userUid string) (
+		*entity.BrokerageAccount, error)
+	UpdateAccount(accountId string, userUid string, nickname string) (
+		*entity.BrokerageAccount, error)
+	DeleteAccount(accountId string, userUid string) (*entity.BrokerageAccount,
+		error)
+	AccountPositions(userUid string) ([]entity.AccountPosition, error)
 }
@@ -13,13 +13,14 @@ func NewMockApplication() *MockApplication {
 }
 
 func (a *MockApplication) SearchBrokerage(searchType string, name string,
-	country string) ([]entity.Brokerage, error) {
+	country string, userUid string) ([]entity.Brokerage, error) {
 	var brokerageInfo []entity.Brokerage
 	var err error
 
-	if searchType == "COUNTRY" && country != "BR" && country != "US" &&
-		country != "ERROR_BROKERAGE_SEARCH" {
-		return nil, entity.ErrInvalidCountryCode
+	if searchType == "COUNTRY" && country != "ERROR_BROKERAGE_SEARCH" {
+		if _, err := entity.SearchMarket(country); err != nil {
+			return nil, err
+		}
 	}
 
 	if searchType == "SINGLE" && name == "" {
@@ -98,3 +99,242 @@ func (a *MockApplication) SearchBrokerage(searchType string, name string,
 
 	return brokerageInfo, nil
 }
+
+func (a *MockApplication) SearchBrokerageById(brokerageId string,
+	userUid string) (*entity.Brokerage, error) {
+
+	switch brokerageId {
+	case "UNKNOWN_ID":
+		return nil, entity.ErrInvalidBrokerageId
+	case "ERROR_BROKERAGE_REPO":
+		return nil, errors.New("Unknown error in the brokerage repository")
+	case "CUSTOM_BROKERAGE_ID":
+		return &entity.Brokerage{
+			Id:       brokerageId,
+			Name:     "My Broker",
+			Fullname: "My Broker",
+			Country:  "US",
+			UserUid:  &userUid,
+		}, nil
+	}
+
+	return &entity.Brokerage{
+		Id:       brokerageId,
+		Name:     "Test BR 1",
+		Fullname: "Test BR 1",
+		Country:  "BR",
+	}, nil
+}
+
+func (a *MockApplication) CreateBrokerage(name string, fullname string,
+	country string, userUid *string, auditLog *entity.AuditLog) (
+	*entity.Brokerage, error) {
+
+	brokerage, err := entity.NewBrokerage(name, fullname, country, userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	if brokerage.Name == "Test BR 1" {
+		return nil, entity.ErrInvalidBrokerageExist
+	}
+
+	brokerage.Id = "TestBrokerageID"
+
+	return brokerage, nil
+}
+
+func (a *MockApplication) UpdateBrokerage(brokerage entity.Brokerage,
+	name string, fullname string, country string, auditLog *entity.AuditLog) (
+	*entity.Brokerage, error) {
+
+	if name == "Test BR 2" {
+		return nil, entity.ErrInvalidBrokerageExist
+	}
+
+	if name != "" {
+		brokerage.Name = name
+	}
+
+	if fullname != "" {
+		brokerage.Fullname = fullname
+	}
+
+	if country != "" {
+		brokerage.Country = country
+	}
+
+	if err := brokerage.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &brokerage, nil
+}
+
+func (a *MockApplication) DeleteBrokerage(brokerageId string,
+	auditLog *entity.AuditLog) (*entity.Brokerage, error) {
+
+	if brokerageId == "WITH_ACCOUNTS" {
+		return nil, entity.ErrInvalidBrokerageInUse
+	}
+
+	return &entity.Brokerage{
+		Id:      brokerageId,
+		Name:    "Test BR 1",
+		Country: "BR",
+	}, nil
+}
+
+func (a *MockApplication) CreateAccount(nickname string, brokerageId string,
+	userUid string) (*entity.BrokerageAccount, error) {
+
+	account, err := entity.NewBrokerageAccount(nickname, brokerageId, userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	if brokerageId == "UNKNOWN_ID" {
+		return nil, entity.ErrInvalidBrokerageId
+	}
+
+	if account.Nickname == "Test BR 1" {
+		return nil, entity.ErrInvalidBrokerageAccountExist
+	}
+
+	account.Id = "TestAccountID"
+	account.Brokerage = &entity.Brokerage{
+		Id:      brokerageId,
+		Name:    "Test BR 1",
+		Country: "BR",
+	}
+
+	return account, nil
+}
+
+func (a *MockApplication) SearchAccounts(userUid string) (
+	[]entity.BrokerageAccount, error) {
+
+	return []entity.BrokerageAccount{
+		{
+			Id:       "TestAccountID",
+			Nickname: "Test BR 1",
+			Brokerage: &entity.Brokerage{
+				Id:      "TestBrokerageID3",
+				Name:    "Test BR 1",
+				Country: "BR",
+			},
+			UserUid: userUid,
+		},
+	}, nil
+}
+
+func (a *MockApplication) SearchAccountById(accountId string,
+	userUid string) (*entity.BrokerageAccount, error) {
+
+	if accountId == "UNKNOWN_ACCOUNT" {
+		return nil, entity.ErrInvalidBrokerageAccount
+	}
+
+	if accountId == "US_ACCOUNT" {
+		return &entity.BrokerageAccount{
+			Id:       accountId,
+			Nickname: "Test US 1",
+			Brokerage: &entity.Brokerage{
+				Id:      "TestBrokerageID1",
+				Name:    "Test US 1",
+				Country: "US",
+			},
+			UserUid: userUid,
+		}, nil
+	}
+
+	return &entity.BrokerageAccount{
+		Id:       accountId,
+		Nickname: "Test BR 1",
+		Brokerage: &entity.Brokerage{
+			Id:      "TestBrokerageID3",
+			Name:    "Test BR 1",
+			Country: "BR",
+		},
+		UserUid: userUid,
+	}, nil
+}
+
+func (a *MockApplication) DefaultAccount(brokerage entity.Brokerage,
+	userUid string) (*entity.BrokerageAccount, error) {
+
+	return &entity.BrokerageAccount{
+		Id:        "TestAccountID",
+		Nickname:  brokerage.Name,
+		Brokerage: &brokerage,
+		UserUid:   userUid,
+	}, nil
+}
+
+func (a *MockApplication) SearchDefaultAccount(brokerage entity.Brokerage,
+	userUid string) (*entity.BrokerageAccount, error) {
+
+	return a.DefaultAccount(brokerage, userUid)
+}
+
+func (a *MockApplication) UpdateAccount(accountId string, userUid string,
+	nickname string) (*entity.BrokerageAccount, error) {
+
+	if nickname == "" {
+		return nil, entity.ErrInvalidBrokerageAccountNickname
+	}
+
+	if accountId == "UNKNOWN_ACCOUNT" {
+		return nil, entity.ErrInvalidBrokerageAccount
+	}
+
+	if nickname == "Test BR 1" {
+		return nil, entity.ErrInvalidBrokerageAccountExist
+	}
+
+	return &entity.BrokerageAccount{
+		Id:       accountId,
+		Nickname: nickname,
+		Brokerage: &entity.Brokerage{
+			Id:      "TestBrokerageID3",
+			Name:    "Test BR 1",
+			Country: "BR",
+		},
+		UserUid: userUid,
+	}, nil
+}
+
+func (a *MockApplication) DeleteAccount(accountId string, userUid string) (
+	*entity.BrokerageAccount, error) {
+
+	switch accountId {
+	case "UNKNOWN_ACCOUNT":
+		return nil, entity.ErrInvalidBrokerageAccount
+	case "WITH_ORDERS":
+		return nil, entity.ErrInvalidBrokerageAccountInUse
+	}
+
+	return &entity.BrokerageAccount{
+		Id:       accountId,
+		Nickname: "Test BR 1",
+		UserUid:  userUid,
+	}, nil
+}
+
+func (a *MockApplication) AccountPositions(userUid string) (
+	[]entity.AccountPosition, error) {
+
+	return []entity.AccountPosition{
+		{
+			AccountId:     "TestAccountID",
+			Nickname:      "Test BR 1",
+			BrokerageId:   "TestBrokerageID3",
+			BrokerageName: "Test BR 1",
+			AssetId:       "TestAssetID",
+			Symbol:        "TEST3",
+			Currency:      "BRL",
+			Quantity:      20,
+			Invested:      450.5,
+		},
+	}, nil
+}
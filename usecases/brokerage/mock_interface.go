@@ -11,8 +11,8 @@ func NewMockRepo() *MockDb {
 	return &MockDb{}
 }
 
-func (m *MockDb) Search(specificFetch string, args ...string) (
-	[]entity.Brokerage, error) {
+func (m *MockDb) Search(specificFetch string, userUid string,
+	args ...string) ([]entity.Brokerage, error) {
 
 	switch specificFetch {
 	case "ALL":
@@ -62,3 +62,199 @@ func (m *MockDb) Search(specificFetch string, args ...string) (
 		return nil, entity.ErrInvalidBrokerageSearchType
 	}
 }
+
+func (m *MockDb) SearchById(brokerageId string, userUid string) (
+	[]entity.Brokerage, error) {
+
+	switch brokerageId {
+	case "UNKNOWN_ID":
+		return nil, nil
+	case "CUSTOM_BROKERAGE_ID":
+		return []entity.Brokerage{
+			{
+				Id:       brokerageId,
+				Name:     "My Broker",
+				Fullname: "My Broker",
+				Country:  "US",
+				UserUid:  &userUid,
+			},
+		}, nil
+	}
+
+	return []entity.Brokerage{
+		{
+			Id:       brokerageId,
+			Name:     "Clear",
+			Fullname: "Clear Corretora",
+			Country:  "BR",
+		},
+	}, nil
+}
+
+func (m *MockDb) Create(brokerage entity.Brokerage,
+	auditLog *entity.AuditLog) ([]entity.Brokerage, error) {
+
+	if brokerage.Name == "Clear" {
+		return nil, nil
+	}
+
+	brokerage.Id = "TestBrokerageID"
+
+	return []entity.Brokerage{brokerage}, nil
+}
+
+func (m *MockDb) Update(brokerage entity.Brokerage,
+	auditLog *entity.AuditLog) ([]entity.Brokerage, error) {
+
+	if brokerage.Name == "Rico" {
+		return nil, nil
+	}
+
+	return []entity.Brokerage{brokerage}, nil
+}
+
+func (m *MockDb) Delete(brokerageId string, auditLog *entity.AuditLog) (
+	[]entity.Brokerage, error) {
+	if brokerageId == "WITH_ACCOUNTS" {
+		return nil, nil
+	}
+
+	return []entity.Brokerage{
+		{
+			Id:      brokerageId,
+			Name:    "Clear",
+			Country: "BR",
+		},
+	}, nil
+}
+
+func (m *MockDb) CreateAccount(account entity.BrokerageAccount) (
+	[]entity.BrokerageAccount, error) {
+
+	if account.Nickname == "Rico" {
+		return nil, nil
+	}
+
+	account.Id = "TestAccountID"
+	account.Brokerage = &entity.Brokerage{
+		Id:      account.Brokerage.Id,
+		Name:    "Clear",
+		Country: "BR",
+	}
+
+	return []entity.BrokerageAccount{account}, nil
+}
+
+func (m *MockDb) SearchAccounts(userUid string) ([]entity.BrokerageAccount,
+	error) {
+
+	return []entity.BrokerageAccount{
+		{
+			Id:       "TestAccountID",
+			Nickname: "Clear",
+			Brokerage: &entity.Brokerage{
+				Id:      "55555555-ed8b-11eb-9a03-0242ac130003",
+				Name:    "Clear",
+				Country: "BR",
+			},
+			UserUid: userUid,
+		},
+	}, nil
+}
+
+func (m *MockDb) SearchAccountById(accountId string, userUid string) (
+	[]entity.BrokerageAccount, error) {
+
+	if accountId == "UNKNOWN_ACCOUNT" {
+		return nil, nil
+	}
+
+	return []entity.BrokerageAccount{
+		{
+			Id:       accountId,
+			Nickname: "Clear",
+			Brokerage: &entity.Brokerage{
+				Id:      "55555555-ed8b-11eb-9a03-0242ac130003",
+				Name:    "Clear",
+				Country: "BR",
+			},
+			UserUid: userUid,
+		},
+	}, nil
+}
+
+func (m *MockDb) SearchAccountByBrokerage(brokerageId string,
+	userUid string) ([]entity.BrokerageAccount, error) {
+
+	if brokerageId == "WITHOUT_ACCOUNT" {
+		return nil, nil
+	}
+
+	return []entity.BrokerageAccount{
+		{
+			Id:       "TestAccountID",
+			Nickname: "Clear",
+			Brokerage: &entity.Brokerage{
+				Id:      brokerageId,
+				Name:    "Clear",
+				Country: "BR",
+			},
+			UserUid: userUid,
+		},
+	}, nil
+}
+
+func (m *MockDb) UpdateAccount(accountId string, userUid string,
+	nickname string) ([]entity.BrokerageAccount, error) {
+
+	if nickname == "Rico" {
+		return nil, nil
+	}
+
+	return []entity.BrokerageAccount{
+		{
+			Id:       accountId,
+			Nickname: nickname,
+			Brokerage: &entity.Brokerage{
+				Id:      "55555555-ed8b-11eb-9a03-0242ac130003",
+				Name:    "Clear",
+				Country: "BR",
+			},
+			UserUid: userUid,
+		},
+	}, nil
+}
+
+func (m *MockDb) DeleteAccount(accountId string, userUid string) (
+	[]entity.BrokerageAccount, error) {
+
+	if accountId == "WITH_ORDERS" {
+		return nil, nil
+	}
+
+	return []entity.BrokerageAccount{
+		{
+			Id:       accountId,
+			Nickname: "Clear",
+			UserUid:  userUid,
+		},
+	}, nil
+}
+
+func (m *MockDb) SearchAccountPositions(userUid string) (
+	[]entity.AccountPosition, error) {
+
+	return []entity.AccountPosition{
+		{
+			AccountId:     "TestAccountID",
+			Nickname:      "Clear",
+			BrokerageId:   "55555555-ed8b-11eb-9a03-0242ac130003",
+			BrokerageName: "Clear",
+			AssetId:       "TestAssetID",
+			Symbol:        "ITUB4",
+			Currency:      "BRL",
+			Quantity:      20,
+			Invested:      450.5,
+		},
+	}, nil
+}
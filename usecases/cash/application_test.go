@@ -0,0 +1,86 @@
+package cash
+
+import (
+	"errors"
+	"stockfyApi/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateMovement(t *testing.T) {
+	cashApp := NewApplication(NewMockRepo())
+	date := entity.StringToTime("2021-10-01")
+
+	movement, err := cashApp.CreateMovement("withdrawal", 100, "BRL", date, "",
+		"TestAccountID1", "", "TestUserUID")
+	assert.Nil(t, err)
+	assert.Equal(t, &entity.CashMovement{
+		Id:       "TestMovementID",
+		Type:     entity.CashWithdrawal,
+		Amount:   -100,
+		Currency: "BRL",
+		Date:     date,
+		Account:  &entity.BrokerageAccount{Id: "TestAccountID1"},
+		UserUid:  "TestUserUID",
+	}, movement)
+
+	movement, err = cashApp.CreateMovement("withdrawal", 0, "BRL", date, "",
+		"TestAccountID1", "", "TestUserUID")
+	assert.Nil(t, movement)
+	assert.Equal(t, entity.ErrInvalidCashMovementAmount, err)
+
+	movement, err = cashApp.CreateMovement("withdrawal", 100, "BRL", date, "",
+		"TestAccountID1", "", "ERROR_REPOSITORY")
+	assert.Nil(t, movement)
+	assert.Equal(t, errors.New("Unknown cash repository error"), err)
+}
+
+func TestDeleteMovement(t *testing.T) {
+	cashApp := NewApplication(NewMockRepo())
+
+	movement, err := cashApp.DeleteMovement("TestMovementID", "TestUserUID")
+	assert.Nil(t, err)
+	assert.Equal(t, "TestMovementID", movement.Id)
+
+	movement, err = cashApp.DeleteMovement("UNKNOWN_ID", "TestUserUID")
+	assert.Nil(t, movement)
+	assert.Equal(t, entity.ErrInvalidCashMovement, err)
+}
+
+func TestSearchLedger(t *testing.T) {
+	cashApp := NewApplication(NewMockRepo())
+
+	ledger, err := cashApp.SearchLedger("TestAccountID1",
+		entity.StringToTime("2021-10-31"), "TestUserUID")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(ledger))
+	assert.Equal(t, 414.2, ledger[1].Balance)
+
+	ledger, err = cashApp.SearchLedger("TestAccountID2",
+		entity.StringToTime("2021-10-31"), "TestUserUID")
+	assert.Nil(t, err)
+	assert.Equal(t, []entity.CashEntry{}, ledger)
+
+	ledger, err = cashApp.SearchLedger("", entity.StringToTime("2021-10-31"),
+		"ERROR_REPOSITORY")
+	assert.Nil(t, ledger)
+	assert.Equal(t, errors.New("Unknown cash repository error"), err)
+}
+
+func TestSearchBalances(t *testing.T) {
+	cashApp := NewApplication(NewMockRepo())
+
+	balances, err := cashApp.SearchBalances(entity.StringToTime("2021-12-31"),
+		"TestUserUID")
+	assert.Nil(t, err)
+	assert.Equal(t, []entity.CashBalance{
+		{AccountId: "TestAccountID1", Nickname: "Test BR 1", Currency: "BRL",
+			Balance: 419.2},
+	}, balances)
+
+	balances, err = cashApp.SearchBalances(entity.StringToTime("2021-12-31"),
+		"ERROR_REPOSITORY")
+	assert.Nil(t, balances)
+	assert.Equal(t, errors.New("Unknown cash repository error"), err)
+}
@@ -0,0 +1,25 @@
+package cash
+
+import (
+	"stockfyApi/entity"
+	"time"
+)
+
+type Repository interface {
+	CreateMovement(movement entity.CashMovement) ([]entity.CashMovement, error)
+	DeleteMovement(movementId string, userUid string) ([]entity.CashMovement,
+		error)
+	SearchEntries(date time.Time, userUid string) ([]entity.CashEntry, error)
+}
+
+type UseCases interface {
+	CreateMovement(movementType string, amount float64, currency string,
+		date time.Time, description string, accountId string,
+		toAccountId string, userUid string) (*entity.CashMovement, error)
+	DeleteMovement(movementId string, userUid string) (*entity.CashMovement,
+		error)
+	SearchLedger(accountId string, date time.Time, userUid string) (
+		[]entity.CashEntry, error)
+	SearchBalances(date time.Time, userUid string) ([]entity.CashBalance,
+		error)
+}
@@ -0,0 +1,72 @@
+package cash
+
+import (
+	"stockfyApi/entity"
+	"time"
+)
+
+type MockApplication struct {
+	repo MockDb
+}
+
+func NewMockApplication() *MockApplication {
+	return &MockApplication{}
+}
+
+func (a *MockApplication) CreateMovement(movementType string, amount float64,
+	currency string, date time.Time, description string, accountId string,
+	toAccountId string, userUid string) (*entity.CashMovement, error) {
+
+	movement, err := entity.NewCashMovement(movementType, amount, currency,
+		date, description, accountId, toAccountId, userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	movementCreated, err := a.repo.CreateMovement(*movement)
+	if err != nil {
+		return nil, err
+	}
+
+	movementCreated[0].Account = movement.Account
+	movementCreated[0].ToAccount = movement.ToAccount
+
+	return &movementCreated[0], nil
+}
+
+func (a *MockApplication) DeleteMovement(movementId string, userUid string) (
+	*entity.CashMovement, error) {
+
+	movementDeleted, err := a.repo.DeleteMovement(movementId, userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	if movementDeleted == nil {
+		return nil, entity.ErrInvalidCashMovement
+	}
+
+	return &movementDeleted[0], nil
+}
+
+func (a *MockApplication) SearchLedger(accountId string, date time.Time,
+	userUid string) ([]entity.CashEntry, error) {
+
+	entries, err := a.repo.SearchEntries(date, userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewCashLedger(entries, accountId), nil
+}
+
+func (a *MockApplication) SearchBalances(date time.Time, userUid string) (
+	[]entity.CashBalance, error) {
+
+	entries, err := a.repo.SearchEntries(date, userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewCashBalances(entries), nil
+}
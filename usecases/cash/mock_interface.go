@@ -0,0 +1,104 @@
+package cash
+
+import (
+	"errors"
+	"stockfyApi/entity"
+	"time"
+)
+
+type MockDb struct {
+}
+
+func NewMockRepo() *MockDb {
+	return &MockDb{}
+}
+
+func (m *MockDb) CreateMovement(movement entity.CashMovement) (
+	[]entity.CashMovement, error) {
+
+	if movement.UserUid == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown cash repository error")
+	}
+
+	movement.Id = "TestMovementID"
+	movement.Account = nil
+	movement.ToAccount = nil
+
+	return []entity.CashMovement{movement}, nil
+}
+
+func (m *MockDb) DeleteMovement(movementId string, userUid string) (
+	[]entity.CashMovement, error) {
+
+	if userUid == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown cash repository error")
+	}
+
+	if movementId != "TestMovementID" {
+		return nil, nil
+	}
+
+	return []entity.CashMovement{
+		{
+			Id:       movementId,
+			Type:     entity.CashDeposit,
+			Amount:   1000,
+			Currency: "BRL",
+			Date:     entity.StringToTime("2021-10-01"),
+			UserUid:  userUid,
+		},
+	}, nil
+}
+
+func (m *MockDb) SearchEntries(date time.Time, userUid string) (
+	[]entity.CashEntry, error) {
+
+	if userUid == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown cash repository error")
+	}
+
+	entries := []entity.CashEntry{
+		{
+			Source:      entity.CashSourceMovement,
+			SourceId:    "TestMovementID",
+			Type:        entity.CashDeposit,
+			Amount:      1000,
+			Currency:    "BRL",
+			Date:        entity.StringToTime("2021-10-01"),
+			Description: "Monthly deposit",
+			AccountId:   "TestAccountID1",
+			Nickname:    "Test BR 1",
+		},
+		{
+			Source:      entity.CashSourceOrder,
+			SourceId:    "TestOrderID",
+			Type:        "buy",
+			Amount:      -585.8,
+			Currency:    "BRL",
+			Date:        entity.StringToTime("2021-10-05"),
+			Description: "TEST3",
+			AccountId:   "TestAccountID1",
+			Nickname:    "Test BR 1",
+		},
+		{
+			Source:      entity.CashSourceEarning,
+			SourceId:    "TestEarningID",
+			Type:        "Dividendos",
+			Amount:      5,
+			Currency:    "BRL",
+			Date:        entity.StringToTime("2021-11-03"),
+			Description: "TEST3",
+			AccountId:   "TestAccountID1",
+			Nickname:    "Test BR 1",
+		},
+	}
+
+	var entriesUntilDate []entity.CashEntry
+	for _, entry := range entries {
+		if !entry.Date.After(date) {
+			entriesUntilDate = append(entriesUntilDate, entry)
+		}
+	}
+
+	return entriesUntilDate, nil
+}
@@ -0,0 +1,73 @@
+package companyprofile
+
+import (
+	"stockfyApi/entity"
+	"time"
+)
+
+type Application struct {
+	repo Repository
+}
+
+//NewApplication create new use case
+func NewApplication(r Repository) *Application {
+	return &Application{
+		repo: r,
+	}
+}
+
+// FetchCompanyProfile requests the company overview from the market data
+// providers and stores it as the profile of the asset.
+func (a *Application) FetchCompanyProfile(assetId string, symbol string,
+	country string, finnhubApi ExternalApiRepository,
+	alphaApi ExternalApiRepository) (*entity.CompanyProfile, error) {
+
+	var finnhubOverview map[string]string
+	var alphaOverview map[string]string
+
+	market, err := entity.SearchMarket(country)
+	if err != nil {
+		return nil, err
+	}
+
+	if finnhubApi != nil {
+		finnhubOverview = finnhubApi.CompanyOverview(
+			market.ProviderSymbol(symbol))
+	}
+
+	if alphaApi != nil {
+		alphaOverview = alphaApi.CompanyOverview(market.ProviderSymbol(symbol))
+	}
+
+	profile := entity.NewCompanyProfile(assetId, finnhubOverview,
+		alphaOverview)
+
+	profileStored, err := a.repo.Upsert(*profile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &profileStored[0], nil
+}
+
+func (a *Application) SearchCompanyProfile(assetId string) (
+	*entity.CompanyProfile, error) {
+
+	profile, err := a.repo.SearchByAsset(assetId)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile == nil {
+		return nil, nil
+	}
+
+	return &profile[0], nil
+}
+
+// SearchOutdatedProfiles returns the assets whose profile was never fetched or
+// must be refreshed at the given date.
+func (a *Application) SearchOutdatedProfiles(date time.Time) ([]entity.Asset,
+	error) {
+	return a.repo.SearchOutdated(date.Add(-entity.CompanyProfileRefreshInterval))
+}
@@ -0,0 +1,79 @@
+package companyprofile
+
+import (
+	"errors"
+	"stockfyApi/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchCompanyProfile(t *testing.T) {
+	type test struct {
+		assetId         string
+		symbol          string
+		country         string
+		expectedProfile *entity.CompanyProfile
+		expectedError   error
+	}
+
+	tests := []test{
+		{
+			assetId: "TestAssetID",
+			symbol:  "AAPL",
+			country: "US",
+			expectedProfile: &entity.CompanyProfile{
+				Logo:      "https://test.com/logo.png",
+				Website:   "https://test.com",
+				Exchange:  "NASDAQ NMS - GLOBAL MARKET",
+				MarketCap: 2462175e6,
+				Asset:     &entity.Asset{Id: "TestAssetID"},
+				UpdatedAt: entity.StringToTime("2021-10-01"),
+			},
+			expectedError: nil,
+		},
+		{
+			assetId:         "TestAssetID",
+			symbol:          "AAPL",
+			country:         "EU",
+			expectedProfile: nil,
+			expectedError:   entity.ErrInvalidCountryCode,
+		},
+		{
+			assetId:         "ERROR_REPOSITORY",
+			symbol:          "AAPL",
+			country:         "US",
+			expectedProfile: nil,
+			expectedError:   errors.New("Unknown company profile repository error"),
+		},
+	}
+
+	profileApp := NewApplication(NewMockRepo())
+
+	for _, testCase := range tests {
+		profile, err := profileApp.FetchCompanyProfile(testCase.assetId,
+			testCase.symbol, testCase.country, NewExternalApi(), nil)
+		assert.Equal(t, testCase.expectedProfile, profile)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+
+	// The brazilian symbols are requested with the market suffix
+	profile, err := profileApp.FetchCompanyProfile("TestAssetID", "ITUB4",
+		"BR", nil, NewExternalApi())
+	assert.Nil(t, err)
+	assert.Equal(t, "BOVESPA", profile.Exchange)
+	assert.Equal(t, 9.5, profile.PeRatio)
+	assert.Equal(t, 0.05, profile.DividendYield)
+}
+
+func TestSearchCompanyProfile(t *testing.T) {
+	profileApp := NewApplication(NewMockRepo())
+
+	profile, err := profileApp.SearchCompanyProfile("TestAssetID")
+	assert.Nil(t, err)
+	assert.Equal(t, "TestAssetID", profile.Asset.Id)
+
+	profile, err = profileApp.SearchCompanyProfile("UNKNOWN_ID")
+	assert.Nil(t, err)
+	assert.Nil(t, profile)
+}
@@ -0,0 +1,24 @@
+package companyprofile
+
+import (
+	"stockfyApi/entity"
+	"time"
+)
+
+type Repository interface {
+	Upsert(profile entity.CompanyProfile) ([]entity.CompanyProfile, error)
+	SearchByAsset(assetId string) ([]entity.CompanyProfile, error)
+	SearchOutdated(updatedBefore time.Time) ([]entity.Asset, error)
+}
+
+type ExternalApiRepository interface {
+	CompanyOverview(symbol string) map[string]string
+}
+
+type UseCases interface {
+	FetchCompanyProfile(assetId string, symbol string, country string,
+		finnhubApi ExternalApiRepository, alphaApi ExternalApiRepository) (
+		*entity.CompanyProfile, error)
+	SearchCompanyProfile(assetId string) (*entity.CompanyProfile, error)
+	SearchOutdatedProfiles(date time.Time) ([]entity.Asset, error)
+}
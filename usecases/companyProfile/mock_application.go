@@ -0,0 +1,57 @@
+package companyprofile
+
+import (
+	"errors"
+	"stockfyApi/entity"
+	"time"
+)
+
+type MockApplication struct {
+}
+
+func NewMockApplication() *MockApplication {
+	return &MockApplication{}
+}
+
+func (a *MockApplication) FetchCompanyProfile(assetId string, symbol string,
+	country string, finnhubApi ExternalApiRepository,
+	alphaApi ExternalApiRepository) (*entity.CompanyProfile, error) {
+
+	if assetId == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown company profile repository error")
+	}
+
+	return &entity.CompanyProfile{
+		Logo:      "https://test.com/logo.png",
+		Website:   "https://test.com",
+		Exchange:  "NASDAQ",
+		MarketCap: 2462175e6,
+		Asset:     &entity.Asset{Id: assetId, Symbol: symbol},
+	}, nil
+}
+
+func (a *MockApplication) SearchCompanyProfile(assetId string) (
+	*entity.CompanyProfile, error) {
+
+	switch assetId {
+	case "ERROR_REPOSITORY":
+		return nil, errors.New("Unknown company profile repository error")
+	case "UNKNOWN_ID":
+		return nil, nil
+	default:
+		return &entity.CompanyProfile{
+			Logo:      "https://test.com/logo.png",
+			Website:   "https://test.com",
+			Exchange:  "NASDAQ",
+			MarketCap: 2462175e6,
+			PeRatio:   28.9,
+			Asset:     &entity.Asset{Id: assetId},
+			UpdatedAt: time.Now(),
+		}, nil
+	}
+}
+
+func (a *MockApplication) SearchOutdatedProfiles(date time.Time) (
+	[]entity.Asset, error) {
+	return nil, nil
+}
@@ -0,0 +1,84 @@
+package companyprofile
+
+import (
+	"errors"
+	"stockfyApi/entity"
+	"time"
+)
+
+type MockDb struct {
+}
+
+func NewMockRepo() *MockDb {
+	return &MockDb{}
+}
+
+type MockExternal struct {
+}
+
+func NewExternalApi() *MockExternal {
+	return &MockExternal{}
+}
+
+func (m *MockDb) Upsert(profile entity.CompanyProfile) (
+	[]entity.CompanyProfile, error) {
+	if profile.Asset.Id == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown company profile repository error")
+	}
+
+	profile.UpdatedAt = entity.StringToTime("2021-10-01")
+
+	return []entity.CompanyProfile{profile}, nil
+}
+
+func (m *MockDb) SearchByAsset(assetId string) ([]entity.CompanyProfile,
+	error) {
+	if assetId == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown company profile repository error")
+	}
+
+	if assetId == "UNKNOWN_ID" {
+		return nil, nil
+	}
+
+	return []entity.CompanyProfile{
+		{
+			Logo:      "https://test.com/logo.png",
+			Website:   "https://test.com",
+			Exchange:  "NASDAQ",
+			MarketCap: 2462175e6,
+			PeRatio:   28.9,
+			Asset:     &entity.Asset{Id: assetId, Symbol: "AAPL"},
+			UpdatedAt: entity.StringToTime("2021-10-01"),
+		},
+	}, nil
+}
+
+func (m *MockDb) SearchOutdated(updatedBefore time.Time) ([]entity.Asset,
+	error) {
+	return []entity.Asset{
+		{
+			Id:        "TestAssetID",
+			Symbol:    "AAPL",
+			AssetType: &entity.AssetType{Type: "STOCK", Country: "US"},
+		},
+	}, nil
+}
+
+func (m *MockExternal) CompanyOverview(symbol string) map[string]string {
+	if symbol == "ITUB4.SA" {
+		return map[string]string{
+			"Exchange":             "BOVESPA",
+			"MarketCapitalization": "250000000000",
+			"PERatio":              "9.5",
+			"DividendYield":        "0.05",
+		}
+	}
+
+	return map[string]string{
+		"exchange":             "NASDAQ NMS - GLOBAL MARKET",
+		"logo":                 "https://test.com/logo.png",
+		"marketCapitalization": "2462175",
+		"weburl":               "https://test.com",
+	}
+}
@@ -0,0 +1,113 @@
+package earningevent
+
+import (
+	"stockfyApi/entity"
+	externalapi "stockfyApi/externalApi"
+	"stockfyApi/usecases/asset"
+)
+
+type Application struct {
+	repo Repository
+}
+
+//NewApplication create new use case
+func NewApplication(r Repository) *Application {
+	return &Application{
+		repo: r,
+	}
+}
+
+// FetchEarningEvents requests the earnings announced for the asset to the
+// provider of its market and stores them. Events already imported are
+// updated, so the import can be executed several times.
+func (a *Application) FetchEarningEvents(assetId string, symbol string,
+	country string, extInterface externalapi.ThirdPartyInterfaces) (
+	[]entity.EarningEvent, error) {
+
+	var events []entity.EarningEvent
+
+	market, err := entity.SearchMarket(country)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only some providers of the markets return the announced earnings
+	providerApi, ok := asset.MarketProviderApi(market, extInterface).(
+		ExternalApiRepository)
+	if !ok {
+		return nil, entity.ErrInvalidEarningEventProvider
+	}
+
+	for _, event := range providerApi.GetEarningEvents(
+		market.ProviderSymbol(symbol)) {
+		if event.Currency == "" {
+			event.Currency = market.DefaultCurrency()
+		}
+
+		// Events without the amount or the ex-date are not useful to
+		// calculate the earnings and are ignored
+		eventFormatted, err := entity.NewEarningEvent(event.Type, event.Amount,
+			event.Currency, event.ExDate, event.PaymentDate, assetId)
+		if err != nil {
+			continue
+		}
+
+		events = append(events, *eventFormatted)
+	}
+
+	if events == nil {
+		return nil, nil
+	}
+
+	return a.repo.CreateEvents(events)
+}
+
+// SearchUnclaimedEvents returns the events of the asset without an earning
+// registered for the user.
+func (a *Application) SearchUnclaimedEvents(assetId string, userUid string) (
+	[]entity.EarningEvent, error) {
+	return a.repo.SearchUnclaimed(assetId, userUid)
+}
+
+// SearchEventHolders returns the uid of the users with the asset.
+func (a *Application) SearchEventHolders(assetId string) ([]string, error) {
+	return a.repo.SearchHolders(assetId)
+}
+
+// SearchHeldAssets returns the assets held by at least one user.
+func (a *Application) SearchHeldAssets() ([]entity.Asset, error) {
+	return a.repo.SearchHeldAssets()
+}
+
+// EarningsFromEvents returns the earnings of the user for each event based on
+// the quantity held on its ex-date. The earnings matching an earning already
+// registered by the user, without the event, are not returned.
+func (a *Application) EarningsFromEvents(events []entity.EarningEvent,
+	orders []entity.Order, registered []entity.Earnings,
+	userUid string) []entity.Earnings {
+
+	var earnings []entity.Earnings
+
+	for _, event := range events {
+		quantity := entity.QuantityOnExDate(orders, event.ExDate)
+		if quantity <= 0 {
+			continue
+		}
+
+		earnings = append(earnings, *event.Earning(quantity, userUid))
+	}
+
+	return entity.UnregisteredEarnings(earnings, registered)
+}
+
+// CreateEarningsFromEvents registers the earnings of the events. The earnings
+// of an event already registered for the user are ignored.
+func (a *Application) CreateEarningsFromEvents(earnings []entity.Earnings) (
+	[]entity.Earnings, error) {
+
+	if len(earnings) == 0 {
+		return nil, nil
+	}
+
+	return a.repo.CreateEarnings(earnings)
+}
@@ -0,0 +1,154 @@
+package earningevent
+
+import (
+	"stockfyApi/entity"
+	externalapi "stockfyApi/externalApi"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchEarningEvents(t *testing.T) {
+	type test struct {
+		symbol         string
+		country        string
+		extInterface   externalapi.ThirdPartyInterfaces
+		expectedEvents []entity.EarningEvent
+		expectedError  error
+	}
+
+	extApiMocked := externalapi.ThirdPartyInterfaces{
+		FinnhubApi:      NewExternalApi(),
+		AlphaVantageApi: NewExternalApi(),
+	}
+
+	tests := []test{
+		{
+			symbol:       "ITUB4",
+			country:      "BR",
+			extInterface: extApiMocked,
+			expectedEvents: []entity.EarningEvent{
+				{
+					Id:          "EarningEventID",
+					Type:        "JCP",
+					Amount:      0.01765,
+					Currency:    "BRL",
+					ExDate:      entity.StringToTime("2021-10-01"),
+					PaymentDate: entity.StringToTime("2021-11-01"),
+					Asset:       &entity.Asset{Id: "TestAssetID"},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			symbol:         "AAPL",
+			country:        "US",
+			extInterface:   extApiMocked,
+			expectedEvents: nil,
+			expectedError:  nil,
+		},
+		{
+			symbol:         "BTC",
+			country:        "CRYPTO",
+			extInterface:   extApiMocked,
+			expectedEvents: nil,
+			expectedError:  entity.ErrInvalidEarningEventProvider,
+		},
+		{
+			symbol:         "ITUB4",
+			country:        "BR",
+			extInterface:   externalapi.ThirdPartyInterfaces{},
+			expectedEvents: nil,
+			expectedError:  entity.ErrInvalidEarningEventProvider,
+		},
+	}
+
+	earningEventApp := NewApplication(NewMockRepo())
+
+	for _, testCase := range tests {
+		events, err := earningEventApp.FetchEarningEvents("TestAssetID",
+			testCase.symbol, testCase.country, testCase.extInterface)
+		assert.Equal(t, testCase.expectedEvents, events)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+func TestEarningsFromEvents(t *testing.T) {
+	events := []entity.EarningEvent{
+		{
+			Id:          "EarningEventID",
+			Type:        "Dividendos",
+			Amount:      0.5,
+			Currency:    "BRL",
+			ExDate:      entity.StringToTime("2021-10-05"),
+			PaymentDate: entity.StringToTime("2021-10-20"),
+			Asset:       &entity.Asset{Id: "TestAssetID"},
+		},
+		{
+			Id:       "EventBeforeOrders",
+			Type:     "Dividendos",
+			Amount:   0.5,
+			Currency: "BRL",
+			ExDate:   entity.StringToTime("2021-08-05"),
+			Asset:    &entity.Asset{Id: "TestAssetID"},
+		},
+	}
+
+	orders := []entity.Order{
+		{Quantity: 100, Date: entity.StringToTime("2021-09-01")},
+		{Quantity: 100, Date: entity.StringToTime("2021-10-05")},
+	}
+
+	earningEventApp := NewApplication(NewMockRepo())
+
+	eventId := "EarningEventID"
+	earnings := earningEventApp.EarningsFromEvents(events, orders, nil,
+		"TestUserUID")
+	assert.Equal(t, []entity.Earnings{
+		{
+			Type:           "Dividendos",
+			Earning:        50,
+			Currency:       "BRL",
+			Date:           entity.StringToTime("2021-10-20"),
+			Asset:          &entity.Asset{Id: "TestAssetID"},
+			UserUid:        "TestUserUID",
+			EarningEventId: &eventId,
+		},
+	}, earnings)
+
+	// The earning imported from the B3 statement is already registered
+	registered := []entity.Earnings{
+		{
+			Id:       "ImportedEarningID",
+			Type:     "Dividendos",
+			Earning:  50,
+			Currency: "BRL",
+			Date:     entity.StringToTime("2021-10-20"),
+			Asset:    &entity.Asset{Id: "TestAssetID"},
+		},
+	}
+	earnings = earningEventApp.EarningsFromEvents(events, orders, registered,
+		"TestUserUID")
+	assert.Nil(t, earnings)
+}
+
+func TestCreateEarningsFromEvents(t *testing.T) {
+	earningEventApp := NewApplication(NewMockRepo())
+
+	claimedEvent := "CLAIMED_EVENT"
+	newEvent := "EarningEventID"
+
+	earnings, err := earningEventApp.CreateEarningsFromEvents([]entity.Earnings{
+		{Type: "JCP", Earning: 10, EarningEventId: &claimedEvent},
+		{Type: "Dividendos", Earning: 20, EarningEventId: &newEvent},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []entity.Earnings{
+		{Id: "EarningID", Type: "Dividendos", Earning: 20,
+			EarningEventId: &newEvent},
+	}, earnings)
+
+	earnings, err = earningEventApp.CreateEarningsFromEvents(nil)
+	assert.Nil(t, err)
+	assert.Nil(t, earnings)
+}
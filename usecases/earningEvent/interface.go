@@ -0,0 +1,35 @@
+package earningevent
+
+import (
+	"stockfyApi/entity"
+	externalapi "stockfyApi/externalApi"
+)
+
+type Repository interface {
+	CreateEvents(events []entity.EarningEvent) ([]entity.EarningEvent, error)
+	SearchUnclaimed(assetId string, userUid string) ([]entity.EarningEvent,
+		error)
+	SearchHolders(assetId string) ([]string, error)
+	SearchHeldAssets() ([]entity.Asset, error)
+	CreateEarnings(earnings []entity.Earnings) ([]entity.Earnings, error)
+}
+
+// ExternalApiRepository is implemented by the external APIs that are able to
+// return the earnings announced for a symbol.
+type ExternalApiRepository interface {
+	GetEarningEvents(symbol string) []entity.EarningEvent
+}
+
+type UseCases interface {
+	FetchEarningEvents(assetId string, symbol string, country string,
+		extInterface externalapi.ThirdPartyInterfaces) ([]entity.EarningEvent,
+		error)
+	SearchUnclaimedEvents(assetId string, userUid string) (
+		[]entity.EarningEvent, error)
+	SearchEventHolders(assetId string) ([]string, error)
+	SearchHeldAssets() ([]entity.Asset, error)
+	EarningsFromEvents(events []entity.EarningEvent, orders []entity.Order,
+		registered []entity.Earnings, userUid string) []entity.Earnings
+	CreateEarningsFromEvents(earnings []entity.Earnings) ([]entity.Earnings,
+		error)
+}
@@ -0,0 +1,92 @@
+package earningevent
+
+import (
+	"errors"
+	"stockfyApi/entity"
+	externalapi "stockfyApi/externalApi"
+)
+
+type MockApplication struct {
+}
+
+func NewMockApplication() *MockApplication {
+	return &MockApplication{}
+}
+
+func (a *MockApplication) FetchEarningEvents(assetId string, symbol string,
+	country string, extInterface externalapi.ThirdPartyInterfaces) (
+	[]entity.EarningEvent, error) {
+
+	if assetId == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown earning event repository error")
+	}
+
+	return []entity.EarningEvent{
+		{
+			Id:          "EarningEventID",
+			Type:        "JCP",
+			Amount:      0.5,
+			Currency:    "BRL",
+			ExDate:      entity.StringToTime("2021-10-05"),
+			PaymentDate: entity.StringToTime("2021-10-20"),
+			Asset:       &entity.Asset{Id: assetId, Symbol: symbol},
+		},
+	}, nil
+}
+
+func (a *MockApplication) SearchUnclaimedEvents(assetId string,
+	userUid string) ([]entity.EarningEvent, error) {
+
+	if assetId == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown earning event repository error")
+	}
+
+	return []entity.EarningEvent{
+		{
+			Id:          "EarningEventID",
+			Type:        "JCP",
+			Amount:      0.5,
+			Currency:    "BRL",
+			ExDate:      entity.StringToTime("2021-10-05"),
+			PaymentDate: entity.StringToTime("2021-10-20"),
+			Asset:       &entity.Asset{Id: assetId},
+		},
+	}, nil
+}
+
+func (a *MockApplication) SearchEventHolders(assetId string) ([]string,
+	error) {
+	return []string{"TestUserUID"}, nil
+}
+
+func (a *MockApplication) SearchHeldAssets() ([]entity.Asset, error) {
+	return nil, nil
+}
+
+func (a *MockApplication) EarningsFromEvents(events []entity.EarningEvent,
+	orders []entity.Order, registered []entity.Earnings,
+	userUid string) []entity.Earnings {
+
+	var earnings []entity.Earnings
+
+	for _, event := range events {
+		quantity := entity.QuantityOnExDate(orders, event.ExDate)
+		if quantity <= 0 {
+			continue
+		}
+
+		earnings = append(earnings, *event.Earning(quantity, userUid))
+	}
+
+	return entity.UnregisteredEarnings(earnings, registered)
+}
+
+func (a *MockApplication) CreateEarningsFromEvents(
+	earnings []entity.Earnings) ([]entity.Earnings, error) {
+
+	for i := range earnings {
+		earnings[i].Id = "EarningID"
+	}
+
+	return earnings, nil
+}
@@ -0,0 +1,115 @@
+package earningevent
+
+import (
+	"errors"
+	"stockfyApi/entity"
+)
+
+type MockDb struct {
+}
+
+func NewMockRepo() *MockDb {
+	return &MockDb{}
+}
+
+type MockExternal struct {
+}
+
+func NewExternalApi() *MockExternal {
+	return &MockExternal{}
+}
+
+func (m *MockDb) CreateEvents(events []entity.EarningEvent) (
+	[]entity.EarningEvent, error) {
+	if events[0].Asset.Id == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown earning event repository error")
+	}
+
+	for i := range events {
+		events[i].Id = "EarningEventID"
+	}
+
+	return events, nil
+}
+
+func (m *MockDb) SearchUnclaimed(assetId string, userUid string) (
+	[]entity.EarningEvent, error) {
+	if assetId == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown earning event repository error")
+	}
+
+	return []entity.EarningEvent{
+		{
+			Id:          "EarningEventID",
+			Type:        "Dividendos",
+			Amount:      0.5,
+			Currency:    "BRL",
+			ExDate:      entity.StringToTime("2021-10-05"),
+			PaymentDate: entity.StringToTime("2021-10-20"),
+			Asset:       &entity.Asset{Id: assetId},
+		},
+	}, nil
+}
+
+func (m *MockDb) SearchHolders(assetId string) ([]string, error) {
+	return []string{"TestUserUID"}, nil
+}
+
+func (m *MockDb) SearchHeldAssets() ([]entity.Asset, error) {
+	return []entity.Asset{
+		{
+			Id:        "TestAssetID",
+			Symbol:    "ITUB4",
+			AssetType: &entity.AssetType{Type: "STOCK", Country: "BR"},
+		},
+	}, nil
+}
+
+func (m *MockDb) CreateEarnings(earnings []entity.Earnings) (
+	[]entity.Earnings, error) {
+	var earningsCreated []entity.Earnings
+
+	// The earnings of the event "CLAIMED_EVENT" were already registered
+	for _, earning := range earnings {
+		if *earning.EarningEventId == "CLAIMED_EVENT" {
+			continue
+		}
+
+		earning.Id = "EarningID"
+		earningsCreated = append(earningsCreated, earning)
+	}
+
+	return earningsCreated, nil
+}
+
+func (m *MockExternal) GetEarningEvents(symbol string) []entity.EarningEvent {
+	if symbol != "ITUB4.SA" {
+		return nil
+	}
+
+	return []entity.EarningEvent{
+		{
+			Type:        "JCP",
+			Amount:      0.01765,
+			ExDate:      entity.StringToTime("2021-10-01"),
+			PaymentDate: entity.StringToTime("2021-11-01"),
+		},
+		{
+			Type:   "Dividendos",
+			Amount: 0,
+			ExDate: entity.StringToTime("2021-11-01"),
+		},
+	}
+}
+
+func (m *MockExternal) VerifySymbol2(symbol string) entity.SymbolLookup {
+	return entity.SymbolLookup{}
+}
+
+func (m *MockExternal) GetPrice(symbol string) entity.SymbolPrice {
+	return entity.SymbolPrice{}
+}
+
+func (m *MockExternal) CompanyOverview(symbol string) map[string]string {
+	return nil
+}
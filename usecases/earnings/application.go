@@ -35,6 +35,13 @@ func (a *Application) CreateEarning(earningType string, earnings float64,
 	return &earningCreated[0], nil
 }
 
+// CreateEarnings stores all the earnings or none of them.
+func (a *Application) CreateEarnings(earnings []entity.Earnings) (
+	[]entity.Earnings, error) {
+
+	return a.repo.CreateBulk(earnings)
+}
+
 func (a *Application) SearchEarningsFromAssetUser(assetId string, userUid string) (
 	[]entity.Earnings, error) {
 	earnings, err := a.repo.SearchFromAssetUser(assetId, userUid)
@@ -63,6 +70,13 @@ func (a *Application) SearchEarningsFromAssetUserByDate(assetId string,
 	return earnings, nil
 }
 
+// SearchAllEarningsFromUser returns all the earnings of the user.
+func (a *Application) SearchAllEarningsFromUser(userUid string) (
+	[]entity.Earnings, error) {
+
+	return a.repo.SearchAllFromUser(userUid)
+}
+
 func (a *Application) SearchEarningsFromUser(earningId string, useUid string) (
 	*entity.Earnings, error) {
 	earningReturn, err := a.repo.SearchFromUser(earningId, useUid)
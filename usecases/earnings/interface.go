@@ -4,9 +4,11 @@ import "stockfyApi/entity"
 
 type Repository interface {
 	Create(earningOrder entity.Earnings) ([]entity.Earnings, error)
+	CreateBulk(earnings []entity.Earnings) ([]entity.Earnings, error)
 	DeleteFromAsset(assetId string) ([]entity.Earnings, error)
 	SearchFromUser(earningsId string, userUid string) ([]entity.Earnings, error)
 	SearchFromAssetUser(assetId string, userUid string) ([]entity.Earnings, error)
+	SearchAllFromUser(userUid string) ([]entity.Earnings, error)
 	SearchFromAssetUserEarningsByDate(assetId string, userUid string,
 		orderBy string, limit int, offset int) ([]entity.Earnings, error)
 	DeleteFromUser(id string, userUid string) (string, error)
@@ -18,12 +20,14 @@ type UseCases interface {
 	CreateEarning(earningType string, earnings float64, currency string,
 		date string, country string, assetId string, userUid string) (
 		*entity.Earnings, error)
+	CreateEarnings(earnings []entity.Earnings) ([]entity.Earnings, error)
 	SearchEarningsFromAssetUser(assetId string, userUid string) (
 		[]entity.Earnings, error)
 	SearchEarningsFromAssetUserByDate(assetId string, userUid string,
 		orderBy string, limit int, offset int) ([]entity.Earnings, error)
 	SearchEarningsFromUser(earningId string, useUid string) (*entity.Earnings,
 		error)
+	SearchAllEarningsFromUser(userUid string) ([]entity.Earnings, error)
 	DeleteEarningsFromUser(earningId string, userUid string) (*string, error)
 	DeleteEarningsFromAsset(assetId string) ([]entity.Earnings, error)
 	DeleteEarningsFromAssetUser(assetId, userUid string) ([]entity.Earnings,
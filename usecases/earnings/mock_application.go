@@ -28,6 +28,12 @@ func (a *MockApplication) CreateEarning(earningType string, earnings float64,
 	return eargningFormatted, nil
 }
 
+func (a *MockApplication) CreateEarnings(earnings []entity.Earnings) (
+	[]entity.Earnings, error) {
+
+	return NewApplication(NewMockRepo()).CreateEarnings(earnings)
+}
+
 func (a *MockApplication) SearchEarningsFromAssetUser(assetId string,
 	userUid string) ([]entity.Earnings, error) {
 
@@ -99,6 +105,28 @@ func (a *MockApplication) SearchEarningsFromAssetUserByDate(assetId string,
 	}, nil
 }
 
+func (a *MockApplication) SearchAllEarningsFromUser(userUid string) (
+	[]entity.Earnings, error) {
+
+	if userUid == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown earnings repository error")
+	}
+
+	return []entity.Earnings{
+		{
+			Id:       "TestEarningID1",
+			Type:     "Dividendos",
+			Earning:  5.00,
+			Date:     entity.StringToTime("2021-10-01"),
+			Currency: "BRL",
+			Asset: &entity.Asset{
+				Id:     "TestID",
+				Symbol: "TEST3",
+			},
+		},
+	}, nil
+}
+
 func (a *MockApplication) SearchEarningsFromUser(earningId string, useUid string) (
 	*entity.Earnings, error) {
 
@@ -2,6 +2,7 @@ package earnings
 
 import (
 	"errors"
+	"fmt"
 	"stockfyApi/entity"
 	"time"
 )
@@ -33,11 +34,32 @@ func (m *MockDb) Create(earningOrder entity.Earnings) ([]entity.Earnings, error)
 	}, nil
 }
 
+func (m *MockDb) CreateBulk(earnings []entity.Earnings) ([]entity.Earnings,
+	error) {
+
+	var earningsCreated []entity.Earnings
+	for i, earning := range earnings {
+		if earning.Asset.Id == "WRONG_ID" {
+			return nil, errors.New("Some Database Error")
+		}
+
+		earning.Id = fmt.Sprintf("EARNING_ID%d", i+1)
+		earningsCreated = append(earningsCreated, earning)
+	}
+
+	return earningsCreated, nil
+}
+
 func (m *MockDb) SearchFromAssetUser(assetId string, userUid string) (
 	[]entity.Earnings, error) {
 	return []entity.Earnings{}, nil
 }
 
+func (m *MockDb) SearchAllFromUser(userUid string) ([]entity.Earnings,
+	error) {
+	return []entity.Earnings{}, nil
+}
+
 func (r *MockDb) SearchFromAssetUserEarningsByDate(assetId string,
 	userUid string, orderBy string, limit int, offset int) ([]entity.Earnings,
 	error) {
@@ -0,0 +1,191 @@
+package fixedincome
+
+import (
+	"math"
+	"sort"
+	"stockfyApi/calendar"
+	"stockfyApi/entity"
+	"time"
+)
+
+type Application struct {
+	repo Repository
+}
+
+//NewApplication create new use case
+func NewApplication(r Repository) *Application {
+	return &Application{
+		repo: r,
+	}
+}
+
+// FixedIncomeVerification validates the information of a fixed income before
+// its asset is created.
+func (a *Application) FixedIncomeVerification(category string, issuer string,
+	indexer string, rate float64, maturity string) error {
+
+	_, err := entity.NewFixedIncome(category, issuer, indexer, rate,
+		entity.StringToTime(maturity), "")
+
+	return err
+}
+
+func (a *Application) CreateFixedIncome(category string, issuer string,
+	indexer string, rate float64, maturity string, assetId string) (
+	*entity.FixedIncome, error) {
+
+	fixedIncome, err := entity.NewFixedIncome(category, issuer, indexer, rate,
+		entity.StringToTime(maturity), assetId)
+	if err != nil {
+		return nil, err
+	}
+
+	fixedIncomeCreated, err := a.repo.Create(*fixedIncome)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fixedIncomeCreated[0], nil
+}
+
+func (a *Application) SearchFixedIncomeByAsset(assetId string) (
+	*entity.FixedIncome, error) {
+
+	fixedIncome, err := a.repo.SearchByAsset(assetId)
+	if err != nil {
+		return nil, err
+	}
+
+	if fixedIncome == nil {
+		return nil, nil
+	}
+
+	return &fixedIncome[0], nil
+}
+
+func (a *Application) CreateIndexSeries(series []entity.IndexSeries) (
+	[]entity.IndexSeries, error) {
+
+	for _, index := range series {
+		if index.Indexer != entity.IndexerCdi &&
+			index.Indexer != entity.IndexerIpca {
+			return nil, entity.ErrInvalidFixedIncomeIndexer
+		}
+
+		if index.Date.IsZero() {
+			return nil, entity.ErrInvalidFixedIncomeBlank
+		}
+	}
+
+	return a.repo.CreateIndexSeries(series)
+}
+
+// fixedIncomeLot is the remaining quantity of a purchase, which is redeemed
+// following the FIFO order, as the income tax depends on the holding period
+// of each purchase.
+type fixedIncomeLot struct {
+	date     time.Time
+	quantity float64
+	price    float64
+}
+
+// FixedIncomePosition accrues the purchases (buy orders) of a fixed income
+// asset until the given date. The redemptions (sell orders) consume the
+// oldest purchases first and their price is the gross value received for each
+// unit. The income tax of the regressive table is applied over the gain of
+// each redemption and over the gain of the remaining position, as if it was
+// redeemed on the given date.
+func (a *Application) FixedIncomePosition(fixedIncome entity.FixedIncome,
+	orders []entity.Order, date time.Time) (*entity.FixedIncomePosition,
+	error) {
+
+	var position entity.FixedIncomePosition
+	var lots []fixedIncomeLot
+	var series []entity.IndexSeries
+	var err error
+
+	if len(orders) == 0 {
+		return &position, nil
+	}
+
+	// The fixed rates are accrued over the trading days of B3, which has the
+	// Brazilian national holidays
+	exchange, err := calendar.SearchExchange("B3")
+	if err != nil {
+		return nil, err
+	}
+
+	sortedOrders := append([]entity.Order(nil), orders...)
+	sort.SliceStable(sortedOrders, func(i, j int) bool {
+		return sortedOrders[i].Date.Before(sortedOrders[j].Date)
+	})
+
+	if fixedIncome.Indexer != entity.IndexerPre {
+		series, err = a.repo.SearchIndexSeries(fixedIncome.Indexer,
+			sortedOrders[0].Date, date)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	incomeTaxRate := func(start time.Time, end time.Time) float64 {
+		if fixedIncome.IsTaxExempt() {
+			return 0
+		}
+
+		return entity.IncomeTaxRegressiveRate(int(end.Sub(start).Hours() / 24))
+	}
+
+	for _, order := range sortedOrders {
+		if order.Date.After(date) {
+			continue
+		}
+
+		if order.OrderType == "buy" {
+			lots = append(lots, fixedIncomeLot{
+				date:     order.Date,
+				quantity: order.Quantity,
+				price:    order.Price,
+			})
+			continue
+		}
+
+		redeemQuantity := math.Abs(order.Quantity)
+		position.RedeemedValue += redeemQuantity * order.Price
+
+		for len(lots) > 0 && redeemQuantity > 0 {
+			quantity := math.Min(redeemQuantity, lots[0].quantity)
+			gain := quantity * (order.Price - lots[0].price)
+
+			if gain > 0 {
+				position.RedeemedIncomeTax += gain * incomeTaxRate(lots[0].date,
+					order.Date)
+			}
+
+			lots[0].quantity -= quantity
+			redeemQuantity -= quantity
+			if lots[0].quantity <= 0 {
+				lots = lots[1:]
+			}
+		}
+	}
+
+	for _, lot := range lots {
+		investedValue := lot.quantity * lot.price
+		grossValue := investedValue * fixedIncome.AccrualFactor(series,
+			lot.date, date, exchange.IsTradingDay)
+
+		position.Quantity += lot.quantity
+		position.InvestedValue += investedValue
+		position.GrossValue += grossValue
+
+		if grossValue > investedValue {
+			position.IncomeTax += (grossValue - investedValue) *
+				incomeTaxRate(lot.date, date)
+		}
+	}
+
+	position.NetValue = position.GrossValue - position.IncomeTax
+
+	return &position, nil
+}
@@ -0,0 +1,256 @@
+package fixedincome
+
+import (
+	"errors"
+	"math"
+	"stockfyApi/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixedIncomeVerification(t *testing.T) {
+	type test struct {
+		category      string
+		issuer        string
+		rate          float64
+		maturity      string
+		expectedError error
+	}
+
+	tests := []test{
+		{
+			category:      "LCI",
+			issuer:        "Banco Inter",
+			rate:          95,
+			maturity:      "2026-01-05",
+			expectedError: nil,
+		},
+		{
+			category:      "CDB",
+			issuer:        "",
+			rate:          95,
+			maturity:      "2026-01-05",
+			expectedError: entity.ErrInvalidFixedIncomeBlank,
+		},
+		{
+			category:      "POUPANCA",
+			issuer:        "Banco Inter",
+			rate:          95,
+			maturity:      "2026-01-05",
+			expectedError: entity.ErrInvalidFixedIncomeCategory,
+		},
+		{
+			category:      "CDB",
+			issuer:        "Banco Inter",
+			rate:          -1,
+			maturity:      "2026-01-05",
+			expectedError: entity.ErrInvalidFixedIncomeRate,
+		},
+	}
+
+	fixedIncomeApp := NewApplication(NewMockRepo())
+
+	for _, testCase := range tests {
+		err := fixedIncomeApp.FixedIncomeVerification(testCase.category,
+			testCase.issuer, "CDI", testCase.rate, testCase.maturity)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+func TestCreateFixedIncome(t *testing.T) {
+	type test struct {
+		category            string
+		indexer             string
+		rate                float64
+		assetId             string
+		expectedFixedIncome *entity.FixedIncome
+		expectedError       error
+	}
+
+	tests := []test{
+		{
+			category: "CDB",
+			indexer:  "CDI",
+			rate:     110,
+			assetId:  "TestAssetID",
+			expectedFixedIncome: &entity.FixedIncome{
+				Id:       "FixedIncomeID",
+				Category: "CDB",
+				Issuer:   "Banco Inter",
+				Indexer:  "CDI",
+				Rate:     110,
+				Maturity: entity.StringToTime("2026-01-05"),
+				Asset:    &entity.Asset{Id: "TestAssetID"},
+			},
+			expectedError: nil,
+		},
+		{
+			category:            "CDB",
+			indexer:             "SELIC",
+			rate:                110,
+			assetId:             "TestAssetID",
+			expectedFixedIncome: nil,
+			expectedError:       entity.ErrInvalidFixedIncomeIndexer,
+		},
+		{
+			category:            "CDB",
+			indexer:             "CDI",
+			rate:                110,
+			assetId:             "ERROR_REPOSITORY",
+			expectedFixedIncome: nil,
+			expectedError:       errors.New("Unknown fixed income repository error"),
+		},
+	}
+
+	fixedIncomeApp := NewApplication(NewMockRepo())
+
+	for _, testCase := range tests {
+		fixedIncome, err := fixedIncomeApp.CreateFixedIncome(testCase.category,
+			"Banco Inter", testCase.indexer, testCase.rate, "2026-01-05",
+			testCase.assetId)
+		assert.Equal(t, testCase.expectedFixedIncome, fixedIncome)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+func TestSearchFixedIncomeByAsset(t *testing.T) {
+	fixedIncomeApp := NewApplication(NewMockRepo())
+
+	fixedIncome, err := fixedIncomeApp.SearchFixedIncomeByAsset("TestAssetID")
+	assert.Nil(t, err)
+	assert.Equal(t, "TestAssetID", fixedIncome.Asset.Id)
+
+	fixedIncome, err = fixedIncomeApp.SearchFixedIncomeByAsset("UNKNOWN_ID")
+	assert.Nil(t, err)
+	assert.Nil(t, fixedIncome)
+}
+
+func TestCreateIndexSeries(t *testing.T) {
+	fixedIncomeApp := NewApplication(NewMockRepo())
+
+	series := []entity.IndexSeries{
+		{Indexer: "CDI", Date: entity.StringToTime("2021-10-04"), Value: 6.15},
+		{Indexer: "IPCA", Date: entity.StringToTime("2021-10-01"), Value: 1.25},
+	}
+
+	seriesCreated, err := fixedIncomeApp.CreateIndexSeries(series)
+	assert.Nil(t, err)
+	assert.Equal(t, series, seriesCreated)
+
+	_, err = fixedIncomeApp.CreateIndexSeries([]entity.IndexSeries{
+		{Indexer: "PRE", Date: entity.StringToTime("2021-10-04"), Value: 12},
+	})
+	assert.Equal(t, entity.ErrInvalidFixedIncomeIndexer, err)
+}
+
+func TestFixedIncomePosition(t *testing.T) {
+	type test struct {
+		category         string
+		orders           []entity.Order
+		expectedPosition entity.FixedIncomePosition
+	}
+
+	// Three business days of CDI at 6.15% a.a. are accrued by the mocked
+	// repository
+	factor := math.Pow(math.Pow(1.0615, 1.0/252), 3)
+	gain := 1000 * (factor - 1)
+
+	tests := []test{
+		{
+			category: "CDB",
+			orders: []entity.Order{
+				{
+					Quantity:  1,
+					Price:     1000,
+					OrderType: "buy",
+					Date:      entity.StringToTime("2021-10-01"),
+				},
+			},
+			expectedPosition: entity.FixedIncomePosition{
+				Quantity:      1,
+				InvestedValue: 1000,
+				GrossValue:    1000 * factor,
+				IncomeTax:     gain * 0.225,
+				NetValue:      1000*factor - gain*0.225,
+			},
+		},
+		{
+			category: "CDB",
+			orders: []entity.Order{
+				{
+					Quantity:  -1,
+					Price:     1000.5,
+					OrderType: "sell",
+					Date:      entity.StringToTime("2021-10-05"),
+				},
+				{
+					Quantity:  2,
+					Price:     1000,
+					OrderType: "buy",
+					Date:      entity.StringToTime("2021-10-01"),
+				},
+			},
+			expectedPosition: entity.FixedIncomePosition{
+				Quantity:          1,
+				InvestedValue:     1000,
+				GrossValue:        1000 * factor,
+				IncomeTax:         gain * 0.225,
+				NetValue:          1000*factor - gain*0.225,
+				RedeemedValue:     1000.5,
+				RedeemedIncomeTax: 0.5 * 0.225,
+			},
+		},
+		{
+			category: "LCI",
+			orders: []entity.Order{
+				{
+					Quantity:  1,
+					Price:     1000,
+					OrderType: "buy",
+					Date:      entity.StringToTime("2021-10-01"),
+				},
+			},
+			expectedPosition: entity.FixedIncomePosition{
+				Quantity:      1,
+				InvestedValue: 1000,
+				GrossValue:    1000 * factor,
+				NetValue:      1000 * factor,
+			},
+		},
+		{
+			category:         "CDB",
+			orders:           nil,
+			expectedPosition: entity.FixedIncomePosition{},
+		},
+	}
+
+	fixedIncomeApp := NewApplication(NewMockRepo())
+
+	for _, testCase := range tests {
+		fixedIncome := entity.FixedIncome{
+			Category: testCase.category,
+			Issuer:   "Banco Inter",
+			Indexer:  "CDI",
+			Rate:     100,
+			Maturity: entity.StringToTime("2026-01-05"),
+		}
+
+		position, err := fixedIncomeApp.FixedIncomePosition(fixedIncome,
+			testCase.orders, entity.StringToTime("2021-10-06"))
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.expectedPosition.Quantity, position.Quantity)
+		assert.InDelta(t, testCase.expectedPosition.InvestedValue,
+			position.InvestedValue, 1e-9)
+		assert.InDelta(t, testCase.expectedPosition.GrossValue,
+			position.GrossValue, 1e-9)
+		assert.InDelta(t, testCase.expectedPosition.IncomeTax,
+			position.IncomeTax, 1e-9)
+		assert.InDelta(t, testCase.expectedPosition.NetValue,
+			position.NetValue, 1e-9)
+		assert.InDelta(t, testCase.expectedPosition.RedeemedValue,
+			position.RedeemedValue, 1e-9)
+		assert.InDelta(t, testCase.expectedPosition.RedeemedIncomeTax,
+			position.RedeemedIncomeTax, 1e-9)
+	}
+}
@@ -0,0 +1,28 @@
+package fixedincome
+
+import (
+	"stockfyApi/entity"
+	"time"
+)
+
+type Repository interface {
+	Create(fixedIncome entity.FixedIncome) ([]entity.FixedIncome, error)
+	SearchByAsset(assetId string) ([]entity.FixedIncome, error)
+	CreateIndexSeries(series []entity.IndexSeries) (
+		[]entity.IndexSeries, error)
+	SearchIndexSeries(indexer string, startDate time.Time,
+		endDate time.Time) ([]entity.IndexSeries, error)
+}
+
+type UseCases interface {
+	FixedIncomeVerification(category string, issuer string, indexer string,
+		rate float64, maturity string) error
+	CreateFixedIncome(category string, issuer string, indexer string,
+		rate float64, maturity string, assetId string) (*entity.FixedIncome,
+		error)
+	SearchFixedIncomeByAsset(assetId string) (*entity.FixedIncome, error)
+	CreateIndexSeries(series []entity.IndexSeries) (
+		[]entity.IndexSeries, error)
+	FixedIncomePosition(fixedIncome entity.FixedIncome, orders []entity.Order,
+		date time.Time) (*entity.FixedIncomePosition, error)
+}
@@ -0,0 +1,92 @@
+package fixedincome
+
+import (
+	"errors"
+	"stockfyApi/entity"
+	"time"
+)
+
+type MockApplication struct {
+}
+
+func NewMockApplication() *MockApplication {
+	return &MockApplication{}
+}
+
+func (a *MockApplication) FixedIncomeVerification(category string,
+	issuer string, indexer string, rate float64, maturity string) error {
+
+	_, err := entity.NewFixedIncome(category, issuer, indexer, rate,
+		entity.StringToTime(maturity), "")
+
+	return err
+}
+
+func (a *MockApplication) CreateFixedIncome(category string, issuer string,
+	indexer string, rate float64, maturity string, assetId string) (
+	*entity.FixedIncome, error) {
+
+	fixedIncome, err := entity.NewFixedIncome(category, issuer, indexer, rate,
+		entity.StringToTime(maturity), assetId)
+	if err != nil {
+		return nil, err
+	}
+
+	if assetId == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown fixed income repository error")
+	}
+
+	fixedIncome.Id = "FixedIncomeID"
+
+	return fixedIncome, nil
+}
+
+func (a *MockApplication) SearchFixedIncomeByAsset(assetId string) (
+	*entity.FixedIncome, error) {
+
+	switch assetId {
+	case "ERROR_REPOSITORY":
+		return nil, errors.New("Unknown fixed income repository error")
+	case "UNKNOWN_ID":
+		return nil, nil
+	default:
+		return &entity.FixedIncome{
+			Id:       "FixedIncomeID",
+			Category: "CDB",
+			Issuer:   "Banco Inter",
+			Indexer:  "CDI",
+			Rate:     100,
+			Maturity: entity.StringToTime("2026-01-05"),
+			Asset:    &entity.Asset{Id: assetId},
+		}, nil
+	}
+}
+
+func (a *MockApplication) CreateIndexSeries(series []entity.IndexSeries) (
+	[]entity.IndexSeries, error) {
+	for _, index := range series {
+		if index.Indexer != entity.IndexerCdi &&
+			index.Indexer != entity.IndexerIpca {
+			return nil, entity.ErrInvalidFixedIncomeIndexer
+		}
+	}
+
+	return series, nil
+}
+
+func (a *MockApplication) FixedIncomePosition(fixedIncome entity.FixedIncome,
+	orders []entity.Order, date time.Time) (*entity.FixedIncomePosition,
+	error) {
+
+	var position entity.FixedIncomePosition
+
+	for _, order := range orders {
+		position.Quantity += order.Quantity
+		position.InvestedValue += order.Quantity * order.Price
+	}
+	position.GrossValue = position.InvestedValue * 1.1
+	position.IncomeTax = (position.GrossValue - position.InvestedValue) * 0.15
+	position.NetValue = position.GrossValue - position.IncomeTax
+
+	return &position, nil
+}
@@ -0,0 +1,69 @@
+package fixedincome
+
+import (
+	"errors"
+	"stockfyApi/entity"
+	"time"
+)
+
+type MockDb struct {
+}
+
+func NewMockRepo() *MockDb {
+	return &MockDb{}
+}
+
+func (m *MockDb) Create(fixedIncome entity.FixedIncome) ([]entity.FixedIncome,
+	error) {
+	if fixedIncome.Asset.Id == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown fixed income repository error")
+	}
+
+	fixedIncome.Id = "FixedIncomeID"
+
+	return []entity.FixedIncome{fixedIncome}, nil
+}
+
+func (m *MockDb) SearchByAsset(assetId string) ([]entity.FixedIncome, error) {
+	if assetId == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown fixed income repository error")
+	}
+
+	if assetId == "UNKNOWN_ID" {
+		return nil, nil
+	}
+
+	return []entity.FixedIncome{
+		{
+			Id:       "FixedIncomeID",
+			Category: "CDB",
+			Issuer:   "Banco Inter",
+			Indexer:  "CDI",
+			Rate:     100,
+			Maturity: entity.StringToTime("2026-01-05"),
+			Asset:    &entity.Asset{Id: assetId},
+		},
+	}, nil
+}
+
+func (m *MockDb) CreateIndexSeries(series []entity.IndexSeries) (
+	[]entity.IndexSeries, error) {
+	return series, nil
+}
+
+func (m *MockDb) SearchIndexSeries(indexer string, startDate time.Time,
+	endDate time.Time) ([]entity.IndexSeries, error) {
+
+	if indexer == entity.IndexerIpca {
+		return []entity.IndexSeries{
+			{Indexer: "IPCA", Date: entity.StringToTime("2021-11-01"), Value: 1},
+		}, nil
+	}
+
+	// CDI of 6.15% a.a. in the first business days of October 2021
+	return []entity.IndexSeries{
+		{Indexer: "CDI", Date: entity.StringToTime("2021-10-04"), Value: 6.15},
+		{Indexer: "CDI", Date: entity.StringToTime("2021-10-05"), Value: 6.15},
+		{Indexer: "CDI", Date: entity.StringToTime("2021-10-06"), Value: 6.15},
+	}, nil
+}
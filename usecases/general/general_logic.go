@@ -5,16 +5,17 @@ import (
 )
 
 func CountryValidation(country string) error {
-	if country != "BR" && country != "US" && country != "" {
-		return entity.ErrInvalidCountryCode
+	if country == "" {
+		return nil
 	}
 
-	return nil
+	_, err := entity.SearchMarket(country)
+
+	return err
 }
 
 func AssetTypeNameValidation(name string) error {
-	if name != "STOCK" && name != "ETF" && name != "REIT" && name != "FII" &&
-		name != "" {
+	if name != "" && !entity.IsValidAssetTypeName(name) {
 		return entity.ErrInvalidAssetTypeName
 	}
 	return nil
@@ -22,6 +22,10 @@ func TestCountryValidation(t *testing.T) {
 			country:      "US",
 			respExpected: nil,
 		},
+		{
+			country:      "CRYPTO",
+			respExpected: nil,
+		},
 		{
 			country:      "",
 			respExpected: nil,
@@ -61,6 +65,14 @@ func TestAssetTypeNameValidation(t *testing.T) {
 			name:         "FII",
 			respExpected: nil,
 		},
+		{
+			name:         "CRYPTO",
+			respExpected: nil,
+		},
+		{
+			name:         "FIXED_INCOME",
+			respExpected: nil,
+		},
 		{
 			name:         "",
 			respExpected: nil,
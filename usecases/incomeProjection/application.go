@@ -0,0 +1,45 @@
+package incomeprojection
+
+import (
+	"stockfyApi/entity"
+	"time"
+)
+
+type Application struct {
+	repo Repository
+}
+
+//NewApplication create new use case
+func NewApplication(r Repository) *Application {
+	return &Application{
+		repo: r,
+	}
+}
+
+// ProjectIncome projects the earnings of the user for the next 12 months
+// based on the positions of the orders, the announced earning events and the
+// earnings received in the last 12 months.
+func (a *Application) ProjectIncome(userUid string, date time.Time) (
+	*entity.IncomeProjection, error) {
+
+	orders, err := a.repo.SearchOrders(userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0,
+		date.Location())
+
+	earnings, err := a.repo.SearchEarnings(userUid,
+		startDate.AddDate(0, -entity.IncomeProjectionMonths, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := a.repo.SearchAnnouncedEvents(userUid, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewIncomeProjection(orders, earnings, events, date), nil
+}
@@ -0,0 +1,33 @@
+package incomeprojection
+
+import (
+	"errors"
+	"stockfyApi/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectIncome(t *testing.T) {
+	incomeProjectionApp := NewApplication(NewMockRepo())
+
+	projection, err := incomeProjectionApp.ProjectIncome("TestUserUID",
+		entity.StringToTime("2021-10-19"))
+	assert.Nil(t, err)
+	assert.Equal(t, []entity.ProjectedIncome{
+		{Currency: "BRL", Confirmed: 50, Estimated: 100},
+	}, projection.Totals)
+	assert.Equal(t, []entity.ProjectedIncome{
+		{Asset: &entity.Asset{Id: "TestAssetID", Symbol: "ITUB4"},
+			Currency: "BRL", Estimated: 100},
+	}, projection.Months[1].Assets)
+	assert.Equal(t, []entity.ProjectedIncome{
+		{Asset: &entity.Asset{Id: "TestAssetID", Symbol: "ITUB4"},
+			Currency: "BRL", Confirmed: 50},
+	}, projection.Months[2].Assets)
+
+	projection, err = incomeProjectionApp.ProjectIncome("ERROR_REPOSITORY",
+		entity.StringToTime("2021-10-19"))
+	assert.Nil(t, projection)
+	assert.Equal(t, errors.New("Unknown order repository error"), err)
+}
@@ -0,0 +1,19 @@
+package incomeprojection
+
+import (
+	"stockfyApi/entity"
+	"time"
+)
+
+type Repository interface {
+	SearchOrders(userUid string) ([]entity.Order, error)
+	SearchEarnings(userUid string, startDate time.Time) ([]entity.Earnings,
+		error)
+	SearchAnnouncedEvents(userUid string, date time.Time) (
+		[]entity.EarningEvent, error)
+}
+
+type UseCases interface {
+	ProjectIncome(userUid string, date time.Time) (*entity.IncomeProjection,
+		error)
+}
@@ -0,0 +1,29 @@
+package incomeprojection
+
+import (
+	"errors"
+	"stockfyApi/entity"
+	"time"
+)
+
+type MockApplication struct {
+	repo MockDb
+}
+
+func NewMockApplication() *MockApplication {
+	return &MockApplication{}
+}
+
+func (a *MockApplication) ProjectIncome(userUid string, date time.Time) (
+	*entity.IncomeProjection, error) {
+
+	if userUid == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown order repository error")
+	}
+
+	orders, _ := a.repo.SearchOrders(userUid)
+	earnings, _ := a.repo.SearchEarnings(userUid, date)
+	events, _ := a.repo.SearchAnnouncedEvents(userUid, date)
+
+	return entity.NewIncomeProjection(orders, earnings, events, date), nil
+}
@@ -0,0 +1,54 @@
+package incomeprojection
+
+import (
+	"errors"
+	"stockfyApi/entity"
+	"time"
+)
+
+type MockDb struct {
+}
+
+func NewMockRepo() *MockDb {
+	return &MockDb{}
+}
+
+func (m *MockDb) SearchOrders(userUid string) ([]entity.Order, error) {
+	if userUid == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown order repository error")
+	}
+
+	return []entity.Order{
+		{
+			Quantity: 200,
+			Date:     entity.StringToTime("2020-09-01"),
+			Asset:    &entity.Asset{Id: "TestAssetID", Symbol: "ITUB4"},
+		},
+	}, nil
+}
+
+func (m *MockDb) SearchEarnings(userUid string, startDate time.Time) (
+	[]entity.Earnings, error) {
+	return []entity.Earnings{
+		{
+			Earning:  100,
+			Currency: "BRL",
+			Date:     entity.StringToTime("2020-11-10"),
+			Asset:    &entity.Asset{Id: "TestAssetID", Symbol: "ITUB4"},
+		},
+	}, nil
+}
+
+func (m *MockDb) SearchAnnouncedEvents(userUid string, date time.Time) (
+	[]entity.EarningEvent, error) {
+	return []entity.EarningEvent{
+		{
+			Type:        "JCP",
+			Amount:      0.25,
+			Currency:    "BRL",
+			ExDate:      entity.StringToTime("2021-10-05"),
+			PaymentDate: entity.StringToTime("2021-12-15"),
+			Asset:       &entity.Asset{Id: "TestAssetID", Symbol: "ITUB4"},
+		},
+	}, nil
+}
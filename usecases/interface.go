@@ -4,48 +4,96 @@ import (
 	"stockfyApi/usecases/asset"
 	assettype "stockfyApi/usecases/assetType"
 	assetusers "stockfyApi/usecases/assetUser"
+	auditlog "stockfyApi/usecases/auditLog"
+	"stockfyApi/usecases/backup"
 	"stockfyApi/usecases/brokerage"
+	"stockfyApi/usecases/cash"
+	companyprofile "stockfyApi/usecases/companyProfile"
 	dbverification "stockfyApi/usecases/dbVerification"
+	earningevent "stockfyApi/usecases/earningEvent"
 	"stockfyApi/usecases/earnings"
+	fixedincome "stockfyApi/usecases/fixedIncome"
+	incomeprojection "stockfyApi/usecases/incomeProjection"
+	"stockfyApi/usecases/market"
+	"stockfyApi/usecases/option"
 	"stockfyApi/usecases/order"
+	"stockfyApi/usecases/reconciliation"
+	"stockfyApi/usecases/remittance"
 	"stockfyApi/usecases/sector"
+	symbolsearch "stockfyApi/usecases/symbolSearch"
 	"stockfyApi/usecases/user"
 )
 
 type Repositories struct {
-	AssetRepository          asset.Repository
-	SectorRepository         sector.Repository
-	AssetTypeRepository      assettype.Repository
-	UserRepository           user.Repository
-	OrderRepository          order.Repository
-	AssetUserRepository      assetusers.Repository
-	BrokerageRepository      brokerage.Repository
-	EarningsRepository       earnings.Repository
-	DbVerificationRepository dbverification.Repository
+	AssetRepository            asset.Repository
+	SectorRepository           sector.Repository
+	AssetTypeRepository        assettype.Repository
+	UserRepository             user.Repository
+	OrderRepository            order.Repository
+	AssetUserRepository        assetusers.Repository
+	BrokerageRepository        brokerage.Repository
+	EarningsRepository         earnings.Repository
+	DbVerificationRepository   dbverification.Repository
+	FixedIncomeRepository      fixedincome.Repository
+	MarketRepository           market.Repository
+	OptionRepository           option.Repository
+	CompanyProfileRepository   companyprofile.Repository
+	EarningEventRepository     earningevent.Repository
+	IncomeProjectionRepository incomeprojection.Repository
+	SymbolSearchRepository     symbolsearch.Repository
+	AuditLogRepository         auditlog.Repository
+	BackupRepository           backup.Repository
+	ReconciliationRepository   reconciliation.Repository
+	CashRepository             cash.Repository
+	RemittanceRepository       remittance.Repository
 }
 
 type Applications struct {
-	AssetApp          asset.UseCases
-	AssetTypeApp      assettype.UseCases
-	AssetUserApp      assetusers.UseCases
-	SectorApp         sector.UseCases
-	UserApp           user.UseCases
-	OrderApp          order.UseCases
-	BrokerageApp      brokerage.UseCases
-	EarningsApp       earnings.UseCases
-	DbVerificationApp dbverification.UseCases
+	AssetApp            asset.UseCases
+	AssetTypeApp        assettype.UseCases
+	AssetUserApp        assetusers.UseCases
+	SectorApp           sector.UseCases
+	UserApp             user.UseCases
+	OrderApp            order.UseCases
+	BrokerageApp        brokerage.UseCases
+	EarningsApp         earnings.UseCases
+	DbVerificationApp   dbverification.UseCases
+	FixedIncomeApp      fixedincome.UseCases
+	MarketApp           market.UseCases
+	OptionApp           option.UseCases
+	CompanyProfileApp   companyprofile.UseCases
+	EarningEventApp     earningevent.UseCases
+	IncomeProjectionApp incomeprojection.UseCases
+	SymbolSearchApp     symbolsearch.UseCases
+	AuditLogApp         auditlog.UseCases
+	BackupApp           backup.UseCases
+	ReconciliationApp   reconciliation.UseCases
+	CashApp             cash.UseCases
+	RemittanceApp       remittance.UseCases
 }
 
 func NewApplications(repos Repositories, extRepo user.ExternalUserDatabase) *Applications {
 	return &Applications{
-		SectorApp:         sector.NewApplication(repos.SectorRepository),
-		AssetTypeApp:      assettype.NewApplication(repos.AssetTypeRepository),
-		AssetApp:          asset.NewApplication(repos.AssetRepository),
-		AssetUserApp:      assetusers.NewApplication(repos.AssetUserRepository),
-		UserApp:           user.NewApplication(repos.UserRepository, extRepo),
-		OrderApp:          order.NewApplication(repos.OrderRepository),
-		BrokerageApp:      brokerage.NewApplication(repos.BrokerageRepository),
-		EarningsApp:       earnings.NewApplication(repos.EarningsRepository),
-		DbVerificationApp: dbverification.NewApplication(repos.DbVerificationRepository),
+		SectorApp:           sector.NewApplication(repos.SectorRepository),
+		AssetTypeApp:        assettype.NewApplication(repos.AssetTypeRepository),
+		AssetApp:            asset.NewApplication(repos.AssetRepository),
+		AssetUserApp:        assetusers.NewApplication(repos.AssetUserRepository),
+		UserApp:             user.NewApplication(repos.UserRepository, extRepo),
+		OrderApp:            order.NewApplication(repos.OrderRepository),
+		BrokerageApp:        brokerage.NewApplication(repos.BrokerageRepository),
+		EarningsApp:         earnings.NewApplication(repos.EarningsRepository),
+		DbVerificationApp:   dbverification.NewApplication(repos.DbVerificationRepository),
+		FixedIncomeApp:      fixedincome.NewApplication(repos.FixedIncomeRepository),
+		MarketApp:           market.NewApplication(repos.MarketRepository),
+		OptionApp:           option.NewApplication(repos.OptionRepository),
+		CompanyProfileApp:   companyprofile.NewApplication(repos.CompanyProfileRepository),
+		EarningEventApp:     earningevent.NewApplication(repos.EarningEventRepository),
+		IncomeProjectionApp: incomeprojection.NewApplication(repos.IncomeProjectionRepository),
+		SymbolSearchApp:     symbolsearch.NewApplication(repos.SymbolSearchRepository),
+		AuditLogApp:         auditlog.NewApplication(repos.AuditLogRepository),
+		BackupApp:           backup.NewApplication(repos.BackupRepository),
+		ReconciliationApp:   reconciliation.NewApplication(repos.ReconciliationRepository),
+		CashApp:             cash.NewApplication(repos.CashRepository),
+		RemittanceApp:       remittance.NewApplication(repos.RemittanceRepository),
 	}
 }
@@ -1,11 +1,14 @@
 package logicApi
 
 import (
+	"io"
+	"sort"
 	"stockfyApi/entity"
 	externalapi "stockfyApi/externalApi"
 	"stockfyApi/usecases"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type Application struct {
@@ -25,6 +28,39 @@ func NewApplication(a usecases.Applications,
 func (a *Application) ApiAssetVerification(symbol string, country string) (
 	int, *entity.Asset, error) {
 
+	httpStatusCode, assetInfo, err := a.newAsset(symbol, country)
+	if err != nil {
+		return httpStatusCode, nil, err
+	}
+
+	assetTypeConverted := a.app.AssetTypeApp.
+		AssetTypeConversionToUseCaseStruct(assetInfo.AssetType.Id,
+			assetInfo.AssetType.Type, assetInfo.AssetType.Country)
+
+	// Create Asset
+	assetCreated, err := a.app.AssetApp.CreateAsset(symbol,
+		assetInfo.Fullname, assetInfo.Preference, assetInfo.Sector.Id,
+		assetTypeConverted)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	err = a.app.SectorApp.AssignSectorMapping(assetCreated.Id,
+		assetInfo.SectorMapping)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, &assetCreated, nil
+
+}
+
+// newAsset searches the information of a symbol in the market data providers
+// and returns its asset without creating it. The label of the sector is
+// classified in the sector taxonomy and kept in the asset.
+func (a *Application) newAsset(symbol string, country string) (int,
+	*entity.Asset, error) {
+
 	symbolLookup, err := a.app.AssetApp.AssetVerificationExistence(symbol,
 		country, a.externalInterfaces)
 
@@ -46,11 +82,12 @@ func (a *Application) ApiAssetVerification(symbol string, country string) (
 		symbolLookup.Type, country, symbol)
 
 	// Verify the Sector
-	sectorName := a.app.AssetApp.AssetVerificationSector(
+	sectorLabel := a.app.AssetApp.AssetVerificationSector(
 		assetType, symbol, country, a.externalInterfaces.FinnhubApi)
 
-	// Create Sector
-	sectorInfo, err := a.app.SectorApp.CreateSector(sectorName)
+	// Classify the sector label in the sector taxonomy
+	sectorInfo, sectorMapping, err := a.app.SectorApp.ClassifySectorLabel(
+		entity.SectorLabelProvider(assetType), sectorLabel)
 	if err != nil {
 		return 500, nil, err
 	}
@@ -62,34 +99,35 @@ func (a *Application) ApiAssetVerification(symbol string, country string) (
 		return 500, nil, err
 	}
 
-	assetTypeConverted := a.app.AssetTypeApp.
-		AssetTypeConversionToUseCaseStruct(assetTypeInfo[0].Id,
-			assetTypeInfo[0].Type, assetTypeInfo[0].Country)
-
 	// Specify the preference asset type if it is a brazilian asset
 	preference := a.app.AssetApp.AssetPreferenceType(symbol,
 		country, assetTypeInfo[0].Type)
 
-	// Create Asset
-	assetCreated, err := a.app.AssetApp.CreateAsset(symbol,
-		symbolLookup.Fullname, &preference, sectorInfo[0].Id, assetTypeConverted)
+	assetInfo, err := entity.NewAsset(symbol, symbolLookup.Fullname,
+		&preference, sectorInfo.Id, assetTypeInfo[0].Id, assetTypeInfo[0].Type,
+		assetTypeInfo[0].Country)
 	if err != nil {
 		return 500, nil, err
 	}
+	assetInfo.AssetType = &assetTypeInfo[0]
+	assetInfo.SectorMapping = sectorMapping
 
-	return 200, &assetCreated, nil
-
+	return 200, assetInfo, nil
 }
 
+// ApiCreateOrder creates an order in the brokerage account informed. When only
+// the brokerage is informed, the order is created in the default account of
+// the user in this brokerage.
 func (a *Application) ApiCreateOrder(symbol string, country string,
 	orderType string, quantity float64, price float64, currency string,
-	brokerage string, date string, userUid string) (int, *entity.Order, error) {
+	brokerage string, accountId string, date string, userUid string) (int,
+	*entity.Order, error) {
 
 	var assetInfo *entity.Asset
 	httpStatusCode := 200
 
 	err := a.app.OrderApp.OrderVerification(orderType, country, quantity, price,
-		currency)
+		currency, date)
 	if err != nil {
 		return 400, nil, err
 	}
@@ -124,16 +162,16 @@ func (a *Application) ApiCreateOrder(symbol string, country string,
 		}
 	}
 
-	// Search if the brokerage exists
-	brokerageInfo, err := a.app.BrokerageApp.SearchBrokerage("SINGLE",
-		brokerage, "")
+	// Search the brokerage account of the order
+	accountStatusCode, accountInfo, err := a.orderAccount(brokerage, accountId,
+		userUid)
 	if err != nil {
-		return 400, nil, err
+		return accountStatusCode, nil, err
 	}
 
 	// Create Order
 	orderReturn, err := a.app.OrderApp.CreateOrder(quantity, price, currency,
-		orderType, date, brokerageInfo[0].Id, assetInfo.Id, userUid)
+		orderType, date, accountInfo.Id, assetInfo.Id, userUid)
 	if err != nil {
 		return 500, nil, err
 	}
@@ -141,419 +179,2377 @@ func (a *Application) ApiCreateOrder(symbol string, country string,
 	return httpStatusCode, orderReturn, nil
 }
 
-func (a *Application) ApiAssetsPerAssetType(assetType string, country string,
-	ordersInfo bool, withPrice bool, userUid string) (int, *entity.AssetType,
-	error) {
-
-	chPrice := make(chan *entity.SymbolPrice)
-	defer close(chPrice)
-	var assetPrice *entity.SymbolPrice
-
-	if assetType == "" {
-		return 400, nil, entity.ErrInvalidApiQueryTypeBlank
-	}
-
-	if country == "" {
-		return 400, nil, entity.ErrInvalidApiQueryCountryBlank
-	}
-
-	searchedAssetType, err := a.app.AssetApp.SearchAssetPerAssetType(assetType,
-		country, userUid, ordersInfo)
+// ApiImportOrders creates the orders of a CSV file. Every row is validated
+// before any order is created and, when a row has problems, no order is
+// created and the rows are returned with their problems. In the dry run the
+// rows are only validated. The orders are created in a single transaction,
+// but the missing assets are added to the catalog shared by every user before
+// it.
+func (a *Application) ApiImportOrders(file io.Reader,
+	mapping map[string]string, delimiter string, dryRun bool,
+	userUid string) (int, []entity.OrderImportRow, []entity.Order, error) {
+
+	rows, err := a.app.OrderApp.ReadOrderImport(file, mapping, delimiter)
 	if err != nil {
-		return 400, nil, err
+		return 400, nil, nil, err
 	}
 
-	if withPrice == true {
-		for _, assetInfo := range searchedAssetType.Assets {
-			go func(assetSymbol string) {
-				var assetPrice *entity.SymbolPrice
+	return a.importOrderRows(rows, dryRun, userUid)
+}
 
-				assetPrice, err = a.app.AssetApp.AssetVerificationPrice(
-					assetSymbol, searchedAssetType.Country, a.externalInterfaces)
+// ApiImportTradeNotes reads the orders of the trade notes of the brazilian
+// brokerages. The notes are only previewed until the user confirms them, when
+// their orders are created like the orders of an imported file. The symbols
+// map sets the symbol of the specifications of the note, otherwise the symbol
+// is searched by the name of the company.
+func (a *Application) ApiImportTradeNotes(text string, brokerage string,
+	symbols map[string]string, confirm bool, userUid string) (int,
+	[]entity.TradeNote, []entity.OrderImportRow, []entity.Order, error) {
 
-				chPrice <- assetPrice
-			}(assetInfo.Symbol)
-		}
+	notes, err := a.app.OrderApp.ReadTradeNotes(text)
+	if err != nil {
+		return 400, nil, nil, nil, err
+	}
 
-		for i := 0; i < len(searchedAssetType.Assets); i++ {
-			assetPrice = <-chPrice
-			if assetPrice != nil {
-				for i, assetInfo := range searchedAssetType.Assets {
-					if assetPrice.Symbol == assetInfo.Symbol {
-						searchedAssetType.Assets[i].Price = assetPrice
-					}
+	for i := range notes {
+		for j := range notes[i].Orders {
+			order := &notes[i].Orders[j]
+			if symbols[order.Specification] != "" {
+				order.Symbol = symbols[order.Specification]
+			} else if order.Symbol == "" {
+				order.Symbol, err = a.tradeNoteSymbol(*order)
+				if err != nil {
+					return 500, nil, nil, nil, err
 				}
 			}
-
 		}
-
 	}
 
-	return 200, searchedAssetType, nil
+	httpStatusCode, rows, orders, err := a.importOrderRows(
+		entity.TradeNoteImportRows(notes, brokerage), !confirm, userUid)
 
+	return httpStatusCode, notes, rows, orders, err
 }
 
-func (a *Application) ApiDeleteAssets(myUser bool, userUid string,
-	symbol string) (int, *entity.Asset, error) {
-
-	var deletedAssetInfo *entity.Asset
-	var assetUserDeleted *entity.AssetUsers
+// ApiImportB3Report imports a report exported from the investor area of the
+// B3. The trades are imported as orders and the movements as earnings and as
+// the orders of the corporate events, like the bonus shares and the splits,
+// both validated before anything is created. The custody position is not
+// imported, but compared with the positions computed from the orders until
+// the date informed, since the report does not have it. The institutions of
+// the report are matched with the brokerages by their name, or by the
+// brokerages map from the institution to the brokerage name.
+func (a *Application) ApiImportB3Report(file []byte,
+	brokerages map[string]string, date string, dryRun bool, userUid string) (
+	int, *entity.B3Report, error) {
+
+	report, err := a.app.OrderApp.ReadB3Report(file)
+	if err != nil {
+		return 400, nil, err
+	}
 
-	// If the myUser flag is not true, our application assumes that the Asset
-	// will be deleted for every user. Only user with admin privileges are enabled
-	// for such action. When myUser is false, then, our application assumes that
-	// the asset will be deleted only for the user requested for it.
-	if !myUser {
+	userBrokerages, err := a.app.BrokerageApp.SearchBrokerage("ALL", "", "",
+		userUid)
+	if err != nil {
+		return 500, nil, err
+	}
 
-		// Search to find if the user has admin privileges
-		searchedUser, _ := a.app.UserApp.SearchUser(userUid)
-		if searchedUser.Type != "admin" {
-			return 403, nil, entity.ErrInvalidUserAdminPrivilege
+	brokerageName := func(institution string) string {
+		if brokerages[institution] != "" {
+			return brokerages[institution]
 		}
 
-		// Search the Asset information
-		assetInfo, err := a.app.AssetApp.SearchAsset(symbol)
-		if err != nil {
-			return 500, nil, err
+		brokerageInfo := entity.SearchBrokerageByInstitution(institution,
+			userBrokerages)
+		if brokerageInfo == nil {
+			return ""
 		}
+		return brokerageInfo.Name
+	}
 
-		if assetInfo == nil {
-			return 404, nil, entity.ErrInvalidAssetSymbol
+	switch report.Type {
+	case entity.B3TradesReport:
+		for i, trade := range report.Trades {
+			if name := brokerageName(trade.Brokerage); name != "" {
+				report.Trades[i].Brokerage = name
+			}
 		}
 
-		// Delete Asset from the database
-		deletedAsset, err := a.app.AssetApp.DeleteAsset(assetInfo.Id)
-		if err != nil {
-			return 500, nil, err
-		}
+		httpStatusCode, rows, orders, err := a.importOrderRows(report.Trades,
+			dryRun, userUid)
+		report.Trades, report.Orders = rows, orders
 
-		if deletedAsset == nil {
-			return 404, nil, entity.ErrInvalidDeleteAsset
+		return httpStatusCode, report, err
+	case entity.B3MovementsReport:
+		for i, movement := range report.Movements {
+			if name := brokerageName(movement.Brokerage); name != "" {
+				report.Movements[i].Brokerage = name
+			}
 		}
 
-		deletedAssetInfo = assetInfo
-
-	} else {
+		return a.importB3Movements(report, dryRun, userUid)
+	}
 
-		// Search if the user has this Asset
-		assetInfo, err := a.app.AssetApp.SearchAssetByUser(symbol, userUid,
-			false, false)
-		if err != nil {
-			return 500, nil, err
+	// The custody of each brokerage is compared with the positions computed
+	// from the orders of that brokerage until the date of the report.
+	custodyDate, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return 400, report, entity.ErrInvalidCustodyDate
+	}
+	report.Date = custodyDate
+
+	var snapshots []*entity.CustodySnapshot
+	for i, position := range report.Positions {
+		brokerageInfo := entity.SearchBrokerageByInstitution(
+			brokerageName(position.Brokerage), userBrokerages)
+		if brokerageInfo == nil {
+			report.Positions[i].AddError(entity.ErrInvalidBrokerageNameSearch)
+			continue
 		}
+		report.Positions[i].Brokerage = brokerageInfo.Name
 
-		if assetInfo == nil {
-			return 404, nil, entity.ErrInvalidAssetSymbol
+		if !report.Positions[i].Valid() {
+			continue
 		}
 
-		// Delete Orders from this Asset for a specific user
-		_, err = a.app.OrderApp.DeleteOrdersFromAssetUser(
-			assetInfo.Id, userUid)
-		if err != nil {
-			return 500, nil, err
+		var snapshot *entity.CustodySnapshot
+		for _, brokerageSnapshot := range snapshots {
+			if brokerageSnapshot.Brokerage.Id == brokerageInfo.Id {
+				snapshot = brokerageSnapshot
+			}
 		}
-
-		_, err = a.app.EarningsApp.DeleteEarningsFromAssetUser(assetInfo.Id,
-			userUid)
-		if err != nil {
-			return 500, nil, err
+		if snapshot == nil {
+			snapshot = &entity.CustodySnapshot{
+				Brokerage: brokerageInfo,
+				Date:      custodyDate,
+			}
+			snapshots = append(snapshots, snapshot)
 		}
 
-		// Delete Asset for this user
-		assetUserDeleted, err = a.app.AssetUserApp.DeleteAssetUserRelation(
-			assetInfo.Id, userUid)
+		snapshot.Positions = append(snapshot.Positions,
+			entity.CustodyPosition{
+				Symbol:   position.Symbol,
+				Quantity: position.Quantity,
+			})
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Brokerage.Name < snapshots[j].Brokerage.Name
+	})
+
+	for _, snapshot := range snapshots {
+		reconciliation, err := a.app.ReconciliationApp.ReconcileCustody(
+			*snapshot, userUid)
 		if err != nil {
+			switch err {
+			case entity.ErrInvalidCustodyDate, entity.ErrInvalidCustodyPosition:
+				return 400, report, err
+			}
 			return 500, nil, err
 		}
 
-		if assetUserDeleted == nil {
-			return 404, nil, entity.ErrInvalidAssetUser
-		}
-
-		deletedAssetInfo = assetInfo
+		report.Reconciliation = append(report.Reconciliation,
+			reconciliation...)
 	}
 
-	return 200, deletedAssetInfo, nil
+	return 200, report, nil
 }
 
-func (a *Application) ApiGetOrdersFromAssetUser(symbol string, userUid string,
-	orderBy string, limit string, offset string) (int, []entity.Order, error) {
-	var ordersInfo []entity.Order
-
-	if symbol == "" {
-		return 400, nil, entity.ErrInvalidApiQuerySymbolBlank
-	}
+// ApiImportBrokerStatement imports a statement exported by a US brokerage.
+// The buys and the sells are imported as orders and the dividends as earnings,
+// with the tax withheld from them, all in USD. The brokerage of the statement
+// is the brokerage informed, otherwise the brokerage of the user matching the
+// institution of the OFX files or the brokerage of the CSV format.
+func (a *Application) ApiImportBrokerStatement(file []byte, brokerage string,
+	dryRun bool, userUid string) (int, *entity.BrokerStatement, []entity.Order,
+	[]entity.Earnings, error) {
 
-	assetInfo, err := a.app.AssetApp.SearchAssetByUser(symbol, userUid, false,
-		false)
+	statement, err := a.app.OrderApp.ReadBrokerStatement(file)
 	if err != nil {
-		return 500, nil, err
-	}
-
-	if assetInfo == nil {
-		return 404, nil, entity.ErrInvalidAssetSymbol
+		return 400, nil, nil, nil, err
 	}
 
-	if limit == "" && offset == "" {
-		ordersInfo, err = a.app.OrderApp.SearchOrdersFromAssetUser(assetInfo.Id,
-			userUid)
-		if err != nil {
-			return 500, nil, err
-		}
-	} else {
-
-		limitInt, err := strconv.Atoi(limit)
-		if err != nil {
-			return 400, nil, entity.ErrInvalidOrderLimit
-		}
-
-		offsetInt, err := strconv.Atoi(offset)
+	if brokerage == "" {
+		userBrokerages, err := a.app.BrokerageApp.SearchBrokerage("ALL", "",
+			"", userUid)
 		if err != nil {
-			return 400, nil, entity.ErrInvalidOrderOffset
+			return 500, nil, nil, nil, err
 		}
 
-		ordersInfo, err = a.app.OrderApp.SearchOrdersSearchFromAssetUserByDate(
-			assetInfo.Id, userUid, orderBy, limitInt, offsetInt)
-		if err != nil {
-			return 500, nil, err
+		brokerage = statement.Brokerage
+		brokerageInfo := entity.SearchBrokerageByInstitution(
+			statement.Brokerage, userBrokerages)
+		if brokerageInfo != nil {
+			brokerage = brokerageInfo.Name
 		}
 	}
+	statement.SetBrokerage(brokerage)
 
-	if ordersInfo == nil {
-		return 404, nil, entity.ErrInvalidOrder
+	httpStatusCode, orderRows, earningRows, orders, earnings, err :=
+		a.importStatementRows(statement.Orders, statement.Earnings, dryRun,
+			userUid)
+	if httpStatusCode == 500 || httpStatusCode == 404 {
+		return httpStatusCode, nil, nil, nil, err
 	}
+	statement.Orders, statement.Earnings = orderRows, earningRows
 
-	return 200, ordersInfo, nil
+	return httpStatusCode, statement, orders, earnings, err
 }
 
-func (a *Application) ApiUpdateOrdersFromUser(orderId string, userUid string,
-	orderType string, price float64, quantity float64, date string,
-	brokerage string) (int, *entity.Order, error) {
+// ApiExportPortfolio returns all the assets, orders and earnings of the user,
+// with the positions of each brokerage account, to be exported in the format
+// informed.
+func (a *Application) ApiExportPortfolio(format string, userUid string) (int,
+	*entity.PortfolioExport, error) {
 
-	if orderType == "" || price == 0 || quantity == 0 || date == "" ||
-		brokerage == "" {
-		return 400, nil, entity.ErrInvalidApiOrderUpdate
+	if err := entity.ExportFormatVerification(
+		strings.ToLower(format)); err != nil {
+		return 400, nil, err
 	}
 
-	orderInfo, err := a.app.OrderApp.SearchOrderByIdAndUserUid(orderId, userUid)
+	assets, err := a.app.AssetApp.SearchAssetsByUser(userUid)
 	if err != nil {
-		if strings.Contains(err.Error(), "ERROR: invalid input syntax for type uuid:") {
-			return 400, nil, err
-		}
-
 		return 500, nil, err
 	}
 
-	if orderInfo == nil {
-		return 404, nil, entity.ErrInvalidOrder
-	}
-
-	err = a.app.OrderApp.OrderVerification(orderType, orderInfo.Brokerage.Country,
-		quantity, price, orderInfo.Currency)
+	orders, err := a.app.OrderApp.SearchOrdersFromUser(userUid)
 	if err != nil {
-		return 400, nil, err
+		return 500, nil, err
 	}
 
-	brokerageInfo, err := a.app.BrokerageApp.SearchBrokerage("SINGLE",
-		brokerage, "")
+	earnings, err := a.app.EarningsApp.SearchAllEarningsFromUser(userUid)
 	if err != nil {
-		return 400, nil, err
+		return 500, nil, err
 	}
 
-	updatedOrder, err := a.app.OrderApp.UpdateOrder(orderId, userUid, price,
-		quantity, orderType, date, brokerageInfo[0].Id, orderInfo.Currency)
+	positions, err := a.app.BrokerageApp.AccountPositions(userUid)
 	if err != nil {
 		return 500, nil, err
 	}
 
-	return 200, updatedOrder, nil
+	return 200, &entity.PortfolioExport{
+		Assets:    assets,
+		Orders:    orders,
+		Earnings:  earnings,
+		Positions: positions,
+	}, nil
 }
 
-func (a *Application) ApiCreateEarnings(symbol string, currency string,
-	earningType string, date string, earnings float64, userUid string) (int,
-	*entity.Earnings, error) {
+// ApiCreateBackup returns the backup of the whole portfolio of the user, with
+// the assets identified by the symbol and the country so it can be restored in
+// another account.
+func (a *Application) ApiCreateBackup(userUid string) (int,
+	*entity.PortfolioBackup, error) {
 
-	err := a.app.EarningsApp.EarningsVerification(symbol, currency, earningType,
-		date, earnings)
+	assets, err := a.app.AssetApp.SearchAssetsByUser(userUid)
 	if err != nil {
-		return 400, nil, err
+		return 500, nil, err
 	}
 
-	assetInfo, err := a.app.AssetApp.SearchAssetByUser(symbol, userUid, false,
-		false)
+	brokerages, err := a.app.BrokerageApp.SearchBrokerage("ALL", "", "",
+		userUid)
 	if err != nil {
 		return 500, nil, err
 	}
 
-	if assetInfo == nil {
-		return 404, nil, nil
+	accounts, err := a.app.BrokerageApp.SearchAccounts(userUid)
+	if err != nil {
+		return 500, nil, err
 	}
 
-	earningCreated, err := a.app.EarningsApp.CreateEarning(earningType, earnings,
-		currency, date, assetInfo.AssetType.Country, assetInfo.Id, userUid)
+	orders, err := a.app.OrderApp.SearchOrdersFromUser(userUid)
 	if err != nil {
-		return 400, nil, err
+		return 500, nil, err
 	}
 
-	return 200, earningCreated, nil
+	earnings, err := a.app.EarningsApp.SearchAllEarningsFromUser(userUid)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, entity.NewPortfolioBackup(assets, brokerages, accounts, orders,
+		earnings, time.Now()), nil
 }
 
-func (a *Application) ApiGetEarningsFromAssetUser(symbol string, userUid string,
-	orderBy string, limit string, offset string) (
-	int, []entity.Earnings, error) {
+// ApiRestoreBackup replays the backup in the portfolio of the user. Restoring
+// the same backup again does not duplicate the orders and earnings.
+func (a *Application) ApiRestoreBackup(backup entity.PortfolioBackup,
+	userUid string) (int, *entity.BackupRestore, error) {
 
-	var earningsReturn []entity.Earnings
+	restore, err := a.app.BackupApp.RestoreBackup(backup, userUid)
+	if err != nil {
+		switch err {
+		case entity.ErrInvalidBackupVersion, entity.ErrInvalidBackupAccount,
+			entity.ErrInvalidBackupAsset, entity.ErrInvalidBackupAssetType,
+			entity.ErrInvalidBackupOrder, entity.ErrInvalidBackupEarning:
+			return 400, nil, err
+		}
+		return 500, nil, err
+	}
 
-	if symbol == "" {
-		return 400, nil, entity.ErrInvalidApiQuerySymbolBlank
+	return 200, restore, nil
+}
+
+// ApiReconcileCustody compares the custody of the user in a brokerage at a
+// date with the positions computed from the orders until that date. The
+// custody is the positions informed or the positions of the brokerage in a
+// position report of the B3. Only the assets whose quantities differ are
+// returned.
+func (a *Application) ApiReconcileCustody(brokerage string, date string,
+	positions []entity.CustodyPosition, file []byte, userUid string) (int,
+	[]entity.CustodyReconciliation, error) {
+
+	custodyDate, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return 400, nil, entity.ErrInvalidCustodyDate
 	}
 
-	assetInfo, err := a.app.AssetApp.SearchAssetByUser(symbol, userUid, false,
-		false)
+	brokerageInfo, err := a.app.BrokerageApp.SearchBrokerage("SINGLE",
+		brokerage, "", userUid)
 	if err != nil {
-		return 500, nil, err
+		return 400, nil, err
 	}
 
-	if assetInfo == nil {
-		return 404, nil, entity.ErrMessageApiAssetSymbolUser
+	snapshot := entity.CustodySnapshot{
+		Brokerage: &brokerageInfo[0],
+		Date:      custodyDate,
+		Positions: positions,
 	}
 
-	if limit == "" && offset == "" {
-		earningsReturn, err = a.app.EarningsApp.SearchEarningsFromAssetUser(
-			assetInfo.Id, userUid)
+	if len(file) > 0 {
+		report, err := a.app.OrderApp.ReadB3Report(file)
 		if err != nil {
-			return 500, nil, err
+			return 400, nil, err
 		}
-	} else {
 
-		limitInt, err := strconv.Atoi(limit)
-		if err != nil {
-			return 400, nil, entity.ErrInvalidEarningsLimit
+		if report.Type != entity.B3PositionReport {
+			return 400, nil, entity.ErrInvalidCustodyReport
 		}
 
-		offsetInt, err := strconv.Atoi(offset)
-		if err != nil {
-			return 400, nil, entity.ErrInvalidEarningsOffset
+		for _, position := range report.Positions {
+			if !position.Valid() || entity.SearchBrokerageByInstitution(
+				position.Brokerage, brokerageInfo) == nil {
+				continue
+			}
+
+			snapshot.Positions = append(snapshot.Positions,
+				entity.CustodyPosition{
+					Symbol:   position.Symbol,
+					Quantity: position.Quantity,
+				})
 		}
+	}
 
-		earningsReturn, err = a.app.EarningsApp.SearchEarningsFromAssetUserByDate(
-			assetInfo.Id, userUid, orderBy, limitInt, offsetInt)
-		if err != nil {
+	reconciliation, err := a.app.ReconciliationApp.ReconcileCustody(snapshot,
+		userUid)
+	if err != nil {
+		switch err {
+		case entity.ErrInvalidCustodyBrokerage, entity.ErrInvalidCustodyDate,
+			entity.ErrInvalidCustodyPosition:
 			return 400, nil, err
 		}
+		return 500, nil, err
 	}
 
-	if earningsReturn == nil {
-		return 404, nil, entity.ErrMessageApiEarningAssetUser
+	discrepancies := []entity.CustodyReconciliation{}
+	for _, position := range reconciliation {
+		if position.Status != entity.CustodyMatch {
+			discrepancies = append(discrepancies, position)
+		}
 	}
 
-	return 200, earningsReturn, nil
+	return 200, discrepancies, nil
 }
 
-func (a *Application) ApiUpdateEarningsFromUser(earningId string, earning float64,
-	earningType string, date string, userUid string) (int, *entity.Earnings,
-	error) {
+// ApiCreateCashMovement registers a movement of money in a brokerage account
+// of the user. The movement is in the currency of the account, and a transfer
+// must be between accounts with the same currency.
+func (a *Application) ApiCreateCashMovement(movementType string,
+	amount float64, currency string, date string, description string,
+	accountId string, toAccountId string, userUid string) (int,
+	*entity.CashMovement, error) {
 
-	// Get actual information about the requested earning for update
-	searchedEarning, err := a.app.EarningsApp.SearchEarningsFromUser(earningId,
-		userUid)
+	movementDate, err := time.Parse("2006-01-02", date)
 	if err != nil {
+		return 400, nil, entity.ErrInvalidCashMovementDate
+	}
+
+	if accountId == "" {
+		return 400, nil, entity.ErrInvalidCashMovementAccount
+	}
+
+	account, err := a.app.BrokerageApp.SearchAccountById(accountId, userUid)
+	if err == entity.ErrInvalidBrokerageAccount {
+		return 400, nil, err
+	} else if err != nil {
 		return 500, nil, err
 	}
 
-	if searchedEarning == nil {
-		return 404, nil, entity.ErrMessageApiEarningId
+	if currency == "" {
+		currency = account.Currency()
 	}
 
-	// Get information about the asset associated to this earning
-	assetInfo, err := a.app.AssetApp.SearchAsset(searchedEarning.Asset.Symbol)
+	if currency != account.Currency() {
+		return 400, nil, entity.ErrInvalidCashMovementCurrency
+	}
+
+	var toAccount *entity.BrokerageAccount
+	if toAccountId != "" {
+		toAccount, err = a.app.BrokerageApp.SearchAccountById(toAccountId,
+			userUid)
+		if err == entity.ErrInvalidBrokerageAccount {
+			return 400, nil, err
+		} else if err != nil {
+			return 500, nil, err
+		}
+
+		if toAccount.Currency() != currency {
+			return 400, nil, entity.ErrInvalidCashMovementCurrency
+		}
+	}
+
+	movement, err := a.app.CashApp.CreateMovement(movementType, amount,
+		currency, movementDate, description, accountId, toAccountId, userUid)
 	if err != nil {
+		switch err {
+		case entity.ErrInvalidCashMovementType,
+			entity.ErrInvalidCashMovementAmount,
+			entity.ErrInvalidCashMovementDate, entity.ErrInvalidCurrency,
+			entity.ErrInvalidCashMovementAccount,
+			entity.ErrInvalidCashMovementTransfer:
+			return 400, nil, err
+		}
 		return 500, nil, err
 	}
 
-	// Verification if the information received in the body attends the
-	// requirements of the Earning table
-	err = a.app.EarningsApp.EarningsVerification(searchedEarning.Asset.Symbol,
-		searchedEarning.Currency, earningType, date, earning)
+	movement.Account = account
+	movement.ToAccount = toAccount
+
+	return 200, movement, nil
+}
+
+// ApiCashLedger returns the cash ledger of the user until the date, or until
+// today when the date is blank, with the entries of the cash movements, the
+// orders and the earnings. All the accounts are returned when the account is
+// blank.
+func (a *Application) ApiCashLedger(accountId string, date string,
+	userUid string) (int, []entity.CashEntry, error) {
+
+	ledgerDate, err := cashDate(date)
 	if err != nil {
 		return 400, nil, err
 	}
 
-	// Update the earning information of the earning with specific ID
-	earningsUpdate, err := a.app.EarningsApp.EarningsUpdate(earningType,
-		earning, searchedEarning.Currency, date, assetInfo.AssetType.Country,
-		earningId, userUid)
+	if accountId != "" {
+		_, err = a.app.BrokerageApp.SearchAccountById(accountId, userUid)
+		if err == entity.ErrInvalidBrokerageAccount {
+			return 400, nil, err
+		} else if err != nil {
+			return 500, nil, err
+		}
+	}
+
+	ledger, err := a.app.CashApp.SearchLedger(accountId, ledgerDate, userUid)
 	if err != nil {
 		return 500, nil, err
 	}
 
-	return 200, earningsUpdate, nil
+	return 200, ledger, nil
 }
 
-func (a *Application) ApiGetAssetByUser(symbol string, userUid string,
-	withOrders bool, withOrderResume bool, withPrice bool) (int, *entity.Asset,
-	error) {
+// ApiCashBalances returns the cash of the user in each brokerage account at
+// the date, or today when the date is blank.
+func (a *Application) ApiCashBalances(date string, userUid string) (int,
+	[]entity.CashBalance, error) {
 
-	var assetPrice *entity.SymbolPrice
-	var err error
-	chAssetInfo := make(chan *entity.Asset)
+	balanceDate, err := cashDate(date)
+	if err != nil {
+		return 400, nil, err
+	}
 
-	chAssetInfoErr := make(chan error)
-	chPrice := make(chan *entity.SymbolPrice)
-	chPriceErr := make(chan error)
+	balances, err := a.app.CashApp.SearchBalances(balanceDate, userUid)
+	if err != nil {
+		return 500, nil, err
+	}
 
-	go func() {
-		assetInfo, err := a.app.AssetApp.SearchAsset(symbol)
-		chAssetInfoErr <- err
-		chAssetInfo <- assetInfo
-		close(chAssetInfoErr)
-		close(chAssetInfo)
-	}()
+	return 200, balances, nil
+}
 
-	if err := <-chAssetInfoErr; err != nil {
-		return 500, nil, entity.ErrInvalidAssetSymbol
+// cashDate parses the date of the cash ledger, which is today when blank.
+func cashDate(date string) (time.Time, error) {
+	if date == "" {
+		return time.Now(), nil
 	}
 
-	assetInfo := <-chAssetInfo
-	if assetInfo == nil {
-		return 400, nil, entity.ErrInvalidAssetSymbol
+	ledgerDate, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return time.Time{}, entity.ErrInvalidCashMovementDate
 	}
 
-	if withPrice == true {
-		go func() {
-			var assetPrice *entity.SymbolPrice
-			var err error
+	return ledgerDate, nil
+}
 
-			assetPrice, err = a.app.AssetApp.AssetVerificationPrice(
-				assetInfo.Symbol, assetInfo.AssetType.Country,
-				a.externalInterfaces)
+// ApiCreateRemittance registers money sent from one currency and received in
+// a brokerage account of the user in the currency of the account. The account
+// it was sent from is optional, and when informed the money is sent in its
+// currency.
+func (a *Application) ApiCreateRemittance(sentAmount float64,
+	sentCurrency string, receivedAmount float64, iof float64, fees float64,
+	commercialRate float64, date string, fromAccountId string,
+	toAccountId string, userUid string) (int, *entity.Remittance, error) {
 
-			chPrice <- assetPrice
-			chPriceErr <- err
-			close(chPrice)
-			close(chPriceErr)
-		}()
+	remittanceDate, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return 400, nil, entity.ErrInvalidRemittanceDate
 	}
 
-	searchedAsset, err := a.app.AssetApp.SearchAssetByUser(
-		assetInfo.Symbol, userUid, withOrders, withOrderResume)
+	if toAccountId == "" {
+		return 400, nil, entity.ErrInvalidRemittanceAccount
+	}
+
+	toAccount, err := a.app.BrokerageApp.SearchAccountById(toAccountId,
+		userUid)
+	if err == entity.ErrInvalidBrokerageAccount {
+		return 400, nil, err
+	} else if err != nil {
+		return 500, nil, err
+	}
+
+	var fromAccount *entity.BrokerageAccount
+	if fromAccountId != "" {
+		fromAccount, err = a.app.BrokerageApp.SearchAccountById(fromAccountId,
+			userUid)
+		if err == entity.ErrInvalidBrokerageAccount {
+			return 400, nil, err
+		} else if err != nil {
+			return 500, nil, err
+		}
+
+		if sentCurrency == "" {
+			sentCurrency = fromAccount.Currency()
+		}
+
+		if sentCurrency != fromAccount.Currency() {
+			return 400, nil, entity.ErrInvalidRemittanceCurrency
+		}
+	}
+
+	if sentCurrency == "" {
+		sentCurrency = entity.CostBasisCurrency
+	}
+
+	remittance, err := a.app.RemittanceApp.CreateRemittance(sentAmount,
+		sentCurrency, receivedAmount, toAccount.Currency(), iof, fees,
+		commercialRate, remittanceDate, fromAccountId, toAccountId, userUid)
 	if err != nil {
+		switch err {
+		case entity.ErrInvalidRemittanceAmount, entity.ErrInvalidRemittanceTaxes,
+			entity.ErrInvalidRemittanceRate,
+			entity.ErrInvalidRemittanceCurrency,
+			entity.ErrInvalidRemittanceDate,
+			entity.ErrInvalidRemittanceAccount:
+			return 400, nil, err
+		}
 		return 500, nil, err
 	}
 
-	if searchedAsset == nil {
-		return 404, nil, nil
+	remittance.FromAccount = fromAccount
+	remittance.ToAccount = toAccount
+
+	return 200, remittance, nil
+}
+
+// ApiBrlCostBasis returns the cost in BRL of the positions of the user bought
+// in foreign currencies, converted by the effective rate of the remittances
+// made until each buy.
+func (a *Application) ApiBrlCostBasis(userUid string) (int,
+	[]entity.BrlCostBasis, error) {
+
+	orders, err := a.app.OrderApp.SearchOrdersFromUser(userUid)
+	if err != nil {
+		return 500, nil, err
 	}
 
-	if withPrice == true {
-		assetPrice = <-chPrice
-		err = <-chPriceErr
+	costBasis, err := a.app.RemittanceApp.SearchBrlCostBasis(orders, userUid)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, costBasis, nil
+}
+
+// importB3Movements creates the earnings and the orders of the corporate
+// events of the movements, like the imported statements.
+func (a *Application) importB3Movements(report *entity.B3Report, dryRun bool,
+	userUid string) (int, *entity.B3Report, error) {
+
+	var orderRows []entity.OrderImportRow
+	var earningRows []entity.EarningImportRow
+	var orderMovements, earningMovements []int
+
+	for i, movement := range report.Movements {
+		if movement.Ignored {
+			continue
+		}
+
+		if movement.OrderType != "" {
+			orderRows = append(orderRows, entity.OrderImportRow{
+				Line:      movement.Line,
+				Symbol:    movement.Symbol,
+				Country:   "BR",
+				OrderType: movement.OrderType,
+				Quantity:  movement.Quantity,
+				Price:     movement.Price,
+				Currency:  "BRL",
+				Brokerage: movement.Brokerage,
+				Date:      movement.Date,
+				Errors:    movement.Errors,
+			})
+			orderMovements = append(orderMovements, i)
+			continue
+		}
+
+		earningRows = append(earningRows, entity.EarningImportRow{
+			Line:        movement.Line,
+			Symbol:      movement.Symbol,
+			Country:     "BR",
+			EarningType: movement.EarningType,
+			Amount:      movement.Amount,
+			Currency:    "BRL",
+			Date:        movement.Date,
+			Errors:      movement.Errors,
+		})
+		earningMovements = append(earningMovements, i)
+	}
+
+	httpStatusCode, orderRows, earningRows, orders, earnings, err :=
+		a.importStatementRows(orderRows, earningRows, dryRun, userUid)
+	if httpStatusCode == 500 || httpStatusCode == 404 {
+		return httpStatusCode, nil, err
+	}
+
+	for i, row := range orderRows {
+		movement := &report.Movements[orderMovements[i]]
+		movement.Errors = row.Errors
+		movement.NewAsset = row.NewAsset
+		movement.Duplicate = row.Duplicate
+	}
+
+	for i, row := range earningRows {
+		movement := &report.Movements[earningMovements[i]]
+		movement.Errors = row.Errors
+		movement.NewAsset = row.NewAsset
+		movement.Duplicate = row.Duplicate
+	}
+	report.Orders, report.Earnings = orders, earnings
+
+	return httpStatusCode, report, err
+}
+
+// importAssets are the assets of the imported rows, searched once for each
+// symbol when the rows are validated and reused when they are created. A
+// symbol with neither asset nor error is an asset not registered yet. The
+// assets not registered yet are kept in created, without Id, to be created
+// together with the imported rows.
+type importAssets struct {
+	assets  map[string]*entity.Asset
+	errors  map[string]error
+	created []entity.Asset
+}
+
+func newImportAssets() *importAssets {
+	return &importAssets{
+		assets: map[string]*entity.Asset{},
+		errors: map[string]error{},
+	}
+}
+
+// importStatementRows imports the orders and the earnings of a statement.
+// Both are validated before anything is created, and they are created
+// together with the assets and the default brokerage accounts not registered
+// yet, in a single transaction.
+func (a *Application) importStatementRows(orderRows []entity.OrderImportRow,
+	earningRows []entity.EarningImportRow, dryRun bool, userUid string) (int,
+	[]entity.OrderImportRow, []entity.EarningImportRow, []entity.Order,
+	[]entity.Earnings, error) {
+
+	assets := newImportAssets()
+
+	orderStatusCode, brokerages, err := a.validateOrderRows(orderRows, assets,
+		userUid)
+	if orderStatusCode == 500 || orderStatusCode == 404 {
+		return orderStatusCode, nil, nil, nil, nil, err
+	}
+
+	earningStatusCode, err := a.validateEarningRows(earningRows, assets,
+		userUid)
+	if earningStatusCode == 500 || earningStatusCode == 404 {
+		return earningStatusCode, nil, nil, nil, nil, err
+	}
+
+	if orderStatusCode == 400 || earningStatusCode == 400 {
+		return 400, orderRows, earningRows, nil, nil,
+			entity.ErrInvalidOrderImportRows
+	}
+
+	if dryRun {
+		return 200, orderRows, earningRows, nil, nil, nil
+	}
+
+	httpStatusCode, orders, accounts, err := a.newImportOrders(orderRows,
+		assets, brokerages, userUid)
+	if err != nil {
+		return httpStatusCode, nil, nil, nil, nil, err
+	}
+
+	httpStatusCode, earnings, err := a.newImportEarnings(earningRows, assets,
+		userUid)
+	if err != nil {
+		return httpStatusCode, nil, nil, nil, nil, err
+	}
+
+	if orders == nil && earnings == nil {
+		return 200, orderRows, earningRows, nil, nil, nil
+	}
+
+	ordersCreated, earningsCreated, err := a.app.OrderApp.CreateImport(
+		assets.created, accounts, orders, earnings)
+	if err != nil {
+		return 500, nil, nil, nil, nil, err
+	}
+
+	return 200, orderRows, earningRows, ordersCreated, earningsCreated, nil
+}
+
+// importOrderRows validates the imported orders and creates them when every
+// row is valid and it is not a dry run. The orders already registered by the
+// user are marked as duplicated and are not created again, so a file can be
+// imported more than once. The orders are created in a single transaction,
+// together with the assets and the default brokerage accounts not registered
+// yet, so nothing is kept when one of them fails.
+func (a *Application) importOrderRows(rows []entity.OrderImportRow,
+	dryRun bool, userUid string) (int, []entity.OrderImportRow, []entity.Order,
+	error) {
+
+	assets := newImportAssets()
+
+	httpStatusCode, brokerages, err := a.validateOrderRows(rows, assets,
+		userUid)
+	if httpStatusCode == 400 {
+		return 400, rows, nil, err
+	} else if err != nil {
+		return httpStatusCode, nil, nil, err
+	}
+
+	if dryRun {
+		return 200, rows, nil, nil
+	}
+
+	httpStatusCode, orders, accounts, err := a.newImportOrders(rows, assets,
+		brokerages, userUid)
+	if err != nil {
+		return httpStatusCode, nil, nil, err
+	}
+
+	if orders == nil {
+		return 200, rows, nil, nil
+	}
+
+	ordersCreated, _, err := a.app.OrderApp.CreateImport(assets.created,
+		accounts, orders, nil)
+	if err != nil {
+		return 500, nil, nil, err
+	}
+
+	return 200, rows, ordersCreated, nil
+}
+
+// validateOrderRows reports the problems of each imported order in its row
+// and marks the duplicated ones. The brokerages of the rows are returned by
+// their name in lower case.
+func (a *Application) validateOrderRows(rows []entity.OrderImportRow,
+	assets *importAssets, userUid string) (int, map[string]*entity.Brokerage,
+	error) {
+
+	assetOrders := map[string][]entity.Order{}
+	brokerages := map[string]*entity.Brokerage{}
+	brokerageErrors := map[string]error{}
+	validRows := true
+
+	for i := range rows {
+		row := &rows[i]
+
+		err := a.app.OrderApp.OrderVerification(row.OrderType, row.Country,
+			row.Quantity, row.Price, row.Currency, row.Date)
 		if err != nil {
-			return 400, nil, err
+			row.AddError(err)
+		}
+
+		if err := a.searchImportAsset(assets, row.Symbol,
+			row.Country); err != nil {
+			return 500, nil, err
+		}
+
+		assetInfo := assets.assets[row.Symbol]
+		if _, searched := assetOrders[row.Symbol]; !searched &&
+			assetInfo != nil {
+			assetOrders[row.Symbol], err = a.app.OrderApp.
+				SearchOrdersFromAssetUser(assetInfo.Id, userUid)
+			if err != nil {
+				return 500, nil, err
+			}
+		}
+
+		if assets.errors[row.Symbol] != nil {
+			row.AddError(assets.errors[row.Symbol])
+		} else if row.Symbol != "" && assetInfo == nil {
+			row.NewAsset = true
+		}
+
+		brokerageName := strings.ToLower(row.Brokerage)
+		if _, searched := brokerageErrors[brokerageName]; !searched &&
+			brokerageName != "" {
+			brokerageInfo, err := a.app.BrokerageApp.SearchBrokerage("SINGLE",
+				row.Brokerage, "", userUid)
+			if err == nil {
+				brokerages[brokerageName] = &brokerageInfo[0]
+			}
+			brokerageErrors[brokerageName] = err
+		}
+
+		if brokerageErrors[brokerageName] != nil {
+			row.AddError(brokerageErrors[brokerageName])
+		}
+
+		if !row.Valid() {
+			validRows = false
 		}
 	}
 
-	searchedAsset.Price = assetPrice
+	entity.DuplicateOrderRows(rows, assetOrders)
 
-	return 200, searchedAsset, nil
+	if !validRows {
+		return 400, brokerages, entity.ErrInvalidOrderImportRows
+	}
+
+	return 200, brokerages, nil
+}
+
+// newImportOrders returns the orders of the validated rows, except the
+// duplicated ones, in the default account of each brokerage. The assets and
+// the default accounts not registered yet are not created, the accounts are
+// returned to be created together with the orders.
+func (a *Application) newImportOrders(rows []entity.OrderImportRow,
+	assets *importAssets, brokerages map[string]*entity.Brokerage,
+	userUid string) (int, []entity.Order, []entity.BrokerageAccount, error) {
+
+	accounts := map[string]*entity.BrokerageAccount{}
+	var newAccounts []entity.BrokerageAccount
+	var orders []entity.Order
+	for _, row := range rows {
+		if row.Duplicate {
+			continue
+		}
+
+		httpStatusCode, assetInfo, err := a.newImportAsset(assets,
+			row.Symbol, row.Country)
+		if err != nil {
+			return httpStatusCode, nil, nil, err
+		}
+
+		brokerageName := strings.ToLower(row.Brokerage)
+		if accounts[brokerageName] == nil {
+			accountInfo, err := a.app.BrokerageApp.SearchDefaultAccount(
+				*brokerages[brokerageName], userUid)
+			if err != nil {
+				return 500, nil, nil, err
+			}
+			accounts[brokerageName] = accountInfo
+
+			if accountInfo.Id == "" {
+				newAccounts = append(newAccounts, *accountInfo)
+			}
+		}
+
+		order, err := entity.NewOrder(row.Quantity, row.Price, row.Currency,
+			row.OrderType, entity.StringToTime(row.Date),
+			accounts[brokerageName].Id, assetInfo.Id, userUid)
+		if err != nil {
+			return 500, nil, nil, err
+		}
+		order.Asset = &entity.Asset{Id: assetInfo.Id, Symbol: assetInfo.Symbol}
+		order.Account = accounts[brokerageName]
+		order.Fees = row.Fees
+		order.WithheldTax = row.WithheldTax
+		if row.SettlementDate != "" {
+			settlementDate := entity.StringToTime(row.SettlementDate)
+			order.SettlementDate = &settlementDate
+		}
+
+		orders = append(orders, *order)
+	}
+
+	return 200, orders, newAccounts, nil
+}
+
+// validateEarningRows reports the problems of each imported earning in its
+// row and marks the ones already registered by the user as duplicated, like
+// the imported orders.
+func (a *Application) validateEarningRows(rows []entity.EarningImportRow,
+	assets *importAssets, userUid string) (int, error) {
+
+	assetEarnings := map[string][]entity.Earnings{}
+	validRows := true
+
+	for i := range rows {
+		row := &rows[i]
+
+		if !entity.ValidEarningTypes[row.EarningType] {
+			row.AddError(entity.ErrInvalidEarningType)
+		}
+
+		if row.Amount <= 0 {
+			row.AddError(entity.ErrInvalidEarningsAmount)
+		}
+
+		if row.Date == "" {
+			row.AddError(entity.ErrInvalidEarningsCreateBlankFields)
+		}
+
+		earning := entity.Earnings{Currency: row.Currency}
+		if err := earning.Validate(row.Country); err != nil {
+			row.AddError(err)
+		}
+
+		if err := a.searchImportAsset(assets, row.Symbol,
+			row.Country); err != nil {
+			return 500, err
+		}
+
+		assetInfo := assets.assets[row.Symbol]
+		if _, searched := assetEarnings[row.Symbol]; !searched &&
+			assetInfo != nil {
+			var err error
+			assetEarnings[row.Symbol], err = a.app.EarningsApp.
+				SearchEarningsFromAssetUser(assetInfo.Id, userUid)
+			if err != nil {
+				return 500, err
+			}
+		}
+
+		if assets.errors[row.Symbol] != nil {
+			row.AddError(assets.errors[row.Symbol])
+		} else if row.Symbol != "" && assetInfo == nil {
+			row.NewAsset = true
+		}
+
+		if !row.Valid() {
+			validRows = false
+		}
+	}
+
+	entity.DuplicateEarningRows(rows, assetEarnings)
+
+	if !validRows {
+		return 400, entity.ErrInvalidOrderImportRows
+	}
+
+	return 200, nil
+}
+
+// newImportEarnings returns the earnings of the validated rows, except the
+// duplicated ones. The assets not registered yet are not created, like in
+// newImportOrders.
+func (a *Application) newImportEarnings(rows []entity.EarningImportRow,
+	assets *importAssets, userUid string) (int, []entity.Earnings, error) {
+
+	var earnings []entity.Earnings
+	for _, row := range rows {
+		if row.Duplicate {
+			continue
+		}
+
+		httpStatusCode, assetInfo, err := a.newImportAsset(assets,
+			row.Symbol, row.Country)
+		if err != nil {
+			return httpStatusCode, nil, err
+		}
+
+		earning, err := entity.NewEarnings(row.EarningType, row.Amount,
+			row.Currency, entity.StringToTime(row.Date), row.Country,
+			assetInfo.Id, userUid)
+		if err != nil {
+			return 500, nil, err
+		}
+		earning.Asset = &entity.Asset{Id: assetInfo.Id,
+			Symbol: assetInfo.Symbol}
+		earning.WithheldTax = row.WithheldTax
+
+		earnings = append(earnings, *earning)
+	}
+
+	return 200, earnings, nil
+}
+
+func (a *Application) ApiAssetsPerAssetType(assetType string, country string,
+	ordersInfo bool, withPrice bool, userUid string) (int, *entity.AssetType,
+	error) {
+
+	chPrice := make(chan *entity.SymbolPrice)
+	defer close(chPrice)
+	var assetPrice *entity.SymbolPrice
+
+	if assetType == "" {
+		return 400, nil, entity.ErrInvalidApiQueryTypeBlank
+	}
+
+	if country == "" {
+		return 400, nil, entity.ErrInvalidApiQueryCountryBlank
+	}
+
+	searchedAssetType, err := a.app.AssetApp.SearchAssetPerAssetType(assetType,
+		country, userUid, ordersInfo)
+	if err != nil {
+		return 400, nil, err
+	}
+
+	if withPrice == true && assetType == entity.FixedIncomeAssetType {
+		for i, assetInfo := range searchedAssetType.Assets {
+			searchedAssetType.Assets[i].Price, searchedAssetType.Assets[i].
+				FixedIncome, err = a.fixedIncomePrice(assetInfo, userUid)
+			if err != nil {
+				return 500, nil, err
+			}
+		}
+	} else if withPrice == true {
+		for _, assetInfo := range searchedAssetType.Assets {
+			go func(assetSymbol string) {
+				var assetPrice *entity.SymbolPrice
+
+				assetPrice, err = a.app.AssetApp.AssetVerificationPrice(
+					assetSymbol, searchedAssetType.Country, a.externalInterfaces)
+
+				chPrice <- assetPrice
+			}(assetInfo.Symbol)
+		}
+
+		for i := 0; i < len(searchedAssetType.Assets); i++ {
+			assetPrice = <-chPrice
+			if assetPrice != nil {
+				for i, assetInfo := range searchedAssetType.Assets {
+					if assetPrice.Symbol == assetInfo.Symbol {
+						searchedAssetType.Assets[i].Price = assetPrice
+					}
+				}
+			}
+
+		}
+
+	}
+
+	return 200, searchedAssetType, nil
+
+}
+
+func (a *Application) ApiDeleteAssets(myUser bool, userUid string,
+	symbol string) (int, *entity.Asset, error) {
+
+	var deletedAssetInfo *entity.Asset
+	var assetUserDeleted *entity.AssetUsers
+
+	// If the myUser flag is not true, our application assumes that the Asset
+	// will be deleted for every user. Only user with admin privileges are enabled
+	// for such action. When myUser is false, then, our application assumes that
+	// the asset will be deleted only for the user requested for it.
+	if !myUser {
+
+		// Search to find if the user has admin privileges
+		searchedUser, _ := a.app.UserApp.SearchUser(userUid)
+		if searchedUser.Type != "admin" {
+			return 403, nil, entity.ErrInvalidUserAdminPrivilege
+		}
+
+		// Search the Asset information
+		assetInfo, err := a.app.AssetApp.SearchAsset(symbol)
+		if err != nil {
+			return 500, nil, err
+		}
+
+		if assetInfo == nil {
+			return 404, nil, entity.ErrInvalidAssetSymbol
+		}
+
+		auditLog, err := entity.NewAuditLog(userUid, entity.AuditActionDelete,
+			entity.AuditEntityAsset, assetInfo.Id, assetInfo, nil)
+		if err != nil {
+			return 500, nil, err
+		}
+
+		// Delete Asset from the database
+		deletedAsset, err := a.app.AssetApp.DeleteAsset(assetInfo.Id, auditLog)
+		if err != nil {
+			return 500, nil, err
+		}
+
+		if deletedAsset == nil {
+			return 404, nil, entity.ErrInvalidDeleteAsset
+		}
+
+		deletedAssetInfo = assetInfo
+
+	} else {
+
+		// Search if the user has this Asset
+		assetInfo, err := a.app.AssetApp.SearchAssetByUser(symbol, userUid,
+			false, false)
+		if err != nil {
+			return 500, nil, err
+		}
+
+		if assetInfo == nil {
+			return 404, nil, entity.ErrInvalidAssetSymbol
+		}
+
+		// Delete Orders from this Asset for a specific user
+		_, err = a.app.OrderApp.DeleteOrdersFromAssetUser(
+			assetInfo.Id, userUid)
+		if err != nil {
+			return 500, nil, err
+		}
+
+		_, err = a.app.EarningsApp.DeleteEarningsFromAssetUser(assetInfo.Id,
+			userUid)
+		if err != nil {
+			return 500, nil, err
+		}
+
+		// Delete Asset for this user
+		assetUserDeleted, err = a.app.AssetUserApp.DeleteAssetUserRelation(
+			assetInfo.Id, userUid)
+		if err != nil {
+			return 500, nil, err
+		}
+
+		if assetUserDeleted == nil {
+			return 404, nil, entity.ErrInvalidAssetUser
+		}
+
+		deletedAssetInfo = assetInfo
+	}
+
+	return 200, deletedAssetInfo, nil
+}
+
+func (a *Application) ApiGetOrdersFromAssetUser(symbol string, userUid string,
+	orderBy string, limit string, offset string) (int, []entity.Order, error) {
+	var ordersInfo []entity.Order
+
+	if symbol == "" {
+		return 400, nil, entity.ErrInvalidApiQuerySymbolBlank
+	}
+
+	assetInfo, err := a.app.AssetApp.SearchAssetByUser(symbol, userUid, false,
+		false)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	if assetInfo == nil {
+		return 404, nil, entity.ErrInvalidAssetSymbol
+	}
+
+	if limit == "" && offset == "" {
+		ordersInfo, err = a.app.OrderApp.SearchOrdersFromAssetUser(assetInfo.Id,
+			userUid)
+		if err != nil {
+			return 500, nil, err
+		}
+	} else {
+
+		limitInt, err := strconv.Atoi(limit)
+		if err != nil {
+			return 400, nil, entity.ErrInvalidOrderLimit
+		}
+
+		offsetInt, err := strconv.Atoi(offset)
+		if err != nil {
+			return 400, nil, entity.ErrInvalidOrderOffset
+		}
+
+		ordersInfo, err = a.app.OrderApp.SearchOrdersSearchFromAssetUserByDate(
+			assetInfo.Id, userUid, orderBy, limitInt, offsetInt)
+		if err != nil {
+			return 500, nil, err
+		}
+	}
+
+	if ordersInfo == nil {
+		return 404, nil, entity.ErrInvalidOrder
+	}
+
+	return 200, ordersInfo, nil
+}
+
+func (a *Application) ApiUpdateOrdersFromUser(orderId string, userUid string,
+	orderType string, price float64, quantity float64, date string,
+	brokerage string, accountId string) (int, *entity.Order, error) {
+
+	if orderType == "" || price == 0 || quantity == 0 || date == "" ||
+		(brokerage == "" && accountId == "") {
+		return 400, nil, entity.ErrInvalidApiOrderUpdate
+	}
+
+	orderInfo, err := a.app.OrderApp.SearchOrderByIdAndUserUid(orderId, userUid)
+	if err != nil {
+		if strings.Contains(err.Error(), "ERROR: invalid input syntax for type uuid:") {
+			return 400, nil, err
+		}
+
+		return 500, nil, err
+	}
+
+	if orderInfo == nil {
+		return 404, nil, entity.ErrInvalidOrder
+	}
+
+	err = a.app.OrderApp.OrderVerification(orderType, orderInfo.Brokerage.Country,
+		quantity, price, orderInfo.Currency, date)
+	if err != nil {
+		return 400, nil, err
+	}
+
+	httpStatusCode, accountInfo, err := a.orderAccount(brokerage, accountId,
+		userUid)
+	if err != nil {
+		return httpStatusCode, nil, err
+	}
+
+	updatedOrder, err := a.app.OrderApp.UpdateOrder(orderId, userUid, price,
+		quantity, orderType, date, accountInfo.Id, orderInfo.Currency)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, updatedOrder, nil
+}
+
+func (a *Application) ApiCreateEarnings(symbol string, currency string,
+	earningType string, date string, earnings float64, userUid string) (int,
+	*entity.Earnings, error) {
+
+	err := a.app.EarningsApp.EarningsVerification(symbol, currency, earningType,
+		date, earnings)
+	if err != nil {
+		return 400, nil, err
+	}
+
+	assetInfo, err := a.app.AssetApp.SearchAssetByUser(symbol, userUid, false,
+		false)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	if assetInfo == nil {
+		return 404, nil, nil
+	}
+
+	earningCreated, err := a.app.EarningsApp.CreateEarning(earningType, earnings,
+		currency, date, assetInfo.AssetType.Country, assetInfo.Id, userUid)
+	if err != nil {
+		return 400, nil, err
+	}
+
+	return 200, earningCreated, nil
+}
+
+func (a *Application) ApiGetEarningsFromAssetUser(symbol string, userUid string,
+	orderBy string, limit string, offset string) (
+	int, []entity.Earnings, error) {
+
+	var earningsReturn []entity.Earnings
+
+	if symbol == "" {
+		return 400, nil, entity.ErrInvalidApiQuerySymbolBlank
+	}
+
+	assetInfo, err := a.app.AssetApp.SearchAssetByUser(symbol, userUid, false,
+		false)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	if assetInfo == nil {
+		return 404, nil, entity.ErrMessageApiAssetSymbolUser
+	}
+
+	if limit == "" && offset == "" {
+		earningsReturn, err = a.app.EarningsApp.SearchEarningsFromAssetUser(
+			assetInfo.Id, userUid)
+		if err != nil {
+			return 500, nil, err
+		}
+	} else {
+
+		limitInt, err := strconv.Atoi(limit)
+		if err != nil {
+			return 400, nil, entity.ErrInvalidEarningsLimit
+		}
+
+		offsetInt, err := strconv.Atoi(offset)
+		if err != nil {
+			return 400, nil, entity.ErrInvalidEarningsOffset
+		}
+
+		earningsReturn, err = a.app.EarningsApp.SearchEarningsFromAssetUserByDate(
+			assetInfo.Id, userUid, orderBy, limitInt, offsetInt)
+		if err != nil {
+			return 400, nil, err
+		}
+	}
+
+	if earningsReturn == nil {
+		return 404, nil, entity.ErrMessageApiEarningAssetUser
+	}
+
+	return 200, earningsReturn, nil
+}
+
+func (a *Application) ApiUpdateEarningsFromUser(earningId string, earning float64,
+	earningType string, date string, userUid string) (int, *entity.Earnings,
+	error) {
+
+	// Get actual information about the requested earning for update
+	searchedEarning, err := a.app.EarningsApp.SearchEarningsFromUser(earningId,
+		userUid)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	if searchedEarning == nil {
+		return 404, nil, entity.ErrMessageApiEarningId
+	}
+
+	// Get information about the asset associated to this earning
+	assetInfo, err := a.app.AssetApp.SearchAsset(searchedEarning.Asset.Symbol)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	// Verification if the information received in the body attends the
+	// requirements of the Earning table
+	err = a.app.EarningsApp.EarningsVerification(searchedEarning.Asset.Symbol,
+		searchedEarning.Currency, earningType, date, earning)
+	if err != nil {
+		return 400, nil, err
+	}
+
+	// Update the earning information of the earning with specific ID
+	earningsUpdate, err := a.app.EarningsApp.EarningsUpdate(earningType,
+		earning, searchedEarning.Currency, date, assetInfo.AssetType.Country,
+		earningId, userUid)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, earningsUpdate, nil
+}
+
+func (a *Application) ApiGetAssetByUser(symbol string, userUid string,
+	withOrders bool, withOrderResume bool, withPrice bool) (int, *entity.Asset,
+	error) {
+
+	var assetPrice *entity.SymbolPrice
+	var err error
+	chAssetInfo := make(chan *entity.Asset)
+
+	chAssetInfoErr := make(chan error)
+	chPrice := make(chan *entity.SymbolPrice)
+	chPriceErr := make(chan error)
+
+	go func() {
+		assetInfo, err := a.app.AssetApp.SearchAsset(symbol)
+		chAssetInfoErr <- err
+		chAssetInfo <- assetInfo
+		close(chAssetInfoErr)
+		close(chAssetInfo)
+	}()
+
+	if err := <-chAssetInfoErr; err != nil {
+		return 500, nil, entity.ErrInvalidAssetSymbol
+	}
+
+	assetInfo := <-chAssetInfo
+	if assetInfo == nil {
+		return 400, nil, entity.ErrInvalidAssetSymbol
+	}
+
+	// The fixed income assets do not have a market quote. Their price is
+	// accrued from the orders of the user.
+	if withPrice == true && assetInfo.AssetType.Type ==
+		entity.FixedIncomeAssetType {
+		go func() {
+			var assetPrice *entity.SymbolPrice
+			var err error
+
+			assetPrice, assetInfo.FixedIncome, err = a.fixedIncomePrice(
+				*assetInfo, userUid)
+
+			chPrice <- assetPrice
+			chPriceErr <- err
+			close(chPrice)
+			close(chPriceErr)
+		}()
+	} else if withPrice == true {
+		go func() {
+			var assetPrice *entity.SymbolPrice
+			var err error
+
+			assetPrice, err = a.app.AssetApp.AssetVerificationPrice(
+				assetInfo.Symbol, assetInfo.AssetType.Country,
+				a.externalInterfaces)
+
+			chPrice <- assetPrice
+			chPriceErr <- err
+			close(chPrice)
+			close(chPriceErr)
+		}()
+	}
+
+	searchedAsset, err := a.app.AssetApp.SearchAssetByUser(
+		assetInfo.Symbol, userUid, withOrders, withOrderResume)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	if searchedAsset == nil {
+		return 404, nil, nil
+	}
+
+	if withPrice == true {
+		assetPrice = <-chPrice
+		err = <-chPriceErr
+		if err != nil {
+			return 400, nil, err
+		}
+	}
+
+	searchedAsset.Price = assetPrice
+	searchedAsset.FixedIncome = assetInfo.FixedIncome
+
+	return 200, searchedAsset, nil
+}
+
+func (a *Application) ApiCreateFixedIncome(symbol string, fullname string,
+	category string, issuer string, indexer string, rate float64,
+	maturity string) (int, *entity.FixedIncome, error) {
+
+	if symbol == "" || fullname == "" {
+		return 400, nil, entity.ErrInvalidFixedIncomeBlank
+	}
+
+	err := a.app.FixedIncomeApp.FixedIncomeVerification(category, issuer,
+		indexer, rate, maturity)
+	if err != nil {
+		return 400, nil, err
+	}
+
+	// Classify the sector of the fixed income in the sector taxonomy
+	sectorInfo, sectorMapping, err := a.app.SectorApp.ClassifySectorLabel(
+		entity.SectorProviderStockfy, entity.FixedIncomeSectorName)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	// Search AssetType
+	assetTypeInfo, err := a.app.AssetTypeApp.SearchAssetType(
+		entity.FixedIncomeAssetType, "BR")
+	if err != nil {
+		return 500, nil, err
+	}
+
+	assetTypeConverted := a.app.AssetTypeApp.
+		AssetTypeConversionToUseCaseStruct(assetTypeInfo[0].Id,
+			assetTypeInfo[0].Type, assetTypeInfo[0].Country)
+
+	// Create Asset
+	preference := ""
+	assetCreated, err := a.app.AssetApp.CreateAsset(symbol, fullname,
+		&preference, sectorInfo.Id, assetTypeConverted)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	err = a.app.SectorApp.AssignSectorMapping(assetCreated.Id, sectorMapping)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	fixedIncomeCreated, err := a.app.FixedIncomeApp.CreateFixedIncome(category,
+		issuer, indexer, rate, maturity, assetCreated.Id)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	fixedIncomeCreated.Asset = &assetCreated
+
+	return 200, fixedIncomeCreated, nil
+}
+
+// fixedIncomePrice accrues the position of the user in a fixed income asset
+// until today. The returned price is the gross value of each unit.
+func (a *Application) fixedIncomePrice(assetInfo entity.Asset,
+	userUid string) (*entity.SymbolPrice, *entity.FixedIncome, error) {
+
+	fixedIncome, err := a.app.FixedIncomeApp.SearchFixedIncomeByAsset(
+		assetInfo.Id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if fixedIncome == nil {
+		return nil, nil, entity.ErrInvalidFixedIncome
+	}
+
+	orders, err := a.app.OrderApp.SearchOrdersFromAssetUser(assetInfo.Id,
+		userUid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fixedIncome.Position, err = a.app.FixedIncomeApp.FixedIncomePosition(
+		*fixedIncome, orders, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	symbolPrice := entity.SymbolPrice{
+		Symbol:   assetInfo.Symbol,
+		Currency: "BRL",
+	}
+	if fixedIncome.Position.Quantity > 0 {
+		symbolPrice.CurrentPrice = fixedIncome.Position.GrossValue /
+			fixedIncome.Position.Quantity
+	}
+
+	return &symbolPrice, fixedIncome, nil
+}
+
+func (a *Application) ApiCreateOption(symbol string, underlyingSymbol string,
+	optionType string, strike float64, expiry string) (int, *entity.Option,
+	error) {
+
+	if symbol == "" {
+		return 400, nil, entity.ErrInvalidOptionSymbol
+	}
+
+	err := a.app.OptionApp.OptionVerification(optionType, strike, expiry)
+	if err != nil {
+		return 400, nil, err
+	}
+
+	// The underlying asset must be a brazilian asset already registered
+	underlying, err := a.app.AssetApp.SearchAsset(underlyingSymbol)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	if underlying == nil || underlying.AssetType == nil ||
+		underlying.AssetType.Country != "BR" {
+		return 400, nil, entity.ErrInvalidOptionUnderlying
+	}
+
+	// Search AssetType
+	assetTypeInfo, err := a.app.AssetTypeApp.SearchAssetType(
+		entity.OptionAssetType, "BR")
+	if err != nil {
+		return 500, nil, err
+	}
+
+	assetTypeConverted := a.app.AssetTypeApp.
+		AssetTypeConversionToUseCaseStruct(assetTypeInfo[0].Id,
+			assetTypeInfo[0].Type, assetTypeInfo[0].Country)
+
+	// The option shares the sector of its underlying asset
+	option := entity.Option{
+		OptionType: optionType,
+		Strike:     strike,
+		Expiry:     entity.StringToTime(expiry),
+	}
+
+	preference := ""
+	assetCreated, err := a.app.AssetApp.CreateAsset(symbol,
+		option.Fullname(underlying.Symbol), &preference, underlying.Sector.Id,
+		assetTypeConverted)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	optionCreated, err := a.app.OptionApp.CreateOption(optionType, strike,
+		expiry, assetCreated.Id, underlying.Id)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	optionCreated.Asset = &assetCreated
+	optionCreated.Underlying = underlying
+
+	return 200, optionCreated, nil
+}
+
+// ApiExpireOptions settles every option expired until the given date. The
+// position of each holder is closed and, for options in the money at the
+// close of the underlying asset on the expiry date, the exercise or
+// assignment is registered as an order on the underlying asset. The orders of
+// an option are stored together with its settlement, so an option is never
+// settled twice. The options without the close of the underlying asset yet
+// are skipped and settled by a later run, and their errors are returned by the
+// option symbol.
+func (a *Application) ApiExpireOptions(date time.Time) (int, []entity.Order,
+	map[string]error, error) {
+
+	var ordersCreated []entity.Order
+	skippedOptions := map[string]error{}
+
+	expiredOptions, err := a.app.OptionApp.SearchExpiredOptions(date)
+	if err != nil {
+		return 500, nil, nil, err
+	}
+
+	for _, option := range expiredOptions {
+		closePrice, err := a.app.AssetApp.AssetClosePrice(
+			option.Underlying.Symbol, "BR", option.Expiry, a.externalInterfaces)
+		if err != nil {
+			skippedOptions[option.Asset.Symbol] = err
+			continue
+		}
+
+		holders, err := a.app.OptionApp.SearchOptionHolders(option.Asset.Id)
+		if err != nil {
+			return 500, nil, nil, err
+		}
+
+		var expiryOrders []entity.Order
+		for _, userUid := range holders {
+			orders, err := a.app.OrderApp.SearchOrdersFromAssetUser(
+				option.Asset.Id, userUid)
+			if err != nil {
+				return 500, nil, nil, err
+			}
+
+			for _, expiryOrder := range a.app.OptionApp.ExpiryOrders(option,
+				orders, closePrice) {
+				expiryOrder.UserUid = userUid
+				expiryOrders = append(expiryOrders, expiryOrder)
+			}
+		}
+
+		optionOrders, err := a.app.OptionApp.SettleOption(option.Id,
+			expiryOrders)
+		if err != nil {
+			return 500, nil, nil, err
+		}
+
+		ordersCreated = append(ordersCreated, optionOrders...)
+	}
+
+	return 200, ordersCreated, skippedOptions, nil
+}
+
+// ApiGetCompanyProfile returns the stored profile of the asset. The profile is
+// fetched from the market data providers when it does not exist yet or it is
+// outdated.
+func (a *Application) ApiGetCompanyProfile(symbol string) (int,
+	*entity.CompanyProfile, error) {
+
+	assetInfo, err := a.app.AssetApp.SearchAsset(symbol)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	if assetInfo == nil {
+		return 404, nil, entity.ErrInvalidAssetSymbol
+	}
+
+	if !entity.HasCompanyProfile(assetInfo.AssetType.Type) {
+		return 400, nil, entity.ErrInvalidCompanyProfileAssetType
+	}
+
+	profile, err := a.app.CompanyProfileApp.SearchCompanyProfile(assetInfo.Id)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	if profile == nil || profile.IsOutdated(time.Now()) {
+		profile, err = a.app.CompanyProfileApp.FetchCompanyProfile(
+			assetInfo.Id, assetInfo.Symbol, assetInfo.AssetType.Country,
+			a.externalInterfaces.FinnhubApi,
+			a.externalInterfaces.AlphaVantageApi)
+		if err != nil {
+			return 500, nil, err
+		}
+	}
+
+	return 200, profile, nil
+}
+
+// ApiRefreshCompanyProfiles fetches again the outdated profiles and the
+// profiles of the assets created since the last refresh.
+func (a *Application) ApiRefreshCompanyProfiles() (int,
+	[]entity.CompanyProfile, error) {
+
+	var profilesRefreshed []entity.CompanyProfile
+
+	outdatedAssets, err := a.app.CompanyProfileApp.SearchOutdatedProfiles(
+		time.Now())
+	if err != nil {
+		return 500, nil, err
+	}
+
+	for _, assetInfo := range outdatedAssets {
+		profile, err := a.app.CompanyProfileApp.FetchCompanyProfile(
+			assetInfo.Id, assetInfo.Symbol, assetInfo.AssetType.Country,
+			a.externalInterfaces.FinnhubApi,
+			a.externalInterfaces.AlphaVantageApi)
+		if err != nil {
+			return 500, nil, err
+		}
+
+		profilesRefreshed = append(profilesRefreshed, *profile)
+	}
+
+	return 200, profilesRefreshed, nil
+}
+
+// ApiImportEarningEvents fetches the earnings announced for the asset from
+// the provider of its market and stores them in the earning events.
+func (a *Application) ApiImportEarningEvents(symbol string) (int,
+	[]entity.EarningEvent, error) {
+
+	assetInfo, err := a.app.AssetApp.SearchAsset(symbol)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	if assetInfo == nil {
+		return 404, nil, entity.ErrInvalidAssetSymbol
+	}
+
+	events, err := a.app.EarningEventApp.FetchEarningEvents(assetInfo.Id,
+		assetInfo.Symbol, assetInfo.AssetType.Country, a.externalInterfaces)
+	if err != nil {
+		if err.Error() == entity.ErrInvalidEarningEventProvider.Error() {
+			return 400, nil, err
+		}
+
+		return 500, nil, err
+	}
+
+	return 200, events, nil
+}
+
+// ApiProposedEarnings returns the earnings the user should have received from
+// the earning events of the asset and that are not registered yet.
+func (a *Application) ApiProposedEarnings(symbol string, userUid string) (int,
+	[]entity.Earnings, error) {
+
+	if symbol == "" {
+		return 400, nil, entity.ErrInvalidApiQuerySymbolBlank
+	}
+
+	assetInfo, err := a.app.AssetApp.SearchAssetByUser(symbol, userUid, false,
+		false)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	if assetInfo == nil {
+		return 404, nil, entity.ErrMessageApiAssetSymbolUser
+	}
+
+	earnings, err := a.earningsFromEvents(assetInfo.Id, userUid)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, earnings, nil
+}
+
+// ApiCreateEarningsFromEvents registers the proposed earnings of the asset for
+// the user. The earnings already created from an event are not duplicated.
+func (a *Application) ApiCreateEarningsFromEvents(symbol string,
+	userUid string) (int, []entity.Earnings, error) {
+
+	statusCode, earnings, err := a.ApiProposedEarnings(symbol, userUid)
+	if err != nil {
+		return statusCode, nil, err
+	}
+
+	earningsCreated, err := a.app.EarningEventApp.CreateEarningsFromEvents(
+		earnings)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, earningsCreated, nil
+}
+
+// ApiRefreshEarningEvents imports the earning events of every asset held by
+// the users and creates the earnings of each holder automatically. Running it
+// more than once does not create duplicated earnings. The assets whose events
+// could not be fetched from the provider are skipped and their errors are
+// returned by the asset symbol.
+func (a *Application) ApiRefreshEarningEvents() (int, []entity.Earnings,
+	map[string]error, error) {
+
+	var earningsCreated []entity.Earnings
+	skippedAssets := map[string]error{}
+
+	heldAssets, err := a.app.EarningEventApp.SearchHeldAssets()
+	if err != nil {
+		return 500, nil, nil, err
+	}
+
+	for _, assetInfo := range heldAssets {
+		_, err := a.app.EarningEventApp.FetchEarningEvents(assetInfo.Id,
+			assetInfo.Symbol, assetInfo.AssetType.Country, a.externalInterfaces)
+		if err != nil {
+			// Markets without a provider of earnings, like cryptocurrencies,
+			// do not have events to import. A failed request to the provider
+			// only skips the asset until the next run
+			if err.Error() != entity.ErrInvalidEarningEventProvider.Error() {
+				skippedAssets[assetInfo.Symbol] = err
+			}
+
+			continue
+		}
+
+		holders, err := a.app.EarningEventApp.SearchEventHolders(assetInfo.Id)
+		if err != nil {
+			return 500, nil, nil, err
+		}
+
+		for _, userUid := range holders {
+			earnings, err := a.earningsFromEvents(assetInfo.Id, userUid)
+			if err != nil {
+				return 500, nil, nil, err
+			}
+
+			earningsUser, err := a.app.EarningEventApp.CreateEarningsFromEvents(
+				earnings)
+			if err != nil {
+				return 500, nil, nil, err
+			}
+
+			earningsCreated = append(earningsCreated, earningsUser...)
+		}
+	}
+
+	return 200, earningsCreated, skippedAssets, nil
+}
+
+func (a *Application) earningsFromEvents(assetId string, userUid string) (
+	[]entity.Earnings, error) {
+
+	events, err := a.app.EarningEventApp.SearchUnclaimedEvents(assetId,
+		userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	orders, err := a.app.OrderApp.SearchOrdersFromAssetUser(assetId, userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	// The earnings entered manually or imported from the statements are not
+	// linked to the events, so they are matched by their values
+	registered, err := a.app.EarningsApp.SearchEarningsFromAssetUser(assetId,
+		userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.app.EarningEventApp.EarningsFromEvents(events, orders,
+		registered, userUid), nil
+}
+
+// ApiUpdateAsset changes the fullname, preference or sector of an asset. The
+// assets are shared by every user, so the change is reflected in the
+// positions of every user holding the asset.
+func (a *Application) ApiUpdateAsset(symbol string, fullname string,
+	preference *string, sectorId string, userUid string) (int, *entity.Asset,
+	error) {
+
+	if !a.isAdminUser(userUid) {
+		return 403, nil, entity.ErrInvalidUserAdminPrivilege
+	}
+
+	assetInfo, err := a.app.AssetApp.SearchAsset(symbol)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	if assetInfo == nil {
+		return 404, nil, entity.ErrInvalidAssetSymbol
+	}
+
+	if sectorId != "" {
+		_, err = a.app.SectorApp.SearchSectorById(sectorId)
+		if err == entity.ErrInvalidSector {
+			return 400, nil, err
+		} else if err != nil {
+			return 500, nil, err
+		}
+	}
+
+	auditLog, err := entity.NewAuditLog(userUid, entity.AuditActionUpdate,
+		entity.AuditEntityAsset, assetInfo.Id, assetInfo, nil)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	updatedAsset, err := a.app.AssetApp.UpdateAsset(*assetInfo, fullname,
+		preference, sectorId, auditLog)
+	if err == entity.ErrInvalidAssetEntityBlank ||
+		err == entity.ErrInvalidAssetPreferenceUndefined ||
+		err == entity.ErrInvalidAssetEntityValues {
+		return 400, nil, err
+	} else if err != nil {
+		return 500, nil, err
+	}
+
+	if updatedAsset == nil {
+		return 404, nil, entity.ErrInvalidAssetSymbol
+	}
+
+	return 200, updatedAsset, nil
+}
+
+func (a *Application) ApiRenameSector(sectorId string, name string,
+	userUid string) (int, *entity.Sector, error) {
+
+	if !a.isAdminUser(userUid) {
+		return 403, nil, entity.ErrInvalidUserAdminPrivilege
+	}
+
+	sectorInfo, err := a.app.SectorApp.SearchSectorById(sectorId)
+	if err == entity.ErrInvalidSector {
+		return 404, nil, err
+	} else if err != nil {
+		return 500, nil, err
+	}
+
+	auditLog, err := entity.NewAuditLog(userUid, entity.AuditActionUpdate,
+		entity.AuditEntitySector, sectorId, sectorInfo, nil)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	renamedSector, err := a.app.SectorApp.RenameSector(sectorId, name,
+		auditLog)
+	if err == entity.ErrInvalidSectorBlank {
+		return 400, nil, err
+	} else if err == entity.ErrInvalidSector {
+		return 404, nil, err
+	} else if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, renamedSector, nil
+}
+
+// ApiMergeSectors moves everything classified in the source sector to the
+// target sector, including the assets held by the users, and deletes the
+// source sector.
+func (a *Application) ApiMergeSectors(sourceId string, targetId string,
+	userUid string) (int, *entity.Sector, error) {
+
+	if !a.isAdminUser(userUid) {
+		return 403, nil, entity.ErrInvalidUserAdminPrivilege
+	}
+
+	sourceSector, err := a.app.SectorApp.SearchSectorById(sourceId)
+	if err == entity.ErrInvalidSector {
+		return 404, nil, err
+	} else if err != nil {
+		return 500, nil, err
+	}
+
+	auditLog, err := entity.NewAuditLog(userUid, entity.AuditActionMerge,
+		entity.AuditEntitySector, sourceId, sourceSector, nil)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	targetSector, err := a.app.SectorApp.MergeSectors(sourceId, targetId,
+		auditLog)
+	if err == entity.ErrInvalidSectorMerge {
+		return 400, nil, err
+	} else if err == entity.ErrInvalidSector {
+		return 404, nil, err
+	} else if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, targetSector, nil
+}
+
+func (a *Application) ApiCreateAssetType(assetType string, name string,
+	country string, userUid string) (int, *entity.AssetType, error) {
+
+	if !a.isAdminUser(userUid) {
+		return 403, nil, entity.ErrInvalidUserAdminPrivilege
+	}
+
+	auditLog, err := entity.NewAuditLog(userUid, entity.AuditActionCreate,
+		entity.AuditEntityAssetType, "", nil, nil)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	assetTypeCreated, err := a.app.AssetTypeApp.CreateAssetType(assetType,
+		name, country, auditLog)
+	if err == entity.ErrInvalidAssetTypeBlank ||
+		err == entity.ErrInvalidAssetTypeName ||
+		err == entity.ErrInvalidAssetTypeExist ||
+		err == entity.ErrInvalidCountryCode {
+		return 400, nil, err
+	} else if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, assetTypeCreated, nil
+}
+
+func (a *Application) ApiUpdateAssetType(assetTypeId string, name string,
+	userUid string) (int, *entity.AssetType, error) {
+
+	if !a.isAdminUser(userUid) {
+		return 403, nil, entity.ErrInvalidUserAdminPrivilege
+	}
+
+	assetTypeInfo, err := a.app.AssetTypeApp.SearchAssetTypeById(assetTypeId)
+	if err == entity.ErrInvalidAssetTypeId {
+		return 404, nil, err
+	} else if err != nil {
+		return 500, nil, err
+	}
+
+	auditLog, err := entity.NewAuditLog(userUid, entity.AuditActionUpdate,
+		entity.AuditEntityAssetType, assetTypeId, assetTypeInfo, nil)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	updatedAssetType, err := a.app.AssetTypeApp.UpdateAssetType(assetTypeId,
+		name, auditLog)
+	if err == entity.ErrInvalidAssetTypeBlank {
+		return 400, nil, err
+	} else if err == entity.ErrInvalidAssetTypeId {
+		return 404, nil, err
+	} else if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, updatedAssetType, nil
+}
+
+// ApiDeleteAssetType deletes an asset type without assets. The assets must be
+// deleted before their asset type.
+func (a *Application) ApiDeleteAssetType(assetTypeId string, userUid string) (
+	int, *entity.AssetType, error) {
+
+	if !a.isAdminUser(userUid) {
+		return 403, nil, entity.ErrInvalidUserAdminPrivilege
+	}
+
+	assetTypeInfo, err := a.app.AssetTypeApp.SearchAssetTypeById(assetTypeId)
+	if err == entity.ErrInvalidAssetTypeId {
+		return 404, nil, err
+	} else if err != nil {
+		return 500, nil, err
+	}
+
+	auditLog, err := entity.NewAuditLog(userUid, entity.AuditActionDelete,
+		entity.AuditEntityAssetType, assetTypeId, assetTypeInfo, nil)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	deletedAssetType, err := a.app.AssetTypeApp.DeleteAssetType(assetTypeId,
+		auditLog)
+	if err == entity.ErrInvalidAssetTypeId {
+		return 404, nil, err
+	} else if err == entity.ErrInvalidAssetTypeInUse {
+		return 400, nil, err
+	} else if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, deletedAssetType, nil
+}
+
+// ApiCreateBrokerage creates a brokerage available to everyone, which only
+// the admins are allowed to do, or a custom brokerage visible only to the user.
+func (a *Application) ApiCreateBrokerage(name string, fullname string,
+	country string, custom bool, userUid string) (int, *entity.Brokerage,
+	error) {
+
+	var owner *string
+
+	if custom {
+		owner = &userUid
+	} else if !a.isAdminUser(userUid) {
+		return 403, nil, entity.ErrInvalidUserAdminPrivilege
+	}
+
+	var auditLog *entity.AuditLog
+	if !custom {
+		var err error
+		auditLog, err = entity.NewAuditLog(userUid, entity.AuditActionCreate,
+			entity.AuditEntityBrokerage, "", nil, nil)
+		if err != nil {
+			return 500, nil, err
+		}
+	}
+
+	brokerageCreated, err := a.app.BrokerageApp.CreateBrokerage(name, fullname,
+		country, owner, auditLog)
+	if err == entity.ErrInvalidBrokerageBlank ||
+		err == entity.ErrInvalidBrokerageExist ||
+		err == entity.ErrInvalidCountryCode {
+		return 400, nil, err
+	} else if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, brokerageCreated, nil
+}
+
+// ApiUpdateBrokerage changes a brokerage. The brokerages available to everyone
+// are changed only by the admins and the custom ones only by their owner.
+func (a *Application) ApiUpdateBrokerage(brokerageId string, name string,
+	fullname string, country string, userUid string) (int, *entity.Brokerage,
+	error) {
+
+	httpStatusCode, brokerageInfo, err := a.editableBrokerage(brokerageId,
+		userUid)
+	if err != nil {
+		return httpStatusCode, nil, err
+	}
+
+	auditLog, err := a.brokerageAuditLog(*brokerageInfo,
+		entity.AuditActionUpdate, userUid)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	brokerageUpdated, err := a.app.BrokerageApp.UpdateBrokerage(*brokerageInfo,
+		name, fullname, country, auditLog)
+	if err == entity.ErrInvalidBrokerageBlank ||
+		err == entity.ErrInvalidBrokerageExist ||
+		err == entity.ErrInvalidCountryCode {
+		return 400, nil, err
+	} else if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, brokerageUpdated, nil
+}
+
+// ApiDeleteBrokerage removes a brokerage without accounts, following the same
+// permissions of ApiUpdateBrokerage.
+func (a *Application) ApiDeleteBrokerage(brokerageId string, userUid string) (
+	int, *entity.Brokerage, error) {
+
+	httpStatusCode, brokerageInfo, err := a.editableBrokerage(brokerageId,
+		userUid)
+	if err != nil {
+		return httpStatusCode, nil, err
+	}
+
+	auditLog, err := a.brokerageAuditLog(*brokerageInfo,
+		entity.AuditActionDelete, userUid)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	brokerageDeleted, err := a.app.BrokerageApp.DeleteBrokerage(brokerageId,
+		auditLog)
+	if err == entity.ErrInvalidBrokerageInUse {
+		return 400, nil, err
+	} else if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, brokerageDeleted, nil
+}
+
+// brokerageAuditLog returns the audit log of the change of a brokerage
+// available to everyone. The changes of the custom brokerages are not audited.
+func (a *Application) brokerageAuditLog(brokerage entity.Brokerage,
+	action string, userUid string) (*entity.AuditLog, error) {
+
+	if brokerage.IsCustom() {
+		return nil, nil
+	}
+
+	return entity.NewAuditLog(userUid, action, entity.AuditEntityBrokerage,
+		brokerage.Id, brokerage, nil)
+}
+
+// editableBrokerage returns the brokerage when the user is allowed to change
+// it.
+func (a *Application) editableBrokerage(brokerageId string, userUid string) (
+	int, *entity.Brokerage, error) {
+
+	brokerageInfo, err := a.app.BrokerageApp.SearchBrokerageById(brokerageId,
+		userUid)
+	if err == entity.ErrInvalidBrokerageId {
+		return 404, nil, err
+	} else if err != nil {
+		return 500, nil, err
+	}
+
+	if brokerageInfo.IsCustom() {
+		if !brokerageInfo.IsOwner(userUid) {
+			return 403, nil, entity.ErrInvalidBrokerageOwner
+		}
+	} else if !a.isAdminUser(userUid) {
+		return 403, nil, entity.ErrInvalidUserAdminPrivilege
+	}
+
+	return 200, brokerageInfo, nil
+}
+
+// orderAccount returns the brokerage account of an order. The account
+// informed has priority over the brokerage, which uses the default account of
+// the user in it.
+func (a *Application) orderAccount(brokerage string, accountId string,
+	userUid string) (int, *entity.BrokerageAccount, error) {
+
+	if accountId != "" {
+		accountInfo, err := a.app.BrokerageApp.SearchAccountById(accountId,
+			userUid)
+		if err == entity.ErrInvalidBrokerageAccount {
+			return 400, nil, err
+		} else if err != nil {
+			return 500, nil, err
+		}
+
+		return 200, accountInfo, nil
+	}
+
+	brokerageInfo, err := a.app.BrokerageApp.SearchBrokerage("SINGLE",
+		brokerage, "", userUid)
+	if err != nil {
+		return 400, nil, err
+	}
+
+	accountInfo, err := a.app.BrokerageApp.DefaultAccount(brokerageInfo[0],
+		userUid)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, accountInfo, nil
+}
+
+// importAsset searches the asset of an imported order. The asset is nil when
+// it is not registered yet, but it exists in the market data providers.
+func (a *Application) importAsset(symbol string, country string) (int,
+	*entity.Asset, error) {
+
+	assetInfo, err := a.app.AssetApp.SearchAsset(symbol)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	if assetInfo != nil {
+		return 200, assetInfo, nil
+	}
+
+	_, err = a.app.AssetApp.AssetVerificationExistence(symbol, country,
+		a.externalInterfaces)
+	if err != nil {
+		return 400, nil, err
+	}
+
+	return 200, nil, nil
+}
+
+// searchImportAsset searches the asset of a symbol of the imported rows, unless
+// it was already searched. Only the failures of the search are returned, the
+// problems of the symbol are kept with its asset.
+func (a *Application) searchImportAsset(assets *importAssets, symbol string,
+	country string) error {
+
+	if _, searched := assets.errors[symbol]; searched || symbol == "" {
+		return nil
+	}
+
+	httpStatusCode, assetInfo, err := a.importAsset(symbol, country)
+	if httpStatusCode == 500 {
+		return err
+	}
+	assets.assets[symbol], assets.errors[symbol] = assetInfo, err
+
+	return nil
+}
+
+// newImportAsset returns the asset of a symbol of the imported rows. The
+// asset not registered yet is returned without Id and kept in the assets to be
+// created, in the same transaction of the imported rows.
+func (a *Application) newImportAsset(assets *importAssets, symbol string,
+	country string) (int, *entity.Asset, error) {
+
+	if assets.assets[symbol] == nil {
+		httpStatusCode, assetInfo, err := a.newAsset(symbol, country)
+		if err != nil {
+			return httpStatusCode, nil, err
+		}
+		assets.assets[symbol] = assetInfo
+		assets.created = append(assets.created, *assetInfo)
+	}
+
+	return 200, assets.assets[symbol], nil
+}
+
+// tradeNoteSymbol searches the symbol of a trade note order by the name of
+// the company and the suffix of its share class. The symbol is blank when no
+// asset matches.
+func (a *Application) tradeNoteSymbol(order entity.TradeNoteOrder) (string,
+	error) {
+
+	if order.Company == "" || order.SymbolSuffix == "" {
+		return "", nil
+	}
+
+	suggestions, err := a.app.SymbolSearchApp.SearchSymbols(order.Company,
+		"BR", 0)
+	if err == entity.ErrInvalidSymbolSearchQuery {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	for _, suggestion := range suggestions {
+		symbol := strings.TrimPrefix(suggestion.Symbol, strings.TrimRight(
+			suggestion.Symbol, "0123456789"))
+		if symbol == order.SymbolSuffix {
+			return suggestion.Symbol, nil
+		}
+	}
+
+	return "", nil
+}
+
+func (a *Application) isAdminUser(userUid string) bool {
+	searchedUser, _ := a.app.UserApp.SearchUser(userUid)
+
+	return searchedUser != nil && searchedUser.Type == "admin"
 }
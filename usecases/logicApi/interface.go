@@ -1,13 +1,49 @@
 package logicApi
 
-import "stockfyApi/entity"
+import (
+	"io"
+	"stockfyApi/entity"
+	"time"
+)
 
 type UseCases interface {
 	ApiAssetVerification(symbol string, country string) (int, *entity.Asset,
 		error)
 	ApiCreateOrder(symbol string, country string, orderType string,
 		quantity float64, price float64, currency string, brokerage string,
-		date string, userUid string) (int, *entity.Order, error)
+		accountId string, date string, userUid string) (int, *entity.Order,
+		error)
+	ApiImportOrders(file io.Reader, mapping map[string]string,
+		delimiter string, dryRun bool, userUid string) (int,
+		[]entity.OrderImportRow, []entity.Order, error)
+	ApiImportTradeNotes(text string, brokerage string,
+		symbols map[string]string, confirm bool, userUid string) (int,
+		[]entity.TradeNote, []entity.OrderImportRow, []entity.Order, error)
+	ApiImportB3Report(file []byte, brokerages map[string]string, date string,
+		dryRun bool, userUid string) (int, *entity.B3Report, error)
+	ApiImportBrokerStatement(file []byte, brokerage string, dryRun bool,
+		userUid string) (int, *entity.BrokerStatement, []entity.Order,
+		[]entity.Earnings, error)
+	ApiExportPortfolio(format string, userUid string) (int,
+		*entity.PortfolioExport, error)
+	ApiCreateBackup(userUid string) (int, *entity.PortfolioBackup, error)
+	ApiRestoreBackup(backup entity.PortfolioBackup, userUid string) (int,
+		*entity.BackupRestore, error)
+	ApiReconcileCustody(brokerage string, date string,
+		positions []entity.CustodyPosition, file []byte, userUid string) (int,
+		[]entity.CustodyReconciliation, error)
+	ApiCreateCashMovement(movementType string, amount float64,
+		currency string, date string, description string, accountId string,
+		toAccountId string, userUid string) (int, *entity.CashMovement, error)
+	ApiCashLedger(accountId string, date string, userUid string) (int,
+		[]entity.CashEntry, error)
+	ApiCashBalances(date string, userUid string) (int, []entity.CashBalance,
+		error)
+	ApiCreateRemittance(sentAmount float64, sentCurrency string,
+		receivedAmount float64, iof float64, fees float64,
+		commercialRate float64, date string, fromAccountId string,
+		toAccountId string, userUid string) (int, *entity.Remittance, error)
+	ApiBrlCostBasis(userUid string) (int, []entity.BrlCostBasis, error)
 	ApiAssetsPerAssetType(assetType string, country string, ordersInfo bool,
 		withPrice bool, userUid string) (int, *entity.AssetType, error)
 	ApiDeleteAssets(myUser bool, userUid string, symbol string) (int,
@@ -15,8 +51,8 @@ type UseCases interface {
 	ApiGetOrdersFromAssetUser(symbol string, userUid string, orderBy string,
 		limit string, offset string) (int, []entity.Order, error)
 	ApiUpdateOrdersFromUser(orderId string, userUid string, orderType string,
-		price float64, quantity float64, date string, brokerage string) (int,
-		*entity.Order, error)
+		price float64, quantity float64, date string, brokerage string,
+		accountId string) (int, *entity.Order, error)
 	ApiCreateEarnings(symbol string, currency string, earningType string,
 		date string, earnings float64, userUid string) (int, *entity.Earnings,
 		error)
@@ -27,4 +63,38 @@ type UseCases interface {
 		error)
 	ApiGetAssetByUser(symbol string, userUid string, withOrders bool,
 		withOrderResume bool, withPrice bool) (int, *entity.Asset, error)
+	ApiCreateFixedIncome(symbol string, fullname string, category string,
+		issuer string, indexer string, rate float64, maturity string) (int,
+		*entity.FixedIncome, error)
+	ApiCreateOption(symbol string, underlyingSymbol string, optionType string,
+		strike float64, expiry string) (int, *entity.Option, error)
+	ApiExpireOptions(date time.Time) (int, []entity.Order, map[string]error,
+		error)
+	ApiGetCompanyProfile(symbol string) (int, *entity.CompanyProfile, error)
+	ApiRefreshCompanyProfiles() (int, []entity.CompanyProfile, error)
+	ApiImportEarningEvents(symbol string) (int, []entity.EarningEvent, error)
+	ApiProposedEarnings(symbol string, userUid string) (int, []entity.Earnings,
+		error)
+	ApiCreateEarningsFromEvents(symbol string, userUid string) (int,
+		[]entity.Earnings, error)
+	ApiRefreshEarningEvents() (int, []entity.Earnings, map[string]error,
+		error)
+	ApiUpdateAsset(symbol string, fullname string, preference *string,
+		sectorId string, userUid string) (int, *entity.Asset, error)
+	ApiRenameSector(sectorId string, name string, userUid string) (int,
+		*entity.Sector, error)
+	ApiMergeSectors(sourceId string, targetId string, userUid string) (int,
+		*entity.Sector, error)
+	ApiCreateAssetType(assetType string, name string, country string,
+		userUid string) (int, *entity.AssetType, error)
+	ApiUpdateAssetType(assetTypeId string, name string, userUid string) (int,
+		*entity.AssetType, error)
+	ApiDeleteAssetType(assetTypeId string, userUid string) (int,
+		*entity.AssetType, error)
+	ApiCreateBrokerage(name string, fullname string, country string,
+		custom bool, userUid string) (int, *entity.Brokerage, error)
+	ApiUpdateBrokerage(brokerageId string, name string, fullname string,
+		country string, userUid string) (int, *entity.Brokerage, error)
+	ApiDeleteBrokerage(brokerageId string, userUid string) (int,
+		*entity.Brokerage, error)
 }
@@ -2,10 +2,13 @@ package logicApi
 
 import (
 	"errors"
+	"io"
 	"stockfyApi/entity"
+	externalapi "stockfyApi/externalApi"
 	"stockfyApi/usecases"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type MockApplication struct {
@@ -23,8 +26,8 @@ func (a *MockApplication) ApiAssetVerification(symbol string, country string) (
 	int, *entity.Asset, error) {
 	preference := "TestPref"
 
-	if country != "BR" && country != "US" {
-		return 400, nil, entity.ErrInvalidCountryCode
+	if _, err := entity.SearchMarket(country); err != nil {
+		return 400, nil, err
 	}
 
 	if symbol == "UNKNOWN_SYMBOL" {
@@ -63,7 +66,7 @@ func (a *MockApplication) ApiAssetVerification(symbol string, country string) (
 
 func (a *MockApplication) ApiCreateOrder(symbol string, country string, orderType string,
 	quantity float64, price float64, currency string, brokerage string,
-	date string, userUid string) (int, *entity.Order, error) {
+	accountId string, date string, userUid string) (int, *entity.Order, error) {
 
 	var assetInfo *entity.Asset
 	var httpStatusCode int
@@ -72,7 +75,7 @@ func (a *MockApplication) ApiCreateOrder(symbol string, country string, orderTyp
 	dateFormatted := entity.StringToTime(date)
 
 	err := a.app.OrderApp.OrderVerification(orderType, country, quantity, price,
-		currency)
+		currency, date)
 	if err != nil {
 		return 400, nil, err
 	}
@@ -107,7 +110,11 @@ func (a *MockApplication) ApiCreateOrder(symbol string, country string, orderTyp
 		return 500, nil, errors.New("Unknown asset user repository error")
 	}
 
-	if brokerage == "UNKNOWN_BROKERAGE" {
+	if accountId == "UNKNOWN_ACCOUNT" {
+		return 400, nil, entity.ErrInvalidBrokerageAccount
+	}
+
+	if accountId == "" && brokerage == "UNKNOWN_BROKERAGE" {
 		return 400, nil, entity.ErrInvalidBrokerageNameSearch
 	}
 
@@ -132,6 +139,612 @@ func (a *MockApplication) ApiCreateOrder(symbol string, country string, orderTyp
 	}, nil
 }
 
+func (a *MockApplication) ApiImportOrders(file io.Reader,
+	mapping map[string]string, delimiter string, dryRun bool,
+	userUid string) (int, []entity.OrderImportRow, []entity.Order, error) {
+
+	rows, err := a.app.OrderApp.ReadOrderImport(file, mapping, delimiter)
+	if err != nil {
+		return 400, nil, nil, err
+	}
+
+	return a.importOrderRows(rows, dryRun, userUid)
+}
+
+func (a *MockApplication) ApiImportTradeNotes(text string, brokerage string,
+	symbols map[string]string, confirm bool, userUid string) (int,
+	[]entity.TradeNote, []entity.OrderImportRow, []entity.Order, error) {
+
+	notes, err := a.app.OrderApp.ReadTradeNotes(text)
+	if err != nil {
+		return 400, nil, nil, nil, err
+	}
+
+	for i := range notes {
+		for j, order := range notes[i].Orders {
+			if symbols[order.Specification] != "" {
+				notes[i].Orders[j].Symbol = symbols[order.Specification]
+			}
+		}
+	}
+
+	httpStatusCode, rows, orders, err := a.importOrderRows(
+		entity.TradeNoteImportRows(notes, brokerage), !confirm, userUid)
+
+	return httpStatusCode, notes, rows, orders, err
+}
+
+func (a *MockApplication) ApiImportB3Report(file []byte,
+	brokerages map[string]string, date string, dryRun bool, userUid string) (
+	int, *entity.B3Report, error) {
+
+	report, err := a.app.OrderApp.ReadB3Report(file)
+	if err != nil {
+		return 400, nil, err
+	}
+
+	userBrokerages := []entity.Brokerage{{Name: "Clear"}, {Name: "Rico"}}
+	brokerageName := func(institution string) string {
+		if brokerages[institution] != "" {
+			return brokerages[institution]
+		}
+
+		brokerageInfo := entity.SearchBrokerageByInstitution(institution,
+			userBrokerages)
+		if brokerageInfo == nil {
+			return "UNKNOWN_BROKERAGE"
+		}
+		return brokerageInfo.Name
+	}
+
+	switch report.Type {
+	case entity.B3TradesReport:
+		for i, trade := range report.Trades {
+			report.Trades[i].Brokerage = brokerageName(trade.Brokerage)
+		}
+
+		httpStatusCode, rows, orders, err := a.importOrderRows(report.Trades,
+			dryRun, userUid)
+		report.Trades, report.Orders = rows, orders
+
+		return httpStatusCode, report, err
+	case entity.B3MovementsReport:
+		var earnings []entity.Earnings
+		validRows := true
+		for _, movement := range report.Movements {
+			if !movement.Valid() {
+				validRows = false
+			}
+
+			if movement.EarningType != "" {
+				earning, _ := entity.NewEarnings(movement.EarningType,
+					movement.Amount, "BRL", entity.StringToTime(movement.Date),
+					"BR", "TestAssetID", userUid)
+				earnings = append(earnings, *earning)
+			}
+		}
+
+		if !validRows {
+			return 400, report, entity.ErrInvalidOrderImportRows
+		}
+
+		if !dryRun && earnings != nil {
+			report.Earnings, err = a.app.EarningsApp.CreateEarnings(earnings)
+			if err != nil {
+				return 500, nil, err
+			}
+		}
+
+		return 200, report, nil
+	}
+
+	custodyDate, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return 400, report, entity.ErrInvalidCustodyDate
+	}
+	report.Date = custodyDate
+
+	var snapshots []*entity.CustodySnapshot
+	for i, position := range report.Positions {
+		report.Positions[i].Brokerage = brokerageName(position.Brokerage)
+
+		var snapshot *entity.CustodySnapshot
+		for _, brokerageSnapshot := range snapshots {
+			if brokerageSnapshot.Brokerage.Name ==
+				report.Positions[i].Brokerage {
+				snapshot = brokerageSnapshot
+			}
+		}
+		if snapshot == nil {
+			snapshot = &entity.CustodySnapshot{
+				Brokerage: &entity.Brokerage{Id: "TestBrokerageID",
+					Name: report.Positions[i].Brokerage},
+				Date: custodyDate,
+			}
+			snapshots = append(snapshots, snapshot)
+		}
+
+		snapshot.Positions = append(snapshot.Positions,
+			entity.CustodyPosition{
+				Symbol:   position.Symbol,
+				Quantity: position.Quantity,
+			})
+	}
+
+	for _, snapshot := range snapshots {
+		reconciliation, err := a.app.ReconciliationApp.ReconcileCustody(
+			*snapshot, userUid)
+		if err != nil {
+			switch err {
+			case entity.ErrInvalidCustodyDate, entity.ErrInvalidCustodyPosition:
+				return 400, report, err
+			}
+			return 500, nil, err
+		}
+
+		report.Reconciliation = append(report.Reconciliation,
+			reconciliation...)
+	}
+
+	return 200, report, nil
+}
+
+func (a *MockApplication) ApiImportBrokerStatement(file []byte,
+	brokerage string, dryRun bool, userUid string) (int,
+	*entity.BrokerStatement, []entity.Order, []entity.Earnings, error) {
+
+	statement, err := a.app.OrderApp.ReadBrokerStatement(file)
+	if err != nil {
+		return 400, nil, nil, nil, err
+	}
+
+	if brokerage == "" {
+		brokerage = statement.Brokerage
+	}
+	statement.SetBrokerage(brokerage)
+
+	var earnings []entity.Earnings
+	validRows := true
+	for _, row := range statement.Earnings {
+		if !row.Valid() {
+			validRows = false
+			continue
+		}
+
+		earning, _ := entity.NewEarnings(row.EarningType, row.Amount,
+			row.Currency, entity.StringToTime(row.Date), row.Country,
+			"TestAssetID", userUid)
+		earning.WithheldTax = row.WithheldTax
+		earnings = append(earnings, *earning)
+	}
+
+	httpStatusCode, rows, _, err := a.importOrderRows(statement.Orders, true,
+		userUid)
+	statement.Orders = rows
+	if httpStatusCode != 200 {
+		return httpStatusCode, statement, nil, nil, err
+	}
+
+	if !validRows {
+		return 400, statement, nil, nil, entity.ErrInvalidOrderImportRows
+	}
+
+	if dryRun {
+		return 200, statement, nil, nil, nil
+	}
+
+	orders, earnings, err := a.app.OrderApp.CreateImport(nil, nil,
+		a.newImportOrders(rows, userUid), earnings)
+	if err != nil {
+		return 500, nil, nil, nil, err
+	}
+
+	return 200, statement, orders, earnings, nil
+}
+
+func (a *MockApplication) ApiExportPortfolio(format string, userUid string) (
+	int, *entity.PortfolioExport, error) {
+
+	if err := entity.ExportFormatVerification(
+		strings.ToLower(format)); err != nil {
+		return 400, nil, err
+	}
+
+	assets, err := a.app.AssetApp.SearchAssetsByUser(userUid)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	orders, err := a.app.OrderApp.SearchOrdersFromUser(userUid)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	earnings, err := a.app.EarningsApp.SearchAllEarningsFromUser(userUid)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	positions, err := a.app.BrokerageApp.AccountPositions(userUid)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, &entity.PortfolioExport{
+		Assets:    assets,
+		Orders:    orders,
+		Earnings:  earnings,
+		Positions: positions,
+	}, nil
+}
+
+func (a *MockApplication) ApiCreateBackup(userUid string) (int,
+	*entity.PortfolioBackup, error) {
+
+	assets, err := a.app.AssetApp.SearchAssetsByUser(userUid)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	brokerages, err := a.app.BrokerageApp.SearchBrokerage("ALL", "", "",
+		userUid)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	accounts, err := a.app.BrokerageApp.SearchAccounts(userUid)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	orders, err := a.app.OrderApp.SearchOrdersFromUser(userUid)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	earnings, err := a.app.EarningsApp.SearchAllEarningsFromUser(userUid)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, entity.NewPortfolioBackup(assets, brokerages, accounts, orders,
+		earnings, time.Now()), nil
+}
+
+func (a *MockApplication) ApiRestoreBackup(backup entity.PortfolioBackup,
+	userUid string) (int, *entity.BackupRestore, error) {
+
+	restore, err := a.app.BackupApp.RestoreBackup(backup, userUid)
+	if err != nil {
+		switch err {
+		case entity.ErrInvalidBackupVersion, entity.ErrInvalidBackupAccount,
+			entity.ErrInvalidBackupAsset, entity.ErrInvalidBackupAssetType,
+			entity.ErrInvalidBackupOrder, entity.ErrInvalidBackupEarning:
+			return 400, nil, err
+		}
+		return 500, nil, err
+	}
+
+	return 200, restore, nil
+}
+
+func (a *MockApplication) ApiReconcileCustody(brokerage string, date string,
+	positions []entity.CustodyPosition, file []byte, userUid string) (int,
+	[]entity.CustodyReconciliation, error) {
+
+	custodyDate, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return 400, nil, entity.ErrInvalidCustodyDate
+	}
+
+	brokerageInfo, err := a.app.BrokerageApp.SearchBrokerage("SINGLE",
+		brokerage, "", userUid)
+	if err != nil {
+		return 400, nil, err
+	}
+
+	snapshot := entity.CustodySnapshot{
+		Brokerage: &brokerageInfo[0],
+		Date:      custodyDate,
+		Positions: positions,
+	}
+
+	if len(file) > 0 {
+		report, err := a.app.OrderApp.ReadB3Report(file)
+		if err != nil {
+			return 400, nil, err
+		}
+
+		if report.Type != entity.B3PositionReport {
+			return 400, nil, entity.ErrInvalidCustodyReport
+		}
+
+		for _, position := range report.Positions {
+			if !position.Valid() || entity.SearchBrokerageByInstitution(
+				position.Brokerage, brokerageInfo) == nil {
+				continue
+			}
+
+			snapshot.Positions = append(snapshot.Positions,
+				entity.CustodyPosition{
+					Symbol:   position.Symbol,
+					Quantity: position.Quantity,
+				})
+		}
+	}
+
+	reconciliation, err := a.app.ReconciliationApp.ReconcileCustody(snapshot,
+		userUid)
+	if err != nil {
+		switch err {
+		case entity.ErrInvalidCustodyBrokerage, entity.ErrInvalidCustodyDate,
+			entity.ErrInvalidCustodyPosition:
+			return 400, nil, err
+		}
+		return 500, nil, err
+	}
+
+	discrepancies := []entity.CustodyReconciliation{}
+	for _, position := range reconciliation {
+		if position.Status != entity.CustodyMatch {
+			discrepancies = append(discrepancies, position)
+		}
+	}
+
+	return 200, discrepancies, nil
+}
+
+func (a *MockApplication) ApiCreateCashMovement(movementType string,
+	amount float64, currency string, date string, description string,
+	accountId string, toAccountId string, userUid string) (int,
+	*entity.CashMovement, error) {
+
+	movementDate, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return 400, nil, entity.ErrInvalidCashMovementDate
+	}
+
+	if accountId == "" {
+		return 400, nil, entity.ErrInvalidCashMovementAccount
+	}
+
+	account, err := a.app.BrokerageApp.SearchAccountById(accountId, userUid)
+	if err == entity.ErrInvalidBrokerageAccount {
+		return 400, nil, err
+	} else if err != nil {
+		return 500, nil, err
+	}
+
+	if currency == "" {
+		currency = account.Currency()
+	}
+
+	if currency != account.Currency() {
+		return 400, nil, entity.ErrInvalidCashMovementCurrency
+	}
+
+	var toAccount *entity.BrokerageAccount
+	if toAccountId != "" {
+		toAccount, err = a.app.BrokerageApp.SearchAccountById(toAccountId,
+			userUid)
+		if err == entity.ErrInvalidBrokerageAccount {
+			return 400, nil, err
+		} else if err != nil {
+			return 500, nil, err
+		}
+
+		if toAccount.Currency() != currency {
+			return 400, nil, entity.ErrInvalidCashMovementCurrency
+		}
+	}
+
+	movement, err := a.app.CashApp.CreateMovement(movementType, amount,
+		currency, movementDate, description, accountId, toAccountId, userUid)
+	if err != nil {
+		switch err {
+		case entity.ErrInvalidCashMovementType,
+			entity.ErrInvalidCashMovementAmount,
+			entity.ErrInvalidCashMovementDate, entity.ErrInvalidCurrency,
+			entity.ErrInvalidCashMovementAccount,
+			entity.ErrInvalidCashMovementTransfer:
+			return 400, nil, err
+		}
+		return 500, nil, err
+	}
+
+	movement.Account = account
+	movement.ToAccount = toAccount
+
+	return 200, movement, nil
+}
+
+func (a *MockApplication) ApiCashLedger(accountId string, date string,
+	userUid string) (int, []entity.CashEntry, error) {
+
+	ledgerDate, err := cashDate(date)
+	if err != nil {
+		return 400, nil, err
+	}
+
+	if accountId != "" {
+		_, err = a.app.BrokerageApp.SearchAccountById(accountId, userUid)
+		if err == entity.ErrInvalidBrokerageAccount {
+			return 400, nil, err
+		} else if err != nil {
+			return 500, nil, err
+		}
+	}
+
+	ledger, err := a.app.CashApp.SearchLedger(accountId, ledgerDate, userUid)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, ledger, nil
+}
+
+func (a *MockApplication) ApiCashBalances(date string, userUid string) (int,
+	[]entity.CashBalance, error) {
+
+	balanceDate, err := cashDate(date)
+	if err != nil {
+		return 400, nil, err
+	}
+
+	balances, err := a.app.CashApp.SearchBalances(balanceDate, userUid)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, balances, nil
+}
+
+func (a *MockApplication) ApiCreateRemittance(sentAmount float64,
+	sentCurrency string, receivedAmount float64, iof float64, fees float64,
+	commercialRate float64, date string, fromAccountId string,
+	toAccountId string, userUid string) (int, *entity.Remittance, error) {
+
+	remittanceDate, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return 400, nil, entity.ErrInvalidRemittanceDate
+	}
+
+	if toAccountId == "" {
+		return 400, nil, entity.ErrInvalidRemittanceAccount
+	}
+
+	toAccount, err := a.app.BrokerageApp.SearchAccountById(toAccountId,
+		userUid)
+	if err == entity.ErrInvalidBrokerageAccount {
+		return 400, nil, err
+	} else if err != nil {
+		return 500, nil, err
+	}
+
+	var fromAccount *entity.BrokerageAccount
+	if fromAccountId != "" {
+		fromAccount, err = a.app.BrokerageApp.SearchAccountById(fromAccountId,
+			userUid)
+		if err == entity.ErrInvalidBrokerageAccount {
+			return 400, nil, err
+		} else if err != nil {
+			return 500, nil, err
+		}
+
+		if sentCurrency == "" {
+			sentCurrency = fromAccount.Currency()
+		}
+
+		if sentCurrency != fromAccount.Currency() {
+			return 400, nil, entity.ErrInvalidRemittanceCurrency
+		}
+	}
+
+	if sentCurrency == "" {
+		sentCurrency = entity.CostBasisCurrency
+	}
+
+	remittance, err := a.app.RemittanceApp.CreateRemittance(sentAmount,
+		sentCurrency, receivedAmount, toAccount.Currency(), iof, fees,
+		commercialRate, remittanceDate, fromAccountId, toAccountId, userUid)
+	if err != nil {
+		switch err {
+		case entity.ErrInvalidRemittanceAmount, entity.ErrInvalidRemittanceTaxes,
+			entity.ErrInvalidRemittanceRate,
+			entity.ErrInvalidRemittanceCurrency,
+			entity.ErrInvalidRemittanceDate,
+			entity.ErrInvalidRemittanceAccount:
+			return 400, nil, err
+		}
+		return 500, nil, err
+	}
+
+	remittance.FromAccount = fromAccount
+	remittance.ToAccount = toAccount
+
+	return 200, remittance, nil
+}
+
+func (a *MockApplication) ApiBrlCostBasis(userUid string) (int,
+	[]entity.BrlCostBasis, error) {
+
+	orders, err := a.app.OrderApp.SearchOrdersFromUser(userUid)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	costBasis, err := a.app.RemittanceApp.SearchBrlCostBasis(orders, userUid)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, costBasis, nil
+}
+
+func (a *MockApplication) importOrderRows(rows []entity.OrderImportRow,
+	dryRun bool, userUid string) (int, []entity.OrderImportRow, []entity.Order,
+	error) {
+
+	validRows := true
+	for i, row := range rows {
+		err := a.app.OrderApp.OrderVerification(row.OrderType, row.Country,
+			row.Quantity, row.Price, row.Currency, row.Date)
+		if err != nil {
+			rows[i].AddError(err)
+		}
+
+		if row.Symbol == "UNKNOWN_SYMBOL" {
+			rows[i].AddError(entity.ErrInvalidAssetSymbol)
+		} else if row.Symbol == "NEW_SYMBOL" {
+			rows[i].NewAsset = true
+		}
+
+		if row.Brokerage == "UNKNOWN_BROKERAGE" {
+			rows[i].AddError(entity.ErrInvalidBrokerageNameSearch)
+		}
+
+		if !rows[i].Valid() {
+			validRows = false
+		}
+	}
+
+	if !validRows {
+		return 400, rows, nil, entity.ErrInvalidOrderImportRows
+	}
+
+	if dryRun {
+		return 200, rows, nil, nil
+	}
+
+	ordersCreated, _, err := a.app.OrderApp.CreateImport(nil, nil,
+		a.newImportOrders(rows, userUid), nil)
+	if err != nil {
+		return 500, nil, nil, err
+	}
+
+	return 200, rows, ordersCreated, nil
+}
+
+func (a *MockApplication) newImportOrders(rows []entity.OrderImportRow,
+	userUid string) []entity.Order {
+
+	var orders []entity.Order
+	for _, row := range rows {
+		order, _ := entity.NewOrder(row.Quantity, row.Price, row.Currency,
+			row.OrderType, entity.StringToTime(row.Date), "TestAccountID",
+			"TestAssetID", userUid)
+		order.Fees = row.Fees
+		order.WithheldTax = row.WithheldTax
+		if row.SettlementDate != "" {
+			settlementDate := entity.StringToTime(row.SettlementDate)
+			order.SettlementDate = &settlementDate
+		}
+		orders = append(orders, *order)
+	}
+
+	return orders
+}
+
 func (a *MockApplication) ApiAssetsPerAssetType(assetType string, country string,
 	ordersInfo bool, withPrice bool, userUid string) (int, *entity.AssetType, error) {
 
@@ -145,8 +758,8 @@ func (a *MockApplication) ApiAssetsPerAssetType(assetType string, country string
 		return 400, nil, entity.ErrInvalidApiQueryCountryBlank
 	}
 
-	if country != "BR" && country != "US" && country != "" {
-		return 400, nil, entity.ErrInvalidCountryCode
+	if _, err := entity.SearchMarket(country); err != nil {
+		return 400, nil, err
 	}
 
 	if assetType == "INVALID_ASSET_TYPE" {
@@ -365,11 +978,11 @@ func (a *MockApplication) ApiGetOrdersFromAssetUser(symbol string,
 }
 
 func (a *MockApplication) ApiUpdateOrdersFromUser(orderId string, userUid string, orderType string,
-	price float64, quantity float64, date string, brokerage string) (int,
-	*entity.Order, error) {
+	price float64, quantity float64, date string, brokerage string,
+	accountId string) (int, *entity.Order, error) {
 
 	if orderType == "" || price == 0 || quantity == 0 || date == "" ||
-		brokerage == "" {
+		(brokerage == "" && accountId == "") {
 		return 400, nil, entity.ErrInvalidApiOrderUpdate
 	}
 
@@ -382,12 +995,16 @@ func (a *MockApplication) ApiUpdateOrdersFromUser(orderId string, userUid string
 	}
 
 	err := a.app.OrderApp.OrderVerification(orderType, "BR", quantity, price,
-		"BRL")
+		"BRL", date)
 	if err != nil {
 		return 400, nil, err
 	}
 
-	if brokerage == "UNKNOWN_BROKERAGE" {
+	if accountId == "UNKNOWN_ACCOUNT" {
+		return 400, nil, entity.ErrInvalidBrokerageAccount
+	}
+
+	if accountId == "" && brokerage == "UNKNOWN_BROKERAGE" {
 		return 400, nil, entity.ErrInvalidBrokerageNameSearch
 	}
 
@@ -647,3 +1264,437 @@ func (a *MockApplication) ApiGetAssetByUser(symbol string, userUid string, withO
 	}, nil
 
 }
+
+func (a *MockApplication) ApiCreateFixedIncome(symbol string, fullname string,
+	category string, issuer string, indexer string, rate float64,
+	maturity string) (int, *entity.FixedIncome, error) {
+
+	if symbol == "" || fullname == "" {
+		return 400, nil, entity.ErrInvalidFixedIncomeBlank
+	}
+
+	err := a.app.FixedIncomeApp.FixedIncomeVerification(category, issuer,
+		indexer, rate, maturity)
+	if err != nil {
+		return 400, nil, err
+	}
+
+	if symbol == "ERROR_ASSET_REPO" {
+		return 500, nil, errors.New("Unknown asset repository error")
+	}
+
+	preference := ""
+	fixedIncome, err := a.app.FixedIncomeApp.CreateFixedIncome(category, issuer,
+		indexer, rate, maturity, "TestID")
+	if err != nil {
+		return 500, nil, err
+	}
+
+	fixedIncome.Asset = &entity.Asset{
+		Id:         "TestID",
+		Symbol:     symbol,
+		Fullname:   fullname,
+		Preference: &preference,
+	}
+
+	return 200, fixedIncome, nil
+}
+
+func (a *MockApplication) ApiCreateOption(symbol string,
+	underlyingSymbol string, optionType string, strike float64,
+	expiry string) (int, *entity.Option, error) {
+
+	if symbol == "" {
+		return 400, nil, entity.ErrInvalidOptionSymbol
+	}
+
+	err := a.app.OptionApp.OptionVerification(optionType, strike, expiry)
+	if err != nil {
+		return 400, nil, err
+	}
+
+	if underlyingSymbol == "UNKNOWN_SYMBOL" {
+		return 400, nil, entity.ErrInvalidOptionUnderlying
+	}
+
+	if symbol == "ERROR_ASSET_REPO" {
+		return 500, nil, errors.New("Unknown asset repository error")
+	}
+
+	option, err := a.app.OptionApp.CreateOption(optionType, strike, expiry,
+		"TestID", "TestUnderlyingID")
+	if err != nil {
+		return 500, nil, err
+	}
+
+	preference := ""
+	option.Asset = &entity.Asset{
+		Id:         "TestID",
+		Symbol:     symbol,
+		Fullname:   option.Fullname(underlyingSymbol),
+		Preference: &preference,
+	}
+	option.Underlying = &entity.Asset{
+		Id:     "TestUnderlyingID",
+		Symbol: underlyingSymbol,
+	}
+
+	return 200, option, nil
+}
+
+func (a *MockApplication) ApiExpireOptions(date time.Time) (int,
+	[]entity.Order, map[string]error, error) {
+
+	if date.IsZero() {
+		return 400, nil, nil, entity.ErrInvalidOptionExpiry
+	}
+
+	return 200, []entity.Order{
+		{
+			Id:        "TestOrderID",
+			Quantity:  -100,
+			Price:     0,
+			Currency:  "BRL",
+			OrderType: "sell",
+			Date:      date,
+			Brokerage: &entity.Brokerage{Id: "TestBrokerageID"},
+			Asset:     &entity.Asset{Id: "TestID"},
+		},
+	}, nil, nil
+}
+
+func (a *MockApplication) ApiGetCompanyProfile(symbol string) (int,
+	*entity.CompanyProfile, error) {
+
+	switch symbol {
+	case "ERROR_ASSET_REPOSITORY":
+		return 500, nil, errors.New("Unknown error in the asset repository")
+	case "UNKNOWN_SYMBOL":
+		return 404, nil, entity.ErrInvalidAssetSymbol
+	case "BTC":
+		return 400, nil, entity.ErrInvalidCompanyProfileAssetType
+	}
+
+	profile, err := a.app.CompanyProfileApp.SearchCompanyProfile("TestID")
+	if err != nil {
+		return 500, nil, err
+	}
+
+	profile.Asset.Symbol = symbol
+	profile.UpdatedAt = entity.StringToTime("2021-10-01")
+
+	return 200, profile, nil
+}
+
+func (a *MockApplication) ApiRefreshCompanyProfiles() (int,
+	[]entity.CompanyProfile, error) {
+	return 200, nil, nil
+}
+
+func (a *MockApplication) ApiImportEarningEvents(symbol string) (int,
+	[]entity.EarningEvent, error) {
+
+	switch symbol {
+	case "ERROR_ASSET_REPOSITORY":
+		return 500, nil, errors.New("Unknown error in the asset repository")
+	case "UNKNOWN_SYMBOL":
+		return 404, nil, entity.ErrInvalidAssetSymbol
+	case "BTC":
+		return 400, nil, entity.ErrInvalidEarningEventProvider
+	}
+
+	events, err := a.app.EarningEventApp.FetchEarningEvents("TestID", symbol,
+		"BR", externalapi.ThirdPartyInterfaces{})
+	if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, events, nil
+}
+
+func (a *MockApplication) ApiProposedEarnings(symbol string, userUid string) (
+	int, []entity.Earnings, error) {
+
+	switch symbol {
+	case "":
+		return 400, nil, entity.ErrInvalidApiQuerySymbolBlank
+	case "ERROR_ASSET_REPOSITORY":
+		return 500, nil, errors.New("Unknown error in the asset repository")
+	case "INVALID_SYMBOL":
+		return 404, nil, entity.ErrMessageApiAssetSymbolUser
+	}
+
+	events, err := a.app.EarningEventApp.SearchUnclaimedEvents("TestID",
+		userUid)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	for i := range events {
+		events[i].Asset.Symbol = symbol
+	}
+
+	orders := []entity.Order{
+		{Quantity: 100, Date: entity.StringToTime("2021-09-01")},
+	}
+
+	return 200, a.app.EarningEventApp.EarningsFromEvents(events, orders, nil,
+		userUid), nil
+}
+
+func (a *MockApplication) ApiCreateEarningsFromEvents(symbol string,
+	userUid string) (int, []entity.Earnings, error) {
+
+	statusCode, earnings, err := a.ApiProposedEarnings(symbol, userUid)
+	if err != nil {
+		return statusCode, nil, err
+	}
+
+	earningsCreated, err := a.app.EarningEventApp.CreateEarningsFromEvents(
+		earnings)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, earningsCreated, nil
+}
+
+func (a *MockApplication) ApiRefreshEarningEvents() (int, []entity.Earnings,
+	map[string]error, error) {
+	return 200, nil, nil, nil
+}
+
+func (a *MockApplication) ApiUpdateAsset(symbol string, fullname string,
+	preference *string, sectorId string, userUid string) (int, *entity.Asset,
+	error) {
+
+	if userUid != "USER_WITH_PRIVILEGE" {
+		return 403, nil, entity.ErrInvalidUserAdminPrivilege
+	}
+
+	switch symbol {
+	case "ERROR_ASSET_REPO":
+		return 500, nil, errors.New("Unknown asset repository error")
+	case "UNKNOWN_SYMBOL":
+		return 404, nil, entity.ErrInvalidAssetSymbol
+	}
+
+	if sectorId == "UNKNOWN_ID" {
+		return 400, nil, entity.ErrInvalidSector
+	}
+
+	currentPreference := "TestPref"
+	asset := &entity.Asset{
+		Id:         "TestID",
+		Symbol:     symbol,
+		Preference: &currentPreference,
+		Fullname:   "Test Name",
+		AssetType: &entity.AssetType{
+			Id:      "TestAssetTypeID",
+			Type:    "STOCK",
+			Country: "US",
+			Name:    "Test ASTY Name",
+		},
+		Sector: &entity.Sector{
+			Id:   "TestSectorID",
+			Name: "Test Sector",
+		},
+	}
+
+	if fullname != "" {
+		asset.Fullname = fullname
+	}
+
+	if preference != nil {
+		asset.Preference = preference
+	}
+
+	if sectorId != "" {
+		asset.Sector = &entity.Sector{Id: sectorId, Name: "Test Sector"}
+	}
+
+	return 200, asset, nil
+}
+
+func (a *MockApplication) ApiRenameSector(sectorId string, name string,
+	userUid string) (int, *entity.Sector, error) {
+
+	if userUid != "USER_WITH_PRIVILEGE" {
+		return 403, nil, entity.ErrInvalidUserAdminPrivilege
+	}
+
+	sector, err := a.app.SectorApp.RenameSector(sectorId, name, nil)
+	if err == entity.ErrInvalidSectorBlank {
+		return 400, nil, err
+	} else if err != nil {
+		return 404, nil, err
+	}
+
+	return 200, sector, nil
+}
+
+func (a *MockApplication) ApiMergeSectors(sourceId string, targetId string,
+	userUid string) (int, *entity.Sector, error) {
+
+	if userUid != "USER_WITH_PRIVILEGE" {
+		return 403, nil, entity.ErrInvalidUserAdminPrivilege
+	}
+
+	sector, err := a.app.SectorApp.MergeSectors(sourceId, targetId, nil)
+	if err == entity.ErrInvalidSectorMerge {
+		return 400, nil, err
+	} else if err != nil {
+		return 404, nil, err
+	}
+
+	return 200, sector, nil
+}
+
+func (a *MockApplication) ApiCreateAssetType(assetType string, name string,
+	country string, userUid string) (int, *entity.AssetType, error) {
+
+	if userUid != "USER_WITH_PRIVILEGE" {
+		return 403, nil, entity.ErrInvalidUserAdminPrivilege
+	}
+
+	if assetType == "STOCK" && country == "US" {
+		return 400, nil, entity.ErrInvalidAssetTypeExist
+	}
+
+	assetTypeCreated, err := entity.NewAssetType(assetType, name, country)
+	if err != nil {
+		return 400, nil, err
+	}
+	assetTypeCreated.Id = "TestAssetTypeID"
+
+	return 200, assetTypeCreated, nil
+}
+
+func (a *MockApplication) ApiUpdateAssetType(assetTypeId string, name string,
+	userUid string) (int, *entity.AssetType, error) {
+
+	if userUid != "USER_WITH_PRIVILEGE" {
+		return 403, nil, entity.ErrInvalidUserAdminPrivilege
+	}
+
+	switch {
+	case assetTypeId == "UNKNOWN_ID":
+		return 404, nil, entity.ErrInvalidAssetTypeId
+	case name == "":
+		return 400, nil, entity.ErrInvalidAssetTypeBlank
+	}
+
+	return 200, &entity.AssetType{
+		Id:      assetTypeId,
+		Type:    "REIT",
+		Name:    name,
+		Country: "US",
+	}, nil
+}
+
+func (a *MockApplication) ApiDeleteAssetType(assetTypeId string,
+	userUid string) (int, *entity.AssetType, error) {
+
+	if userUid != "USER_WITH_PRIVILEGE" {
+		return 403, nil, entity.ErrInvalidUserAdminPrivilege
+	}
+
+	switch assetTypeId {
+	case "UNKNOWN_ID":
+		return 404, nil, entity.ErrInvalidAssetTypeId
+	case "WITH_ASSETS":
+		return 400, nil, entity.ErrInvalidAssetTypeInUse
+	}
+
+	return 200, &entity.AssetType{
+		Id:      assetTypeId,
+		Type:    "REIT",
+		Name:    "REITs",
+		Country: "US",
+	}, nil
+}
+
+func (a *MockApplication) ApiCreateBrokerage(name string, fullname string,
+	country string, custom bool, userUid string) (int, *entity.Brokerage,
+	error) {
+
+	var owner *string
+
+	if custom {
+		owner = &userUid
+	} else if userUid != "USER_WITH_PRIVILEGE" {
+		return 403, nil, entity.ErrInvalidUserAdminPrivilege
+	}
+
+	brokerageCreated, err := a.app.BrokerageApp.CreateBrokerage(name, fullname,
+		country, owner, nil)
+	if err == entity.ErrInvalidBrokerageBlank ||
+		err == entity.ErrInvalidBrokerageExist ||
+		err == entity.ErrInvalidCountryCode {
+		return 400, nil, err
+	} else if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, brokerageCreated, nil
+}
+
+func (a *MockApplication) ApiUpdateBrokerage(brokerageId string, name string,
+	fullname string, country string, userUid string) (int, *entity.Brokerage,
+	error) {
+
+	httpStatusCode, brokerageInfo, err := a.editableBrokerage(brokerageId,
+		userUid)
+	if err != nil {
+		return httpStatusCode, nil, err
+	}
+
+	brokerageUpdated, err := a.app.BrokerageApp.UpdateBrokerage(*brokerageInfo,
+		name, fullname, country, nil)
+	if err == entity.ErrInvalidBrokerageBlank ||
+		err == entity.ErrInvalidBrokerageExist ||
+		err == entity.ErrInvalidCountryCode {
+		return 400, nil, err
+	} else if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, brokerageUpdated, nil
+}
+
+func (a *MockApplication) ApiDeleteBrokerage(brokerageId string,
+	userUid string) (int, *entity.Brokerage, error) {
+
+	httpStatusCode, _, err := a.editableBrokerage(brokerageId, userUid)
+	if err != nil {
+		return httpStatusCode, nil, err
+	}
+
+	brokerageDeleted, err := a.app.BrokerageApp.DeleteBrokerage(brokerageId,
+		nil)
+	if err == entity.ErrInvalidBrokerageInUse {
+		return 400, nil, err
+	} else if err != nil {
+		return 500, nil, err
+	}
+
+	return 200, brokerageDeleted, nil
+}
+
+func (a *MockApplication) editableBrokerage(brokerageId string,
+	userUid string) (int, *entity.Brokerage, error) {
+
+	brokerageInfo, err := a.app.BrokerageApp.SearchBrokerageById(brokerageId,
+		userUid)
+	if err == entity.ErrInvalidBrokerageId {
+		return 404, nil, err
+	} else if err != nil {
+		return 500, nil, err
+	}
+
+	if !brokerageInfo.IsCustom() && userUid != "USER_WITH_PRIVILEGE" {
+		return 403, nil, entity.ErrInvalidUserAdminPrivilege
+	}
+
+	return 200, brokerageInfo, nil
+}
@@ -0,0 +1,37 @@
+package market
+
+import (
+	"stockfyApi/entity"
+)
+
+type Application struct {
+	repo Repository
+}
+
+//NewApplication create new use case
+func NewApplication(r Repository) *Application {
+	return &Application{
+		repo: r,
+	}
+}
+
+// LoadMarkets replaces the default markets by the ones registered in the
+// database. If there is no market registered, the default ones are kept.
+func (a *Application) LoadMarkets() ([]entity.Market, error) {
+	markets, err := a.repo.SearchAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(markets) == 0 {
+		return entity.ListMarkets(), nil
+	}
+
+	entity.SetMarkets(markets)
+
+	return markets, nil
+}
+
+func (a *Application) SearchMarkets() []entity.Market {
+	return entity.ListMarkets()
+}
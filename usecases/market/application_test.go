@@ -0,0 +1,66 @@
+package market
+
+import (
+	"errors"
+	"stockfyApi/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadMarkets(t *testing.T) {
+	type test struct {
+		markets         []entity.Market
+		repositoryError bool
+		expectedMarkets []entity.Market
+		expectedError   error
+	}
+
+	bdrMarket := entity.Market{
+		Country:           "BDR",
+		Name:              "BDRs",
+		Currencies:        []string{"BRL"},
+		ReferenceCurrency: "USD",
+		SymbolSuffix:      ".SA",
+		QuantityDecimals:  0,
+		Provider:          entity.ProviderAlphaVantage,
+		AssetTypes:        []string{"STOCK", "ETF"},
+	}
+
+	tests := []test{
+		{
+			markets:         nil,
+			repositoryError: true,
+			expectedMarkets: nil,
+			expectedError:   errors.New("Unknown market repository error"),
+		},
+		{
+			markets:         nil,
+			repositoryError: false,
+			expectedMarkets: entity.DefaultMarkets,
+			expectedError:   nil,
+		},
+		{
+			markets:         append(entity.DefaultMarkets, bdrMarket),
+			repositoryError: false,
+			expectedMarkets: append(entity.DefaultMarkets, bdrMarket),
+			expectedError:   nil,
+		},
+	}
+
+	defer entity.SetMarkets(entity.DefaultMarkets)
+
+	for _, testCase := range tests {
+		app := NewApplication(NewMockRepo(testCase.markets,
+			testCase.repositoryError))
+
+		markets, err := app.LoadMarkets()
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expectedMarkets, markets)
+	}
+
+	// The markets loaded from the repository are used by the validations
+	market, err := entity.SearchMarket("BDR")
+	assert.Nil(t, err)
+	assert.Equal(t, &bdrMarket, market)
+}
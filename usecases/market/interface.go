@@ -0,0 +1,12 @@
+package market
+
+import "stockfyApi/entity"
+
+type Repository interface {
+	SearchAll() ([]entity.Market, error)
+}
+
+type UseCases interface {
+	LoadMarkets() ([]entity.Market, error)
+	SearchMarkets() []entity.Market
+}
@@ -0,0 +1,20 @@
+package market
+
+import (
+	"stockfyApi/entity"
+)
+
+type MockApplication struct {
+}
+
+func NewMockApplication() *MockApplication {
+	return &MockApplication{}
+}
+
+func (a *MockApplication) LoadMarkets() ([]entity.Market, error) {
+	return entity.DefaultMarkets, nil
+}
+
+func (a *MockApplication) SearchMarkets() []entity.Market {
+	return entity.DefaultMarkets
+}
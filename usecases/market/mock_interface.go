@@ -0,0 +1,26 @@
+package market
+
+import (
+	"errors"
+	"stockfyApi/entity"
+)
+
+type MockDb struct {
+	markets []entity.Market
+	err     bool
+}
+
+func NewMockRepo(markets []entity.Market, err bool) *MockDb {
+	return &MockDb{
+		markets: markets,
+		err:     err,
+	}
+}
+
+func (m *MockDb) SearchAll() ([]entity.Market, error) {
+	if m.err {
+		return nil, errors.New("Unknown market repository error")
+	}
+
+	return m.markets, nil
+}
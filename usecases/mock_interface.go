@@ -2,11 +2,23 @@ package usecases
 
 import (
 	"stockfyApi/usecases/asset"
+	auditlog "stockfyApi/usecases/auditLog"
+	"stockfyApi/usecases/backup"
 	"stockfyApi/usecases/brokerage"
+	"stockfyApi/usecases/cash"
+	companyprofile "stockfyApi/usecases/companyProfile"
 	dbverification "stockfyApi/usecases/dbVerification"
+	earningevent "stockfyApi/usecases/earningEvent"
 	"stockfyApi/usecases/earnings"
+	fixedincome "stockfyApi/usecases/fixedIncome"
+	incomeprojection "stockfyApi/usecases/incomeProjection"
+	"stockfyApi/usecases/market"
+	"stockfyApi/usecases/option"
 	"stockfyApi/usecases/order"
+	"stockfyApi/usecases/reconciliation"
+	"stockfyApi/usecases/remittance"
 	"stockfyApi/usecases/sector"
+	symbolsearch "stockfyApi/usecases/symbolSearch"
 	"stockfyApi/usecases/user"
 )
 
@@ -28,10 +40,22 @@ func NewMockApplications() *Applications {
 		// AssetTypeApp:      *assettype.NewApplication(),
 		AssetApp: asset.NewMockApplication(),
 		// AssetUserApp:      *assetusers.NewApplication(),
-		UserApp:           user.NewMockApplication(),
-		OrderApp:          order.NewMockApplication(),
-		BrokerageApp:      brokerage.NewMockApplication(),
-		EarningsApp:       earnings.NewMockApplication(),
-		DbVerificationApp: dbverification.NewMockApplication(),
+		UserApp:             user.NewMockApplication(),
+		OrderApp:            order.NewMockApplication(),
+		BrokerageApp:        brokerage.NewMockApplication(),
+		EarningsApp:         earnings.NewMockApplication(),
+		DbVerificationApp:   dbverification.NewMockApplication(),
+		FixedIncomeApp:      fixedincome.NewMockApplication(),
+		MarketApp:           market.NewMockApplication(),
+		OptionApp:           option.NewMockApplication(),
+		CompanyProfileApp:   companyprofile.NewMockApplication(),
+		EarningEventApp:     earningevent.NewMockApplication(),
+		IncomeProjectionApp: incomeprojection.NewMockApplication(),
+		SymbolSearchApp:     symbolsearch.NewMockApplication(),
+		AuditLogApp:         auditlog.NewMockApplication(),
+		BackupApp:           backup.NewMockApplication(),
+		ReconciliationApp:   reconciliation.NewMockApplication(),
+		CashApp:             cash.NewMockApplication(),
+		RemittanceApp:       remittance.NewMockApplication(),
 	}
 }
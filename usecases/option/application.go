@@ -0,0 +1,163 @@
+package option
+
+import (
+	"sort"
+	"stockfyApi/entity"
+	"time"
+)
+
+type Application struct {
+	repo Repository
+}
+
+//NewApplication create new use case
+func NewApplication(r Repository) *Application {
+	return &Application{
+		repo: r,
+	}
+}
+
+func (a *Application) OptionVerification(optionType string, strike float64,
+	expiry string) error {
+
+	_, err := entity.NewOption(optionType, strike, entity.StringToTime(expiry),
+		"", "")
+
+	return err
+}
+
+func (a *Application) CreateOption(optionType string, strike float64,
+	expiry string, assetId string, underlyingId string) (*entity.Option,
+	error) {
+
+	option, err := entity.NewOption(optionType, strike,
+		entity.StringToTime(expiry), assetId, underlyingId)
+	if err != nil {
+		return nil, err
+	}
+
+	optionCreated, err := a.repo.Create(*option)
+	if err != nil {
+		return nil, err
+	}
+
+	return &optionCreated[0], nil
+}
+
+func (a *Application) SearchOptionByAsset(assetId string) (*entity.Option,
+	error) {
+
+	option, err := a.repo.SearchByAsset(assetId)
+	if err != nil {
+		return nil, err
+	}
+
+	if option == nil {
+		return nil, nil
+	}
+
+	return &option[0], nil
+}
+
+// SearchExpiredOptions returns the options expired until the given date that
+// were not settled yet.
+func (a *Application) SearchExpiredOptions(date time.Time) ([]entity.Option,
+	error) {
+	return a.repo.SearchExpired(date)
+}
+
+// SearchOptionHolders returns the uid of the users with orders on the option.
+func (a *Application) SearchOptionHolders(assetId string) ([]string, error) {
+	return a.repo.SearchHolders(assetId)
+}
+
+// SettleOption marks the option as settled together with the orders that
+// close the positions of its holders. No order is created when the option was
+// already settled.
+func (a *Application) SettleOption(optionId string, orders []entity.Order) (
+	[]entity.Order, error) {
+	return a.repo.Settle(optionId, orders)
+}
+
+// optionAccountPosition is the net quantity of an option in a brokerage
+// account of the user.
+type optionAccountPosition struct {
+	brokerage *entity.Brokerage
+	account   *entity.BrokerageAccount
+	quantity  float64
+}
+
+// ExpiryOrders returns the orders that close the position of a user in an
+// expired option. The position is closed in each brokerage account with a
+// zero price order. If the option is in the money, the exercise (long
+// position) or the assignment (short position) also creates an order on the
+// underlying asset at the strike price, in the same brokerage account.
+func (a *Application) ExpiryOrders(option entity.Option,
+	orders []entity.Order, underlyingPrice float64) []entity.Order {
+
+	var expiryOrders []entity.Order
+	var positions []*optionAccountPosition
+	accountPositions := map[string]*optionAccountPosition{}
+
+	sortedOrders := append([]entity.Order(nil), orders...)
+	sort.SliceStable(sortedOrders, func(i, j int) bool {
+		return sortedOrders[i].Date.Before(sortedOrders[j].Date)
+	})
+
+	for _, order := range sortedOrders {
+		accountId := ""
+		if order.Account != nil {
+			accountId = order.Account.Id
+		}
+
+		position, ok := accountPositions[accountId]
+		if !ok {
+			position = &optionAccountPosition{}
+			accountPositions[accountId] = position
+			positions = append(positions, position)
+		}
+
+		position.quantity += order.Quantity
+		position.brokerage = order.Brokerage
+		position.account = order.Account
+	}
+
+	for _, position := range positions {
+		if position.quantity == 0 {
+			continue
+		}
+
+		expiryOrders = append(expiryOrders, expiryOrder(-position.quantity, 0,
+			option.Expiry, position.brokerage, position.account, option.Asset))
+
+		if option.InTheMoney(underlyingPrice) {
+			expiryOrders = append(expiryOrders, expiryOrder(
+				option.ExerciseQuantity(position.quantity), option.Strike,
+				option.Expiry, position.brokerage, position.account,
+				option.Underlying))
+		}
+	}
+
+	return expiryOrders
+}
+
+func expiryOrder(quantity float64, price float64, date time.Time,
+	brokerage *entity.Brokerage, account *entity.BrokerageAccount,
+	asset *entity.Asset) entity.Order {
+
+	orderType := "buy"
+	if quantity < 0 {
+		orderType = "sell"
+	}
+
+	return entity.Order{
+		Quantity:  quantity,
+		Price:     price,
+		Currency:  "BRL",
+		OrderType: orderType,
+		Date:      date,
+		Brokerage: brokerage,
+		Account:   account,
+		Asset:     asset,
+	}
+}
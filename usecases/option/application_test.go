@@ -0,0 +1,253 @@
+package option
+
+import (
+	"errors"
+	"stockfyApi/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionVerification(t *testing.T) {
+	type test struct {
+		optionType    string
+		strike        float64
+		expiry        string
+		expectedError error
+	}
+
+	tests := []test{
+		{
+			optionType:    "CALL",
+			strike:        30,
+			expiry:        "2021-12-17",
+			expectedError: nil,
+		},
+		{
+			optionType:    "STRADDLE",
+			strike:        30,
+			expiry:        "2021-12-17",
+			expectedError: entity.ErrInvalidOptionType,
+		},
+		{
+			optionType:    "PUT",
+			strike:        0,
+			expiry:        "2021-12-17",
+			expectedError: entity.ErrInvalidOptionStrike,
+		},
+	}
+
+	optionApp := NewApplication(NewMockRepo())
+
+	for _, testCase := range tests {
+		err := optionApp.OptionVerification(testCase.optionType,
+			testCase.strike, testCase.expiry)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+func TestCreateOption(t *testing.T) {
+	optionApp := NewApplication(NewMockRepo())
+
+	option, err := optionApp.CreateOption("CALL", 30, "2021-12-17",
+		"PETRL300ID", "PETR4ID")
+	assert.Nil(t, err)
+	assert.Equal(t, &entity.Option{
+		Id:         "OptionID",
+		OptionType: "CALL",
+		Strike:     30,
+		Expiry:     entity.StringToTime("2021-12-17"),
+		Asset:      &entity.Asset{Id: "PETRL300ID"},
+		Underlying: &entity.Asset{Id: "PETR4ID"},
+	}, option)
+
+	option, err = optionApp.CreateOption("CALL", 30, "2021-12-17",
+		"ERROR_REPOSITORY", "PETR4ID")
+	assert.Nil(t, option)
+	assert.Equal(t, errors.New("Unknown option repository error"), err)
+}
+
+func TestSearchOptionByAsset(t *testing.T) {
+	optionApp := NewApplication(NewMockRepo())
+
+	option, err := optionApp.SearchOptionByAsset("PETRL300ID")
+	assert.Nil(t, err)
+	assert.Equal(t, "PETR4", option.Underlying.Symbol)
+
+	option, err = optionApp.SearchOptionByAsset("UNKNOWN_ID")
+	assert.Nil(t, err)
+	assert.Nil(t, option)
+}
+
+func TestSettleOption(t *testing.T) {
+	optionApp := NewApplication(NewMockRepo())
+
+	orders := []entity.Order{
+		{Quantity: -100, Price: 0, Currency: "BRL", OrderType: "sell",
+			UserUid: "TestUserUID"},
+	}
+
+	ordersCreated, err := optionApp.SettleOption("TestOptionID", orders)
+	assert.Nil(t, err)
+	assert.Equal(t, []entity.Order{
+		{Id: "TestOrderID1", Quantity: -100, Price: 0, Currency: "BRL",
+			OrderType: "sell", UserUid: "TestUserUID"},
+	}, ordersCreated)
+
+	// An option already settled does not create its orders again
+	ordersCreated, err = optionApp.SettleOption("SETTLED_ID", orders)
+	assert.Nil(t, err)
+	assert.Nil(t, ordersCreated)
+}
+
+func TestExpiryOrders(t *testing.T) {
+	type test struct {
+		optionType      string
+		orders          []entity.Order
+		underlyingPrice float64
+		expectedOrders  []entity.Order
+	}
+
+	brokerage := &entity.Brokerage{Id: "BrokerageID", Name: "Clear"}
+	account := &entity.BrokerageAccount{Id: "AccountID", Nickname: "Clear"}
+	otherAccount := &entity.BrokerageAccount{Id: "OtherAccountID",
+		Nickname: "Clear 2"}
+	expiry := entity.StringToTime("2021-12-17")
+	optionAsset := &entity.Asset{Id: "PETRL300ID", Symbol: "PETRL300"}
+	underlying := &entity.Asset{Id: "PETR4ID", Symbol: "PETR4"}
+
+	tests := []test{
+		// Long call exercised
+		{
+			optionType: "CALL",
+			orders: []entity.Order{
+				{Quantity: 100, Price: 1.2, OrderType: "buy",
+					Date: entity.StringToTime("2021-11-10"), Brokerage: brokerage,
+					Account: account},
+			},
+			underlyingPrice: 32,
+			expectedOrders: []entity.Order{
+				{Quantity: -100, Price: 0, Currency: "BRL", OrderType: "sell",
+					Date: expiry, Brokerage: brokerage, Account: account,
+					Asset: optionAsset},
+				{Quantity: 100, Price: 30, Currency: "BRL", OrderType: "buy",
+					Date: expiry, Brokerage: brokerage, Account: account,
+					Asset: underlying},
+			},
+		},
+		// Short put assigned
+		{
+			optionType: "PUT",
+			orders: []entity.Order{
+				{Quantity: -200, Price: 0.8, OrderType: "sell",
+					Date: entity.StringToTime("2021-11-10"), Brokerage: brokerage,
+					Account: account},
+			},
+			underlyingPrice: 28,
+			expectedOrders: []entity.Order{
+				{Quantity: 200, Price: 0, Currency: "BRL", OrderType: "buy",
+					Date: expiry, Brokerage: brokerage, Account: account,
+					Asset: optionAsset},
+				{Quantity: 200, Price: 30, Currency: "BRL", OrderType: "buy",
+					Date: expiry, Brokerage: brokerage, Account: account,
+					Asset: underlying},
+			},
+		},
+		// Long call expired worthless
+		{
+			optionType: "CALL",
+			orders: []entity.Order{
+				{Quantity: 100, Price: 1.2, OrderType: "buy",
+					Date: entity.StringToTime("2021-11-10"), Brokerage: brokerage,
+					Account: account},
+			},
+			underlyingPrice: 29,
+			expectedOrders: []entity.Order{
+				{Quantity: -100, Price: 0, Currency: "BRL", OrderType: "sell",
+					Date: expiry, Brokerage: brokerage, Account: account,
+					Asset: optionAsset},
+			},
+		},
+		// Position closed before expiry
+		{
+			optionType: "CALL",
+			orders: []entity.Order{
+				{Quantity: 100, Price: 1.2, OrderType: "buy",
+					Date: entity.StringToTime("2021-11-10"), Brokerage: brokerage,
+					Account: account},
+				{Quantity: -100, Price: 1.5, OrderType: "sell",
+					Date: entity.StringToTime("2021-11-20"), Brokerage: brokerage,
+					Account: account},
+			},
+			underlyingPrice: 32,
+			expectedOrders:  nil,
+		},
+		// Long call exercised in two accounts
+		{
+			optionType: "CALL",
+			orders: []entity.Order{
+				{Quantity: 100, Price: 1.2, OrderType: "buy",
+					Date: entity.StringToTime("2021-11-10"), Brokerage: brokerage,
+					Account: account},
+				{Quantity: 300, Price: 1.1, OrderType: "buy",
+					Date: entity.StringToTime("2021-11-12"), Brokerage: brokerage,
+					Account: otherAccount},
+				{Quantity: -100, Price: 1.5, OrderType: "sell",
+					Date: entity.StringToTime("2021-11-20"), Brokerage: brokerage,
+					Account: otherAccount},
+			},
+			underlyingPrice: 32,
+			expectedOrders: []entity.Order{
+				{Quantity: -100, Price: 0, Currency: "BRL", OrderType: "sell",
+					Date: expiry, Brokerage: brokerage, Account: account,
+					Asset: optionAsset},
+				{Quantity: 100, Price: 30, Currency: "BRL", OrderType: "buy",
+					Date: expiry, Brokerage: brokerage, Account: account,
+					Asset: underlying},
+				{Quantity: -200, Price: 0, Currency: "BRL", OrderType: "sell",
+					Date: expiry, Brokerage: brokerage, Account: otherAccount,
+					Asset: optionAsset},
+				{Quantity: 200, Price: 30, Currency: "BRL", OrderType: "buy",
+					Date: expiry, Brokerage: brokerage, Account: otherAccount,
+					Asset: underlying},
+			},
+		},
+		// Position moved between accounts is closed only where it is held
+		{
+			optionType: "CALL",
+			orders: []entity.Order{
+				{Quantity: 100, Price: 1.2, OrderType: "buy",
+					Date: entity.StringToTime("2021-11-10"), Brokerage: brokerage,
+					Account: account},
+				{Quantity: -100, Price: 1.5, OrderType: "sell",
+					Date: entity.StringToTime("2021-11-20"), Brokerage: brokerage,
+					Account: account},
+				{Quantity: 100, Price: 1.4, OrderType: "buy",
+					Date: entity.StringToTime("2021-11-22"), Brokerage: brokerage,
+					Account: otherAccount},
+			},
+			underlyingPrice: 29,
+			expectedOrders: []entity.Order{
+				{Quantity: -100, Price: 0, Currency: "BRL", OrderType: "sell",
+					Date: expiry, Brokerage: brokerage, Account: otherAccount,
+					Asset: optionAsset},
+			},
+		},
+	}
+
+	optionApp := NewApplication(NewMockRepo())
+
+	for _, testCase := range tests {
+		option := entity.Option{
+			OptionType: testCase.optionType,
+			Strike:     30,
+			Expiry:     expiry,
+			Asset:      optionAsset,
+			Underlying: underlying,
+		}
+
+		orders := optionApp.ExpiryOrders(option, testCase.orders,
+			testCase.underlyingPrice)
+		assert.Equal(t, testCase.expectedOrders, orders)
+	}
+}
@@ -0,0 +1,27 @@
+package option
+
+import (
+	"stockfyApi/entity"
+	"time"
+)
+
+type Repository interface {
+	Create(option entity.Option) ([]entity.Option, error)
+	SearchByAsset(assetId string) ([]entity.Option, error)
+	SearchExpired(date time.Time) ([]entity.Option, error)
+	SearchHolders(assetId string) ([]string, error)
+	Settle(optionId string, orders []entity.Order) ([]entity.Order, error)
+}
+
+type UseCases interface {
+	OptionVerification(optionType string, strike float64, expiry string) error
+	CreateOption(optionType string, strike float64, expiry string,
+		assetId string, underlyingId string) (*entity.Option, error)
+	SearchOptionByAsset(assetId string) (*entity.Option, error)
+	SearchExpiredOptions(date time.Time) ([]entity.Option, error)
+	SearchOptionHolders(assetId string) ([]string, error)
+	SettleOption(optionId string, orders []entity.Order) ([]entity.Order,
+		error)
+	ExpiryOrders(option entity.Option, orders []entity.Order,
+		underlyingPrice float64) []entity.Order
+}
@@ -0,0 +1,82 @@
+package option
+
+import (
+	"errors"
+	"stockfyApi/entity"
+	"time"
+)
+
+type MockApplication struct {
+}
+
+func NewMockApplication() *MockApplication {
+	return &MockApplication{}
+}
+
+func (a *MockApplication) OptionVerification(optionType string,
+	strike float64, expiry string) error {
+
+	_, err := entity.NewOption(optionType, strike, entity.StringToTime(expiry),
+		"", "")
+
+	return err
+}
+
+func (a *MockApplication) CreateOption(optionType string, strike float64,
+	expiry string, assetId string, underlyingId string) (*entity.Option,
+	error) {
+
+	option, err := entity.NewOption(optionType, strike,
+		entity.StringToTime(expiry), assetId, underlyingId)
+	if err != nil {
+		return nil, err
+	}
+
+	if assetId == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown option repository error")
+	}
+
+	option.Id = "OptionID"
+
+	return option, nil
+}
+
+func (a *MockApplication) SearchOptionByAsset(assetId string) (*entity.Option,
+	error) {
+
+	switch assetId {
+	case "ERROR_REPOSITORY":
+		return nil, errors.New("Unknown option repository error")
+	case "UNKNOWN_ID":
+		return nil, nil
+	default:
+		return &entity.Option{
+			Id:         "OptionID",
+			OptionType: "CALL",
+			Strike:     30,
+			Expiry:     entity.StringToTime("2021-12-17"),
+			Asset:      &entity.Asset{Id: assetId, Symbol: "PETRL300"},
+			Underlying: &entity.Asset{Id: "PETR4ID", Symbol: "PETR4"},
+		}, nil
+	}
+}
+
+func (a *MockApplication) SearchExpiredOptions(date time.Time) (
+	[]entity.Option, error) {
+	return nil, nil
+}
+
+func (a *MockApplication) SearchOptionHolders(assetId string) ([]string,
+	error) {
+	return []string{"TestUserUID"}, nil
+}
+
+func (a *MockApplication) SettleOption(optionId string,
+	orders []entity.Order) ([]entity.Order, error) {
+	return orders, nil
+}
+
+func (a *MockApplication) ExpiryOrders(option entity.Option,
+	orders []entity.Order, underlyingPrice float64) []entity.Order {
+	return nil
+}
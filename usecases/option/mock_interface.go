@@ -0,0 +1,87 @@
+package option
+
+import (
+	"errors"
+	"stockfyApi/entity"
+	"strconv"
+	"time"
+)
+
+type MockDb struct {
+}
+
+func NewMockRepo() *MockDb {
+	return &MockDb{}
+}
+
+func (m *MockDb) Create(option entity.Option) ([]entity.Option, error) {
+	if option.Asset.Id == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown option repository error")
+	}
+
+	option.Id = "OptionID"
+
+	return []entity.Option{option}, nil
+}
+
+func (m *MockDb) SearchByAsset(assetId string) ([]entity.Option, error) {
+	if assetId == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown option repository error")
+	}
+
+	if assetId == "UNKNOWN_ID" {
+		return nil, nil
+	}
+
+	return []entity.Option{
+		{
+			Id:         "OptionID",
+			OptionType: "CALL",
+			Strike:     30,
+			Expiry:     entity.StringToTime("2021-12-17"),
+			Asset:      &entity.Asset{Id: assetId, Symbol: "PETRL300"},
+			Underlying: &entity.Asset{Id: "PETR4ID", Symbol: "PETR4"},
+		},
+	}, nil
+}
+
+func (m *MockDb) SearchExpired(date time.Time) ([]entity.Option, error) {
+	if date.Before(entity.StringToTime("2021-12-17")) {
+		return nil, nil
+	}
+
+	return []entity.Option{
+		{
+			Id:         "OptionID",
+			OptionType: "CALL",
+			Strike:     30,
+			Expiry:     entity.StringToTime("2021-12-17"),
+			Asset:      &entity.Asset{Id: "PETRL300ID", Symbol: "PETRL300"},
+			Underlying: &entity.Asset{Id: "PETR4ID", Symbol: "PETR4"},
+		},
+	}, nil
+}
+
+func (m *MockDb) SearchHolders(assetId string) ([]string, error) {
+	if assetId == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown option repository error")
+	}
+
+	return []string{"TestUserUID"}, nil
+}
+
+func (m *MockDb) Settle(optionId string, orders []entity.Order) (
+	[]entity.Order, error) {
+
+	if optionId == "SETTLED_ID" {
+		return nil, nil
+	}
+
+	var ordersCreated []entity.Order
+	for i, order := range orders {
+		order.Id = "TestOrderID" + strconv.Itoa(i+1)
+		ordersCreated = append(ordersCreated, order)
+	}
+
+	return ordersCreated, nil
+}
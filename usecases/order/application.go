@@ -1,9 +1,16 @@
 package order
 
 import (
+	"encoding/csv"
 	"errors"
+	"io"
+	b3report "stockfyApi/b3Report"
+	brokerstatement "stockfyApi/brokerStatement"
+	"stockfyApi/calendar"
 	"stockfyApi/entity"
+	tradenote "stockfyApi/tradeNote"
 	"strings"
+	"unicode/utf8"
 )
 
 type Application struct {
@@ -18,12 +25,12 @@ func NewApplication(r Repository) *Application {
 }
 
 func (a *Application) CreateOrder(quantity float64, price float64,
-	currency string, orderType string, date string, brokerageId string,
+	currency string, orderType string, date string, accountId string,
 	assetId string, userUid string) (*entity.Order, error) {
 
 	dateFormatted := entity.StringToTime(date)
 	orderFormatted, err := entity.NewOrder(quantity, price, currency, orderType,
-		dateFormatted, brokerageId, assetId, userUid)
+		dateFormatted, accountId, assetId, userUid)
 	if err != nil {
 		return nil, err
 	}
@@ -92,6 +99,13 @@ func (a *Application) SearchOrdersFromAssetUser(assetId string, userUid string)
 	return assetInfo, nil
 }
 
+// SearchOrdersFromUser returns all the orders of the user.
+func (a *Application) SearchOrdersFromUser(userUid string) ([]entity.Order,
+	error) {
+
+	return a.repo.SearchFromUser(userUid)
+}
+
 func (a *Application) SearchOrdersSearchFromAssetUserByDate(assetId string,
 	userUid string, orderBy string, limit int, offset int) ([]entity.Order,
 	error) {
@@ -111,13 +125,13 @@ func (a *Application) SearchOrdersSearchFromAssetUserByDate(assetId string,
 }
 
 func (a *Application) UpdateOrder(orderId string, userUid string, price float64,
-	quantity float64, orderType, date string, brokerageId string,
+	quantity float64, orderType, date string, accountId string,
 	currency string) (*entity.Order, error) {
 
 	dateFormatted := entity.StringToTime(date)
 
 	orderFormatted, err := entity.NewOrder(quantity, price, currency,
-		orderType, dateFormatted, brokerageId, "", userUid)
+		orderType, dateFormatted, accountId, "", userUid)
 	if err != nil {
 		return nil, err
 	}
@@ -129,28 +143,23 @@ func (a *Application) UpdateOrder(orderId string, userUid string, price float64,
 }
 
 func (a *Application) OrderVerification(orderType string, country string,
-	quantity float64, price float64, currency string) error {
+	quantity float64, price float64, currency string, date string) error {
 
 	if orderType != "sell" && orderType != "buy" {
 		return entity.ErrInvalidOrderType
 	}
 
-	if country != "BR" && country != "US" {
-		return entity.ErrInvalidCountryCode
-	}
-
-	if country == "BR" && (orderType == "sell" || orderType == "buy") {
-		if !entity.IsIntegral(quantity) {
-			return entity.ErrInvalidOrderQuantityBrazil
-		}
+	market, err := entity.SearchMarket(country)
+	if err != nil {
+		return err
 	}
 
-	if country == "BR" && currency != "BRL" {
-		return entity.ErrInvalidBrazilCurrency
+	if err := market.ValidateQuantity(quantity); err != nil {
+		return err
 	}
 
-	if country == "US" && currency != "USD" {
-		return entity.ErrInvalidUsaCurrency
+	if err := market.ValidateCurrency(currency); err != nil {
+		return err
 	}
 
 	if orderType == "buy" && quantity < 0 {
@@ -161,5 +170,123 @@ func (a *Application) OrderVerification(orderType string, country string,
 		return entity.ErrInvalidOrderPrice
 	}
 
+	orderDate, err := calendar.ParseDate(date)
+	if err != nil {
+		return entity.ErrInvalidOrderDate
+	}
+
+	// Markets without exchange calendar, like cryptocurrencies, trade every day
+	exchange, err := calendar.SearchExchangeByCountry(country)
+	if err == nil && !exchange.IsTradingDay(orderDate) {
+		return entity.ErrInvalidOrderDateNoSession
+	}
+
 	return nil
 }
+
+// ReadOrderImport reads the orders of a CSV file. The first line has the name
+// of the columns, which can be renamed by the mapping. The rows are returned
+// with the problems found in each of them, without being validated as orders.
+func (a *Application) ReadOrderImport(file io.Reader,
+	mapping map[string]string, delimiter string) ([]entity.OrderImportRow,
+	error) {
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if delimiter != "" {
+		comma, size := utf8.DecodeRuneInString(delimiter)
+		if size != len(delimiter) || comma == '"' {
+			return nil, entity.ErrInvalidOrderImportFile
+		}
+		reader.Comma = comma
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, entity.ErrInvalidOrderImportFile
+	}
+
+	columns, err := entity.OrderImportColumns(header, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []entity.OrderImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, entity.ErrInvalidOrderImportFile
+		}
+
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		if len(rows) == entity.MaxOrderImportRows {
+			return nil, entity.ErrInvalidOrderImportSize
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, entity.NewOrderImportRow(line, record, columns))
+	}
+
+	if len(rows) == 0 {
+		return nil, entity.ErrInvalidOrderImportEmpty
+	}
+
+	return rows, nil
+}
+
+// ReadTradeNotes reads the trade notes of the brazilian brokerages from the
+// text extracted of their PDF, with the fees and withheld tax of each order.
+func (a *Application) ReadTradeNotes(text string) ([]entity.TradeNote,
+	error) {
+
+	if strings.TrimSpace(text) == "" {
+		return nil, entity.ErrInvalidTradeNoteBlank
+	}
+
+	return tradenote.ParseSinacor(text)
+}
+
+// ReadB3Report reads a report exported from the investor area of the B3: the
+// trades, the movements or the custody position of the user.
+func (a *Application) ReadB3Report(file []byte) (*entity.B3Report, error) {
+	if len(file) == 0 {
+		return nil, entity.ErrInvalidOrderImportFile
+	}
+
+	return b3report.Read(file)
+}
+
+// ReadBrokerStatement reads a statement exported by a US brokerage, as an
+// Avenue or Passfolio CSV file or an OFX file.
+func (a *Application) ReadBrokerStatement(file []byte) (*entity.BrokerStatement,
+	error) {
+
+	if len(file) == 0 {
+		return nil, entity.ErrInvalidOrderImportFile
+	}
+
+	return brokerstatement.Read(file)
+}
+
+// CreateOrders stores all the orders or none of them.
+func (a *Application) CreateOrders(orders []entity.Order) ([]entity.Order,
+	error) {
+
+	return a.repo.CreateBulk(orders)
+}
+
+// CreateImport stores the imported orders and earnings together with the
+// assets and the default brokerage accounts they need, or none of them. The
+// orders and the earnings of the new assets and accounts do not have their Id.
+func (a *Application) CreateImport(assets []entity.Asset,
+	accounts []entity.BrokerageAccount, orders []entity.Order,
+	earnings []entity.Earnings) ([]entity.Order, []entity.Earnings, error) {
+
+	return a.repo.CreateImport(assets, accounts, orders, earnings)
+}
@@ -3,6 +3,7 @@ package order
 import (
 	"errors"
 	"stockfyApi/entity"
+	"strings"
 	"testing"
 	"time"
 
@@ -26,6 +27,10 @@ func TestCreateOrder(t *testing.T) {
 		OrderType: "buy",
 		Date:      dateFormatted,
 		Brokerage: &brokerage,
+		Account: &entity.BrokerageAccount{
+			Id:       "AccountID",
+			Nickname: "Test Broker",
+		},
 		Asset: &entity.Asset{
 			Id: "AssetID",
 		},
@@ -35,7 +40,7 @@ func TestCreateOrder(t *testing.T) {
 	app := NewApplication(mocked)
 
 	orderCreated, err := app.CreateOrder(3.4, 221.38, "USD", "buy", "2021-10-04",
-		"BrokerageID", "AssetID", "userUID")
+		"AccountID", "AssetID", "userUID")
 
 	assert.Equal(t, expectedOrderCreated, *orderCreated)
 	assert.Nil(t, err)
@@ -135,6 +140,7 @@ func TestOrderVerification(t *testing.T) {
 		quantity      float64
 		price         float64
 		currency      string
+		date          string
 		expectedError error
 	}
 
@@ -145,6 +151,7 @@ func TestOrderVerification(t *testing.T) {
 			quantity:      -20,
 			price:         10.92,
 			currency:      "BRL",
+			date:          "2021-10-01",
 			expectedError: nil,
 		},
 		{
@@ -153,6 +160,7 @@ func TestOrderVerification(t *testing.T) {
 			quantity:      20,
 			price:         10.92,
 			currency:      "BRL",
+			date:          "2021-10-01",
 			expectedError: nil,
 		},
 		{
@@ -161,6 +169,7 @@ func TestOrderVerification(t *testing.T) {
 			quantity:      20,
 			price:         10.92,
 			currency:      "BRL",
+			date:          "2021-10-01",
 			expectedError: entity.ErrInvalidOrderType,
 		},
 		{
@@ -169,6 +178,7 @@ func TestOrderVerification(t *testing.T) {
 			quantity:      20.35,
 			price:         10.92,
 			currency:      "BRL",
+			date:          "2021-10-01",
 			expectedError: entity.ErrInvalidCountryCode,
 		},
 		{
@@ -177,6 +187,7 @@ func TestOrderVerification(t *testing.T) {
 			quantity:      20.35,
 			price:         10.92,
 			currency:      "BRL",
+			date:          "2021-10-01",
 			expectedError: entity.ErrInvalidOrderQuantityBrazil,
 		},
 		{
@@ -185,6 +196,7 @@ func TestOrderVerification(t *testing.T) {
 			quantity:      -20.35,
 			price:         10.92,
 			currency:      "BRL",
+			date:          "2021-10-01",
 			expectedError: entity.ErrInvalidOrderQuantityBrazil,
 		},
 		{
@@ -193,6 +205,7 @@ func TestOrderVerification(t *testing.T) {
 			quantity:      -20.35,
 			price:         10.92,
 			currency:      "BRL",
+			date:          "2021-10-01",
 			expectedError: entity.ErrInvalidUsaCurrency,
 		},
 		{
@@ -201,14 +214,52 @@ func TestOrderVerification(t *testing.T) {
 			quantity:      -20,
 			price:         10.92,
 			currency:      "USD",
+			date:          "2021-10-01",
 			expectedError: entity.ErrInvalidBrazilCurrency,
 		},
+		{
+			orderType:     "buy",
+			country:       "CRYPTO",
+			quantity:      0.00012345,
+			price:         310250.18,
+			currency:      "BRL",
+			date:          "2021-10-01",
+			expectedError: nil,
+		},
+		{
+			orderType:     "sell",
+			country:       "CRYPTO",
+			quantity:      -1.5,
+			price:         61250.32,
+			currency:      "USD",
+			date:          "2021-10-01",
+			expectedError: nil,
+		},
+		{
+			orderType:     "buy",
+			country:       "CRYPTO",
+			quantity:      0.123456789,
+			price:         61250.32,
+			currency:      "USD",
+			date:          "2021-10-01",
+			expectedError: entity.ErrInvalidOrderQuantityCrypto,
+		},
+		{
+			orderType:     "buy",
+			country:       "CRYPTO",
+			quantity:      0.5,
+			price:         61250.32,
+			currency:      "EUR",
+			date:          "2021-10-01",
+			expectedError: entity.ErrInvalidCryptoCurrency,
+		},
 		{
 			orderType:     "buy",
 			country:       "BR",
 			quantity:      -1,
 			price:         10.92,
 			currency:      "BRL",
+			date:          "2021-10-01",
 			expectedError: entity.ErrInvalidOrderBuyQuantity,
 		},
 		{
@@ -217,6 +268,7 @@ func TestOrderVerification(t *testing.T) {
 			quantity:      1,
 			price:         10.92,
 			currency:      "BRL",
+			date:          "2021-10-01",
 			expectedError: entity.ErrInvalidOrderSellQuantity,
 		},
 		{
@@ -225,8 +277,45 @@ func TestOrderVerification(t *testing.T) {
 			quantity:      -1,
 			price:         -10.92,
 			currency:      "BRL",
+			date:          "2021-10-01",
 			expectedError: entity.ErrInvalidOrderPrice,
 		},
+		{
+			orderType:     "buy",
+			country:       "BR",
+			quantity:      20,
+			price:         10.92,
+			currency:      "BRL",
+			date:          "01/10/2021",
+			expectedError: entity.ErrInvalidOrderDate,
+		},
+		{
+			orderType:     "buy",
+			country:       "BR",
+			quantity:      20,
+			price:         10.92,
+			currency:      "BRL",
+			date:          "2021-10-02",
+			expectedError: entity.ErrInvalidOrderDateNoSession,
+		},
+		{
+			orderType:     "buy",
+			country:       "US",
+			quantity:      2,
+			price:         150.2,
+			currency:      "USD",
+			date:          "2021-10-03",
+			expectedError: entity.ErrInvalidOrderDateNoSession,
+		},
+		{
+			orderType:     "buy",
+			country:       "CRYPTO",
+			quantity:      0.5,
+			price:         61250.32,
+			currency:      "USD",
+			date:          "2021-10-02",
+			expectedError: nil,
+		},
 	}
 
 	mocked := NewMockRepo()
@@ -234,7 +323,7 @@ func TestOrderVerification(t *testing.T) {
 
 	for _, testCase := range tests {
 		err := app.OrderVerification(testCase.orderType, testCase.country,
-			testCase.quantity, testCase.price, testCase.currency)
+			testCase.quantity, testCase.price, testCase.currency, testCase.date)
 		assert.Equal(t, testCase.expectedError, err)
 
 	}
@@ -327,3 +416,181 @@ func TestSearchOrdersSearchFromAssetUserByDate(t *testing.T) {
 		assert.Equal(t, testCase.expectedError, err)
 	}
 }
+
+func TestReadOrderImport(t *testing.T) {
+	type test struct {
+		file          string
+		mapping       map[string]string
+		delimiter     string
+		expectedRows  []entity.OrderImportRow
+		expectedError error
+	}
+
+	tests := []test{
+		{
+			file: "symbol,country,type,quantity,price,currency,brokerage,date\n" +
+				"ITUB4,BR,buy,10,25.50,BRL,Clear,2021-10-05\n" +
+				"\n" +
+				"AAPL,US,sell,-2,abc,USD,Avenue,2021-10-05\n",
+			expectedRows: []entity.OrderImportRow{
+				{
+					Line:      2,
+					Symbol:    "ITUB4",
+					Country:   "BR",
+					OrderType: "buy",
+					Quantity:  10,
+					Price:     25.50,
+					Currency:  "BRL",
+					Brokerage: "Clear",
+					Date:      "2021-10-05",
+				},
+				{
+					Line:      4,
+					Symbol:    "AAPL",
+					Country:   "US",
+					OrderType: "sell",
+					Quantity:  -2,
+					Currency:  "USD",
+					Brokerage: "Avenue",
+					Date:      "2021-10-05",
+					Errors:    []string{entity.ErrInvalidOrderImportNumber.Error()},
+				},
+			},
+		},
+		{
+			file: "Ativo;Pais;Tipo;Qtd;Preco;Moeda;Corretora;Data;Taxas\n" +
+				"ITUB4;BR;buy;10;25,50;BRL;Clear;05/10/2021;1,20\n",
+			mapping: map[string]string{
+				"symbol":    "Ativo",
+				"country":   "Pais",
+				"type":      "Tipo",
+				"quantity":  "Qtd",
+				"price":     "Preco",
+				"currency":  "Moeda",
+				"brokerage": "Corretora",
+				"date":      "Data",
+				"fees":      "Taxas",
+			},
+			delimiter: ";",
+			expectedRows: []entity.OrderImportRow{
+				{
+					Line:      2,
+					Symbol:    "ITUB4",
+					Country:   "BR",
+					OrderType: "buy",
+					Quantity:  10,
+					Price:     25.50,
+					Currency:  "BRL",
+					Brokerage: "Clear",
+					Date:      "2021-10-05",
+					Fees:      1.20,
+				},
+			},
+		},
+		{
+			file:          "symbol,country\nITUB4,BR\n",
+			expectedError: entity.ErrInvalidOrderImportColumn,
+		},
+		{
+			file:          "symbol,country,type,quantity,price,currency,brokerage,date\n",
+			expectedError: entity.ErrInvalidOrderImportEmpty,
+		},
+		{
+			file:          "symbol,country\n",
+			delimiter:     ";;",
+			expectedError: entity.ErrInvalidOrderImportFile,
+		},
+	}
+
+	app := NewApplication(NewMockRepo())
+
+	for _, testCase := range tests {
+		rows, err := app.ReadOrderImport(strings.NewReader(testCase.file),
+			testCase.mapping, testCase.delimiter)
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expectedRows, rows)
+	}
+}
+
+func TestReadTradeNotes(t *testing.T) {
+	app := NewApplication(NewMockRepo())
+
+	notes, err := app.ReadTradeNotes("  \n ")
+	assert.Nil(t, notes)
+	assert.Equal(t, entity.ErrInvalidTradeNoteBlank, err)
+
+	notes, err = app.ReadTradeNotes("This is not a trade note")
+	assert.Nil(t, notes)
+	assert.Equal(t, entity.ErrInvalidTradeNote, err)
+}
+
+func TestReadB3Report(t *testing.T) {
+	app := NewApplication(NewMockRepo())
+
+	report, err := app.ReadB3Report(nil)
+	assert.Nil(t, report)
+	assert.Equal(t, entity.ErrInvalidOrderImportFile, err)
+
+	report, err = app.ReadB3Report([]byte("Instituição;Código de Negociação;" +
+		"Quantidade\nCLEAR CORRETORA - GRUPO XP;ITUB4;100\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, &entity.B3Report{
+		Type: entity.B3PositionReport,
+		Positions: []entity.B3Position{
+			{Line: 2, Symbol: "ITUB4", Brokerage: "CLEAR CORRETORA - GRUPO XP",
+				Quantity: 100},
+		},
+	}, report)
+}
+
+func TestReadBrokerStatement(t *testing.T) {
+	app := NewApplication(NewMockRepo())
+
+	statement, err := app.ReadBrokerStatement(nil)
+	assert.Nil(t, statement)
+	assert.Equal(t, entity.ErrInvalidOrderImportFile, err)
+
+	statement, err = app.ReadBrokerStatement([]byte("Date,Activity Type," +
+		"Symbol,Side,Quantity,Price,Net Amount\n" +
+		"2021-10-05,FILL,AAPL,BUY,0.25,141.20,-35.30\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, &entity.BrokerStatement{
+		Format:    entity.PassfolioStatement,
+		Brokerage: "Passfolio",
+		Orders: []entity.OrderImportRow{
+			{Line: 2, Symbol: "AAPL", Country: "US", OrderType: "buy",
+				Quantity: 0.25, Price: 141.20, Currency: "USD",
+				Date: "2021-10-05"},
+		},
+	}, statement)
+}
+
+func TestCreateImport(t *testing.T) {
+	orderApp := NewApplication(NewMockRepo())
+
+	assets := []entity.Asset{{Symbol: "MSFT"}}
+	orders := []entity.Order{
+		{Quantity: 2, Price: 150, Currency: "USD", OrderType: "buy",
+			Asset: &entity.Asset{Id: "TestAssetID"}},
+		{Quantity: 1, Price: 290, Currency: "USD", OrderType: "buy",
+			Asset: &entity.Asset{Symbol: "MSFT"}},
+	}
+	earnings := []entity.Earnings{
+		{Type: "Dividendos", Earning: 0.44, Currency: "USD",
+			Asset: &entity.Asset{Id: "TestAssetID"}},
+	}
+
+	ordersCreated, earningsCreated, err := orderApp.CreateImport(assets, nil,
+		orders, earnings)
+	assert.Nil(t, err)
+	assert.Equal(t, "OrderIDTestAssetID", ordersCreated[0].Id)
+	assert.Equal(t, "OrderIDNewAssetIDMSFT", ordersCreated[1].Id)
+	assert.Equal(t, "EarningIDTestAssetID", earningsCreated[0].Id)
+
+	earnings[0].Asset = &entity.Asset{Id: "ERROR_ASSET"}
+	ordersCreated, earningsCreated, err = orderApp.CreateImport(assets, nil,
+		orders, earnings)
+	assert.Nil(t, ordersCreated)
+	assert.Nil(t, earningsCreated)
+	assert.NotNil(t, err)
+}
@@ -1,6 +1,9 @@
 package order
 
-import "stockfyApi/entity"
+import (
+	"io"
+	"stockfyApi/entity"
+)
 
 type Repository interface {
 	Create(orderInsert entity.Order) entity.Order
@@ -10,14 +13,19 @@ type Repository interface {
 		error)
 	DeleteFromAssetUser(assetId string, userUid string) ([]entity.Order, error)
 	SearchFromAssetUser(assetId string, userUid string) ([]entity.Order, error)
+	SearchFromUser(userUid string) ([]entity.Order, error)
 	SearchFromAssetUserOrderByDate(assetId string, userUid string,
 		orderBy string, limit int, offset int) ([]entity.Order, error)
 	UpdateFromUser(orderUpdate entity.Order) []entity.Order
+	CreateBulk(orders []entity.Order) ([]entity.Order, error)
+	CreateImport(assets []entity.Asset, accounts []entity.BrokerageAccount,
+		orders []entity.Order, earnings []entity.Earnings) ([]entity.Order,
+		[]entity.Earnings, error)
 }
 
 type UseCases interface {
 	CreateOrder(quantity float64, price float64, currency string,
-		orderType string, date string, brokerageId string, assetId string,
+		orderType string, date string, accountId string, assetId string,
 		userUid string) (*entity.Order, error)
 	DeleteOrdersFromAsset(assetId string) ([]entity.Order, error)
 	DeleteOrdersFromAssetUser(assetId string, userUid string) (*[]entity.Order,
@@ -29,9 +37,19 @@ type UseCases interface {
 		error)
 	SearchOrdersFromAssetUser(assetId string, userUid string) ([]entity.Order,
 		error)
+	SearchOrdersFromUser(userUid string) ([]entity.Order, error)
 	UpdateOrder(orderId string, userUid string, price float64, quantity float64,
-		orderType, date string, brokerageId string, currency string) (
+		orderType, date string, accountId string, currency string) (
 		*entity.Order, error)
 	OrderVerification(orderType string, country string, quantity float64,
-		price float64, currency string) error
+		price float64, currency string, date string) error
+	ReadOrderImport(file io.Reader, mapping map[string]string,
+		delimiter string) ([]entity.OrderImportRow, error)
+	ReadTradeNotes(text string) ([]entity.TradeNote, error)
+	ReadB3Report(file []byte) (*entity.B3Report, error)
+	ReadBrokerStatement(file []byte) (*entity.BrokerStatement, error)
+	CreateOrders(orders []entity.Order) ([]entity.Order, error)
+	CreateImport(assets []entity.Asset, accounts []entity.BrokerageAccount,
+		orders []entity.Order, earnings []entity.Earnings) ([]entity.Order,
+		[]entity.Earnings, error)
 }
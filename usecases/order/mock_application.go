@@ -2,6 +2,9 @@ package order
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"stockfyApi/calendar"
 	"stockfyApi/entity"
 	"strings"
 )
@@ -14,12 +17,12 @@ func NewMockApplication() *MockApplication {
 }
 
 func (a *MockApplication) CreateOrder(quantity float64, price float64,
-	currency string, orderType string, date string, brokerageId string,
+	currency string, orderType string, date string, accountId string,
 	assetId string, userUid string) (*entity.Order, error) {
 
 	dateFormatted := entity.StringToTime(date)
 	orderFormatted, err := entity.NewOrder(quantity, price, currency, orderType,
-		dateFormatted, brokerageId, assetId, userUid)
+		dateFormatted, accountId, assetId, userUid)
 	if err != nil {
 		return nil, err
 	}
@@ -138,6 +141,38 @@ func (a *MockApplication) SearchOrdersFromAssetUser(assetId string, userUid stri
 	}, nil
 }
 
+func (a *MockApplication) SearchOrdersFromUser(userUid string) (
+	[]entity.Order, error) {
+
+	if userUid == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown orders repository error")
+	}
+
+	return []entity.Order{
+		{
+			Id:        "TestOrderID",
+			Quantity:  20,
+			Price:     29.29,
+			Currency:  "BRL",
+			OrderType: "buy",
+			Date:      entity.StringToTime("2021-10-01"),
+			Brokerage: &entity.Brokerage{
+				Id:      "TestBrokerageID",
+				Name:    "Test Brokerage",
+				Country: "BR",
+			},
+			Account: &entity.BrokerageAccount{
+				Id:       "TestAccountID",
+				Nickname: "Test Brokerage",
+			},
+			Asset: &entity.Asset{
+				Id:     "TestID",
+				Symbol: "TEST3",
+			},
+		},
+	}, nil
+}
+
 func (a *MockApplication) SearchOrdersSearchFromAssetUserByDate(assetId string,
 	userUid string, orderBy string, limit int, offset int) ([]entity.Order,
 	error) {
@@ -183,13 +218,13 @@ func (a *MockApplication) SearchOrdersSearchFromAssetUserByDate(assetId string,
 }
 
 func (a *MockApplication) UpdateOrder(orderId string, userUid string, price float64,
-	quantity float64, orderType, date string, brokerageId string,
+	quantity float64, orderType, date string, accountId string,
 	currency string) (*entity.Order, error) {
 
 	dateFormatted := entity.StringToTime(date)
 
 	orderFormatted, err := entity.NewOrder(quantity, price, currency,
-		orderType, dateFormatted, brokerageId, "", userUid)
+		orderType, dateFormatted, accountId, "", userUid)
 	if err != nil {
 		return nil, err
 	}
@@ -211,28 +246,23 @@ func (a *MockApplication) UpdateOrder(orderId string, userUid string, price floa
 }
 
 func (a *MockApplication) OrderVerification(orderType string, country string,
-	quantity float64, price float64, currency string) error {
+	quantity float64, price float64, currency string, date string) error {
 
 	if orderType != "sell" && orderType != "buy" {
 		return entity.ErrInvalidOrderType
 	}
 
-	if country != "BR" && country != "US" {
-		return entity.ErrInvalidCountryCode
-	}
-
-	if country == "BR" && (orderType == "sell" || orderType == "buy") {
-		if !entity.IsIntegral(quantity) {
-			return entity.ErrInvalidOrderQuantityBrazil
-		}
+	market, err := entity.SearchMarket(country)
+	if err != nil {
+		return err
 	}
 
-	if country == "BR" && currency != "BRL" {
-		return entity.ErrInvalidBrazilCurrency
+	if err := market.ValidateQuantity(quantity); err != nil {
+		return err
 	}
 
-	if country == "US" && currency != "USD" {
-		return entity.ErrInvalidUsaCurrency
+	if err := market.ValidateCurrency(currency); err != nil {
+		return err
 	}
 
 	if orderType == "buy" && quantity < 0 {
@@ -243,5 +273,74 @@ func (a *MockApplication) OrderVerification(orderType string, country string,
 		return entity.ErrInvalidOrderPrice
 	}
 
+	orderDate, err := calendar.ParseDate(date)
+	if err != nil {
+		return entity.ErrInvalidOrderDate
+	}
+
+	// Markets without exchange calendar, like cryptocurrencies, trade every day
+	exchange, err := calendar.SearchExchangeByCountry(country)
+	if err == nil && !exchange.IsTradingDay(orderDate) {
+		return entity.ErrInvalidOrderDateNoSession
+	}
+
 	return nil
 }
+
+func (a *MockApplication) ReadOrderImport(file io.Reader,
+	mapping map[string]string, delimiter string) ([]entity.OrderImportRow,
+	error) {
+
+	return NewApplication(NewMockRepo()).ReadOrderImport(file, mapping,
+		delimiter)
+}
+
+func (a *MockApplication) ReadTradeNotes(text string) ([]entity.TradeNote,
+	error) {
+
+	return NewApplication(NewMockRepo()).ReadTradeNotes(text)
+}
+
+func (a *MockApplication) ReadB3Report(file []byte) (*entity.B3Report,
+	error) {
+
+	return NewApplication(NewMockRepo()).ReadB3Report(file)
+}
+
+func (a *MockApplication) ReadBrokerStatement(file []byte) (
+	*entity.BrokerStatement, error) {
+
+	return NewApplication(NewMockRepo()).ReadBrokerStatement(file)
+}
+
+func (a *MockApplication) CreateOrders(orders []entity.Order) ([]entity.Order,
+	error) {
+
+	var ordersCreated []entity.Order
+	for i, order := range orders {
+		order.Id = fmt.Sprintf("TestOrderID%d", i+1)
+		order.Brokerage = &entity.Brokerage{
+			Id:      "TestBrokerageID",
+			Name:    "Test BR 1",
+			Country: "BR",
+		}
+		ordersCreated = append(ordersCreated, order)
+	}
+
+	return ordersCreated, nil
+}
+
+func (a *MockApplication) CreateImport(assets []entity.Asset,
+	accounts []entity.BrokerageAccount, orders []entity.Order,
+	earnings []entity.Earnings) ([]entity.Order, []entity.Earnings, error) {
+
+	ordersCreated, _ := a.CreateOrders(orders)
+
+	var earningsCreated []entity.Earnings
+	for i, earning := range earnings {
+		earning.Id = fmt.Sprintf("TestEarningID%d", i+1)
+		earningsCreated = append(earningsCreated, earning)
+	}
+
+	return ordersCreated, earningsCreated, nil
+}
@@ -31,6 +31,10 @@ func (m *MockDb) Create(orderInsert entity.Order) entity.Order {
 		OrderType: "buy",
 		Date:      dateFormatted,
 		Brokerage: &brokerage,
+		Account: &entity.BrokerageAccount{
+			Id:       orderInsert.Account.Id,
+			Nickname: "Test Broker",
+		},
 		Asset: &entity.Asset{
 			Id: "AssetID",
 		},
@@ -59,6 +63,10 @@ func (m *MockDb) SearchFromAssetUser(assetId string, userUid string) (
 	return []entity.Order{}, nil
 }
 
+func (m *MockDb) SearchFromUser(userUid string) ([]entity.Order, error) {
+	return []entity.Order{}, nil
+}
+
 func (m *MockDb) SearchFromAssetUserOrderByDate(assetId string,
 	userUid string, orderBy string, limit int, offset int) ([]entity.Order,
 	error) {
@@ -134,3 +142,53 @@ func (m *MockDb) SearchByOrderAndUserId(orderId string, userUid string) (
 func (m *MockDb) UpdateFromUser(orderUpdate entity.Order) []entity.Order {
 	return []entity.Order{}
 }
+
+func (m *MockDb) CreateBulk(orders []entity.Order) ([]entity.Order, error) {
+	var ordersCreated []entity.Order
+
+	for _, order := range orders {
+		if order.Asset.Id == "ERROR_ASSET" {
+			return nil, errors.New("Unknown error in the order repository")
+		}
+
+		order.Id = "OrderID" + order.Asset.Id
+		ordersCreated = append(ordersCreated, order)
+	}
+
+	return ordersCreated, nil
+}
+
+func (m *MockDb) CreateImport(assets []entity.Asset,
+	accounts []entity.BrokerageAccount, orders []entity.Order,
+	earnings []entity.Earnings) ([]entity.Order, []entity.Earnings, error) {
+
+	var ordersInsert []entity.Order
+	for _, order := range orders {
+		if order.Asset.Id == "" {
+			order.Asset = &entity.Asset{Id: "NewAssetID" + order.Asset.Symbol}
+		}
+
+		ordersInsert = append(ordersInsert, order)
+	}
+
+	ordersCreated, err := m.CreateBulk(ordersInsert)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var earningsCreated []entity.Earnings
+	for _, earning := range earnings {
+		if earning.Asset.Id == "" {
+			earning.Asset = &entity.Asset{Id: "NewAssetID" + earning.Asset.Symbol}
+		}
+
+		if earning.Asset.Id == "ERROR_ASSET" {
+			return nil, nil, errors.New("Unknown error in the order repository")
+		}
+
+		earning.Id = "EarningID" + earning.Asset.Id
+		earningsCreated = append(earningsCreated, earning)
+	}
+
+	return ordersCreated, earningsCreated, nil
+}
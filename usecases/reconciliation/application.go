@@ -0,0 +1,33 @@
+package reconciliation
+
+import "stockfyApi/entity"
+
+type Application struct {
+	repo Repository
+}
+
+//NewApplication create new use case
+func NewApplication(r Repository) *Application {
+	return &Application{
+		repo: r,
+	}
+}
+
+// ReconcileCustody compares the custody snapshot of a brokerage with the
+// positions computed from the orders of the user in that brokerage until the
+// date of the snapshot. Every asset is returned, also the ones that match.
+func (a *Application) ReconcileCustody(snapshot entity.CustodySnapshot,
+	userUid string) ([]entity.CustodyReconciliation, error) {
+
+	if err := snapshot.Validate(); err != nil {
+		return nil, err
+	}
+
+	positions, err := a.repo.SearchPositionsAtDate(snapshot.Brokerage.Id,
+		snapshot.Date, userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.ReconcileCustody(snapshot, positions), nil
+}
@@ -0,0 +1,46 @@
+package reconciliation
+
+import (
+	"errors"
+	"stockfyApi/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcileCustody(t *testing.T) {
+	reconciliationApp := NewApplication(NewMockRepo())
+
+	date := entity.StringToTime("2021-12-30")
+	snapshot := entity.CustodySnapshot{
+		Brokerage: &entity.Brokerage{Id: "TestBrokerageID", Name: "Clear"},
+		Date:      date,
+		Positions: []entity.CustodyPosition{
+			{Symbol: "ITUB4", Quantity: 20},
+			{Symbol: "BBDC4", Quantity: 20},
+		},
+	}
+
+	reconciliation, err := reconciliationApp.ReconcileCustody(snapshot,
+		"TestUserUID")
+	assert.Nil(t, err)
+	assert.Equal(t, []entity.CustodyReconciliation{
+		{Symbol: "BBDC4", Brokerage: "Clear", CustodyQuantity: 20,
+			OrdersQuantity: 10, Difference: 10,
+			Status: entity.CustodyQuantityMismatch, Fix: &entity.CustodyFix{
+				Action: entity.CustodyFixSplit, Ratio: 2, Date: date}},
+		{Symbol: "ITUB4", Brokerage: "Clear", CustodyQuantity: 20,
+			OrdersQuantity: 20, Status: entity.CustodyMatch},
+	}, reconciliation)
+
+	reconciliation, err = reconciliationApp.ReconcileCustody(snapshot,
+		"ERROR_REPOSITORY")
+	assert.Nil(t, reconciliation)
+	assert.Equal(t, errors.New("Unknown reconciliation repository error"), err)
+
+	snapshot.Brokerage = nil
+	reconciliation, err = reconciliationApp.ReconcileCustody(snapshot,
+		"TestUserUID")
+	assert.Nil(t, reconciliation)
+	assert.Equal(t, entity.ErrInvalidCustodyBrokerage, err)
+}
@@ -0,0 +1,16 @@
+package reconciliation
+
+import (
+	"stockfyApi/entity"
+	"time"
+)
+
+type Repository interface {
+	SearchPositionsAtDate(brokerageId string, date time.Time,
+		userUid string) ([]entity.AccountPosition, error)
+}
+
+type UseCases interface {
+	ReconcileCustody(snapshot entity.CustodySnapshot, userUid string) (
+		[]entity.CustodyReconciliation, error)
+}
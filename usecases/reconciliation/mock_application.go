@@ -0,0 +1,27 @@
+package reconciliation
+
+import "stockfyApi/entity"
+
+type MockApplication struct {
+	repo MockDb
+}
+
+func NewMockApplication() *MockApplication {
+	return &MockApplication{}
+}
+
+func (a *MockApplication) ReconcileCustody(snapshot entity.CustodySnapshot,
+	userUid string) ([]entity.CustodyReconciliation, error) {
+
+	if err := snapshot.Validate(); err != nil {
+		return nil, err
+	}
+
+	positions, err := a.repo.SearchPositionsAtDate(snapshot.Brokerage.Id,
+		snapshot.Date, userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.ReconcileCustody(snapshot, positions), nil
+}
@@ -0,0 +1,39 @@
+package reconciliation
+
+import (
+	"errors"
+	"stockfyApi/entity"
+	"time"
+)
+
+type MockDb struct {
+}
+
+func NewMockRepo() *MockDb {
+	return &MockDb{}
+}
+
+func (m *MockDb) SearchPositionsAtDate(brokerageId string, date time.Time,
+	userUid string) ([]entity.AccountPosition, error) {
+
+	if userUid == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown reconciliation repository error")
+	}
+
+	return []entity.AccountPosition{
+		{
+			AccountId:   "TestAccountID",
+			BrokerageId: brokerageId,
+			AssetId:     "TestAssetID1",
+			Symbol:      "ITUB4",
+			Quantity:    20,
+		},
+		{
+			AccountId:   "TestAccountID",
+			BrokerageId: brokerageId,
+			AssetId:     "TestAssetID2",
+			Symbol:      "BBDC4",
+			Quantity:    10,
+		},
+	}, nil
+}
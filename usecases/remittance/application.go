@@ -0,0 +1,77 @@
+package remittance
+
+import (
+	"stockfyApi/entity"
+	"time"
+)
+
+type Application struct {
+	repo Repository
+}
+
+//NewApplication create new use case
+func NewApplication(r Repository) *Application {
+	return &Application{
+		repo: r,
+	}
+}
+
+func (a *Application) CreateRemittance(sentAmount float64,
+	sentCurrency string, receivedAmount float64, receivedCurrency string,
+	iof float64, fees float64, commercialRate float64, date time.Time,
+	fromAccountId string, toAccountId string, userUid string) (
+	*entity.Remittance, error) {
+
+	remittance, err := entity.NewRemittance(sentAmount, sentCurrency,
+		receivedAmount, receivedCurrency, iof, fees, commercialRate, date,
+		fromAccountId, toAccountId, userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	remittanceCreated, err := a.repo.Create(*remittance)
+	if err != nil {
+		return nil, err
+	}
+
+	remittanceCreated[0].FromAccount = remittance.FromAccount
+	remittanceCreated[0].ToAccount = remittance.ToAccount
+
+	return &remittanceCreated[0], nil
+}
+
+// SearchRemittances returns the remittances of the user from the oldest to
+// the newest.
+func (a *Application) SearchRemittances(userUid string) (
+	[]entity.Remittance, error) {
+
+	return a.repo.SearchFromUser(userUid)
+}
+
+func (a *Application) DeleteRemittance(remittanceId string,
+	userUid string) (*entity.Remittance, error) {
+
+	remittanceDeleted, err := a.repo.Delete(remittanceId, userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	if remittanceDeleted == nil {
+		return nil, entity.ErrInvalidRemittance
+	}
+
+	return &remittanceDeleted[0], nil
+}
+
+// SearchBrlCostBasis converts to BRL the cost of the positions of the orders
+// bought in foreign currencies, using the remittances of the user.
+func (a *Application) SearchBrlCostBasis(orders []entity.Order,
+	userUid string) ([]entity.BrlCostBasis, error) {
+
+	remittances, err := a.repo.SearchFromUser(userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewBrlCostBasis(orders, remittances), nil
+}
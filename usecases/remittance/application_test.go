@@ -0,0 +1,77 @@
+package remittance
+
+import (
+	"errors"
+	"stockfyApi/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateRemittance(t *testing.T) {
+	remittanceApp := NewApplication(NewMockRepo())
+	date := entity.StringToTime("2021-10-01")
+
+	remittance, err := remittanceApp.CreateRemittance(5066, "BRL", 1000, "USD",
+		55, 11, 4.9, date, "", "TestAccountID2", "TestUserUID")
+	assert.Nil(t, err)
+	assert.Equal(t, &entity.Remittance{
+		Id:               "TestRemittanceID",
+		SentAmount:       5066,
+		SentCurrency:     "BRL",
+		ReceivedAmount:   1000,
+		ReceivedCurrency: "USD",
+		Iof:              55,
+		Fees:             11,
+		CommercialRate:   4.9,
+		Date:             date,
+		ToAccount:        &entity.BrokerageAccount{Id: "TestAccountID2"},
+		UserUid:          "TestUserUID",
+	}, remittance)
+
+	remittance, err = remittanceApp.CreateRemittance(5066, "BRL", 1000, "BRL",
+		0, 0, 0, date, "", "TestAccountID2", "TestUserUID")
+	assert.Nil(t, remittance)
+	assert.Equal(t, entity.ErrInvalidRemittanceCurrency, err)
+
+	remittance, err = remittanceApp.CreateRemittance(5066, "BRL", 1000, "USD",
+		0, 0, 0, date, "", "TestAccountID2", "ERROR_REPOSITORY")
+	assert.Nil(t, remittance)
+	assert.Equal(t, errors.New("Unknown remittance repository error"), err)
+}
+
+func TestDeleteRemittance(t *testing.T) {
+	remittanceApp := NewApplication(NewMockRepo())
+
+	remittance, err := remittanceApp.DeleteRemittance("TestRemittanceID",
+		"TestUserUID")
+	assert.Nil(t, err)
+	assert.Equal(t, "TestRemittanceID", remittance.Id)
+
+	remittance, err = remittanceApp.DeleteRemittance("UNKNOWN_ID",
+		"TestUserUID")
+	assert.Nil(t, remittance)
+	assert.Equal(t, entity.ErrInvalidRemittance, err)
+}
+
+func TestSearchBrlCostBasis(t *testing.T) {
+	remittanceApp := NewApplication(NewMockRepo())
+
+	orders := []entity.Order{
+		{Quantity: 10, Price: 20, Fees: 1, Currency: "USD", OrderType: "buy",
+			Date:  entity.StringToTime("2021-10-05"),
+			Asset: &entity.Asset{Symbol: "AAPL"}},
+	}
+
+	costBasis, err := remittanceApp.SearchBrlCostBasis(orders, "TestUserUID")
+	assert.Nil(t, err)
+	assert.Equal(t, []entity.BrlCostBasis{
+		{Symbol: "AAPL", Currency: "USD", Quantity: 10, Invested: 201,
+			BrlInvested: 1018.266, FxRate: 5.066},
+	}, costBasis)
+
+	costBasis, err = remittanceApp.SearchBrlCostBasis(orders,
+		"ERROR_REPOSITORY")
+	assert.Nil(t, costBasis)
+	assert.Equal(t, errors.New("Unknown remittance repository error"), err)
+}
@@ -0,0 +1,25 @@
+package remittance
+
+import (
+	"stockfyApi/entity"
+	"time"
+)
+
+type Repository interface {
+	Create(remittance entity.Remittance) ([]entity.Remittance, error)
+	SearchFromUser(userUid string) ([]entity.Remittance, error)
+	Delete(remittanceId string, userUid string) ([]entity.Remittance, error)
+}
+
+type UseCases interface {
+	CreateRemittance(sentAmount float64, sentCurrency string,
+		receivedAmount float64, receivedCurrency string, iof float64,
+		fees float64, commercialRate float64, date time.Time,
+		fromAccountId string, toAccountId string, userUid string) (
+		*entity.Remittance, error)
+	SearchRemittances(userUid string) ([]entity.Remittance, error)
+	DeleteRemittance(remittanceId string, userUid string) (*entity.Remittance,
+		error)
+	SearchBrlCostBasis(orders []entity.Order, userUid string) (
+		[]entity.BrlCostBasis, error)
+}
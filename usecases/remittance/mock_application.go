@@ -0,0 +1,70 @@
+package remittance
+
+import (
+	"stockfyApi/entity"
+	"time"
+)
+
+type MockApplication struct {
+	repo MockDb
+}
+
+func NewMockApplication() *MockApplication {
+	return &MockApplication{}
+}
+
+func (a *MockApplication) CreateRemittance(sentAmount float64,
+	sentCurrency string, receivedAmount float64, receivedCurrency string,
+	iof float64, fees float64, commercialRate float64, date time.Time,
+	fromAccountId string, toAccountId string, userUid string) (
+	*entity.Remittance, error) {
+
+	remittance, err := entity.NewRemittance(sentAmount, sentCurrency,
+		receivedAmount, receivedCurrency, iof, fees, commercialRate, date,
+		fromAccountId, toAccountId, userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	remittanceCreated, err := a.repo.Create(*remittance)
+	if err != nil {
+		return nil, err
+	}
+
+	remittanceCreated[0].FromAccount = remittance.FromAccount
+	remittanceCreated[0].ToAccount = remittance.ToAccount
+
+	return &remittanceCreated[0], nil
+}
+
+func (a *MockApplication) SearchRemittances(userUid string) (
+	[]entity.Remittance, error) {
+
+	return a.repo.SearchFromUser(userUid)
+}
+
+func (a *MockApplication) DeleteRemittance(remittanceId string,
+	userUid string) (*entity.Remittance, error) {
+
+	remittanceDeleted, err := a.repo.Delete(remittanceId, userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	if remittanceDeleted == nil {
+		return nil, entity.ErrInvalidRemittance
+	}
+
+	return &remittanceDeleted[0], nil
+}
+
+func (a *MockApplication) SearchBrlCostBasis(orders []entity.Order,
+	userUid string) ([]entity.BrlCostBasis, error) {
+
+	remittances, err := a.repo.SearchFromUser(userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewBrlCostBasis(orders, remittances), nil
+}
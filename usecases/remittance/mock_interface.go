@@ -0,0 +1,76 @@
+package remittance
+
+import (
+	"errors"
+	"stockfyApi/entity"
+)
+
+type MockDb struct {
+}
+
+func NewMockRepo() *MockDb {
+	return &MockDb{}
+}
+
+func (m *MockDb) Create(remittance entity.Remittance) ([]entity.Remittance,
+	error) {
+
+	if remittance.UserUid == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown remittance repository error")
+	}
+
+	remittance.Id = "TestRemittanceID"
+	remittance.FromAccount = nil
+	remittance.ToAccount = nil
+
+	return []entity.Remittance{remittance}, nil
+}
+
+func (m *MockDb) SearchFromUser(userUid string) ([]entity.Remittance, error) {
+	if userUid == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown remittance repository error")
+	}
+
+	return []entity.Remittance{
+		{
+			Id:               "TestRemittanceID",
+			SentAmount:       5066,
+			SentCurrency:     "BRL",
+			ReceivedAmount:   1000,
+			ReceivedCurrency: "USD",
+			Iof:              55,
+			Fees:             11,
+			CommercialRate:   4.9,
+			Date:             entity.StringToTime("2021-10-01"),
+			ToAccount: &entity.BrokerageAccount{
+				Id:       "TestAccountID2",
+				Nickname: "Test US 1",
+			},
+			UserUid: userUid,
+		},
+	}, nil
+}
+
+func (m *MockDb) Delete(remittanceId string, userUid string) (
+	[]entity.Remittance, error) {
+
+	if userUid == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown remittance repository error")
+	}
+
+	if remittanceId != "TestRemittanceID" {
+		return nil, nil
+	}
+
+	return []entity.Remittance{
+		{
+			Id:               remittanceId,
+			SentAmount:       5066,
+			SentCurrency:     "BRL",
+			ReceivedAmount:   1000,
+			ReceivedCurrency: "USD",
+			Date:             entity.StringToTime("2021-10-01"),
+			UserUid:          userUid,
+		},
+	}, nil
+}
@@ -2,6 +2,7 @@ package sector
 
 import (
 	"stockfyApi/entity"
+	"strings"
 )
 
 // type Sector struct {
@@ -20,8 +21,31 @@ func NewApplication(r Repository) *Application {
 	}
 }
 
-func (a *Application) CreateSector(name string) ([]entity.Sector, error) {
-	return a.repo.Create(name)
+// CreateSector creates a node of the sector taxonomy. Without the level, the
+// node is created as a sector, in the first level of the taxonomy.
+func (a *Application) CreateSector(name string, level string, code string,
+	parentId string) ([]entity.Sector, error) {
+	var parent *entity.Sector
+
+	if parentId != "" {
+		parents, err := a.repo.SearchById(parentId)
+		if err != nil {
+			return nil, err
+		}
+
+		if parents == nil {
+			return nil, entity.ErrInvalidSectorParent
+		}
+
+		parent = &parents[0]
+	}
+
+	sector, err := entity.NewSector(name, level, code, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.repo.Create(*sector)
 }
 
 func (a *Application) SearchSectorByName(name string) (*entity.Sector, error) {
@@ -42,3 +66,185 @@ func (a *Application) SearchSectorByName(name string) (*entity.Sector, error) {
 	return &sectorInfo[0], nil
 
 }
+
+func (a *Application) SearchSectorById(sectorId string) (*entity.Sector,
+	error) {
+
+	sectorInfo, err := a.repo.SearchById(sectorId)
+	if err != nil {
+		return nil, err
+	}
+
+	if sectorInfo == nil {
+		return nil, entity.ErrInvalidSector
+	}
+
+	return &sectorInfo[0], nil
+}
+
+func (a *Application) SearchSectors() ([]entity.Sector, error) {
+	return a.repo.SearchAll()
+}
+
+// RenameSector changes the name of a node of the taxonomy, keeping its level,
+// code and parent. The audit log of the admin is stored with the change.
+func (a *Application) RenameSector(sectorId string, name string,
+	auditLog *entity.AuditLog) (*entity.Sector, error) {
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, entity.ErrInvalidSectorBlank
+	}
+
+	sectorInfo, err := a.repo.Update(sectorId, name, auditLog)
+	if err != nil {
+		return nil, err
+	}
+
+	if sectorInfo == nil {
+		return nil, entity.ErrInvalidSector
+	}
+
+	return &sectorInfo[0], nil
+}
+
+// MergeSectors moves the assets, the label mappings and the children of the
+// source sector to the target sector and deletes the source sector, storing
+// the audit log of the admin with the merge.
+func (a *Application) MergeSectors(sourceId string, targetId string,
+	auditLog *entity.AuditLog) (*entity.Sector, error) {
+
+	source, err := a.SearchSectorById(sourceId)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := a.SearchSectorById(targetId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = source.ValidateMerge(target); err != nil {
+		return nil, err
+	}
+
+	mergedSector, err := a.repo.Merge(source.Id, target.Id, auditLog)
+	if err != nil {
+		return nil, err
+	}
+
+	if mergedSector == nil {
+		return nil, entity.ErrInvalidSector
+	}
+
+	return &mergedSector[0], nil
+}
+
+// ClassifySectorLabel returns the node of the taxonomy mapped to the sector
+// label of the provider. A label seen for the first time is stored as an
+// unmapped label and, like the blank labels, it is classified as unclassified.
+func (a *Application) ClassifySectorLabel(provider string, label string) (
+	*entity.Sector, *entity.SectorMapping, error) {
+	var mapping *entity.SectorMapping
+
+	if !entity.IsValidSectorProvider(provider) {
+		return nil, nil, entity.ErrInvalidSectorProvider
+	}
+
+	if label != "" {
+		mappings, err := a.repo.CreateMapping(provider, label)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		mapping = &mappings[0]
+		if mapping.Sector != nil {
+			return mapping.Sector, mapping, nil
+		}
+	}
+
+	unclassified, err := a.repo.Create(entity.Sector{
+		Name:  entity.UnclassifiedSectorName,
+		Level: entity.SectorLevelSector,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &unclassified[0], mapping, nil
+}
+
+// AssignSectorMapping stores the mapping used to classify the asset, so the
+// asset is reclassified when the mapping is updated.
+func (a *Application) AssignSectorMapping(assetId string,
+	mapping *entity.SectorMapping) error {
+
+	if mapping == nil {
+		return nil
+	}
+
+	_, err := a.repo.UpdateAssetMapping(assetId, mapping.Id)
+	return err
+}
+
+func (a *Application) SearchSectorMappings(provider string,
+	unmappedOnly bool) ([]entity.SectorMapping, error) {
+
+	if provider != "" && !entity.IsValidSectorProvider(provider) {
+		return nil, entity.ErrInvalidSectorProvider
+	}
+
+	return a.repo.SearchMappings(provider, unmappedOnly)
+}
+
+// UpdateSectorMapping maps the label to another node of the taxonomy and
+// reclassifies the assets classified by this label.
+func (a *Application) UpdateSectorMapping(mappingId string, sectorId string) (
+	*entity.SectorMapping, error) {
+
+	sectors, err := a.repo.SearchById(sectorId)
+	if err != nil {
+		return nil, err
+	}
+
+	if sectors == nil {
+		return nil, entity.ErrInvalidSector
+	}
+
+	mappings, err := a.repo.UpdateMapping(mappingId, sectorId)
+	if err != nil {
+		return nil, err
+	}
+
+	if mappings == nil {
+		return nil, entity.ErrInvalidSectorMapping
+	}
+
+	return &mappings[0], nil
+}
+
+// SectorAllocation returns the amount invested by the user in each node of
+// the level of the taxonomy.
+func (a *Application) SectorAllocation(userUid string, level string) (
+	[]entity.SectorAllocation, error) {
+
+	if level == "" {
+		level = entity.SectorLevelSector
+	}
+
+	if entity.SectorLevelDepth(level) < 0 {
+		return nil, entity.ErrInvalidSectorLevel
+	}
+
+	positions, err := a.repo.SearchPositions(userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	sectors, err := a.repo.SearchAll()
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewSectorAllocation(positions, sectors, level)
+}
@@ -8,11 +8,51 @@ import (
 )
 
 func TestCreate(t *testing.T) {
+	parentId := "TestBanksGroupID"
 
-	expectedSectorReturn := []entity.Sector{
+	type test struct {
+		name          string
+		level         string
+		parentId      string
+		expected      []entity.Sector
+		expectedError error
+	}
+
+	tests := []test{
+		{
+			name: "Finance",
+			expected: []entity.Sector{
+				{
+					Id:    "a38a9jkrh40a",
+					Name:  "Finance",
+					Level: entity.SectorLevelSector,
+				},
+			},
+		},
+		{
+			name:     "Regional Banks",
+			level:    entity.SectorLevelIndustry,
+			parentId: parentId,
+			expected: []entity.Sector{
+				{
+					Id:       "a38a9jkrh40a",
+					Name:     "Regional Banks",
+					Level:    entity.SectorLevelIndustry,
+					ParentId: &parentId,
+				},
+			},
+		},
+		{
+			name:          "Regional Banks",
+			level:         entity.SectorLevelIndustry,
+			parentId:      "UNKNOWN_ID",
+			expectedError: entity.ErrInvalidSectorParent,
+		},
 		{
-			Id:   "a38a9jkrh40a",
-			Name: "Finance",
+			name:          "Regional Banks",
+			level:         entity.SectorLevelIndustry,
+			parentId:      "TestFinancialsID",
+			expectedError: entity.ErrInvalidSectorParent,
 		},
 	}
 
@@ -20,11 +60,12 @@ func TestCreate(t *testing.T) {
 
 	sectorApp := NewApplication(mockedRepo)
 
-	sectorReturned, err := sectorApp.CreateSector("Finance")
-
-	assert.Nil(t, err)
-	assert.Equal(t, expectedSectorReturn, sectorReturned)
-
+	for _, testCase := range tests {
+		sectorReturned, err := sectorApp.CreateSector(testCase.name,
+			testCase.level, "", testCase.parentId)
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expected, sectorReturned)
+	}
 }
 
 func TestSearchSectorByName(t *testing.T) {
@@ -59,3 +100,148 @@ func TestSearchSectorByName(t *testing.T) {
 		assert.Equal(t, testCase.expectedSectorInfo, sectorInfo)
 	}
 }
+
+func TestClassifySectorLabel(t *testing.T) {
+	type test struct {
+		provider        string
+		label           string
+		expectedSector  string
+		expectedMapping bool
+		expectedError   error
+	}
+
+	tests := []test{
+		{
+			provider:        entity.SectorProviderFinnhub,
+			label:           "Banking",
+			expectedSector:  "TestBanksID",
+			expectedMapping: true,
+		},
+		{
+			provider:        entity.SectorProviderFinnhub,
+			label:           "Fintech",
+			expectedSector:  "a38a9jkrh40a",
+			expectedMapping: true,
+		},
+		{
+			provider:        entity.SectorProviderFinnhub,
+			label:           "",
+			expectedSector:  "a38a9jkrh40a",
+			expectedMapping: false,
+		},
+		{
+			provider:      "BLOOMBERG",
+			label:         "Banking",
+			expectedError: entity.ErrInvalidSectorProvider,
+		},
+	}
+
+	sectorApp := NewApplication(NewMockRepo())
+
+	for _, testCase := range tests {
+		sector, mapping, err := sectorApp.ClassifySectorLabel(
+			testCase.provider, testCase.label)
+		assert.Equal(t, testCase.expectedError, err)
+		if err != nil {
+			continue
+		}
+
+		assert.Equal(t, testCase.expectedSector, sector.Id)
+		assert.Equal(t, testCase.expectedMapping, mapping != nil)
+	}
+}
+
+func TestUpdateSectorMapping(t *testing.T) {
+	sectorApp := NewApplication(NewMockRepo())
+
+	mapping, err := sectorApp.UpdateSectorMapping("TestUnmappedID",
+		"TestBanksID")
+	assert.Nil(t, err)
+	assert.Equal(t, "TestBanksID", mapping.Sector.Id)
+
+	_, err = sectorApp.UpdateSectorMapping("TestUnmappedID", "UNKNOWN_ID")
+	assert.Equal(t, entity.ErrInvalidSector, err)
+
+	_, err = sectorApp.UpdateSectorMapping("UNKNOWN_ID", "TestBanksID")
+	assert.Equal(t, entity.ErrInvalidSectorMapping, err)
+}
+
+func TestRenameSector(t *testing.T) {
+	sectorApp := NewApplication(NewMockRepo())
+
+	sector, err := sectorApp.RenameSector("TestFinancialsID", " Finance ", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "Finance", sector.Name)
+	assert.Equal(t, "40", sector.Code)
+
+	_, err = sectorApp.RenameSector("TestFinancialsID", " ", nil)
+	assert.Equal(t, entity.ErrInvalidSectorBlank, err)
+
+	_, err = sectorApp.RenameSector("UNKNOWN_ID", "Finance", nil)
+	assert.Equal(t, entity.ErrInvalidSector, err)
+}
+
+func TestMergeSectors(t *testing.T) {
+	type test struct {
+		sourceId       string
+		targetId       string
+		expectedSector string
+		expectedError  error
+	}
+
+	tests := []test{
+		{
+			sourceId:       "TestUnclassifiedID",
+			targetId:       "TestFinancialsID",
+			expectedSector: "TestFinancialsID",
+			expectedError:  nil,
+		},
+		{
+			sourceId:      "TestBanksID",
+			targetId:      "TestFinancialsID",
+			expectedError: entity.ErrInvalidSectorMerge,
+		},
+		{
+			sourceId:      "TestFinancialsID",
+			targetId:      "TestFinancialsID",
+			expectedError: entity.ErrInvalidSectorMerge,
+		},
+		{
+			sourceId:      "UNKNOWN_ID",
+			targetId:      "TestFinancialsID",
+			expectedError: entity.ErrInvalidSector,
+		},
+	}
+
+	sectorApp := NewApplication(NewMockRepo())
+
+	for _, testCase := range tests {
+		sector, err := sectorApp.MergeSectors(testCase.sourceId,
+			testCase.targetId, nil)
+		assert.Equal(t, testCase.expectedError, err)
+		if testCase.expectedError == nil {
+			assert.Equal(t, testCase.expectedSector, sector.Id)
+		}
+	}
+}
+
+func TestSectorAllocation(t *testing.T) {
+	sectorApp := NewApplication(NewMockRepo())
+
+	allocations, err := sectorApp.SectorAllocation("TestUserUID", "")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(allocations))
+	assert.Equal(t, "TestFinancialsID", allocations[0].Sector.Id)
+	assert.Equal(t, 100.0, allocations[0].Percentage)
+
+	allocations, err = sectorApp.SectorAllocation("TestUserUID",
+		entity.SectorLevelIndustryGroup)
+	assert.Nil(t, err)
+	assert.Equal(t, "TestBanksGroupID", allocations[0].Sector.Id)
+
+	_, err = sectorApp.SectorAllocation("TestUserUID", "SUB_INDUSTRY")
+	assert.Equal(t, entity.ErrInvalidSectorLevel, err)
+
+	_, err = sectorApp.SectorAllocation("ERROR_REPOSITORY", "")
+	assert.NotNil(t, err)
+}
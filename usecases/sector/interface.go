@@ -3,12 +3,42 @@ package sector
 import "stockfyApi/entity"
 
 type Repository interface {
-	Create(sector string) ([]entity.Sector, error)
+	Create(sector entity.Sector) ([]entity.Sector, error)
 	SearchByName(sector string) ([]entity.Sector, error)
+	SearchById(sectorId string) ([]entity.Sector, error)
+	SearchAll() ([]entity.Sector, error)
+	Update(sectorId string, name string, auditLog *entity.AuditLog) (
+		[]entity.Sector, error)
+	Merge(sourceId string, targetId string, auditLog *entity.AuditLog) (
+		[]entity.Sector, error)
 	// SearchByAsset(symbol string) ([]entity.Sector, error)
+	CreateMapping(provider string, label string) ([]entity.SectorMapping,
+		error)
+	SearchMappings(provider string, unmappedOnly bool) (
+		[]entity.SectorMapping, error)
+	UpdateMapping(mappingId string, sectorId string) ([]entity.SectorMapping,
+		error)
+	UpdateAssetMapping(assetId string, mappingId string) ([]entity.Asset, error)
+	SearchPositions(userUid string) ([]entity.SectorPosition, error)
 }
 
 type UseCases interface {
-	CreateSector(name string) ([]entity.Sector, error)
+	CreateSector(name string, level string, code string, parentId string) (
+		[]entity.Sector, error)
 	SearchSectorByName(name string) (*entity.Sector, error)
+	SearchSectorById(sectorId string) (*entity.Sector, error)
+	SearchSectors() ([]entity.Sector, error)
+	RenameSector(sectorId string, name string, auditLog *entity.AuditLog) (
+		*entity.Sector, error)
+	MergeSectors(sourceId string, targetId string,
+		auditLog *entity.AuditLog) (*entity.Sector, error)
+	ClassifySectorLabel(provider string, label string) (*entity.Sector,
+		*entity.SectorMapping, error)
+	AssignSectorMapping(assetId string, mapping *entity.SectorMapping) error
+	SearchSectorMappings(provider string, unmappedOnly bool) (
+		[]entity.SectorMapping, error)
+	UpdateSectorMapping(mappingId string, sectorId string) (
+		*entity.SectorMapping, error)
+	SectorAllocation(userUid string, level string) ([]entity.SectorAllocation,
+		error)
 }
@@ -6,24 +6,36 @@ import (
 )
 
 type MockApplication struct {
+	repo Mock
 }
 
 func NewMockApplication() *MockApplication {
 	return &MockApplication{}
 }
 
-func (a *MockApplication) CreateSector(name string) ([]entity.Sector, error) {
+func (a *MockApplication) CreateSector(name string, level string, code string,
+	parentId string) ([]entity.Sector, error) {
 
 	if name == "ERROR_SECTOR" {
 		return nil, errors.New("Some Error")
 	}
 
-	return []entity.Sector{
-		{
-			Id:   "TestID",
-			Name: name,
-		},
-	}, nil
+	var parent *entity.Sector
+	if parentId != "" {
+		parents, _ := a.repo.SearchById(parentId)
+		if parents == nil {
+			return nil, entity.ErrInvalidSectorParent
+		}
+		parent = &parents[0]
+	}
+
+	sector, err := entity.NewSector(name, level, code, parent)
+	if err != nil {
+		return nil, err
+	}
+	sector.Id = "TestID"
+
+	return []entity.Sector{*sector}, nil
 }
 
 func (a *MockApplication) SearchSectorByName(name string) (*entity.Sector, error) {
@@ -45,3 +57,118 @@ func (a *MockApplication) SearchSectorByName(name string) (*entity.Sector, error
 	}
 
 }
+
+func (a *MockApplication) SearchSectors() ([]entity.Sector, error) {
+	return a.repo.SearchAll()
+}
+
+func (a *MockApplication) SearchSectorById(sectorId string) (*entity.Sector,
+	error) {
+
+	if sectorId == "ERROR_SECTOR" {
+		return nil, errors.New("Some Error")
+	}
+
+	sectors, _ := a.repo.SearchById(sectorId)
+	if sectors == nil {
+		return nil, entity.ErrInvalidSector
+	}
+
+	return &sectors[0], nil
+}
+
+func (a *MockApplication) RenameSector(sectorId string, name string,
+	auditLog *entity.AuditLog) (*entity.Sector, error) {
+
+	if name == "" {
+		return nil, entity.ErrInvalidSectorBlank
+	}
+
+	sectors, _ := a.repo.Update(sectorId, name, auditLog)
+	if sectors == nil {
+		return nil, entity.ErrInvalidSector
+	}
+
+	return &sectors[0], nil
+}
+
+func (a *MockApplication) MergeSectors(sourceId string, targetId string,
+	auditLog *entity.AuditLog) (*entity.Sector, error) {
+
+	source, err := a.SearchSectorById(sourceId)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := a.SearchSectorById(targetId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = source.ValidateMerge(target); err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+func (a *MockApplication) ClassifySectorLabel(provider string, label string) (
+	*entity.Sector, *entity.SectorMapping, error) {
+
+	return &entity.Sector{
+		Id:   "TestSectorID",
+		Name: "Test Sector",
+	}, &entity.SectorMapping{
+		Id:       "TestMappingID",
+		Provider: provider,
+		Label:    label,
+	}, nil
+}
+
+func (a *MockApplication) AssignSectorMapping(assetId string,
+	mapping *entity.SectorMapping) error {
+	return nil
+}
+
+func (a *MockApplication) SearchSectorMappings(provider string,
+	unmappedOnly bool) ([]entity.SectorMapping, error) {
+
+	if provider != "" && !entity.IsValidSectorProvider(provider) {
+		return nil, entity.ErrInvalidSectorProvider
+	}
+
+	return a.repo.SearchMappings(provider, unmappedOnly)
+}
+
+func (a *MockApplication) UpdateSectorMapping(mappingId string,
+	sectorId string) (*entity.SectorMapping, error) {
+
+	sectors, _ := a.repo.SearchById(sectorId)
+	if sectors == nil {
+		return nil, entity.ErrInvalidSector
+	}
+
+	mappings, _ := a.repo.UpdateMapping(mappingId, sectorId)
+	if mappings == nil {
+		return nil, entity.ErrInvalidSectorMapping
+	}
+
+	return &mappings[0], nil
+}
+
+func (a *MockApplication) SectorAllocation(userUid string, level string) (
+	[]entity.SectorAllocation, error) {
+
+	if level == "" {
+		level = entity.SectorLevelSector
+	}
+
+	positions, err := a.repo.SearchPositions(userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	sectors, _ := a.repo.SearchAll()
+
+	return entity.NewSectorAllocation(positions, sectors, level)
+}
@@ -1,6 +1,7 @@
 package sector
 
 import (
+	"errors"
 	"stockfyApi/entity"
 )
 
@@ -11,15 +12,19 @@ func NewMockRepo() *Mock {
 	return &Mock{}
 }
 
-func (m *Mock) Create(name string) ([]entity.Sector, error) {
+var (
+	mockFinancialsId = "TestFinancialsID"
+	mockBanksGroupId = "TestBanksGroupID"
+)
 
-	sectorCreated := []entity.Sector{
-		{
-			Id:   "a38a9jkrh40a",
-			Name: name,
-		},
+func (m *Mock) Create(sector entity.Sector) ([]entity.Sector, error) {
+	if sector.Name == "ERROR_SECTOR" {
+		return nil, errors.New("Unknown sector repository error")
 	}
 
+	sector.Id = "a38a9jkrh40a"
+	sectorCreated := []entity.Sector{sector}
+
 	return sectorCreated, nil
 }
 
@@ -35,3 +40,156 @@ func (m *Mock) SearchByName(sector string) ([]entity.Sector, error) {
 		},
 	}, nil
 }
+
+func (m *Mock) SearchById(sectorId string) ([]entity.Sector, error) {
+	for _, sector := range mockSectors() {
+		if sector.Id == sectorId {
+			return []entity.Sector{sector}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (m *Mock) SearchAll() ([]entity.Sector, error) {
+	return mockSectors(), nil
+}
+
+func (m *Mock) Update(sectorId string, name string,
+	auditLog *entity.AuditLog) ([]entity.Sector, error) {
+	sectors, _ := m.SearchById(sectorId)
+	if sectors == nil {
+		return nil, nil
+	}
+
+	sectors[0].Name = name
+
+	return sectors, nil
+}
+
+func (m *Mock) Merge(sourceId string, targetId string,
+	auditLog *entity.AuditLog) ([]entity.Sector, error) {
+	if sourceId == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown sector repository error")
+	}
+
+	return m.SearchById(targetId)
+}
+
+func (m *Mock) CreateMapping(provider string, label string) (
+	[]entity.SectorMapping, error) {
+	if label == "ERROR_LABEL" {
+		return nil, errors.New("Unknown sector repository error")
+	}
+
+	mapping := entity.SectorMapping{
+		Id:       "TestMappingID",
+		Provider: provider,
+		Label:    label,
+	}
+
+	if label == "Banking" {
+		mapping.Sector = &mockSectors()[2]
+	}
+
+	return []entity.SectorMapping{mapping}, nil
+}
+
+func (m *Mock) SearchMappings(provider string, unmappedOnly bool) (
+	[]entity.SectorMapping, error) {
+	mappings := []entity.SectorMapping{
+		{
+			Id:       "TestMappingID",
+			Provider: entity.SectorProviderFinnhub,
+			Label:    "Banking",
+			Sector:   &mockSectors()[2],
+		},
+		{
+			Id:       "TestUnmappedID",
+			Provider: entity.SectorProviderFinnhub,
+			Label:    "Fintech",
+		},
+	}
+
+	if unmappedOnly {
+		return mappings[1:], nil
+	}
+
+	return mappings, nil
+}
+
+func (m *Mock) UpdateMapping(mappingId string, sectorId string) (
+	[]entity.SectorMapping, error) {
+	if mappingId == "UNKNOWN_ID" {
+		return nil, nil
+	}
+
+	sectors, _ := m.SearchById(sectorId)
+
+	return []entity.SectorMapping{
+		{
+			Id:       mappingId,
+			Provider: entity.SectorProviderFinnhub,
+			Label:    "Fintech",
+			Sector:   &sectors[0],
+		},
+	}, nil
+}
+
+func (m *Mock) UpdateAssetMapping(assetId string, mappingId string) (
+	[]entity.Asset, error) {
+	if assetId == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown sector repository error")
+	}
+
+	return []entity.Asset{{Id: assetId}}, nil
+}
+
+func (m *Mock) SearchPositions(userUid string) ([]entity.SectorPosition,
+	error) {
+	if userUid == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown sector repository error")
+	}
+
+	return []entity.SectorPosition{
+		{
+			AssetId:  "TestAssetID",
+			Symbol:   "ITUB4",
+			SectorId: "TestBanksID",
+			Currency: "BRL",
+			Quantity: 10,
+			Invested: 300,
+		},
+	}, nil
+}
+
+func mockSectors() []entity.Sector {
+	return []entity.Sector{
+		{
+			Id:    mockFinancialsId,
+			Name:  "Financials",
+			Level: entity.SectorLevelSector,
+			Code:  "40",
+		},
+		{
+			Id:       mockBanksGroupId,
+			Name:     "Banks",
+			Level:    entity.SectorLevelIndustryGroup,
+			Code:     "4010",
+			ParentId: &mockFinancialsId,
+		},
+		{
+			Id:       "TestBanksID",
+			Name:     "Banks",
+			Level:    entity.SectorLevelIndustry,
+			Code:     "401010",
+			ParentId: &mockBanksGroupId,
+		},
+		{
+			Id:    "TestUnclassifiedID",
+			Name:  entity.UnclassifiedSectorName,
+			Level: entity.SectorLevelSector,
+			Code:  "99",
+		},
+	}
+}
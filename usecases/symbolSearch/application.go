@@ -0,0 +1,139 @@
+package symbolsearch
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"path/filepath"
+	"stockfyApi/calendar"
+	"stockfyApi/entity"
+	"stockfyApi/usecases/general"
+	"strings"
+)
+
+// Number of symbols inserted in each query while importing a symbol list.
+const symbolsPerInsert = 1000
+
+type Application struct {
+	repo Repository
+}
+
+//NewApplication create new use case
+func NewApplication(r Repository) *Application {
+	return &Application{
+		repo: r,
+	}
+}
+
+// SearchSymbols returns the symbols whose code or company name matches the
+// query, ranked by relevance. Only the local index is searched, without
+// requests to the market data providers.
+func (a *Application) SearchSymbols(query string, country string, limit int) (
+	[]entity.SymbolSuggestion, error) {
+
+	query, err := entity.NormalizeSymbolQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = general.CountryValidation(country); err != nil {
+		return nil, err
+	}
+
+	limit, err = entity.SymbolSearchLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.repo.Search(query, country, limit)
+}
+
+// ImportSymbolList stores the symbols listed in an exchange. The list is a CSV
+// file with the symbol, fullname and type columns and an optional country
+// column. Without the country, the first country of the exchange is used.
+func (a *Application) ImportSymbolList(exchangeCode string,
+	symbolList io.Reader) (int, error) {
+
+	exchange, err := calendar.SearchExchange(exchangeCode)
+	if err != nil {
+		return 0, err
+	}
+
+	records, err := csv.NewReader(symbolList).ReadAll()
+	if err != nil || len(records) == 0 {
+		return 0, entity.ErrInvalidSymbolListEntry
+	}
+
+	columns := map[string]int{}
+	for i, column := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	for _, column := range []string{"symbol", "fullname", "type"} {
+		if _, ok := columns[column]; !ok {
+			return 0, entity.ErrInvalidSymbolListEntry
+		}
+	}
+
+	var symbols []entity.SymbolSuggestion
+	for _, record := range records[1:] {
+		country := exchange.Countries[0]
+		if i, ok := columns["country"]; ok && record[i] != "" {
+			country = strings.ToUpper(strings.TrimSpace(record[i]))
+		}
+
+		symbol, err := entity.NewSymbolSuggestion(record[columns["symbol"]],
+			record[columns["fullname"]], record[columns["type"]], country)
+		if err != nil {
+			return 0, err
+		}
+
+		symbols = append(symbols, *symbol)
+	}
+
+	imported := 0
+	for start := 0; start < len(symbols); start += symbolsPerInsert {
+		end := start + symbolsPerInsert
+		if end > len(symbols) {
+			end = len(symbols)
+		}
+
+		symbolsCreated, err := a.repo.CreateSymbols(symbols[start:end])
+		if err != nil {
+			return imported, err
+		}
+
+		imported += len(symbolsCreated)
+	}
+
+	return imported, nil
+}
+
+// ImportSymbolLists imports the symbol list of each exchange from the data
+// directory, where the files are named after the exchange code, e.g. B3.csv.
+func (a *Application) ImportSymbolLists(dataDir string) (int, error) {
+	imported := 0
+
+	files, err := filepath.Glob(filepath.Join(dataDir, "*.csv"))
+	if err != nil {
+		return 0, err
+	}
+
+	for _, file := range files {
+		symbolList, err := os.Open(file)
+		if err != nil {
+			return imported, err
+		}
+
+		exchangeCode := strings.TrimSuffix(filepath.Base(file), ".csv")
+		symbolsImported, err := a.ImportSymbolList(exchangeCode, symbolList)
+		symbolList.Close()
+		if err != nil {
+			return imported, err
+		}
+
+		imported += symbolsImported
+	}
+
+	return imported, nil
+}
@@ -0,0 +1,109 @@
+package symbolsearch
+
+import (
+	"errors"
+	"stockfyApi/entity"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchSymbols(t *testing.T) {
+	type test struct {
+		query         string
+		country       string
+		limit         int
+		expectedLen   int
+		expectedError error
+	}
+
+	tests := []test{
+		{query: "itau", country: "", limit: 0, expectedLen: 2},
+		{query: "itau", country: "BR", limit: 1, expectedLen: 1},
+		{query: " ", expectedError: entity.ErrInvalidSymbolSearchQuery},
+		{query: "itau", country: "EU",
+			expectedError: entity.ErrInvalidCountryCode},
+		{query: "itau", limit: 100,
+			expectedError: entity.ErrInvalidSymbolSearchLimit},
+		{query: "ERROR_REPOSITORY",
+			expectedError: errors.New("Unknown symbol repository error")},
+	}
+
+	symbolSearchApp := NewApplication(NewMockRepo())
+
+	for _, testCase := range tests {
+		suggestions, err := symbolSearchApp.SearchSymbols(testCase.query,
+			testCase.country, testCase.limit)
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expectedLen, len(suggestions))
+	}
+}
+
+func TestImportSymbolList(t *testing.T) {
+	type test struct {
+		exchangeCode     string
+		symbolList       string
+		expectedImported int
+		expectedError    error
+	}
+
+	tests := []test{
+		{
+			exchangeCode: "B3",
+			symbolList: "symbol,fullname,type\n" +
+				"ITUB4.SA,Itau Unibanco Holding S.A,STOCK\n" +
+				"KNRI11,Kinea Renda Imobiliaria,FII\n",
+			expectedImported: 2,
+		},
+		{
+			exchangeCode: "NYSE",
+			symbolList: "fullname,symbol,type,country\n" +
+				"Bitcoin,BTC,CRYPTO,CRYPTO\n",
+			expectedImported: 1,
+		},
+		{
+			exchangeCode:  "LSE",
+			symbolList:    "symbol,fullname,type\n",
+			expectedError: entity.ErrInvalidExchange,
+		},
+		{
+			exchangeCode:  "B3",
+			symbolList:    "symbol,type\nITUB4,STOCK\n",
+			expectedError: entity.ErrInvalidSymbolListEntry,
+		},
+		{
+			exchangeCode: "B3",
+			symbolList: "symbol,fullname,type\n" +
+				"KNRI11,Kinea Renda Imobiliaria,REIT\n",
+			expectedError: entity.ErrInvalidSymbolListEntry,
+		},
+		{
+			exchangeCode: "NYSE",
+			symbolList: "symbol,fullname,type\n" +
+				"ERROR_REPOSITORY,Error Inc,STOCK\n",
+			expectedError: errors.New("Unknown symbol repository error"),
+		},
+	}
+
+	symbolSearchApp := NewApplication(NewMockRepo())
+
+	for _, testCase := range tests {
+		imported, err := symbolSearchApp.ImportSymbolList(testCase.exchangeCode,
+			strings.NewReader(testCase.symbolList))
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expectedImported, imported)
+	}
+}
+
+func TestImportSymbolLists(t *testing.T) {
+	symbolSearchApp := NewApplication(NewMockRepo())
+
+	imported, err := symbolSearchApp.ImportSymbolLists("testdata")
+	assert.Nil(t, err)
+	assert.Equal(t, 4, imported)
+
+	imported, err = symbolSearchApp.ImportSymbolLists("missing")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, imported)
+}
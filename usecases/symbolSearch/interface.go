@@ -0,0 +1,20 @@
+package symbolsearch
+
+import (
+	"io"
+	"stockfyApi/entity"
+)
+
+type Repository interface {
+	CreateSymbols(symbols []entity.SymbolSuggestion) (
+		[]entity.SymbolSuggestion, error)
+	Search(query string, country string, limit int) (
+		[]entity.SymbolSuggestion, error)
+}
+
+type UseCases interface {
+	SearchSymbols(query string, country string, limit int) (
+		[]entity.SymbolSuggestion, error)
+	ImportSymbolList(exchangeCode string, symbolList io.Reader) (int, error)
+	ImportSymbolLists(dataDir string) (int, error)
+}
@@ -0,0 +1,44 @@
+package symbolsearch
+
+import (
+	"io"
+	"stockfyApi/entity"
+	"stockfyApi/usecases/general"
+)
+
+type MockApplication struct {
+	repo MockDb
+}
+
+func NewMockApplication() *MockApplication {
+	return &MockApplication{}
+}
+
+func (a *MockApplication) SearchSymbols(query string, country string,
+	limit int) ([]entity.SymbolSuggestion, error) {
+
+	query, err := entity.NormalizeSymbolQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = general.CountryValidation(country); err != nil {
+		return nil, err
+	}
+
+	limit, err = entity.SymbolSearchLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.repo.Search(query, country, limit)
+}
+
+func (a *MockApplication) ImportSymbolList(exchangeCode string,
+	symbolList io.Reader) (int, error) {
+	return 0, nil
+}
+
+func (a *MockApplication) ImportSymbolLists(dataDir string) (int, error) {
+	return 0, nil
+}
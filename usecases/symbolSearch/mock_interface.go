@@ -0,0 +1,56 @@
+package symbolsearch
+
+import (
+	"errors"
+	"stockfyApi/entity"
+)
+
+type MockDb struct {
+}
+
+func NewMockRepo() *MockDb {
+	return &MockDb{}
+}
+
+func (m *MockDb) CreateSymbols(symbols []entity.SymbolSuggestion) (
+	[]entity.SymbolSuggestion, error) {
+	for _, symbol := range symbols {
+		if symbol.Symbol == "ERROR_REPOSITORY" {
+			return nil, errors.New("Unknown symbol repository error")
+		}
+	}
+
+	return symbols, nil
+}
+
+func (m *MockDb) Search(query string, country string, limit int) (
+	[]entity.SymbolSuggestion, error) {
+	if query == "ERROR_REPOSITORY" {
+		return nil, errors.New("Unknown symbol repository error")
+	}
+
+	assetId := "TestAssetID"
+	suggestions := []entity.SymbolSuggestion{
+		{
+			Symbol:   "ITUB4",
+			Fullname: "Itau Unibanco Holding S.A",
+			Type:     "STOCK",
+			Country:  "BR",
+			AssetId:  &assetId,
+			Rank:     3.5,
+		},
+		{
+			Symbol:   "ITUB3",
+			Fullname: "Itau Unibanco Holding S.A",
+			Type:     "STOCK",
+			Country:  "BR",
+			Rank:     2.4,
+		},
+	}
+
+	if limit < len(suggestions) {
+		suggestions = suggestions[:limit]
+	}
+
+	return suggestions, nil
+}
@@ -2,6 +2,7 @@ package user
 
 import (
 	"stockfyApi/entity"
+	"strings"
 )
 
 type Application struct {
@@ -36,6 +37,19 @@ func (a *Application) CreateUser(uid string, email string, displayName string,
 
 func (a *Application) DeleteUser(userUid string) (*entity.UserInfo, error) {
 
+	// Delete the Firebase users linked to the user
+	identities, err := a.repo.SearchIdentities(userUid)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, identity := range identities {
+		_, err = a.extRepo.DeleteUser(identity.FirebaseUid)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Delete from the Firebase
 	deletedUser, err := a.extRepo.DeleteUser(userUid)
 	if err != nil {
@@ -85,6 +99,61 @@ func (a *Application) SearchUser(userUid string) (*entity.Users, error) {
 	return &searchedUser[0], nil
 }
 
+// SearchUserByEmail returns the user registered with the email, or nil when
+// there is none.
+func (a *Application) SearchUserByEmail(email string) (*entity.Users, error) {
+	searchedUser, err := a.repo.SearchByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(searchedUser) == 0 {
+		return nil, nil
+	}
+
+	return &searchedUser[0], nil
+}
+
+func (a *Application) SearchUserIdentities(userUid string) (
+	[]entity.UserIdentity, error) {
+	return a.repo.SearchIdentities(userUid)
+}
+
+// LinkUser links the Firebase user of the ID token, signed in with another
+// authentication provider, to the user. Both must have the same email. After
+// the link, the portfolio of the Firebase user is merged into the one of the
+// user and the ID tokens of the Firebase user access the portfolio of the user.
+func (a *Application) LinkUser(userUid string, email string, idToken string) (
+	[]entity.UserIdentity, error) {
+
+	tokenInfo, err := a.extRepo.VerifyIDToken(idToken)
+	if err != nil {
+		return nil, entity.ErrInvalidUserToken
+	}
+
+	if tokenInfo.UserID == userUid {
+		return nil, entity.ErrInvalidUserLinkSameUser
+	}
+
+	if !strings.EqualFold(tokenInfo.Email, email) {
+		return nil, entity.ErrInvalidUserLinkEmail
+	}
+
+	identity, err := a.repo.SearchIdentity(tokenInfo.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(identity) != 0 {
+		return nil, entity.ErrInvalidUserLinked
+	}
+
+	return a.repo.Link(userUid, entity.UserIdentity{
+		FirebaseUid: tokenInfo.UserID,
+		ProviderId:  tokenInfo.ProviderId,
+	})
+}
+
 // Create User in Firebase
 func (a *Application) UserCreate(email string, password string,
 	displayName string) (*entity.UserInfo, error) {
@@ -145,6 +214,17 @@ func (a *Application) UserTokenVerification(idToken string) (*entity.UserTokenIn
 		return nil, err
 	}
 
+	// The ID token of a linked Firebase user accesses the portfolio of the
+	// user it was linked to
+	identity, err := a.repo.SearchIdentity(userTokenInfo.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(identity) != 0 {
+		userTokenInfo.UserID = identity[0].UserUid
+	}
+
 	return &userTokenInfo, nil
 }
 
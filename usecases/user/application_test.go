@@ -82,6 +82,15 @@ func TestDeleteUser(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			userUid: "TestNormalID",
+			expectedUserInfo: &entity.UserInfo{
+				DisplayName: "Test Name",
+				Email:       "test@email.com",
+				UID:         "TestNormalID",
+			},
+			expectedError: nil,
+		},
 		{
 			userUid:          "ERROR_USER_REPOSITORY",
 			expectedUserInfo: nil,
@@ -229,6 +238,96 @@ func TestSearchUser(t *testing.T) {
 	}
 }
 
+func TestSearchUserByEmail(t *testing.T) {
+	mockedRepo := NewMockRepo()
+	userApp := NewApplication(mockedRepo, nil)
+
+	searchedUser, err := userApp.SearchUserByEmail("registered@email.com")
+	assert.Nil(t, err)
+	assert.Equal(t, &entity.Users{
+		Uid:      "TestNormalID",
+		Email:    "registered@email.com",
+		Username: "Test Name",
+		Type:     "normal",
+	}, searchedUser)
+
+	searchedUser, err = userApp.SearchUserByEmail("new@email.com")
+	assert.Nil(t, err)
+	assert.Nil(t, searchedUser)
+
+	searchedUser, err = userApp.SearchUserByEmail("ERROR_USER_REPOSITORY")
+	assert.Equal(t, errors.New("Unknown search error in the user repository"),
+		err)
+	assert.Nil(t, searchedUser)
+}
+
+func TestLinkUser(t *testing.T) {
+	type test struct {
+		userUid            string
+		email              string
+		idToken            string
+		expectedIdentities []entity.UserIdentity
+		expectedError      error
+	}
+
+	tests := []test{
+		{
+			userUid: "TestNormalID",
+			email:   "Test@Email.com",
+			idToken: "GoogleIdToken",
+			expectedIdentities: []entity.UserIdentity{
+				{
+					FirebaseUid: "GoogleUserUID",
+					UserUid:     "TestNormalID",
+					ProviderId:  "google.com",
+				},
+			},
+		},
+		{
+			userUid:       "TestNormalID",
+			email:         "test@email.com",
+			idToken:       "INVALID_ID_TOKEN",
+			expectedError: entity.ErrInvalidUserToken,
+		},
+		{
+			userUid:       "TestNormalID",
+			email:         "test@email.com",
+			idToken:       "TestNormalID",
+			expectedError: entity.ErrInvalidUserLinkSameUser,
+		},
+		{
+			userUid:       "TestNormalID",
+			email:         "other@email.com",
+			idToken:       "GoogleIdToken",
+			expectedError: entity.ErrInvalidUserLinkEmail,
+		},
+		{
+			userUid:       "TestOtherID",
+			email:         "test@email.com",
+			idToken:       "LinkedUserUID",
+			expectedError: entity.ErrInvalidUserLinked,
+		},
+		{
+			userUid:       "ERROR_USER_REPOSITORY",
+			email:         "test@email.com",
+			idToken:       "GoogleIdToken",
+			expectedError: errors.New("Unknown link error in the user repository"),
+		},
+	}
+
+	mockedRepo := NewMockRepo()
+	mockedExtApi := NewExternalApi()
+	userApp := NewApplication(mockedRepo, mockedExtApi)
+
+	for _, testCase := range tests {
+		identities, err := userApp.LinkUser(testCase.userUid, testCase.email,
+			testCase.idToken)
+
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expectedIdentities, identities)
+	}
+}
+
 func TestUserCreate(t *testing.T) {
 	type test struct {
 		email            string
@@ -472,10 +571,26 @@ func TestUserTokenVerification(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			idToken: "LinkedUserUID",
+			expectedUserTokenInfo: &entity.UserTokenInfo{
+				Email:         "test@email.com",
+				EmailVerified: true,
+				UserID:        "TestNormalID",
+			},
+			expectedError: nil,
+		},
+		{
+			idToken:               "ERROR_USER_REPOSITORY",
+			expectedUserTokenInfo: nil,
+			expectedError: errors.New(
+				"Unknown search error in the user repository"),
+		},
 	}
 
+	mockedRepo := NewMockRepo()
 	mockedExtApi := NewExternalApi()
-	userApp := NewApplication(nil, mockedExtApi)
+	userApp := NewApplication(mockedRepo, mockedExtApi)
 
 	for _, testCase := range tests {
 		userTokenInfo, err := userApp.UserTokenVerification(testCase.idToken)
@@ -9,6 +9,11 @@ type Repository interface {
 	Delete(firebaseUid string) ([]entity.Users, error)
 	Update(userInfo entity.Users) ([]entity.Users, error)
 	Search(userUid string) ([]entity.Users, error)
+	SearchByEmail(email string) ([]entity.Users, error)
+	SearchIdentity(firebaseUid string) ([]entity.UserIdentity, error)
+	SearchIdentities(userUid string) ([]entity.UserIdentity, error)
+	Link(userUid string, identity entity.UserIdentity) ([]entity.UserIdentity,
+		error)
 }
 
 type ExternalUserDatabase interface {
@@ -39,6 +44,10 @@ type UseCases interface {
 	UpdateUser(userUid string, email string, displayName string, password string) (
 		*entity.Users, error)
 	SearchUser(userUid string) (*entity.Users, error)
+	SearchUserByEmail(email string) (*entity.Users, error)
+	SearchUserIdentities(userUid string) ([]entity.UserIdentity, error)
+	LinkUser(userUid string, email string, idToken string) (
+		[]entity.UserIdentity, error)
 	UserCreate(email string, password string, displayName string) (
 		*entity.UserInfo, error)
 	UserCreateCustomToken(userUid string) (string, error)
@@ -81,6 +81,54 @@ func (a *MockApplication) SearchUser(userUid string) (*entity.Users, error) {
 	}
 }
 
+func (a *MockApplication) SearchUserByEmail(email string) (*entity.Users,
+	error) {
+	if email == "ERROR_USER_REPOSITORY" {
+		return nil, errors.New("Unknown error in the user repository")
+	} else if email == "registered@email.com" {
+		return &entity.Users{
+			Uid:      "USER_WITHOUT_PRIVILEGE",
+			Username: "Test Name",
+			Email:    email,
+			Type:     "normal",
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func (a *MockApplication) SearchUserIdentities(userUid string) (
+	[]entity.UserIdentity, error) {
+	return []entity.UserIdentity{
+		{
+			FirebaseUid: "LinkedUserUID",
+			UserUid:     userUid,
+			ProviderId:  "google.com",
+			CreatedAt:   entity.StringToTime("2021-10-01"),
+		},
+	}, nil
+}
+
+func (a *MockApplication) LinkUser(userUid string, email string,
+	idToken string) ([]entity.UserIdentity, error) {
+	if idToken == "INVALID_ID_TOKEN" {
+		return nil, entity.ErrInvalidUserToken
+	} else if idToken == "ValidIdTokenWithoutPrivilegedUser" {
+		return nil, entity.ErrInvalidUserLinkSameUser
+	} else if idToken == "ERROR_USER_REPOSITORY" {
+		return nil, errors.New("Unknown error in the user repository")
+	}
+
+	return []entity.UserIdentity{
+		{
+			FirebaseUid: "LinkedUserUID",
+			UserUid:     userUid,
+			ProviderId:  "google.com",
+			CreatedAt:   entity.StringToTime("2021-10-01"),
+		},
+	}, nil
+}
+
 func (a *MockApplication) UserCreate(email string, password string,
 	displayName string) (*entity.UserInfo, error) {
 
@@ -296,6 +344,8 @@ func (a *MockApplication) UserLoginOAuth2(webKey string, oauthIdToken string,
 	providerId string, requestUri string) (*entity.UserInfoOAuth2, error) {
 
 	isNewUser := false
+	needConfirmation := false
+	idToken := "ValidIdTokenWithoutPrivilegedUser"
 	email := "test@email.com"
 
 	switch oauthIdToken {
@@ -308,21 +358,31 @@ func (a *MockApplication) UserLoginOAuth2(webKey string, oauthIdToken string,
 		isNewUser = true
 		email = ""
 		break
+	case "NEW_USER_REGISTERED_EMAIL":
+		isNewUser = true
+		idToken = "NewProviderIdToken"
+		email = "registered@email.com"
+		break
+	case "NEED_CONFIRMATION":
+		needConfirmation = true
+		idToken = ""
+		break
 	default:
 		isNewUser = false
 	}
 
 	return &entity.UserInfoOAuth2{
-		IdToken:       "ValidIdTokenWithoutPrivilegedUser",
-		OAuthIdToken:  oauthIdToken,
-		Email:         email,
-		EmailVerified: true,
-		Fullname:      "Test Name",
-		UserUid:       "TestUID",
-		RefreshToken:  "ValidRefreshToken",
-		Expiration:    "3600",
-		IsNewUser:     isNewUser,
-		Error:         nil,
+		IdToken:          idToken,
+		OAuthIdToken:     oauthIdToken,
+		Email:            email,
+		EmailVerified:    true,
+		Fullname:         "Test Name",
+		UserUid:          "TestUID",
+		RefreshToken:     "ValidRefreshToken",
+		Expiration:       "3600",
+		IsNewUser:        isNewUser,
+		NeedConfirmation: needConfirmation,
+		Error:            nil,
 	}, nil
 
 }
@@ -95,6 +95,69 @@ func (m *MockDb) Search(userUid string) ([]entity.Users, error) {
 	}, nil
 }
 
+func (m *MockDb) SearchByEmail(email string) ([]entity.Users, error) {
+	if email == "ERROR_USER_REPOSITORY" {
+		return nil, errors.New("Unknown search error in the user repository")
+	}
+
+	if email == "registered@email.com" {
+		return []entity.Users{
+			{
+				Uid:      "TestNormalID",
+				Email:    email,
+				Username: "Test Name",
+				Type:     "normal",
+			},
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func (m *MockDb) SearchIdentity(firebaseUid string) ([]entity.UserIdentity,
+	error) {
+	if firebaseUid == "ERROR_USER_REPOSITORY" {
+		return nil, errors.New("Unknown search error in the user repository")
+	}
+
+	if firebaseUid == "LinkedUserUID" {
+		return []entity.UserIdentity{
+			{
+				FirebaseUid: firebaseUid,
+				UserUid:     "TestNormalID",
+				ProviderId:  "google.com",
+			},
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func (m *MockDb) SearchIdentities(userUid string) ([]entity.UserIdentity,
+	error) {
+	if userUid == "TestNormalID" {
+		return []entity.UserIdentity{
+			{
+				FirebaseUid: "LinkedUserUID",
+				UserUid:     userUid,
+				ProviderId:  "google.com",
+			},
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func (m *MockDb) Link(userUid string, identity entity.UserIdentity) (
+	[]entity.UserIdentity, error) {
+	if userUid == "ERROR_USER_REPOSITORY" {
+		return nil, errors.New("Unknown link error in the user repository")
+	}
+
+	identity.UserUid = userUid
+	return []entity.UserIdentity{identity}, nil
+}
+
 func (m *MockExternal) CreateUser(email string, password string,
 	displayName string) (*entity.UserInfo, error) {
 
@@ -237,6 +300,15 @@ func (m *MockExternal) VerifyIDToken(idToken string) (entity.UserTokenInfo, erro
 		return entity.UserTokenInfo{}, errors.New("INVALID_ID_TOKEN")
 	}
 
+	if idToken == "GoogleIdToken" {
+		return entity.UserTokenInfo{
+			Email:         "test@email.com",
+			EmailVerified: true,
+			UserID:        "GoogleUserUID",
+			ProviderId:    "google.com",
+		}, nil
+	}
+
 	return entity.UserTokenInfo{
 		Email:         "test@email.com",
 		EmailVerified: true,
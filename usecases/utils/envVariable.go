@@ -24,3 +24,22 @@ func ViperReadEnvVariable(path string, filename string, key string) string {
 
 	return value
 }
+
+func ViperReadOptionalEnvVariable(path string, filename string, key string,
+	defaultValue string) string {
+	viper.SetConfigName(filename)
+	viper.SetConfigType("env")
+	viper.AddConfigPath(path)
+
+	err := viper.ReadInConfig()
+	if err != nil {
+		log.Fatalf("Error while reading config file %s", err)
+	}
+
+	value, ok := viper.Get(key).(string)
+	if !ok || value == "" {
+		return defaultValue
+	}
+
+	return value
+}